@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -13,11 +14,15 @@ import (
 
 	"vacaytracker-api/internal/config"
 	"vacaytracker-api/internal/handler"
+	"vacaytracker-api/internal/metrics"
 	"vacaytracker-api/internal/middleware"
 	"vacaytracker-api/internal/repository/sqlite"
 	"vacaytracker-api/internal/service"
 )
 
+// legacyAPISunset is when the unversioned /api routes are due to be removed
+var legacyAPISunset = time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC)
+
 func main() {
 	// Load configuration
 	cfg := config.Load()
@@ -28,7 +33,11 @@ func main() {
 	}
 
 	// Initialize database connection
-	db, err := sqlite.New(cfg.DBPath)
+	db, err := sqlite.New(cfg.DBPath, sqlite.PoolConfig{
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+	})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -47,41 +56,78 @@ func main() {
 	userRepo := sqlite.NewUserRepository(db)
 	vacationRepo := sqlite.NewVacationRepository(db)
 	settingsRepo := sqlite.NewSettingsRepository(db)
+	ledgerRepo := sqlite.NewLedgerRepository(db)
+	refreshTokenRepo := sqlite.NewRefreshTokenRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
+	emailOutboxRepo := sqlite.NewEmailOutboxRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	attachmentRepo := sqlite.NewAttachmentRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
 
 	// Initialize services
-	authService := service.NewAuthService(userRepo, cfg.JWTSecret)
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, db)
-	userService := service.NewUserService(userRepo, authService)
+	authService := service.NewAuthService(userRepo, refreshTokenRepo, cfg.JWTSecret)
+	authService.SetTokenTTL(cfg.TokenTTL)
+	authService.SetIssuerAndAudience(cfg.JWTIssuer, cfg.JWTAudience, cfg.JWTAudienceSince)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, ledgerRepo, db)
+	userService := service.NewUserService(userRepo, ledgerRepo, db, teamRepo, settingsRepo, authService)
 	emailService := service.NewEmailService(cfg)
-	newsletterService := service.NewNewsletterService(cfg, userRepo, vacationRepo, settingsRepo, emailService)
+	emailQueue := service.NewEmailQueue(emailService, emailOutboxRepo)
+	emailService.SetQueue(emailQueue)
+	emailQueue.Start()
+	newsletterService := service.NewNewsletterService(cfg, userRepo, vacationRepo, settingsRepo, emailService, authService)
+	reportService := service.NewReportService(vacationRepo, userRepo, settingsRepo)
+	webhookService := service.NewWebhookService(settingsRepo)
+	teamService := service.NewTeamService(teamRepo)
+	commentService := service.NewCommentService(commentRepo, vacationRepo, userRepo)
+	auditService := service.NewAuditService(auditRepo)
+	attachmentService := service.NewAttachmentService(attachmentRepo, vacationRepo, cfg.AttachmentDir, cfg.AttachmentMaxSize)
+
+	// Prometheus collectors (opt-in via METRICS_ENABLED)
+	var appMetrics *metrics.Metrics
+	if cfg.MetricsEnabled {
+		appMetrics = metrics.New()
+	}
 
-	// Initialize and start the newsletter scheduler
-	scheduler := service.NewScheduler(newsletterService, settingsRepo)
+	// Initialize and start the background scheduler (newsletter, accrual, email retries and metrics gauges)
+	scheduler := service.NewScheduler(newsletterService, vacationService, vacationRepo, settingsRepo, emailQueue, appMetrics)
 	scheduler.Start()
 
-	// Create initial admin user if it doesn't exist
-	if err := authService.CreateInitialAdmin(
-		context.Background(),
-		cfg.AdminEmail,
-		cfg.AdminPassword,
-		cfg.AdminName,
-		25, // Default vacation balance
-	); err != nil {
-		log.Fatalf("Failed to create initial admin: %v", err)
+	// Create initial admin user if it doesn't exist, starting with the default entitlement
+	if cfg.CreateInitialAdmin {
+		settings, err := settingsRepo.Get(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to load settings for initial admin: %v", err)
+		}
+		if err := authService.CreateInitialAdmin(
+			context.Background(),
+			cfg.AdminEmail,
+			cfg.AdminPassword,
+			cfg.AdminName,
+			settings.DaysToBalance(settings.DefaultVacationDays),
+		); err != nil {
+			log.Fatalf("Failed to create initial admin: %v", err)
+		}
 	}
 
 	// Initialize handlers
-	healthHandler := handler.NewHealthHandler()
-	authHandler := handler.NewAuthHandler(authService)
-	vacationHandler := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
-	adminHandler := handler.NewAdminHandler(cfg, userService, userRepo, vacationService, vacationRepo, settingsRepo, emailService, newsletterService)
+	healthHandler := handler.NewHealthHandler(db, scheduler)
+	authHandler := handler.NewAuthHandler(authService, emailService)
+	vacationHandler := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, webhookService, commentService, attachmentService)
+	adminHandler := handler.NewAdminHandler(cfg, userService, userRepo, vacationService, vacationRepo, settingsRepo, emailService, newsletterService, reportService, webhookService, auditService)
 	settingsHandler := handler.NewSettingsHandler(settingsRepo)
+	teamHandler := handler.NewTeamHandler(teamService, auditService)
 
 	// Create Gin router
 	router := gin.New()
 
 	// Add global middleware
-	router.Use(gin.Logger())
+	// Request IDs first so every later log line (access, security) can carry one
+	router.Use(middleware.RequestID())
+	router.Use(middleware.AccessLogMiddleware(os.Stdout, cfg.IsProduction()))
+	if appMetrics != nil {
+		// Registered ahead of Recovery so recovered panics are still recorded as 500s
+		router.Use(middleware.MetricsMiddleware(appMetrics))
+	}
 	router.Use(gin.Recovery())
 	router.Use(middleware.ErrorMiddleware())
 
@@ -97,8 +143,16 @@ func main() {
 	router.Use(middleware.SecurityLoggingMiddleware(securityLogger))
 
 	// Initialize rate limiters
-	loginRateLimiter := middleware.LoginRateLimiter()
-	apiRateLimiter := middleware.APIRateLimiter()
+	loginRateLimiter := middleware.LoginRateLimiter(cfg.LoginRateLimit, cfg.RateLimitWindow)
+	apiRateLimiter := middleware.APIRateLimiter(cfg.APIRateLimit, cfg.RateLimitWindow)
+
+	// Fail fast on a malformed admin allowlist instead of panicking while registering routes
+	if _, err := middleware.ParseAllowlist(cfg.AdminAllowedCIDRs); err != nil {
+		log.Fatalf("Invalid ADMIN_ALLOWED_CIDRS: %v", err)
+	}
+	if len(cfg.AdminAllowedCIDRs) > 0 {
+		log.Printf("Admin routes restricted to: %s", strings.Join(cfg.AdminAllowedCIDRs, ", "))
+	}
 
 	// CORS middleware (development mode allows all origins)
 	if cfg.IsDevelopment() {
@@ -113,48 +167,105 @@ func main() {
 
 	// Public routes
 	router.GET("/health", healthHandler.Check)
+	router.GET("/health/ready", healthHandler.Ready)
+
+	if appMetrics != nil {
+		router.GET("/metrics", gin.WrapH(appMetrics.Handler()))
+	}
+
+	// API documentation: open outside production; in production only the spec is served, to admins
+	docsHandler := handler.NewDocsHandler(router.Routes)
+	if !cfg.IsProduction() {
+		router.GET("/docs", docsHandler.UI)
+		router.GET("/docs/init.js", docsHandler.UIInit)
+	}
+
+	// API routes, registered under /api/v1 and again under the deprecated unversioned /api
+	registerAPI := func(api *gin.RouterGroup) {
+		api.Use(apiRateLimiter.Middleware()) // Apply general rate limiting to all API routes
+
+		if cfg.IsProduction() {
+			api.GET("/openapi.json", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), docsHandler.Spec)
+		} else {
+			api.GET("/openapi.json", docsHandler.Spec)
+		}
 
-	// API routes
-	api := router.Group("/api")
-	api.Use(apiRateLimiter.Middleware()) // Apply general rate limiting to all API routes
-	{
 		// Auth routes (public)
 		auth := api.Group("/auth")
 		{
 			// Login has stricter rate limiting (5 per minute)
 			auth.POST("/login", loginRateLimiter.Middleware(), authHandler.Login)
+			auth.POST("/forgot-password", loginRateLimiter.Middleware(), authHandler.ForgotPassword)
+			auth.POST("/reset-password", loginRateLimiter.Middleware(), authHandler.ResetPassword)
+			auth.POST("/confirm-email", loginRateLimiter.Middleware(), authHandler.ConfirmEmail)
+			auth.POST("/refresh", loginRateLimiter.Middleware(), authHandler.Refresh)
 		}
 
+		// Email routes (public - opened from links in emails, authorized by a signed token)
+		api.GET("/email/unsubscribe", authHandler.Unsubscribe)
+
 		// Auth routes (authenticated)
 		authProtected := api.Group("/auth")
 		authProtected.Use(middleware.AuthMiddleware(authService))
 		{
+			authProtected.POST("/logout", authHandler.Logout)
 			authProtected.GET("/me", authHandler.Me)
 			authProtected.PUT("/password", authHandler.ChangePassword)
+			authProtected.POST("/change-email", authHandler.ChangeEmail)
 			authProtected.PUT("/email-preferences", authHandler.UpdateEmailPreferences)
 		}
 
 		// Vacation routes (authenticated)
 		vacation := api.Group("/vacation")
 		vacation.Use(middleware.AuthMiddleware(authService))
+		vacation.Use(middleware.PasswordChangeMiddleware(authService))
 		{
 			vacation.POST("/request", vacationHandler.Create)
-			vacation.GET("/requests", vacationHandler.List)
+			vacation.POST("/suggest", vacationHandler.Suggest)
+			vacation.GET("/requests", middleware.ETag(), vacationHandler.List)
+			vacation.GET("/requests.ics", vacationHandler.MyCalendar)
 			vacation.GET("/requests/:id", vacationHandler.Get)
 			vacation.DELETE("/requests/:id", vacationHandler.Cancel)
-			vacation.GET("/team", vacationHandler.Team)
+			vacation.POST("/requests/:id/submit", vacationHandler.Submit)
+			vacation.GET("/requests/:id/comments", vacationHandler.Comments)
+			vacation.POST("/requests/:id/comments", vacationHandler.AddComment)
+			vacation.GET("/requests/:id/attachments", vacationHandler.Attachments)
+			vacation.POST("/requests/:id/attachments", vacationHandler.UploadAttachment)
+			vacation.GET("/requests/:id/attachments/:attachmentId", vacationHandler.DownloadAttachment)
+			vacation.GET("/drafts", vacationHandler.Drafts)
+			vacation.GET("/team", middleware.ETag(), vacationHandler.Team)
+			vacation.GET("/team.ics", vacationHandler.TeamCalendar)
+			vacation.GET("/team/calendar", middleware.ETag(), vacationHandler.TeamWeeks)
+			vacation.GET("/gantt", vacationHandler.Gantt)
+			vacation.GET("/statement", vacationHandler.Statement)
+			vacation.GET("/report", vacationHandler.Report)
+
+			// Review for managers (direct reports only) and admins
+			vacation.GET("/pending", middleware.ManagerOrAdminMiddleware(authService), middleware.ETag(), adminHandler.ListPending)
+			vacation.PUT("/requests/:id/review", middleware.ManagerOrAdminMiddleware(authService), adminHandler.Review)
+		}
+
+		// Calendar routes (authenticated)
+		calendar := api.Group("/calendar")
+		calendar.Use(middleware.AuthMiddleware(authService))
+		calendar.Use(middleware.PasswordChangeMiddleware(authService))
+		{
+			calendar.GET("/working-days", vacationHandler.WorkingDays)
 		}
 
 		// Settings routes (authenticated - public settings only)
 		settings := api.Group("/settings")
 		settings.Use(middleware.AuthMiddleware(authService))
+		settings.Use(middleware.PasswordChangeMiddleware(authService))
 		{
 			settings.GET("/public", settingsHandler.GetPublic)
 		}
 
-		// Admin routes (authenticated + admin role)
+		// Admin routes (allowlisted networks + authenticated + admin role)
 		admin := api.Group("/admin")
+		admin.Use(middleware.IPAllowlist(cfg.AdminAllowedCIDRs))
 		admin.Use(middleware.AuthMiddleware(authService))
+		admin.Use(middleware.PasswordChangeMiddleware(authService))
 		admin.Use(middleware.AdminMiddleware())
 		{
 			// User management
@@ -163,26 +274,63 @@ func main() {
 			admin.GET("/users/:id", adminHandler.GetUser)
 			admin.PUT("/users/:id", adminHandler.UpdateUser)
 			admin.DELETE("/users/:id", adminHandler.DeleteUser)
+			admin.POST("/users/:id/restore", adminHandler.RestoreUser)
+			admin.PUT("/users/:id/status", adminHandler.UpdateUserStatus)
 			admin.PUT("/users/:id/balance", adminHandler.UpdateBalance)
+			admin.GET("/users/:id/statement", adminHandler.UserStatement)
+			admin.GET("/users/:id/vacations", adminHandler.UserVacations)
+			admin.GET("/users/:id/report", adminHandler.UserReport)
+			admin.GET("/users/:id/prorated-balance", adminHandler.ProratedBalance)
+			admin.GET("/users/reset-preview", adminHandler.ResetPreview)
 			admin.POST("/users/reset-balances", adminHandler.ResetBalances)
+			admin.POST("/users/adjust-balances", adminHandler.AdjustBalances)
+			admin.GET("/users/balance-reconcile", adminHandler.ReconcileBalances)
+			admin.GET("/balances", adminHandler.ListBalances)
+
+			// Teams
+			admin.GET("/teams", teamHandler.List)
+			admin.POST("/teams", teamHandler.Create)
+			admin.PUT("/teams/:id", teamHandler.Update)
+			admin.DELETE("/teams/:id", teamHandler.Delete)
 
 			// Vacation management
-			admin.GET("/vacation/pending", adminHandler.ListPending)
+			admin.GET("/vacation/pending", middleware.ETag(), adminHandler.ListPending)
+			admin.GET("/vacation/stale", adminHandler.ListStale)
 			admin.PUT("/vacation/:id/review", adminHandler.Review)
+			admin.POST("/vacation/:id/recompute", adminHandler.RecomputeTotalDays)
+			admin.GET("/vacation/coverage", adminHandler.Coverage)
+
+			// Reports
+			admin.GET("/reports/compliance", adminHandler.ComplianceReport)
+			admin.GET("/dashboard", adminHandler.Dashboard)
+			admin.GET("/stats", adminHandler.YearlyStats)
 
 			// Settings
 			admin.GET("/settings", adminHandler.GetSettings)
 			admin.PUT("/settings", adminHandler.UpdateSettings)
 
+			// Blackout periods
+			admin.GET("/blackouts", adminHandler.ListBlackouts)
+			admin.POST("/blackouts", adminHandler.CreateBlackout)
+			admin.DELETE("/blackouts/:id", adminHandler.DeleteBlackout)
+
 			// Newsletter
 			admin.POST("/newsletter/send", adminHandler.SendNewsletter)
 			admin.GET("/newsletter/preview", adminHandler.PreviewNewsletter)
 
 			// Email Testing
 			admin.POST("/email/test", adminHandler.SendTestEmail)
+			admin.GET("/notifications/recipients", adminHandler.NotificationRecipients)
 			admin.POST("/email/preview", adminHandler.PreviewEmail)
+			admin.POST("/email/validate", adminHandler.ValidateEmailTemplates)
+			admin.GET("/email/log", adminHandler.EmailLog)
+
+			// Audit log
+			admin.GET("/audit", adminHandler.AuditLog)
 		}
 	}
+	registerAPI(router.Group(middleware.APIVersionPrefix))
+	registerAPI(router.Group("/api", middleware.LegacyAPIHeaders(legacyAPISunset)))
 
 	// Create HTTP server with timeouts
 	srv := &http.Server{
@@ -198,9 +346,9 @@ func main() {
 		log.Printf("VacayTracker API starting on port %s", cfg.Port)
 		log.Printf("Environment: %s", cfg.Env)
 		log.Printf("Health check: http://localhost:%s/health", cfg.Port)
-		log.Printf("Login endpoint: POST http://localhost:%s/api/auth/login", cfg.Port)
-		log.Printf("Vacation endpoints: http://localhost:%s/api/vacation/*", cfg.Port)
-		log.Printf("Admin endpoints: http://localhost:%s/api/admin/*", cfg.Port)
+		log.Printf("Login endpoint: POST http://localhost:%s/api/v1/auth/login", cfg.Port)
+		log.Printf("Vacation endpoints: http://localhost:%s/api/v1/vacation/*", cfg.Port)
+		log.Printf("Admin endpoints: http://localhost:%s/api/v1/admin/*", cfg.Port)
 
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start server: %v", err)
@@ -214,7 +362,7 @@ func main() {
 
 	log.Println("Shutting down server...")
 
-	// Stop the newsletter scheduler
+	// Stop the background scheduler
 	scheduler.Stop()
 
 	// Give outstanding requests 5 seconds to complete
@@ -225,5 +373,8 @@ func main() {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	// Stop the email worker once no request can enqueue more; buffered emails stay pending
+	emailQueue.Stop()
+
 	log.Println("Server exited gracefully")
 }
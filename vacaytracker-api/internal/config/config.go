@@ -1,9 +1,13 @@
 package config
 
 import (
+	"fmt"
 	"log"
+	"net/mail"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,19 +19,49 @@ type Config struct {
 	Env    string
 	AppURL string
 
+	// Branding used in emails
+	AppName string
+	LogoURL string // Empty uses the logo served at AppURL
+
 	// Database
-	DBPath string
+	DBPath            string
+	DBMaxOpenConns    int // SQLite has one writer; more connections only add concurrent readers
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration // 0 keeps connections open indefinitely
+
+	// Attachments
+	AttachmentDir     string
+	AttachmentMaxSize int64 // Bytes
 
 	// Authentication
-	JWTSecret     string
-	AdminPassword string
-	AdminEmail    string
-	AdminName     string
+	JWTSecret        string
+	JWTIssuer        string        // iss claim of issued tokens
+	JWTAudience      string        // aud claim of issued tokens, required on incoming ones; empty disables the check
+	JWTAudienceSince time.Time     // When JWTIssuer/JWTAudience were rolled out; older tokens are honoured for one TokenTTL after it
+	TokenTTL         time.Duration // Access token lifetime
+	AdminPassword    string        // Required only when CreateInitialAdmin is set
+	AdminEmail       string
+	AdminName        string
+
+	// CreateInitialAdmin creates the configured admin at startup; disable when admins are provisioned externally
+	CreateInitialAdmin bool
+
+	// AdminAllowedCIDRs restricts /api/admin to these networks; empty allows any
+	AdminAllowedCIDRs []string
+
+	// Rate limiting (requests per client IP per window)
+	LoginRateLimit  int
+	APIRateLimit    int
+	RateLimitWindow time.Duration
 
 	// Email (Resend)
 	ResendAPIKey     string
 	EmailFromAddress string
 	EmailFromName    string
+	EmailReplyTo     string // Reply-To of every outgoing email, e.g. an HR inbox; empty leaves replies going to the sender
+
+	// Observability
+	MetricsEnabled bool
 }
 
 // Load reads configuration from environment variables
@@ -42,19 +76,48 @@ func Load() *Config {
 		Env:    getEnv("ENV", "development"),
 		AppURL: getEnv("APP_URL", "http://localhost:3000"),
 
+		// Branding defaults
+		AppName: getEnv("APP_NAME", DefaultAppName),
+		LogoURL: getEnv("LOGO_URL", ""),
+
 		// Database defaults
-		DBPath: getEnv("DB_PATH", "./data/vacaytracker.db"),
+		DBPath:            getEnv("DB_PATH", "./data/vacaytracker.db"),
+		DBMaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 1),
+		DBMaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 1),
+		DBConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 0),
+
+		// Attachment defaults
+		AttachmentDir:     getEnv("ATTACHMENT_DIR", "./data/attachments"),
+		AttachmentMaxSize: int64(getEnvInt("ATTACHMENT_MAX_SIZE_MB", 10)) << 20,
 
 		// Authentication (required)
-		JWTSecret:     mustGetEnv("JWT_SECRET"),
-		AdminPassword: mustGetEnv("ADMIN_PASSWORD"),
-		AdminEmail:    getEnv("ADMIN_EMAIL", "admin@company.com"),
-		AdminName:     getEnv("ADMIN_NAME", "Admin"),
+		JWTSecret:        mustGetEnv("JWT_SECRET"),
+		JWTIssuer:        getEnv("JWT_ISSUER", DefaultJWTIssuer),
+		JWTAudience:      getEnv("JWT_AUDIENCE", ""),
+		JWTAudienceSince: getEnvTime("JWT_AUDIENCE_SINCE"),
+		TokenTTL:         getEnvDuration("TOKEN_TTL", 24*time.Hour), // Default: 24 hours
+		AdminPassword:    getEnv("ADMIN_PASSWORD", ""),
+		AdminEmail:       getEnv("ADMIN_EMAIL", "admin@company.com"),
+		AdminName:        getEnv("ADMIN_NAME", "Admin"),
+
+		CreateInitialAdmin: getEnvBool("CREATE_INITIAL_ADMIN", true),
+
+		// Admin network restriction (optional)
+		AdminAllowedCIDRs: getEnvList("ADMIN_ALLOWED_CIDRS"),
+
+		// Rate limiting defaults
+		LoginRateLimit:  getEnvInt("LOGIN_RATE_LIMIT", 5),
+		APIRateLimit:    getEnvInt("API_RATE_LIMIT", 100),
+		RateLimitWindow: time.Duration(getEnvInt("RATE_LIMIT_WINDOW_SECONDS", 60)) * time.Second,
 
 		// Email (optional)
 		ResendAPIKey:     getEnv("RESEND_API_KEY", ""),
 		EmailFromAddress: getEnv("EMAIL_FROM_ADDRESS", ""),
 		EmailFromName:    getEnv("EMAIL_FROM_NAME", "VacayTracker"),
+		EmailReplyTo:     getEnv("EMAIL_REPLY_TO", ""),
+
+		// Observability (opt-in so /metrics is not exposed by default)
+		MetricsEnabled: getEnvBool("METRICS_ENABLED", false),
 	}
 
 	// Validate JWT secret length
@@ -62,9 +125,66 @@ func Load() *Config {
 		log.Fatal("JWT_SECRET must be at least 32 characters long")
 	}
 
+	if err := validateTokenTTL(cfg.TokenTTL); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := validateEmailAddresses(cfg); err != nil {
+		log.Fatal(err)
+	}
+
+	if cfg.DBMaxOpenConns < 1 {
+		log.Fatal("DB_MAX_OPEN_CONNS must be at least 1")
+	}
+
+	if cfg.CreateInitialAdmin && cfg.AdminPassword == "" {
+		log.Fatal("Required environment variable ADMIN_PASSWORD is not set (or set CREATE_INITIAL_ADMIN=false)")
+	}
+
 	return cfg
 }
 
+// DefaultAppName is the product name shown in emails unless APP_NAME overrides it
+const DefaultAppName = "VacayTracker"
+
+// DefaultJWTIssuer is the iss claim of tokens unless JWT_ISSUER overrides it
+// Tokens carrying it are always accepted, so changing the issuer doesn't invalidate existing sessions
+const DefaultJWTIssuer = "vacaytracker"
+
+// Bounds for TokenTTL; beyond the upper bound the 30-day refresh token no longer matters
+const (
+	minTokenTTL = time.Minute
+	maxTokenTTL = 30 * 24 * time.Hour
+)
+
+// validateTokenTTL rejects access token lifetimes too short to use or too long to be safe
+func validateTokenTTL(ttl time.Duration) error {
+	if ttl < minTokenTTL || ttl > maxTokenTTL {
+		return fmt.Errorf("TOKEN_TTL must be between %s and %s, got %s", minTokenTTL, maxTokenTTL, ttl)
+	}
+	return nil
+}
+
+// validateEmailAddresses rejects sender and reply-to addresses Resend would refuse on every send
+// Both must be bare addresses; the display name comes from EMAIL_FROM_NAME
+func validateEmailAddresses(cfg *Config) error {
+	for _, env := range []struct{ name, value string }{
+		{"EMAIL_FROM_ADDRESS", cfg.EmailFromAddress},
+		{"EMAIL_REPLY_TO", cfg.EmailReplyTo},
+	} {
+		if env.value == "" {
+			continue
+		}
+		if addr, err := mail.ParseAddress(env.value); err != nil || addr.Address != env.value {
+			return fmt.Errorf("%s must be a plain email address like hr@example.com, got %q", env.name, env.value)
+		}
+	}
+	if strings.ContainsAny(cfg.EmailFromName, "\r\n<>") {
+		return fmt.Errorf("EMAIL_FROM_NAME must not contain line breaks or angle brackets, got %q", cfg.EmailFromName)
+	}
+	return nil
+}
+
 // IsDevelopment returns true if running in development mode
 func (c *Config) IsDevelopment() bool {
 	return c.Env == "development"
@@ -80,6 +200,27 @@ func (c *Config) EmailEnabled() bool {
 	return c.ResendAPIKey != "" && c.EmailFromAddress != ""
 }
 
+// EmailFrom returns the From header of outgoing email, the sender name and address combined
+func (c *Config) EmailFrom() string {
+	return fmt.Sprintf("%s <%s>", c.EmailFromName, c.EmailFromAddress)
+}
+
+// BrandName returns the app name shown in emails
+func (c *Config) BrandName() string {
+	if c.AppName == "" {
+		return DefaultAppName
+	}
+	return c.AppName
+}
+
+// BrandLogoURL returns the logo shown in emails, defaulting to the one served at AppURL
+func (c *Config) BrandLogoURL() string {
+	if c.LogoURL == "" {
+		return c.AppURL + "/logo.png"
+	}
+	return c.LogoURL
+}
+
 // getEnv retrieves an environment variable with a default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -108,6 +249,65 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// getEnvDuration retrieves an environment variable as a Go duration (e.g. "15m", "24h") with a default value
+// It logs a fatal error if the variable is set but malformed, so a typo can't silently fall back to the default
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	duration, err := parseEnvDuration(key, defaultValue)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return duration
+}
+
+// parseEnvDuration retrieves an environment variable as a Go duration with a default value
+// Returns an error if the variable is set but isn't a valid duration
+func parseEnvDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration such as 15m or 24h, got %q", key, value)
+	}
+	return duration, nil
+}
+
+// getEnvTime retrieves an environment variable as an RFC 3339 timestamp, or the zero time when unset
+// It logs a fatal error if the variable is set but malformed
+func getEnvTime(key string) time.Time {
+	t, err := parseEnvTime(key)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return t
+}
+
+// parseEnvTime retrieves an environment variable as an RFC 3339 timestamp, or the zero time when unset
+// Returns an error if the variable is set but isn't a valid timestamp
+func parseEnvTime(key string) (time.Time, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC 3339 timestamp such as 2027-06-01T00:00:00Z, got %q", key, value)
+	}
+	return t, nil
+}
+
+// getEnvList retrieves a comma-separated environment variable, dropping blank entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // mustGetEnv retrieves a required environment variable
 // It logs a fatal error if the variable is not set
 func mustGetEnv(key string) string {
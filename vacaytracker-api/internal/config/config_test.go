@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestGetEnv(t *testing.T) {
@@ -43,6 +44,109 @@ func TestGetEnvInt(t *testing.T) {
 	}
 }
 
+func TestGetEnvList(t *testing.T) {
+	os.Setenv("TEST_LIST", " 10.0.0.0/8, ,192.168.1.20 ")
+	defer os.Unsetenv("TEST_LIST")
+
+	got := getEnvList("TEST_LIST")
+	if len(got) != 2 || got[0] != "10.0.0.0/8" || got[1] != "192.168.1.20" {
+		t.Errorf("getEnvList() = %v, want [10.0.0.0/8 192.168.1.20]", got)
+	}
+
+	// Test with non-existing var
+	if got := getEnvList("NON_EXISTING_LIST"); len(got) != 0 {
+		t.Errorf("getEnvList() = %v, want empty", got)
+	}
+}
+
+func TestParseEnvDuration(t *testing.T) {
+	os.Setenv("TEST_DURATION", "15m")
+	defer os.Unsetenv("TEST_DURATION")
+
+	if got, err := parseEnvDuration("TEST_DURATION", time.Hour); err != nil || got != 15*time.Minute {
+		t.Errorf("parseEnvDuration() = %v, %v, want %v", got, err, 15*time.Minute)
+	}
+
+	// Test with invalid duration
+	os.Setenv("TEST_INVALID_DURATION", "15")
+	defer os.Unsetenv("TEST_INVALID_DURATION")
+
+	if _, err := parseEnvDuration("TEST_INVALID_DURATION", time.Hour); err == nil {
+		t.Error("parseEnvDuration() expected an error for a duration without a unit")
+	}
+
+	// Test with non-existing var
+	if got, err := parseEnvDuration("NON_EXISTING_DURATION", time.Hour); err != nil || got != time.Hour {
+		t.Errorf("parseEnvDuration() = %v, %v, want %v", got, err, time.Hour)
+	}
+}
+
+func TestParseEnvTime(t *testing.T) {
+	os.Setenv("TEST_TIME", "2027-06-01T08:00:00Z")
+	defer os.Unsetenv("TEST_TIME")
+
+	want := time.Date(2027, 6, 1, 8, 0, 0, 0, time.UTC)
+	if got, err := parseEnvTime("TEST_TIME"); err != nil || !got.Equal(want) {
+		t.Errorf("parseEnvTime() = %v, %v, want %v", got, err, want)
+	}
+
+	// Test with a date but no time of day
+	os.Setenv("TEST_INVALID_TIME", "2027-06-01")
+	defer os.Unsetenv("TEST_INVALID_TIME")
+
+	if _, err := parseEnvTime("TEST_INVALID_TIME"); err == nil {
+		t.Error("parseEnvTime() expected an error for a date without a time")
+	}
+
+	// Test with non-existing var
+	if got, err := parseEnvTime("NON_EXISTING_TIME"); err != nil || !got.IsZero() {
+		t.Errorf("parseEnvTime() = %v, %v, want the zero time", got, err)
+	}
+}
+
+func TestValidateTokenTTL(t *testing.T) {
+	tests := []struct {
+		ttl     time.Duration
+		wantErr bool
+	}{
+		{15 * time.Minute, false},
+		{24 * time.Hour, false},
+		{time.Minute, false},
+		{30 * 24 * time.Hour, false},
+		{30 * time.Second, true},
+		{0, true},
+		{-time.Hour, true},
+		{31 * 24 * time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		if err := validateTokenTTL(tt.ttl); (err != nil) != tt.wantErr {
+			t.Errorf("validateTokenTTL(%v) error = %v, wantErr %v", tt.ttl, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateEmailAddresses(t *testing.T) {
+	tests := []struct {
+		cfg     Config
+		wantErr bool
+	}{
+		{Config{}, false},
+		{Config{EmailFromAddress: "noreply@example.com", EmailFromName: "HR Team", EmailReplyTo: "hr@example.com"}, false},
+		{Config{EmailFromAddress: "not-an-email"}, true},
+		{Config{EmailFromAddress: "HR <hr@example.com>"}, true},
+		{Config{EmailReplyTo: "hr@"}, true},
+		{Config{EmailReplyTo: "hr@example.com, it@example.com"}, true},
+		{Config{EmailFromName: "HR\r\nBcc: x@example.com"}, true},
+	}
+
+	for _, tt := range tests {
+		if err := validateEmailAddresses(&tt.cfg); (err != nil) != tt.wantErr {
+			t.Errorf("validateEmailAddresses(%+v) error = %v, wantErr %v", tt.cfg, err, tt.wantErr)
+		}
+	}
+}
+
 func TestGetEnvBool(t *testing.T) {
 	// Test with true value
 	os.Setenv("TEST_BOOL_TRUE", "true")
@@ -123,3 +227,23 @@ func TestEmailEnabled(t *testing.T) {
 		t.Error("EmailEnabled() should return false when both are empty")
 	}
 }
+
+func TestBranding(t *testing.T) {
+	// Unset - should fall back to the defaults
+	cfg := &Config{AppURL: "https://vacay.example.com"}
+	if got := cfg.BrandName(); got != DefaultAppName {
+		t.Errorf("BrandName() = %q, want %q", got, DefaultAppName)
+	}
+	if got := cfg.BrandLogoURL(); got != "https://vacay.example.com/logo.png" {
+		t.Errorf("BrandLogoURL() = %q, want the logo served at AppURL", got)
+	}
+
+	// Set - should be used as is
+	cfg = &Config{AppURL: "https://vacay.example.com", AppName: "Acme Leave", LogoURL: "https://cdn.example.com/acme.png"}
+	if got := cfg.BrandName(); got != "Acme Leave" {
+		t.Errorf("BrandName() = %q, want %q", got, "Acme Leave")
+	}
+	if got := cfg.BrandLogoURL(); got != "https://cdn.example.com/acme.png" {
+		t.Errorf("BrandLogoURL() = %q, want the configured logo", got)
+	}
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+)
+
+// Attachment is a file uploaded to a vacation request, such as a doctor's note
+// The file contents are kept on disk; this is only its metadata
+type Attachment struct {
+	ID          string    `json:"id"`
+	RequestID   string    `json:"requestId"`
+	UploadedBy  string    `json:"uploadedBy"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
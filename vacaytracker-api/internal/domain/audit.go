@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditAction identifies the kind of admin mutation recorded in the audit log
+type AuditAction string
+
+const (
+	AuditUserCreate       AuditAction = "user.create"
+	AuditUserUpdate       AuditAction = "user.update"
+	AuditUserDelete       AuditAction = "user.delete"
+	AuditUserRestore      AuditAction = "user.restore"
+	AuditUserStatus       AuditAction = "user.status"
+	AuditBalanceUpdate    AuditAction = "balance.update"
+	AuditBalanceReset     AuditAction = "balance.reset"
+	AuditBalanceAdjust    AuditAction = "balance.adjust"
+	AuditRequestApprove   AuditAction = "request.approve"
+	AuditRequestReject    AuditAction = "request.reject"
+	AuditRequestRecompute AuditAction = "request.recompute"
+	AuditSettingsUpdate   AuditAction = "settings.update"
+	AuditBlackoutCreate   AuditAction = "blackout.create"
+	AuditBlackoutDelete   AuditAction = "blackout.delete"
+	AuditTeamCreate       AuditAction = "team.create"
+	AuditTeamUpdate       AuditAction = "team.update"
+	AuditTeamDelete       AuditAction = "team.delete"
+)
+
+// Audit target types
+const (
+	AuditTargetUser     = "user"
+	AuditTargetRequest  = "vacation_request"
+	AuditTargetSettings = "settings"
+	AuditTargetBlackout = "blackout"
+	AuditTargetTeam     = "team"
+)
+
+// AuditEntry records one admin mutation with JSON snapshots of the target before and after
+// Before is empty for creations, After for deletions
+type AuditEntry struct {
+	ID         string          `json:"id"`
+	ActorID    string          `json:"actorId"`
+	ActorName  string          `json:"actorName,omitempty"` // Populated from JOIN
+	Action     AuditAction     `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetID   string          `json:"targetId,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// AuditFilter narrows an audit log listing; zero values match everything
+// From and To are inclusive YYYY-MM-DD dates
+type AuditFilter struct {
+	From    string
+	To      string
+	ActorID string
+}
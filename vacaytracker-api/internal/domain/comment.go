@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+)
+
+// RequestComment is a message in the discussion thread of a vacation request
+type RequestComment struct {
+	ID         string    `json:"id"`
+	RequestID  string    `json:"requestId"`
+	AuthorID   string    `json:"authorId"`
+	AuthorName string    `json:"authorName,omitempty"` // Populated from JOIN
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
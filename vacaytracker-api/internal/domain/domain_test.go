@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"strings"
 	"testing"
+	"time"
 )
 
 // ============================================
@@ -92,6 +94,35 @@ func TestIsValidRole(t *testing.T) {
 	}
 }
 
+func TestEmailPreferencesDisable(t *testing.T) {
+	prefs := EmailPreferences{VacationUpdates: true, WeeklyDigest: true, TeamNotifications: true}
+
+	if !prefs.Disable(EmailPrefWeeklyDigest) {
+		t.Fatal("Disable(weeklyDigest) should succeed")
+	}
+	if prefs.WeeklyDigest || !prefs.VacationUpdates || !prefs.TeamNotifications {
+		t.Errorf("only WeeklyDigest should be disabled, got %+v", prefs)
+	}
+	if prefs.Disable("everything") {
+		t.Error("Disable should reject unknown preferences")
+	}
+}
+
+func TestUserLocaleOrDefault(t *testing.T) {
+	tests := map[string]string{
+		"":   DefaultLocale,
+		"en": LocaleEnglish,
+		"de": LocaleGerman,
+		"xx": DefaultLocale,
+	}
+	for locale, want := range tests {
+		user := &User{Locale: locale}
+		if got := user.LocaleOrDefault(); got != want {
+			t.Errorf("LocaleOrDefault() for %q = %q, want %q", locale, got, want)
+		}
+	}
+}
+
 // ============================================
 // Vacation Tests
 // ============================================
@@ -137,6 +168,14 @@ func TestVacationCanBeCancelled(t *testing.T) {
 	if rejected.CanBeCancelled() {
 		t.Error("Rejected requests should not be cancellable")
 	}
+
+	tentative := &VacationRequest{Status: StatusTentative}
+	if !tentative.CanBeCancelled() {
+		t.Error("Tentative requests should be cancellable")
+	}
+	if tentative.IsUnderReview() {
+		t.Error("Tentative requests should not be under review")
+	}
 }
 
 func TestIsValidStatus(t *testing.T) {
@@ -149,6 +188,9 @@ func TestIsValidStatus(t *testing.T) {
 	if !IsValidStatus("rejected") {
 		t.Error("'rejected' should be a valid status")
 	}
+	if !IsValidStatus("tentative") {
+		t.Error("'tentative' should be a valid status")
+	}
 	if IsValidStatus("invalid") {
 		t.Error("'invalid' should not be a valid status")
 	}
@@ -236,6 +278,23 @@ func TestWeekendPolicyIsDayExcluded(t *testing.T) {
 	}
 }
 
+func TestWeekendPolicyWorkingDays(t *testing.T) {
+	if got := DefaultWeekendPolicy().WorkingDays(); got != 5 {
+		t.Errorf("WorkingDays() = %d, want 5", got)
+	}
+
+	all := WeekendPolicy{ExcludeWeekends: true, ExcludedDays: []int{0, 1, 2, 3, 4, 5, 6}}
+	if got := all.WorkingDays(); got != 0 {
+		t.Errorf("WorkingDays() = %d, want 0", got)
+	}
+
+	// Excluded days are ignored when ExcludeWeekends is off
+	all.ExcludeWeekends = false
+	if got := all.WorkingDays(); got != 7 {
+		t.Errorf("WorkingDays() = %d, want 7", got)
+	}
+}
+
 func TestParseWeekendPolicy(t *testing.T) {
 	// Test valid JSON
 	json := `{"excludeWeekends":false,"excludedDays":[1,2]}`
@@ -271,3 +330,361 @@ func TestWeekendPolicyToJSONString(t *testing.T) {
 		t.Error("ToJSONString() should not return empty string")
 	}
 }
+
+func TestSettingsOverlappingBlackout(t *testing.T) {
+	settings := Settings{
+		BlackoutPeriods: []BlackoutPeriod{
+			{ID: "q2", StartDate: "2027-06-24", EndDate: "2027-06-30", Reason: "Quarter close"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		wantMatch bool
+	}{
+		{"entirely before", "2027-06-14", "2027-06-23", false},
+		{"touches first day", "2027-06-20", "2027-06-24", true},
+		{"inside", "2027-06-25", "2027-06-26", true},
+		{"spans whole period", "2027-06-20", "2027-07-05", true},
+		{"touches last day", "2027-06-30", "2027-07-02", true},
+		{"entirely after", "2027-07-01", "2027-07-05", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := settings.OverlappingBlackout(tt.start, tt.end)
+			if (got != nil) != tt.wantMatch {
+				t.Errorf("OverlappingBlackout(%s, %s) = %v, want match %v", tt.start, tt.end, got, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestSettingsRejectionReasonLabel(t *testing.T) {
+	settings := Settings{
+		RejectionReasons: []RejectionReason{
+			{Code: "coverage", Label: "Not enough team coverage"},
+			{Code: "notice", Label: "Requested too late"},
+		},
+	}
+
+	label, ok := settings.RejectionReasonLabel("notice")
+	if !ok || label != "Requested too late" {
+		t.Errorf("RejectionReasonLabel(notice) = %q, %v", label, ok)
+	}
+	if _, ok := settings.RejectionReasonLabel("unknown"); ok {
+		t.Error("RejectionReasonLabel(unknown) should not match")
+	}
+}
+
+func TestParseRejectionReasons(t *testing.T) {
+	reasons, err := ParseRejectionReasons("")
+	if err != nil || reasons == nil || len(reasons) != 0 {
+		t.Errorf("ParseRejectionReasons(\"\") = %v, %v; want empty slice", reasons, err)
+	}
+
+	data, err := RejectionReasonsToJSONString([]RejectionReason{{Code: "coverage", Label: "Not enough team coverage"}})
+	if err != nil {
+		t.Fatalf("RejectionReasonsToJSONString: %v", err)
+	}
+	reasons, err = ParseRejectionReasons(data)
+	if err != nil || len(reasons) != 1 || reasons[0].Code != "coverage" {
+		t.Errorf("round trip = %v, %v", reasons, err)
+	}
+}
+
+func TestSettingsProratedEntitlement(t *testing.T) {
+	today := time.Date(2027, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		resetMonth int
+		start      string
+		want       int
+	}{
+		{"first day of leave year", 1, "2027-01-01", 25},
+		{"mid-year hire", 1, "2027-07-01", 13},
+		{"last day of leave year", 1, "2027-12-31", 0},
+		{"started in an earlier leave year", 1, "2026-05-01", 25},
+		{"april reset month", 4, "2027-01-01", 6},
+		{"next leave year", 4, "2027-10-01", 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := Settings{DefaultVacationDays: 25, VacationResetMonth: tt.resetMonth}
+			start, _ := time.Parse("2006-01-02", tt.start)
+			if got := settings.ProratedEntitlement(start, today); got != tt.want {
+				t.Errorf("ProratedEntitlement(%s) = %d, want %d", tt.start, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingsMinimumBalance(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+		want     int
+	}{
+		{"overdraw disabled", Settings{MaxOverdrawDays: 5}, 0},
+		{"overdraw enabled", Settings{AllowNegativeBalance: true, MaxOverdrawDays: 5}, -5},
+		{"enabled without limit", Settings{AllowNegativeBalance: true}, 0},
+		{"hours unit", Settings{AllowNegativeBalance: true, MaxOverdrawDays: 2, BalanceUnit: BalanceUnitHours, HoursPerDay: 8}, -16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.MinimumBalance(); got != tt.want {
+				t.Errorf("MinimumBalance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingsCrossesLowBalance(t *testing.T) {
+	tests := []struct {
+		name          string
+		settings      Settings
+		before, after int
+		want          bool
+	}{
+		{"drops to threshold", Settings{LowBalanceThreshold: 5}, 8, 5, true},
+		{"drops below threshold", Settings{LowBalanceThreshold: 5}, 6, 2, true},
+		{"stays above", Settings{LowBalanceThreshold: 5}, 10, 6, false},
+		{"already low", Settings{LowBalanceThreshold: 5}, 4, 1, false},
+		{"disabled", Settings{}, 8, 0, false},
+		{"hours unit", Settings{LowBalanceThreshold: 5, BalanceUnit: BalanceUnitHours, HoursPerDay: 8}, 48, 40, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.CrossesLowBalance(tt.before, tt.after); got != tt.want {
+				t.Errorf("CrossesLowBalance(%d, %d) = %v, want %v", tt.before, tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *Settings)
+		wantErr string
+	}{
+		{"defaults", func(s *Settings) {}, ""},
+		{"no working days", func(s *Settings) { s.WeekendPolicy.ExcludedDays = []int{0, 1, 2, 3, 4, 5, 6} }, "at least one working day"},
+		{"reset month out of range", func(s *Settings) { s.VacationResetMonth = 13 }, "reset month"},
+		{"admin default role", func(s *Settings) { s.DefaultNewUserRole = RoleAdmin }, "cannot be admin"},
+		{"relative webhook", func(s *Settings) { s.WebhookURL = "/hook" }, "webhook URL"},
+		{"hours without day length", func(s *Settings) { s.BalanceUnit = BalanceUnitHours; s.HoursPerDay = 0 }, "hours per day"},
+		{"accrual above entitlement", func(s *Settings) { s.AccrualEnabled = true; s.AccrualDaysPerMonth = 30 }, "accrual days per month (30)"},
+		{"disabled accrual ignored", func(s *Settings) { s.AccrualEnabled = false; s.AccrualDaysPerMonth = 30 }, ""},
+		{"threshold equal to entitlement", func(s *Settings) { s.LowBalanceThreshold = s.DefaultVacationDays }, ""},
+		{"threshold above entitlement", func(s *Settings) { s.LowBalanceThreshold = s.DefaultVacationDays + 1 }, "low balance threshold"},
+		{"named time zone", func(s *Settings) { s.Timezone = "Australia/Sydney" }, ""},
+		{"empty time zone means UTC", func(s *Settings) { s.Timezone = "" }, ""},
+		{"unknown time zone", func(s *Settings) { s.Timezone = "Mars/Olympus" }, "unknown time zone 'Mars/Olympus'"},
+		{"server local time zone", func(s *Settings) { s.Timezone = "Local" }, "unknown time zone"},
+		{"duplicate reason code", func(s *Settings) {
+			s.RejectionReasons = []RejectionReason{{Code: "coverage", Label: "A"}, {Code: "coverage", Label: "B"}}
+		}, "duplicate rejection reason code 'coverage'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := DefaultSettings()
+			tt.modify(&s)
+			err := s.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSettingsToday(t *testing.T) {
+	// 23:30 UTC on June 15 is already June 16 in Sydney and still June 15 in New York
+	now := time.Date(2027, 6, 15, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		timezone string
+		want     time.Time
+	}{
+		{"UTC", time.Date(2027, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{"", time.Date(2027, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{"Australia/Sydney", time.Date(2027, 6, 16, 0, 0, 0, 0, time.UTC)},
+		{"America/New_York", time.Date(2027, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{"Mars/Olympus", time.Date(2027, 6, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			s := Settings{Timezone: tt.timezone}
+			if got := s.Today(now); !got.Equal(tt.want) {
+				t.Errorf("Today() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingsRoundBusinessDays(t *testing.T) {
+	ceil := Settings{RoundingMode: RoundingCeil, WeekendPolicy: DefaultWeekendPolicy()}
+	sixDay := Settings{RoundingMode: RoundingCeil, WeekendPolicy: WeekendPolicy{ExcludeWeekends: true, ExcludedDays: []int{0}}}
+
+	tests := []struct {
+		name     string
+		settings Settings
+		days     int
+		want     int
+	}{
+		{"none keeps exact days", Settings{RoundingMode: RoundingNone, WeekendPolicy: DefaultWeekendPolicy()}, 3, 3},
+		{"unset keeps exact days", Settings{WeekendPolicy: DefaultWeekendPolicy()}, 3, 3},
+		{"ceil one day", ceil, 1, 5},
+		{"ceil four days", ceil, 4, 5},
+		{"ceil full week", ceil, 5, 5},
+		{"ceil one past a week", ceil, 6, 10},
+		{"ceil two full weeks", ceil, 10, 10},
+		{"ceil six day week", sixDay, 7, 12},
+		{"ceil without working days", Settings{RoundingMode: RoundingCeil, WeekendPolicy: WeekendPolicy{ExcludeWeekends: true, ExcludedDays: []int{0, 1, 2, 3, 4, 5, 6}}}, 3, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.RoundBusinessDays(tt.days); got != tt.want {
+				t.Errorf("RoundBusinessDays(%d) = %d, want %d", tt.days, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingsBalanceUnitConversion(t *testing.T) {
+	days := Settings{BalanceUnit: BalanceUnitDays, HoursPerDay: 8}
+	if got := days.DaysToBalance(3); got != 3 {
+		t.Errorf("days DaysToBalance(3) = %d, want 3", got)
+	}
+	if got := days.BalanceToDays(3); got != 3 {
+		t.Errorf("days BalanceToDays(3) = %d, want 3", got)
+	}
+
+	hours := Settings{BalanceUnit: BalanceUnitHours, HoursPerDay: 8}
+	if got := hours.DaysToBalance(3); got != 24 {
+		t.Errorf("hours DaysToBalance(3) = %d, want 24", got)
+	}
+	if got := hours.BalanceToDays(20); got != 2 {
+		t.Errorf("hours BalanceToDays(20) = %d, want 2", got)
+	}
+
+	// A missing day length falls back to treating amounts as days
+	unset := Settings{BalanceUnit: BalanceUnitHours}
+	if got := unset.DaysToBalance(3); got != 3 {
+		t.Errorf("unset DaysToBalance(3) = %d, want 3", got)
+	}
+}
+
+func TestNewsletterConfigNextSendAt(t *testing.T) {
+	// December 15, 2025 is a Monday
+	now := time.Date(2025, 12, 15, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		config NewsletterConfig
+		want   *time.Time
+	}{
+		{
+			name:   "disabled",
+			config: NewsletterConfig{Enabled: false, Frequency: "weekly", DayOfWeek: 1, Hour: 9},
+			want:   nil,
+		},
+		{
+			name:   "weekly - later today",
+			config: NewsletterConfig{Enabled: true, Frequency: "weekly", DayOfWeek: 1, Hour: 14},
+			want:   timePtr(time.Date(2025, 12, 15, 14, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:   "weekly - due now",
+			config: NewsletterConfig{Enabled: true, Frequency: "weekly", DayOfWeek: 1, Hour: 9},
+			want:   timePtr(time.Date(2025, 12, 15, 9, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "weekly - already sent today",
+			config: NewsletterConfig{Enabled: true, Frequency: "weekly", DayOfWeek: 1, Hour: 9,
+				LastSentAt: timePtr(time.Date(2025, 12, 15, 9, 1, 0, 0, time.UTC))},
+			want: timePtr(time.Date(2025, 12, 22, 9, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:   "weekly - later this week",
+			config: NewsletterConfig{Enabled: true, Frequency: "weekly", DayOfWeek: 5, Hour: 8},
+			want:   timePtr(time.Date(2025, 12, 19, 8, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:   "monthly - next month",
+			config: NewsletterConfig{Enabled: true, Frequency: "monthly", DayOfMonth: 1, Hour: 9},
+			want:   timePtr(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.config.NextSendAt(now)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("NextSendAt() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || !got.Equal(*tt.want) {
+				t.Errorf("NextSendAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewsletterConfigPersonalDigestDue(t *testing.T) {
+	// December 15, 2025 is a Monday
+	now := time.Date(2025, 12, 15, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		config NewsletterConfig
+		want   bool
+	}{
+		{"due even with the newsletter disabled", NewsletterConfig{Enabled: false, DayOfWeek: 1, Hour: 9}, true},
+		{"before the hour", NewsletterConfig{DayOfWeek: 1, Hour: 10}, false},
+		{"other weekday", NewsletterConfig{DayOfWeek: 5, Hour: 9}, false},
+		{"already run today", NewsletterConfig{DayOfWeek: 1, Hour: 9,
+			LastPersonalDigestAt: timePtr(time.Date(2025, 12, 15, 9, 1, 0, 0, time.UTC))}, false},
+		{"last run a week ago", NewsletterConfig{DayOfWeek: 1, Hour: 9,
+			LastPersonalDigestAt: timePtr(time.Date(2025, 12, 8, 9, 1, 0, 0, time.UTC))}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.PersonalDigestDue(now); got != tt.want {
+				t.Errorf("PersonalDigestDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNewsletterConfig_DefaultsMissingFields(t *testing.T) {
+	config, err := ParseNewsletterConfig(`{"enabled":true,"frequency":"weekly","dayOfMonth":1}`)
+	if err != nil {
+		t.Fatalf("ParseNewsletterConfig() error = %v", err)
+	}
+	if config.DayOfWeek != int(time.Monday) || config.Hour != 9 {
+		t.Errorf("missing schedule fields should default to Monday 9:00, got day %d hour %d", config.DayOfWeek, config.Hour)
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+)
+
+// EmailStatus represents the delivery state of a queued email
+type EmailStatus string
+
+const (
+	EmailStatusPending  EmailStatus = "pending"
+	EmailStatusSent     EmailStatus = "sent"
+	EmailStatusRetrying EmailStatus = "retrying"
+	EmailStatusFailed   EmailStatus = "failed"
+)
+
+// EmailMessage is an email persisted in the delivery queue
+type EmailMessage struct {
+	ID             string      `json:"id"`
+	Recipient      string      `json:"recipient"`
+	Subject        string      `json:"subject"`
+	HTMLBody       string      `json:"-"`
+	TextBody       string      `json:"-"`
+	Tags           []string    `json:"tags"`
+	IdempotencyKey string      `json:"-"`
+	ReplyTo        string      `json:"-"`
+	Status         EmailStatus `json:"status"`
+	Attempts       int         `json:"attempts"`
+	LastError      string      `json:"lastError,omitempty"`
+	NextAttemptAt  *time.Time  `json:"nextAttemptAt,omitempty"`
+	SentAt         *time.Time  `json:"sentAt,omitempty"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+)
+
+// LedgerReason describes why a vacation balance changed
+type LedgerReason string
+
+const (
+	LedgerOpening    LedgerReason = "opening"    // Balance a user started with
+	LedgerVacation   LedgerReason = "vacation"   // Deduction for an approved request, or its refund on cancellation
+	LedgerAdjustment LedgerReason = "adjustment" // Manual change by an admin
+	LedgerReset      LedgerReason = "reset"      // Yearly balance reset
+	LedgerAccrual    LedgerReason = "accrual"    // Monthly accrual credit
+)
+
+// LedgerEntry records a single signed change to a user's vacation balance
+type LedgerEntry struct {
+	ID          string       `json:"id"`
+	UserID      string       `json:"userId"`
+	Delta       int          `json:"delta"`
+	Reason      LedgerReason `json:"reason"`
+	ReferenceID *string      `json:"referenceId,omitempty"` // e.g. the vacation request ID
+	Note        *string      `json:"note,omitempty"`        // Admin's explanation for an adjustment
+	CreatedAt   time.Time    `json:"createdAt"`
+}
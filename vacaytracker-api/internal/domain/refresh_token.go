@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+)
+
+// RefreshToken is a stored long-lived token that can be exchanged for a new access token
+// Only a hash of the token value is stored
+type RefreshToken struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"userId"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// IsRevoked returns true if the token has been revoked (e.g. rotated)
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired returns true if the token has expired at the given time
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -2,6 +2,9 @@ package domain
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -11,22 +14,163 @@ type WeekendPolicy struct {
 	ExcludedDays    []int `json:"excludedDays"` // 0 = Sunday, 6 = Saturday
 }
 
+// OverlapPolicy decides what happens to a request overlapping the employee's other requests
+type OverlapPolicy string
+
+const (
+	OverlapPolicyBlock OverlapPolicy = "block" // Reject the request
+	OverlapPolicyWarn  OverlapPolicy = "warn"  // Accept it, flagged for the admin
+)
+
+// BalanceUnit is the unit vacation balances and request totals are kept in
+type BalanceUnit string
+
+const (
+	BalanceUnitDays  BalanceUnit = "days"
+	BalanceUnitHours BalanceUnit = "hours" // A business day counts as Settings.HoursPerDay
+)
+
+// RoundingMode decides how a request's business days are rounded before they are charged
+type RoundingMode string
+
+const (
+	RoundingNone RoundingMode = "none" // Charge the exact business days
+	RoundingCeil RoundingMode = "ceil" // Round up to whole working weeks
+)
+
 // NewsletterConfig holds newsletter scheduling settings
 type NewsletterConfig struct {
 	Enabled    bool       `json:"enabled"`
 	Frequency  string     `json:"frequency"`  // "weekly" or "monthly"
 	DayOfMonth int        `json:"dayOfMonth"` // 1-28 for monthly frequency
+	DayOfWeek  int        `json:"dayOfWeek"`  // 0 = Sunday, 6 = Saturday; for weekly frequency
+	Hour       int        `json:"hour"`       // 0-23 in server time; sent on the first check at or after this hour
 	LastSentAt *time.Time `json:"lastSentAt"` // Track last newsletter send time
+
+	// LastPersonalDigestAt tracks the last run of the employees' personal weekly digest,
+	// which goes out every DayOfWeek at Hour whether or not the newsletter is enabled
+	LastPersonalDigestAt *time.Time `json:"lastPersonalDigestAt,omitempty"`
+}
+
+// NextSendAt returns when the newsletter is next scheduled as of now, or nil if it is disabled
+// A time at or before now means a send is due
+func (n NewsletterConfig) NextSendAt(now time.Time) *time.Time {
+	if !n.Enabled || (n.Frequency != "weekly" && n.Frequency != "monthly") {
+		return nil
+	}
+
+	// Any weekly or monthly (day 1-28) slot falls within the next two months
+	for i := 0; i <= 62; i++ {
+		slot := time.Date(now.Year(), now.Month(), now.Day()+i, n.Hour, 0, 0, 0, now.Location())
+		if !n.isSendDay(slot) {
+			continue
+		}
+		if i == 0 && n.LastSentAt != nil {
+			y1, m1, d1 := n.LastSentAt.In(now.Location()).Date()
+			y2, m2, d2 := now.Date()
+			if y1 == y2 && m1 == m2 && d1 == d2 {
+				continue // Already sent today
+			}
+		}
+		return &slot
+	}
+	return nil
+}
+
+// PersonalDigestDue returns true if the personal weekly digest should run at now:
+// on DayOfWeek, at or after Hour, and not already run that day
+func (n NewsletterConfig) PersonalDigestDue(now time.Time) bool {
+	if int(now.Weekday()) != n.DayOfWeek || now.Hour() < n.Hour {
+		return false
+	}
+	if n.LastPersonalDigestAt != nil {
+		y1, m1, d1 := n.LastPersonalDigestAt.In(now.Location()).Date()
+		y2, m2, d2 := now.Date()
+		if y1 == y2 && m1 == m2 && d1 == d2 {
+			return false
+		}
+	}
+	return true
+}
+
+// isSendDay returns true if day is a scheduled newsletter day
+func (n NewsletterConfig) isSendDay(day time.Time) bool {
+	if n.Frequency == "weekly" {
+		return int(day.Weekday()) == n.DayOfWeek
+	}
+	return day.Day() == n.DayOfMonth
+}
+
+// DigestSections chooses which sections the newsletter digest includes
+type DigestSections struct {
+	MonthlyStats      bool `json:"monthlyStats"`      // Request counts for last month, including pending
+	UpcomingVacations bool `json:"upcomingVacations"` // Team vacations starting next month
+	LowBalance        bool `json:"lowBalance"`        // Employees at or below the low balance threshold
+}
+
+// IsEmpty returns true if every section is turned off
+func (d DigestSections) IsEmpty() bool {
+	return !d.MonthlyStats && !d.UpcomingVacations && !d.LowBalance
+}
+
+// ApprovalStep is a single level in the approval chain
+// A nil ApproverID means any admin may approve this level
+type ApprovalStep struct {
+	Name       string  `json:"name"`
+	ApproverID *string `json:"approverId,omitempty"`
+}
+
+// BlackoutPeriod is a date range where new leave is disallowed
+type BlackoutPeriod struct {
+	ID        string `json:"id"`
+	StartDate string `json:"startDate"` // Format: YYYY-MM-DD
+	EndDate   string `json:"endDate"`   // Format: YYYY-MM-DD (inclusive)
+	Reason    string `json:"reason"`
+}
+
+// RejectionReason is a predefined reason admins can pick when rejecting a request
+// Code identifies it in review requests; Label is what the employee sees
+type RejectionReason struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
 }
 
 // Settings holds application-wide configuration stored in the database
 type Settings struct {
-	ID                  string           `json:"id"` // Always "settings" (singleton)
-	WeekendPolicy       WeekendPolicy    `json:"weekendPolicy"`
-	Newsletter          NewsletterConfig `json:"newsletter"`
-	DefaultVacationDays int              `json:"defaultVacationDays"`
-	VacationResetMonth  int              `json:"vacationResetMonth"` // 1-12 (January = 1)
-	UpdatedAt           time.Time        `json:"updatedAt"`
+	ID                      string            `json:"id"` // Always "settings" (singleton)
+	WeekendPolicy           WeekendPolicy     `json:"weekendPolicy"`
+	Newsletter              NewsletterConfig  `json:"newsletter"`
+	DigestSections          DigestSections    `json:"digestSections"`
+	DefaultVacationDays     int               `json:"defaultVacationDays"`
+	VacationResetMonth      int               `json:"vacationResetMonth"` // 1-12 (January = 1)
+	ApprovalLevels          []ApprovalStep    `json:"approvalLevels"`     // Empty means a single admin approval
+	MinNoticeDays           int               `json:"minNoticeDays"`      // Business days of notice required; 0 disables
+	ApprovalCommentRequired bool              `json:"approvalCommentRequired"`
+	MaxConsecutiveDays      int               `json:"maxConsecutiveDays"` // Business days per request; 0 = unlimited
+	BlackoutPeriods         []BlackoutPeriod  `json:"blackoutPeriods"`
+	LongVacationDays        int               `json:"longVacationDays"` // Requests above this many business days are long; 0 disables cool-off
+	CoolOffDays             int               `json:"coolOffDays"`      // Calendar days required between two long vacations
+	AccrualEnabled          bool              `json:"accrualEnabled"`
+	AccrualDaysPerMonth     int               `json:"accrualDaysPerMonth"` // Credited monthly; balances never accrue past DefaultVacationDays
+	LastAccrualMonth        string            `json:"lastAccrualMonth"`    // YYYY-MM of the last accrual run; empty if never run
+	AllowNegativeBalance    bool              `json:"allowNegativeBalance"`
+	MaxOverdrawDays         int               `json:"maxOverdrawDays"` // How far below zero a balance may go when overdraw is allowed
+	WebhookURL              string            `json:"webhookUrl"`      // Receives request lifecycle events; empty disables
+	MinCoverage             int               `json:"minCoverage"`     // Employees who must remain available on every business day; 0 disables
+	OverlapPolicy           OverlapPolicy     `json:"overlapPolicy"`
+	OverlapAllowTouching    bool              `json:"overlapAllowTouching"` // Requests on consecutive days don't overlap; a shared day always does
+	RejectionReasons        []RejectionReason `json:"rejectionReasons"`
+	DefaultNewUserRole      Role              `json:"defaultNewUserRole"`    // Given to users created without a role; never admin
+	BalanceUnit             BalanceUnit       `json:"balanceUnit"`           // Switching it doesn't convert existing balances or requests
+	HoursPerDay             int               `json:"hoursPerDay"`           // Length of a business day when BalanceUnit is hours
+	LowBalanceThreshold     int               `json:"lowBalanceThreshold"`   // Days at or below which an approval sends a low balance email; 0 disables it
+	RoundingMode            RoundingMode      `json:"roundingMode"`          // Applied to business days before they are charged
+	MaxRequestsPerYear      int               `json:"maxRequestsPerYear"`    // Requests an employee may file per leave year; 0 means unlimited
+	CountRejectedRequests   bool              `json:"countRejectedRequests"` // Whether rejected requests count toward MaxRequestsPerYear
+	Timezone                string            `json:"timezone"`              // IANA zone whose calendar date counts as today for past-date and notice checks
+	MaxFutureDays           int               `json:"maxFutureDays"`         // Calendar days ahead a request may start; 0 means unlimited
+	RemoteBlocksLeave       bool              `json:"remoteBlocksLeave"`     // Remote days and paid leave on the same dates overlap; requests of the same type always do
+	UpdatedAt               time.Time         `json:"updatedAt"`
 }
 
 // DefaultWeekendPolicy returns the default weekend policy
@@ -45,19 +189,58 @@ func DefaultNewsletterConfig() NewsletterConfig {
 		Enabled:    false,
 		Frequency:  "monthly",
 		DayOfMonth: 1,
+		DayOfWeek:  int(time.Monday),
+		Hour:       9,
 		LastSentAt: nil,
 	}
 }
 
+// DefaultDigestSections returns the default digest sections
+// By default, every section is included
+func DefaultDigestSections() DigestSections {
+	return DigestSections{
+		MonthlyStats:      true,
+		UpcomingVacations: true,
+		LowBalance:        true,
+	}
+}
+
 // DefaultSettings returns a Settings struct with default values
 func DefaultSettings() Settings {
 	return Settings{
-		ID:                  "settings",
-		WeekendPolicy:       DefaultWeekendPolicy(),
-		Newsletter:          DefaultNewsletterConfig(),
-		DefaultVacationDays: 25,
-		VacationResetMonth:  1, // January
-		UpdatedAt:           time.Now(),
+		ID:                      "settings",
+		WeekendPolicy:           DefaultWeekendPolicy(),
+		Newsletter:              DefaultNewsletterConfig(),
+		DigestSections:          DefaultDigestSections(),
+		DefaultVacationDays:     25,
+		VacationResetMonth:      1, // January
+		ApprovalLevels:          []ApprovalStep{},
+		MinNoticeDays:           0,
+		ApprovalCommentRequired: false,
+		MaxConsecutiveDays:      0,
+		BlackoutPeriods:         []BlackoutPeriod{},
+		LongVacationDays:        0,
+		CoolOffDays:             0,
+		AccrualEnabled:          false,
+		AccrualDaysPerMonth:     2,
+		AllowNegativeBalance:    false,
+		MaxOverdrawDays:         0,
+		WebhookURL:              "",
+		MinCoverage:             0,
+		OverlapPolicy:           OverlapPolicyBlock,
+		OverlapAllowTouching:    true,
+		RejectionReasons:        []RejectionReason{},
+		DefaultNewUserRole:      RoleEmployee,
+		BalanceUnit:             BalanceUnitDays,
+		HoursPerDay:             8,
+		LowBalanceThreshold:     5,
+		RoundingMode:            RoundingNone,
+		MaxRequestsPerYear:      0,
+		CountRejectedRequests:   false,
+		Timezone:                "UTC",
+		MaxFutureDays:           0,
+		RemoteBlocksLeave:       true,
+		UpdatedAt:               time.Now(),
 	}
 }
 
@@ -89,7 +272,8 @@ func ParseNewsletterConfig(data string) (NewsletterConfig, error) {
 		return DefaultNewsletterConfig(), nil
 	}
 
-	var config NewsletterConfig
+	// Start from the defaults so configs stored before a field existed get its default
+	config := DefaultNewsletterConfig()
 	if err := json.Unmarshal([]byte(data), &config); err != nil {
 		return DefaultNewsletterConfig(), err
 	}
@@ -105,6 +289,309 @@ func (n NewsletterConfig) ToJSONString() (string, error) {
 	return string(bytes), nil
 }
 
+// ParseDigestSections parses JSON string into DigestSections struct
+func ParseDigestSections(data string) (DigestSections, error) {
+	if data == "" {
+		return DefaultDigestSections(), nil
+	}
+
+	var sections DigestSections
+	if err := json.Unmarshal([]byte(data), &sections); err != nil {
+		return DefaultDigestSections(), err
+	}
+	return sections, nil
+}
+
+// ToJSONString converts DigestSections to JSON string for database storage
+func (d DigestSections) ToJSONString() (string, error) {
+	bytes, err := json.Marshal(d)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// ParseApprovalLevels parses JSON string into an approval chain
+func ParseApprovalLevels(data string) ([]ApprovalStep, error) {
+	if data == "" {
+		return []ApprovalStep{}, nil
+	}
+
+	var levels []ApprovalStep
+	if err := json.Unmarshal([]byte(data), &levels); err != nil {
+		return []ApprovalStep{}, err
+	}
+	if levels == nil {
+		levels = []ApprovalStep{}
+	}
+	return levels, nil
+}
+
+// ApprovalLevelsToJSONString converts an approval chain to JSON string for database storage
+func ApprovalLevelsToJSONString(levels []ApprovalStep) (string, error) {
+	if levels == nil {
+		levels = []ApprovalStep{}
+	}
+	bytes, err := json.Marshal(levels)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// ParseBlackoutPeriods parses JSON string into blackout periods
+func ParseBlackoutPeriods(data string) ([]BlackoutPeriod, error) {
+	if data == "" {
+		return []BlackoutPeriod{}, nil
+	}
+
+	var periods []BlackoutPeriod
+	if err := json.Unmarshal([]byte(data), &periods); err != nil {
+		return []BlackoutPeriod{}, err
+	}
+	if periods == nil {
+		periods = []BlackoutPeriod{}
+	}
+	return periods, nil
+}
+
+// BlackoutPeriodsToJSONString converts blackout periods to JSON string for database storage
+func BlackoutPeriodsToJSONString(periods []BlackoutPeriod) (string, error) {
+	if periods == nil {
+		periods = []BlackoutPeriod{}
+	}
+	bytes, err := json.Marshal(periods)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// ParseRejectionReasons parses JSON string into predefined rejection reasons
+func ParseRejectionReasons(data string) ([]RejectionReason, error) {
+	if data == "" {
+		return []RejectionReason{}, nil
+	}
+
+	var reasons []RejectionReason
+	if err := json.Unmarshal([]byte(data), &reasons); err != nil {
+		return []RejectionReason{}, err
+	}
+	if reasons == nil {
+		reasons = []RejectionReason{}
+	}
+	return reasons, nil
+}
+
+// RejectionReasonsToJSONString converts predefined rejection reasons to JSON string for database storage
+func RejectionReasonsToJSONString(reasons []RejectionReason) (string, error) {
+	if reasons == nil {
+		reasons = []RejectionReason{}
+	}
+	bytes, err := json.Marshal(reasons)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// RejectionReasonLabel returns the label of the predefined rejection reason with the given code
+func (s Settings) RejectionReasonLabel(code string) (string, bool) {
+	for _, r := range s.RejectionReasons {
+		if r.Code == code {
+			return r.Label, true
+		}
+	}
+	return "", false
+}
+
+// OverlappingBlackout returns the first blackout period overlapping startDate..endDate (YYYY-MM-DD), or nil
+func (s Settings) OverlappingBlackout(startDate, endDate string) *BlackoutPeriod {
+	for i := range s.BlackoutPeriods {
+		b := s.BlackoutPeriods[i]
+		if b.StartDate <= endDate && b.EndDate >= startDate {
+			return &b
+		}
+	}
+	return nil
+}
+
+// ConflictingTypes returns the request types whose requests overlap a new request of type t, or nil for every type
+// Requests of the same type always conflict; remote days and paid leave only while RemoteBlocksLeave is set
+func (s Settings) ConflictingTypes(t RequestType) []RequestType {
+	if s.RemoteBlocksLeave {
+		return nil
+	}
+	return []RequestType{t}
+}
+
+// Validate checks the rules that span fields or aren't expressible as request binding tags
+// The returned error's message is suitable to show to the admin as a validation error
+func (s Settings) Validate() error {
+	if s.WeekendPolicy.WorkingDays() == 0 {
+		return errors.New("weekend policy must leave at least one working day")
+	}
+	if s.VacationResetMonth < 1 || s.VacationResetMonth > 12 {
+		return errors.New("vacation reset month must be between 1 and 12")
+	}
+	if s.DefaultNewUserRole == RoleAdmin {
+		// Anyone created without a role would silently get full access
+		return errors.New("default new user role cannot be admin")
+	}
+	if s.WebhookURL != "" && !IsValidWebhookURL(s.WebhookURL) {
+		return errors.New("webhook URL must be an absolute http or https URL")
+	}
+	if s.BalanceUnit == BalanceUnitHours && s.HoursPerDay < 1 {
+		return errors.New("hours per day must be at least 1 when balances are kept in hours")
+	}
+	if s.AccrualEnabled && s.AccrualDaysPerMonth > s.DefaultVacationDays {
+		return fmt.Errorf("accrual days per month (%d) cannot exceed default vacation days (%d)", s.AccrualDaysPerMonth, s.DefaultVacationDays)
+	}
+	if s.LowBalanceThreshold > s.DefaultVacationDays {
+		return fmt.Errorf("low balance threshold (%d) cannot exceed default vacation days (%d)", s.LowBalanceThreshold, s.DefaultVacationDays)
+	}
+	if _, err := time.LoadLocation(s.Timezone); err != nil || s.Timezone == "Local" {
+		return fmt.Errorf("unknown time zone '%s', expected an IANA name such as Europe/Berlin", s.Timezone)
+	}
+	seen := make(map[string]bool, len(s.RejectionReasons))
+	for _, r := range s.RejectionReasons {
+		if seen[r.Code] {
+			return fmt.Errorf("duplicate rejection reason code '%s'", r.Code)
+		}
+		seen[r.Code] = true
+	}
+	return nil
+}
+
+// IsLongVacation reports whether a request of totalDays is subject to the cool-off rule
+func (s Settings) IsLongVacation(totalDays int) bool {
+	return s.LongVacationDays > 0 && s.CoolOffDays > 0 && totalDays > s.LongVacationDays
+}
+
+// MinimumBalance returns the lowest balance a request may leave behind, in the balance unit
+func (s Settings) MinimumBalance() int {
+	if s.AllowNegativeBalance && s.MaxOverdrawDays > 0 {
+		return -s.DaysToBalance(s.MaxOverdrawDays)
+	}
+	return 0
+}
+
+// DaysToBalance converts a number of days into the balance unit
+func (s Settings) DaysToBalance(days int) int {
+	if s.BalanceUnit == BalanceUnitHours && s.HoursPerDay > 0 {
+		return days * s.HoursPerDay
+	}
+	return days
+}
+
+// CrossesLowBalance reports whether a balance change takes the balance from above LowBalanceThreshold
+// to at or below it, so each crossing is reported once however many approvals follow
+func (s Settings) CrossesLowBalance(before, after int) bool {
+	if s.LowBalanceThreshold <= 0 {
+		return false
+	}
+	threshold := s.DaysToBalance(s.LowBalanceThreshold)
+	return before > threshold && after <= threshold
+}
+
+// RoundBusinessDays applies RoundingMode to a request's business days
+// With ceil, days becomes ceil(days / w) * w where w is the number of working days per
+// week under the weekend policy, so 3 of 5 days charges 5 and 6 charges 10
+func (s Settings) RoundBusinessDays(days int) int {
+	if s.RoundingMode != RoundingCeil {
+		return days
+	}
+	week := s.WeekendPolicy.WorkingDays()
+	if week == 0 {
+		return days
+	}
+	return (days + week - 1) / week * week
+}
+
+// BalanceToDays converts an amount in the balance unit into whole days, rounding down
+func (s Settings) BalanceToDays(amount int) int {
+	if s.BalanceUnit == BalanceUnitHours && s.HoursPerDay > 0 {
+		return amount / s.HoursPerDay
+	}
+	return amount
+}
+
+// IsValidWebhookURL checks that a webhook target is an absolute http(s) URL
+func IsValidWebhookURL(raw string) bool {
+	u, err := url.ParseRequestURI(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// IsFinalApprovalStep reports whether the given step index is the last one in the chain
+// With no configured levels, the single admin approval is always final
+func (s Settings) IsFinalApprovalStep(step int) bool {
+	return step >= len(s.ApprovalLevels)-1
+}
+
+// LeaveYearStart returns the first day of the leave year containing date
+// Leave years begin on the 1st of VacationResetMonth
+func (s Settings) LeaveYearStart(date time.Time) time.Time {
+	resetMonth := time.Month(s.VacationResetMonth)
+	if resetMonth < time.January || resetMonth > time.December {
+		resetMonth = time.January
+	}
+	year := date.Year()
+	if date.Month() < resetMonth {
+		year--
+	}
+	return time.Date(year, resetMonth, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Location returns the time zone of Timezone; an empty or unknown one means UTC
+func (s Settings) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Today returns the calendar date of now in Timezone as midnight UTC,
+// the form request dates take once parsed, so they compare directly
+func (s Settings) Today(now time.Time) time.Time {
+	year, month, day := now.In(s.Location()).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// ProratedEntitlement returns the share of DefaultVacationDays earned by someone starting on startDate
+// Start dates before the leave year containing today earn the full entitlement
+func (s Settings) ProratedEntitlement(startDate, today time.Time) int {
+	start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+	if start.Before(s.LeaveYearStart(today)) {
+		return s.DefaultVacationDays
+	}
+
+	yearStart := s.LeaveYearStart(start)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+	totalDays := int(yearEnd.Sub(yearStart).Hours() / 24)
+	remainingDays := int(yearEnd.Sub(start).Hours() / 24)
+
+	// Round to the nearest whole day
+	return (s.DefaultVacationDays*remainingDays + totalDays/2) / totalDays
+}
+
+// WorkingDays returns how many weekdays remain bookable under the policy
+func (w WeekendPolicy) WorkingDays() int {
+	count := 0
+	for day := 0; day < 7; day++ {
+		if !w.IsDayExcluded(day) {
+			count++
+		}
+	}
+	return count
+}
+
 // IsDayExcluded checks if a given weekday is excluded from business day calculations
 // weekday: 0 = Sunday, 1 = Monday, ..., 6 = Saturday
 func (w WeekendPolicy) IsDayExcluded(weekday int) bool {
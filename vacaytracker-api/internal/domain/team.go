@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"time"
+)
+
+// Team is a department whose members share a calendar view
+type Team struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
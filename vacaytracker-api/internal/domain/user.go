@@ -20,18 +20,54 @@ type EmailPreferences struct {
 	TeamNotifications bool `json:"teamNotifications"`
 }
 
+// Email preference names, matching the EmailPreferences JSON field names
+const (
+	EmailPrefVacationUpdates   = "vacationUpdates"
+	EmailPrefWeeklyDigest      = "weeklyDigest"
+	EmailPrefTeamNotifications = "teamNotifications"
+)
+
+// Disable turns off the named preference
+// Returns false if the name is not a known preference
+func (p *EmailPreferences) Disable(name string) bool {
+	switch name {
+	case EmailPrefVacationUpdates:
+		p.VacationUpdates = false
+	case EmailPrefWeeklyDigest:
+		p.WeeklyDigest = false
+	case EmailPrefTeamNotifications:
+		p.TeamNotifications = false
+	default:
+		return false
+	}
+	return true
+}
+
 // User represents an employee or admin in the system
 type User struct {
-	ID               string           `json:"id"`
-	Email            string           `json:"email"`
-	PasswordHash     string           `json:"-"` // Never expose password hash
-	Name             string           `json:"name"`
-	Role             Role             `json:"role"`
-	VacationBalance  int              `json:"vacationBalance"`
-	StartDate        *string          `json:"startDate,omitempty"`
-	EmailPreferences EmailPreferences `json:"emailPreferences"`
-	CreatedAt        time.Time        `json:"createdAt"`
-	UpdatedAt        time.Time        `json:"updatedAt"`
+	ID                 string           `json:"id"`
+	Email              string           `json:"email"`
+	PasswordHash       string           `json:"-"` // Never expose password hash
+	Name               string           `json:"name"`
+	Role               Role             `json:"role"`
+	VacationBalance    int              `json:"vacationBalance"`
+	StartDate          *string          `json:"startDate,omitempty"`
+	EmailPreferences   EmailPreferences `json:"emailPreferences"`
+	MustChangePassword bool             `json:"mustChangePassword"`     // Set while the user still has a temporary password
+	ManagerID          *string          `json:"managerId,omitempty"`    // Direct manager, who may review this user's requests
+	TeamID             *string          `json:"teamId,omitempty"`       // Team whose calendar the user sees by default
+	DeletedAt          *time.Time       `json:"deletedAt,omitempty"`    // Set when the user is soft-deleted
+	Active             bool             `json:"active"`                 // Inactive users cannot log in
+	Locale             string           `json:"locale"`                 // Email language, e.g. "en" or "de"
+	PendingEmail       *string          `json:"pendingEmail,omitempty"` // Requested new email, applied once confirmed
+	LastLoginAt        *time.Time       `json:"lastLoginAt,omitempty"`  // Most recent successful login; nil if the user never logged in
+	CreatedAt          time.Time        `json:"createdAt"`
+	UpdatedAt          time.Time        `json:"updatedAt"`
+}
+
+// IsDeleted returns true if the user has been soft-deleted
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
 }
 
 // IsAdmin returns true if the user has admin role
@@ -80,6 +116,72 @@ func ValidRoles() []Role {
 	return []Role{RoleAdmin, RoleEmployee}
 }
 
+// UserSortField is a field the admin user list can be ordered by
+type UserSortField string
+
+const (
+	UserSortName      UserSortField = "name"
+	UserSortEmail     UserSortField = "email"
+	UserSortBalance   UserSortField = "balance"
+	UserSortCreatedAt UserSortField = "createdAt"
+)
+
+// UserSort describes the ordering of a user listing
+// The zero value keeps the default order, newest users first
+type UserSort struct {
+	Field      UserSortField
+	Descending bool
+}
+
+// UserFilter narrows the admin user list; zero-valued fields don't filter
+type UserFilter struct {
+	Role   *Role
+	Active *bool
+	Search string // Case-insensitive; matches name or email anywhere, or the role name exactly
+	Email  string // Exact email lookup, ignoring case
+}
+
+// IsValidUserSortField checks if a sort field string is supported
+func IsValidUserSortField(field string) bool {
+	switch UserSortField(field) {
+	case UserSortName, UserSortEmail, UserSortBalance, UserSortCreatedAt:
+		return true
+	}
+	return false
+}
+
+// Supported email locales
+const (
+	LocaleEnglish = "en"
+	LocaleGerman  = "de"
+)
+
+// DefaultLocale is used for users without a supported locale
+const DefaultLocale = LocaleEnglish
+
+// SupportedLocales returns all locales with translated emails
+func SupportedLocales() []string {
+	return []string{LocaleEnglish, LocaleGerman}
+}
+
+// IsValidLocale checks if a locale string is supported
+func IsValidLocale(locale string) bool {
+	for _, l := range SupportedLocales() {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// LocaleOrDefault returns the user's locale, falling back to DefaultLocale when unset or unsupported
+func (u *User) LocaleOrDefault() string {
+	if IsValidLocale(u.Locale) {
+		return u.Locale
+	}
+	return DefaultLocale
+}
+
 // IsValidRole checks if a role string is valid
 func IsValidRole(role string) bool {
 	for _, r := range ValidRoles() {
@@ -8,27 +8,73 @@ import (
 type VacationStatus string
 
 const (
-	StatusPending  VacationStatus = "pending"
-	StatusApproved VacationStatus = "approved"
-	StatusRejected VacationStatus = "rejected"
+	StatusTentative     VacationStatus = "tentative" // Pencilled in by the employee, not yet submitted for review
+	StatusPending       VacationStatus = "pending"
+	StatusAwaitingFinal VacationStatus = "awaiting_final" // Approved by at least one level, waiting for the rest of the chain
+	StatusApproved      VacationStatus = "approved"
+	StatusRejected      VacationStatus = "rejected"
 )
 
+// RequestType distinguishes paid leave from days that are only tracked for visibility
+type RequestType string
+
+const (
+	RequestTypeVacation RequestType = "vacation" // Paid leave, deducted from the balance
+	RequestTypeRemote   RequestType = "remote"   // Working from elsewhere; shown on the calendar, never deducted
+)
+
+// DayType classifies a date within a vacation request
+type DayType string
+
+const (
+	DayBusiness DayType = "business" // Counts towards TotalDays
+	DayWeekend  DayType = "weekend"  // Excluded by the weekend policy
+)
+
+// RequestDay is one date of a vacation request and how it counts
+type RequestDay struct {
+	Date string  `json:"date"` // Format: YYYY-MM-DD
+	Type DayType `json:"type"`
+}
+
+// LowBalanceAlert describes the balance left after an approval crossed Settings.LowBalanceThreshold
+type LowBalanceAlert struct {
+	Balance int
+	Unit    BalanceUnit
+}
+
 // VacationRequest represents an employee's vacation request
 type VacationRequest struct {
-	ID              string         `json:"id"`
-	UserID          string         `json:"userId"`
-	UserName        string         `json:"userName,omitempty"`  // Populated from JOIN
-	UserEmail       string         `json:"userEmail,omitempty"` // Populated from JOIN
-	StartDate       string         `json:"startDate"`           // Format: YYYY-MM-DD
-	EndDate         string         `json:"endDate"`             // Format: YYYY-MM-DD
-	TotalDays       int            `json:"totalDays"`
-	Reason          *string        `json:"reason,omitempty"`
-	Status          VacationStatus `json:"status"`
-	ReviewedBy      *string        `json:"reviewedBy,omitempty"`
-	ReviewedAt      *time.Time     `json:"reviewedAt,omitempty"`
-	RejectionReason *string        `json:"rejectionReason,omitempty"`
-	CreatedAt       time.Time      `json:"createdAt"`
-	UpdatedAt       time.Time      `json:"updatedAt"`
+	ID                    string           `json:"id"`
+	UserID                string           `json:"userId"`
+	UserName              string           `json:"userName,omitempty"`  // Populated from JOIN
+	UserEmail             string           `json:"userEmail,omitempty"` // Populated from JOIN
+	StartDate             string           `json:"startDate"`           // Format: YYYY-MM-DD
+	EndDate               string           `json:"endDate"`             // Format: YYYY-MM-DD
+	TotalDays             int              `json:"totalDays"`
+	Reason                *string          `json:"reason,omitempty"`
+	Status                VacationStatus   `json:"status"`
+	Type                  RequestType      `json:"type"`
+	ReviewedBy            *string          `json:"reviewedBy,omitempty"`
+	ReviewedByName        string           `json:"reviewedByName,omitempty"` // Populated from JOIN
+	ReviewedAt            *time.Time       `json:"reviewedAt,omitempty"`
+	RejectionReason       *string          `json:"rejectionReason,omitempty"`
+	ApprovalComment       *string          `json:"approvalComment,omitempty"`
+	BalanceOverrideReason *string          `json:"balanceOverrideReason,omitempty"` // Set when an admin approved despite insufficient balance
+	OriginalStartDate     *string          `json:"originalStartDate,omitempty"`     // Submitted range, set when an admin approved different dates
+	OriginalEndDate       *string          `json:"originalEndDate,omitempty"`       // Paired with OriginalStartDate
+	ApprovalStep          int              `json:"approvalStep"`                    // Index into Settings.ApprovalLevels of the next approver
+	OverlapWarning        bool             `json:"overlapWarning,omitempty"`        // Set on create/submit when accepted despite an overlap; not stored
+	DayBreakdown          []RequestDay     `json:"dayBreakdown,omitempty"`          // Set on create/get; not stored
+	LowBalanceAlert       *LowBalanceAlert `json:"-"`                               // Set when the approval took the balance to the low threshold; not stored
+	Warnings              []string         `json:"warnings,omitempty"`              // Advisory conditions that didn't block create/review; not stored
+	CreatedAt             time.Time        `json:"createdAt"`
+	UpdatedAt             time.Time        `json:"updatedAt"`
+}
+
+// IsTentative returns true if the request has not been submitted for review yet
+func (v *VacationRequest) IsTentative() bool {
+	return v.Status == StatusTentative
 }
 
 // IsPending returns true if the request is pending review
@@ -36,6 +82,16 @@ func (v *VacationRequest) IsPending() bool {
 	return v.Status == StatusPending
 }
 
+// IsAwaitingFinal returns true if the request passed at least one approval level
+func (v *VacationRequest) IsAwaitingFinal() bool {
+	return v.Status == StatusAwaitingFinal
+}
+
+// IsUnderReview returns true if the request still needs an approval decision
+func (v *VacationRequest) IsUnderReview() bool {
+	return v.IsPending() || v.IsAwaitingFinal()
+}
+
 // IsApproved returns true if the request has been approved
 func (v *VacationRequest) IsApproved() bool {
 	return v.Status == StatusApproved
@@ -46,25 +102,32 @@ func (v *VacationRequest) IsRejected() bool {
 	return v.Status == StatusRejected
 }
 
+// IsRemote returns true for remote days, which never touch the balance or the paid leave rules
+func (v *VacationRequest) IsRemote() bool {
+	return v.Type == RequestTypeRemote
+}
+
 // CanBeCancelled returns true if the request can be cancelled
-// Only pending requests can be cancelled
+// Only tentative requests and requests still under review can be cancelled
 func (v *VacationRequest) CanBeCancelled() bool {
-	return v.IsPending()
+	return v.IsTentative() || v.IsUnderReview()
 }
 
 // TeamVacation is a simplified view for team calendar display
 type TeamVacation struct {
-	ID        string `json:"id"`
-	UserID    string `json:"userId"`
-	UserName  string `json:"userName"`
-	StartDate string `json:"startDate"`
-	EndDate   string `json:"endDate"`
-	TotalDays int    `json:"totalDays"`
+	ID        string         `json:"id"`
+	UserID    string         `json:"userId"`
+	UserName  string         `json:"userName"`
+	StartDate string         `json:"startDate"`
+	EndDate   string         `json:"endDate"`
+	TotalDays int            `json:"totalDays"`
+	Status    VacationStatus `json:"status"`
+	Type      RequestType    `json:"type"`
 }
 
 // ValidStatuses returns all valid vacation status values
 func ValidStatuses() []VacationStatus {
-	return []VacationStatus{StatusPending, StatusApproved, StatusRejected}
+	return []VacationStatus{StatusTentative, StatusPending, StatusAwaitingFinal, StatusApproved, StatusRejected}
 }
 
 // IsValidStatus checks if a status string is valid
@@ -3,20 +3,24 @@ package dto
 import (
 	"fmt"
 	"net/http"
+
+	"vacaytracker-api/internal/domain"
 )
 
 // Error codes
 const (
 	// Authentication errors
-	ErrInvalidCredentials = "INVALID_CREDENTIALS"
-	ErrAuthTokenMissing   = "AUTH_TOKEN_MISSING"
-	ErrAuthTokenInvalid   = "AUTH_TOKEN_INVALID"
-	ErrAuthTokenExpired   = "AUTH_TOKEN_EXPIRED"
+	ErrInvalidCredentials     = "INVALID_CREDENTIALS"
+	ErrAuthTokenMissing       = "AUTH_TOKEN_MISSING"
+	ErrAuthTokenInvalid       = "AUTH_TOKEN_INVALID"
+	ErrAuthTokenExpired       = "AUTH_TOKEN_EXPIRED"
+	ErrPasswordChangeRequired = "PASSWORD_CHANGE_REQUIRED"
+	ErrAccountDisabled        = "ACCOUNT_DISABLED"
 
 	// Authorization errors
-	ErrAdminRequired    = "ADMIN_REQUIRED"
-	ErrForbidden        = "FORBIDDEN"
-	ErrUnauthorized     = "UNAUTHORIZED"
+	ErrAdminRequired = "ADMIN_REQUIRED"
+	ErrForbidden     = "FORBIDDEN"
+	ErrUnauthorized  = "UNAUTHORIZED"
 
 	// Validation errors
 	ErrValidation       = "VALIDATION_ERROR"
@@ -32,11 +36,12 @@ const (
 	ErrAlreadyExists    = "ALREADY_EXISTS"
 
 	// Business logic errors
-	ErrInsufficientBalance   = "INSUFFICIENT_BALANCE"
-	ErrCannotCancelApproved  = "CANNOT_CANCEL_APPROVED"
-	ErrCannotCancelRejected  = "CANNOT_CANCEL_REJECTED"
-	ErrOverlappingRequest    = "OVERLAPPING_REQUEST"
-	ErrInvalidStatus         = "INVALID_STATUS"
+	ErrInsufficientBalance  = "INSUFFICIENT_BALANCE"
+	ErrCannotCancelApproved = "CANNOT_CANCEL_APPROVED"
+	ErrCannotCancelRejected = "CANNOT_CANCEL_REJECTED"
+	ErrOverlappingRequest   = "OVERLAPPING_REQUEST"
+	ErrCoverageExceeded     = "COVERAGE_EXCEEDED"
+	ErrInvalidStatus        = "INVALID_STATUS"
 
 	// Rate limiting errors
 	ErrRateLimitExceeded = "RATE_LIMIT_EXCEEDED"
@@ -46,13 +51,31 @@ const (
 	ErrDatabase = "DATABASE_ERROR"
 )
 
+// ErrorCodes lists every error code the API returns, for the OpenAPI description
+var ErrorCodes = []string{
+	ErrInvalidCredentials, ErrAuthTokenMissing, ErrAuthTokenInvalid, ErrAuthTokenExpired,
+	ErrPasswordChangeRequired, ErrAccountDisabled,
+	ErrAdminRequired, ErrForbidden, ErrUnauthorized,
+	ErrValidation, ErrInvalidDateRange, ErrDateInPast, ErrInvalidInput,
+	ErrUserNotFound, ErrRequestNotFound, ErrSettingsNotFound, ErrNotFound, ErrAlreadyExists,
+	ErrInsufficientBalance, ErrCannotCancelApproved, ErrCannotCancelRejected,
+	ErrOverlappingRequest, ErrCoverageExceeded, ErrInvalidStatus,
+	ErrRateLimitExceeded,
+	ErrInternal, ErrDatabase,
+}
+
 // ErrorResponse represents an API error response
+// Errors is only set when a request body failed binding and maps each offending field to its problem
 type ErrorResponse struct {
 	Code    string                 `json:"code"`
 	Message string                 `json:"message"`
 	Details map[string]interface{} `json:"details,omitempty"`
+	Errors  ValidationErrors       `json:"errors,omitempty"`
 }
 
+// ValidationErrors maps a request field, by its JSON path (e.g. "approvalLevels[0].name"), to why it was rejected
+type ValidationErrors map[string]string
+
 // AppError represents an application error with HTTP status
 type AppError struct {
 	Code       string
@@ -114,6 +137,16 @@ func ErrTokenExpiredError() *AppError {
 	return NewAppError(ErrAuthTokenExpired, "Token has expired", http.StatusUnauthorized)
 }
 
+// ErrPasswordChangeRequiredError returns an error for users who must change their temporary password first
+func ErrPasswordChangeRequiredError() *AppError {
+	return NewAppError(ErrPasswordChangeRequired, "You must change your password before continuing", http.StatusForbidden)
+}
+
+// ErrAccountDisabledError returns an error for users whose account has been deactivated
+func ErrAccountDisabledError() *AppError {
+	return NewAppError(ErrAccountDisabled, "Your account has been deactivated", http.StatusForbidden)
+}
+
 // ErrAdminRequiredError returns an admin required error
 func ErrAdminRequiredError() *AppError {
 	return NewAppError(ErrAdminRequired, "Admin privileges required", http.StatusForbidden)
@@ -160,14 +193,66 @@ func ErrAlreadyExistsError(resource string) *AppError {
 }
 
 // ErrInsufficientBalanceError returns an insufficient balance error
-func ErrInsufficientBalanceError(requested, available int) *AppError {
+// resultingBalance in the details is the balance the request would leave behind
+// unit is the balance unit (days or hours) the amounts are in
+func ErrInsufficientBalanceError(requested, available int, unit string) *AppError {
 	return NewAppError(
 		ErrInsufficientBalance,
-		fmt.Sprintf("Insufficient vacation balance: requested %d days, available %d days", requested, available),
+		fmt.Sprintf("Insufficient vacation balance: requested %d %s, available %d %s", requested, unit, available, unit),
 		http.StatusUnprocessableEntity,
 	).WithDetails(map[string]interface{}{
-		"requested": requested,
-		"available": available,
+		"requested":        requested,
+		"available":        available,
+		"resultingBalance": available - requested,
+	})
+}
+
+// ErrMaxConsecutiveDaysError returns a validation error for requests longer than the configured limit
+func ErrMaxConsecutiveDaysError(requested, limit int) *AppError {
+	return NewAppError(
+		ErrValidation,
+		fmt.Sprintf("requests cannot exceed %d consecutive days", limit),
+		http.StatusBadRequest,
+	).WithDetails(map[string]interface{}{
+		"requested":          requested,
+		"maxConsecutiveDays": limit,
+	})
+}
+
+// ErrMaxRequestsPerYearError returns a validation error for employees who already filed the allowed number of requests this leave year
+func ErrMaxRequestsPerYearError(count, limit int) *AppError {
+	return NewAppError(
+		ErrValidation,
+		fmt.Sprintf("you have already filed %d of the %d requests allowed this leave year", count, limit),
+		http.StatusBadRequest,
+	).WithDetails(map[string]interface{}{
+		"requests":           count,
+		"maxRequestsPerYear": limit,
+	})
+}
+
+// ErrBlackoutPeriodError returns a validation error for requests overlapping a blackout period
+func ErrBlackoutPeriodError(blackout domain.BlackoutPeriod) *AppError {
+	return NewAppError(
+		ErrValidation,
+		fmt.Sprintf("requested dates overlap a blackout period: %s", blackout.Reason),
+		http.StatusBadRequest,
+	).WithDetails(map[string]interface{}{
+		"blackoutId":    blackout.ID,
+		"blackoutStart": blackout.StartDate,
+		"blackoutEnd":   blackout.EndDate,
+		"reason":        blackout.Reason,
+	})
+}
+
+// ErrFileTooLargeError returns a validation error for uploads above the size limit
+func ErrFileTooLargeError(maxSize int64) *AppError {
+	return NewAppError(
+		ErrValidation,
+		fmt.Sprintf("file exceeds the maximum size of %d MB", maxSize>>20),
+		http.StatusRequestEntityTooLarge,
+	).WithDetails(map[string]interface{}{
+		"maxSizeBytes": maxSize,
 	})
 }
 
@@ -185,6 +270,20 @@ func ErrOverlappingRequestError() *AppError {
 	return NewAppError(ErrOverlappingRequest, "Request overlaps with an existing vacation", http.StatusConflict)
 }
 
+// ErrCoverageExceededError returns a conflict error for approvals that would leave too few employees available
+func ErrCoverageExceededError(date string, off, available, minCoverage int) *AppError {
+	return NewAppError(
+		ErrCoverageExceeded,
+		fmt.Sprintf("approving would leave %d employee(s) available on %s, below the minimum of %d", available, date, minCoverage),
+		http.StatusConflict,
+	).WithDetails(map[string]interface{}{
+		"date":        date,
+		"off":         off,
+		"available":   available,
+		"minCoverage": minCoverage,
+	})
+}
+
 // ErrInternalError returns an internal server error
 func ErrInternalError() *AppError {
 	return NewAppError(ErrInternal, "An internal error occurred", http.StatusInternalServerError)
@@ -10,12 +10,41 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required,min=6,max=72"`
 }
 
+// RefreshTokenRequest represents the token refresh request body
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+// LogoutRequest represents the optional logout request body
+// RefreshToken, when given, is revoked along with the access token
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
 // ChangePasswordRequest represents the password change request body
 type ChangePasswordRequest struct {
 	CurrentPassword string `json:"currentPassword" binding:"required"`
 	NewPassword     string `json:"newPassword" binding:"required,min=6,max=72"`
 }
 
+// ForgotPasswordRequest represents the password reset link request body
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest represents the password reset request body
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required,min=6,max=72"`
+}
+
+// ChangeEmailRequest represents the email change request body
+// The current password is required so a hijacked session can't take over the account
+type ChangeEmailRequest struct {
+	NewEmail        string `json:"newEmail" binding:"required,email"`
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+}
+
 // UpdateEmailPreferencesRequest represents the email preferences update request
 type UpdateEmailPreferencesRequest struct {
 	VacationUpdates   *bool `json:"vacationUpdates"`
@@ -32,18 +61,29 @@ type CreateUserRequest struct {
 	Email           string `json:"email" binding:"required,email"`
 	Password        string `json:"password" binding:"required,min=6,max=72"`
 	Name            string `json:"name" binding:"required,min=1,max=100"`
-	Role            string `json:"role" binding:"required,oneof=admin employee"`
+	Role            string `json:"role,omitempty" binding:"omitempty,oneof=admin employee"` // Defaults to Settings.DefaultNewUserRole
 	VacationBalance *int   `json:"vacationBalance"`
 	StartDate       string `json:"startDate,omitempty"`
+	ManagerID       string `json:"managerId,omitempty"`
+	TeamID          string `json:"teamId,omitempty"`
+	Locale          string `json:"locale,omitempty" binding:"omitempty,oneof=en de"` // Defaults to English
 }
 
 // UpdateUserRequest represents the user update request body
 type UpdateUserRequest struct {
-	Email           string `json:"email,omitempty" binding:"omitempty,email"`
-	Name            string `json:"name,omitempty" binding:"omitempty,max=100"`
-	Role            string `json:"role,omitempty" binding:"omitempty,oneof=admin employee"`
-	VacationBalance *int   `json:"vacationBalance,omitempty"`
-	StartDate       string `json:"startDate,omitempty"`
+	Email           string  `json:"email,omitempty" binding:"omitempty,email"`
+	Name            string  `json:"name,omitempty" binding:"omitempty,max=100"`
+	Role            string  `json:"role,omitempty" binding:"omitempty,oneof=admin employee"`
+	VacationBalance *int    `json:"vacationBalance,omitempty"`
+	StartDate       string  `json:"startDate,omitempty"`
+	ManagerID       *string `json:"managerId,omitempty"` // Empty string removes the manager
+	TeamID          *string `json:"teamId,omitempty"`    // Empty string removes the team
+	Locale          string  `json:"locale,omitempty" binding:"omitempty,oneof=en de"`
+}
+
+// UpdateUserStatusRequest represents the user activation request
+type UpdateUserStatusRequest struct {
+	Active *bool `json:"active" binding:"required"`
 }
 
 // UpdateVacationBalanceRequest represents the balance update request
@@ -51,6 +91,14 @@ type UpdateVacationBalanceRequest struct {
 	VacationBalance int `json:"vacationBalance" binding:"required,min=0"`
 }
 
+// AdjustBalancesRequest represents a relative balance change for a group of users
+// Delta may be negative to take days away; zero is rejected
+type AdjustBalancesRequest struct {
+	UserIDs []string `json:"userIds" binding:"required,min=1,max=500,dive,required"`
+	Delta   int      `json:"delta" binding:"required,min=-365,max=365"`
+	Reason  string   `json:"reason" binding:"required,max=500"`
+}
+
 // ============================================
 // Vacation Requests
 // ============================================
@@ -61,12 +109,53 @@ type CreateVacationRequest struct {
 	StartDate string `json:"startDate" binding:"required"`
 	EndDate   string `json:"endDate" binding:"required"`
 	Reason    string `json:"reason,omitempty" binding:"max=200"`
+	Tentative bool   `json:"tentative,omitempty"`                                      // Pencil in without review, balance or overlap checks
+	Force     bool   `json:"force,omitempty"`                                          // Admins only: create despite a blackout period or the booking horizon
+	Type      string `json:"type,omitempty" binding:"omitempty,oneof=vacation remote"` // Defaults to vacation; remote days never touch the balance
+}
+
+// SuggestVacationRequest asks for free date ranges of a given length
+// Dates should be in DD/MM/YYYY format (EU format)
+type SuggestVacationRequest struct {
+	Days  int    `json:"days" binding:"required,min=1,max=60"`
+	From  string `json:"from" binding:"required"`
+	To    string `json:"to" binding:"required"`
+	Count int    `json:"count,omitempty" binding:"omitempty,min=1,max=10"`
 }
 
 // ReviewVacationRequest represents the approval/rejection request
+// Reason is the rejection reason or, for approvals, the approval comment
+// ReasonCode picks a predefined rejection reason; Reason then adds optional detail
+// Force lets an admin approve despite insufficient balance; OverrideReason explains why
+// StartDate and EndDate (DD/MM/YYYY) approve a narrower range than requested; both or neither must be set
 type ReviewVacationRequest struct {
-	Status string `json:"status" binding:"required,oneof=approved rejected"`
-	Reason string `json:"reason,omitempty" binding:"max=200"`
+	Status         string `json:"status" binding:"required,oneof=approved rejected"`
+	Reason         string `json:"reason,omitempty" binding:"max=200"`
+	ReasonCode     string `json:"reasonCode,omitempty" binding:"max=50"`
+	Force          bool   `json:"force,omitempty"`
+	OverrideReason string `json:"overrideReason,omitempty" binding:"max=200"`
+	StartDate      string `json:"startDate,omitempty"`
+	EndDate        string `json:"endDate,omitempty"`
+}
+
+// CreateBlackoutRequest represents a new blackout period
+// Dates should be in DD/MM/YYYY format (EU format)
+type CreateBlackoutRequest struct {
+	StartDate string `json:"startDate" binding:"required"`
+	EndDate   string `json:"endDate" binding:"required"`
+	Reason    string `json:"reason" binding:"required,max=200"`
+}
+
+// TeamRequest represents the team create/rename request body
+type TeamRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+// CreateCommentRequest represents a new comment on a vacation request
+// Notify emails the other party: admins for the owner's comments, otherwise the owner
+type CreateCommentRequest struct {
+	Body   string `json:"body" binding:"required,max=2000"`
+	Notify bool   `json:"notify,omitempty"`
 }
 
 // ============================================
@@ -75,16 +164,54 @@ type ReviewVacationRequest struct {
 
 // UpdateSettingsRequest represents the settings update request
 type UpdateSettingsRequest struct {
-	WeekendPolicy       *WeekendPolicyRequest    `json:"weekendPolicy,omitempty"`
-	Newsletter          *NewsletterConfigRequest `json:"newsletter,omitempty"`
-	DefaultVacationDays *int                     `json:"defaultVacationDays,omitempty" binding:"omitempty,min=0,max=365"`
-	VacationResetMonth  *int                     `json:"vacationResetMonth,omitempty" binding:"omitempty,min=1,max=12"`
+	WeekendPolicy           *WeekendPolicyRequest     `json:"weekendPolicy,omitempty"`
+	Newsletter              *NewsletterConfigRequest  `json:"newsletter,omitempty"`
+	DigestSections          *DigestSectionsRequest    `json:"digestSections,omitempty"`
+	DefaultVacationDays     *int                      `json:"defaultVacationDays,omitempty" binding:"omitempty,min=0,max=365"`
+	VacationResetMonth      *int                      `json:"vacationResetMonth,omitempty" binding:"omitempty,min=1,max=12"`
+	ApprovalLevels          *[]ApprovalStepRequest    `json:"approvalLevels,omitempty" binding:"omitempty,max=5,dive"`
+	MinNoticeDays           *int                      `json:"minNoticeDays,omitempty" binding:"omitempty,min=0,max=90"`
+	ApprovalCommentRequired *bool                     `json:"approvalCommentRequired,omitempty"`
+	MaxConsecutiveDays      *int                      `json:"maxConsecutiveDays,omitempty" binding:"omitempty,min=0,max=365"`
+	LongVacationDays        *int                      `json:"longVacationDays,omitempty" binding:"omitempty,min=0,max=365"`
+	CoolOffDays             *int                      `json:"coolOffDays,omitempty" binding:"omitempty,min=0,max=365"`
+	AccrualEnabled          *bool                     `json:"accrualEnabled,omitempty"`
+	AccrualDaysPerMonth     *int                      `json:"accrualDaysPerMonth,omitempty" binding:"omitempty,min=0,max=31"`
+	AllowNegativeBalance    *bool                     `json:"allowNegativeBalance,omitempty"`
+	MaxOverdrawDays         *int                      `json:"maxOverdrawDays,omitempty" binding:"omitempty,min=0,max=365"`
+	WebhookURL              *string                   `json:"webhookUrl,omitempty" binding:"omitempty,max=2048"` // Empty string disables the webhook
+	MinCoverage             *int                      `json:"minCoverage,omitempty" binding:"omitempty,min=0,max=10000"`
+	OverlapPolicy           *string                   `json:"overlapPolicy,omitempty" binding:"omitempty,oneof=block warn"`
+	OverlapAllowTouching    *bool                     `json:"overlapAllowTouching,omitempty"`
+	RejectionReasons        *[]RejectionReasonRequest `json:"rejectionReasons,omitempty" binding:"omitempty,max=50,dive"`
+	DefaultNewUserRole      *string                   `json:"defaultNewUserRole,omitempty" binding:"omitempty,oneof=admin employee"`
+	BalanceUnit             *string                   `json:"balanceUnit,omitempty" binding:"omitempty,oneof=days hours"`
+	HoursPerDay             *int                      `json:"hoursPerDay,omitempty" binding:"omitempty,min=1,max=24"`
+	LowBalanceThreshold     *int                      `json:"lowBalanceThreshold,omitempty" binding:"omitempty,min=0,max=365"`
+	RoundingMode            *string                   `json:"roundingMode,omitempty" binding:"omitempty,oneof=none ceil"`
+	MaxRequestsPerYear      *int                      `json:"maxRequestsPerYear,omitempty" binding:"omitempty,min=0,max=365"`
+	CountRejectedRequests   *bool                     `json:"countRejectedRequests,omitempty"`
+	Timezone                *string                   `json:"timezone,omitempty" binding:"omitempty,max=64"`
+	MaxFutureDays           *int                      `json:"maxFutureDays,omitempty" binding:"omitempty,min=0,max=3650"`
+	RemoteBlocksLeave       *bool                     `json:"remoteBlocksLeave,omitempty"`
+}
+
+// ApprovalStepRequest represents a single level of the approval chain
+type ApprovalStepRequest struct {
+	Name       string  `json:"name" binding:"required,max=50"`
+	ApproverID *string `json:"approverId,omitempty"`
+}
+
+// RejectionReasonRequest represents a predefined rejection reason
+type RejectionReasonRequest struct {
+	Code  string `json:"code" binding:"required,max=50"`
+	Label string `json:"label" binding:"required,max=200"`
 }
 
 // WeekendPolicyRequest represents weekend policy settings
 type WeekendPolicyRequest struct {
 	ExcludeWeekends *bool  `json:"excludeWeekends,omitempty"`
-	ExcludedDays    *[]int `json:"excludedDays,omitempty"`
+	ExcludedDays    *[]int `json:"excludedDays,omitempty" binding:"omitempty,dive,min=0,max=6"` // 0 = Sunday, 6 = Saturday
 }
 
 // NewsletterConfigRequest represents newsletter settings
@@ -92,6 +219,15 @@ type NewsletterConfigRequest struct {
 	Enabled    *bool   `json:"enabled,omitempty"`
 	Frequency  *string `json:"frequency,omitempty" binding:"omitempty,oneof=weekly monthly"`
 	DayOfMonth *int    `json:"dayOfMonth,omitempty" binding:"omitempty,min=1,max=28"`
+	DayOfWeek  *int    `json:"dayOfWeek,omitempty" binding:"omitempty,min=0,max=6"` // 0 = Sunday, 6 = Saturday
+	Hour       *int    `json:"hour,omitempty" binding:"omitempty,min=0,max=23"`
+}
+
+// DigestSectionsRequest chooses which sections the newsletter digest includes
+type DigestSectionsRequest struct {
+	MonthlyStats      *bool `json:"monthlyStats,omitempty"`
+	UpcomingVacations *bool `json:"upcomingVacations,omitempty"`
+	LowBalance        *bool `json:"lowBalance,omitempty"`
 }
 
 // ============================================
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"vacaytracker-api/internal/domain"
 )
 
@@ -10,36 +12,60 @@ import (
 
 // LoginResponse represents the login response
 type LoginResponse struct {
-	Token string        `json:"token"`
-	User  *UserResponse `json:"user"`
+	Token        string        `json:"token"`
+	RefreshToken string        `json:"refreshToken"`
+	User         *UserResponse `json:"user"`
 }
 
 // UserResponse represents a user in API responses
 type UserResponse struct {
-	ID               string                  `json:"id"`
-	Email            string                  `json:"email"`
-	Name             string                  `json:"name"`
-	Role             string                  `json:"role"`
-	VacationBalance  int                     `json:"vacationBalance"`
-	StartDate        *string                 `json:"startDate,omitempty"`
-	EmailPreferences domain.EmailPreferences `json:"emailPreferences"`
-	CreatedAt        string                  `json:"createdAt"`
-	UpdatedAt        string                  `json:"updatedAt"`
+	ID                 string                  `json:"id"`
+	Email              string                  `json:"email"`
+	Name               string                  `json:"name"`
+	Role               string                  `json:"role"`
+	VacationBalance    int                     `json:"vacationBalance"`
+	StartDate          *string                 `json:"startDate,omitempty"`
+	EmailPreferences   domain.EmailPreferences `json:"emailPreferences"`
+	MustChangePassword bool                    `json:"mustChangePassword"`
+	ManagerID          *string                 `json:"managerId,omitempty"`
+	TeamID             *string                 `json:"teamId,omitempty"`
+	DeletedAt          *string                 `json:"deletedAt,omitempty"`
+	Active             bool                    `json:"active"`
+	Locale             string                  `json:"locale"`
+	PendingEmail       *string                 `json:"pendingEmail,omitempty"`
+	LastLoginAt        *string                 `json:"lastLoginAt,omitempty"` // Absent if the user never logged in
+	CreatedAt          string                  `json:"createdAt"`
+	UpdatedAt          string                  `json:"updatedAt"`
 }
 
 // ToUserResponse converts a domain User to UserResponse
 func ToUserResponse(user *domain.User) *UserResponse {
-	return &UserResponse{
-		ID:               user.ID,
-		Email:            user.Email,
-		Name:             user.Name,
-		Role:             string(user.Role),
-		VacationBalance:  user.VacationBalance,
-		StartDate:        user.StartDate,
-		EmailPreferences: user.EmailPreferences,
-		CreatedAt:        user.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:        user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	resp := &UserResponse{
+		ID:                 user.ID,
+		Email:              user.Email,
+		Name:               user.Name,
+		Role:               string(user.Role),
+		VacationBalance:    user.VacationBalance,
+		StartDate:          user.StartDate,
+		EmailPreferences:   user.EmailPreferences,
+		MustChangePassword: user.MustChangePassword,
+		ManagerID:          user.ManagerID,
+		TeamID:             user.TeamID,
+		Active:             user.Active,
+		Locale:             user.LocaleOrDefault(),
+		PendingEmail:       user.PendingEmail,
+		CreatedAt:          user.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:          user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if user.DeletedAt != nil {
+		deletedAt := user.DeletedAt.Format("2006-01-02T15:04:05Z")
+		resp.DeletedAt = &deletedAt
 	}
+	if user.LastLoginAt != nil {
+		lastLoginAt := user.LastLoginAt.Format("2006-01-02T15:04:05Z")
+		resp.LastLoginAt = &lastLoginAt
+	}
+	return resp
 }
 
 // ============================================
@@ -47,9 +73,18 @@ func ToUserResponse(user *domain.User) *UserResponse {
 // ============================================
 
 // UserListResponse represents a paginated list of users
+// In cursor mode Pagination is omitted and NextCursor continues the listing
 type UserListResponse struct {
 	Users      []*UserResponse `json:"users"`
-	Pagination *PaginationInfo `json:"pagination"`
+	Pagination *PaginationInfo `json:"pagination,omitempty"`
+	Sort       *SortInfo       `json:"sort"`
+	NextCursor *string         `json:"nextCursor,omitempty"` // Pass as ?after= for the next page; absent on the last page
+}
+
+// SortInfo represents the ordering applied to a list
+type SortInfo struct {
+	Field string `json:"field"`
+	Order string `json:"order"` // "asc" or "desc"
 }
 
 // PaginationInfo represents pagination metadata
@@ -66,38 +101,60 @@ type PaginationInfo struct {
 
 // VacationRequestResponse represents a vacation request in API responses
 type VacationRequestResponse struct {
-	ID              string  `json:"id"`
-	UserID          string  `json:"userId"`
-	UserName        string  `json:"userName,omitempty"`
-	UserEmail       string  `json:"userEmail,omitempty"`
-	StartDate       string  `json:"startDate"`
-	EndDate         string  `json:"endDate"`
-	TotalDays       int     `json:"totalDays"`
-	Reason          *string `json:"reason,omitempty"`
-	Status          string  `json:"status"`
-	ReviewedBy      *string `json:"reviewedBy,omitempty"`
-	ReviewedAt      *string `json:"reviewedAt,omitempty"`
-	RejectionReason *string `json:"rejectionReason,omitempty"`
-	CreatedAt       string  `json:"createdAt"`
-	UpdatedAt       string  `json:"updatedAt"`
+	ID                    string              `json:"id"`
+	UserID                string              `json:"userId"`
+	UserName              string              `json:"userName,omitempty"`
+	UserEmail             string              `json:"userEmail,omitempty"`
+	StartDate             string              `json:"startDate"`
+	EndDate               string              `json:"endDate"`
+	TotalDays             int                 `json:"totalDays"`
+	Reason                *string             `json:"reason,omitempty"`
+	Status                string              `json:"status"`
+	Type                  string              `json:"type"`
+	ReviewedBy            *string             `json:"reviewedBy,omitempty"`
+	ReviewedByName        string              `json:"reviewedByName,omitempty"`
+	ReviewedAt            *string             `json:"reviewedAt,omitempty"`
+	RejectionReason       *string             `json:"rejectionReason,omitempty"`
+	ApprovalComment       *string             `json:"approvalComment,omitempty"`
+	BalanceOverrideReason *string             `json:"balanceOverrideReason,omitempty"`
+	OriginalStartDate     *string             `json:"originalStartDate,omitempty"` // Submitted range when approved with adjusted dates
+	OriginalEndDate       *string             `json:"originalEndDate,omitempty"`
+	ApprovalStep          int                 `json:"approvalStep"`
+	OverlapWarning        bool                `json:"overlapWarning,omitempty"`
+	DayBreakdown          []domain.RequestDay `json:"dayBreakdown,omitempty"` // Each date and whether it counts; only on create and get
+	BalanceAfter          *int                `json:"balanceAfter,omitempty"` // Balance left if approved; only set while under review
+	Comments              []*CommentResponse  `json:"comments,omitempty"`     // Only loaded with includeComments; omitted when the thread is empty
+	Warnings              []string            `json:"warnings,omitempty"`     // Advisory messages from create and review; the request went through regardless
+	CreatedAt             string              `json:"createdAt"`
+	UpdatedAt             string              `json:"updatedAt"`
 }
 
 // ToVacationRequestResponse converts a domain VacationRequest to response
 func ToVacationRequestResponse(req *domain.VacationRequest) *VacationRequestResponse {
 	resp := &VacationRequestResponse{
-		ID:              req.ID,
-		UserID:          req.UserID,
-		UserName:        req.UserName,
-		UserEmail:       req.UserEmail,
-		StartDate:       req.StartDate,
-		EndDate:         req.EndDate,
-		TotalDays:       req.TotalDays,
-		Reason:          req.Reason,
-		Status:          string(req.Status),
-		ReviewedBy:      req.ReviewedBy,
-		RejectionReason: req.RejectionReason,
-		CreatedAt:       req.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:       req.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:                    req.ID,
+		UserID:                req.UserID,
+		UserName:              req.UserName,
+		UserEmail:             req.UserEmail,
+		StartDate:             req.StartDate,
+		EndDate:               req.EndDate,
+		TotalDays:             req.TotalDays,
+		Reason:                req.Reason,
+		Status:                string(req.Status),
+		Type:                  string(req.Type),
+		ReviewedBy:            req.ReviewedBy,
+		ReviewedByName:        req.ReviewedByName,
+		RejectionReason:       req.RejectionReason,
+		ApprovalComment:       req.ApprovalComment,
+		BalanceOverrideReason: req.BalanceOverrideReason,
+		OriginalStartDate:     req.OriginalStartDate,
+		OriginalEndDate:       req.OriginalEndDate,
+		ApprovalStep:          req.ApprovalStep,
+		OverlapWarning:        req.OverlapWarning,
+		Warnings:              req.Warnings,
+		DayBreakdown:          req.DayBreakdown,
+		CreatedAt:             req.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:             req.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 
 	if req.ReviewedAt != nil {
@@ -108,10 +165,111 @@ func ToVacationRequestResponse(req *domain.VacationRequest) *VacationRequestResp
 	return resp
 }
 
+// ToVacationRequestResponseWithBalance converts a request whose owner currently has currentBalance days
+// BalanceAfter is only set while the request is under review, since approved requests are already deducted,
+// and never for remote days, which don't deduct anything
+func ToVacationRequestResponseWithBalance(req *domain.VacationRequest, currentBalance int) *VacationRequestResponse {
+	resp := ToVacationRequestResponse(req)
+	if req.IsUnderReview() && !req.IsRemote() {
+		balanceAfter := currentBalance - req.TotalDays
+		resp.BalanceAfter = &balanceAfter
+	}
+	return resp
+}
+
+// CommentResponse represents a comment on a vacation request
+type CommentResponse struct {
+	ID         string `json:"id"`
+	RequestID  string `json:"requestId"`
+	AuthorID   string `json:"authorId"`
+	AuthorName string `json:"authorName,omitempty"`
+	Body       string `json:"body"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+// ToCommentResponse converts a domain RequestComment to response
+func ToCommentResponse(comment *domain.RequestComment) *CommentResponse {
+	return &CommentResponse{
+		ID:         comment.ID,
+		RequestID:  comment.RequestID,
+		AuthorID:   comment.AuthorID,
+		AuthorName: comment.AuthorName,
+		Body:       comment.Body,
+		CreatedAt:  comment.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// ToCommentResponses converts a comment thread to responses
+func ToCommentResponses(comments []*domain.RequestComment) []*CommentResponse {
+	responses := make([]*CommentResponse, len(comments))
+	for i, comment := range comments {
+		responses[i] = ToCommentResponse(comment)
+	}
+	return responses
+}
+
+// CommentListResponse represents the comment thread of a request
+type CommentListResponse struct {
+	Comments []*CommentResponse `json:"comments"`
+	Total    int                `json:"total"`
+}
+
+// AttachmentResponse represents a file uploaded to a vacation request
+type AttachmentResponse struct {
+	ID          string `json:"id"`
+	RequestID   string `json:"requestId"`
+	UploadedBy  string `json:"uploadedBy"`
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+// ToAttachmentResponse converts a domain Attachment to response
+func ToAttachmentResponse(attachment *domain.Attachment) *AttachmentResponse {
+	return &AttachmentResponse{
+		ID:          attachment.ID,
+		RequestID:   attachment.RequestID,
+		UploadedBy:  attachment.UploadedBy,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		CreatedAt:   attachment.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// AttachmentListResponse represents the attachments of a request
+type AttachmentListResponse struct {
+	Attachments []*AttachmentResponse `json:"attachments"`
+	Total       int                   `json:"total"`
+}
+
 // VacationListResponse represents a list of vacation requests
 type VacationListResponse struct {
-	Requests []*VacationRequestResponse `json:"requests"`
-	Total    int                        `json:"total"`
+	Requests         []*VacationRequestResponse `json:"requests"`
+	Total            int                        `json:"total"`
+	Count            *int                       `json:"count,omitempty"`            // Pending list only: requests in the full result set
+	SummaryTotalDays *int                       `json:"summaryTotalDays,omitempty"` // Pending list only: sum of their total days
+}
+
+// WorkingDaysResponse classifies each date of a range for date pickers
+type WorkingDaysResponse struct {
+	From string              `json:"from"`
+	To   string              `json:"to"`
+	Days []domain.RequestDay `json:"days"` // business days count against the balance, weekend days don't
+}
+
+// VacationSuggestion represents a conflict-free date range that could be requested
+type VacationSuggestion struct {
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	TotalDays int    `json:"totalDays"` // Charged in the balance unit, as a request for the range would be
+}
+
+// VacationSuggestionsResponse represents suggested date ranges
+type VacationSuggestionsResponse struct {
+	Suggestions []*VacationSuggestion `json:"suggestions"`
+	Total       int                   `json:"total"`
 }
 
 // TeamVacationResponse represents team vacation data for calendar
@@ -119,16 +277,77 @@ type TeamVacationResponse struct {
 	Vacations []*TeamVacationItem `json:"vacations"`
 	Month     int                 `json:"month"`
 	Year      int                 `json:"year"`
+	TeamID    string              `json:"teamId,omitempty"` // Empty when showing the whole company
 }
 
 // TeamVacationItem represents a single team vacation entry
+// Status is approved, or pending/awaiting_final for tentative entries when pending requests were asked for
 type TeamVacationItem struct {
-	ID        string `json:"id"`
+	ID        string                `json:"id"`
+	UserID    string                `json:"userId"`
+	UserName  string                `json:"userName"`
+	StartDate string                `json:"startDate"`
+	EndDate   string                `json:"endDate"`
+	TotalDays int                   `json:"totalDays"`
+	Status    domain.VacationStatus `json:"status"`
+	Type      domain.RequestType    `json:"type"`
+}
+
+// GanttResponse represents team leave laid out for a Gantt chart
+type GanttResponse struct {
+	From    string         `json:"from"`
+	To      string         `json:"to"`
+	Days    int            `json:"days"` // Calendar days in the range
+	Members []*GanttMember `json:"members"`
+}
+
+// GanttMember represents one team member's row in the Gantt chart
+type GanttMember struct {
+	UserID   string          `json:"userId"`
+	UserName string          `json:"userName"`
+	Segments []*GanttSegment `json:"segments"`
+}
+
+// GanttSegment represents a single approved vacation bar
+// Offset and Width are fractions (0-1) of the requested range, after clipping to it
+type GanttSegment struct {
+	ID        string             `json:"id"`
+	StartDate string             `json:"startDate"`
+	EndDate   string             `json:"endDate"`
+	TotalDays int                `json:"totalDays"`
+	Type      domain.RequestType `json:"type"`
+	Offset    float64            `json:"offset"`
+	Width     float64            `json:"width"`
+}
+
+// TeamWeeksResponse represents a month of team leave grouped into ISO weeks for a calendar grid
+type TeamWeeksResponse struct {
+	Month  int         `json:"month"`
+	Year   int         `json:"year"`
+	TeamID string      `json:"teamId,omitempty"` // Empty when showing the whole company
+	Weeks  []*TeamWeek `json:"weeks"`
+}
+
+// TeamWeek represents one ISO week; the first and last weeks only hold the days inside the month
+type TeamWeek struct {
+	Year int            `json:"year"` // ISO year, which can differ from the calendar year around New Year
+	Week int            `json:"week"`
+	Days []*TeamWeekDay `json:"days"`
+}
+
+// TeamWeekDay represents the employees off on one date
+// Weekend days never list anyone, as they are not part of a request's leave
+type TeamWeekDay struct {
+	Date        string             `json:"date"` // YYYY-MM-DD
+	BusinessDay bool               `json:"businessDay"`
+	Off         []*TeamWeekAbsence `json:"off"`
+}
+
+// TeamWeekAbsence represents one employee off on a date
+type TeamWeekAbsence struct {
 	UserID    string `json:"userId"`
 	UserName  string `json:"userName"`
-	StartDate string `json:"startDate"`
-	EndDate   string `json:"endDate"`
-	TotalDays int    `json:"totalDays"`
+	RequestID string `json:"requestId"`
 }
 
 // ============================================
@@ -137,26 +356,130 @@ type TeamVacationItem struct {
 
 // SettingsResponse represents application settings
 type SettingsResponse struct {
-	ID                  string                   `json:"id"`
-	WeekendPolicy       domain.WeekendPolicy     `json:"weekendPolicy"`
-	Newsletter          domain.NewsletterConfig  `json:"newsletter"`
-	DefaultVacationDays int                      `json:"defaultVacationDays"`
-	VacationResetMonth  int                      `json:"vacationResetMonth"`
-	UpdatedAt           string                   `json:"updatedAt"`
+	ID                      string                   `json:"id"`
+	WeekendPolicy           domain.WeekendPolicy     `json:"weekendPolicy"`
+	Newsletter              domain.NewsletterConfig  `json:"newsletter"`
+	DigestSections          domain.DigestSections    `json:"digestSections"`
+	DefaultVacationDays     int                      `json:"defaultVacationDays"`
+	VacationResetMonth      int                      `json:"vacationResetMonth"`
+	ApprovalLevels          []domain.ApprovalStep    `json:"approvalLevels"`
+	MinNoticeDays           int                      `json:"minNoticeDays"`
+	ApprovalCommentRequired bool                     `json:"approvalCommentRequired"`
+	MaxConsecutiveDays      int                      `json:"maxConsecutiveDays"`
+	BlackoutPeriods         []domain.BlackoutPeriod  `json:"blackoutPeriods"`
+	LongVacationDays        int                      `json:"longVacationDays"`
+	CoolOffDays             int                      `json:"coolOffDays"`
+	AccrualEnabled          bool                     `json:"accrualEnabled"`
+	AccrualDaysPerMonth     int                      `json:"accrualDaysPerMonth"`
+	LastAccrualMonth        string                   `json:"lastAccrualMonth,omitempty"`
+	AllowNegativeBalance    bool                     `json:"allowNegativeBalance"`
+	MaxOverdrawDays         int                      `json:"maxOverdrawDays"`
+	WebhookURL              string                   `json:"webhookUrl"`
+	MinCoverage             int                      `json:"minCoverage"`
+	OverlapPolicy           string                   `json:"overlapPolicy"`
+	OverlapAllowTouching    bool                     `json:"overlapAllowTouching"`
+	RejectionReasons        []domain.RejectionReason `json:"rejectionReasons"`
+	DefaultNewUserRole      string                   `json:"defaultNewUserRole"`
+	BalanceUnit             string                   `json:"balanceUnit"` // Unit of every balance and request total: days or hours
+	HoursPerDay             int                      `json:"hoursPerDay"`
+	LowBalanceThreshold     int                      `json:"lowBalanceThreshold"`
+	RoundingMode            string                   `json:"roundingMode"` // How business days are rounded before they are charged: none or ceil
+	MaxRequestsPerYear      int                      `json:"maxRequestsPerYear"`
+	CountRejectedRequests   bool                     `json:"countRejectedRequests"`
+	Timezone                string                   `json:"timezone"` // IANA zone deciding today's date for request checks
+	MaxFutureDays           int                      `json:"maxFutureDays"`
+	RemoteBlocksLeave       bool                     `json:"remoteBlocksLeave"`
+	NextNewsletterAt        *string                  `json:"nextNewsletterAt"` // Next scheduled digest send; null when disabled
+	UpdatedAt               string                   `json:"updatedAt"`
 }
 
 // ToSettingsResponse converts domain Settings to response
 func ToSettingsResponse(settings *domain.Settings) *SettingsResponse {
+	approvalLevels := settings.ApprovalLevels
+	if approvalLevels == nil {
+		approvalLevels = []domain.ApprovalStep{}
+	}
+	blackoutPeriods := settings.BlackoutPeriods
+	if blackoutPeriods == nil {
+		blackoutPeriods = []domain.BlackoutPeriod{}
+	}
+	rejectionReasons := settings.RejectionReasons
+	if rejectionReasons == nil {
+		rejectionReasons = []domain.RejectionReason{}
+	}
+	var nextNewsletterAt *string
+	if next := settings.Newsletter.NextSendAt(time.Now()); next != nil {
+		formatted := next.Format(time.RFC3339)
+		nextNewsletterAt = &formatted
+	}
+
 	return &SettingsResponse{
-		ID:                  settings.ID,
-		WeekendPolicy:       settings.WeekendPolicy,
-		Newsletter:          settings.Newsletter,
-		DefaultVacationDays: settings.DefaultVacationDays,
-		VacationResetMonth:  settings.VacationResetMonth,
-		UpdatedAt:           settings.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:                      settings.ID,
+		WeekendPolicy:           settings.WeekendPolicy,
+		Newsletter:              settings.Newsletter,
+		DigestSections:          settings.DigestSections,
+		DefaultVacationDays:     settings.DefaultVacationDays,
+		VacationResetMonth:      settings.VacationResetMonth,
+		ApprovalLevels:          approvalLevels,
+		MinNoticeDays:           settings.MinNoticeDays,
+		ApprovalCommentRequired: settings.ApprovalCommentRequired,
+		MaxConsecutiveDays:      settings.MaxConsecutiveDays,
+		BlackoutPeriods:         blackoutPeriods,
+		LongVacationDays:        settings.LongVacationDays,
+		CoolOffDays:             settings.CoolOffDays,
+		AccrualEnabled:          settings.AccrualEnabled,
+		AccrualDaysPerMonth:     settings.AccrualDaysPerMonth,
+		LastAccrualMonth:        settings.LastAccrualMonth,
+		AllowNegativeBalance:    settings.AllowNegativeBalance,
+		MaxOverdrawDays:         settings.MaxOverdrawDays,
+		WebhookURL:              settings.WebhookURL,
+		MinCoverage:             settings.MinCoverage,
+		OverlapPolicy:           string(settings.OverlapPolicy),
+		OverlapAllowTouching:    settings.OverlapAllowTouching,
+		RejectionReasons:        rejectionReasons,
+		DefaultNewUserRole:      string(settings.DefaultNewUserRole),
+		BalanceUnit:             string(settings.BalanceUnit),
+		HoursPerDay:             settings.HoursPerDay,
+		LowBalanceThreshold:     settings.LowBalanceThreshold,
+		RoundingMode:            string(settings.RoundingMode),
+		MaxRequestsPerYear:      settings.MaxRequestsPerYear,
+		CountRejectedRequests:   settings.CountRejectedRequests,
+		Timezone:                settings.Timezone,
+		MaxFutureDays:           settings.MaxFutureDays,
+		RemoteBlocksLeave:       settings.RemoteBlocksLeave,
+		NextNewsletterAt:        nextNewsletterAt,
+		UpdatedAt:               settings.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 }
 
+// CoverageResponse represents who is off on each date of a range
+type CoverageResponse struct {
+	From   string         `json:"from"`
+	To     string         `json:"to"`
+	TeamID string         `json:"teamId,omitempty"`
+	Days   []*CoverageDay `json:"days"` // One entry per calendar date, in order
+}
+
+// CoverageDay represents the employees with approved leave on one date
+type CoverageDay struct {
+	Date        string   `json:"date"` // YYYY-MM-DD
+	BusinessDay bool     `json:"businessDay"`
+	Count       int      `json:"count"`
+	Names       []string `json:"names"`
+}
+
+// BlackoutListResponse represents the configured blackout periods
+type BlackoutListResponse struct {
+	Blackouts []domain.BlackoutPeriod `json:"blackouts"`
+	Total     int                     `json:"total"`
+}
+
+// TeamListResponse represents the configured teams
+type TeamListResponse struct {
+	Teams []*domain.Team `json:"teams"`
+	Total int            `json:"total"`
+}
+
 // ============================================
 // Newsletter Responses
 // ============================================
@@ -185,6 +508,32 @@ type TestEmailResponse struct {
 	Message  string `json:"message"`
 }
 
+// EmailLogResponse represents recently queued emails and their delivery status
+type EmailLogResponse struct {
+	Emails []*domain.EmailMessage `json:"emails"`
+	Total  int                    `json:"total"`
+}
+
+// AuditLogResponse represents a page of the admin audit log
+type AuditLogResponse struct {
+	Entries    []*domain.AuditEntry `json:"entries"`
+	Pagination *PaginationInfo      `json:"pagination"`
+}
+
+// EmailValidationResponse reports the result of rendering every email template with sample data
+type EmailValidationResponse struct {
+	Valid   bool                  `json:"valid"`
+	Checked int                   `json:"checked"` // Template renders and subject lines checked
+	Errors  []*EmailTemplateError `json:"errors"`
+}
+
+// EmailTemplateError describes one email template that failed to parse or render
+type EmailTemplateError struct {
+	Template string `json:"template"`
+	Sample   string `json:"sample,omitempty"` // Sample data set that failed; omitted for parse errors
+	Error    string `json:"error"`
+}
+
 // EmailPreviewResponse represents a preview of an email template
 type EmailPreviewResponse struct {
 	Template string `json:"template"`
@@ -202,9 +551,198 @@ type ResetBalancesResponse struct {
 	Success      bool   `json:"success"`
 	UsersUpdated int    `json:"usersUpdated"`
 	NewBalance   int    `json:"newBalance"`
+	LeaveYear    int    `json:"leaveYear"` // Leave year the reset balance applies to
 	Message      string `json:"message"`
 }
 
+// RecomputeTotalDaysResponse reports a pending request's total before and after recomputing it
+type RecomputeTotalDaysResponse struct {
+	RequestID         string `json:"requestId"`
+	PreviousTotalDays int    `json:"previousTotalDays"`
+	NewTotalDays      int    `json:"newTotalDays"`
+}
+
+// ResetPreviewResponse shows the balances a reset would leave employees with, without applying it
+type ResetPreviewResponse struct {
+	NewBalance int                  `json:"newBalance"`
+	LeaveYear  int                  `json:"leaveYear"`
+	Balances   []*BalanceAdjustment `json:"balances"`
+}
+
+// BalanceAdjustment reports one user's balance after a bulk adjustment
+type BalanceAdjustment struct {
+	UserID          string `json:"userId"`
+	Name            string `json:"name"`
+	PreviousBalance int    `json:"previousBalance"`
+	NewBalance      int    `json:"newBalance"`
+}
+
+// AdjustBalancesResponse represents the result of a bulk balance adjustment
+type AdjustBalancesResponse struct {
+	Delta    int                  `json:"delta"`
+	Reason   string               `json:"reason"`
+	Balances []*BalanceAdjustment `json:"balances"`
+}
+
+// ProratedBalanceResponse represents the pro-rated entitlement for a given start date
+type ProratedBalanceResponse struct {
+	StartDate      string `json:"startDate"`
+	LeaveYearStart string `json:"leaveYearStart"`
+	AnnualDays     int    `json:"annualDays"`
+	ProratedDays   int    `json:"proratedDays"`
+}
+
+// BalanceDiscrepancy represents a user whose stored balance disagrees with the ledger
+type BalanceDiscrepancy struct {
+	UserID        string `json:"userId"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	StoredBalance int    `json:"storedBalance"`
+	LedgerBalance int    `json:"ledgerBalance"`
+	Delta         int    `json:"delta"` // storedBalance - ledgerBalance
+}
+
+// BalanceReconcileResponse represents the result of a balance reconciliation
+type BalanceReconcileResponse struct {
+	Discrepancies []*BalanceDiscrepancy `json:"discrepancies"`
+	UsersChecked  int                   `json:"usersChecked"`
+	Total         int                   `json:"total"`
+}
+
+// EmployeeBalanceItem represents one employee in the balances overview
+type EmployeeBalanceItem struct {
+	UserID   string `json:"userId"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Balance  int    `json:"balance"`
+	DaysUsed int    `json:"daysUsed"` // Approved, for requests starting in the year
+}
+
+// EmployeeBalancesResponse represents every active employee's remaining balance
+type EmployeeBalancesResponse struct {
+	Year        int                    `json:"year"`
+	BalanceUnit string                 `json:"balanceUnit"`
+	Employees   []*EmployeeBalanceItem `json:"employees"`
+	Total       int                    `json:"total"`
+}
+
+// NotificationRecipient represents an admin and whether they get new-request notifications
+type NotificationRecipient struct {
+	UserID     string `json:"userId"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Receives   bool   `json:"receives"`
+	SkipReason string `json:"skipReason,omitempty"` // Why the admin is left out, when Receives is false
+}
+
+// NotificationRecipientsResponse lists every admin and whether they are notified of new requests
+type NotificationRecipientsResponse struct {
+	Admins     []*NotificationRecipient `json:"admins"`
+	Recipients int                      `json:"recipients"` // Admins with Receives set
+}
+
+// LeaveStatementResponse represents a user's leave entitlement statement for one year
+// OpeningBalance + Grants - Taken + Carryover = ClosingBalance
+type LeaveStatementResponse struct {
+	UserID         string                     `json:"userId"`
+	UserName       string                     `json:"userName"`
+	Year           int                        `json:"year"`
+	OpeningBalance int                        `json:"openingBalance"` // Ledger balance at the start of the year
+	Grants         int                        `json:"grants"`         // Opening entries and admin adjustments during the year
+	Taken          int                        `json:"taken"`          // Days deducted for approved requests
+	Carryover      int                        `json:"carryover"`      // Net change from yearly resets (carried over or forfeited)
+	ClosingBalance int                        `json:"closingBalance"`
+	Requests       []*VacationRequestResponse `json:"requests"` // Approved requests starting in the year
+}
+
+// ============================================
+// Report Responses
+// ============================================
+
+// StatsResponse represents aggregated vacation request statistics
+// DaysUsed is the sum of total days of approved requests
+type StatsResponse struct {
+	TotalSubmitted int `json:"totalSubmitted"`
+	Approved       int `json:"approved"`
+	Rejected       int `json:"rejected"`
+	Pending        int `json:"pending"`
+	DaysUsed       int `json:"daysUsed"`
+}
+
+// MonthStatsResponse represents the statistics for one month of a year
+type MonthStatsResponse struct {
+	Month int `json:"month"` // 1-12
+	StatsResponse
+}
+
+// YearlyStatsResponse represents vacation request statistics for a year, by request creation date
+type YearlyStatsResponse struct {
+	Year int `json:"year"`
+	StatsResponse
+	Months []MonthStatsResponse `json:"months"` // Always 12 entries, January first
+}
+
+// AnnualReportResponse represents a per-employee yearly vacation report
+// Counts cover requests starting in the year; tentative requests are not included
+type AnnualReportResponse struct {
+	UserID           string                     `json:"userId"`
+	UserName         string                     `json:"userName"`
+	Year             int                        `json:"year"`
+	TotalRequested   int                        `json:"totalRequested"`
+	Approved         int                        `json:"approved"`
+	Rejected         int                        `json:"rejected"`
+	Pending          int                        `json:"pending"`
+	DaysUsed         int                        `json:"daysUsed"`         // Approved days
+	RemainingBalance int                        `json:"remainingBalance"` // Current vacation balance
+	Months           []MonthUsageResponse       `json:"months"`           // Always 12 entries, January first
+	Requests         []*VacationRequestResponse `json:"requests"`         // Approved requests, for attendance
+}
+
+// MonthUsageResponse represents approved vacation days attributed to one month
+type MonthUsageResponse struct {
+	Month    int `json:"month"` // 1-12
+	DaysUsed int `json:"daysUsed"`
+}
+
+// AdminDashboardResponse represents the admin dashboard summary
+type AdminDashboardResponse struct {
+	TotalUsers          int                    `json:"totalUsers"`
+	TotalEmployees      int                    `json:"totalEmployees"`
+	PendingRequests     int                    `json:"pendingRequests"`
+	DaysUsedThisMonth   int                    `json:"daysUsedThisMonth"`
+	UpcomingFrom        string                 `json:"upcomingFrom"` // YYYY-MM-DD, today
+	UpcomingTo          string                 `json:"upcomingTo"`   // YYYY-MM-DD, inclusive
+	UpcomingVacations   []*domain.TeamVacation `json:"upcomingVacations"`
+	LowBalanceCount     int                    `json:"lowBalanceCount"` // Employees at or below the threshold
+	LowBalanceThreshold int                    `json:"lowBalanceThreshold"`
+}
+
+// ComplianceReportResponse represents a consolidated compliance report for a period
+type ComplianceReportResponse struct {
+	From                   string                 `json:"from"`
+	To                     string                 `json:"to"`
+	TotalRequests          int                    `json:"totalRequests"`
+	Approved               int                    `json:"approved"`
+	Rejected               int                    `json:"rejected"`
+	Pending                int                    `json:"pending"`
+	ApprovalRate           float64                `json:"approvalRate"` // approved / (approved + rejected)
+	AverageTurnaroundHours float64                `json:"averageTurnaroundHours"`
+	Rejections             []*ComplianceRejection `json:"rejections"`
+}
+
+// ComplianceRejection represents a rejected request in the compliance report
+type ComplianceRejection struct {
+	RequestID  string  `json:"requestId"`
+	UserID     string  `json:"userId"`
+	UserName   string  `json:"userName"`
+	StartDate  string  `json:"startDate"`
+	EndDate    string  `json:"endDate"`
+	TotalDays  int     `json:"totalDays"`
+	Reason     *string `json:"reason,omitempty"`
+	ReviewedBy *string `json:"reviewedBy,omitempty"`
+	ReviewedAt *string `json:"reviewedAt,omitempty"`
+}
+
 // ============================================
 // Generic Responses
 // ============================================
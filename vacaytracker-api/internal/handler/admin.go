@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -27,6 +31,9 @@ type AdminHandler struct {
 	settingsRepo      repository.SettingsRepository
 	emailService      *service.EmailService
 	newsletterService *service.NewsletterService
+	reportService     *service.ReportService
+	webhookService    *service.WebhookService
+	auditService      *service.AuditService
 }
 
 // NewAdminHandler creates a new AdminHandler
@@ -39,6 +46,9 @@ func NewAdminHandler(
 	settingsRepo repository.SettingsRepository,
 	emailService *service.EmailService,
 	newsletterService *service.NewsletterService,
+	reportService *service.ReportService,
+	webhookService *service.WebhookService,
+	auditService *service.AuditService,
 ) *AdminHandler {
 	return &AdminHandler{
 		cfg:               cfg,
@@ -49,6 +59,9 @@ func NewAdminHandler(
 		settingsRepo:      settingsRepo,
 		emailService:      emailService,
 		newsletterService: newsletterService,
+		reportService:     reportService,
+		webhookService:    webhookService,
+		auditService:      auditService,
 	}
 }
 
@@ -57,7 +70,8 @@ func NewAdminHandler(
 // ============================================
 
 // ListUsers handles GET /api/admin/users
-// Lists all users with optional filtering and pagination
+// Lists all users with optional filtering, sorting and pagination
+// Passing ?after= (empty for the first page) switches to cursor pagination ordered by ID
 func (h *AdminHandler) ListUsers(c *gin.Context) {
 	// Parse query parameters
 	var role *domain.Role
@@ -73,7 +87,51 @@ func (h *AdminHandler) ListUsers(c *gin.Context) {
 		role = &roleVal
 	}
 
-	search := c.Query("search")
+	filter := domain.UserFilter{
+		Role:   role,
+		Search: c.Query("search"),
+		Email:  c.Query("email"),
+	}
+	if a := c.Query("active"); a != "" {
+		active, err := strconv.ParseBool(a)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid active. Must be true or false",
+			})
+			return
+		}
+		filter.Active = &active
+	}
+
+	// Default ordering is newest first
+	sort := domain.UserSort{}
+	sortInfo := &dto.SortInfo{Field: string(domain.UserSortCreatedAt), Order: "desc"}
+	if field := c.Query("sort"); field != "" {
+		if !domain.IsValidUserSortField(field) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid sort. Must be name, email, balance, or createdAt",
+			})
+			return
+		}
+		sort.Field = domain.UserSortField(field)
+		sortInfo = &dto.SortInfo{Field: field, Order: "asc"}
+	}
+	if order := c.Query("order"); order != "" {
+		if order != "asc" && order != "desc" {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid order. Must be asc or desc",
+			})
+			return
+		}
+		if sort.Field == "" {
+			sort.Field = domain.UserSortCreatedAt
+		}
+		sort.Descending = order == "desc"
+		sortInfo.Order = order
+	}
 
 	page := 1
 	if p := c.Query("page"); p != "" {
@@ -89,7 +147,19 @@ func (h *AdminHandler) ListUsers(c *gin.Context) {
 		}
 	}
 
-	users, total, err := h.userService.List(c.Request.Context(), role, search, page, limit)
+	if after, ok := c.GetQuery("after"); ok {
+		if c.Query("sort") != "" || c.Query("order") != "" {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Cursor pagination is ordered by id and cannot be combined with sort or order",
+			})
+			return
+		}
+		h.listUsersAfter(c, filter, after, limit)
+		return
+	}
+
+	users, total, err := h.userService.List(c.Request.Context(), filter, sort, page, limit)
 	if err != nil {
 		if appErr, ok := err.(*dto.AppError); ok {
 			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
@@ -118,19 +188,47 @@ func (h *AdminHandler) ListUsers(c *gin.Context) {
 			Total:      total,
 			TotalPages: totalPages,
 		},
+		Sort: sortInfo,
 	})
 }
 
+// listUsersAfter writes one page of the cursor-paginated user listing
+func (h *AdminHandler) listUsersAfter(c *gin.Context, filter domain.UserFilter, after string, limit int) {
+	users, nextCursor, err := h.userService.ListAfter(c.Request.Context(), filter, after, limit)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to list users",
+			})
+		}
+		return
+	}
+
+	responses := make([]*dto.UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = dto.ToUserResponse(user)
+	}
+
+	resp := dto.UserListResponse{
+		Users: responses,
+		Sort:  &dto.SortInfo{Field: "id", Order: "asc"},
+	}
+	if nextCursor != "" {
+		resp.NextCursor = &nextCursor
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
 // CreateUser handles POST /api/admin/users
 // Creates a new user
 func (h *AdminHandler) CreateUser(c *gin.Context) {
 	var req dto.CreateUserRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Code:    dto.ErrValidation,
-			Message: "Invalid request body: " + err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
 		return
 	}
 
@@ -150,6 +248,8 @@ func (h *AdminHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, h.auditService, domain.AuditUserCreate, domain.AuditTargetUser, user.ID, nil, dto.ToUserResponse(user))
+
 	// Send welcome email with temporary password (non-blocking)
 	h.emailService.SendWelcome(user, tempPassword)
 
@@ -185,13 +285,11 @@ func (h *AdminHandler) UpdateUser(c *gin.Context) {
 
 	var req dto.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Code:    dto.ErrValidation,
-			Message: "Invalid request body: " + err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
 		return
 	}
 
+	before := h.auditUserSnapshot(c.Request.Context(), userID)
 	user, err := h.userService.Update(c.Request.Context(), userID, req, currentUserID)
 	if err != nil {
 		if appErr, ok := err.(*dto.AppError); ok {
@@ -205,15 +303,18 @@ func (h *AdminHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, h.auditService, domain.AuditUserUpdate, domain.AuditTargetUser, userID, before, dto.ToUserResponse(user))
+
 	c.JSON(http.StatusOK, dto.ToUserResponse(user))
 }
 
 // DeleteUser handles DELETE /api/admin/users/:id
-// Deletes a user
+// Soft-deletes a user; their vacation history is kept
 func (h *AdminHandler) DeleteUser(c *gin.Context) {
 	userID := c.Param("id")
 	currentUserID := middleware.GetUserID(c)
 
+	before := h.auditUserSnapshot(c.Request.Context(), userID)
 	err := h.userService.Delete(c.Request.Context(), userID, currentUserID)
 	if err != nil {
 		if appErr, ok := err.(*dto.AppError); ok {
@@ -227,11 +328,62 @@ func (h *AdminHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, h.auditService, domain.AuditUserDelete, domain.AuditTargetUser, userID, before, nil)
+
 	c.JSON(http.StatusOK, dto.MessageResponse{
 		Message: "User deleted successfully",
 	})
 }
 
+// UpdateUserStatus handles PUT /api/admin/users/:id/status
+// Activates or deactivates a user
+func (h *AdminHandler) UpdateUserStatus(c *gin.Context) {
+	var req dto.UpdateUserStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
+
+	before := h.auditUserSnapshot(c.Request.Context(), c.Param("id"))
+	user, err := h.userService.SetActive(c.Request.Context(), c.Param("id"), *req.Active, middleware.GetUserID(c))
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to update user status",
+			})
+		}
+		return
+	}
+
+	recordAudit(c, h.auditService, domain.AuditUserStatus, domain.AuditTargetUser, user.ID, before, dto.ToUserResponse(user))
+
+	c.JSON(http.StatusOK, dto.ToUserResponse(user))
+}
+
+// RestoreUser handles POST /api/admin/users/:id/restore
+// Restores a soft-deleted user
+func (h *AdminHandler) RestoreUser(c *gin.Context) {
+	user, err := h.userService.Restore(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to restore user",
+			})
+		}
+		return
+	}
+
+	recordAudit(c, h.auditService, domain.AuditUserRestore, domain.AuditTargetUser, user.ID, nil, dto.ToUserResponse(user))
+
+	c.JSON(http.StatusOK, dto.ToUserResponse(user))
+}
+
 // UpdateBalance handles PUT /api/admin/users/:id/balance
 // Updates a user's vacation balance
 func (h *AdminHandler) UpdateBalance(c *gin.Context) {
@@ -239,13 +391,11 @@ func (h *AdminHandler) UpdateBalance(c *gin.Context) {
 
 	var req dto.UpdateVacationBalanceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Code:    dto.ErrValidation,
-			Message: "Invalid request body: " + err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
 		return
 	}
 
+	before := h.auditUserSnapshot(c.Request.Context(), userID)
 	user, err := h.userService.UpdateBalance(c.Request.Context(), userID, req.VacationBalance)
 	if err != nil {
 		if appErr, ok := err.(*dto.AppError); ok {
@@ -259,6 +409,8 @@ func (h *AdminHandler) UpdateBalance(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, h.auditService, domain.AuditBalanceUpdate, domain.AuditTargetUser, userID, before, dto.ToUserResponse(user))
+
 	c.JSON(http.StatusOK, dto.ToUserResponse(user))
 }
 
@@ -266,10 +418,18 @@ func (h *AdminHandler) UpdateBalance(c *gin.Context) {
 // Vacation Management Endpoints
 // ============================================
 
-// ListPending handles GET /api/admin/vacation/pending
-// Lists all pending vacation requests
+// ListPending handles GET /api/admin/vacation/pending and GET /api/vacation/pending
+// Lists pending vacation requests; managers only see their direct reports
 func (h *AdminHandler) ListPending(c *gin.Context) {
-	requests, err := h.vacationService.ListPending(c.Request.Context())
+	from, to := c.Query("from"), c.Query("to")
+
+	var requests []*domain.VacationRequest
+	var err error
+	if middleware.IsAdmin(c) {
+		requests, err = h.vacationService.ListPending(c.Request.Context(), from, to)
+	} else {
+		requests, err = h.vacationService.ListPendingForManager(c.Request.Context(), middleware.GetUserID(c), from, to)
+	}
 	if err != nil {
 		if appErr, ok := err.(*dto.AppError); ok {
 			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
@@ -282,44 +442,237 @@ func (h *AdminHandler) ListPending(c *gin.Context) {
 		return
 	}
 
-	// Convert to response DTOs
+	// Convert to response DTOs, summing over the full result set
 	responses := make([]*dto.VacationRequestResponse, len(requests))
+	count, totalDays := len(requests), 0
 	for i, req := range requests {
 		responses[i] = dto.ToVacationRequestResponse(req)
+		totalDays += req.TotalDays
 	}
 
 	c.JSON(http.StatusOK, dto.VacationListResponse{
-		Requests: responses,
-		Total:    len(responses),
+		Requests:         responses,
+		Total:            len(responses),
+		Count:            &count,
+		SummaryTotalDays: &totalDays,
 	})
 }
 
-// Review handles PUT /api/admin/vacation/:id/review
-// Approves or rejects a vacation request
+// ListStale handles GET /api/admin/vacation/stale
+// Lists requests awaiting a decision for longer than the given number of days, oldest first
+// Query params: days (optional, 1-365, default 7)
+func (h *AdminHandler) ListStale(c *gin.Context) {
+	days := service.DefaultStaleDays
+	if d := c.Query("days"); d != "" {
+		parsed, err := strconv.Atoi(d)
+		if err != nil || parsed < 1 || parsed > 365 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "days must be a number between 1 and 365",
+			})
+			return
+		}
+		days = parsed
+	}
+
+	requests, err := h.vacationService.ListStale(c.Request.Context(), time.Now(), days)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to list stale requests",
+			})
+		}
+		return
+	}
+
+	responses := make([]*dto.VacationRequestResponse, len(requests))
+	count, totalDays := len(requests), 0
+	for i, req := range requests {
+		responses[i] = dto.ToVacationRequestResponse(req)
+		totalDays += req.TotalDays
+	}
+
+	c.JSON(http.StatusOK, dto.VacationListResponse{
+		Requests:         responses,
+		Total:            len(responses),
+		Count:            &count,
+		SummaryTotalDays: &totalDays,
+	})
+}
+
+// RecomputeTotalDays handles POST /api/admin/vacation/:id/recompute
+// Recalculates a pending request's total days under the current settings
+func (h *AdminHandler) RecomputeTotalDays(c *gin.Context) {
+	requestID := c.Param("id")
+
+	result, err := h.vacationService.RecomputeTotalDays(c.Request.Context(), requestID)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to recompute vacation request",
+			})
+		}
+		return
+	}
+
+	if result.NewTotalDays != result.PreviousTotalDays {
+		recordAudit(c, h.auditService, domain.AuditRequestRecompute, domain.AuditTargetRequest, requestID,
+			gin.H{"totalDays": result.PreviousTotalDays}, gin.H{"totalDays": result.NewTotalDays})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Coverage handles GET /api/admin/vacation/coverage
+// Lists, per date, the employees with approved leave
+// Query params: from, to (required, YYYY-MM-DD), teamId (optional)
+func (h *AdminHandler) Coverage(c *gin.Context) {
+	from := c.Query("from")
+	to := c.Query("to")
+	if from == "" || to == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    dto.ErrValidation,
+			Message: "Both from and to query parameters are required (YYYY-MM-DD)",
+		})
+		return
+	}
+
+	coverage, err := h.vacationService.CoverageForRange(c.Request.Context(), from, to, c.Query("teamId"))
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get coverage",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, coverage)
+}
+
+// Review handles PUT /api/admin/vacation/:id/review and PUT /api/vacation/requests/:id/review
+// Approves or rejects a vacation request; managers may only review their direct reports
 func (h *AdminHandler) Review(c *gin.Context) {
 	requestID := c.Param("id")
 	adminID := middleware.GetUserID(c)
 
 	var req dto.ReviewVacationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Code:    dto.ErrValidation,
-			Message: "Invalid request body: " + err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
 		return
 	}
 
+	// Only admins may override the balance check
+	if req.Force {
+		if !middleware.IsAdmin(c) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Code:    dto.ErrForbidden,
+				Message: "Only admins can override the balance check",
+			})
+			return
+		}
+		if domain.VacationStatus(req.Status) != domain.StatusApproved {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Force only applies to approvals",
+			})
+			return
+		}
+	}
+
+	// Adjusted dates are a counter-offer and need both ends of the range
+	adjustDates := req.StartDate != "" || req.EndDate != ""
+	if adjustDates {
+		if domain.VacationStatus(req.Status) != domain.StatusApproved {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Adjusted dates only apply to approvals",
+			})
+			return
+		}
+		if req.StartDate == "" || req.EndDate == "" {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Both startDate and endDate are required to adjust the dates",
+			})
+			return
+		}
+	}
+
+	// Managers may only review their direct reports
+	if !middleware.IsAdmin(c) {
+		if err := h.vacationService.EnsureManagerOf(c.Request.Context(), adminID, requestID); err != nil {
+			if appErr, ok := err.(*dto.AppError); ok {
+				c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+			} else {
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+					Code:    dto.ErrInternal,
+					Message: "Failed to review request",
+				})
+			}
+			return
+		}
+	}
+
 	var vacation *domain.VacationRequest
 	var err error
 
+	// Rejections may pick a predefined reason, which is stored by its label
+	if req.ReasonCode != "" {
+		if domain.VacationStatus(req.Status) != domain.StatusRejected {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Reason code only applies to rejections",
+			})
+			return
+		}
+		resolved, err := h.vacationService.ResolveRejectionReason(c.Request.Context(), req.ReasonCode, req.Reason)
+		if err != nil {
+			if appErr, ok := err.(*dto.AppError); ok {
+				c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+			} else {
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+					Code:    dto.ErrInternal,
+					Message: "Failed to review request",
+				})
+			}
+			return
+		}
+		req.Reason = resolved
+	}
+
+	var reason *string
+	if req.Reason != "" {
+		reason = &req.Reason
+	}
+
+	before := h.auditRequestSnapshot(c.Request.Context(), requestID)
+	action := domain.AuditRequestApprove
+
 	switch domain.VacationStatus(req.Status) {
 	case domain.StatusApproved:
-		vacation, err = h.vacationService.Approve(c.Request.Context(), requestID, adminID)
-	case domain.StatusRejected:
-		var reason *string
-		if req.Reason != "" {
-			reason = &req.Reason
+		if adjustDates {
+			var overrideReason *string
+			if req.Force {
+				overrideReason = &req.OverrideReason
+			}
+			vacation, err = h.vacationService.ApproveWithDates(c.Request.Context(), requestID, adminID, reason, req.StartDate, req.EndDate, overrideReason)
+		} else if req.Force {
+			vacation, err = h.vacationService.ApproveWithOverride(c.Request.Context(), requestID, adminID, reason, req.OverrideReason)
+		} else {
+			vacation, err = h.vacationService.Approve(c.Request.Context(), requestID, adminID, reason)
 		}
+	case domain.StatusRejected:
+		action = domain.AuditRequestReject
 		vacation, err = h.vacationService.Reject(c.Request.Context(), requestID, adminID, reason)
 	default:
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
@@ -341,14 +694,17 @@ func (h *AdminHandler) Review(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, h.auditService, action, domain.AuditTargetRequest, requestID, before, dto.ToVacationRequestResponse(vacation))
+
 	// Send email notification to the user (non-blocking)
 	// Use background context since the request context is cancelled after the response is sent
-	go h.sendReviewEmail(context.Background(), vacation, req.Status, req.Reason)
+	// Intermediate approvals leave the request awaiting_final, which sends nothing
+	go h.sendReviewEmail(context.Background(), vacation, string(vacation.Status), req.Reason)
 
 	c.JSON(http.StatusOK, dto.ToVacationRequestResponse(vacation))
 }
 
-// sendReviewEmail sends an email after a vacation request is reviewed
+// sendReviewEmail sends an email and the webhook event after a vacation request is reviewed
 func (h *AdminHandler) sendReviewEmail(ctx context.Context, vacation *domain.VacationRequest, status string, reason string) {
 	user, err := h.userRepo.GetByID(ctx, vacation.UserID)
 	if err != nil {
@@ -361,48 +717,440 @@ func (h *AdminHandler) sendReviewEmail(ctx context.Context, vacation *domain.Vac
 
 	switch domain.VacationStatus(status) {
 	case domain.StatusApproved:
+		h.webhookService.Notify(service.WebhookRequestApproved, user, vacation)
 		h.emailService.SendRequestApproved(user, vacation)
+		sendLowBalanceEmails(ctx, h.userRepo, h.emailService, user, vacation)
+		sendTeammateOffEmails(ctx, h.userRepo, h.emailService, user, vacation)
 	case domain.StatusRejected:
+		h.webhookService.Notify(service.WebhookRequestRejected, user, vacation)
 		h.emailService.SendRequestRejected(user, vacation, reason)
 	}
 }
 
-// ============================================
-// Balance Reset Endpoint
-// ============================================
+// ============================================
+// Balance Reset Endpoint
+// ============================================
+
+// ResetBalances handles POST /api/admin/users/reset-balances
+// Resets all employee vacation balances to the default value from settings
+func (h *AdminHandler) ResetBalances(c *gin.Context) {
+	// Get settings to determine default vacation days
+	settings, err := h.settingsRepo.Get(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    dto.ErrInternal,
+			Message: "Failed to get settings",
+		})
+		return
+	}
+
+	// Reset all balances
+	newBalance := resetBalance(settings)
+	count, err := h.userService.ResetAllBalances(c.Request.Context(), newBalance)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to reset balances",
+			})
+		}
+		return
+	}
+
+	leaveYear := service.LeaveYearOf(settings, settings.Today(time.Now()))
+	recordAudit(c, h.auditService, domain.AuditBalanceReset, domain.AuditTargetUser, "", nil, gin.H{
+		"newBalance":   newBalance,
+		"usersUpdated": count,
+		"leaveYear":    leaveYear,
+	})
+
+	c.JSON(http.StatusOK, dto.ResetBalancesResponse{
+		Success:      true,
+		UsersUpdated: count,
+		NewBalance:   newBalance,
+		LeaveYear:    leaveYear,
+		Message:      fmt.Sprintf("Reset vacation balance to %d %s for %d employees for leave year %d", newBalance, settings.BalanceUnit, count, leaveYear),
+	})
+}
+
+// ResetPreview handles GET /api/admin/users/reset-preview
+// Reports every employee's balance before and after a reset under the current settings, without writing anything
+func (h *AdminHandler) ResetPreview(c *gin.Context) {
+	settings, err := h.settingsRepo.Get(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    dto.ErrInternal,
+			Message: "Failed to get settings",
+		})
+		return
+	}
+
+	newBalance := resetBalance(settings)
+	balances, err := h.userService.PreviewResetBalances(c.Request.Context(), newBalance)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to preview balance reset",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ResetPreviewResponse{
+		NewBalance: newBalance,
+		LeaveYear:  service.LeaveYearOf(settings, settings.Today(time.Now())),
+		Balances:   balances,
+	})
+}
+
+// resetBalance is the balance a yearly reset gives every employee under settings
+func resetBalance(settings *domain.Settings) int {
+	return settings.DaysToBalance(settings.DefaultVacationDays)
+}
+
+// AdjustBalances handles POST /api/admin/users/adjust-balances
+// Adds (or subtracts) the same number of days to each selected user's balance, all or nothing
+func (h *AdminHandler) AdjustBalances(c *gin.Context) {
+	var req dto.AdjustBalancesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
+
+	balances, err := h.userService.AdjustBalances(c.Request.Context(), req.UserIDs, req.Delta, req.Reason)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to adjust balances",
+			})
+		}
+		return
+	}
+
+	resp := dto.AdjustBalancesResponse{
+		Delta:    req.Delta,
+		Reason:   req.Reason,
+		Balances: balances,
+	}
+
+	recordAudit(c, h.auditService, domain.AuditBalanceAdjust, domain.AuditTargetUser, "", nil, resp)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ReconcileBalances handles GET /api/admin/users/balance-reconcile
+// Reports users whose stored balance differs from the balance ledger (read-only)
+func (h *AdminHandler) ReconcileBalances(c *gin.Context) {
+	discrepancies, checked, err := h.userService.ReconcileBalances(c.Request.Context())
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to reconcile balances",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BalanceReconcileResponse{
+		Discrepancies: discrepancies,
+		UsersChecked:  checked,
+		Total:         len(discrepancies),
+	})
+}
+
+// ListBalances handles GET /api/admin/balances
+// Lists every active employee's balance and the days used in the year query parameter (default: current leave year),
+// lowest balance first unless sort/order say otherwise; sent as CSV when the client accepts text/csv
+func (h *AdminHandler) ListBalances(c *gin.Context) {
+	year, ok := parseYearQuery(c, h.vacationService)
+	if !ok {
+		return
+	}
+
+	sort := domain.UserSort{Field: domain.UserSortBalance}
+	if field := c.Query("sort"); field != "" {
+		if !domain.IsValidUserSortField(field) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid sort. Must be name, email, balance, or createdAt",
+			})
+			return
+		}
+		sort.Field = domain.UserSortField(field)
+	}
+	if order := c.Query("order"); order != "" {
+		if order != "asc" && order != "desc" {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid order. Must be asc or desc",
+			})
+			return
+		}
+		sort.Descending = order == "desc"
+	}
+
+	balances, err := h.userService.ListBalances(c.Request.Context(), year, sort)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to list balances",
+			})
+		}
+		return
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, mimeCSV) == mimeCSV {
+		writeBalancesCSV(c, balances)
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+// NotificationRecipients handles GET /api/admin/notifications/recipients
+// Lists every admin and whether they would be emailed about a newly submitted request
+func (h *AdminHandler) NotificationRecipients(c *gin.Context) {
+	recipients, err := h.userService.NotificationRecipients(c.Request.Context())
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to list notification recipients",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recipients)
+}
+
+// UserStatement handles GET /api/admin/users/:id/statement
+// Returns a user's leave entitlement statement for the year query parameter (default: current leave year)
+func (h *AdminHandler) UserStatement(c *gin.Context) {
+	year, ok := parseYearQuery(c, h.vacationService)
+	if !ok {
+		return
+	}
+
+	statement, err := h.vacationService.Statement(c.Request.Context(), c.Param("id"), year)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get leave statement",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, statement)
+}
+
+// UserVacations handles GET /api/admin/users/:id/vacations
+// Query params: status, year (both optional, as for an employee's own list)
+func (h *AdminHandler) UserVacations(c *gin.Context) {
+	status, year, ok := parseStatusYearQuery(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get user",
+			})
+		}
+		return
+	}
+
+	requests, err := h.vacationService.ListByUser(c.Request.Context(), user.ID, status, year, "", "")
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to list vacation requests",
+			})
+		}
+		return
+	}
+
+	responses := make([]*dto.VacationRequestResponse, len(requests))
+	for i, req := range requests {
+		responses[i] = dto.ToVacationRequestResponseWithBalance(req, user.VacationBalance)
+	}
+
+	c.JSON(http.StatusOK, dto.VacationListResponse{
+		Requests: responses,
+		Total:    len(responses),
+	})
+}
+
+// UserReport handles GET /api/admin/users/:id/report
+// Query params: year (optional leave year, defaults to the current one)
+func (h *AdminHandler) UserReport(c *gin.Context) {
+	year, ok := parseYearQuery(c, h.vacationService)
+	if !ok {
+		return
+	}
+
+	report, err := h.vacationService.AnnualReport(c.Request.Context(), c.Param("id"), year)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get annual report",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ProratedBalance handles GET /api/admin/users/:id/prorated-balance
+// Previews the pro-rated entitlement for the user's start date, or for ?startDate= when given
+func (h *AdminHandler) ProratedBalance(c *gin.Context) {
+	startDate := c.Query("startDate")
+	if startDate == "" {
+		user, err := h.userService.GetByID(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			if appErr, ok := err.(*dto.AppError); ok {
+				c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+			} else {
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+					Code:    dto.ErrInternal,
+					Message: "Failed to get user",
+				})
+			}
+			return
+		}
+		if user.StartDate == nil || *user.StartDate == "" {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "User has no start date; pass startDate to preview",
+			})
+			return
+		}
+		startDate = *user.StartDate
+	}
+
+	preview, err := h.userService.ProratedBalance(c.Request.Context(), startDate)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to compute pro-rated balance",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// ============================================
+// Blackout Period Endpoints
+// ============================================
+
+// ListBlackouts handles GET /api/admin/blackouts
+// Lists date ranges where new leave is disallowed
+func (h *AdminHandler) ListBlackouts(c *gin.Context) {
+	blackouts, err := h.vacationService.ListBlackouts(c.Request.Context())
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to list blackout periods",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BlackoutListResponse{
+		Blackouts: blackouts,
+		Total:     len(blackouts),
+	})
+}
+
+// CreateBlackout handles POST /api/admin/blackouts
+// Adds a date range where new leave is disallowed
+func (h *AdminHandler) CreateBlackout(c *gin.Context) {
+	var req dto.CreateBlackoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
 
-// ResetBalances handles POST /api/admin/users/reset-balances
-// Resets all employee vacation balances to the default value from settings
-func (h *AdminHandler) ResetBalances(c *gin.Context) {
-	// Get settings to determine default vacation days
-	settings, err := h.settingsRepo.Get(c.Request.Context())
+	blackout, err := h.vacationService.AddBlackout(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Code:    dto.ErrInternal,
-			Message: "Failed to get settings",
-		})
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to create blackout period",
+			})
+		}
 		return
 	}
 
-	// Reset all balances
-	count, err := h.userService.ResetAllBalances(c.Request.Context(), settings.DefaultVacationDays)
+	recordAudit(c, h.auditService, domain.AuditBlackoutCreate, domain.AuditTargetBlackout, blackout.ID, nil, blackout)
+
+	c.JSON(http.StatusCreated, blackout)
+}
+
+// DeleteBlackout handles DELETE /api/admin/blackouts/:id
+// Removes a blackout period
+func (h *AdminHandler) DeleteBlackout(c *gin.Context) {
+	blackoutID := c.Param("id")
+	before := h.auditBlackoutSnapshot(c.Request.Context(), blackoutID)
+	err := h.vacationService.DeleteBlackout(c.Request.Context(), blackoutID)
 	if err != nil {
 		if appErr, ok := err.(*dto.AppError); ok {
 			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
 		} else {
 			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 				Code:    dto.ErrInternal,
-				Message: "Failed to reset balances",
+				Message: "Failed to delete blackout period",
 			})
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ResetBalancesResponse{
-		Success:      true,
-		UsersUpdated: count,
-		NewBalance:   settings.DefaultVacationDays,
-		Message:      fmt.Sprintf("Reset vacation balance to %d days for %d employees", settings.DefaultVacationDays, count),
+	recordAudit(c, h.auditService, domain.AuditBlackoutDelete, domain.AuditTargetBlackout, blackoutID, before, nil)
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Blackout period deleted successfully",
 	})
 }
 
@@ -430,10 +1178,7 @@ func (h *AdminHandler) GetSettings(c *gin.Context) {
 func (h *AdminHandler) UpdateSettings(c *gin.Context) {
 	var req dto.UpdateSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Code:    dto.ErrValidation,
-			Message: "Invalid request body: " + err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
 		return
 	}
 
@@ -446,6 +1191,7 @@ func (h *AdminHandler) UpdateSettings(c *gin.Context) {
 		})
 		return
 	}
+	before := dto.ToSettingsResponse(settings)
 
 	// Apply updates
 	if req.WeekendPolicy != nil {
@@ -467,6 +1213,24 @@ func (h *AdminHandler) UpdateSettings(c *gin.Context) {
 		if req.Newsletter.DayOfMonth != nil {
 			settings.Newsletter.DayOfMonth = *req.Newsletter.DayOfMonth
 		}
+		if req.Newsletter.DayOfWeek != nil {
+			settings.Newsletter.DayOfWeek = *req.Newsletter.DayOfWeek
+		}
+		if req.Newsletter.Hour != nil {
+			settings.Newsletter.Hour = *req.Newsletter.Hour
+		}
+	}
+
+	if req.DigestSections != nil {
+		if req.DigestSections.MonthlyStats != nil {
+			settings.DigestSections.MonthlyStats = *req.DigestSections.MonthlyStats
+		}
+		if req.DigestSections.UpcomingVacations != nil {
+			settings.DigestSections.UpcomingVacations = *req.DigestSections.UpcomingVacations
+		}
+		if req.DigestSections.LowBalance != nil {
+			settings.DigestSections.LowBalance = *req.DigestSections.LowBalance
+		}
 	}
 
 	if req.DefaultVacationDays != nil {
@@ -477,6 +1241,141 @@ func (h *AdminHandler) UpdateSettings(c *gin.Context) {
 		settings.VacationResetMonth = *req.VacationResetMonth
 	}
 
+	if req.ApprovalLevels != nil {
+		levels := make([]domain.ApprovalStep, 0, len(*req.ApprovalLevels))
+		for _, step := range *req.ApprovalLevels {
+			// Approvals go through the admin review endpoint, so named approvers must be admins
+			if step.ApproverID != nil {
+				approver, err := h.userRepo.GetByID(c.Request.Context(), *step.ApproverID)
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+						Code:    dto.ErrInternal,
+						Message: "Failed to validate approval levels",
+					})
+					return
+				}
+				if approver == nil || !approver.IsAdmin() {
+					c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+						Code:    dto.ErrValidation,
+						Message: "Approver for level '" + step.Name + "' must be an existing admin",
+					})
+					return
+				}
+			}
+			levels = append(levels, domain.ApprovalStep{Name: step.Name, ApproverID: step.ApproverID})
+		}
+		settings.ApprovalLevels = levels
+	}
+
+	if req.MinNoticeDays != nil {
+		settings.MinNoticeDays = *req.MinNoticeDays
+	}
+
+	if req.ApprovalCommentRequired != nil {
+		settings.ApprovalCommentRequired = *req.ApprovalCommentRequired
+	}
+
+	if req.MaxConsecutiveDays != nil {
+		settings.MaxConsecutiveDays = *req.MaxConsecutiveDays
+	}
+
+	if req.LongVacationDays != nil {
+		settings.LongVacationDays = *req.LongVacationDays
+	}
+
+	if req.CoolOffDays != nil {
+		settings.CoolOffDays = *req.CoolOffDays
+	}
+
+	if req.AccrualEnabled != nil {
+		settings.AccrualEnabled = *req.AccrualEnabled
+	}
+
+	if req.AccrualDaysPerMonth != nil {
+		settings.AccrualDaysPerMonth = *req.AccrualDaysPerMonth
+	}
+
+	if req.AllowNegativeBalance != nil {
+		settings.AllowNegativeBalance = *req.AllowNegativeBalance
+	}
+
+	if req.MaxOverdrawDays != nil {
+		settings.MaxOverdrawDays = *req.MaxOverdrawDays
+	}
+
+	if req.MinCoverage != nil {
+		settings.MinCoverage = *req.MinCoverage
+	}
+
+	if req.OverlapPolicy != nil {
+		settings.OverlapPolicy = domain.OverlapPolicy(*req.OverlapPolicy)
+	}
+
+	if req.OverlapAllowTouching != nil {
+		settings.OverlapAllowTouching = *req.OverlapAllowTouching
+	}
+
+	if req.RejectionReasons != nil {
+		reasons := make([]domain.RejectionReason, 0, len(*req.RejectionReasons))
+		for _, r := range *req.RejectionReasons {
+			reasons = append(reasons, domain.RejectionReason{Code: r.Code, Label: r.Label})
+		}
+		settings.RejectionReasons = reasons
+	}
+
+	if req.BalanceUnit != nil {
+		settings.BalanceUnit = domain.BalanceUnit(*req.BalanceUnit)
+	}
+
+	if req.HoursPerDay != nil {
+		settings.HoursPerDay = *req.HoursPerDay
+	}
+
+	if req.LowBalanceThreshold != nil {
+		settings.LowBalanceThreshold = *req.LowBalanceThreshold
+	}
+
+	if req.RoundingMode != nil {
+		settings.RoundingMode = domain.RoundingMode(*req.RoundingMode)
+	}
+
+	if req.MaxRequestsPerYear != nil {
+		settings.MaxRequestsPerYear = *req.MaxRequestsPerYear
+	}
+
+	if req.CountRejectedRequests != nil {
+		settings.CountRejectedRequests = *req.CountRejectedRequests
+	}
+
+	if req.Timezone != nil {
+		settings.Timezone = *req.Timezone
+	}
+
+	if req.MaxFutureDays != nil {
+		settings.MaxFutureDays = *req.MaxFutureDays
+	}
+
+	if req.RemoteBlocksLeave != nil {
+		settings.RemoteBlocksLeave = *req.RemoteBlocksLeave
+	}
+
+	if req.DefaultNewUserRole != nil {
+		settings.DefaultNewUserRole = domain.Role(*req.DefaultNewUserRole)
+	}
+
+	if req.WebhookURL != nil {
+		settings.WebhookURL = *req.WebhookURL
+	}
+
+	// Validate the settings as a whole, since a change to one field can break a rule spanning others
+	if err := settings.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    dto.ErrValidation,
+			Message: err.Error(),
+		})
+		return
+	}
+
 	// Save settings
 	if err := h.settingsRepo.Update(c.Request.Context(), settings); err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
@@ -489,9 +1388,114 @@ func (h *AdminHandler) UpdateSettings(c *gin.Context) {
 	// Fetch updated settings
 	settings, _ = h.settingsRepo.Get(c.Request.Context())
 
+	recordAudit(c, h.auditService, domain.AuditSettingsUpdate, domain.AuditTargetSettings, settings.ID, before, dto.ToSettingsResponse(settings))
+
 	c.JSON(http.StatusOK, dto.ToSettingsResponse(settings))
 }
 
+// ============================================
+// Audit Log Endpoints
+// ============================================
+
+// AuditLog handles GET /api/admin/audit
+// Lists admin mutations newest first, optionally filtered by date range (YYYY-MM-DD, inclusive) and actor
+func (h *AdminHandler) AuditLog(c *gin.Context) {
+	filter := domain.AuditFilter{
+		From:    c.Query("from"),
+		To:      c.Query("to"),
+		ActorID: c.Query("actor"),
+	}
+	for _, date := range []string{filter.From, filter.To} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid date. Use YYYY-MM-DD",
+			})
+			return
+		}
+	}
+
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 200 {
+			limit = parsed
+		}
+	}
+
+	entries, total, err := h.auditService.List(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get audit log",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AuditLogResponse{
+		Entries: entries,
+		Pagination: &dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	})
+}
+
+// auditUserSnapshot loads a user as it looks before a change; nil when it can't be loaded
+func (h *AdminHandler) auditUserSnapshot(ctx context.Context, id string) *dto.UserResponse {
+	user, err := h.userRepo.GetByID(ctx, id)
+	if err != nil || user == nil {
+		return nil
+	}
+	return dto.ToUserResponse(user)
+}
+
+// auditRequestSnapshot loads a vacation request as it looks before a review; nil when it can't be loaded
+func (h *AdminHandler) auditRequestSnapshot(ctx context.Context, id string) *dto.VacationRequestResponse {
+	request, err := h.vacationRepo.GetByID(ctx, id)
+	if err != nil || request == nil {
+		return nil
+	}
+	return dto.ToVacationRequestResponse(request)
+}
+
+// auditBlackoutSnapshot loads a blackout period as it looks before a change; nil when it can't be loaded
+func (h *AdminHandler) auditBlackoutSnapshot(ctx context.Context, id string) *domain.BlackoutPeriod {
+	blackouts, err := h.vacationService.ListBlackouts(ctx)
+	if err != nil {
+		return nil
+	}
+	for i := range blackouts {
+		if blackouts[i].ID == id {
+			return &blackouts[i]
+		}
+	}
+	return nil
+}
+
+// recordAudit stores an audit entry for a mutation that has succeeded
+// A failure is logged rather than returned, since the change itself has already been made
+func recordAudit(c *gin.Context, audit *service.AuditService, action domain.AuditAction, targetType, targetID string, before, after interface{}) {
+	if err := audit.Record(c.Request.Context(), middleware.GetUserID(c), action, targetType, targetID, before, after); err != nil {
+		log.Printf("ERROR: failed to record audit entry %s for %s %s: %v", action, targetType, targetID, err)
+	}
+}
+
 // ============================================
 // Newsletter Endpoints
 // ============================================
@@ -530,6 +1534,96 @@ func (h *AdminHandler) PreviewNewsletter(c *gin.Context) {
 	c.JSON(http.StatusOK, preview)
 }
 
+// ============================================
+// Report Endpoints
+// ============================================
+
+// Dashboard handles GET /api/admin/dashboard
+// Returns the admin dashboard summary in a single call
+// Query params: threshold (optional, employees with this many days or fewer count as low balance, default 5)
+func (h *AdminHandler) Dashboard(c *gin.Context) {
+	threshold := service.LowBalanceThreshold
+	if t := c.Query("threshold"); t != "" {
+		parsed, err := strconv.Atoi(t)
+		if err != nil || parsed < 0 || parsed > 365 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "threshold must be a number between 0 and 365",
+			})
+			return
+		}
+		threshold = parsed
+	}
+
+	dashboard, err := h.reportService.Dashboard(c.Request.Context(), time.Now(), threshold)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to build dashboard",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
+
+// YearlyStats handles GET /api/admin/stats
+// Query params: year (optional leave year, defaults to the current one)
+func (h *AdminHandler) YearlyStats(c *gin.Context) {
+	year, ok := parseYearQuery(c, h.vacationService)
+	if !ok {
+		return
+	}
+
+	stats, err := h.reportService.YearlyStats(c.Request.Context(), year)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get yearly stats",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// ComplianceReport handles GET /api/admin/reports/compliance
+// Returns a consolidated compliance report for requests submitted in a period
+func (h *AdminHandler) ComplianceReport(c *gin.Context) {
+	from := c.Query("from")
+	to := c.Query("to")
+	if from == "" || to == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    dto.ErrValidation,
+			Message: "Both from and to query parameters are required (YYYY-MM-DD)",
+		})
+		return
+	}
+
+	report, err := h.reportService.ComplianceReport(c.Request.Context(), from, to)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to generate compliance report",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
 // ============================================
 // Email Test Endpoints
 // ============================================
@@ -539,10 +1633,7 @@ func (h *AdminHandler) PreviewNewsletter(c *gin.Context) {
 func (h *AdminHandler) SendTestEmail(c *gin.Context) {
 	var req dto.TestEmailRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Code:    dto.ErrValidation,
-			Message: "Invalid request: " + err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
 		return
 	}
 
@@ -646,15 +1737,61 @@ func (h *AdminHandler) SendTestEmail(c *gin.Context) {
 	})
 }
 
+// ValidateEmailTemplates handles POST /api/admin/email/validate
+// Renders every email template with synthetic edge-case data and reports parse or render errors
+func (h *AdminHandler) ValidateEmailTemplates(c *gin.Context) {
+	checked, failures := h.emailService.ValidateTemplates()
+
+	templateErrors := make([]*dto.EmailTemplateError, len(failures))
+	for i, failure := range failures {
+		templateErrors[i] = &dto.EmailTemplateError{
+			Template: failure.Template,
+			Sample:   failure.Sample,
+			Error:    failure.Err,
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.EmailValidationResponse{
+		Valid:   len(templateErrors) == 0,
+		Checked: checked,
+		Errors:  templateErrors,
+	})
+}
+
+// EmailLog handles GET /api/admin/email/log
+// Returns recently queued emails with their delivery status, newest first
+func (h *AdminHandler) EmailLog(c *gin.Context) {
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 200 {
+			limit = parsed
+		}
+	}
+
+	emails, err := h.emailService.RecentDeliveries(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    dto.ErrInternal,
+			Message: "Failed to get email log",
+		})
+		return
+	}
+	if emails == nil {
+		emails = []*domain.EmailMessage{}
+	}
+
+	c.JSON(http.StatusOK, dto.EmailLogResponse{
+		Emails: emails,
+		Total:  len(emails),
+	})
+}
+
 // PreviewEmail handles POST /api/admin/email/preview
 // Returns a preview of an email template without sending
 func (h *AdminHandler) PreviewEmail(c *gin.Context) {
 	var req dto.PreviewEmailRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Code:    dto.ErrValidation,
-			Message: "Invalid request: " + err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
 		return
 	}
 
@@ -743,6 +1880,37 @@ func (h *AdminHandler) PreviewEmail(c *gin.Context) {
 	})
 }
 
+// mimeCSV is the content type of CSV exports
+const mimeCSV = "text/csv"
+
+// writeBalancesCSV sends the balances overview as a downloadable CSV file
+func writeBalancesCSV(c *gin.Context, balances *dto.EmployeeBalancesResponse) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"name", "email", "balance", "daysUsed", "unit"})
+	for _, e := range balances.Employees {
+		_ = w.Write([]string{
+			csvSafe(e.Name),
+			csvSafe(e.Email),
+			strconv.Itoa(e.Balance),
+			strconv.Itoa(e.DaysUsed),
+			balances.BalanceUnit,
+		})
+	}
+	w.Flush()
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("balances-%d.csv", balances.Year)))
+	c.Data(http.StatusOK, mimeCSV+"; charset=utf-8", buf.Bytes())
+}
+
+// csvSafe keeps user-provided text from being read as a formula by spreadsheet applications
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
 // stringPtr returns a pointer to a string
 func stringPtr(s string) *string {
 	return &s
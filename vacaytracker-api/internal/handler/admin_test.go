@@ -19,6 +19,7 @@ import (
 	"vacaytracker-api/internal/domain"
 	"vacaytracker-api/internal/dto"
 	"vacaytracker-api/internal/handler"
+	"vacaytracker-api/internal/repository"
 	"vacaytracker-api/internal/service"
 	"vacaytracker-api/internal/testutil"
 )
@@ -31,7 +32,10 @@ type adminTestDeps struct {
 	userRepo     *testutil.MockUserRepository
 	vacRepo      *testutil.MockVacationRepository
 	settingsRepo *testutil.MockSettingsRepository
+	ledgerRepo   *testutil.MockLedgerRepository
 	transactor   *testutil.MockTransactor
+	outboxRepo   *testutil.MockEmailOutboxRepository
+	auditRepo    *testutil.MockAuditRepository
 	handler      *handler.AdminHandler
 	router       *gin.Engine
 }
@@ -43,20 +47,26 @@ func setupAdminTest(t *testing.T) *adminTestDeps {
 	userRepo := &testutil.MockUserRepository{}
 	vacRepo := &testutil.MockVacationRepository{}
 	settingsRepo := &testutil.MockSettingsRepository{}
+	ledgerRepo := &testutil.MockLedgerRepository{}
 	transactor := &testutil.MockTransactor{}
+	teamRepo := &testutil.MockTeamRepository{}
+	outboxRepo := &testutil.MockEmailOutboxRepository{}
+	auditRepo := &testutil.MockAuditRepository{}
 
 	cfg := &config.Config{
 		JWTSecret: "test-secret-key-that-is-at-least-32-chars",
 		AppURL:    "http://localhost:3000",
 	}
 
-	authService := service.NewAuthService(userRepo, cfg.JWTSecret)
-	userService := service.NewUserService(userRepo, authService)
-	vacationService := service.NewVacationService(vacRepo, userRepo, settingsRepo, transactor)
+	authService := service.NewAuthService(userRepo, &testutil.MockRefreshTokenRepository{}, cfg.JWTSecret)
+	userService := service.NewUserService(userRepo, ledgerRepo, transactor, teamRepo, settingsRepo, authService)
+	vacationService := service.NewVacationService(vacRepo, userRepo, settingsRepo, ledgerRepo, transactor)
 	emailService := service.NewEmailService(cfg)
-	newsletterService := service.NewNewsletterService(cfg, userRepo, vacRepo, settingsRepo, emailService)
+	emailService.SetQueue(service.NewEmailQueue(emailService, outboxRepo))
+	newsletterService := service.NewNewsletterService(cfg, userRepo, vacRepo, settingsRepo, emailService, authService)
+	reportService := service.NewReportService(vacRepo, userRepo, settingsRepo)
 
-	h := handler.NewAdminHandler(cfg, userService, userRepo, vacationService, vacRepo, settingsRepo, emailService, newsletterService)
+	h := handler.NewAdminHandler(cfg, userService, userRepo, vacationService, vacRepo, settingsRepo, emailService, newsletterService, reportService, service.NewWebhookService(settingsRepo), service.NewAuditService(auditRepo))
 
 	r := gin.New()
 	admin := r.Group("/api/admin")
@@ -70,19 +80,56 @@ func setupAdminTest(t *testing.T) *adminTestDeps {
 		admin.GET("/users/:id", h.GetUser)
 		admin.PUT("/users/:id", h.UpdateUser)
 		admin.DELETE("/users/:id", h.DeleteUser)
+		admin.POST("/users/:id/restore", h.RestoreUser)
+		admin.PUT("/users/:id/status", h.UpdateUserStatus)
 		admin.PUT("/users/:id/balance", h.UpdateBalance)
+		admin.GET("/users/:id/statement", h.UserStatement)
+		admin.GET("/users/:id/vacations", h.UserVacations)
+		admin.GET("/users/:id/report", h.UserReport)
+		admin.GET("/users/:id/prorated-balance", h.ProratedBalance)
+		admin.GET("/users/reset-preview", h.ResetPreview)
 		admin.POST("/users/reset-balances", h.ResetBalances)
+		admin.POST("/users/adjust-balances", h.AdjustBalances)
+		admin.GET("/users/balance-reconcile", h.ReconcileBalances)
+		admin.GET("/balances", h.ListBalances)
 		admin.GET("/vacation/pending", h.ListPending)
+		admin.GET("/vacation/stale", h.ListStale)
 		admin.PUT("/vacation/:id/review", h.Review)
+		admin.POST("/vacation/:id/recompute", h.RecomputeTotalDays)
+		admin.GET("/vacation/coverage", h.Coverage)
 		admin.GET("/settings", h.GetSettings)
 		admin.PUT("/settings", h.UpdateSettings)
+		admin.GET("/blackouts", h.ListBlackouts)
+		admin.POST("/blackouts", h.CreateBlackout)
+		admin.DELETE("/blackouts/:id", h.DeleteBlackout)
+		admin.GET("/reports/compliance", h.ComplianceReport)
+		admin.GET("/dashboard", h.Dashboard)
+		admin.GET("/stats", h.YearlyStats)
+		admin.GET("/email/log", h.EmailLog)
+		admin.POST("/email/validate", h.ValidateEmailTemplates)
+		admin.GET("/notifications/recipients", h.NotificationRecipients)
+		admin.GET("/audit", h.AuditLog)
+	}
+
+	// Manager routes run as a non-admin with direct reports
+	manager := r.Group("/api/manager")
+	manager.Use(func(c *gin.Context) {
+		testutil.SetAuthContext(c, "mgr-1", "manager@test.com", "Manager", domain.RoleEmployee)
+		c.Next()
+	})
+	{
+		manager.GET("/vacation/pending", h.ListPending)
+		manager.PUT("/vacation/:id/review", h.Review)
 	}
 
 	return &adminTestDeps{
 		userRepo:     userRepo,
 		vacRepo:      vacRepo,
 		settingsRepo: settingsRepo,
+		ledgerRepo:   ledgerRepo,
 		transactor:   transactor,
+		outboxRepo:   outboxRepo,
+		auditRepo:    auditRepo,
 		handler:      h,
 		router:       r,
 	}
@@ -99,6 +146,7 @@ func sampleUser(id, email, name string, role domain.Role, balance int) *domain.U
 		VacationBalance:  balance,
 		PasswordHash:     "$2a$04$fakehashfortest",
 		EmailPreferences: domain.DefaultEmailPreferences(),
+		Active:           true,
 		CreatedAt:        now,
 		UpdatedAt:        now,
 	}
@@ -133,7 +181,7 @@ func TestAdminListUsers_Success(t *testing.T) {
 		sampleUser("u2", "bob@test.com", "Bob", domain.RoleAdmin, 25),
 	}
 
-	deps.userRepo.GetAllFn = func(ctx context.Context, role *domain.Role, search string, limit, offset int) ([]*domain.User, int, error) {
+	deps.userRepo.GetAllFn = func(ctx context.Context, filter domain.UserFilter, _ domain.UserSort, limit, offset int) ([]*domain.User, int, error) {
 		return users, 2, nil
 	}
 
@@ -155,12 +203,66 @@ func TestAdminListUsers_Success(t *testing.T) {
 	assert.Equal(t, "u2", resp.Users[1].ID)
 }
 
+func TestAdminListUsers_Cursor(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	var capturedAfter string
+	var capturedLimit int
+	deps.userRepo.GetAllAfterFn = func(_ context.Context, _ domain.UserFilter, afterID string, limit int) ([]*domain.User, error) {
+		capturedAfter, capturedLimit = afterID, limit
+		return []*domain.User{
+			sampleUser("u2", "bob@test.com", "Bob", domain.RoleEmployee, 20),
+			sampleUser("u3", "carol@test.com", "Carol", domain.RoleEmployee, 20),
+			sampleUser("u4", "dave@test.com", "Dave", domain.RoleEmployee, 20),
+		}, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users?after=u1&limit=2", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "u1", capturedAfter)
+	assert.Equal(t, 3, capturedLimit, "one extra row tells whether another page follows")
+
+	var resp dto.UserListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Users, 2)
+	assert.Nil(t, resp.Pagination)
+	require.NotNil(t, resp.NextCursor)
+	assert.Equal(t, "u3", *resp.NextCursor)
+
+	// The last page has no cursor
+	deps.userRepo.GetAllAfterFn = func(_ context.Context, _ domain.UserFilter, _ string, _ int) ([]*domain.User, error) {
+		return []*domain.User{sampleUser("u4", "dave@test.com", "Dave", domain.RoleEmployee, 20)}, nil
+	}
+	req = httptest.NewRequest(http.MethodGet, "/api/admin/users?after=u3&limit=2", nil)
+	w = httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	resp = dto.UserListResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Users, 1)
+	assert.Nil(t, resp.NextCursor)
+}
+
+func TestAdminListUsers_CursorRejectsSort(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users?after=&sort=name", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestAdminListUsers_WithRoleFilter(t *testing.T) {
 	deps := setupAdminTest(t)
 
 	var capturedRole *domain.Role
-	deps.userRepo.GetAllFn = func(ctx context.Context, role *domain.Role, search string, limit, offset int) ([]*domain.User, int, error) {
-		capturedRole = role
+	deps.userRepo.GetAllFn = func(ctx context.Context, filter domain.UserFilter, _ domain.UserSort, limit, offset int) ([]*domain.User, int, error) {
+		capturedRole = filter.Role
 		return []*domain.User{sampleUser("u1", "alice@test.com", "Alice", domain.RoleEmployee, 20)}, 1, nil
 	}
 
@@ -178,6 +280,89 @@ func TestAdminListUsers_WithRoleFilter(t *testing.T) {
 	assert.Len(t, resp.Users, 1)
 }
 
+func TestAdminListUsers_ActiveAndEmailFilters(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	var captured domain.UserFilter
+	deps.userRepo.GetAllFn = func(ctx context.Context, filter domain.UserFilter, _ domain.UserSort, limit, offset int) ([]*domain.User, int, error) {
+		captured = filter
+		return []*domain.User{}, 0, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users?role=admin&active=false&email=a@test.com&search=al", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, captured.Role)
+	assert.Equal(t, domain.RoleAdmin, *captured.Role)
+	require.NotNil(t, captured.Active)
+	assert.False(t, *captured.Active)
+	assert.Equal(t, "a@test.com", captured.Email)
+	assert.Equal(t, "al", captured.Search)
+}
+
+func TestAdminListUsers_InvalidActive(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users?active=maybe", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdminListUsers_Sort(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	var capturedSort domain.UserSort
+	deps.userRepo.GetAllFn = func(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, limit, offset int) ([]*domain.User, int, error) {
+		capturedSort = sort
+		return []*domain.User{sampleUser("u1", "alice@test.com", "Alice", domain.RoleEmployee, 2)}, 1, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users?sort=balance&order=asc", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.UserSort{Field: domain.UserSortBalance}, capturedSort)
+
+	var resp dto.UserListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Sort)
+	assert.Equal(t, "balance", resp.Sort.Field)
+	assert.Equal(t, "asc", resp.Sort.Order)
+}
+
+func TestAdminListUsers_DefaultSortMetadata(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.UserListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Sort)
+	assert.Equal(t, "createdAt", resp.Sort.Field)
+	assert.Equal(t, "desc", resp.Sort.Order)
+}
+
+func TestAdminListUsers_InvalidSort(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	for _, query := range []string{"sort=password_hash", "sort=name&order=sideways"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/users?"+query, nil)
+		w := httptest.NewRecorder()
+		deps.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
 func TestAdminListUsers_InvalidRole(t *testing.T) {
 	deps := setupAdminTest(t)
 
@@ -224,10 +409,34 @@ func TestAdminCreateUser_Success(t *testing.T) {
 	assert.Equal(t, 25, resp.VacationBalance) // default
 }
 
+func TestAdminCreateUser_DefaultRole(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.userRepo.EmailExistsFn = func(ctx context.Context, email string) (bool, error) {
+		return false, nil
+	}
+	deps.userRepo.CreateFn = func(ctx context.Context, user *domain.User) error {
+		return nil
+	}
+
+	body := `{"email":"new@test.com","password":"securePass1","name":"New User"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var resp dto.UserResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "employee", resp.Role)
+}
+
 func TestAdminCreateUser_InvalidBody(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	// Missing required fields (email, password, name, role)
+	// Missing required fields (email, password, name)
 	body := `{"email":"notanemail"}`
 	req := httptest.NewRequest(http.MethodPost, "/api/admin/users", strings.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
@@ -240,6 +449,65 @@ func TestAdminCreateUser_InvalidBody(t *testing.T) {
 	var resp dto.ErrorResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
 	assert.Equal(t, dto.ErrValidation, resp.Code)
+	assert.Equal(t, dto.ValidationErrors{
+		"email":    "must be a valid email address",
+		"password": "is required",
+		"name":     "is required",
+	}, resp.Errors)
+}
+
+func TestAdminCreateUser_InvalidFieldType(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	body := `{"email":"new@test.com","password":"securePass1","name":"New User","vacationBalance":"lots"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrValidation, resp.Code)
+	assert.Equal(t, dto.ValidationErrors{"vacationBalance": "must be of type int"}, resp.Errors)
+}
+
+func TestAdminCreateUser_MalformedJSONHasNoFieldErrors(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users", strings.NewReader(`{"email":`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrValidation, resp.Code)
+	assert.Empty(t, resp.Errors)
+}
+
+func TestAdminUpdateSettings_NestedFieldErrorsUseJSONPaths(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	body := `{"approvalLevels":[{"name":"Manager"},{"name":""}]}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ValidationErrors{"approvalLevels[1].name": "is required"}, resp.Errors)
+	// The message keeps the validator's own wording with Go field names
+	assert.Contains(t, resp.Message, "UpdateSettingsRequest.ApprovalLevels[1].Name")
 }
 
 func TestAdminCreateUser_DuplicateEmail(t *testing.T) {
@@ -329,7 +597,7 @@ func TestAdminUpdateUser_Success(t *testing.T) {
 	deps.userRepo.EmailExistsExcludingFn = func(ctx context.Context, email, excludeID string) (bool, error) {
 		return false, nil
 	}
-	deps.userRepo.UpdateFn = func(ctx context.Context, u *domain.User) error {
+	deps.userRepo.UpdateTxFn = func(ctx context.Context, tx *sql.Tx, u *domain.User) error {
 		return nil
 	}
 
@@ -452,230 +720,244 @@ func TestAdminDeleteUser_NotFound(t *testing.T) {
 	assert.Equal(t, dto.ErrNotFound, resp.Code)
 }
 
-// ===================================================================
-// UpdateBalance tests
-// ===================================================================
-
-func TestAdminUpdateBalance_Success(t *testing.T) {
+func TestAdminUpdateUserStatus_Deactivate(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	user := sampleUser("user-42", "emp@test.com", "Employee", domain.RoleEmployee, 20)
-
 	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
-		if id == "user-42" {
-			return user, nil
-		}
-		return nil, nil
-	}
-	deps.userRepo.UpdateVacationBalanceFn = func(ctx context.Context, id string, balance int) error {
-		return nil
+		return sampleUser("user-42", "target@test.com", "Target User", domain.RoleEmployee, 20), nil
 	}
 
-	body := `{"vacationBalance":30}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/user-42/balance", strings.NewReader(body))
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/user-42/status", strings.NewReader(`{"active":false}`))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
-
 	deps.router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
 	var resp dto.UserResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, "user-42", resp.ID)
-	assert.Equal(t, 30, resp.VacationBalance)
+	assert.False(t, resp.Active)
 }
 
-func TestAdminUpdateBalance_UserNotFound(t *testing.T) {
+func TestAdminUpdateUserStatus_MissingActive(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
-		return nil, nil
-	}
-
-	body := `{"vacationBalance":30}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/nonexistent/balance", strings.NewReader(body))
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/user-42/status", strings.NewReader(`{}`))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
-
 	deps.router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusNotFound, w.Code)
-
-	var resp dto.ErrorResponse
-	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, dto.ErrNotFound, resp.Code)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
-func TestAdminUpdateBalance_InvalidBody(t *testing.T) {
+func TestAdminRestoreUser_Success(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	// Missing required vacationBalance field
-	body := `{}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/user-42/balance", strings.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
-	w := httptest.NewRecorder()
+	user := sampleUser("user-42", "target@test.com", "Target User", domain.RoleEmployee, 20)
+	deletedAt := time.Now()
+	user.DeletedAt = &deletedAt
+
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return user, nil
+	}
 
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/user-42/restore", nil)
+	w := httptest.NewRecorder()
 	deps.router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
 
-	var resp dto.ErrorResponse
+	var resp dto.UserResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, dto.ErrValidation, resp.Code)
+	assert.Equal(t, "user-42", resp.ID)
+	assert.Nil(t, resp.DeletedAt)
 }
 
 // ===================================================================
-// ListPending tests
+// ProratedBalance tests
 // ===================================================================
 
-func TestAdminListPending_Success(t *testing.T) {
+func TestAdminProratedBalance_StartDateOverride(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	pending := []*domain.VacationRequest{
-		sampleVacation("vac-1", "user-10", domain.StatusPending, 3),
-		sampleVacation("vac-2", "user-20", domain.StatusPending, 5),
-	}
-
-	deps.vacRepo.ListPendingFn = func(ctx context.Context) ([]*domain.VacationRequest, error) {
-		return pending, nil
-	}
-
-	req := httptest.NewRequest(http.MethodGet, "/api/admin/vacation/pending", nil)
+	year := time.Now().Year() + 1
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/admin/users/user-42/prorated-balance?startDate=%d-07-01", year), nil)
 	w := httptest.NewRecorder()
 	deps.router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var resp dto.VacationListResponse
+	var resp dto.ProratedBalanceResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, 2, resp.Total)
-	assert.Len(t, resp.Requests, 2)
-	assert.Equal(t, "vac-1", resp.Requests[0].ID)
-	assert.Equal(t, "pending", resp.Requests[0].Status)
-	assert.Equal(t, "vac-2", resp.Requests[1].ID)
+	assert.Equal(t, fmt.Sprintf("%d-01-01", year), resp.LeaveYearStart)
+	assert.Equal(t, 25, resp.AnnualDays)
+	assert.Equal(t, 13, resp.ProratedDays)
 }
 
-// ===================================================================
-// Review tests
-// ===================================================================
-
-func TestAdminReview_ApproveSuccess(t *testing.T) {
+func TestAdminProratedBalance_UsesUserStartDate(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	vacation := sampleVacation("vac-1", "user-10", domain.StatusPending, 3)
-	user := sampleUser("user-10", "emp@test.com", "Employee", domain.RoleEmployee, 20)
-
-	approvedVacation := *vacation
-	approvedVacation.Status = domain.StatusApproved
-
-	callCount := 0
-	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
-		if id == "vac-1" {
-			callCount++
-			// First call: pending (for Approve check); subsequent calls: approved (after update)
-			if callCount <= 1 {
-				return vacation, nil
-			}
-			return &approvedVacation, nil
-		}
-		return nil, nil
-	}
-
+	user := sampleUser("user-42", "emp@test.com", "Employee", domain.RoleEmployee, 20)
+	startDate := "2020-03-01"
+	user.StartDate = &startDate
 	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
-		if id == "user-10" {
-			return user, nil
-		}
-		return nil, nil
-	}
-
-	deps.vacRepo.UpdateStatusTxFn = func(ctx context.Context, tx *sql.Tx, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error {
-		assert.Equal(t, "vac-1", id)
-		assert.Equal(t, domain.StatusApproved, status)
-		assert.Equal(t, "admin-1", reviewedBy)
-		assert.Nil(t, rejectionReason)
-		return nil
-	}
-
-	deps.userRepo.UpdateVacationBalanceTxFn = func(ctx context.Context, tx *sql.Tx, id string, balance int) error {
-		assert.Equal(t, "user-10", id)
-		assert.Equal(t, 17, balance) // 20 - 3
-		return nil
+		return user, nil
 	}
 
-	body := `{"status":"approved"}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/user-42/prorated-balance", nil)
 	w := httptest.NewRecorder()
-
 	deps.router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var resp dto.VacationRequestResponse
+	var resp dto.ProratedBalanceResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, "vac-1", resp.ID)
-	assert.Equal(t, "approved", resp.Status)
+	assert.Equal(t, "2020-03-01", resp.StartDate)
+	assert.Equal(t, 25, resp.ProratedDays, "long-standing employees get the full entitlement")
 }
 
-func TestAdminReview_RejectSuccess(t *testing.T) {
+func TestAdminProratedBalance_NoStartDate(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	vacation := sampleVacation("vac-2", "user-10", domain.StatusPending, 5)
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return sampleUser("user-42", "emp@test.com", "Employee", domain.RoleEmployee, 20), nil
+	}
 
-	rejectedVacation := *vacation
-	rejectedVacation.Status = domain.StatusRejected
-	reason := "Project deadline conflict"
-	rejectedVacation.RejectionReason = &reason
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/user-42/prorated-balance", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
 
-	callCount := 0
-	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
-		if id == "vac-2" {
-			callCount++
-			if callCount <= 1 {
-				return vacation, nil
-			}
-			return &rejectedVacation, nil
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// ===================================================================
+// UpdateBalance tests
+// ===================================================================
+
+func TestAdminUpdateBalance_Success(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	user := sampleUser("user-42", "emp@test.com", "Employee", domain.RoleEmployee, 20)
+
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		if id == "user-42" {
+			return user, nil
 		}
 		return nil, nil
 	}
-
-	deps.vacRepo.UpdateStatusFn = func(ctx context.Context, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error {
-		assert.Equal(t, "vac-2", id)
-		assert.Equal(t, domain.StatusRejected, status)
-		assert.Equal(t, "admin-1", reviewedBy)
-		require.NotNil(t, rejectionReason)
-		assert.Equal(t, "Project deadline conflict", *rejectionReason)
+	deps.userRepo.UpdateVacationBalanceTxFn = func(ctx context.Context, tx *sql.Tx, id string, balance int) error {
 		return nil
 	}
 
-	// For reject, the user repo GetByID is called in the async sendReviewEmail goroutine.
-	// We set it up to avoid nil pointer issues but it does not affect the HTTP response.
+	body := `{"vacationBalance":30}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/user-42/balance", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.UserResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "user-42", resp.ID)
+	assert.Equal(t, 30, resp.VacationBalance)
+}
+
+func TestAdminUpdateBalance_RecordsAudit(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	user := sampleUser("user-42", "emp@test.com", "Employee", domain.RoleEmployee, 20)
 	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
-		return sampleUser(id, "emp@test.com", "Employee", domain.RoleEmployee, 20), nil
+		return user, nil
+	}
+	var recorded []*domain.AuditEntry
+	deps.auditRepo.CreateFn = func(ctx context.Context, entry *domain.AuditEntry) error {
+		recorded = append(recorded, entry)
+		return nil
 	}
 
-	body := `{"status":"rejected","reason":"Project deadline conflict"}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-2/review", strings.NewReader(body))
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/user-42/balance", strings.NewReader(`{"vacationBalance":30}`))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, recorded, 1)
+	entry := recorded[0]
+	assert.Equal(t, "admin-1", entry.ActorID)
+	assert.Equal(t, domain.AuditBalanceUpdate, entry.Action)
+	assert.Equal(t, domain.AuditTargetUser, entry.TargetType)
+	assert.Equal(t, "user-42", entry.TargetID)
+
+	var before, after dto.UserResponse
+	require.NoError(t, json.Unmarshal(entry.Before, &before))
+	require.NoError(t, json.Unmarshal(entry.After, &after))
+	assert.Equal(t, 20, before.VacationBalance)
+	assert.Equal(t, 30, after.VacationBalance)
+}
+
+func TestAdminUpdateBalance_AuditFailureDoesNotFailRequest(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return sampleUser("user-42", "emp@test.com", "Employee", domain.RoleEmployee, 20), nil
+	}
+	deps.auditRepo.CreateFn = func(ctx context.Context, entry *domain.AuditEntry) error {
+		return fmt.Errorf("disk full")
+	}
 
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/user-42/balance", strings.NewReader(`{"vacationBalance":30}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
 	deps.router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
+}
 
-	var resp dto.VacationRequestResponse
+func TestAdminUpdateBalance_FailureNotAudited(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.auditRepo.CreateFn = func(ctx context.Context, entry *domain.AuditEntry) error {
+		t.Fatal("a failed change must not be audited")
+		return nil
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/nonexistent/balance", strings.NewReader(`{"vacationBalance":30}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAdminUpdateBalance_UserNotFound(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return nil, nil
+	}
+
+	body := `{"vacationBalance":30}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/nonexistent/balance", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var resp dto.ErrorResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, "vac-2", resp.ID)
-	assert.Equal(t, "rejected", resp.Status)
+	assert.Equal(t, dto.ErrNotFound, resp.Code)
 }
 
-func TestAdminReview_InvalidBody(t *testing.T) {
+func TestAdminUpdateBalance_InvalidBody(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	// Status must be "approved" or "rejected"
-	body := `{"status":"cancelled"}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
+	// Missing required vacationBalance field
+	body := `{}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/user-42/balance", strings.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -689,91 +971,1727 @@ func TestAdminReview_InvalidBody(t *testing.T) {
 }
 
 // ===================================================================
-// GetSettings tests
+// ListPending tests
 // ===================================================================
 
-func TestAdminGetSettings_Success(t *testing.T) {
+func TestAdminListPending_Success(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	settings := domain.DefaultSettings()
-	settings.DefaultVacationDays = 30
-	settings.VacationResetMonth = 4
+	pending := []*domain.VacationRequest{
+		sampleVacation("vac-1", "user-10", domain.StatusPending, 3),
+		sampleVacation("vac-2", "user-20", domain.StatusPending, 5),
+	}
+
+	deps.vacRepo.ListPendingFn = func(ctx context.Context, _, _ string) ([]*domain.VacationRequest, error) {
+		return pending, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/vacation/pending", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.VacationListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Total)
+	assert.Len(t, resp.Requests, 2)
+	assert.Equal(t, "vac-1", resp.Requests[0].ID)
+	assert.Equal(t, "pending", resp.Requests[0].Status)
+	assert.Equal(t, "vac-2", resp.Requests[1].ID)
+	require.NotNil(t, resp.Count)
+	assert.Equal(t, 2, *resp.Count)
+	require.NotNil(t, resp.SummaryTotalDays)
+	assert.Equal(t, 8, *resp.SummaryTotalDays)
+}
+
+func TestAdminListStale(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	var cutoff time.Time
+	deps.vacRepo.ListStalePendingFn = func(ctx context.Context, createdBefore time.Time) ([]*domain.VacationRequest, error) {
+		cutoff = createdBefore
+		return []*domain.VacationRequest{sampleVacation("vac-1", "user-10", domain.StatusPending, 3)}, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/vacation/stale?days=14", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -14), cutoff, time.Minute)
+
+	var resp dto.VacationListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Requests, 1)
+	assert.Equal(t, "vac-1", resp.Requests[0].ID)
+	require.NotNil(t, resp.SummaryTotalDays)
+	assert.Equal(t, 3, *resp.SummaryTotalDays)
+
+	// Without days the default applies
+	req = httptest.NewRequest(http.MethodGet, "/api/admin/vacation/stale", nil)
+	w = httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -service.DefaultStaleDays), cutoff, time.Minute)
+}
+
+func TestAdminListStale_InvalidDays(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	for _, days := range []string{"0", "-3", "abc", "366"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/vacation/stale?days="+days, nil)
+		w := httptest.NewRecorder()
+		deps.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, days)
+	}
+}
+
+func TestManagerListPending_ScopedToReports(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.userRepo.ListByManagerFn = func(ctx context.Context, managerID string) ([]*domain.User, error) {
+		return []*domain.User{sampleUser("user-10", "ten@test.com", "Ten", domain.RoleEmployee, 20)}, nil
+	}
+	deps.vacRepo.ListPendingFn = func(ctx context.Context, _, _ string) ([]*domain.VacationRequest, error) {
+		return []*domain.VacationRequest{
+			sampleVacation("vac-1", "user-10", domain.StatusPending, 3),
+			sampleVacation("vac-2", "user-20", domain.StatusPending, 5),
+		}, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/manager/vacation/pending", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.VacationListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Total)
+	require.Len(t, resp.Requests, 1)
+	assert.Equal(t, "vac-1", resp.Requests[0].ID)
+}
+
+func TestManagerReview_NotDirectReportForbidden(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+		return sampleVacation("vac-1", "user-20", domain.StatusPending, 3), nil
+	}
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return sampleUser("user-20", "twenty@test.com", "Twenty", domain.RoleEmployee, 20), nil
+	}
+
+	body := `{"status":"approved"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/manager/vacation/vac-1/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrForbidden, resp.Code)
+}
+
+// ===================================================================
+// Review tests
+// ===================================================================
+
+func TestAdminRecomputeTotalDays_Success(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	// 2026-03-01 is a Sunday, so the request covers 4 business days
+	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+		return sampleVacation(id, "user-10", domain.StatusPending, 5), nil
+	}
+	var stored int
+	deps.vacRepo.UpdateTotalDaysFn = func(ctx context.Context, id string, totalDays int) error {
+		stored = totalDays
+		return nil
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/vacation/vac-1/recompute", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.RecomputeTotalDaysResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "vac-1", resp.RequestID)
+	assert.Equal(t, 5, resp.PreviousTotalDays)
+	assert.Equal(t, 4, resp.NewTotalDays)
+	assert.Equal(t, 4, stored)
+}
+
+func TestAdminReview_ApproveSuccess(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	vacation := sampleVacation("vac-1", "user-10", domain.StatusPending, 3)
+	user := sampleUser("user-10", "emp@test.com", "Employee", domain.RoleEmployee, 20)
+
+	approvedVacation := *vacation
+	approvedVacation.Status = domain.StatusApproved
+
+	var recorded []*domain.AuditEntry
+	deps.auditRepo.CreateFn = func(ctx context.Context, entry *domain.AuditEntry) error {
+		recorded = append(recorded, entry)
+		return nil
+	}
+
+	// Pending until the status update lands, approved afterwards
+	stored := vacation
+	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+		if id == "vac-1" {
+			return stored, nil
+		}
+		return nil, nil
+	}
+
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		if id == "user-10" {
+			return user, nil
+		}
+		return nil, nil
+	}
+
+	deps.vacRepo.UpdateStatusTxFn = func(ctx context.Context, tx *sql.Tx, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error {
+		assert.Equal(t, "vac-1", id)
+		assert.Equal(t, domain.StatusApproved, status)
+		assert.Equal(t, "admin-1", reviewedBy)
+		assert.Nil(t, rejectionReason)
+		stored = &approvedVacation
+		return nil
+	}
+
+	deps.userRepo.AddVacationBalanceTxFn = func(ctx context.Context, tx *sql.Tx, id string, delta, floor int) (int, error) {
+		assert.Equal(t, "user-10", id)
+		assert.Equal(t, -3, delta)
+		return 20 + delta, nil
+	}
+
+	body := `{"status":"approved"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.VacationRequestResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "vac-1", resp.ID)
+	assert.Equal(t, "approved", resp.Status)
+
+	require.Len(t, recorded, 1)
+	assert.Equal(t, domain.AuditRequestApprove, recorded[0].Action)
+	assert.Equal(t, domain.AuditTargetRequest, recorded[0].TargetType)
+	assert.Equal(t, "vac-1", recorded[0].TargetID)
+	assert.Contains(t, string(recorded[0].Before), `"status":"pending"`)
+	assert.Contains(t, string(recorded[0].After), `"status":"approved"`)
+}
+
+func TestAdminReview_ApproveCommentRequired(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+	settings.ApprovalCommentRequired = true
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+
+	vacation := sampleVacation("vac-1", "user-10", domain.StatusPending, 3)
+	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+		return vacation, nil
+	}
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return sampleUser("user-10", "emp@test.com", "Employee", domain.RoleEmployee, 20), nil
+	}
+
+	var storedComment string
+	deps.vacRepo.UpdateApprovalCommentTxFn = func(ctx context.Context, tx *sql.Tx, id string, comment string) error {
+		storedComment = comment
+		return nil
+	}
+
+	// Without a comment the approval is blocked
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(`{"status":"approved"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "approval comment is required")
+
+	// With a comment it goes through and the comment is stored
+	req = httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(`{"status":"approved","reason":"Coverage confirmed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Coverage confirmed", storedComment)
+}
+
+func TestAdminReview_RejectSuccess(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	vacation := sampleVacation("vac-2", "user-10", domain.StatusPending, 5)
+
+	rejectedVacation := *vacation
+	rejectedVacation.Status = domain.StatusRejected
+	reason := "Project deadline conflict"
+	rejectedVacation.RejectionReason = &reason
+
+	stored := vacation
+	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+		if id == "vac-2" {
+			return stored, nil
+		}
+		return nil, nil
+	}
+
+	deps.vacRepo.UpdateStatusFn = func(ctx context.Context, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error {
+		assert.Equal(t, "vac-2", id)
+		assert.Equal(t, domain.StatusRejected, status)
+		assert.Equal(t, "admin-1", reviewedBy)
+		require.NotNil(t, rejectionReason)
+		assert.Equal(t, "Project deadline conflict", *rejectionReason)
+		stored = &rejectedVacation
+		return nil
+	}
+
+	// For reject, the user repo GetByID is called in the async sendReviewEmail goroutine.
+	// We set it up to avoid nil pointer issues but it does not affect the HTTP response.
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return sampleUser(id, "emp@test.com", "Employee", domain.RoleEmployee, 20), nil
+	}
+
+	body := `{"status":"rejected","reason":"Project deadline conflict"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-2/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.VacationRequestResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "vac-2", resp.ID)
+	assert.Equal(t, "rejected", resp.Status)
+}
+
+func TestAdminReview_RejectWithReasonCode(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+	settings.RejectionReasons = []domain.RejectionReason{{Code: "coverage", Label: "Not enough team coverage"}}
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+		return sampleVacation("vac-2", "user-10", domain.StatusPending, 5), nil
+	}
+	var stored string
+	deps.vacRepo.UpdateStatusFn = func(ctx context.Context, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error {
+		require.NotNil(t, rejectionReason)
+		stored = *rejectionReason
+		return nil
+	}
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return sampleUser(id, "emp@test.com", "Employee", domain.RoleEmployee, 20), nil
+	}
+
+	body := `{"status":"rejected","reasonCode":"coverage"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-2/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Not enough team coverage", stored)
+}
+
+func TestAdminReview_UnknownReasonCode(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.vacRepo.UpdateStatusFn = func(ctx context.Context, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error {
+		t.Fatal("request must not be rejected with an unknown reason code")
+		return nil
+	}
+
+	body := `{"status":"rejected","reasonCode":"budget"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-2/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrValidation, resp.Code)
+}
+
+func TestAdminReview_InvalidBody(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	// Status must be "approved" or "rejected"
+	body := `{"status":"cancelled"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrValidation, resp.Code)
+}
+
+// ===================================================================
+// GetSettings tests
+// ===================================================================
+
+func TestAdminGetSettings_Success(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+	settings.DefaultVacationDays = 30
+	settings.VacationResetMonth = 4
+
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/settings", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.SettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 30, resp.DefaultVacationDays)
+	assert.Equal(t, 4, resp.VacationResetMonth)
+	assert.True(t, resp.WeekendPolicy.ExcludeWeekends)
+}
+
+// ===================================================================
+// UpdateSettings tests
+// ===================================================================
+
+func TestAdminUpdateSettings_Success(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+
+	callCount := 0
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		callCount++
+		if callCount == 1 {
+			// First call: return current settings
+			return &settings, nil
+		}
+		// Second call: return updated settings (after update)
+		updated := settings
+		updated.DefaultVacationDays = 30
+		updated.VacationResetMonth = 6
+		return &updated, nil
+	}
+
+	var updatedSettings *domain.Settings
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		updatedSettings = s
+		return nil
+	}
+
+	body := `{"defaultVacationDays":30,"vacationResetMonth":6}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Verify Update was called with the right values
+	require.NotNil(t, updatedSettings)
+	assert.Equal(t, 30, updatedSettings.DefaultVacationDays)
+	assert.Equal(t, 6, updatedSettings.VacationResetMonth)
+
+	var resp dto.SettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 30, resp.DefaultVacationDays)
+	assert.Equal(t, 6, resp.VacationResetMonth)
+}
+
+func TestAdminUpdateSettings_InvalidBody(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	// vacationResetMonth must be 1-12
+	body := `{"vacationResetMonth":13}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrValidation, resp.Code)
+}
+
+// ===================================================================
+// ResetBalances tests
+// ===================================================================
+
+func TestAdminResetBalances_Success(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+	settings.DefaultVacationDays = 25
+
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+
+	deps.userRepo.UpdateAllBalancesTxFn = func(ctx context.Context, tx *sql.Tx, balance int) (int64, error) {
+		assert.Equal(t, 25, balance)
+		return 10, nil
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/reset-balances", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.ResetBalancesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Success)
+	assert.Equal(t, 10, resp.UsersUpdated)
+	assert.Equal(t, 25, resp.NewBalance)
+	assert.Equal(t, time.Now().Year(), resp.LeaveYear)
+	assert.Contains(t, resp.Message, "Reset vacation balance to 25 days for 10 employees")
+}
+
+func TestAdminResetPreview_Success(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+	settings.DefaultVacationDays = 25
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	deps.userRepo.GetByRoleFn = func(ctx context.Context, role domain.Role) ([]*domain.User, error) {
+		return []*domain.User{
+			sampleUser("u1", "alice@test.com", "Alice", domain.RoleEmployee, 7),
+		}, nil
+	}
+	deps.userRepo.UpdateAllBalancesTxFn = func(ctx context.Context, tx *sql.Tx, balance int) (int64, error) {
+		t.Fatal("preview must not reset balances")
+		return 0, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/reset-preview", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.ResetPreviewResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 25, resp.NewBalance)
+	require.Len(t, resp.Balances, 1)
+	assert.Equal(t, "u1", resp.Balances[0].UserID)
+	assert.Equal(t, 7, resp.Balances[0].PreviousBalance)
+	assert.Equal(t, 25, resp.Balances[0].NewBalance)
+}
+
+func TestAdminAdjustBalances_Success(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	users := map[string]*domain.User{
+		"u1": sampleUser("u1", "alice@test.com", "Alice", domain.RoleEmployee, 10),
+		"u2": sampleUser("u2", "bob@test.com", "Bob", domain.RoleEmployee, 4),
+	}
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return users[id], nil
+	}
+	deps.userRepo.AddVacationBalanceTxFn = func(ctx context.Context, tx *sql.Tx, id string, delta, floor int) (int, error) {
+		return users[id].VacationBalance + delta, nil
+	}
+	var audited *domain.AuditEntry
+	deps.auditRepo.CreateFn = func(ctx context.Context, entry *domain.AuditEntry) error {
+		audited = entry
+		return nil
+	}
+
+	body := `{"userIds":["u1","u2"],"delta":2,"reason":"Crunch bonus"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/adjust-balances", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.AdjustBalancesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Delta)
+	require.Len(t, resp.Balances, 2)
+	assert.Equal(t, 12, resp.Balances[0].NewBalance)
+	assert.Equal(t, 6, resp.Balances[1].NewBalance)
+
+	require.NotNil(t, audited)
+	assert.Equal(t, domain.AuditBalanceAdjust, audited.Action)
+	assert.Contains(t, string(audited.After), "Crunch bonus")
+}
+
+func TestAdminAdjustBalances_InvalidBody(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	for _, body := range []string{
+		`{"userIds":[],"delta":2,"reason":"x"}`,
+		`{"userIds":["u1"],"delta":0,"reason":"x"}`,
+		`{"userIds":["u1"],"delta":2}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/users/adjust-balances", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		deps.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestAdminReconcileBalances_ReportsDiscrepancies(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.userRepo.GetByRoleFn = func(ctx context.Context, role domain.Role) ([]*domain.User, error) {
+		if role == domain.RoleAdmin {
+			return []*domain.User{sampleUser("admin-1", "admin@test.com", "Admin", domain.RoleAdmin, 25)}, nil
+		}
+		return []*domain.User{
+			sampleUser("u1", "alice@test.com", "Alice", domain.RoleEmployee, 30),
+			sampleUser("u2", "bob@test.com", "Bob", domain.RoleEmployee, 20),
+		}, nil
+	}
+	deps.ledgerRepo.SumByUserFn = func(ctx context.Context) (map[string]int, error) {
+		return map[string]int{"admin-1": 25, "u1": 25, "u2": 20}, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/balance-reconcile", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.BalanceReconcileResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 3, resp.UsersChecked)
+	assert.Equal(t, 1, resp.Total)
+	require.Len(t, resp.Discrepancies, 1)
+	assert.Equal(t, "u1", resp.Discrepancies[0].UserID)
+	assert.Equal(t, 30, resp.Discrepancies[0].StoredBalance)
+	assert.Equal(t, 25, resp.Discrepancies[0].LedgerBalance)
+	assert.Equal(t, 5, resp.Discrepancies[0].Delta)
+}
+
+func TestAdminListBalances_JSON(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	var gotSort domain.UserSort
+	deps.userRepo.ListBalancesFn = func(ctx context.Context, year int, sort domain.UserSort) ([]*repository.EmployeeBalance, error) {
+		assert.Equal(t, 2027, year)
+		gotSort = sort
+		return []*repository.EmployeeBalance{
+			{UserID: "u2", Name: "Bob", Email: "bob@test.com", VacationBalance: 3, DaysUsed: 22},
+			{UserID: "u1", Name: "Alice", Email: "alice@test.com", VacationBalance: 18, DaysUsed: 7},
+		}, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/balances?year=2027", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.UserSort{Field: domain.UserSortBalance}, gotSort)
+
+	var resp dto.EmployeeBalancesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2027, resp.Year)
+	assert.Equal(t, "days", resp.BalanceUnit)
+	assert.Equal(t, 2, resp.Total)
+	require.Len(t, resp.Employees, 2)
+	assert.Equal(t, "u2", resp.Employees[0].UserID)
+	assert.Equal(t, 3, resp.Employees[0].Balance)
+	assert.Equal(t, 22, resp.Employees[0].DaysUsed)
+}
+
+func TestAdminListBalances_CSV(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.userRepo.ListBalancesFn = func(ctx context.Context, year int, sort domain.UserSort) ([]*repository.EmployeeBalance, error) {
+		assert.Equal(t, domain.UserSort{Field: domain.UserSortName, Descending: true}, sort)
+		return []*repository.EmployeeBalance{
+			{UserID: "u1", Name: "=Alice", Email: "alice@test.com", VacationBalance: 18, DaysUsed: 7},
+		}, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/balances?year=2027&sort=name&order=desc", nil)
+	req.Header.Set("Accept", "text/csv")
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "balances-2027.csv")
+	assert.Equal(t, "name,email,balance,daysUsed,unit\n'=Alice,alice@test.com,18,7,days\n", w.Body.String())
+}
+
+func TestAdminListBalances_InvalidSort(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	for _, query := range []string{"?sort=daysUsed", "?order=up", "?year=abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/balances"+query, nil)
+		w := httptest.NewRecorder()
+		deps.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestAdminNotificationRecipients(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	optedOut := sampleUser("admin-2", "quiet@test.com", "Quiet", domain.RoleAdmin, 25)
+	optedOut.EmailPreferences.TeamNotifications = false
+	deps.userRepo.GetByRoleFn = func(ctx context.Context, role domain.Role) ([]*domain.User, error) {
+		assert.Equal(t, domain.RoleAdmin, role)
+		return []*domain.User{sampleUser("admin-1", "admin@test.com", "Admin", domain.RoleAdmin, 25), optedOut}, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/notifications/recipients", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.NotificationRecipientsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Recipients)
+	require.Len(t, resp.Admins, 2)
+	assert.True(t, resp.Admins[0].Receives)
+	assert.False(t, resp.Admins[1].Receives)
+	assert.Equal(t, "team notifications turned off", resp.Admins[1].SkipReason)
+}
+
+func TestAdminReconcileBalances_NoDiscrepancies(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/balance-reconcile", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"discrepancies":[]`)
+}
+
+func TestAdminUserStatement_Success(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return sampleUser("u1", "alice@test.com", "Alice", domain.RoleEmployee, 20), nil
+	}
+	deps.ledgerRepo.ListByUserFn = func(ctx context.Context, userID string) ([]*domain.LedgerEntry, error) {
+		return []*domain.LedgerEntry{
+			{Delta: 25, Reason: domain.LedgerOpening, CreatedAt: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+			{Delta: -5, Reason: domain.LedgerVacation, CreatedAt: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		}, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/u1/statement?year=2026", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.LeaveStatementResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "u1", resp.UserID)
+	assert.Equal(t, 25, resp.Grants)
+	assert.Equal(t, 5, resp.Taken)
+	assert.Equal(t, 20, resp.ClosingBalance)
+}
+
+func TestAdminUserStatement_InvalidYear(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/u1/statement?year=abc", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdminUserVacations_Success(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return sampleUser(id, "alice@test.com", "Alice", domain.RoleEmployee, 20), nil
+	}
+	deps.vacRepo.ListByUserFn = func(ctx context.Context, userID string, status *domain.VacationStatus, year *int, from, to string) ([]*domain.VacationRequest, error) {
+		assert.Equal(t, "u1", userID)
+		require.NotNil(t, status)
+		assert.Equal(t, domain.StatusApproved, *status)
+		require.NotNil(t, year)
+		assert.Equal(t, 2027, *year)
+		return []*domain.VacationRequest{sampleVacation("v1", "u1", domain.StatusApproved, 5)}, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/u1/vacations?status=approved&year=2027", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.VacationListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Total)
+	assert.Equal(t, "v1", resp.Requests[0].ID)
+}
+
+func TestAdminUserVacations_UserNotFound(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/missing/vacations", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAdminUserVacations_InvalidStatus(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/u1/vacations?status=bogus", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdminUserReport_Success(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return sampleUser("u1", "alice@test.com", "Alice", domain.RoleEmployee, 20), nil
+	}
+	var gotYear int
+	deps.vacRepo.GetUserYearStatsFn = func(ctx context.Context, userID string, year int) (*repository.UserYearStats, error) {
+		gotYear = year
+		stats := &repository.UserYearStats{TotalRequested: 2, TotalApproved: 1, TotalRejected: 1, TotalDaysUsed: 3}
+		stats.DaysByMonth[4] = 3
+		return stats, nil
+	}
+	deps.vacRepo.ListByUserFn = func(ctx context.Context, userID string, status *domain.VacationStatus, year *int, from, to string) ([]*domain.VacationRequest, error) {
+		return []*domain.VacationRequest{sampleVacation("vac-1", "u1", domain.StatusApproved, 3)}, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/u1/report?year=2026", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.AnnualReportResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2026, gotYear)
+	assert.Equal(t, "u1", resp.UserID)
+	assert.Equal(t, 2, resp.TotalRequested)
+	assert.Equal(t, 3, resp.DaysUsed)
+	assert.Equal(t, 20, resp.RemainingBalance)
+	require.Len(t, resp.Months, 12)
+	assert.Equal(t, 3, resp.Months[4].DaysUsed)
+	require.Len(t, resp.Requests, 1)
+	assert.Equal(t, "vac-1", resp.Requests[0].ID)
+}
+
+func TestAdminUserReport_NotFound(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/missing/report", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// ===================================================================
+// Blackout period tests
+// ===================================================================
+
+func TestAdminBlackouts_CreateListDelete(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		copied := settings
+		return &copied, nil
+	}
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		settings = *s
+		return nil
+	}
+
+	body := `{"startDate":"24/06/2027","endDate":"30/06/2027","reason":"Quarter close"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/blackouts", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created domain.BlackoutPeriod
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "2027-06-24", created.StartDate)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/admin/blackouts", nil)
+	w = httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var list dto.BlackoutListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Equal(t, 1, list.Total)
+	assert.Equal(t, created.ID, list.Blackouts[0].ID)
+
+	var audited *domain.AuditEntry
+	deps.auditRepo.CreateFn = func(ctx context.Context, entry *domain.AuditEntry) error {
+		audited = entry
+		return nil
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/admin/blackouts/"+created.ID, nil)
+	w = httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, settings.BlackoutPeriods)
+
+	require.NotNil(t, audited)
+	assert.Equal(t, domain.AuditBlackoutDelete, audited.Action)
+	assert.Contains(t, string(audited.Before), `"reason":"Quarter close"`)
+	assert.Nil(t, audited.After)
+}
+
+func TestAdminBlackouts_CreateMissingReason(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	body := `{"startDate":"24/06/2027","endDate":"30/06/2027"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/blackouts", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdminBlackouts_DeleteNotFound(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/admin/blackouts/missing", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// ===================================================================
+// Additional edge-case tests
+// ===================================================================
+
+func TestAdminListUsers_WithPagination(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	var capturedLimit, capturedOffset int
+	deps.userRepo.GetAllFn = func(ctx context.Context, filter domain.UserFilter, _ domain.UserSort, limit, offset int) ([]*domain.User, int, error) {
+		capturedLimit = limit
+		capturedOffset = offset
+		return []*domain.User{sampleUser("u1", "a@test.com", "A", domain.RoleEmployee, 20)}, 50, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users?page=3&limit=10", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 10, capturedLimit)
+	assert.Equal(t, 20, capturedOffset) // (page-1) * limit = (3-1) * 10
+
+	var resp dto.UserListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 3, resp.Pagination.Page)
+	assert.Equal(t, 10, resp.Pagination.Limit)
+	assert.Equal(t, 50, resp.Pagination.Total)
+	assert.Equal(t, 5, resp.Pagination.TotalPages)
+}
+
+func TestAdminReview_VacationNotFound(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+		return nil, nil
+	}
+
+	body := `{"status":"approved"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/nonexistent/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrNotFound, resp.Code)
+}
+
+func TestAdminReview_AlreadyProcessed(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	// Vacation is already approved, cannot be reviewed again
+	vacation := sampleVacation("vac-1", "user-10", domain.StatusApproved, 3)
+
+	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+		if id == "vac-1" {
+			return vacation, nil
+		}
+		return nil, nil
+	}
+
+	body := `{"status":"rejected","reason":"too late"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrAlreadyExists, resp.Code)
+}
+
+func TestAdminGetSettings_RepoError(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		return nil, fmt.Errorf("database connection lost")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/settings", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrInternal, resp.Code)
+}
+
+func TestAdminUpdateSettings_WeekendPolicy(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+
+	callCount := 0
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		callCount++
+		if callCount == 1 {
+			return &settings, nil
+		}
+		updated := settings
+		updated.WeekendPolicy.ExcludeWeekends = false
+		return &updated, nil
+	}
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		return nil
+	}
+
+	body := `{"weekendPolicy":{"excludeWeekends":false}}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.SettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.False(t, resp.WeekendPolicy.ExcludeWeekends)
+}
+
+func TestAdminUpdateSettings_WebhookURL(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		return nil
+	}
+
+	body := `{"webhookUrl":"https://hooks.example.com/vacay"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.SettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "https://hooks.example.com/vacay", resp.WebhookURL)
+
+	// An empty string clears the webhook
+	req = httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(`{"webhookUrl":""}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, settings.WebhookURL)
+}
+
+func TestAdminUpdateSettings_DigestSections(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		return nil
+	}
+
+	// Omitted sections keep their current value
+	body := `{"digestSections":{"lowBalance":false}}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.SettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, domain.DigestSections{MonthlyStats: true, UpcomingVacations: true, LowBalance: false}, resp.DigestSections)
+}
+
+func TestAdminUpdateSettings_OverlapPolicy(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		return nil
+	}
+
+	body := `{"overlapPolicy":"warn","overlapAllowTouching":true}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.SettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "warn", resp.OverlapPolicy)
+	assert.True(t, resp.OverlapAllowTouching)
+}
+
+func TestAdminUpdateSettings_RejectionReasons(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		return nil
+	}
+
+	body := `{"rejectionReasons":[{"code":"coverage","label":"Not enough team coverage"}]}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.SettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []domain.RejectionReason{{Code: "coverage", Label: "Not enough team coverage"}}, resp.RejectionReasons)
+}
+
+func TestAdminUpdateSettings_DuplicateRejectionReasonCode(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	body := `{"rejectionReasons":[{"code":"coverage","label":"A"},{"code":"coverage","label":"B"}]}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdminUpdateSettings_DefaultNewUserRoleAdminRejected(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		t.Fatal("settings should not be saved")
+		return nil
+	}
+
+	body := `{"defaultNewUserRole":"admin"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Contains(t, resp.Message, "cannot be admin")
+}
+
+func TestAdminUpdateSettings_BalanceUnit(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	var saved *domain.Settings
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		saved = s
+		return nil
+	}
+
+	body := `{"balanceUnit":"hours","hoursPerDay":7}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, saved)
+	assert.Equal(t, domain.BalanceUnitHours, saved.BalanceUnit)
+	assert.Equal(t, 7, saved.HoursPerDay)
+}
+
+func TestAdminUpdateSettings_InvalidBalanceUnit(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	for _, body := range []string{`{"balanceUnit":"weeks"}`, `{"hoursPerDay":0}`, `{"hoursPerDay":25}`} {
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		deps.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestAdminUpdateSettings_Timezone(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	var saved *domain.Settings
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		saved = s
+		return nil
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(`{"timezone":"Australia/Sydney"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, saved)
+	assert.Equal(t, "Australia/Sydney", saved.Timezone)
+
+	saved = nil
+	req = httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(`{"timezone":"Mars/Olympus"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Nil(t, saved)
+}
+
+func TestAdminUpdateSettings_InvalidOverlapPolicy(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	body := `{"overlapPolicy":"ignore"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdminUpdateSettings_NewsletterSchedule(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	settings := domain.DefaultSettings()
+	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		return nil
+	}
+
+	body := `{"newsletter":{"enabled":true,"frequency":"weekly","dayOfWeek":5,"hour":16}}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.SettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 5, resp.Newsletter.DayOfWeek)
+	assert.Equal(t, 16, resp.Newsletter.Hour)
+	require.NotNil(t, resp.NextNewsletterAt)
+
+	next, err := time.Parse(time.RFC3339, *resp.NextNewsletterAt)
+	require.NoError(t, err)
+	assert.Equal(t, time.Friday, next.Weekday())
+	assert.Equal(t, 16, next.Hour())
+}
+
+func TestAdminUpdateSettings_InvalidNewsletterSchedule(t *testing.T) {
+	for _, body := range []string{
+		`{"newsletter":{"dayOfWeek":7}}`,
+		`{"newsletter":{"hour":24}}`,
+		`{"newsletter":{"hour":-1}}`,
+	} {
+		deps := setupAdminTest(t)
+		deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+			t.Fatal("settings should not be saved")
+			return nil
+		}
+
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		deps.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestAdminGetSettings_NewsletterDisabledHasNoNextSend(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/settings", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"nextNewsletterAt":null`)
+}
+
+func TestAdminUpdateSettings_InvalidWebhookURL(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	updated := false
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		updated = true
+		return nil
+	}
+
+	for _, url := range []string{"not a url", "ftp://files.example.com", "/relative/path"} {
+		body := `{"webhookUrl":"` + url + `"}`
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		deps.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+	assert.False(t, updated)
+}
+
+func TestAdminUpdateSettings_WeekendPolicyNeedsWorkingDay(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	updated := false
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		updated = true
+		return nil
+	}
+
+	for _, body := range []string{
+		`{"weekendPolicy":{"excludedDays":[0,1,2,3,4,5,6]}}`,
+		`{"weekendPolicy":{"excludedDays":[7]}}`,
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		deps.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+	assert.False(t, updated)
+}
+
+func TestAdminUpdateSettings_CrossFieldValidation(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
+		t.Fatal("settings should not be saved")
+		return nil
+	}
+
+	// Lowering the entitlement below the existing low balance threshold breaks the relationship
+	body := `{"defaultVacationDays":3}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrValidation, resp.Code)
+	assert.Contains(t, resp.Message, "low balance threshold (5) cannot exceed default vacation days (3)")
+}
+
+func TestAdminDeleteUser_LastAdmin(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	// Target is a different admin, but they are the last admin.
+	user := sampleUser("other-admin", "otheradmin@test.com", "Other Admin", domain.RoleAdmin, 25)
+
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		if id == "other-admin" {
+			return user, nil
+		}
+		return nil, nil
+	}
+	deps.userRepo.CountByRoleFn = func(ctx context.Context, role domain.Role) (int, error) {
+		if role == domain.RoleAdmin {
+			return 1, nil // only one admin left
+		}
+		return 0, nil
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/admin/users/other-admin", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrForbidden, resp.Code)
+	assert.Contains(t, resp.Message, "last admin")
+}
+
+func TestAdminCreateUser_WithCustomBalance(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.userRepo.EmailExistsFn = func(ctx context.Context, email string) (bool, error) {
+		return false, nil
+	}
+	deps.userRepo.CreateFn = func(ctx context.Context, user *domain.User) error {
+		return nil
+	}
+
+	body := `{"email":"custom@test.com","password":"securePass1","name":"Custom Balance","role":"employee","vacationBalance":15}`
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var resp dto.UserResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 15, resp.VacationBalance)
+}
+
+func TestAdminListPending_Empty(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	deps.vacRepo.ListPendingFn = func(ctx context.Context, _, _ string) ([]*domain.VacationRequest, error) {
+		return []*domain.VacationRequest{}, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/vacation/pending", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.VacationListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 0, resp.Total)
+	assert.Len(t, resp.Requests, 0)
+	require.NotNil(t, resp.SummaryTotalDays)
+	assert.Equal(t, 0, *resp.SummaryTotalDays)
+}
+
+func TestAdminReview_ApproveInsufficientBalance(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	vacation := sampleVacation("vac-1", "user-10", domain.StatusPending, 10)
+	// User only has 5 days left, but request is for 10
+	user := sampleUser("user-10", "emp@test.com", "Employee", domain.RoleEmployee, 5)
+
+	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+		if id == "vac-1" {
+			return vacation, nil
+		}
+		return nil, nil
+	}
+
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		if id == "user-10" {
+			return user, nil
+		}
+		return nil, nil
+	}
+
+	body := `{"status":"approved"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrInsufficientBalance, resp.Code)
+}
+
+func TestAdminReview_ForceApprovesDespiteInsufficientBalance(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	vacation := sampleVacation("vac-1", "user-10", domain.StatusPending, 10)
+	user := sampleUser("user-10", "emp@test.com", "Employee", domain.RoleEmployee, 5)
+
+	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+		return vacation, nil
+	}
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return user, nil
+	}
+	var recorded string
+	deps.vacRepo.UpdateBalanceOverrideTxFn = func(ctx context.Context, tx *sql.Tx, id, reason string) error {
+		recorded = reason
+		return nil
+	}
+	var newBalance int
+	deps.userRepo.AddVacationBalanceTxFn = func(ctx context.Context, tx *sql.Tx, id string, delta, floor int) (int, error) {
+		newBalance = 5 + delta
+		return newBalance, nil
+	}
+
+	body := `{"status":"approved","force":true,"overrideReason":"Advance on next year's allowance"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Advance on next year's allowance", recorded)
+	assert.Equal(t, -5, newBalance)
+}
+
+func TestAdminReview_ForceRejectedForManagers(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	body := `{"status":"approved","force":true,"overrideReason":"Advance"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/manager/vacation/vac-1/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestAdminReview_ForceOnlyForApprovals(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	body := `{"status":"rejected","force":true,"overrideReason":"Advance"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdminReview_ApproveWithAdjustedDates(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	// Sun 2026-03-01 to Thu 03-05; the counter-offer keeps Monday and Tuesday
+	vacation := sampleVacation("vac-1", "user-10", domain.StatusPending, 4)
+	user := sampleUser("user-10", "emp@test.com", "Employee", domain.RoleEmployee, 10)
+
+	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+		return vacation, nil
+	}
+	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+		return user, nil
+	}
+	var adjustedStart, adjustedEnd string
+	var adjustedTotal int
+	deps.vacRepo.AdjustDatesTxFn = func(ctx context.Context, tx *sql.Tx, id, startDate, endDate string, totalDays int) error {
+		adjustedStart, adjustedEnd, adjustedTotal = startDate, endDate, totalDays
+		return nil
+	}
+	var newBalance int
+	deps.userRepo.AddVacationBalanceTxFn = func(ctx context.Context, tx *sql.Tx, id string, delta, floor int) (int, error) {
+		newBalance = 10 + delta
+		return newBalance, nil
+	}
+
+	body := `{"status":"approved","startDate":"02/03/2026","endDate":"03/03/2026"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2026-03-02", adjustedStart)
+	assert.Equal(t, "2026-03-03", adjustedEnd)
+	assert.Equal(t, 2, adjustedTotal)
+	assert.Equal(t, 8, newBalance)
+}
+
+func TestAdminReview_AdjustedDatesValidation(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	for _, body := range []string{
+		`{"status":"rejected","startDate":"02/03/2026","endDate":"03/03/2026"}`,
+		`{"status":"approved","startDate":"02/03/2026"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		deps.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestAdminResetBalances_SettingsRepoError(t *testing.T) {
+	deps := setupAdminTest(t)
 
 	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
-		return &settings, nil
+		return nil, fmt.Errorf("settings database error")
 	}
 
-	req := httptest.NewRequest(http.MethodGet, "/api/admin/settings", nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/reset-balances", nil)
 	w := httptest.NewRecorder()
 	deps.router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
 
-	var resp dto.SettingsResponse
+	var resp dto.ErrorResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, 30, resp.DefaultVacationDays)
-	assert.Equal(t, 4, resp.VacationResetMonth)
-	assert.True(t, resp.WeekendPolicy.ExcludeWeekends)
+	assert.Equal(t, dto.ErrInternal, resp.Code)
 }
 
 // ===================================================================
-// UpdateSettings tests
+// ComplianceReport tests
 // ===================================================================
 
-func TestAdminUpdateSettings_Success(t *testing.T) {
+func TestAdminComplianceReport_Success(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	settings := domain.DefaultSettings()
-
-	callCount := 0
-	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
-		callCount++
-		if callCount == 1 {
-			// First call: return current settings
-			return &settings, nil
-		}
-		// Second call: return updated settings (after update)
-		updated := settings
-		updated.DefaultVacationDays = 30
-		updated.VacationResetMonth = 6
-		return &updated, nil
-	}
-
-	var updatedSettings *domain.Settings
-	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
-		updatedSettings = s
-		return nil
+	deps.vacRepo.ListCreatedBetweenFn = func(ctx context.Context, from, to string) ([]*domain.VacationRequest, error) {
+		return []*domain.VacationRequest{
+			sampleVacation("vac-1", "user-1", domain.StatusApproved, 3),
+			sampleVacation("vac-2", "user-2", domain.StatusRejected, 2),
+		}, nil
 	}
 
-	body := `{"defaultVacationDays":30,"vacationResetMonth":6}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/reports/compliance?from=2027-01-01&to=2027-03-31", nil)
 	w := httptest.NewRecorder()
-
 	deps.router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	// Verify Update was called with the right values
-	require.NotNil(t, updatedSettings)
-	assert.Equal(t, 30, updatedSettings.DefaultVacationDays)
-	assert.Equal(t, 6, updatedSettings.VacationResetMonth)
-
-	var resp dto.SettingsResponse
+	var resp dto.ComplianceReportResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, 30, resp.DefaultVacationDays)
-	assert.Equal(t, 6, resp.VacationResetMonth)
+	assert.Equal(t, 2, resp.TotalRequests)
+	assert.Equal(t, 1, resp.Approved)
+	assert.Equal(t, 1, resp.Rejected)
+	assert.InDelta(t, 0.5, resp.ApprovalRate, 0.0001)
+	require.Len(t, resp.Rejections, 1)
+	assert.Equal(t, "vac-2", resp.Rejections[0].RequestID)
 }
 
-func TestAdminUpdateSettings_InvalidBody(t *testing.T) {
+func TestAdminComplianceReport_MissingParams(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	// vacationResetMonth must be 1-12
-	body := `{"vacationResetMonth":13}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/reports/compliance?from=2027-01-01", nil)
 	w := httptest.NewRecorder()
-
 	deps.router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -784,290 +2702,285 @@ func TestAdminUpdateSettings_InvalidBody(t *testing.T) {
 }
 
 // ===================================================================
-// ResetBalances tests
+// Coverage tests
 // ===================================================================
 
-func TestAdminResetBalances_Success(t *testing.T) {
+func TestAdminCoverage_Success(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	settings := domain.DefaultSettings()
-	settings.DefaultVacationDays = 25
-
-	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
-		return &settings, nil
-	}
-
-	deps.userRepo.UpdateAllBalancesFn = func(ctx context.Context, balance int) (int64, error) {
-		assert.Equal(t, 25, balance)
-		return 10, nil
+	var gotTeamID string
+	deps.vacRepo.ListTeamRangeFn = func(ctx context.Context, from, to, teamID string) ([]*domain.TeamVacation, error) {
+		gotTeamID = teamID
+		return []*domain.TeamVacation{
+			{ID: "v1", UserID: "user-1", UserName: "Alice", StartDate: "2027-06-14", EndDate: "2027-06-15"},
+		}, nil
 	}
 
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/reset-balances", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/vacation/coverage?from=2027-06-15&to=2027-06-16&teamId=team-1", nil)
 	w := httptest.NewRecorder()
 	deps.router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "team-1", gotTeamID)
 
-	var resp dto.ResetBalancesResponse
+	var resp dto.CoverageResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.True(t, resp.Success)
-	assert.Equal(t, 10, resp.UsersUpdated)
-	assert.Equal(t, 25, resp.NewBalance)
-	assert.Contains(t, resp.Message, "Reset vacation balance to 25 days for 10 employees")
+	require.Len(t, resp.Days, 2)
+	assert.Equal(t, 1, resp.Days[0].Count)
+	assert.Equal(t, []string{"Alice"}, resp.Days[0].Names)
+	assert.Equal(t, 0, resp.Days[1].Count)
+}
+
+func TestAdminCoverage_MissingParams(t *testing.T) {
+	deps := setupAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/vacation/coverage?from=2027-06-15", nil)
+	w := httptest.NewRecorder()
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 // ===================================================================
-// Additional edge-case tests
+// Dashboard tests
 // ===================================================================
 
-func TestAdminListUsers_WithPagination(t *testing.T) {
+func TestAdminDashboard_Success(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	var capturedLimit, capturedOffset int
-	deps.userRepo.GetAllFn = func(ctx context.Context, role *domain.Role, search string, limit, offset int) ([]*domain.User, int, error) {
-		capturedLimit = limit
-		capturedOffset = offset
-		return []*domain.User{sampleUser("u1", "a@test.com", "A", domain.RoleEmployee, 20)}, 50, nil
+	deps.userRepo.CountByRoleFn = func(ctx context.Context, role domain.Role) (int, error) {
+		if role == domain.RoleAdmin {
+			return 1, nil
+		}
+		return 4, nil
+	}
+	var gotThreshold int
+	deps.userRepo.GetLowBalanceUsersFn = func(ctx context.Context, threshold int) ([]*domain.User, error) {
+		gotThreshold = threshold
+		return []*domain.User{sampleUser("user-1", "alice@test.com", "Alice", domain.RoleEmployee, 2)}, nil
+	}
+	deps.vacRepo.ListPendingFn = func(ctx context.Context, from, to string) ([]*domain.VacationRequest, error) {
+		return []*domain.VacationRequest{
+			sampleVacation("vac-1", "user-1", domain.StatusPending, 3),
+			sampleVacation("vac-2", "user-2", domain.StatusPending, 2),
+		}, nil
+	}
+	deps.vacRepo.ListUpcomingApprovedFn = func(ctx context.Context, from, to string) ([]*domain.TeamVacation, error) {
+		return []*domain.TeamVacation{{ID: "vac-3", UserID: "user-1", UserName: "Alice"}}, nil
 	}
 
-	req := httptest.NewRequest(http.MethodGet, "/api/admin/users?page=3&limit=10", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/dashboard", nil)
 	w := httptest.NewRecorder()
 	deps.router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Equal(t, 10, capturedLimit)
-	assert.Equal(t, 20, capturedOffset) // (page-1) * limit = (3-1) * 10
 
-	var resp dto.UserListResponse
+	var resp dto.AdminDashboardResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, 3, resp.Pagination.Page)
-	assert.Equal(t, 10, resp.Pagination.Limit)
-	assert.Equal(t, 50, resp.Pagination.Total)
-	assert.Equal(t, 5, resp.Pagination.TotalPages)
+	assert.Equal(t, 5, resp.TotalUsers)
+	assert.Equal(t, 2, resp.PendingRequests)
+	require.Len(t, resp.UpcomingVacations, 1)
+	assert.Equal(t, "vac-3", resp.UpcomingVacations[0].ID)
+	assert.Equal(t, 1, resp.LowBalanceCount)
+	assert.Equal(t, service.LowBalanceThreshold, gotThreshold)
+	assert.Equal(t, service.LowBalanceThreshold, resp.LowBalanceThreshold)
 }
 
-func TestAdminReview_VacationNotFound(t *testing.T) {
+func TestAdminDashboard_CustomThreshold(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
+	var gotThreshold int
+	deps.userRepo.GetLowBalanceUsersFn = func(ctx context.Context, threshold int) ([]*domain.User, error) {
+		gotThreshold = threshold
 		return nil, nil
 	}
 
-	body := `{"status":"approved"}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/nonexistent/review", strings.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/dashboard?threshold=10", nil)
 	w := httptest.NewRecorder()
-
 	deps.router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusNotFound, w.Code)
-
-	var resp dto.ErrorResponse
-	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, dto.ErrNotFound, resp.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 10, gotThreshold)
 }
 
-func TestAdminReview_AlreadyProcessed(t *testing.T) {
+func TestAdminDashboard_InvalidThreshold(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	// Vacation is already approved, cannot be reviewed again
-	vacation := sampleVacation("vac-1", "user-10", domain.StatusApproved, 3)
-
-	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
-		if id == "vac-1" {
-			return vacation, nil
-		}
-		return nil, nil
-	}
-
-	body := `{"status":"rejected","reason":"too late"}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/dashboard?threshold=abc", nil)
 	w := httptest.NewRecorder()
-
 	deps.router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	var resp dto.ErrorResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, dto.ErrAlreadyExists, resp.Code)
+	assert.Equal(t, dto.ErrValidation, resp.Code)
 }
 
-func TestAdminGetSettings_RepoError(t *testing.T) {
+// ===================================================================
+// YearlyStats tests
+// ===================================================================
+
+func TestAdminYearlyStats_Success(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
-		return nil, fmt.Errorf("database connection lost")
+	var gotYear int
+	deps.vacRepo.GetYearlyStatsFn = func(ctx context.Context, year int) (*repository.YearlyStats, error) {
+		gotYear = year
+		stats := &repository.YearlyStats{}
+		stats.Months[11] = repository.MonthlyStats{TotalSubmitted: 2, TotalApproved: 2, TotalDaysUsed: 6}
+		stats.MonthlyStats = stats.Months[11]
+		return stats, nil
 	}
 
-	req := httptest.NewRequest(http.MethodGet, "/api/admin/settings", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/stats?year=2026", nil)
 	w := httptest.NewRecorder()
 	deps.router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2026, gotYear)
 
-	var resp dto.ErrorResponse
+	var resp dto.YearlyStatsResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, dto.ErrInternal, resp.Code)
+	assert.Equal(t, 2026, resp.Year)
+	assert.Equal(t, 6, resp.DaysUsed)
+	require.Len(t, resp.Months, 12)
+	assert.Equal(t, 12, resp.Months[11].Month)
+	assert.Equal(t, 2, resp.Months[11].Approved)
+	assert.Contains(t, w.Body.String(), `"totalSubmitted":2`)
 }
 
-func TestAdminUpdateSettings_WeekendPolicy(t *testing.T) {
+func TestAdminYearlyStats_InvalidYear(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	settings := domain.DefaultSettings()
-
-	callCount := 0
-	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
-		callCount++
-		if callCount == 1 {
-			return &settings, nil
-		}
-		updated := settings
-		updated.WeekendPolicy.ExcludeWeekends = false
-		return &updated, nil
-	}
-	deps.settingsRepo.UpdateFn = func(ctx context.Context, s *domain.Settings) error {
-		return nil
-	}
-
-	body := `{"weekendPolicy":{"excludeWeekends":false}}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/stats?year=1800", nil)
 	w := httptest.NewRecorder()
-
 	deps.router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusOK, w.Code)
-
-	var resp dto.SettingsResponse
-	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.False(t, resp.WeekendPolicy.ExcludeWeekends)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
-func TestAdminDeleteUser_LastAdmin(t *testing.T) {
+func TestAdminEmailLog_Success(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	// Target is a different admin, but they are the last admin.
-	user := sampleUser("other-admin", "otheradmin@test.com", "Other Admin", domain.RoleAdmin, 25)
-
-	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
-		if id == "other-admin" {
-			return user, nil
-		}
-		return nil, nil
-	}
-	deps.userRepo.CountByRoleFn = func(ctx context.Context, role domain.Role) (int, error) {
-		if role == domain.RoleAdmin {
-			return 1, nil // only one admin left
-		}
-		return 0, nil
+	var gotLimit int
+	sentAt := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	deps.outboxRepo.ListRecentFn = func(ctx context.Context, limit int) ([]*domain.EmailMessage, error) {
+		gotLimit = limit
+		return []*domain.EmailMessage{
+			{ID: "m2", Recipient: "bob@test.com", Subject: "Approved", HTMLBody: "<p>secret</p>", Tags: []string{}, Status: domain.EmailStatusRetrying, Attempts: 2, LastError: "503"},
+			{ID: "m1", Recipient: "alice@test.com", Subject: "Welcome", Tags: []string{"welcome"}, Status: domain.EmailStatusSent, Attempts: 1, SentAt: &sentAt},
+		}, nil
 	}
 
-	req := httptest.NewRequest(http.MethodDelete, "/api/admin/users/other-admin", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/email/log?limit=10", nil)
 	w := httptest.NewRecorder()
 	deps.router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 10, gotLimit)
+	assert.NotContains(t, w.Body.String(), "secret")
 
-	var resp dto.ErrorResponse
+	var resp dto.EmailLogResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, dto.ErrForbidden, resp.Code)
-	assert.Contains(t, resp.Message, "last admin")
+	assert.Equal(t, 2, resp.Total)
+	assert.Equal(t, domain.EmailStatusRetrying, resp.Emails[0].Status)
+	assert.Equal(t, "503", resp.Emails[0].LastError)
+	assert.Equal(t, domain.EmailStatusSent, resp.Emails[1].Status)
 }
 
-func TestAdminCreateUser_WithCustomBalance(t *testing.T) {
+func TestAdminEmailLog_DefaultLimitAndEmpty(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	deps.userRepo.EmailExistsFn = func(ctx context.Context, email string) (bool, error) {
-		return false, nil
-	}
-	deps.userRepo.CreateFn = func(ctx context.Context, user *domain.User) error {
-		return nil
+	var gotLimit int
+	deps.outboxRepo.ListRecentFn = func(ctx context.Context, limit int) ([]*domain.EmailMessage, error) {
+		gotLimit = limit
+		return nil, nil
 	}
 
-	body := `{"email":"custom@test.com","password":"securePass1","name":"Custom Balance","role":"employee","vacationBalance":15}`
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/users", strings.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/email/log?limit=5000", nil)
 	w := httptest.NewRecorder()
-
 	deps.router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusCreated, w.Code)
-
-	var resp dto.UserResponse
-	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, 15, resp.VacationBalance)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 50, gotLimit)
+	assert.JSONEq(t, `{"emails":[],"total":0}`, w.Body.String())
 }
 
-func TestAdminListPending_Empty(t *testing.T) {
-	deps := setupAdminTest(t)
+// ---------------------------------------------------------------------------
+// POST /api/admin/email/validate
+// ---------------------------------------------------------------------------
 
-	deps.vacRepo.ListPendingFn = func(ctx context.Context) ([]*domain.VacationRequest, error) {
-		return []*domain.VacationRequest{}, nil
-	}
+func TestAdminValidateEmailTemplates(t *testing.T) {
+	deps := setupAdminTest(t)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/admin/vacation/pending", nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/email/validate", nil)
 	w := httptest.NewRecorder()
 	deps.router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var resp dto.VacationListResponse
+	var resp dto.EmailValidationResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, 0, resp.Total)
-	assert.Len(t, resp.Requests, 0)
+	assert.True(t, resp.Valid, "%+v", resp.Errors)
+	assert.Empty(t, resp.Errors)
+	assert.Greater(t, resp.Checked, 0)
 }
 
-func TestAdminReview_ApproveInsufficientBalance(t *testing.T) {
-	deps := setupAdminTest(t)
-
-	vacation := sampleVacation("vac-1", "user-10", domain.StatusPending, 10)
-	// User only has 5 days left, but request is for 10
-	user := sampleUser("user-10", "emp@test.com", "Employee", domain.RoleEmployee, 5)
+// ---------------------------------------------------------------------------
+// GET /api/admin/audit
+// ---------------------------------------------------------------------------
 
-	deps.vacRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.VacationRequest, error) {
-		if id == "vac-1" {
-			return vacation, nil
-		}
-		return nil, nil
-	}
+func TestAdminAuditLog_FiltersAndPaginates(t *testing.T) {
+	deps := setupAdminTest(t)
 
-	deps.userRepo.GetByIDFn = func(ctx context.Context, id string) (*domain.User, error) {
-		if id == "user-10" {
-			return user, nil
-		}
-		return nil, nil
+	deps.auditRepo.ListFn = func(ctx context.Context, filter domain.AuditFilter, limit, offset int) ([]*domain.AuditEntry, int, error) {
+		assert.Equal(t, domain.AuditFilter{From: "2027-03-01", To: "2027-03-31", ActorID: "admin-2"}, filter)
+		assert.Equal(t, 10, limit)
+		assert.Equal(t, 10, offset)
+		return []*domain.AuditEntry{{
+			ID:         "audit-1",
+			ActorID:    "admin-2",
+			ActorName:  "Second Admin",
+			Action:     domain.AuditSettingsUpdate,
+			TargetType: domain.AuditTargetSettings,
+			TargetID:   "settings",
+			Before:     json.RawMessage(`{"minNoticeDays":0}`),
+			After:      json.RawMessage(`{"minNoticeDays":14}`),
+		}}, 11, nil
 	}
 
-	body := `{"status":"approved"}`
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/vacation/vac-1/review", strings.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/audit?from=2027-03-01&to=2027-03-31&actor=admin-2&page=2&limit=10", nil)
 	w := httptest.NewRecorder()
-
 	deps.router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Equal(t, http.StatusOK, w.Code)
 
-	var resp dto.ErrorResponse
+	var resp struct {
+		Entries []struct {
+			Action string          `json:"action"`
+			Before json.RawMessage `json:"before"`
+			After  json.RawMessage `json:"after"`
+		} `json:"entries"`
+		Pagination dto.PaginationInfo `json:"pagination"`
+	}
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, dto.ErrInsufficientBalance, resp.Code)
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, "settings.update", resp.Entries[0].Action)
+	assert.JSONEq(t, `{"minNoticeDays":0}`, string(resp.Entries[0].Before))
+	assert.JSONEq(t, `{"minNoticeDays":14}`, string(resp.Entries[0].After))
+	assert.Equal(t, dto.PaginationInfo{Page: 2, Limit: 10, Total: 11, TotalPages: 2}, resp.Pagination)
 }
 
-func TestAdminResetBalances_SettingsRepoError(t *testing.T) {
+func TestAdminAuditLog_InvalidDate(t *testing.T) {
 	deps := setupAdminTest(t)
 
-	deps.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
-		return nil, fmt.Errorf("settings database error")
-	}
-
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/reset-balances", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/audit?from=01/03/2027", nil)
 	w := httptest.NewRecorder()
 	deps.router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
-
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 	var resp dto.ErrorResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, dto.ErrInternal, resp.Code)
+	assert.Equal(t, dto.ErrValidation, resp.Code)
 }
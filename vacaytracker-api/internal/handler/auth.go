@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"errors"
+	"html/template"
+	"io"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	"vacaytracker-api/internal/domain"
 	"vacaytracker-api/internal/dto"
 	"vacaytracker-api/internal/middleware"
 	"vacaytracker-api/internal/service"
@@ -12,13 +17,15 @@ import (
 
 // AuthHandler handles authentication endpoints
 type AuthHandler struct {
-	authService *service.AuthService
+	authService  *service.AuthService
+	emailService *service.EmailService
 }
 
 // NewAuthHandler creates a new AuthHandler
-func NewAuthHandler(authService *service.AuthService) *AuthHandler {
+func NewAuthHandler(authService *service.AuthService, emailService *service.EmailService) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
+		authService:  authService,
+		emailService: emailService,
 	}
 }
 
@@ -29,10 +36,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// Bind and validate request
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Code:    dto.ErrValidation,
-			Message: "Invalid request body: " + err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
 		return
 	}
 
@@ -50,13 +54,88 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// Return token and user
+	refreshToken, err := h.authService.GenerateRefreshToken(c.Request.Context(), user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    dto.ErrInternal,
+			Message: "Login failed",
+		})
+		return
+	}
+
+	// Return tokens and user
 	c.JSON(http.StatusOK, dto.LoginResponse{
-		Token: token,
-		User:  dto.ToUserResponse(user),
+		Token:        token,
+		RefreshToken: refreshToken,
+		User:         dto.ToUserResponse(user),
 	})
 }
 
+// Refresh handles POST /api/auth/refresh
+// Exchanges a refresh token for a new access token, rotating the refresh token
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
+
+	token, refreshToken, user, err := h.authService.RefreshAccessToken(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to refresh token",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LoginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		User:         dto.ToUserResponse(user),
+	})
+}
+
+// Logout handles POST /api/auth/logout
+// Revokes the presented access token, and the refresh token in the optional body, before they expire
+func (h *AuthHandler) Logout(c *gin.Context) {
+	claims := middleware.GetClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	// The body is optional; an empty one only revokes the access token
+	var req dto.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), claims, req.RefreshToken); err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to log out",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "Logged out"})
+}
+
 // Me handles GET /api/auth/me
 // Returns the currently authenticated user
 func (h *AuthHandler) Me(c *gin.Context) {
@@ -102,10 +181,7 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 
 	// Bind and validate request
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Code:    dto.ErrValidation,
-			Message: "Invalid request body: " + err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
 		return
 	}
 
@@ -128,6 +204,129 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	})
 }
 
+// ForgotPassword handles POST /api/auth/forgot-password
+// Emails a password reset link; always succeeds so account existence isn't revealed
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
+
+	user, token, err := h.authService.RequestPasswordReset(c.Request.Context(), req.Email)
+	if err != nil {
+		log.Printf("ERROR: failed to create password reset token: %v", err)
+	} else if user != nil {
+		h.emailService.SendPasswordReset(user, token)
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "If an account exists for that email, a reset link has been sent",
+	})
+}
+
+// ResetPassword handles POST /api/auth/reset-password
+// Sets a new password using a token from a reset email
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
+
+	err := h.authService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to reset password",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Password reset successfully",
+	})
+}
+
+// ChangeEmail handles POST /api/auth/change-email
+// Stores the new address as pending and emails a confirmation link to it
+func (h *AuthHandler) ChangeEmail(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req dto.ChangeEmailRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
+
+	user, token, err := h.authService.RequestEmailChange(c.Request.Context(), userID, req.CurrentPassword, req.NewEmail)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to change email",
+			})
+		}
+		return
+	}
+
+	h.emailService.SendEmailChangeConfirmation(user, req.NewEmail, token)
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "A confirmation link has been sent to the new email address",
+	})
+}
+
+// ConfirmEmail handles POST /api/auth/confirm-email?token=
+// Switches the account to its pending email using a token from the confirmation email
+func (h *AuthHandler) ConfirmEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    dto.ErrValidation,
+			Message: "token is required",
+		})
+		return
+	}
+
+	user, previousEmail, err := h.authService.ConfirmEmailChange(c.Request.Context(), token)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to confirm email",
+			})
+		}
+		return
+	}
+
+	// Let the previous address know, in case the change wasn't the owner's doing
+	h.emailService.SendEmailChangedNotice(user, previousEmail)
+
+	c.JSON(http.StatusOK, dto.ToUserResponse(user))
+}
+
 // UpdateEmailPreferences handles PUT /api/auth/email-preferences
 // Updates the current user's email notification preferences
 func (h *AuthHandler) UpdateEmailPreferences(c *gin.Context) {
@@ -144,10 +343,7 @@ func (h *AuthHandler) UpdateEmailPreferences(c *gin.Context) {
 
 	// Bind and validate request
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Code:    dto.ErrValidation,
-			Message: "Invalid request body: " + err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
 		return
 	}
 
@@ -169,3 +365,78 @@ func (h *AuthHandler) UpdateEmailPreferences(c *gin.Context) {
 		"emailPreferences": user.EmailPreferences,
 	})
 }
+
+// Unsubscribe handles GET /api/email/unsubscribe
+// Turns off the email preference named in a signed link from an email, without requiring login,
+// and answers with a small HTML page since it is opened straight from the mail client
+func (h *AuthHandler) Unsubscribe(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		renderUnsubscribePage(c, http.StatusBadRequest, unsubscribePageData{
+			Title:   "Invalid link",
+			Message: "This unsubscribe link is incomplete. You can change your email preferences from your VacayTracker profile.",
+		})
+		return
+	}
+
+	_, preference, err := h.authService.Unsubscribe(c.Request.Context(), token)
+	if err != nil {
+		status := http.StatusBadRequest
+		message := "This unsubscribe link is invalid or has expired. You can change your email preferences from your VacayTracker profile."
+		if appErr, ok := err.(*dto.AppError); ok && appErr.HTTPStatus == http.StatusInternalServerError {
+			status = http.StatusInternalServerError
+			message = "We couldn't update your email preferences. Please try again later."
+		}
+		renderUnsubscribePage(c, status, unsubscribePageData{Title: "Unsubscribe failed", Message: message})
+		return
+	}
+
+	renderUnsubscribePage(c, http.StatusOK, unsubscribePageData{
+		Title:   "You're unsubscribed",
+		Message: "You will no longer receive " + emailPreferenceLabel(preference) + ". You can turn them back on from your VacayTracker profile.",
+	})
+}
+
+// emailPreferenceLabel describes an email preference for the unsubscribe page
+func emailPreferenceLabel(preference string) string {
+	switch preference {
+	case domain.EmailPrefWeeklyDigest:
+		return "digest emails"
+	case domain.EmailPrefVacationUpdates:
+		return "vacation update emails"
+	case domain.EmailPrefTeamNotifications:
+		return "team notification emails"
+	default:
+		return "these emails"
+	}
+}
+
+type unsubscribePageData struct {
+	Title   string
+	Message string
+}
+
+// unsubscribePage is kept free of inline styles and scripts so it renders under the production CSP
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}} - VacayTracker</title>
+</head>
+<body>
+    <main>
+        <h1>{{.Title}}</h1>
+        <p>{{.Message}}</p>
+    </main>
+</body>
+</html>`))
+
+// renderUnsubscribePage writes the unsubscribe confirmation page
+func renderUnsubscribePage(c *gin.Context, status int, data unsubscribePageData) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(status)
+	if err := unsubscribePage.Execute(c.Writer, data); err != nil {
+		log.Printf("ERROR: failed to render unsubscribe page: %v", err)
+	}
+}
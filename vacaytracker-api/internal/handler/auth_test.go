@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"vacaytracker-api/internal/domain"
 	"vacaytracker-api/internal/dto"
 	"vacaytracker-api/internal/handler"
+	"vacaytracker-api/internal/middleware"
 	"vacaytracker-api/internal/service"
 	"vacaytracker-api/internal/testutil"
 )
@@ -42,6 +44,7 @@ func newTestUser(id, email, name string, role domain.Role, balance int, password
 		Role:             role,
 		VacationBalance:  balance,
 		EmailPreferences: domain.DefaultEmailPreferences(),
+		Active:           true,
 		CreatedAt:        now,
 		UpdatedAt:        now,
 	}
@@ -73,8 +76,8 @@ func TestLogin_Success(t *testing.T) {
 			return nil, nil
 		},
 	}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.POST("/api/auth/login", h.Login)
@@ -91,6 +94,7 @@ func TestLogin_Success(t *testing.T) {
 	var resp dto.LoginResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
 	assert.NotEmpty(t, resp.Token)
+	assert.NotEmpty(t, resp.RefreshToken)
 	require.NotNil(t, resp.User)
 	assert.Equal(t, "user-1", resp.User.ID)
 	assert.Equal(t, "test@example.com", resp.User.Email)
@@ -103,8 +107,8 @@ func TestLogin_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := &testutil.MockUserRepository{}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.POST("/api/auth/login", h.Login)
@@ -126,8 +130,8 @@ func TestLogin_MissingEmail(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := &testutil.MockUserRepository{}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.POST("/api/auth/login", h.Login)
@@ -152,8 +156,8 @@ func TestLogin_MissingPassword(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := &testutil.MockUserRepository{}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.POST("/api/auth/login", h.Login)
@@ -182,8 +186,8 @@ func TestLogin_WrongEmail_UserNotFound(t *testing.T) {
 			return nil, nil // user not found
 		},
 	}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.POST("/api/auth/login", h.Login)
@@ -212,8 +216,8 @@ func TestLogin_WrongPassword(t *testing.T) {
 			return user, nil
 		},
 	}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.POST("/api/auth/login", h.Login)
@@ -232,10 +236,155 @@ func TestLogin_WrongPassword(t *testing.T) {
 	assert.Equal(t, dto.ErrInvalidCredentials, resp.Code)
 }
 
+// ===================================================================
+// Refresh tests
+// ===================================================================
+
+func TestRefresh_MissingToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authService := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.POST("/api/auth/refresh", h.Refresh)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/refresh", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRefresh_UnknownToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authService := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.POST("/api/auth/refresh", h.Refresh)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/refresh", strings.NewReader(`{"refreshToken":"unknown"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrAuthTokenInvalid, resp.Code)
+}
+
+func TestRefresh_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	user := newTestUser("user-1", "test@example.com", "Test User", domain.RoleEmployee, 25, "password123")
+	mockRepo := &testutil.MockUserRepository{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.User, error) {
+			return user, nil
+		},
+	}
+	stored := &domain.RefreshToken{ID: "rt-1", UserID: "user-1", ExpiresAt: time.Now().Add(time.Hour)}
+	refreshRepo := &testutil.MockRefreshTokenRepository{
+		GetByHashFn: func(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+			return stored, nil
+		},
+	}
+	authService := service.NewAuthService(mockRepo, refreshRepo, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.POST("/api/auth/refresh", h.Refresh)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/refresh", strings.NewReader(`{"refreshToken":"some-token"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.LoginResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.NotEmpty(t, resp.Token)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.NotEqual(t, "some-token", resp.RefreshToken)
+}
+
 // ===================================================================
 // Me tests
 // ===================================================================
 
+func TestLogout_RevokesAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	user := newTestUser("user-1", "test@example.com", "Test User", domain.RoleEmployee, 25, "password123")
+	mockRepo := &testutil.MockUserRepository{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.User, error) {
+			return user, nil
+		},
+	}
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.POST("/api/auth/logout", middleware.AuthMiddleware(authService), h.Logout)
+	router.GET("/api/auth/me", middleware.AuthMiddleware(authService), h.Me)
+
+	token, err := authService.GenerateToken(user)
+	require.NoError(t, err)
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		var req *http.Request
+		if body == "" {
+			req = httptest.NewRequest(method, path, nil)
+		} else {
+			req = httptest.NewRequest(method, path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, "/api/auth/me", "").Code)
+
+	// The body is optional
+	w := send(http.MethodPost, "/api/auth/logout", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = send(http.MethodGet, "/api/auth/me", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrAuthTokenInvalid, resp.Code)
+}
+
+func TestLogout_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authService := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.POST("/api/auth/logout", middleware.AuthMiddleware(authService), h.Logout)
+
+	token, err := authService.GenerateToken(newTestUser("user-1", "test@example.com", "Test User", domain.RoleEmployee, 25, "password123"))
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/logout", strings.NewReader(`{"refreshToken": 42}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestMe_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -249,8 +398,8 @@ func TestMe_Success(t *testing.T) {
 			return nil, nil
 		},
 	}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.GET("/api/auth/me",
@@ -278,8 +427,8 @@ func TestMe_NoAuthContext(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := &testutil.MockUserRepository{}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	// No auth context middleware — userID will be empty.
@@ -305,8 +454,8 @@ func TestMe_UserNotFound(t *testing.T) {
 			return nil, nil // user not found
 		},
 	}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.GET("/api/auth/me",
@@ -346,8 +495,8 @@ func TestChangePassword_Success(t *testing.T) {
 			return nil
 		},
 	}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.PUT("/api/auth/password",
@@ -373,8 +522,8 @@ func TestChangePassword_NoAuthContext(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := &testutil.MockUserRepository{}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.PUT("/api/auth/password", h.ChangePassword)
@@ -397,8 +546,8 @@ func TestChangePassword_InvalidBody_MissingFields(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := &testutil.MockUserRepository{}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.PUT("/api/auth/password",
@@ -434,8 +583,8 @@ func TestChangePassword_WrongCurrentPassword(t *testing.T) {
 			return nil, nil
 		},
 	}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.PUT("/api/auth/password",
@@ -457,6 +606,255 @@ func TestChangePassword_WrongCurrentPassword(t *testing.T) {
 	assert.Equal(t, dto.ErrInvalidCredentials, resp.Code)
 }
 
+// ===================================================================
+// Password reset tests
+// ===================================================================
+
+func TestForgotPassword_SameResponseForKnownAndUnknownEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := &testutil.MockUserRepository{}
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.POST("/api/auth/forgot-password", h.ForgotPassword)
+
+	for _, email := range []string{"test@example.com", "nobody@example.com"} {
+		if email == "test@example.com" {
+			user := newTestUser("user-1", email, "Test User", domain.RoleEmployee, 25, "password123")
+			mockRepo.GetByEmailFn = func(ctx context.Context, e string) (*domain.User, error) {
+				return user, nil
+			}
+		} else {
+			mockRepo.GetByEmailFn = nil
+		}
+
+		body := fmt.Sprintf(`{"email":%q}`, email)
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/forgot-password", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, email)
+	}
+}
+
+func TestForgotPassword_InvalidEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authService := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.POST("/api/auth/forgot-password", h.ForgotPassword)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/forgot-password", strings.NewReader(`{"email":"not-an-email"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	user := newTestUser("user-1", "test@example.com", "Test User", domain.RoleEmployee, 25, "password123")
+	var updatedHash string
+	mockRepo := &testutil.MockUserRepository{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.User, error) {
+			return user, nil
+		},
+		UpdatePasswordFn: func(ctx context.Context, id, passwordHash string) error {
+			updatedHash = passwordHash
+			return nil
+		},
+	}
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	token, err := authService.GeneratePasswordResetToken(user)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/api/auth/reset-password", h.ResetPassword)
+
+	body := fmt.Sprintf(`{"token":%q,"newPassword":"newPassword456"}`, token)
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/reset-password", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(updatedHash), []byte("newPassword456")))
+}
+
+func TestResetPassword_AccessTokenRejected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	user := newTestUser("user-1", "test@example.com", "Test User", domain.RoleEmployee, 25, "password123")
+	mockRepo := &testutil.MockUserRepository{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.User, error) {
+			return user, nil
+		},
+	}
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	token, err := authService.GenerateToken(user)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/api/auth/reset-password", h.ResetPassword)
+
+	body := fmt.Sprintf(`{"token":%q,"newPassword":"newPassword456"}`, token)
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/reset-password", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrAuthTokenInvalid, resp.Code)
+}
+
+// ===================================================================
+// ChangeEmail / ConfirmEmail tests
+// ===================================================================
+
+func TestChangeEmail_ConfirmedFromEmailedToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	user := newTestUser("user-1", "test@example.com", "Test User", domain.RoleEmployee, 25, "password123")
+	mockRepo := &testutil.MockUserRepository{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.User, error) {
+			copied := *user
+			return &copied, nil
+		},
+		SetPendingEmailFn: func(ctx context.Context, id, email string) error {
+			user.PendingEmail = &email
+			return nil
+		},
+		ConfirmPendingEmailFn: func(ctx context.Context, id, email string) error {
+			user.Email = email
+			user.PendingEmail = nil
+			return nil
+		},
+	}
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.POST("/api/auth/change-email",
+		authContextMiddleware("user-1", "test@example.com", "Test User", domain.RoleEmployee),
+		h.ChangeEmail,
+	)
+	router.POST("/api/auth/confirm-email", h.ConfirmEmail)
+
+	body := `{"newEmail":"new@example.com","currentPassword":"password123"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/change-email", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "test@example.com", user.Email)
+	require.NotNil(t, user.PendingEmail)
+	assert.Equal(t, "new@example.com", *user.PendingEmail)
+
+	// The token itself only travels by email; mint the same one the handler sent
+	token, err := authService.GenerateEmailChangeToken(user, "new@example.com")
+	require.NoError(t, err)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/auth/confirm-email?token="+url.QueryEscape(token), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dto.UserResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "new@example.com", resp.Email)
+	assert.Nil(t, resp.PendingEmail)
+}
+
+func TestChangeEmail_EmailTaken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	user := newTestUser("user-1", "test@example.com", "Test User", domain.RoleEmployee, 25, "password123")
+	mockRepo := &testutil.MockUserRepository{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.User, error) {
+			return user, nil
+		},
+		EmailExistsExcludingFn: func(ctx context.Context, email, excludeID string) (bool, error) {
+			return true, nil
+		},
+		SetPendingEmailFn: func(ctx context.Context, id, email string) error {
+			t.Fatal("pending email must not be stored for a taken address")
+			return nil
+		},
+	}
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.POST("/api/auth/change-email",
+		authContextMiddleware("user-1", "test@example.com", "Test User", domain.RoleEmployee),
+		h.ChangeEmail,
+	)
+
+	body := `{"newEmail":"taken@example.com","currentPassword":"password123"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/change-email", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestChangeEmail_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authService := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.POST("/api/auth/change-email",
+		authContextMiddleware("user-1", "test@example.com", "Test User", domain.RoleEmployee),
+		h.ChangeEmail,
+	)
+
+	body := `{"newEmail":"not-an-email","currentPassword":"password123"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/change-email", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestConfirmEmail_MissingToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authService := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.POST("/api/auth/confirm-email", h.ConfirmEmail)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/confirm-email", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 // ===================================================================
 // UpdateEmailPreferences tests
 // ===================================================================
@@ -491,8 +889,8 @@ func TestUpdateEmailPreferences_Success(t *testing.T) {
 			return nil
 		},
 	}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.PUT("/api/auth/email-preferences",
@@ -523,8 +921,8 @@ func TestUpdateEmailPreferences_NoAuthContext(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := &testutil.MockUserRepository{}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.PUT("/api/auth/email-preferences", h.UpdateEmailPreferences)
@@ -547,8 +945,8 @@ func TestUpdateEmailPreferences_InvalidBody(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := &testutil.MockUserRepository{}
-	authService := service.NewAuthService(mockRepo, testJWTSecret)
-	h := handler.NewAuthHandler(authService)
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
 
 	router := gin.New()
 	router.PUT("/api/auth/email-preferences",
@@ -630,3 +1028,63 @@ func TestGetPublicSettings_RepoError(t *testing.T) {
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
 	assert.Contains(t, resp["error"], "Failed to get settings")
 }
+
+// ===================================================================
+// Unsubscribe tests
+// ===================================================================
+
+func TestUnsubscribe_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	user := newTestUser("user-1", "test@example.com", "Test User", domain.RoleEmployee, 25, "password123")
+	user.EmailPreferences.WeeklyDigest = true
+	var saved domain.EmailPreferences
+	mockRepo := &testutil.MockUserRepository{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.User, error) {
+			return user, nil
+		},
+		UpdateEmailPreferencesFn: func(ctx context.Context, id string, prefs domain.EmailPreferences) error {
+			saved = prefs
+			return nil
+		},
+	}
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	token, err := authService.GenerateUnsubscribeToken(user, domain.EmailPrefWeeklyDigest)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/api/email/unsubscribe", h.Unsubscribe)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/email/unsubscribe?token="+token, nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), "digest emails")
+	assert.False(t, saved.WeeklyDigest)
+	assert.True(t, saved.VacationUpdates)
+}
+
+func TestUnsubscribe_InvalidToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authService := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+	h := handler.NewAuthHandler(authService, newTestEmailService())
+
+	router := gin.New()
+	router.GET("/api/email/unsubscribe", h.Unsubscribe)
+
+	for _, target := range []string{"/api/email/unsubscribe", "/api/email/unsubscribe?token=not-a-token"} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, target)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/html", target)
+	}
+}
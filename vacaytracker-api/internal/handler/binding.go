@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"vacaytracker-api/internal/dto"
+)
+
+// bindingErrorResponse builds the 400 body for a request that failed ShouldBindJSON into req
+// The message keeps the raw binding error; Errors lists the offending fields by JSON path when they can be told apart
+func bindingErrorResponse(err error, req any) dto.ErrorResponse {
+	return dto.ErrorResponse{
+		Code:    dto.ErrValidation,
+		Message: "Invalid request body: " + err.Error(),
+		Errors:  fieldErrors(err, reflect.TypeOf(req)),
+	}
+}
+
+// fieldErrors translates validator and JSON type errors into per-field messages keyed by JSON path
+// Malformed JSON has no field to blame and yields nil
+func fieldErrors(err error, reqType reflect.Type) dto.ValidationErrors {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make(dto.ValidationErrors, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields[jsonPath(reqType, fe.StructNamespace())] = fieldErrorMessage(fe)
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return dto.ValidationErrors{typeErr.Field: "must be of type " + typeErr.Type.String()}
+	}
+
+	return nil
+}
+
+// jsonPath maps a validator struct namespace onto the JSON names of reqType's fields,
+// turning "UpdateSettingsRequest.ApprovalLevels[0].Name" into "approvalLevels[0].name"
+// Fields that can't be resolved keep their Go name
+func jsonPath(reqType reflect.Type, namespace string) string {
+	segments := strings.Split(namespace, ".")
+	if len(segments) < 2 {
+		return namespace
+	}
+	segments = segments[1:] // The request struct's own name
+
+	t := reqType
+	for i, segment := range segments {
+		name, indexes, _ := strings.Cut(segment, "[")
+
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		var field reflect.StructField
+		found := false
+		if t != nil && t.Kind() == reflect.Struct {
+			field, found = t.FieldByName(name)
+		}
+		if !found {
+			t = nil
+			continue
+		}
+
+		t = field.Type
+		if indexes != "" {
+			// Each index steps into the element type of a slice, array or map
+			for range strings.Count(indexes, "[") + 1 {
+				for t.Kind() == reflect.Pointer {
+					t = t.Elem()
+				}
+				if k := t.Kind(); k != reflect.Slice && k != reflect.Array && k != reflect.Map {
+					break
+				}
+				t = t.Elem()
+			}
+			indexes = "[" + indexes
+		}
+		segments[i] = jsonFieldName(field) + indexes
+	}
+	return strings.Join(segments, ".")
+}
+
+// jsonFieldName returns the name a struct field has in JSON
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// fieldErrorMessage describes a failed validation tag in plain words
+func fieldErrorMessage(fe validator.FieldError) string {
+	isString := fe.Kind() == reflect.String
+	isList := fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "min":
+		switch {
+		case isString:
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		case isList:
+			return fmt.Sprintf("must have at least %s items", fe.Param())
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		switch {
+		case isString:
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		case isList:
+			return fmt.Sprintf("must have at most %s items", fe.Param())
+		}
+		return "must be at most " + fe.Param()
+	}
+	return fmt.Sprintf("failed the %q check", fe.Tag())
+}
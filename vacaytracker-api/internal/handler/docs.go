@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/middleware"
+	"vacaytracker-api/internal/openapi"
+)
+
+// DocsHandler serves the OpenAPI description of the API and a Swagger UI for it
+type DocsHandler struct {
+	routes func() gin.RoutesInfo
+
+	once sync.Once
+	spec []byte
+	err  error
+}
+
+// NewDocsHandler creates a new DocsHandler
+// routes is read on first use, so it sees routes registered after the handler was created
+func NewDocsHandler(routes func() gin.RoutesInfo) *DocsHandler {
+	return &DocsHandler{routes: routes}
+}
+
+// Spec handles GET /api/v1/openapi.json
+// Returns the OpenAPI 3 document generated from the route table, describing the /api/v1 routes
+func (h *DocsHandler) Spec(c *gin.Context) {
+	h.once.Do(func() {
+		h.spec, h.err = openapi.Build(openapi.Info{
+			Title:         "VacayTracker API",
+			Version:       version,
+			PathPrefix:    middleware.APIVersionPrefix,
+			ErrorResponse: dto.ErrorResponse{},
+			ErrorCodes:    dto.ErrorCodes,
+		}, h.routes(), endpointDocs)
+		if h.err != nil {
+			log.Printf("ERROR: failed to build OpenAPI document: %v", h.err)
+		}
+	})
+
+	if h.err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    dto.ErrInternal,
+			Message: "Failed to build API documentation",
+		})
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// swaggerUIVersion pins the Swagger UI bundle loaded from the CDN
+const swaggerUIVersion = "5.17.14"
+
+// docsCSP relaxes the default policy just enough to load Swagger UI from the CDN
+const docsCSP = "default-src 'self'; script-src 'self' https://unpkg.com; style-src 'self' 'unsafe-inline' https://unpkg.com; img-src 'self' data: https://unpkg.com; font-src 'self'; connect-src 'self'"
+
+// UI handles GET /docs
+// Serves a Swagger UI page pointed at /api/v1/openapi.json
+func (h *DocsHandler) UI(c *gin.Context) {
+	c.Header("Content-Security-Policy", docsCSP)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>VacayTracker API</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@`+swaggerUIVersion+`/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@`+swaggerUIVersion+`/swagger-ui-bundle.js"></script>
+<script src="/docs/init.js"></script>
+</body>
+</html>
+`))
+}
+
+// UIInit handles GET /docs/init.js
+// Kept out of the page so the CSP needs no inline scripts
+func (h *DocsHandler) UIInit(c *gin.Context) {
+	c.Data(http.StatusOK, "text/javascript; charset=utf-8", []byte(`window.ui = SwaggerUIBundle({ url: "/api/v1/openapi.json", dom_id: "#swagger-ui" });
+`))
+}
+
+// endpointDocs describes each handler for the OpenAPI document, keyed by "Type.Method"
+var endpointDocs = map[string]openapi.Endpoint{
+	// Auth
+	"AuthHandler.Login":                  {Summary: "Log in with email and password", Request: dto.LoginRequest{}, Response: dto.LoginResponse{}, Public: true},
+	"AuthHandler.ForgotPassword":         {Summary: "Email a password reset link", Request: dto.ForgotPasswordRequest{}, Response: dto.MessageResponse{}, Public: true},
+	"AuthHandler.ResetPassword":          {Summary: "Reset a password with an emailed token", Request: dto.ResetPasswordRequest{}, Response: dto.MessageResponse{}, Public: true},
+	"AuthHandler.ConfirmEmail":           {Summary: "Confirm a pending email change with an emailed token", Query: []string{"token"}, Response: dto.UserResponse{}, Public: true},
+	"AuthHandler.Refresh":                {Summary: "Exchange a refresh token for new tokens", Request: dto.RefreshTokenRequest{}, Response: dto.LoginResponse{}, Public: true},
+	"AuthHandler.Unsubscribe":            {Summary: "Unsubscribe from an email category via a signed link", Query: []string{"token"}, ContentType: "text/html", Public: true},
+	"AuthHandler.Logout":                 {Summary: "Revoke the current access token and optionally a refresh token", Request: dto.LogoutRequest{}, Response: dto.MessageResponse{}},
+	"AuthHandler.Me":                     {Summary: "Get the current user", Response: dto.UserResponse{}},
+	"AuthHandler.ChangePassword":         {Summary: "Change the current user's password", Request: dto.ChangePasswordRequest{}, Response: dto.MessageResponse{}},
+	"AuthHandler.ChangeEmail":            {Summary: "Request an email change, confirmed from the new address", Request: dto.ChangeEmailRequest{}, Response: dto.MessageResponse{}},
+	"AuthHandler.UpdateEmailPreferences": {Summary: "Update the current user's email preferences", Request: dto.UpdateEmailPreferencesRequest{}},
+
+	// Vacation
+	"VacationHandler.Create":             {Summary: "Create a vacation request", Request: dto.CreateVacationRequest{}, Response: dto.VacationRequestResponse{}, Status: http.StatusCreated},
+	"VacationHandler.Suggest":            {Summary: "Suggest conflict-free date ranges", Request: dto.SuggestVacationRequest{}, Response: dto.VacationSuggestionsResponse{}},
+	"VacationHandler.List":               {Summary: "List the current user's requests, or with ids the given requests", Query: []string{"status", "year", "from", "to", "ids"}, Response: dto.VacationListResponse{}},
+	"VacationHandler.MyCalendar":         {Summary: "Download the current user's approved leave as iCalendar", ContentType: "text/calendar"},
+	"VacationHandler.Get":                {Summary: "Get a request", Query: []string{"includeComments"}, Response: dto.VacationRequestResponse{}},
+	"VacationHandler.Cancel":             {Summary: "Cancel a request", Response: dto.MessageResponse{}},
+	"VacationHandler.Submit":             {Summary: "Submit a draft for review", Response: dto.VacationRequestResponse{}},
+	"VacationHandler.Comments":           {Summary: "List the comments on a request", Response: dto.CommentListResponse{}},
+	"VacationHandler.AddComment":         {Summary: "Comment on a request", Request: dto.CreateCommentRequest{}, Response: dto.CommentResponse{}, Status: http.StatusCreated},
+	"VacationHandler.Attachments":        {Summary: "List the files attached to a request", Response: dto.AttachmentListResponse{}},
+	"VacationHandler.UploadAttachment":   {Summary: "Attach a PDF, JPEG or PNG file to a request", Multipart: true, Response: dto.AttachmentResponse{}, Status: http.StatusCreated},
+	"VacationHandler.DownloadAttachment": {Summary: "Download an attached file", ContentType: "application/octet-stream"},
+	"VacationHandler.Drafts":             {Summary: "List the current user's drafts", Response: dto.VacationListResponse{}},
+	"VacationHandler.Team":               {Summary: "Get the team's approved vacations for a month, optionally with pending ones", Query: []string{"month", "year", "teamId", "includePending"}, Response: dto.TeamVacationResponse{}},
+	"VacationHandler.WorkingDays":        {Summary: "Classify each date of a range as a business or weekend day", Query: []string{"from", "to"}, Response: dto.WorkingDaysResponse{}},
+	"VacationHandler.TeamWeeks":          {Summary: "Get the team's approved vacations for a month grouped by ISO week", Query: []string{"month", "year", "teamId"}, Response: dto.TeamWeeksResponse{}},
+	"VacationHandler.TeamCalendar":       {Summary: "Download the team's vacations for a month as iCalendar", Query: []string{"month", "year", "teamId"}, ContentType: "text/calendar"},
+	"VacationHandler.Gantt":              {Summary: "Get a Gantt chart of approved vacations", Query: []string{"from", "to", "teamId"}, Response: dto.GanttResponse{}},
+	"VacationHandler.Statement":          {Summary: "Get the current user's leave statement", Query: []string{"year"}, Response: dto.LeaveStatementResponse{}},
+	"VacationHandler.Report":             {Summary: "Get an annual leave report", Query: []string{"year"}, Response: dto.AnnualReportResponse{}},
+
+	// Settings
+	"SettingsHandler.GetPublic": {Summary: "Get the public settings", Response: PublicSettingsResponse{}},
+
+	// Admin: users
+	"AdminHandler.ListUsers":         {Summary: "List users", Query: []string{"role", "active", "search", "email", "page", "limit", "after", "sort", "order"}, Response: dto.UserListResponse{}},
+	"AdminHandler.CreateUser":        {Summary: "Create a user", Request: dto.CreateUserRequest{}, Response: dto.UserResponse{}, Status: http.StatusCreated},
+	"AdminHandler.GetUser":           {Summary: "Get a user", Response: dto.UserResponse{}},
+	"AdminHandler.UpdateUser":        {Summary: "Update a user", Request: dto.UpdateUserRequest{}, Response: dto.UserResponse{}},
+	"AdminHandler.DeleteUser":        {Summary: "Delete a user", Response: dto.MessageResponse{}},
+	"AdminHandler.RestoreUser":       {Summary: "Restore a deleted user", Response: dto.UserResponse{}},
+	"AdminHandler.UpdateUserStatus":  {Summary: "Activate or deactivate a user", Request: dto.UpdateUserStatusRequest{}, Response: dto.UserResponse{}},
+	"AdminHandler.UpdateBalance":     {Summary: "Set a user's vacation balance", Request: dto.UpdateVacationBalanceRequest{}, Response: dto.UserResponse{}},
+	"AdminHandler.UserStatement":     {Summary: "Get a user's leave statement", Query: []string{"year"}, Response: dto.LeaveStatementResponse{}},
+	"AdminHandler.UserVacations":     {Summary: "List a user's vacation requests", Query: []string{"status", "year"}, Response: dto.VacationListResponse{}},
+	"AdminHandler.UserReport":        {Summary: "Get a user's annual leave report", Query: []string{"year"}, Response: dto.AnnualReportResponse{}},
+	"AdminHandler.ProratedBalance":   {Summary: "Preview the prorated balance for a start date", Query: []string{"startDate"}, Response: dto.ProratedBalanceResponse{}},
+	"AdminHandler.ResetPreview":      {Summary: "Preview a balance reset without applying it", Response: dto.ResetPreviewResponse{}},
+	"AdminHandler.ResetBalances":     {Summary: "Reset every user's balance", Response: dto.ResetBalancesResponse{}},
+	"AdminHandler.AdjustBalances":    {Summary: "Add or subtract days for selected users", Request: dto.AdjustBalancesRequest{}, Response: dto.AdjustBalancesResponse{}},
+	"AdminHandler.ReconcileBalances": {Summary: "Compare balances with the ledger", Response: dto.BalanceReconcileResponse{}},
+	"AdminHandler.ListBalances":      {Summary: "List every active employee's balance (CSV with Accept: text/csv)", Query: []string{"year", "sort", "order"}, Response: dto.EmployeeBalancesResponse{}},
+
+	// Admin: teams
+	"TeamHandler.List":   {Summary: "List teams", Response: dto.TeamListResponse{}},
+	"TeamHandler.Create": {Summary: "Create a team", Request: dto.TeamRequest{}, Response: domain.Team{}, Status: http.StatusCreated},
+	"TeamHandler.Update": {Summary: "Rename a team", Request: dto.TeamRequest{}, Response: domain.Team{}},
+	"TeamHandler.Delete": {Summary: "Delete a team", Response: dto.MessageResponse{}},
+
+	// Admin: vacation management and reports
+	"AdminHandler.ListPending":        {Summary: "List requests awaiting review", Query: []string{"from", "to"}, Response: dto.VacationListResponse{}},
+	"AdminHandler.ListStale":          {Summary: "List requests awaiting review for more than N days", Query: []string{"days"}, Response: dto.VacationListResponse{}},
+	"AdminHandler.Review":             {Summary: "Approve or reject a request", Request: dto.ReviewVacationRequest{}, Response: dto.VacationRequestResponse{}},
+	"AdminHandler.RecomputeTotalDays": {Summary: "Recompute a pending request's total days", Response: dto.RecomputeTotalDaysResponse{}},
+	"AdminHandler.Coverage":           {Summary: "Get staffing coverage for a date range", Query: []string{"from", "to", "teamId"}, Response: dto.CoverageResponse{}},
+	"AdminHandler.ComplianceReport":   {Summary: "Get the leave compliance report", Query: []string{"from", "to"}, Response: dto.ComplianceReportResponse{}},
+	"AdminHandler.Dashboard":          {Summary: "Get the admin dashboard", Query: []string{"threshold"}, Response: dto.AdminDashboardResponse{}},
+	"AdminHandler.YearlyStats":        {Summary: "Get yearly vacation statistics", Query: []string{"year"}, Response: dto.YearlyStatsResponse{}},
+
+	// Admin: settings and blackouts
+	"AdminHandler.GetSettings":    {Summary: "Get the settings", Response: dto.SettingsResponse{}},
+	"AdminHandler.UpdateSettings": {Summary: "Update the settings", Request: dto.UpdateSettingsRequest{}, Response: dto.SettingsResponse{}},
+	"AdminHandler.ListBlackouts":  {Summary: "List blackout periods", Response: dto.BlackoutListResponse{}},
+	"AdminHandler.CreateBlackout": {Summary: "Create a blackout period", Request: dto.CreateBlackoutRequest{}, Response: domain.BlackoutPeriod{}, Status: http.StatusCreated},
+	"AdminHandler.DeleteBlackout": {Summary: "Delete a blackout period", Response: dto.MessageResponse{}},
+
+	// Admin: audit log
+	"AdminHandler.AuditLog": {Summary: "List admin changes, newest first", Query: []string{"from", "to", "actor", "page", "limit"}, Response: dto.AuditLogResponse{}},
+
+	// Admin: email
+	"AdminHandler.SendNewsletter":         {Summary: "Send the newsletter now", Response: dto.NewsletterSendResponse{}},
+	"AdminHandler.PreviewNewsletter":      {Summary: "Preview the newsletter", Response: dto.NewsletterPreviewResponse{}},
+	"AdminHandler.SendTestEmail":          {Summary: "Send a test email to the current admin", Request: dto.TestEmailRequest{}, Response: dto.TestEmailResponse{}},
+	"AdminHandler.PreviewEmail":           {Summary: "Render an email template", Request: dto.PreviewEmailRequest{}, Response: dto.EmailPreviewResponse{}},
+	"AdminHandler.EmailLog":               {Summary: "List recently sent emails", Query: []string{"limit"}, Response: dto.EmailLogResponse{}},
+	"AdminHandler.ValidateEmailTemplates": {Summary: "Render every email template with sample data and report errors", Response: dto.EmailValidationResponse{}},
+	"AdminHandler.NotificationRecipients": {Summary: "List which admins are emailed about new requests", Response: dto.NotificationRecipientsResponse{}},
+
+	// Docs
+	"DocsHandler.Spec": {Summary: "Get this OpenAPI document"},
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/openapi"
+)
+
+func TestDocsSpec_DescribesRegisteredRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	teams := NewTeamHandler(nil, nil)
+	docs := NewDocsHandler(router.Routes)
+	router.GET("/api/v1/openapi.json", docs.Spec)
+	// Registered after the docs handler; the spec is built on first request
+	router.PUT("/api/v1/admin/teams/:id", teams.Update)
+	router.PUT("/api/admin/teams/:id", teams.Update)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var doc struct {
+		OpenAPI string                               `json:"openapi"`
+		Paths   map[string]map[string]map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	require.Contains(t, doc.Paths, "/api/v1/admin/teams/{id}")
+	assert.Equal(t, "Rename a team", doc.Paths["/api/v1/admin/teams/{id}"]["put"]["summary"])
+	assert.NotContains(t, doc.Paths, "/api/admin/teams/{id}", "deprecated routes are left out")
+	assert.Contains(t, w.Body.String(), `"VALIDATION_ERROR"`)
+}
+
+func TestDocsUI_AllowsSwaggerAssets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/docs", NewDocsHandler(router.Routes).UI)
+
+	req, _ := http.NewRequest(http.MethodGet, "/docs", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "swagger-ui-bundle.js")
+	assert.Contains(t, w.Header().Get("Content-Security-Policy"), "https://unpkg.com")
+}
+
+// Every documented handler must exist, so a rename cannot silently drop its docs
+func TestEndpointDocs_NameRealHandlers(t *testing.T) {
+	handlers := map[string]reflect.Type{}
+	for _, h := range []any{&AuthHandler{}, &VacationHandler{}, &AdminHandler{}, &TeamHandler{}, &SettingsHandler{}, &DocsHandler{}} {
+		typ := reflect.TypeOf(h)
+		handlers[typ.Elem().Name()] = typ
+	}
+
+	for name := range endpointDocs {
+		typeName, method, ok := strings.Cut(name, ".")
+		require.True(t, ok, name)
+		typ, ok := handlers[typeName]
+		require.True(t, ok, "unknown handler type in %s", name)
+		_, ok = typ.MethodByName(method)
+		assert.True(t, ok, "%s has no method %s", typeName, method)
+	}
+}
+
+// Building a document with every endpoint exercises the schema of every DTO
+func TestEndpointDocs_BuildEverySchema(t *testing.T) {
+	var routes gin.RoutesInfo
+	for name := range endpointDocs {
+		typeName, method, _ := strings.Cut(name, ".")
+		routes = append(routes, gin.RouteInfo{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/" + strings.ToLower(name),
+			Handler: "vacaytracker-api/internal/handler.(*" + typeName + ")." + method + "-fm",
+		})
+	}
+
+	raw, err := openapi.Build(openapi.Info{Title: "Test", PathPrefix: "/api/v1", ErrorResponse: dto.ErrorResponse{}}, routes, endpointDocs)
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	assert.Contains(t, schemas, "UpdateSettingsRequest")
+	assert.Contains(t, schemas, "VacationRequestResponse")
+}
@@ -1,20 +1,37 @@
 package handler
 
 import (
+	"context"
+	"log"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"vacaytracker-api/internal/repository"
+	"vacaytracker-api/internal/service"
 )
 
-const version = "1.0.0"
+// version is the build version, set with -ldflags "-X vacaytracker-api/internal/handler.version=..."
+var version = "1.0.0"
+
+// readinessTimeout bounds the database check so a hung database fails the probe instead of stalling it
+const readinessTimeout = 2 * time.Second
 
 // HealthHandler handles health check endpoints
-type HealthHandler struct{}
+type HealthHandler struct {
+	db        repository.HealthChecker
+	scheduler *service.Scheduler
+	startedAt time.Time
+}
 
 // NewHealthHandler creates a new HealthHandler
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+func NewHealthHandler(db repository.HealthChecker, scheduler *service.Scheduler) *HealthHandler {
+	return &HealthHandler{
+		db:        db,
+		scheduler: scheduler,
+		startedAt: time.Now(),
+	}
 }
 
 // HealthResponse represents the health check response
@@ -24,6 +41,22 @@ type HealthResponse struct {
 	Version   string `json:"version"`
 }
 
+// ReadinessResponse represents the readiness check response
+type ReadinessResponse struct {
+	Status        string          `json:"status"` // "ready" or "unavailable"
+	Timestamp     string          `json:"timestamp"`
+	Version       string          `json:"version"`
+	Uptime        string          `json:"uptime"`
+	UptimeSeconds int64           `json:"uptimeSeconds"`
+	Checks        ReadinessChecks `json:"checks"`
+}
+
+// ReadinessChecks reports the state of each dependency
+type ReadinessChecks struct {
+	Database  string `json:"database"`  // "ok" or "unreachable"
+	Scheduler string `json:"scheduler"` // "running" or "stopped"
+}
+
 // Check handles GET /health
 // Returns the health status of the API
 func (h *HealthHandler) Check(c *gin.Context) {
@@ -35,3 +68,36 @@ func (h *HealthHandler) Check(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// Ready handles GET /health/ready
+// Pings the database and reports the scheduler; responds 503 when the database is unreachable
+func (h *HealthHandler) Ready(c *gin.Context) {
+	uptime := time.Since(h.startedAt).Truncate(time.Second)
+	response := ReadinessResponse{
+		Status:        "ready",
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+		Version:       version,
+		Uptime:        uptime.String(),
+		UptimeSeconds: int64(uptime.Seconds()),
+		Checks: ReadinessChecks{
+			Database:  "ok",
+			Scheduler: "stopped",
+		},
+	}
+
+	if h.scheduler != nil && h.scheduler.Running() {
+		response.Checks.Scheduler = "running"
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+	if err := h.db.HealthCheck(ctx); err != nil {
+		log.Printf("ERROR: readiness check failed: %v", err)
+		response.Status = "unavailable"
+		response.Checks.Database = "unreachable"
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
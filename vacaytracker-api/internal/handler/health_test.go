@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -15,7 +17,7 @@ func TestHealthCheck(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	// Create handler
-	handler := NewHealthHandler()
+	handler := NewHealthHandler(&fakeHealthChecker{}, nil)
 
 	// Create a test router
 	router := gin.New()
@@ -59,8 +61,69 @@ func TestHealthCheck(t *testing.T) {
 }
 
 func TestNewHealthHandler(t *testing.T) {
-	handler := NewHealthHandler()
+	handler := NewHealthHandler(&fakeHealthChecker{}, nil)
 	if handler == nil {
 		t.Error("NewHealthHandler() returned nil")
 	}
 }
+
+// fakeHealthChecker stands in for the database, failing with err when set
+type fakeHealthChecker struct {
+	err error
+}
+
+func (f *fakeHealthChecker) HealthCheck(_ context.Context) error {
+	return f.err
+}
+
+func TestReady_DatabaseUp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewHealthHandler(&fakeHealthChecker{}, nil)
+	router := gin.New()
+	router.GET("/health/ready", handler.Ready)
+
+	req, _ := http.NewRequest(http.MethodGet, "/health/ready", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+
+	var response ReadinessResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Status != "ready" || response.Checks.Database != "ok" {
+		t.Errorf("Expected a ready database, got %+v", response)
+	}
+	if response.Checks.Scheduler != "stopped" {
+		t.Errorf("Expected scheduler 'stopped' without a scheduler, got '%s'", response.Checks.Scheduler)
+	}
+	if response.Version != version || response.Uptime == "" {
+		t.Errorf("Expected version and uptime, got %+v", response)
+	}
+}
+
+func TestReady_DatabaseDown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewHealthHandler(&fakeHealthChecker{err: errors.New("disk I/O error")}, nil)
+	router := gin.New()
+	router.GET("/health/ready", handler.Ready)
+
+	req, _ := http.NewRequest(http.MethodGet, "/health/ready", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status %d, got %d", http.StatusServiceUnavailable, recorder.Code)
+	}
+
+	var response ReadinessResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Status != "unavailable" || response.Checks.Database != "unreachable" {
+		t.Errorf("Expected an unreachable database, got %+v", response)
+	}
+}
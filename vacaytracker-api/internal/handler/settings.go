@@ -22,8 +22,10 @@ func NewSettingsHandler(settingsRepo repository.SettingsRepository) *SettingsHan
 
 // PublicSettingsResponse contains only non-sensitive settings
 type PublicSettingsResponse struct {
-	DefaultVacationDays int `json:"defaultVacationDays"`
-	VacationResetMonth  int `json:"vacationResetMonth"`
+	DefaultVacationDays int    `json:"defaultVacationDays"`
+	VacationResetMonth  int    `json:"vacationResetMonth"`
+	BalanceUnit         string `json:"balanceUnit"` // Unit of balances and request totals: days or hours
+	HoursPerDay         int    `json:"hoursPerDay"`
 }
 
 // GetPublic handles GET /api/settings/public
@@ -38,5 +40,7 @@ func (h *SettingsHandler) GetPublic(c *gin.Context) {
 	c.JSON(http.StatusOK, PublicSettingsResponse{
 		DefaultVacationDays: settings.DefaultVacationDays,
 		VacationResetMonth:  settings.VacationResetMonth,
+		BalanceUnit:         string(settings.BalanceUnit),
+		HoursPerDay:         settings.HoursPerDay,
 	})
 }
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/service"
+)
+
+// TeamHandler handles admin team management endpoints
+type TeamHandler struct {
+	teamService  *service.TeamService
+	auditService *service.AuditService
+}
+
+// NewTeamHandler creates a new TeamHandler
+func NewTeamHandler(teamService *service.TeamService, auditService *service.AuditService) *TeamHandler {
+	return &TeamHandler{
+		teamService:  teamService,
+		auditService: auditService,
+	}
+}
+
+// List handles GET /api/admin/teams
+// Lists all teams
+func (h *TeamHandler) List(c *gin.Context) {
+	teams, err := h.teamService.List(c.Request.Context())
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to list teams",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TeamListResponse{
+		Teams: teams,
+		Total: len(teams),
+	})
+}
+
+// Create handles POST /api/admin/teams
+// Creates a new team
+func (h *TeamHandler) Create(c *gin.Context) {
+	var req dto.TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
+
+	team, err := h.teamService.Create(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to create team",
+			})
+		}
+		return
+	}
+
+	recordAudit(c, h.auditService, domain.AuditTeamCreate, domain.AuditTargetTeam, team.ID, nil, team)
+
+	c.JSON(http.StatusCreated, team)
+}
+
+// Update handles PUT /api/admin/teams/:id
+// Renames a team
+func (h *TeamHandler) Update(c *gin.Context) {
+	var req dto.TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
+
+	before := h.auditTeamSnapshot(c.Request.Context(), c.Param("id"))
+	team, err := h.teamService.Update(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to update team",
+			})
+		}
+		return
+	}
+
+	recordAudit(c, h.auditService, domain.AuditTeamUpdate, domain.AuditTargetTeam, team.ID, before, team)
+
+	c.JSON(http.StatusOK, team)
+}
+
+// Delete handles DELETE /api/admin/teams/:id
+// Removes a team; its members become unassigned
+func (h *TeamHandler) Delete(c *gin.Context) {
+	teamID := c.Param("id")
+	before := h.auditTeamSnapshot(c.Request.Context(), teamID)
+	if err := h.teamService.Delete(c.Request.Context(), teamID); err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to delete team",
+			})
+		}
+		return
+	}
+
+	recordAudit(c, h.auditService, domain.AuditTeamDelete, domain.AuditTargetTeam, teamID, before, nil)
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Team deleted successfully",
+	})
+}
+
+// auditTeamSnapshot loads a team as it looks before a change; nil when it can't be loaded
+func (h *TeamHandler) auditTeamSnapshot(ctx context.Context, id string) *domain.Team {
+	team, err := h.teamService.Get(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return team
+}
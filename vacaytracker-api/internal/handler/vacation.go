@@ -2,9 +2,12 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -18,10 +21,13 @@ import (
 
 // VacationHandler handles vacation request endpoints
 type VacationHandler struct {
-	vacationService *service.VacationService
-	vacationRepo    repository.VacationRepository
-	userRepo        repository.UserRepository
-	emailService    *service.EmailService
+	vacationService   *service.VacationService
+	vacationRepo      repository.VacationRepository
+	userRepo          repository.UserRepository
+	emailService      *service.EmailService
+	webhookService    *service.WebhookService
+	commentService    *service.CommentService
+	attachmentService *service.AttachmentService
 }
 
 // NewVacationHandler creates a new VacationHandler
@@ -30,12 +36,18 @@ func NewVacationHandler(
 	vacationRepo repository.VacationRepository,
 	userRepo repository.UserRepository,
 	emailService *service.EmailService,
+	webhookService *service.WebhookService,
+	commentService *service.CommentService,
+	attachmentService *service.AttachmentService,
 ) *VacationHandler {
 	return &VacationHandler{
-		vacationService: vacationService,
-		vacationRepo:    vacationRepo,
-		userRepo:        userRepo,
-		emailService:    emailService,
+		vacationService:   vacationService,
+		vacationRepo:      vacationRepo,
+		userRepo:          userRepo,
+		emailService:      emailService,
+		webhookService:    webhookService,
+		commentService:    commentService,
+		attachmentService: attachmentService,
 	}
 }
 
@@ -45,10 +57,7 @@ func (h *VacationHandler) Create(c *gin.Context) {
 	var req dto.CreateVacationRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Code:    dto.ErrValidation,
-			Message: "Invalid request body: " + err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
 		return
 	}
 
@@ -74,14 +83,16 @@ func (h *VacationHandler) Create(c *gin.Context) {
 		return
 	}
 
-	// Send email notifications (non-blocking)
+	// Send email notifications (non-blocking); tentative requests notify nobody until submitted
 	// Use background context since the request context is cancelled after the response is sent
-	go h.sendVacationRequestEmails(context.Background(), userID, vacation)
+	if !vacation.IsTentative() {
+		go h.sendVacationRequestEmails(context.Background(), userID, vacation)
+	}
 
-	c.JSON(http.StatusCreated, dto.ToVacationRequestResponse(vacation))
+	c.JSON(http.StatusCreated, h.toResponses(c.Request.Context(), userID, vacation)[0])
 }
 
-// sendVacationRequestEmails sends emails when a vacation request is created
+// sendVacationRequestEmails sends emails and the webhook event when a vacation request is created
 func (h *VacationHandler) sendVacationRequestEmails(ctx context.Context, userID string, vacation *domain.VacationRequest) {
 	// Get the user who submitted the request
 	user, err := h.userRepo.GetByID(ctx, userID)
@@ -93,24 +104,64 @@ func (h *VacationHandler) sendVacationRequestEmails(ctx context.Context, userID
 		return
 	}
 
+	h.webhookService.Notify(service.WebhookRequestSubmitted, user, vacation)
+
 	// Send confirmation email to the user
 	h.emailService.SendRequestSubmitted(user, vacation)
+	sendLowBalanceEmails(ctx, h.userRepo, h.emailService, user, vacation)
+	if vacation.Status == domain.StatusApproved {
+		sendTeammateOffEmails(ctx, h.userRepo, h.emailService, user, vacation)
+	}
+
+	// Send notification to all active admins
+	admins := h.activeAdmins(ctx)
+	if len(admins) == 0 {
+		return
+	}
+
+	h.emailService.SendAdminNewRequest(admins, user, vacation)
+}
 
-	// Send notification to all admins
-	admins, err := h.userRepo.GetByRole(ctx, domain.RoleAdmin)
+// sendWithdrawalNotifications tells admins and the webhook that an approved request was cancelled
+func (h *VacationHandler) sendWithdrawalNotifications(ctx context.Context, userID string, vacation *domain.VacationRequest) {
+	user, err := h.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		log.Printf("ERROR: failed to get admins for email notification: %v", err)
+		log.Printf("ERROR: failed to get user for email notification: %v", err)
+		return
+	}
+	if user == nil {
 		return
 	}
+
+	h.webhookService.Notify(service.WebhookRequestCancelled, user, vacation)
+
+	admins := h.activeAdmins(ctx)
 	if len(admins) == 0 {
 		return
 	}
 
-	h.emailService.SendAdminNewRequest(admins, user, vacation)
+	h.emailService.SendAdminRequestWithdrawn(admins, user, vacation)
+}
+
+// activeAdmins returns the admins who should receive notifications
+func (h *VacationHandler) activeAdmins(ctx context.Context) []*domain.User {
+	allAdmins, err := h.userRepo.GetByRole(ctx, domain.RoleAdmin)
+	if err != nil {
+		log.Printf("ERROR: failed to get admins for email notification: %v", err)
+		return nil
+	}
+	admins := make([]*domain.User, 0, len(allAdmins))
+	for _, admin := range allAdmins {
+		if admin.Active {
+			admins = append(admins, admin)
+		}
+	}
+	return admins
 }
 
 // List handles GET /api/vacation/requests
 // Lists vacation requests for the current user
+// With ?ids=a,b,c it instead returns those requests, skipping missing ones and ones the caller may not view
 func (h *VacationHandler) List(c *gin.Context) {
 	userID := middleware.GetUserID(c)
 	if userID == "" {
@@ -121,34 +172,20 @@ func (h *VacationHandler) List(c *gin.Context) {
 		return
 	}
 
-	// Parse query parameters
-	var status *domain.VacationStatus
-	if s := c.Query("status"); s != "" {
-		vs := domain.VacationStatus(s)
-		if vs != domain.StatusPending && vs != domain.StatusApproved && vs != domain.StatusRejected {
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Code:    dto.ErrValidation,
-				Message: "Invalid status. Must be pending, approved, or rejected",
-			})
-			return
-		}
-		status = &vs
+	if ids, ok := c.GetQuery("ids"); ok {
+		h.listByIDs(c, userID, strings.Split(ids, ","))
+		return
 	}
 
-	var year *int
-	if y := c.Query("year"); y != "" {
-		parsed, err := strconv.Atoi(y)
-		if err != nil || parsed < 2000 || parsed > 2100 {
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Code:    dto.ErrValidation,
-				Message: "Invalid year",
-			})
-			return
-		}
-		year = &parsed
+	status, year, ok := parseStatusYearQuery(c)
+	if !ok {
+		return
 	}
 
-	requests, err := h.vacationService.ListByUser(c.Request.Context(), userID, status, year)
+	// Optional overlap range (YYYY-MM-DD), combinable with year
+	from, to := c.Query("from"), c.Query("to")
+
+	requests, err := h.vacationService.ListByUser(c.Request.Context(), userID, status, year, from, to)
 	if err != nil {
 		if appErr, ok := err.(*dto.AppError); ok {
 			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
@@ -162,9 +199,53 @@ func (h *VacationHandler) List(c *gin.Context) {
 	}
 
 	// Convert to response DTOs
+	responses := h.toResponses(c.Request.Context(), userID, requests...)
+
+	c.JSON(http.StatusOK, dto.VacationListResponse{
+		Requests: responses,
+		Total:    len(responses),
+	})
+}
+
+// listByIDs answers the batch form of List, applying the same owner-or-admin rule as Get to each request
+func (h *VacationHandler) listByIDs(c *gin.Context, userID string, ids []string) {
+	for i := range ids {
+		ids[i] = strings.TrimSpace(ids[i])
+	}
+
+	isAdmin := middleware.GetUserRole(c) == domain.RoleAdmin
+	requests, err := h.vacationService.GetByIDs(c.Request.Context(), ids, userID, isAdmin)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get vacation requests",
+			})
+		}
+		return
+	}
+
+	// Admins may receive several users' requests, so BalanceAfter is filled in per owner
+	byOwner := make(map[string][]*domain.VacationRequest)
+	var owners []string
+	for _, request := range requests {
+		if _, ok := byOwner[request.UserID]; !ok {
+			owners = append(owners, request.UserID)
+		}
+		byOwner[request.UserID] = append(byOwner[request.UserID], request)
+	}
+	converted := make(map[string]*dto.VacationRequestResponse, len(requests))
+	for _, owner := range owners {
+		for _, resp := range h.toResponses(c.Request.Context(), owner, byOwner[owner]...) {
+			converted[resp.ID] = resp
+		}
+	}
+
 	responses := make([]*dto.VacationRequestResponse, len(requests))
-	for i, req := range requests {
-		responses[i] = dto.ToVacationRequestResponse(req)
+	for i, request := range requests {
+		responses[i] = converted[request.ID]
 	}
 
 	c.JSON(http.StatusOK, dto.VacationListResponse{
@@ -210,12 +291,45 @@ func (h *VacationHandler) Get(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToVacationRequestResponse(request))
+	days, err := h.vacationService.DayBreakdown(c.Request.Context(), request)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get vacation request",
+			})
+		}
+		return
+	}
+	request.DayBreakdown = days
+
+	resp := h.toResponses(c.Request.Context(), request.UserID, request)[0]
+
+	// The comment thread is only loaded on request to keep the common case cheap
+	if c.Query("includeComments") == "true" {
+		comments, err := h.commentService.List(c.Request.Context(), requestID, userID, userRole == domain.RoleAdmin)
+		if err != nil {
+			if appErr, ok := err.(*dto.AppError); ok {
+				c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+			} else {
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+					Code:    dto.ErrInternal,
+					Message: "Failed to get comments",
+				})
+			}
+			return
+		}
+		resp.Comments = dto.ToCommentResponses(comments)
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
 
-// Cancel handles DELETE /api/vacation/requests/:id
-// Cancels a pending vacation request
-func (h *VacationHandler) Cancel(c *gin.Context) {
+// Comments handles GET /api/vacation/requests/:id/comments
+// Lists the discussion thread of a request; open to the owner and admins
+func (h *VacationHandler) Comments(c *gin.Context) {
 	requestID := c.Param("id")
 	userID := middleware.GetUserID(c)
 
@@ -227,28 +341,32 @@ func (h *VacationHandler) Cancel(c *gin.Context) {
 		return
 	}
 
-	err := h.vacationService.Cancel(c.Request.Context(), requestID, userID)
+	comments, err := h.commentService.List(c.Request.Context(), requestID, userID, middleware.IsAdmin(c))
 	if err != nil {
 		if appErr, ok := err.(*dto.AppError); ok {
 			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
 		} else {
 			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 				Code:    dto.ErrInternal,
-				Message: "Failed to cancel vacation request",
+				Message: "Failed to get comments",
 			})
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.MessageResponse{
-		Message: "Vacation request cancelled successfully",
+	responses := dto.ToCommentResponses(comments)
+	c.JSON(http.StatusOK, dto.CommentListResponse{
+		Comments: responses,
+		Total:    len(responses),
 	})
 }
 
-// Team handles GET /api/vacation/team
-// Gets team vacation calendar for a given month/year
-func (h *VacationHandler) Team(c *gin.Context) {
+// AddComment handles POST /api/vacation/requests/:id/comments
+// Posts a comment on a request; with notify set, the other party is emailed
+func (h *VacationHandler) AddComment(c *gin.Context) {
+	requestID := c.Param("id")
 	userID := middleware.GetUserID(c)
+
 	if userID == "" {
 		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
 			Code:    dto.ErrAuthTokenMissing,
@@ -257,64 +375,808 @@ func (h *VacationHandler) Team(c *gin.Context) {
 		return
 	}
 
-	// Parse query parameters (default to current month)
-	now := time.Now()
-	month := now.Month()
-	year := now.Year()
+	var req dto.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
 
-	if m := c.Query("month"); m != "" {
-		parsed, err := strconv.Atoi(m)
-		if err != nil || parsed < 1 || parsed > 12 {
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Code:    dto.ErrValidation,
-				Message: "Invalid month. Must be 1-12",
+	comment, request, err := h.commentService.Add(c.Request.Context(), requestID, userID, middleware.IsAdmin(c), req.Body)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to add comment",
 			})
-			return
 		}
-		month = time.Month(parsed)
+		return
 	}
 
-	if y := c.Query("year"); y != "" {
-		parsed, err := strconv.Atoi(y)
-		if err != nil || parsed < 2000 || parsed > 2100 {
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Code:    dto.ErrValidation,
-				Message: "Invalid year",
+	if req.Notify {
+		// Use background context since the request context is cancelled after the response is sent
+		go h.sendCommentEmails(context.Background(), request, comment)
+	}
+
+	c.JSON(http.StatusCreated, dto.ToCommentResponse(comment))
+}
+
+// Attachments handles GET /api/vacation/requests/:id/attachments
+// Lists the files uploaded to a request
+func (h *VacationHandler) Attachments(c *gin.Context) {
+	requestID := c.Param("id")
+	userID := middleware.GetUserID(c)
+
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	attachments, err := h.attachmentService.List(c.Request.Context(), requestID, userID, middleware.IsAdmin(c))
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get attachments",
 			})
+		}
+		return
+	}
+
+	responses := make([]*dto.AttachmentResponse, len(attachments))
+	for i, attachment := range attachments {
+		responses[i] = dto.ToAttachmentResponse(attachment)
+	}
+	c.JSON(http.StatusOK, dto.AttachmentListResponse{
+		Attachments: responses,
+		Total:       len(responses),
+	})
+}
+
+// UploadAttachment handles POST /api/vacation/requests/:id/attachments
+// Accepts a multipart upload in the "file" field (PDF, JPEG or PNG)
+func (h *VacationHandler) UploadAttachment(c *gin.Context) {
+	requestID := c.Param("id")
+	userID := middleware.GetUserID(c)
+
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	// Leave headroom for the multipart framing; the service enforces the exact limit
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.attachmentService.MaxSize()+64<<10)
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			appErr := dto.ErrFileTooLargeError(h.attachmentService.MaxSize())
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
 			return
 		}
-		year = parsed
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    dto.ErrValidation,
+			Message: "A file is required in the \"file\" form field",
+		})
+		return
 	}
+	defer file.Close()
 
-	vacations, err := h.vacationService.ListTeam(c.Request.Context(), int(month), year)
+	attachment, err := h.attachmentService.Upload(c.Request.Context(), requestID, userID, middleware.IsAdmin(c), header.Filename, file)
 	if err != nil {
 		if appErr, ok := err.(*dto.AppError); ok {
 			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
 		} else {
 			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 				Code:    dto.ErrInternal,
-				Message: "Failed to get team vacations",
+				Message: "Failed to upload attachment",
 			})
 		}
 		return
 	}
 
-	// Convert to response DTOs
-	items := make([]*dto.TeamVacationItem, len(vacations))
-	for i, v := range vacations {
-		items[i] = &dto.TeamVacationItem{
-			ID:        v.ID,
-			UserID:    v.UserID,
-			UserName:  v.UserName,
-			StartDate: v.StartDate,
-			EndDate:   v.EndDate,
-			TotalDays: v.TotalDays,
+	c.JSON(http.StatusCreated, dto.ToAttachmentResponse(attachment))
+}
+
+// DownloadAttachment handles GET /api/vacation/requests/:id/attachments/:attachmentId
+// Streams the file back under its original name
+func (h *VacationHandler) DownloadAttachment(c *gin.Context) {
+	requestID := c.Param("id")
+	userID := middleware.GetUserID(c)
+
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	attachment, path, err := h.attachmentService.Open(c.Request.Context(), requestID, c.Param("attachmentId"), userID, middleware.IsAdmin(c))
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get attachment",
+			})
 		}
+		return
 	}
 
-	c.JSON(http.StatusOK, dto.TeamVacationResponse{
-		Vacations: items,
-		Month:     int(month),
-		Year:      year,
-	})
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.FileAttachment(path, attachment.FileName)
+}
+
+// sendCommentEmails emails a new comment to the other party of the thread:
+// admins when the owner wrote it, otherwise the owner
+func (h *VacationHandler) sendCommentEmails(ctx context.Context, vacation *domain.VacationRequest, comment *domain.RequestComment) {
+	if comment.AuthorID != vacation.UserID {
+		owner, err := h.userRepo.GetByID(ctx, vacation.UserID)
+		if err != nil {
+			log.Printf("ERROR: failed to get user for email notification: %v", err)
+			return
+		}
+		if owner != nil {
+			h.emailService.SendRequestComment(owner, vacation, comment)
+		}
+		return
+	}
+
+	for _, admin := range h.activeAdmins(ctx) {
+		h.emailService.SendRequestComment(admin, vacation, comment)
+	}
+}
+
+// Cancel handles DELETE /api/vacation/requests/:id
+// Cancels a request under review, or withdraws approved leave that has not started yet
+func (h *VacationHandler) Cancel(c *gin.Context) {
+	requestID := c.Param("id")
+	userID := middleware.GetUserID(c)
+
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	cancelled, err := h.vacationService.Cancel(c.Request.Context(), requestID, userID)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to cancel vacation request",
+			})
+		}
+		return
+	}
+
+	// The request row is gone, taking the attachment metadata with it; drop the files too
+	if err := h.attachmentService.RemoveForRequest(cancelled.ID); err != nil {
+		log.Printf("ERROR: failed to remove attachments of cancelled request %s: %v", cancelled.ID, err)
+	}
+
+	// Withdrawing approved leave changes the team's plans, so let admins know
+	// Notifications are sent in the background like those for new requests
+	if cancelled.IsApproved() {
+		go h.sendWithdrawalNotifications(context.Background(), userID, cancelled)
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Vacation request cancelled successfully",
+	})
+}
+
+// Suggest handles POST /api/vacation/suggest
+// Suggests conflict-free date ranges of the requested length
+func (h *VacationHandler) Suggest(c *gin.Context) {
+	var req dto.SuggestVacationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse(err, &req))
+		return
+	}
+
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	suggestions, err := h.vacationService.Suggest(c.Request.Context(), userID, req)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to suggest vacation dates",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.VacationSuggestionsResponse{
+		Suggestions: suggestions,
+		Total:       len(suggestions),
+	})
+}
+
+// Drafts handles GET /api/vacation/drafts
+// Lists the current user's tentative requests
+func (h *VacationHandler) Drafts(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	requests, err := h.vacationService.ListDrafts(c.Request.Context(), userID)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to list draft requests",
+			})
+		}
+		return
+	}
+
+	responses := make([]*dto.VacationRequestResponse, len(requests))
+	for i, req := range requests {
+		responses[i] = dto.ToVacationRequestResponse(req)
+	}
+
+	c.JSON(http.StatusOK, dto.VacationListResponse{
+		Requests: responses,
+		Total:    len(responses),
+	})
+}
+
+// Submit handles POST /api/vacation/requests/:id/submit
+// Promotes a tentative request to a pending one
+func (h *VacationHandler) Submit(c *gin.Context) {
+	requestID := c.Param("id")
+	userID := middleware.GetUserID(c)
+
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	vacation, err := h.vacationService.Submit(c.Request.Context(), requestID, userID)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to submit vacation request",
+			})
+		}
+		return
+	}
+
+	// Notify now that the request has entered review, same as on Create
+	go h.sendVacationRequestEmails(context.Background(), userID, vacation)
+
+	c.JSON(http.StatusOK, h.toResponses(c.Request.Context(), userID, vacation)[0])
+}
+
+// Team handles GET /api/vacation/team
+// Gets the caller's team vacation calendar for a given month/year; admins may pass ?teamId=
+func (h *VacationHandler) Team(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	month, year, ok := parseMonthYearQuery(c)
+	if !ok {
+		return
+	}
+
+	includePending := false
+	if raw := c.Query("includePending"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid includePending. Must be true or false",
+			})
+			return
+		}
+		includePending = parsed
+	}
+
+	teamID, ok := h.resolveTeamScope(c, userID)
+	if !ok {
+		return
+	}
+
+	vacations, err := h.vacationService.ListTeam(c.Request.Context(), int(month), year, teamID, includePending)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get team vacations",
+			})
+		}
+		return
+	}
+
+	// Convert to response DTOs
+	items := make([]*dto.TeamVacationItem, len(vacations))
+	for i, v := range vacations {
+		items[i] = &dto.TeamVacationItem{
+			ID:        v.ID,
+			UserID:    v.UserID,
+			UserName:  v.UserName,
+			StartDate: v.StartDate,
+			EndDate:   v.EndDate,
+			TotalDays: v.TotalDays,
+			Status:    v.Status,
+			Type:      v.Type,
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.TeamVacationResponse{
+		Vacations: items,
+		Month:     int(month),
+		Year:      year,
+		TeamID:    teamID,
+	})
+}
+
+// TeamWeeks handles GET /api/vacation/team/calendar
+// Returns approved team vacations for a given month/year grouped by ISO week for a calendar grid
+func (h *VacationHandler) TeamWeeks(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	month, year, ok := parseMonthYearQuery(c)
+	if !ok {
+		return
+	}
+
+	teamID, ok := h.resolveTeamScope(c, userID)
+	if !ok {
+		return
+	}
+
+	weeks, err := h.vacationService.TeamWeeks(c.Request.Context(), int(month), year, teamID)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get team vacations",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, weeks)
+}
+
+// WorkingDays handles GET /api/calendar/working-days
+// Classifies each date between from and to (YYYY-MM-DD) so date pickers can gray out non-working days
+func (h *VacationHandler) WorkingDays(c *gin.Context) {
+	from, to := c.Query("from"), c.Query("to")
+
+	days, err := h.vacationService.WorkingDays(c.Request.Context(), from, to)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get working days",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.WorkingDaysResponse{From: from, To: to, Days: days})
+}
+
+// TeamCalendar handles GET /api/vacation/team.ics
+// Exports approved team vacations for a given month/year as an iCalendar feed
+func (h *VacationHandler) TeamCalendar(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	month, year, ok := parseMonthYearQuery(c)
+	if !ok {
+		return
+	}
+
+	teamID, ok := h.resolveTeamScope(c, userID)
+	if !ok {
+		return
+	}
+
+	vacations, err := h.vacationService.ListTeam(c.Request.Context(), int(month), year, teamID, false)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get team vacations",
+			})
+		}
+		return
+	}
+
+	calendar := service.RenderICalendar("Team Vacations", service.TeamVacationEvents(vacations), time.Now())
+	filename := fmt.Sprintf("team-vacations-%d-%02d.ics", year, int(month))
+	writeCalendar(c, filename, calendar)
+}
+
+// MyCalendar handles GET /api/vacation/requests.ics
+// Exports the current user's approved vacations as an iCalendar feed
+func (h *VacationHandler) MyCalendar(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	status := domain.StatusApproved
+	requests, err := h.vacationService.ListByUser(c.Request.Context(), userID, &status, nil, "", "")
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to list vacation requests",
+			})
+		}
+		return
+	}
+
+	calendar := service.RenderICalendar("My Vacations", service.VacationRequestEvents(requests), time.Now())
+	writeCalendar(c, "my-vacations.ics", calendar)
+}
+
+// sendLowBalanceEmails warns the employee and their manager when an approval crossed the low balance threshold
+func sendLowBalanceEmails(ctx context.Context, userRepo repository.UserRepository, emailService *service.EmailService, employee *domain.User, vacation *domain.VacationRequest) {
+	if vacation.LowBalanceAlert == nil {
+		return
+	}
+
+	emailService.SendLowBalance(employee, employee, vacation)
+
+	if employee.ManagerID == nil {
+		return
+	}
+	manager, err := userRepo.GetByID(ctx, *employee.ManagerID)
+	if err != nil {
+		log.Printf("ERROR: failed to get manager for low balance email: %v", err)
+		return
+	}
+	if manager == nil || manager.IsDeleted() || !manager.Active || manager.ID == employee.ID {
+		return
+	}
+	emailService.SendLowBalance(manager, employee, vacation)
+}
+
+// sendTeammateOffEmails tells the employee's teammates about an approved request
+// Employees without a team have no teammates to notify, and remote days don't take anyone away
+func sendTeammateOffEmails(ctx context.Context, userRepo repository.UserRepository, emailService *service.EmailService, employee *domain.User, vacation *domain.VacationRequest) {
+	if employee.TeamID == nil || vacation.IsRemote() {
+		return
+	}
+	members, err := userRepo.ListByTeam(ctx, *employee.TeamID)
+	if err != nil {
+		log.Printf("ERROR: failed to get team members for teammate off email: %v", err)
+		return
+	}
+	teammates := make([]*domain.User, 0, len(members))
+	for _, member := range members {
+		if member.Active {
+			teammates = append(teammates, member)
+		}
+	}
+	emailService.SendTeammateOff(teammates, employee, vacation)
+}
+
+// writeCalendar sends an iCalendar document as a downloadable file
+func writeCalendar(c *gin.Context, filename, calendar string) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(calendar))
+}
+
+// Gantt handles GET /api/vacation/gantt
+// Returns approved team leave between the from and to query dates (YYYY-MM-DD) laid out for a Gantt chart
+// Scoped to a team like Team: the user's own by default, or teamId for admins
+func (h *VacationHandler) Gantt(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	from := c.Query("from")
+	to := c.Query("to")
+	if from == "" || to == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    dto.ErrValidation,
+			Message: "Both from and to query parameters are required (YYYY-MM-DD)",
+		})
+		return
+	}
+
+	teamID, ok := h.resolveTeamScope(c, userID)
+	if !ok {
+		return
+	}
+
+	chart, err := h.vacationService.Gantt(c.Request.Context(), from, to, teamID)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get team vacations",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, chart)
+}
+
+// Statement handles GET /api/vacation/statement
+// Returns the current user's leave entitlement statement for the year query parameter (default: current leave year)
+func (h *VacationHandler) Statement(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	year, ok := parseYearQuery(c, h.vacationService)
+	if !ok {
+		return
+	}
+
+	statement, err := h.vacationService.Statement(c.Request.Context(), userID, year)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get leave statement",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, statement)
+}
+
+// Report handles GET /api/vacation/report
+// Returns the current user's annual vacation report
+// Query params: year (optional leave year, defaults to the current one)
+func (h *VacationHandler) Report(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    dto.ErrAuthTokenMissing,
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	year, ok := parseYearQuery(c, h.vacationService)
+	if !ok {
+		return
+	}
+
+	report, err := h.vacationService.AnnualReport(c.Request.Context(), userID, year)
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get annual report",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// parseYearQuery reads the leave year query parameter, defaulting to the leave year containing today
+// Writes an error response and returns false if the year is invalid or today's leave year can't be determined
+func parseYearQuery(c *gin.Context, vacationService *service.VacationService) (int, bool) {
+	if y := c.Query("year"); y != "" {
+		year, err := strconv.Atoi(y)
+		if err != nil || year < 2000 || year > 2100 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid year",
+			})
+			return 0, false
+		}
+		return year, true
+	}
+
+	year, err := vacationService.CurrentLeaveYear(c.Request.Context(), time.Now())
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to get settings",
+			})
+		}
+		return 0, false
+	}
+	return year, true
+}
+
+// resolveTeamScope determines which team's calendar to show from the optional teamId query parameter
+// Writes an error response and returns false if the caller may not view the requested team
+func (h *VacationHandler) resolveTeamScope(c *gin.Context, userID string) (string, bool) {
+	teamID, err := h.vacationService.TeamScope(c.Request.Context(), userID, middleware.IsAdmin(c), c.Query("teamId"))
+	if err != nil {
+		if appErr, ok := err.(*dto.AppError); ok {
+			c.JSON(appErr.HTTPStatus, appErr.ToResponse())
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    dto.ErrInternal,
+				Message: "Failed to resolve team",
+			})
+		}
+		return "", false
+	}
+	return teamID, true
+}
+
+// toResponses converts requests owned by userID, filling in BalanceAfter from the user's current balance
+// Falls back to responses without BalanceAfter if the user can't be loaded
+func (h *VacationHandler) toResponses(ctx context.Context, userID string, requests ...*domain.VacationRequest) []*dto.VacationRequestResponse {
+	user, err := h.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		log.Printf("ERROR: failed to get user %s for balance after approval: %v", userID, err)
+	}
+
+	responses := make([]*dto.VacationRequestResponse, len(requests))
+	for i, req := range requests {
+		if user != nil {
+			responses[i] = dto.ToVacationRequestResponseWithBalance(req, user.VacationBalance)
+		} else {
+			responses[i] = dto.ToVacationRequestResponse(req)
+		}
+	}
+	return responses
+}
+
+// parseStatusYearQuery reads the optional status and year filters of a request list
+// Writes a validation error response and returns false if either is invalid
+func parseStatusYearQuery(c *gin.Context) (*domain.VacationStatus, *int, bool) {
+	var status *domain.VacationStatus
+	if s := c.Query("status"); s != "" {
+		vs := domain.VacationStatus(s)
+		if !domain.IsValidStatus(s) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid status. Must be tentative, pending, awaiting_final, approved, or rejected",
+			})
+			return nil, nil, false
+		}
+		status = &vs
+	}
+
+	var year *int
+	if y := c.Query("year"); y != "" {
+		parsed, err := strconv.Atoi(y)
+		if err != nil || parsed < 2000 || parsed > 2100 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid year",
+			})
+			return nil, nil, false
+		}
+		year = &parsed
+	}
+
+	return status, year, true
+}
+
+// parseMonthYearQuery reads the month/year query parameters, defaulting to the current month
+// Writes a validation error response and returns false if either is invalid
+func parseMonthYearQuery(c *gin.Context) (time.Month, int, bool) {
+	now := time.Now()
+	month := now.Month()
+	year := now.Year()
+
+	if m := c.Query("month"); m != "" {
+		parsed, err := strconv.Atoi(m)
+		if err != nil || parsed < 1 || parsed > 12 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid month. Must be 1-12",
+			})
+			return 0, 0, false
+		}
+		month = time.Month(parsed)
+	}
+
+	if y := c.Query("year"); y != "" {
+		parsed, err := strconv.Atoi(y)
+		if err != nil || parsed < 2000 || parsed > 2100 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    dto.ErrValidation,
+				Message: "Invalid year",
+			})
+			return 0, 0, false
+		}
+		year = parsed
+	}
+
+	return month, year, true
 }
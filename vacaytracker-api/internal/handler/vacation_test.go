@@ -1,10 +1,15 @@
 package handler_test
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -39,10 +44,23 @@ func setupVacationRouter(h *handler.VacationHandler, userID, email, name string,
 	}
 
 	r.POST("/api/vacation/request", authMiddleware, h.Create)
+	r.POST("/api/vacation/suggest", authMiddleware, h.Suggest)
 	r.GET("/api/vacation/requests", authMiddleware, h.List)
 	r.GET("/api/vacation/requests/:id", authMiddleware, h.Get)
 	r.DELETE("/api/vacation/requests/:id", authMiddleware, h.Cancel)
+	r.POST("/api/vacation/requests/:id/submit", authMiddleware, h.Submit)
+	r.GET("/api/vacation/requests/:id/comments", authMiddleware, h.Comments)
+	r.POST("/api/vacation/requests/:id/comments", authMiddleware, h.AddComment)
+	r.GET("/api/vacation/requests/:id/attachments", authMiddleware, h.Attachments)
+	r.POST("/api/vacation/requests/:id/attachments", authMiddleware, h.UploadAttachment)
+	r.GET("/api/vacation/requests/:id/attachments/:attachmentId", authMiddleware, h.DownloadAttachment)
+	r.GET("/api/vacation/drafts", authMiddleware, h.Drafts)
 	r.GET("/api/vacation/team", authMiddleware, h.Team)
+	r.GET("/api/vacation/team.ics", authMiddleware, h.TeamCalendar)
+	r.GET("/api/vacation/team/calendar", authMiddleware, h.TeamWeeks)
+	r.GET("/api/calendar/working-days", authMiddleware, h.WorkingDays)
+	r.GET("/api/vacation/requests.ics", authMiddleware, h.MyCalendar)
+	r.GET("/api/vacation/gantt", authMiddleware, h.Gantt)
 
 	return r
 }
@@ -56,7 +74,11 @@ func setupVacationRouterNoAuth(h *handler.VacationHandler) *gin.Engine {
 	r.GET("/api/vacation/requests", h.List)
 	r.GET("/api/vacation/requests/:id", h.Get)
 	r.DELETE("/api/vacation/requests/:id", h.Cancel)
+	r.POST("/api/vacation/requests/:id/submit", h.Submit)
+	r.GET("/api/vacation/drafts", h.Drafts)
 	r.GET("/api/vacation/team", h.Team)
+	r.GET("/api/vacation/team/calendar", h.TeamWeeks)
+	r.GET("/api/vacation/gantt", h.Gantt)
 
 	return r
 }
@@ -68,6 +90,21 @@ func newTestEmailService() *service.EmailService {
 	return service.NewEmailService(cfg)
 }
 
+// newTestWebhookService returns a webhook service whose default settings have no URL, so it never posts
+func newTestWebhookService() *service.WebhookService {
+	return service.NewWebhookService(&testutil.MockSettingsRepository{})
+}
+
+// newTestCommentService returns a comment service backed by empty mocks, for tests that never touch comments
+func newTestCommentService() *service.CommentService {
+	return service.NewCommentService(&testutil.MockCommentRepository{}, &testutil.MockVacationRepository{}, &testutil.MockUserRepository{})
+}
+
+// newTestAttachmentService returns an attachment service that stores files in a per-test directory
+func newTestAttachmentService(t *testing.T) *service.AttachmentService {
+	return service.NewAttachmentService(&testutil.MockAttachmentRepository{}, &testutil.MockVacationRepository{}, t.TempDir(), 1<<20)
+}
+
 // futureMonday returns the next Monday that is at least daysAhead days from now.
 func futureMonday(daysAhead int) time.Time {
 	d := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, daysAhead)
@@ -110,7 +147,7 @@ func TestCreate_Success_Employee(t *testing.T) {
 		return nil, nil
 	}
 
-	vacationRepo.HasOverlapFn = func(_ context.Context, userID, start, end string) (bool, error) {
+	vacationRepo.HasOverlapFn = func(_ context.Context, userID, start, end string, _ bool, _ []domain.RequestType) (bool, error) {
 		return false, nil
 	}
 
@@ -141,10 +178,10 @@ func TestCreate_Success_Employee(t *testing.T) {
 		return nil, nil
 	}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
 	body := `{"startDate":"` + startDateStr + `","endDate":"` + endDateStr + `","reason":"Family trip"}`
@@ -176,10 +213,10 @@ func TestCreate_InvalidJSON(t *testing.T) {
 	settingsRepo := &testutil.MockSettingsRepository{}
 	transactor := &testutil.MockTransactor{}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
 	req, _ := http.NewRequest(http.MethodPost, "/api/vacation/request", strings.NewReader("{invalid json"))
@@ -202,10 +239,10 @@ func TestCreate_NoAuthContext(t *testing.T) {
 	settingsRepo := &testutil.MockSettingsRepository{}
 	transactor := &testutil.MockTransactor{}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouterNoAuth(h)
 
 	body := `{"startDate":"15/06/2027","endDate":"20/06/2027"}`
@@ -245,14 +282,14 @@ func TestCreate_InsufficientBalance(t *testing.T) {
 		}, nil
 	}
 
-	vacationRepo.HasOverlapFn = func(_ context.Context, userID, start, end string) (bool, error) {
+	vacationRepo.HasOverlapFn = func(_ context.Context, userID, start, end string, _ bool, _ []domain.RequestType) (bool, error) {
 		return false, nil
 	}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
 	body := `{"startDate":"` + startDateStr + `","endDate":"` + endDateStr + `"}`
@@ -276,10 +313,10 @@ func TestCreate_InvalidDates(t *testing.T) {
 	settingsRepo := &testutil.MockSettingsRepository{}
 	transactor := &testutil.MockTransactor{}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
 	// Test with badly formatted date (not DD/MM/YYYY)
@@ -310,7 +347,7 @@ func TestList_Success_NoFilters(t *testing.T) {
 	transactor := &testutil.MockTransactor{}
 
 	now := time.Now()
-	vacationRepo.ListByUserFn = func(_ context.Context, userID string, status *domain.VacationStatus, year *int) ([]*domain.VacationRequest, error) {
+	vacationRepo.ListByUserFn = func(_ context.Context, userID string, status *domain.VacationStatus, year *int, _, _ string) ([]*domain.VacationRequest, error) {
 		assert.Equal(t, "user-1", userID)
 		assert.Nil(t, status)
 		assert.Nil(t, year)
@@ -338,10 +375,10 @@ func TestList_Success_NoFilters(t *testing.T) {
 		}, nil
 	}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests", nil)
@@ -359,6 +396,50 @@ func TestList_Success_NoFilters(t *testing.T) {
 	assert.Equal(t, "vac-2", resp.Requests[1].ID)
 }
 
+func TestList_BalanceAfterForRequestsUnderReview(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, Role: domain.RoleEmployee, VacationBalance: 20}, nil
+	}
+	now := time.Now()
+	vacationRepo.ListByUserFn = func(_ context.Context, _ string, _ *domain.VacationStatus, _ *int, _, _ string) ([]*domain.VacationRequest, error) {
+		return []*domain.VacationRequest{
+			{ID: "vac-1", UserID: "user-1", TotalDays: 5, Status: domain.StatusPending, Type: domain.RequestTypeVacation, CreatedAt: now, UpdatedAt: now},
+			{ID: "vac-2", UserID: "user-1", TotalDays: 3, Status: domain.StatusAwaitingFinal, CreatedAt: now, UpdatedAt: now},
+			{ID: "vac-3", UserID: "user-1", TotalDays: 4, Status: domain.StatusApproved, CreatedAt: now, UpdatedAt: now},
+			{ID: "vac-4", UserID: "user-1", TotalDays: 2, Status: domain.StatusTentative, CreatedAt: now, UpdatedAt: now},
+			{ID: "vac-5", UserID: "user-1", TotalDays: 2, Status: domain.StatusPending, Type: domain.RequestTypeRemote, CreatedAt: now, UpdatedAt: now},
+		}, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.VacationListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Requests, 5)
+	require.NotNil(t, resp.Requests[0].BalanceAfter)
+	assert.Equal(t, 15, *resp.Requests[0].BalanceAfter)
+	assert.Equal(t, "vacation", resp.Requests[0].Type)
+	require.NotNil(t, resp.Requests[1].BalanceAfter)
+	assert.Equal(t, 17, *resp.Requests[1].BalanceAfter)
+	assert.Nil(t, resp.Requests[2].BalanceAfter)
+	assert.Nil(t, resp.Requests[3].BalanceAfter)
+	assert.Nil(t, resp.Requests[4].BalanceAfter, "remote days deduct nothing")
+	assert.Equal(t, "remote", resp.Requests[4].Type)
+}
+
 func TestList_WithStatusFilter(t *testing.T) {
 	vacationRepo := &testutil.MockVacationRepository{}
 	userRepo := &testutil.MockUserRepository{}
@@ -366,7 +447,7 @@ func TestList_WithStatusFilter(t *testing.T) {
 	transactor := &testutil.MockTransactor{}
 
 	now := time.Now()
-	vacationRepo.ListByUserFn = func(_ context.Context, userID string, status *domain.VacationStatus, year *int) ([]*domain.VacationRequest, error) {
+	vacationRepo.ListByUserFn = func(_ context.Context, userID string, status *domain.VacationStatus, year *int, _, _ string) ([]*domain.VacationRequest, error) {
 		assert.Equal(t, "user-1", userID)
 		require.NotNil(t, status)
 		assert.Equal(t, domain.StatusApproved, *status)
@@ -384,10 +465,10 @@ func TestList_WithStatusFilter(t *testing.T) {
 		}, nil
 	}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests?status=approved", nil)
@@ -409,10 +490,10 @@ func TestList_InvalidStatus(t *testing.T) {
 	settingsRepo := &testutil.MockSettingsRepository{}
 	transactor := &testutil.MockTransactor{}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests?status=invalid", nil)
@@ -428,16 +509,66 @@ func TestList_InvalidStatus(t *testing.T) {
 	assert.Contains(t, resp.Message, "Invalid status")
 }
 
+func TestList_WithDateRange(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	vacationRepo.ListByUserFn = func(_ context.Context, _ string, _ *domain.VacationStatus, year *int, from, to string) ([]*domain.VacationRequest, error) {
+		require.NotNil(t, year)
+		assert.Equal(t, 2027, *year)
+		assert.Equal(t, "2027-08-01", from)
+		assert.Equal(t, "2027-08-31", to)
+		return nil, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests?year=2027&from=2027-08-01&to=2027-08-31", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestList_InvalidDateRange(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests?from=08/01/2027", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, dto.ErrValidation, resp.Code)
+}
+
 func TestList_NoAuthContext(t *testing.T) {
 	vacationRepo := &testutil.MockVacationRepository{}
 	userRepo := &testutil.MockUserRepository{}
 	settingsRepo := &testutil.MockSettingsRepository{}
 	transactor := &testutil.MockTransactor{}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouterNoAuth(h)
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests", nil)
@@ -452,6 +583,63 @@ func TestList_NoAuthContext(t *testing.T) {
 	assert.Equal(t, dto.ErrAuthTokenMissing, resp.Code)
 }
 
+func TestList_ByIDs(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		balances := map[string]int{"user-1": 20, "user-2": 10}
+		return &domain.User{ID: id, Role: domain.RoleEmployee, VacationBalance: balances[id]}, nil
+	}
+	now := time.Now()
+	vacationRepo.GetByIDsFn = func(_ context.Context, ids []string) ([]*domain.VacationRequest, error) {
+		assert.Equal(t, []string{"vac-1", "vac-2", "missing"}, ids)
+		return []*domain.VacationRequest{
+			{ID: "vac-2", UserID: "user-2", TotalDays: 3, Status: domain.StatusPending, CreatedAt: now, UpdatedAt: now},
+			{ID: "vac-1", UserID: "user-1", TotalDays: 5, Status: domain.StatusPending, CreatedAt: now, UpdatedAt: now},
+		}, nil
+	}
+	vacationRepo.ListByUserFn = func(_ context.Context, _ string, _ *domain.VacationStatus, _ *int, _, _ string) ([]*domain.VacationRequest, error) {
+		t.Fatal("ids should not fall back to listing the user's requests")
+		return nil, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+
+	// Employees only get their own requests back; the rest are dropped silently
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests?ids=vac-1,%20vac-2,missing", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dto.VacationListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Requests, 1)
+	assert.Equal(t, "vac-1", resp.Requests[0].ID)
+	require.NotNil(t, resp.Requests[0].BalanceAfter)
+	assert.Equal(t, 15, *resp.Requests[0].BalanceAfter)
+
+	// Admins get all of them, each with its owner's balance
+	router = setupVacationRouter(h, "admin-1", "admin@test.com", "Test Admin", domain.RoleAdmin)
+	req, _ = http.NewRequest(http.MethodGet, "/api/vacation/requests?ids=vac-1,vac-2,missing", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Requests, 2)
+	assert.Equal(t, 2, resp.Total)
+	assert.Equal(t, "vac-2", resp.Requests[0].ID)
+	require.NotNil(t, resp.Requests[0].BalanceAfter)
+	assert.Equal(t, 7, *resp.Requests[0].BalanceAfter)
+	require.NotNil(t, resp.Requests[1].BalanceAfter)
+	assert.Equal(t, 15, *resp.Requests[1].BalanceAfter)
+}
+
 // ============================================
 // Get Tests
 // ============================================
@@ -480,10 +668,10 @@ func TestGet_Success_OwnRequest(t *testing.T) {
 		return nil, nil
 	}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests/vac-1", nil)
@@ -499,6 +687,12 @@ func TestGet_Success_OwnRequest(t *testing.T) {
 	assert.Equal(t, "user-1", resp.UserID)
 	assert.Equal(t, "pending", resp.Status)
 	assert.Equal(t, 5, resp.TotalDays)
+
+	// Tue 15 to Sun 20 June 2027: the weekend is listed but not counted
+	require.Len(t, resp.DayBreakdown, 6)
+	assert.Equal(t, domain.RequestDay{Date: "2027-06-15", Type: domain.DayBusiness}, resp.DayBreakdown[0])
+	assert.Equal(t, domain.RequestDay{Date: "2027-06-19", Type: domain.DayWeekend}, resp.DayBreakdown[4])
+	assert.Equal(t, domain.RequestDay{Date: "2027-06-20", Type: domain.DayWeekend}, resp.DayBreakdown[5])
 }
 
 func TestGet_NotFound(t *testing.T) {
@@ -512,10 +706,10 @@ func TestGet_NotFound(t *testing.T) {
 		return nil, nil
 	}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests/nonexistent", nil)
@@ -550,10 +744,10 @@ func TestGet_OtherUserRequest_NotAdmin(t *testing.T) {
 		}, nil
 	}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	// Logged in as user-1 (employee), trying to view other-user's request
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
@@ -590,10 +784,10 @@ func TestGet_AdminCanViewAnyRequest(t *testing.T) {
 		}, nil
 	}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	// Logged in as admin
 	router := setupVacationRouter(h, "admin-1", "admin@test.com", "Admin User", domain.RoleAdmin)
 
@@ -614,214 +808,950 @@ func TestGet_AdminCanViewAnyRequest(t *testing.T) {
 // Cancel Tests
 // ============================================
 
-func TestCancel_Success(t *testing.T) {
+// commentFixture wires a vacation handler whose comment service shares its mocks
+func commentFixture(t *testing.T, commentRepo *testutil.MockCommentRepository) *handler.VacationHandler {
 	vacationRepo := &testutil.MockVacationRepository{}
 	userRepo := &testutil.MockUserRepository{}
-	settingsRepo := &testutil.MockSettingsRepository{}
-	transactor := &testutil.MockTransactor{}
 
-	now := time.Now()
 	vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
 		if id == "vac-1" {
 			return &domain.VacationRequest{
 				ID:        "vac-1",
 				UserID:    "user-1",
+				UserName:  "Test Employee",
 				StartDate: "2027-06-15",
 				EndDate:   "2027-06-20",
 				TotalDays: 5,
-				Status:    domain.StatusPending, // must be pending to cancel
-				CreatedAt: now,
-				UpdatedAt: now,
+				Status:    domain.StatusPending,
 			}, nil
 		}
 		return nil, nil
 	}
-
-	deleteCalled := false
-	vacationRepo.DeleteFn = func(_ context.Context, id string) error {
-		assert.Equal(t, "vac-1", id)
-		deleteCalled = true
-		return nil
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, Name: "Name of " + id}, nil
 	}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
-	emailService := newTestEmailService()
+	vacationService := service.NewVacationService(vacationRepo, userRepo, &testutil.MockSettingsRepository{}, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{})
+	commentService := service.NewCommentService(commentRepo, vacationRepo, userRepo)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), commentService, newTestAttachmentService(t))
+	return h
+}
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+func TestAddComment_OwnerCreatesComment(t *testing.T) {
+	var created *domain.RequestComment
+	commentRepo := &testutil.MockCommentRepository{
+		CreateFn: func(_ context.Context, comment *domain.RequestComment) error {
+			comment.ID = "c-1"
+			comment.CreatedAt = time.Date(2027, 5, 1, 9, 0, 0, 0, time.UTC)
+			created = comment
+			return nil
+		},
+	}
+	h := commentFixture(t, commentRepo)
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
-	req, _ := http.NewRequest(http.MethodDelete, "/api/vacation/requests/vac-1", nil)
+	req, _ := http.NewRequest(http.MethodPost, "/api/vacation/requests/vac-1/comments", strings.NewReader(`{"body":"  Can I move this by a day?  "}`))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusOK, w.Code)
-	assert.True(t, deleteCalled)
-
-	var resp dto.MessageResponse
-	err := json.Unmarshal(w.Body.Bytes(), &resp)
-	require.NoError(t, err)
-	assert.Contains(t, resp.Message, "cancelled successfully")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	require.NotNil(t, created)
+	assert.Equal(t, "vac-1", created.RequestID)
+	assert.Equal(t, "user-1", created.AuthorID)
+
+	var resp dto.CommentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "c-1", resp.ID)
+	assert.Equal(t, "Can I move this by a day?", resp.Body)
+	assert.Equal(t, "Name of user-1", resp.AuthorName)
 }
 
-func TestCancel_NotFound(t *testing.T) {
-	vacationRepo := &testutil.MockVacationRepository{}
-	userRepo := &testutil.MockUserRepository{}
-	settingsRepo := &testutil.MockSettingsRepository{}
-	transactor := &testutil.MockTransactor{}
-
-	// GetByID returns nil -- service returns NotFound AppError
-	vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
-		return nil, nil
+func TestAddComment_OtherEmployeeForbidden(t *testing.T) {
+	commentRepo := &testutil.MockCommentRepository{
+		CreateFn: func(_ context.Context, _ *domain.RequestComment) error {
+			t.Fatal("comment must not be stored")
+			return nil
+		},
 	}
+	h := commentFixture(t, commentRepo)
+	router := setupVacationRouter(h, "user-2", "other@test.com", "Other Employee", domain.RoleEmployee)
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
-	emailService := newTestEmailService()
-
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
-	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
-
-	req, _ := http.NewRequest(http.MethodDelete, "/api/vacation/requests/nonexistent", nil)
+	req, _ := http.NewRequest(http.MethodPost, "/api/vacation/requests/vac-1/comments", strings.NewReader(`{"body":"hello"}`))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusNotFound, w.Code)
-
-	var resp dto.ErrorResponse
-	err := json.Unmarshal(w.Body.Bytes(), &resp)
-	require.NoError(t, err)
-	assert.Equal(t, dto.ErrNotFound, resp.Code)
+	assert.Equal(t, http.StatusForbidden, w.Code)
 }
 
-func TestCancel_NoAuthContext(t *testing.T) {
-	vacationRepo := &testutil.MockVacationRepository{}
-	userRepo := &testutil.MockUserRepository{}
-	settingsRepo := &testutil.MockSettingsRepository{}
-	transactor := &testutil.MockTransactor{}
-
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
-	emailService := newTestEmailService()
-
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
-	router := setupVacationRouterNoAuth(h)
+func TestAddComment_BlankBody(t *testing.T) {
+	h := commentFixture(t, &testutil.MockCommentRepository{})
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
-	req, _ := http.NewRequest(http.MethodDelete, "/api/vacation/requests/vac-1", nil)
+	req, _ := http.NewRequest(http.MethodPost, "/api/vacation/requests/vac-1/comments", strings.NewReader(`{"body":"   "}`))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusUnauthorized, w.Code)
-
-	var resp dto.ErrorResponse
-	err := json.Unmarshal(w.Body.Bytes(), &resp)
-	require.NoError(t, err)
-	assert.Equal(t, dto.ErrAuthTokenMissing, resp.Code)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
-// ============================================
-// Team Tests
-// ============================================
+func TestComments_AdminListsThread(t *testing.T) {
+	commentRepo := &testutil.MockCommentRepository{
+		ListByRequestFn: func(_ context.Context, requestID string) ([]*domain.RequestComment, error) {
+			return []*domain.RequestComment{
+				{ID: "c-1", RequestID: requestID, AuthorID: "user-1", AuthorName: "Test Employee", Body: "first"},
+				{ID: "c-2", RequestID: requestID, AuthorID: "admin-1", AuthorName: "Admin", Body: "second"},
+			}, nil
+		},
+	}
+	h := commentFixture(t, commentRepo)
+	router := setupVacationRouter(h, "admin-1", "admin@test.com", "Admin", domain.RoleAdmin)
 
-func TestTeam_Success_DefaultMonthYear(t *testing.T) {
-	vacationRepo := &testutil.MockVacationRepository{}
-	userRepo := &testutil.MockUserRepository{}
-	settingsRepo := &testutil.MockSettingsRepository{}
-	transactor := &testutil.MockTransactor{}
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests/vac-1/comments", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
 
-	now := time.Now()
-	expectedMonth := int(now.Month())
-	expectedYear := now.Year()
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dto.CommentListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Total)
+	assert.Equal(t, "first", resp.Comments[0].Body)
+	assert.Equal(t, "second", resp.Comments[1].Body)
+}
 
-	vacationRepo.ListTeamFn = func(_ context.Context, month, year int) ([]*domain.TeamVacation, error) {
-		assert.Equal(t, expectedMonth, month)
-		assert.Equal(t, expectedYear, year)
-		return []*domain.TeamVacation{
-			{
-				ID:        "vac-1",
-				UserID:    "user-2",
-				UserName:  "Team Member",
-				StartDate: "2027-06-15",
-				EndDate:   "2027-06-20",
-				TotalDays: 5,
-			},
-		}, nil
+func TestGet_IncludeComments(t *testing.T) {
+	commentRepo := &testutil.MockCommentRepository{
+		ListByRequestFn: func(_ context.Context, requestID string) ([]*domain.RequestComment, error) {
+			return []*domain.RequestComment{{ID: "c-1", RequestID: requestID, AuthorID: "user-1", Body: "hi"}}, nil
+		},
 	}
-
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
-	emailService := newTestEmailService()
-
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := commentFixture(t, commentRepo)
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
-	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team", nil)
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests/vac-1?includeComments=true", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
-
 	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dto.VacationRequestResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Comments, 1)
+	assert.Equal(t, "hi", resp.Comments[0].Body)
 
-	var resp dto.TeamVacationResponse
-	err := json.Unmarshal(w.Body.Bytes(), &resp)
-	require.NoError(t, err)
-	assert.Equal(t, expectedMonth, resp.Month)
-	assert.Equal(t, expectedYear, resp.Year)
-	assert.Len(t, resp.Vacations, 1)
-	assert.Equal(t, "vac-1", resp.Vacations[0].ID)
-	assert.Equal(t, "Team Member", resp.Vacations[0].UserName)
+	req, _ = http.NewRequest(http.MethodGet, "/api/vacation/requests/vac-1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.NotContains(t, w.Body.String(), `"comments"`)
 }
 
-func TestTeam_Success_ExplicitMonthYear(t *testing.T) {
+// samplePDF is enough of a PDF for content sniffing
+var samplePDF = []byte("%PDF-1.4\n% sick note\n")
+
+// attachmentFixture wires a vacation handler whose attachment service stores files in dir
+// vac-1 is a pending request owned by user-1
+func attachmentFixture(t *testing.T, attachmentRepo *testutil.MockAttachmentRepository, maxSize int64) (*handler.VacationHandler, string) {
 	vacationRepo := &testutil.MockVacationRepository{}
 	userRepo := &testutil.MockUserRepository{}
-	settingsRepo := &testutil.MockSettingsRepository{}
-	transactor := &testutil.MockTransactor{}
-
-	vacationRepo.ListTeamFn = func(_ context.Context, month, year int) ([]*domain.TeamVacation, error) {
-		assert.Equal(t, 8, month)
-		assert.Equal(t, 2027, year)
-		return []*domain.TeamVacation{}, nil
+	vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == "vac-1" {
+			return &domain.VacationRequest{ID: "vac-1", UserID: "user-1", StartDate: "2027-06-15", EndDate: "2027-06-20", TotalDays: 5, Status: domain.StatusPending}, nil
+		}
+		return nil, nil
 	}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
-	emailService := newTestEmailService()
+	dir := t.TempDir()
+	vacationService := service.NewVacationService(vacationRepo, userRepo, &testutil.MockSettingsRepository{}, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{})
+	attachmentService := service.NewAttachmentService(attachmentRepo, vacationRepo, dir, maxSize)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), attachmentService)
+	return h, dir
+}
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+// multipartUpload builds a POST with content in the "file" form field
+func multipartUpload(t *testing.T, url, fileName string, content []byte) *http.Request {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", fileName)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, url, &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestUploadAttachment_OwnerUploadsPDF(t *testing.T) {
+	var created *domain.Attachment
+	attachmentRepo := &testutil.MockAttachmentRepository{
+		CreateFn: func(_ context.Context, attachment *domain.Attachment) error {
+			created = attachment
+			return nil
+		},
+	}
+	h, dir := attachmentFixture(t, attachmentRepo, 1<<20)
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
-	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team?month=8&year=2027", nil)
 	w := httptest.NewRecorder()
-	router.ServeHTTP(w, req)
+	router.ServeHTTP(w, multipartUpload(t, "/api/vacation/requests/vac-1/attachments", "../doctor-note.pdf", samplePDF))
 
-	assert.Equal(t, http.StatusOK, w.Code)
-
-	var resp dto.TeamVacationResponse
-	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	require.NotNil(t, created)
+	var resp dto.AttachmentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "doctor-note.pdf", resp.FileName)
+	assert.Equal(t, "application/pdf", resp.ContentType)
+	assert.Equal(t, int64(len(samplePDF)), resp.Size)
+
+	stored, err := os.ReadFile(filepath.Join(dir, "vac-1", created.ID))
 	require.NoError(t, err)
-	assert.Equal(t, 8, resp.Month)
-	assert.Equal(t, 2027, resp.Year)
-	assert.Empty(t, resp.Vacations)
+	assert.Equal(t, samplePDF, stored)
 }
 
-func TestTeam_InvalidMonth(t *testing.T) {
-	vacationRepo := &testutil.MockVacationRepository{}
-	userRepo := &testutil.MockUserRepository{}
-	settingsRepo := &testutil.MockSettingsRepository{}
-	transactor := &testutil.MockTransactor{}
-
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
-	emailService := newTestEmailService()
-
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+func TestUploadAttachment_RejectsDisallowedType(t *testing.T) {
+	h, dir := attachmentFixture(t, &testutil.MockAttachmentRepository{}, 1<<20)
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
-	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team?month=13", nil)
 	w := httptest.NewRecorder()
-	router.ServeHTTP(w, req)
+	router.ServeHTTP(w, multipartUpload(t, "/api/vacation/requests/vac-1/attachments", "note.pdf", []byte("just some text, not a PDF")))
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-
-	var resp dto.ErrorResponse
-	err := json.Unmarshal(w.Body.Bytes(), &resp)
-	require.NoError(t, err)
-	assert.Equal(t, dto.ErrValidation, resp.Code)
-	assert.Contains(t, resp.Message, "Invalid month")
+	entries, _ := os.ReadDir(filepath.Join(dir, "vac-1"))
+	assert.Empty(t, entries)
+}
+
+func TestUploadAttachment_TooLarge(t *testing.T) {
+	attachmentRepo := &testutil.MockAttachmentRepository{
+		CreateFn: func(_ context.Context, _ *domain.Attachment) error {
+			t.Fatal("oversized upload must not be stored")
+			return nil
+		},
+	}
+	h, dir := attachmentFixture(t, attachmentRepo, 1024)
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	content := append(append([]byte{}, samplePDF...), bytes.Repeat([]byte("x"), 2048)...)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, multipartUpload(t, "/api/vacation/requests/vac-1/attachments", "note.pdf", content))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	entries, _ := os.ReadDir(filepath.Join(dir, "vac-1"))
+	assert.Empty(t, entries)
+}
+
+func TestUploadAttachment_OtherEmployeeForbidden(t *testing.T) {
+	h, _ := attachmentFixture(t, &testutil.MockAttachmentRepository{}, 1<<20)
+	router := setupVacationRouter(h, "user-2", "other@test.com", "Other Employee", domain.RoleEmployee)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, multipartUpload(t, "/api/vacation/requests/vac-1/attachments", "note.pdf", samplePDF))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDownloadAttachment_AdminGetsFile(t *testing.T) {
+	attachmentRepo := &testutil.MockAttachmentRepository{
+		GetByIDFn: func(_ context.Context, id string) (*domain.Attachment, error) {
+			if id != "att-1" {
+				return nil, nil
+			}
+			return &domain.Attachment{ID: "att-1", RequestID: "vac-1", UploadedBy: "user-1", FileName: "note.pdf", ContentType: "application/pdf", Size: int64(len(samplePDF))}, nil
+		},
+	}
+	h, dir := attachmentFixture(t, attachmentRepo, 1<<20)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "vac-1"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vac-1", "att-1"), samplePDF, 0o640))
+	router := setupVacationRouter(h, "admin-1", "admin@test.com", "Admin", domain.RoleAdmin)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests/vac-1/attachments/att-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, samplePDF, w.Body.Bytes())
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "note.pdf")
+
+	// An attachment is only reachable through the request it belongs to
+	req, _ = http.NewRequest(http.MethodGet, "/api/vacation/requests/vac-1/attachments/att-2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCancel_RemovesAttachments(t *testing.T) {
+	h, dir := attachmentFixture(t, &testutil.MockAttachmentRepository{}, 1<<20)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "vac-1"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vac-1", "att-1"), samplePDF, 0o640))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/vacation/requests/vac-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	_, err := os.Stat(filepath.Join(dir, "vac-1"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCancel_Success(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	now := time.Now()
+	vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == "vac-1" {
+			return &domain.VacationRequest{
+				ID:        "vac-1",
+				UserID:    "user-1",
+				StartDate: "2027-06-15",
+				EndDate:   "2027-06-20",
+				TotalDays: 5,
+				Status:    domain.StatusPending, // must be pending to cancel
+				CreatedAt: now,
+				UpdatedAt: now,
+			}, nil
+		}
+		return nil, nil
+	}
+
+	deleteCalled := false
+	vacationRepo.DeleteFn = func(_ context.Context, id string) error {
+		assert.Equal(t, "vac-1", id)
+		deleteCalled = true
+		return nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/vacation/requests/vac-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, deleteCalled)
+
+	var resp dto.MessageResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Contains(t, resp.Message, "cancelled successfully")
+}
+
+func TestCancel_ApprovedNotifiesAdmins(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	now := time.Now()
+	vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return &domain.VacationRequest{
+			ID:        "vac-1",
+			UserID:    "user-1",
+			StartDate: "2027-06-15",
+			EndDate:   "2027-06-20",
+			TotalDays: 5,
+			Status:    domain.StatusApproved,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}, nil
+	}
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, Name: "Test Employee", Role: domain.RoleEmployee, VacationBalance: 10}, nil
+	}
+	var refunded int
+	userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, delta, _ int) (int, error) {
+		refunded = delta
+		return 10 + delta, nil
+	}
+	// Notifications go out in the background
+	adminsQueried := make(chan domain.Role, 1)
+	userRepo.GetByRoleFn = func(_ context.Context, role domain.Role) ([]*domain.User, error) {
+		adminsQueried <- role
+		return nil, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/vacation/requests/vac-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 5, refunded)
+	select {
+	case role := <-adminsQueried:
+		assert.Equal(t, domain.RoleAdmin, role)
+	case <-time.After(time.Second):
+		t.Fatal("admins were not notified")
+	}
+}
+
+func TestCancel_NotFound(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	// GetByID returns nil -- service returns NotFound AppError
+	vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return nil, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/vacation/requests/nonexistent", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var resp dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, dto.ErrNotFound, resp.Code)
+}
+
+func TestCancel_NoAuthContext(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouterNoAuth(h)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/vacation/requests/vac-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var resp dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, dto.ErrAuthTokenMissing, resp.Code)
+}
+
+// ============================================
+// Suggest Tests
+// ============================================
+
+func TestSuggest_Success(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: "user-1", Role: domain.RoleEmployee, VacationBalance: 20}, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	monday := futureMonday(30)
+	body := `{"days":5,"from":"` + monday.Format("02/01/2006") + `","to":"` + monday.AddDate(0, 0, 13).Format("02/01/2006") + `"}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/vacation/suggest", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.VacationSuggestionsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Total)
+	assert.Equal(t, monday.Format("2006-01-02"), resp.Suggestions[0].StartDate)
+}
+
+func TestSuggest_InvalidBody(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/vacation/suggest", strings.NewReader(`{"days":0}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// ============================================
+// Drafts / Submit Tests
+// ============================================
+
+func TestDrafts_Success(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	vacationRepo.ListByUserFn = func(_ context.Context, userID string, status *domain.VacationStatus, year *int, _, _ string) ([]*domain.VacationRequest, error) {
+		assert.Equal(t, "user-1", userID)
+		require.NotNil(t, status)
+		assert.Equal(t, domain.StatusTentative, *status)
+		assert.Nil(t, year)
+		return []*domain.VacationRequest{
+			{ID: "draft-1", UserID: "user-1", StartDate: "2027-06-14", EndDate: "2027-06-18", TotalDays: 5, Status: domain.StatusTentative},
+		}, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/drafts", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.VacationListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Total)
+	assert.Equal(t, "draft-1", resp.Requests[0].ID)
+	assert.Equal(t, "tentative", resp.Requests[0].Status)
+}
+
+func TestDrafts_NoAuthContext(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouterNoAuth(h)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/drafts", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestSubmit_Success(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	monday := futureMonday(30)
+	draft := &domain.VacationRequest{
+		ID:        "draft-1",
+		UserID:    "user-1",
+		StartDate: monday.Format("2006-01-02"),
+		EndDate:   monday.AddDate(0, 0, 1).Format("2006-01-02"),
+		TotalDays: 2,
+		Status:    domain.StatusTentative,
+	}
+
+	vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == "draft-1" {
+			return draft, nil
+		}
+		return nil, nil
+	}
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: "user-1", Role: domain.RoleEmployee, VacationBalance: 20}, nil
+	}
+	userRepo.GetByRoleFn = func(_ context.Context, role domain.Role) ([]*domain.User, error) {
+		return nil, nil
+	}
+	vacationRepo.PromoteTentativeTxFn = func(_ context.Context, _ *sql.Tx, id string, status domain.VacationStatus, totalDays int) error {
+		draft.Status = status
+		draft.TotalDays = totalDays
+		return nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/vacation/requests/draft-1/submit", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.VacationRequestResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", resp.Status)
+	assert.Equal(t, 2, resp.TotalDays)
+
+	// Allow goroutine to finish before test cleanup
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestSubmit_InsufficientBalance(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	monday := futureMonday(30)
+	vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return &domain.VacationRequest{
+			ID:        "draft-1",
+			UserID:    "user-1",
+			StartDate: monday.Format("2006-01-02"),
+			EndDate:   monday.AddDate(0, 0, 4).Format("2006-01-02"),
+			TotalDays: 5,
+			Status:    domain.StatusTentative,
+		}, nil
+	}
+	// Balance dropped since the draft was pencilled in
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: "user-1", Role: domain.RoleEmployee, VacationBalance: 2}, nil
+	}
+	vacationRepo.PromoteTentativeTxFn = func(_ context.Context, _ *sql.Tx, _ string, _ domain.VacationStatus, _ int) error {
+		t.Fatal("draft must not be promoted when balance is insufficient")
+		return nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/vacation/requests/draft-1/submit", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var resp dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, dto.ErrInsufficientBalance, resp.Code)
+}
+
+// ============================================
+// Team Tests
+// ============================================
+
+func TestTeam_Success_DefaultMonthYear(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	now := time.Now()
+	expectedMonth := int(now.Month())
+	expectedYear := now.Year()
+
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, Role: domain.RoleEmployee}, nil
+	}
+	vacationRepo.ListTeamFn = func(_ context.Context, month, year int, _ string) ([]*domain.TeamVacation, error) {
+		assert.Equal(t, expectedMonth, month)
+		assert.Equal(t, expectedYear, year)
+		return []*domain.TeamVacation{
+			{
+				ID:        "vac-1",
+				UserID:    "user-2",
+				UserName:  "Team Member",
+				StartDate: "2027-06-15",
+				EndDate:   "2027-06-20",
+				TotalDays: 5,
+				Type:      domain.RequestTypeRemote,
+			},
+		}, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.TeamVacationResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, expectedMonth, resp.Month)
+	assert.Equal(t, expectedYear, resp.Year)
+	assert.Len(t, resp.Vacations, 1)
+	assert.Equal(t, "vac-1", resp.Vacations[0].ID)
+	assert.Equal(t, "Team Member", resp.Vacations[0].UserName)
+	assert.Equal(t, domain.RequestTypeRemote, resp.Vacations[0].Type)
+}
+
+func TestTeam_Success_ExplicitMonthYear(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, Role: domain.RoleEmployee}, nil
+	}
+	vacationRepo.ListTeamFn = func(_ context.Context, month, year int, _ string) ([]*domain.TeamVacation, error) {
+		assert.Equal(t, 8, month)
+		assert.Equal(t, 2027, year)
+		return []*domain.TeamVacation{}, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team?month=8&year=2027", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.TeamVacationResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, 8, resp.Month)
+	assert.Equal(t, 2027, resp.Year)
+	assert.Empty(t, resp.Vacations)
+}
+
+func TestTeam_IncludePending(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, Role: domain.RoleEmployee}, nil
+	}
+	vacationRepo.ListTeamWithPendingFn = func(_ context.Context, _, _ int, _ string) ([]*domain.TeamVacation, error) {
+		return []*domain.TeamVacation{
+			{ID: "v1", UserID: "emp-1", UserName: "Alice", StartDate: "2027-08-02", EndDate: "2027-08-03", TotalDays: 2, Status: domain.StatusApproved},
+			{ID: "v2", UserID: "emp-2", UserName: "Bob", StartDate: "2027-08-09", EndDate: "2027-08-09", TotalDays: 1, Status: domain.StatusPending},
+		}, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, &testutil.MockSettingsRepository{}, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{})
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team?month=8&year=2027&includePending=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.TeamVacationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Vacations, 2)
+	assert.Equal(t, domain.StatusApproved, resp.Vacations[0].Status)
+	assert.Equal(t, domain.StatusPending, resp.Vacations[1].Status)
+}
+
+func TestTeam_InvalidIncludePending(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, &testutil.MockSettingsRepository{}, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{})
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team?includePending=maybe", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTeam_ScopedToCallerTeam(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+
+	teamID := "team-1"
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, Role: domain.RoleEmployee, TeamID: &teamID}, nil
+	}
+	vacationRepo.ListTeamFn = func(_ context.Context, _, _ int, gotTeamID string) ([]*domain.TeamVacation, error) {
+		assert.Equal(t, "team-1", gotTeamID)
+		return []*domain.TeamVacation{}, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, &testutil.MockSettingsRepository{}, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{})
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team?month=8&year=2027", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.TeamVacationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "team-1", resp.TeamID)
+}
+
+func TestTeam_EmployeeTeamOverrideForbidden(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, &testutil.MockSettingsRepository{}, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{})
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team?teamId=team-2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestTeamWeeks_Success(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, Role: domain.RoleEmployee}, nil
+	}
+	vacationRepo.ListTeamFn = func(_ context.Context, month, year int, _ string) ([]*domain.TeamVacation, error) {
+		assert.Equal(t, 2, month)
+		assert.Equal(t, 2027, year)
+		return []*domain.TeamVacation{
+			{ID: "v1", UserID: "emp-1", UserName: "Alice", StartDate: "2027-02-01", EndDate: "2027-02-02", TotalDays: 2},
+		}, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, &testutil.MockSettingsRepository{}, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{})
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team/calendar?month=2&year=2027", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.TeamWeeksResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Month)
+	// February 2027 starts on a Monday and spans exactly four ISO weeks
+	require.Len(t, resp.Weeks, 4)
+	assert.Equal(t, 5, resp.Weeks[0].Week)
+	require.Len(t, resp.Weeks[0].Days[1].Off, 1)
+	assert.Equal(t, "Alice", resp.Weeks[0].Days[1].Off[0].UserName)
+	assert.Empty(t, resp.Weeks[0].Days[2].Off)
+}
+
+func TestWorkingDays(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, &testutil.MockSettingsRepository{}, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{})
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	// Fri 18 to Mon 21 June 2027 under the default Saturday/Sunday weekend
+	req, _ := http.NewRequest(http.MethodGet, "/api/calendar/working-days?from=2027-06-18&to=2027-06-21", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dto.WorkingDaysResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "2027-06-18", resp.From)
+	assert.Equal(t, []domain.RequestDay{
+		{Date: "2027-06-18", Type: domain.DayBusiness},
+		{Date: "2027-06-19", Type: domain.DayWeekend},
+		{Date: "2027-06-20", Type: domain.DayWeekend},
+		{Date: "2027-06-21", Type: domain.DayBusiness},
+	}, resp.Days)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/calendar/working-days?from=2027-06-18", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errResp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+	assert.Equal(t, dto.ErrValidation, errResp.Code)
+}
+
+func TestTeamWeeks_Unauthenticated(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, &testutil.MockSettingsRepository{}, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{})
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouterNoAuth(h)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team/calendar", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestTeam_InvalidMonth(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team?month=13", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, dto.ErrValidation, resp.Code)
+	assert.Contains(t, resp.Message, "Invalid month")
 }
 
 func TestTeam_InvalidYear(t *testing.T) {
@@ -830,10 +1760,10 @@ func TestTeam_InvalidYear(t *testing.T) {
 	settingsRepo := &testutil.MockSettingsRepository{}
 	transactor := &testutil.MockTransactor{}
 
-	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, transactor)
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
 	emailService := newTestEmailService()
 
-	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
 	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team?year=abc", nil)
@@ -848,3 +1778,176 @@ func TestTeam_InvalidYear(t *testing.T) {
 	assert.Equal(t, dto.ErrValidation, resp.Code)
 	assert.Contains(t, resp.Message, "Invalid year")
 }
+
+// ============================================
+// Calendar Export Tests
+// ============================================
+
+func TestTeamCalendar_Success(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, Role: domain.RoleEmployee}, nil
+	}
+	vacationRepo.ListTeamFn = func(_ context.Context, month, year int, _ string) ([]*domain.TeamVacation, error) {
+		assert.Equal(t, 6, month)
+		assert.Equal(t, 2027, year)
+		return []*domain.TeamVacation{
+			{ID: "vac-1", UserID: "user-2", UserName: "Alice", StartDate: "2027-06-14", EndDate: "2027-06-18", TotalDays: 5},
+		}, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team.ics?month=6&year=2027", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/calendar")
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "team-vacations-2027-06.ics")
+	assert.Contains(t, w.Body.String(), "UID:vac-1@vacaytracker")
+	assert.Contains(t, w.Body.String(), "DTEND;VALUE=DATE:20270619")
+}
+
+func TestGantt_Success(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	teamID := "team-1"
+	userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, Role: domain.RoleEmployee, TeamID: &teamID}, nil
+	}
+	vacationRepo.ListTeamRangeFn = func(_ context.Context, from, to, teamID string) ([]*domain.TeamVacation, error) {
+		assert.Equal(t, "team-1", teamID, "employees see their own team")
+		return []*domain.TeamVacation{
+			{ID: "vac-1", UserID: "user-2", UserName: "Alice", StartDate: "2027-06-07", EndDate: "2027-06-11", TotalDays: 5},
+			{ID: "vac-2", UserID: "user-2", UserName: "Alice", StartDate: "2027-06-21", EndDate: "2027-06-22", TotalDays: 2},
+		}, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/gantt?from=2027-06-01&to=2027-06-30", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.GanttResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 30, resp.Days)
+	require.Len(t, resp.Members, 1)
+	require.Len(t, resp.Members[0].Segments, 2)
+	assert.Equal(t, "2027-06-21", resp.Members[0].Segments[1].StartDate)
+	assert.Equal(t, "2027-06-22", resp.Members[0].Segments[1].EndDate)
+}
+
+func TestGantt_EmployeeCannotPickTeam(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, &testutil.MockSettingsRepository{}, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{})
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/gantt?from=2027-06-01&to=2027-06-30&teamId=team-2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGantt_MissingRange(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/gantt?from=2027-06-01", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGantt_Unauthenticated(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, &testutil.MockSettingsRepository{}, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{})
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, newTestEmailService(), newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouterNoAuth(h)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/gantt?from=2027-06-01&to=2027-06-30", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestTeamCalendar_InvalidMonth(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/team.ics?month=13", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestMyCalendar_OnlyApproved(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{}
+	userRepo := &testutil.MockUserRepository{}
+	settingsRepo := &testutil.MockSettingsRepository{}
+	transactor := &testutil.MockTransactor{}
+
+	vacationRepo.ListByUserFn = func(_ context.Context, userID string, status *domain.VacationStatus, year *int, _, _ string) ([]*domain.VacationRequest, error) {
+		assert.Equal(t, "user-1", userID)
+		require.NotNil(t, status)
+		assert.Equal(t, domain.StatusApproved, *status)
+		return []*domain.VacationRequest{
+			{ID: "vac-9", UserID: "user-1", StartDate: "2027-08-02", EndDate: "2027-08-06", Status: domain.StatusApproved},
+		}, nil
+	}
+
+	vacationService := service.NewVacationService(vacationRepo, userRepo, settingsRepo, &testutil.MockLedgerRepository{}, transactor)
+	emailService := newTestEmailService()
+
+	h := handler.NewVacationHandler(vacationService, vacationRepo, userRepo, emailService, newTestWebhookService(), newTestCommentService(), newTestAttachmentService(t))
+	router := setupVacationRouter(h, "user-1", "employee@test.com", "Test Employee", domain.RoleEmployee)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/vacation/requests.ics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "my-vacations.ics")
+	assert.Contains(t, w.Body.String(), "UID:vac-9@vacaytracker")
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the Prometheus collectors exposed on /metrics
+// It uses its own registry so only these collectors (plus Go/process stats) are exported
+type Metrics struct {
+	registry          *prometheus.Registry
+	requestDuration   *prometheus.HistogramVec
+	pendingRequests   prometheus.Gauge
+	daysUsedThisMonth prometheus.Gauge
+}
+
+// New creates and registers all collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "vacaytracker_http_request_duration_seconds",
+			Help:    "HTTP request latency by method, route and status; the _count series gives request counts.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		pendingRequests: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "vacaytracker_pending_requests",
+			Help: "Vacation requests currently awaiting review.",
+		}),
+		daysUsedThisMonth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "vacaytracker_days_used_this_month",
+			Help: "Business days of approved vacation requested this calendar month.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requestDuration,
+		m.pendingRequests,
+		m.daysUsedThisMonth,
+	)
+
+	return m
+}
+
+// Handler returns the HTTP handler serving the Prometheus text exposition
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records one handled HTTP request
+func (m *Metrics) ObserveRequest(method, route, status string, duration time.Duration) {
+	m.requestDuration.WithLabelValues(method, route, status).Observe(duration.Seconds())
+}
+
+// SetPendingRequests updates the pending request gauge
+func (m *Metrics) SetPendingRequests(count int) {
+	m.pendingRequests.Set(float64(count))
+}
+
+// SetDaysUsedThisMonth updates the days used gauge
+func (m *Metrics) SetDaysUsedThisMonth(days int) {
+	m.daysUsedThisMonth.Set(float64(days))
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLogEntry is a single structured access log line
+type AccessLogEntry struct {
+	Timestamp string  `json:"timestamp"`
+	Method    string  `json:"method"`
+	Path      string  `json:"path"`
+	Status    int     `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	ClientIP  string  `json:"clientIp"`
+	UserID    string  `json:"userId,omitempty"`
+	RequestID string  `json:"requestId"`
+}
+
+// AccessLogMiddleware writes one line per request to out
+// jsonFormat emits one JSON object per line for log ingestion; otherwise a readable text line for development
+func AccessLogMiddleware(out io.Writer, jsonFormat bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		// Path only; query strings are left out so parameter values never reach the logs
+		entry := AccessLogEntry{
+			Timestamp: start.UTC().Format(time.RFC3339),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.Path,
+			Status:    c.Writer.Status(),
+			LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			ClientIP:  c.ClientIP(),
+			UserID:    GetUserID(c),
+			RequestID: GetRequestID(c),
+		}
+
+		if jsonFormat {
+			line, err := json.Marshal(entry)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(out, "%s\n", line)
+			return
+		}
+
+		userID := entry.UserID
+		if userID == "" {
+			userID = "-"
+		}
+		fmt.Fprintf(out, "[HTTP] %s | %3d | %8.2fms | %15s | %-7s %s | user=%s | rid=%s\n",
+			start.Format("2006/01/02 - 15:04:05"),
+			entry.Status,
+			entry.LatencyMs,
+			entry.ClientIP,
+			entry.Method,
+			entry.Path,
+			userID,
+			entry.RequestID,
+		)
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAccessLogRouter(out *bytes.Buffer, jsonFormat bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(RequestID())
+	router.Use(AccessLogMiddleware(out, jsonFormat))
+	router.GET("/api/things/:id", func(c *gin.Context) {
+		c.Set(ContextKeyUserID, "user-1")
+		c.Status(http.StatusAccepted)
+	})
+	return router
+}
+
+func TestAccessLogMiddleware_JSON(t *testing.T) {
+	var out bytes.Buffer
+	router := setupAccessLogRouter(&out, true)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/things/42?secret=x", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry AccessLogEntry
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	assert.Equal(t, http.MethodGet, entry.Method)
+	assert.Equal(t, "/api/things/42", entry.Path)
+	assert.Equal(t, http.StatusAccepted, entry.Status)
+	assert.Equal(t, "user-1", entry.UserID)
+	assert.Equal(t, "req-123", entry.RequestID)
+	assert.GreaterOrEqual(t, entry.LatencyMs, 0.0)
+	assert.NotEmpty(t, entry.Timestamp)
+	assert.Equal(t, 1, strings.Count(out.String(), "\n"))
+}
+
+func TestAccessLogMiddleware_Text(t *testing.T) {
+	var out bytes.Buffer
+	router := setupAccessLogRouter(&out, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/things/42", nil)
+	req.Header.Set(RequestIDHeader, "req-456")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	line := out.String()
+	assert.True(t, strings.HasPrefix(line, "[HTTP] "))
+	assert.Contains(t, line, "202")
+	assert.Contains(t, line, "/api/things/42")
+	assert.Contains(t, line, "user=user-1")
+	assert.Contains(t, line, "rid=req-456")
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"vacaytracker-api/internal/dto"
+)
+
+// ContextKeyDeniedReason carries why a request was refused, picked up by SecurityLoggingMiddleware
+const ContextKeyDeniedReason = "denied_reason"
+
+// IPAllowlist restricts a route group to clients whose IP falls within one of the given CIDRs
+// Bare IPs are accepted as single-host ranges; an empty list disables the check
+// It panics on an invalid entry, so validate configured values first (see ParseAllowlist)
+func IPAllowlist(cidrs []string) gin.HandlerFunc {
+	prefixes, err := ParseAllowlist(cidrs)
+	if err != nil {
+		panic(err)
+	}
+
+	if len(prefixes) == 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	return func(c *gin.Context) {
+		if !ipAllowed(c.ClientIP(), prefixes) {
+			c.Set(ContextKeyDeniedReason, "IP not in allowlist")
+			err := dto.ErrForbiddenError("Access from this network is not allowed")
+			c.AbortWithStatusJSON(err.HTTPStatus, err.ToResponse())
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ParseAllowlist parses CIDR or bare IP entries, ignoring blanks
+func ParseAllowlist(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, entry := range cidrs {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q: %w", entry, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP %q: %w", entry, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// ipAllowed reports whether ip falls within any of the prefixes
+func ipAllowed(ip string, prefixes []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
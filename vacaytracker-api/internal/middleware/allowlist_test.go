@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAllowlistRouter mounts IPAllowlist ahead of a handler that records whether it ran
+func newAllowlistRouter(cidrs []string, reached *bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(SecurityLoggingMiddleware(NewSecurityLogger()))
+	router.Use(IPAllowlist(cidrs))
+	router.GET("/api/admin/users", func(c *gin.Context) {
+		*reached = true
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	return router
+}
+
+func allowlistRequest(router *gin.Engine, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users", nil)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIPAllowlist_AllowsListedNetworks(t *testing.T) {
+	cidrs := []string{"10.0.0.0/8", "192.168.1.20", "2001:db8::/32"}
+
+	for _, addr := range []string{"10.1.2.3:5000", "192.168.1.20:5000", "[2001:db8::1]:5000"} {
+		reached := false
+		rec := allowlistRequest(newAllowlistRouter(cidrs, &reached), addr)
+
+		assert.Equal(t, http.StatusOK, rec.Code, addr)
+		assert.True(t, reached, addr)
+	}
+}
+
+func TestIPAllowlist_RejectsOtherNetworks(t *testing.T) {
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+
+	reached := false
+	rec := allowlistRequest(newAllowlistRouter([]string{"10.0.0.0/8", "192.168.1.20"}, &reached), "192.168.1.21:5000")
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.False(t, reached, "handler must not run for a rejected IP")
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "FORBIDDEN", body["code"])
+
+	assert.Contains(t, out.String(), "UNAUTHORIZED_ACCESS")
+	assert.Contains(t, out.String(), "IP not in allowlist")
+}
+
+func TestIPAllowlist_EmptyListAllowsAll(t *testing.T) {
+	reached := false
+	rec := allowlistRequest(newAllowlistRouter(nil, &reached), "203.0.113.7:5000")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, reached)
+}
+
+func TestIPAllowlist_PanicsOnInvalidEntry(t *testing.T) {
+	assert.Panics(t, func() { IPAllowlist([]string{"10.0.0.0/33"}) })
+}
+
+func TestParseAllowlist(t *testing.T) {
+	prefixes, err := ParseAllowlist([]string{" 10.0.0.0/8 ", "", "192.168.1.20", "192.168.1.77/24"})
+	require.NoError(t, err)
+	require.Len(t, prefixes, 3)
+	assert.Equal(t, "10.0.0.0/8", prefixes[0].String())
+	assert.Equal(t, "192.168.1.20/32", prefixes[1].String())
+	assert.Equal(t, "192.168.1.0/24", prefixes[2].String())
+
+	_, err = ParseAllowlist([]string{"office"})
+	assert.Error(t, err)
+}
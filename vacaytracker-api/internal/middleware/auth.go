@@ -60,6 +60,34 @@ func AuthMiddleware(authService *service.AuthService) gin.HandlerFunc {
 	}
 }
 
+// PasswordChangeMiddleware blocks users who still have a temporary password or have been deactivated
+// Must be used after AuthMiddleware; the flags are read from the database so changes apply to live sessions
+func PasswordChangeMiddleware(authService *service.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := authService.GetUserByID(c.Request.Context(), GetUserID(c))
+		if err != nil {
+			if appErr, ok := err.(*dto.AppError); ok {
+				respondWithError(c, appErr)
+			} else {
+				respondWithError(c, dto.ErrInternalError())
+			}
+			return
+		}
+
+		if !user.Active {
+			respondWithError(c, dto.ErrAccountDisabledError())
+			return
+		}
+
+		if user.MustChangePassword {
+			respondWithError(c, dto.ErrPasswordChangeRequiredError())
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // AdminMiddleware ensures the user has admin role
 // Must be used after AuthMiddleware
 func AdminMiddleware() gin.HandlerFunc {
@@ -80,6 +108,35 @@ func AdminMiddleware() gin.HandlerFunc {
 	}
 }
 
+// ManagerOrAdminMiddleware ensures the user is an admin or manages at least one user
+// Must be used after AuthMiddleware; handlers scope managers to their direct reports
+func ManagerOrAdminMiddleware(authService *service.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, exists := c.Get(ContextKeyRole)
+		if !exists {
+			respondWithError(c, dto.ErrTokenMissingError())
+			return
+		}
+
+		if roleVal, ok := role.(domain.Role); ok && roleVal == domain.RoleAdmin {
+			c.Next()
+			return
+		}
+
+		isManager, err := authService.IsManager(c.Request.Context(), GetUserID(c))
+		if err != nil {
+			respondWithError(c, dto.ErrInternalError())
+			return
+		}
+		if !isManager {
+			respondWithError(c, dto.ErrForbiddenError("Manager or admin privileges required"))
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // EmployeeMiddleware ensures the user has employee role
 // Must be used after AuthMiddleware
 func EmployeeMiddleware() gin.HandlerFunc {
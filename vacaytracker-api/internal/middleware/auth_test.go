@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -22,7 +23,7 @@ const testJWTSecret = "test-secret-key-for-middleware-tests-32chars!"
 // newTestAuthService creates an AuthService backed by a mock user repo.
 func newTestAuthService() *service.AuthService {
 	mockRepo := &testutil.MockUserRepository{}
-	return service.NewAuthService(mockRepo, testJWTSecret)
+	return service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
 }
 
 // generateValidToken creates a valid JWT for the given user via the real AuthService.
@@ -353,6 +354,135 @@ func TestEmployeeMiddleware_EmployeeRole(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
+// ─── PasswordChangeMiddleware Tests ───
+
+// newPasswordChangeRouter builds a router where the given user is authenticated.
+func newPasswordChangeRouter(user *domain.User) *gin.Engine {
+	mockRepo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, id string) (*domain.User, error) {
+			return user, nil
+		},
+	}
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(ContextKeyUserID, user.ID)
+		c.Next()
+	})
+	router.Use(PasswordChangeMiddleware(authService))
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	return router
+}
+
+func TestPasswordChangeMiddleware_TemporaryPasswordBlocked(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := newPasswordChangeRouter(&domain.User{ID: "usr_1", Active: true, MustChangePassword: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	var body map[string]interface{}
+	err := json.Unmarshal(rec.Body.Bytes(), &body)
+	require.NoError(t, err)
+	assert.Equal(t, "PASSWORD_CHANGE_REQUIRED", body["code"])
+}
+
+func TestPasswordChangeMiddleware_ChangedPasswordAllowed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := newPasswordChangeRouter(&domain.User{ID: "usr_1", Active: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestPasswordChangeMiddleware_DeactivatedBlocked(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := newPasswordChangeRouter(&domain.User{ID: "usr_1", Active: false})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	var body map[string]interface{}
+	err := json.Unmarshal(rec.Body.Bytes(), &body)
+	require.NoError(t, err)
+	assert.Equal(t, "ACCOUNT_DISABLED", body["code"])
+}
+
+// ─── ManagerOrAdminMiddleware Tests ───
+
+// newManagerRouter builds a router for a user with the given role and number of direct reports.
+func newManagerRouter(role domain.Role, reports int) *gin.Engine {
+	mockRepo := &testutil.MockUserRepository{
+		ListByManagerFn: func(_ context.Context, _ string) ([]*domain.User, error) {
+			return make([]*domain.User, reports), nil
+		},
+	}
+	authService := service.NewAuthService(mockRepo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(ContextKeyUserID, "usr_1")
+		c.Set(ContextKeyRole, role)
+		c.Next()
+	})
+	router.Use(ManagerOrAdminMiddleware(authService))
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	return router
+}
+
+func TestManagerOrAdminMiddleware_AdminAllowed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := newManagerRouter(domain.RoleAdmin, 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestManagerOrAdminMiddleware_ManagerAllowed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := newManagerRouter(domain.RoleEmployee, 2)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestManagerOrAdminMiddleware_EmployeeWithoutReportsForbidden(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := newManagerRouter(domain.RoleEmployee, 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
 // ─── Helper Function Tests ───
 
 func TestGetUserID_Present(t *testing.T) {
@@ -24,7 +24,8 @@ func CORSMiddleware(allowedOrigins []string) gin.HandlerFunc {
 		}
 
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Request-ID, If-None-Match")
+		c.Header("Access-Control-Expose-Headers", "X-Request-ID, ETag")
 		c.Header("Access-Control-Max-Age", "86400")
 
 		// Handle preflight requests
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etagWriter holds back the response body so it can be hashed before anything is sent
+type etagWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *etagWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// ETag tags successful GET responses with a hash of their body and answers 304 Not Modified,
+// without a body, when If-None-Match already lists that tag
+// It buffers the whole response, so attach it only to JSON read endpoints that clients poll, not to downloads
+func ETag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := &etagWriter{ResponseWriter: original}
+		c.Writer = w
+		c.Next()
+		c.Writer = original
+
+		// Errors, and handlers that flushed headers themselves, are passed through untagged
+		if original.Status() != http.StatusOK || original.Written() {
+			original.Write(w.body.Bytes())
+			return
+		}
+
+		sum := sha256.Sum256(w.body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		original.Header().Set("ETag", etag)
+
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			original.Header().Del("Content-Type")
+			original.WriteHeader(http.StatusNotModified)
+			original.WriteHeaderNow()
+			return
+		}
+		original.Write(w.body.Bytes())
+	}
+}
+
+// etagMatches reports whether an If-None-Match header lists etag
+// If-None-Match uses weak comparison, so a W/ prefix on a listed tag is ignored
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
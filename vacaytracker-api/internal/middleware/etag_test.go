@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupETagRouter(body *gin.H) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/items", ETag(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, *body)
+	})
+	router.GET("/missing", ETag(), func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"code": "NOT_FOUND"})
+	})
+	return router
+}
+
+func getWithETag(router *gin.Engine, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestETag_NotModifiedWhenTagMatches(t *testing.T) {
+	body := gin.H{"count": 2}
+	router := setupETagRouter(&body)
+
+	first := getWithETag(router, "/items", "")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.JSONEq(t, `{"count":2}`, first.Body.String())
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	second := getWithETag(router, "/items", etag)
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Empty(t, second.Body.String())
+	assert.Equal(t, etag, second.Header().Get("ETag"))
+
+	// Weak and listed tags match too
+	assert.Equal(t, http.StatusNotModified, getWithETag(router, "/items", `"other", W/`+etag).Code)
+}
+
+func TestETag_ChangedBodyGetsNewTag(t *testing.T) {
+	body := gin.H{"count": 2}
+	router := setupETagRouter(&body)
+
+	etag := getWithETag(router, "/items", "").Header().Get("ETag")
+
+	body = gin.H{"count": 3}
+	rec := getWithETag(router, "/items", etag)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"count":3}`, rec.Body.String())
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+}
+
+func TestETag_ErrorsPassThroughUntagged(t *testing.T) {
+	body := gin.H{}
+	router := setupETagRouter(&body)
+
+	rec := getWithETag(router, "/missing", "*")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"code":"NOT_FOUND"}`, rec.Body.String())
+	assert.Empty(t, rec.Header().Get("ETag"))
+}
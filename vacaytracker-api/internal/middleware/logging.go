@@ -19,6 +19,7 @@ type SecurityEvent struct {
 	UserID      string `json:"userId,omitempty"`
 	Email       string `json:"email,omitempty"`
 	Description string `json:"description,omitempty"`
+	RequestID   string `json:"requestId,omitempty"`
 }
 
 // SecurityLogger provides security event logging
@@ -33,7 +34,7 @@ func NewSecurityLogger() *SecurityLogger {
 
 // LogEvent logs a security event
 func (sl *SecurityLogger) LogEvent(event SecurityEvent) {
-	log.Printf("[SECURITY] %s | Type: %s | IP: %s | Path: %s | Method: %s | Status: %d | UserID: %s | Email: %s | %s",
+	log.Printf("[SECURITY] %s | Type: %s | IP: %s | Path: %s | Method: %s | Status: %d | UserID: %s | Email: %s | RequestID: %s | %s",
 		event.Timestamp,
 		event.EventType,
 		event.IP,
@@ -42,6 +43,7 @@ func (sl *SecurityLogger) LogEvent(event SecurityEvent) {
 		event.StatusCode,
 		event.UserID,
 		event.Email,
+		event.RequestID,
 		event.Description,
 	)
 }
@@ -65,6 +67,7 @@ func (sl *SecurityLogger) LogLoginAttempt(c *gin.Context, email string, success
 		StatusCode:  c.Writer.Status(),
 		Email:       email,
 		Description: description,
+		RequestID:   GetRequestID(c),
 	})
 }
 
@@ -80,6 +83,7 @@ func (sl *SecurityLogger) LogAdminAction(c *gin.Context, userID, action string)
 		StatusCode:  c.Writer.Status(),
 		UserID:      userID,
 		Description: action,
+		RequestID:   GetRequestID(c),
 	})
 }
 
@@ -94,6 +98,7 @@ func (sl *SecurityLogger) LogUnauthorizedAccess(c *gin.Context, reason string) {
 		Method:      c.Request.Method,
 		StatusCode:  c.Writer.Status(),
 		Description: reason,
+		RequestID:   GetRequestID(c),
 	})
 }
 
@@ -108,6 +113,7 @@ func (sl *SecurityLogger) LogRateLimitExceeded(c *gin.Context) {
 		Method:      c.Request.Method,
 		StatusCode:  429,
 		Description: "Rate limit exceeded",
+		RequestID:   GetRequestID(c),
 	})
 }
 
@@ -122,6 +128,7 @@ func (sl *SecurityLogger) LogSuspiciousActivity(c *gin.Context, description stri
 		Method:      c.Request.Method,
 		StatusCode:  c.Writer.Status(),
 		Description: description,
+		RequestID:   GetRequestID(c),
 	})
 }
 
@@ -133,7 +140,7 @@ func SecurityLoggingMiddleware(logger *SecurityLogger) gin.HandlerFunc {
 
 		// Log security-relevant responses
 		status := c.Writer.Status()
-		path := c.Request.URL.Path
+		path := unversionedPath(c.Request.URL.Path)
 
 		// Log failed authentication attempts
 		if path == "/api/auth/login" && status == 401 {
@@ -147,14 +154,18 @@ func SecurityLoggingMiddleware(logger *SecurityLogger) gin.HandlerFunc {
 
 		// Log unauthorized access attempts
 		if status == 401 || status == 403 {
-			logger.LogUnauthorizedAccess(c, "Access denied")
+			reason := "Access denied"
+			if denied := c.GetString(ContextKeyDeniedReason); denied != "" {
+				reason = "Access denied: " + denied
+			}
+			logger.LogUnauthorizedAccess(c, reason)
 		}
 
 		// Log admin actions
 		if len(path) > 11 && path[:11] == "/api/admin/" && (c.Request.Method == "POST" || c.Request.Method == "PUT" || c.Request.Method == "DELETE") {
 			userID, _ := c.Get("userID")
 			if userIDStr, ok := userID.(string); ok {
-				action := c.Request.Method + " " + path
+				action := c.Request.Method + " " + c.Request.URL.Path
 				logger.LogAdminAction(c, userIDStr, action)
 			}
 		}
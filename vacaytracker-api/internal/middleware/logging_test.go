@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityLogger_IncludesRequestID(t *testing.T) {
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	router.Use(SecurityLoggingMiddleware(NewSecurityLogger()))
+	router.GET("/api/private", func(c *gin.Context) {
+		c.Status(http.StatusForbidden)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/private", nil)
+	req.Header.Set(RequestIDHeader, "req-789")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Contains(t, out.String(), "UNAUTHORIZED_ACCESS")
+	assert.Contains(t, out.String(), "RequestID: req-789")
+}
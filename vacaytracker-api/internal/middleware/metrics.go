@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"vacaytracker-api/internal/metrics"
+)
+
+// unmatchedRoute labels requests that hit no registered route, keeping label cardinality bounded
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware records request latency keyed by method, route template and status
+func MetricsMiddleware(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		// FullPath is the route template (/api/vacation/requests/:id), not the raw URL
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		m.ObserveRequest(c.Request.Method, route, strconv.Itoa(c.Writer.Status()), time.Since(start))
+	}
+}
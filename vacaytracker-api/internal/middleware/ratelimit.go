@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"math"
 	"net/http"
 	"strconv"
 	"sync"
@@ -11,6 +12,13 @@ import (
 	"vacaytracker-api/internal/dto"
 )
 
+// Defaults used when a limiter is configured with a non-positive limit or window
+const (
+	defaultLoginRateLimit  = 5
+	defaultAPIRateLimit    = 100
+	defaultRateLimitWindow = time.Minute
+)
+
 // RateLimiter provides IP-based rate limiting
 type RateLimiter struct {
 	mu       sync.RWMutex
@@ -97,12 +105,33 @@ func (rl *RateLimiter) RemainingRequests(ip string) int {
 	return remaining
 }
 
+// RetryAfter returns how long an IP has to wait until its window resets
+func (rl *RateLimiter) RetryAfter(ip string) time.Duration {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	entry, exists := rl.requests[ip]
+	if !exists {
+		return 0
+	}
+	if wait := time.Until(entry.resetTime); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
 // Middleware returns a Gin middleware for rate limiting
 func (rl *RateLimiter) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ip := c.ClientIP()
 
 		if !rl.Allow(ip) {
+			// Whole seconds, rounded up so clients never retry before the window resets
+			retryAfter := int(math.Ceil(rl.RetryAfter(ip).Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
 			c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
 				Code:    dto.ErrRateLimitExceeded,
 				Message: "Too many requests. Please try again later.",
@@ -119,12 +148,25 @@ func (rl *RateLimiter) Middleware() gin.HandlerFunc {
 	}
 }
 
-// LoginRateLimiter creates a rate limiter for login attempts (5 per minute)
-func LoginRateLimiter() *RateLimiter {
-	return NewRateLimiter(5, time.Minute)
+// LoginRateLimiter creates a rate limiter for login attempts
+// Non-positive values fall back to 5 requests per minute
+func LoginRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return newRateLimiterWithDefaults(limit, window, defaultLoginRateLimit)
 }
 
-// APIRateLimiter creates a rate limiter for general API requests (100 per minute)
-func APIRateLimiter() *RateLimiter {
-	return NewRateLimiter(100, time.Minute)
+// APIRateLimiter creates a rate limiter for general API requests
+// Non-positive values fall back to 100 requests per minute
+func APIRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return newRateLimiterWithDefaults(limit, window, defaultAPIRateLimit)
+}
+
+// newRateLimiterWithDefaults creates a rate limiter, replacing non-positive settings with defaults
+func newRateLimiterWithDefaults(limit int, window time.Duration, defaultLimit int) *RateLimiter {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if window <= 0 {
+		window = defaultRateLimitWindow
+	}
+	return NewRateLimiter(limit, window)
 }
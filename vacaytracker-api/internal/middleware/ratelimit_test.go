@@ -98,6 +98,23 @@ func TestRateLimiter_RemainingRequests_ZeroWhenExhausted(t *testing.T) {
 	assert.Equal(t, 0, rl.RemainingRequests("10.0.0.1"))
 }
 
+// ─── RateLimiter.RetryAfter Tests ───
+
+func TestRateLimiter_RetryAfter_ZeroForNewIP(t *testing.T) {
+	rl := NewRateLimiter(1, time.Minute)
+
+	assert.Zero(t, rl.RetryAfter("10.0.0.1"))
+}
+
+func TestRateLimiter_RetryAfter_WithinWindow(t *testing.T) {
+	rl := NewRateLimiter(1, time.Minute)
+	rl.Allow("10.0.0.1")
+
+	wait := rl.RetryAfter("10.0.0.1")
+	assert.Greater(t, wait, 59*time.Second)
+	assert.LessOrEqual(t, wait, time.Minute)
+}
+
 // ─── RateLimiter.Middleware Tests ───
 
 func TestRateLimiterMiddleware_AllowedRequest(t *testing.T) {
@@ -147,6 +164,7 @@ func TestRateLimiterMiddleware_BlockedRequest(t *testing.T) {
 	router.ServeHTTP(rec2, req2)
 
 	assert.Equal(t, http.StatusTooManyRequests, rec2.Code)
+	assert.Equal(t, "60", rec2.Header().Get("Retry-After"))
 
 	var body map[string]interface{}
 	err := json.Unmarshal(rec2.Body.Bytes(), &body)
@@ -157,15 +175,31 @@ func TestRateLimiterMiddleware_BlockedRequest(t *testing.T) {
 // ─── Factory Tests ───
 
 func TestLoginRateLimiter_Limit(t *testing.T) {
-	rl := LoginRateLimiter()
+	rl := LoginRateLimiter(10, 30*time.Second)
+
+	assert.Equal(t, 10, rl.limit)
+	assert.Equal(t, 30*time.Second, rl.window)
+}
+
+func TestLoginRateLimiter_DefaultsForNonPositiveValues(t *testing.T) {
+	rl := LoginRateLimiter(0, 0)
 
 	assert.Equal(t, 5, rl.limit)
+	assert.Equal(t, time.Minute, rl.window)
 }
 
 func TestAPIRateLimiter_Limit(t *testing.T) {
-	rl := APIRateLimiter()
+	rl := APIRateLimiter(250, 2*time.Minute)
+
+	assert.Equal(t, 250, rl.limit)
+	assert.Equal(t, 2*time.Minute, rl.window)
+}
+
+func TestAPIRateLimiter_DefaultsForNonPositiveValues(t *testing.T) {
+	rl := APIRateLimiter(-1, -time.Second)
 
 	assert.Equal(t, 100, rl.limit)
+	assert.Equal(t, time.Minute, rl.window)
 }
 
 func TestRateLimiterMiddleware_HeaderValues(t *testing.T) {
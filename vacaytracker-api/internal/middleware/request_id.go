@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// ContextKeyRequestID stores the request ID in the gin context
+const ContextKeyRequestID = "requestID"
+
+// maxRequestIDLength bounds caller-supplied IDs so they can't bloat log lines
+const maxRequestIDLength = 128
+
+// RequestID assigns each request an ID, reusing a well-formed X-Request-ID from the caller
+// (e.g. a load balancer) and echoing it back in the response header
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !isValidRequestID(id) {
+			id = uuid.New().String()
+		}
+
+		c.Set(ContextKeyRequestID, id)
+		c.Header(RequestIDHeader, id)
+
+		c.Next()
+	}
+}
+
+// GetRequestID retrieves the request ID from the context
+func GetRequestID(c *gin.Context) string {
+	requestID, _ := c.Get(ContextKeyRequestID)
+	str, ok := requestID.(string)
+	if !ok {
+		return ""
+	}
+	return str
+}
+
+// isValidRequestID accepts short IDs of printable, non-space ASCII so they are safe to log verbatim
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
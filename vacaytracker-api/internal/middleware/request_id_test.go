@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRequestIDRouter(seen *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(RequestID())
+	router.GET("/test", func(c *gin.Context) {
+		*seen = GetRequestID(c)
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestRequestID_GeneratesWhenMissing(t *testing.T) {
+	var seen string
+	router := setupRequestIDRouter(&seen)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	_, err := uuid.Parse(seen)
+	assert.NoError(t, err)
+	assert.Equal(t, seen, rec.Header().Get(RequestIDHeader))
+}
+
+func TestRequestID_PropagatesIncoming(t *testing.T) {
+	var seen string
+	router := setupRequestIDRouter(&seen)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set(RequestIDHeader, "lb-4f2a9c")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, "lb-4f2a9c", seen)
+	assert.Equal(t, "lb-4f2a9c", rec.Header().Get(RequestIDHeader))
+}
+
+func TestRequestID_ReplacesMalformedIncoming(t *testing.T) {
+	for _, id := range []string{"has space", "new\tline", strings.Repeat("a", maxRequestIDLength+1)} {
+		var seen string
+		router := setupRequestIDRouter(&seen)
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(RequestIDHeader, id)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.NotEqual(t, id, seen)
+		_, err := uuid.Parse(seen)
+		assert.NoError(t, err)
+	}
+}
+
+func TestGetRequestID_NotSet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	assert.Equal(t, "", GetRequestID(c))
+}
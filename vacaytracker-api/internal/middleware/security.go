@@ -30,7 +30,7 @@ func SecurityHeaders() gin.HandlerFunc {
 		c.Header("Permissions-Policy", "geolocation=(), microphone=(), camera=()")
 
 		// Cache control for sensitive endpoints
-		if c.Request.URL.Path == "/api/auth/login" || c.Request.URL.Path == "/api/auth/me" {
+		if path := unversionedPath(c.Request.URL.Path); path == "/api/auth/login" || path == "/api/auth/me" {
 			c.Header("Cache-Control", "no-store, no-cache, must-revalidate, private")
 			c.Header("Pragma", "no-cache")
 		}
@@ -55,7 +55,7 @@ func ProductionSecurityHeaders() gin.HandlerFunc {
 		c.Header("Content-Security-Policy", "default-src 'self'; script-src 'self'; style-src 'self'; img-src 'self' data:; font-src 'self'; connect-src 'self'; frame-ancestors 'none'; base-uri 'self'; form-action 'self'")
 
 		// Cache control
-		if c.Request.URL.Path == "/api/auth/login" || c.Request.URL.Path == "/api/auth/me" {
+		if path := unversionedPath(c.Request.URL.Path); path == "/api/auth/login" || path == "/api/auth/me" {
 			c.Header("Cache-Control", "no-store, no-cache, must-revalidate, private")
 			c.Header("Pragma", "no-cache")
 		}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersionPrefix is the route prefix of the current API version
+const APIVersionPrefix = "/api/v1"
+
+// LegacyAPIHeaders marks responses from the unversioned /api routes as deprecated
+// Clients get Deprecation and Sunset headers, a Link to the same route under
+// APIVersionPrefix and a Warning they can surface in logs
+func LegacyAPIHeaders(sunset time.Time) gin.HandlerFunc {
+	sunsetHeader := sunset.UTC().Format(http.TimeFormat)
+	return func(c *gin.Context) {
+		successor := APIVersionPrefix + strings.TrimPrefix(c.Request.URL.Path, "/api")
+		c.Header("Deprecation", "true")
+		c.Header("Sunset", sunsetHeader)
+		c.Header("Link", "<"+successor+">; rel=\"successor-version\"")
+		c.Header("Warning", `299 - "Unversioned /api routes are deprecated; use `+APIVersionPrefix+`"`)
+		c.Next()
+	}
+}
+
+// unversionedPath maps /api/v1/... onto /api/... so path checks match both route trees
+func unversionedPath(path string) string {
+	if rest, ok := strings.CutPrefix(path, APIVersionPrefix+"/"); ok {
+		return "/api/" + rest
+	}
+	return path
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLegacyAPIHeaders_MarksDeprecatedRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	handler := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "ok"}) }
+	router.Group("/api", LegacyAPIHeaders(time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC))).GET("/vacation/requests/:id", handler)
+	router.Group(APIVersionPrefix).GET("/vacation/requests/:id", handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/vacation/requests/vac-1", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+	assert.Equal(t, "Wed, 30 Jun 2027 00:00:00 GMT", rec.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v1/vacation/requests/vac-1>; rel="successor-version"`, rec.Header().Get("Link"))
+	assert.Contains(t, rec.Header().Get("Warning"), "299")
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/vacation/requests/vac-1", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Deprecation"))
+	assert.Empty(t, rec.Header().Get("Sunset"))
+}
+
+func TestSecurityHeaders_CacheControl_VersionedLoginPath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(SecurityHeaders())
+	router.POST("/api/v1/auth/login", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Contains(t, rec.Header().Get("Cache-Control"), "no-store")
+}
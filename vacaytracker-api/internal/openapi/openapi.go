@@ -0,0 +1,371 @@
+// Package openapi builds an OpenAPI 3 description of the API from the Gin route table
+// Request and response shapes come from reflecting over the DTO structs, so the
+// spec follows their json and binding tags without separate annotations
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Endpoint describes what a handler reads and writes
+// Handlers are matched by name, e.g. "VacationHandler.Create"
+type Endpoint struct {
+	Summary     string
+	Query       []string // Query parameter names
+	Request     any      // JSON request body, nil when there is none
+	Multipart   bool     // Request is a multipart upload in the "file" field
+	Response    any      // JSON success body, nil when ContentType is set or the shape is ad hoc
+	ContentType string   // Success content type for non-JSON responses, e.g. text/calendar
+	Status      int      // Success status, defaults to 200
+	Public      bool     // Reachable without a bearer token
+}
+
+// Info describes the API as a whole
+type Info struct {
+	Title         string
+	Version       string
+	PathPrefix    string   // Only routes under this prefix are described, e.g. "/api/v1"
+	ErrorResponse any      // Body of every error response
+	ErrorCodes    []string // Listed as the enum of the error body's code field
+}
+
+// Build returns the OpenAPI document for every route under info.PathPrefix
+// Routes whose handler has no Endpoint entry are still listed, without body shapes
+func Build(info Info, routes gin.RoutesInfo, endpoints map[string]Endpoint) ([]byte, error) {
+	schemas := newSchemaRegistry()
+
+	errorRef := schemas.ref(reflect.TypeOf(info.ErrorResponse))
+	if errorSchema, ok := schemas.schemas[reflect.TypeOf(info.ErrorResponse).Name()]; ok && len(info.ErrorCodes) > 0 {
+		if props, ok := errorSchema["properties"].(map[string]any); ok {
+			if code, ok := props["code"].(map[string]any); ok {
+				code["enum"] = info.ErrorCodes
+			}
+		}
+	}
+
+	paths := make(map[string]map[string]any)
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, info.PathPrefix+"/") {
+			continue
+		}
+		name := handlerName(route.Handler)
+		endpoint := endpoints[name]
+
+		path, params := convertPath(route.Path)
+		if paths[path] == nil {
+			paths[path] = make(map[string]any)
+		}
+		tag := tagFor(strings.TrimPrefix(route.Path, info.PathPrefix+"/"))
+		paths[path][strings.ToLower(route.Method)] = operation(schemas, name, tag, params, endpoint, errorRef)
+	}
+
+	doc := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   info.Title,
+			"version": info.Version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas.schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+			},
+		},
+	}
+	return json.Marshal(doc)
+}
+
+// operation describes a single method on a path
+func operation(schemas *schemaRegistry, name, tag string, pathParams []string, endpoint Endpoint, errorRef map[string]any) map[string]any {
+	op := map[string]any{
+		"operationId": name,
+		"tags":        []string{tag},
+	}
+	if endpoint.Summary != "" {
+		op["summary"] = endpoint.Summary
+	}
+
+	var params []map[string]any
+	for _, p := range pathParams {
+		params = append(params, map[string]any{
+			"name": p, "in": "path", "required": true,
+			"schema": map[string]any{"type": "string"},
+		})
+	}
+	for _, q := range endpoint.Query {
+		params = append(params, map[string]any{
+			"name": q, "in": "query",
+			"schema": map[string]any{"type": "string"},
+		})
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+
+	switch {
+	case endpoint.Multipart:
+		op["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"multipart/form-data": map[string]any{
+					"schema": map[string]any{
+						"type":       "object",
+						"required":   []string{"file"},
+						"properties": map[string]any{"file": map[string]any{"type": "string", "format": "binary"}},
+					},
+				},
+			},
+		}
+	case endpoint.Request != nil:
+		op["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": schemas.ref(reflect.TypeOf(endpoint.Request))},
+			},
+		}
+	}
+
+	status := endpoint.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := map[string]any{"description": http.StatusText(status)}
+	switch {
+	case endpoint.ContentType != "":
+		success["content"] = map[string]any{endpoint.ContentType: map[string]any{"schema": map[string]any{"type": "string"}}}
+	case endpoint.Response != nil:
+		success["content"] = map[string]any{"application/json": map[string]any{"schema": schemas.ref(reflect.TypeOf(endpoint.Response))}}
+	}
+	op["responses"] = map[string]any{
+		strconv.Itoa(status): success,
+		"default": map[string]any{
+			"description": "Error",
+			"content":     map[string]any{"application/json": map[string]any{"schema": errorRef}},
+		},
+	}
+
+	if !endpoint.Public {
+		op["security"] = []map[string][]string{{"bearerAuth": {}}}
+	}
+	return op
+}
+
+// handlerName turns a Gin handler name such as
+// "vacaytracker-api/internal/handler.(*VacationHandler).Create-fm" into "VacationHandler.Create"
+func handlerName(full string) string {
+	name := full[strings.LastIndex(full, "/")+1:]
+	name = strings.TrimSuffix(name, "-fm")
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
+
+// convertPath rewrites Gin's :param segments into OpenAPI {param} segments
+func convertPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// tagFor groups operations by their first path segment below the prefix
+func tagFor(rest string) string {
+	if i := strings.IndexAny(rest, "/."); i >= 0 {
+		rest = rest[:i]
+	}
+	return rest
+}
+
+// schemaRegistry collects named struct schemas under components/schemas
+type schemaRegistry struct {
+	schemas map[string]map[string]any
+	types   map[string]reflect.Type
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]map[string]any),
+		types:   make(map[string]reflect.Type),
+	}
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+)
+
+// ref returns the schema for a type, registering named structs as components
+func (r *schemaRegistry) ref(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == rawMessageType:
+		// Embedded JSON document of any shape, not base64 bytes
+		return map[string]any{}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		name := r.componentName(t)
+		if _, ok := r.schemas[name]; !ok {
+			// Register before building so self-referencing types terminate
+			r.schemas[name] = map[string]any{}
+			for k, v := range r.object(t) {
+				r.schemas[name][k] = v
+			}
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	return r.inline(t)
+}
+
+// componentName is the type name, qualified by package when two packages share it
+func (r *schemaRegistry) componentName(t reflect.Type) string {
+	name := t.Name()
+	if existing, ok := r.types[name]; ok && existing != t {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = pkg + "." + name
+	}
+	r.types[name] = t
+	return name
+}
+
+// inline returns the schema for a non-struct type
+func (r *schemaRegistry) inline(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": r.ref(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": r.ref(t.Elem())}
+	case reflect.Struct:
+		return r.object(t)
+	default:
+		// interface{} and anything else accept any JSON value
+		return map[string]any{}
+	}
+}
+
+// object builds an object schema from a struct's json and binding tags
+func (r *schemaRegistry) object(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	r.collectFields(t, properties, &required)
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+// collectFields adds a struct's exported fields, flattening embedded structs like encoding/json
+func (r *schemaRegistry) collectFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(jsonTag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.collectFields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := r.ref(field.Type)
+		rules := strings.Split(field.Tag.Get("binding"), ",")
+		for _, rule := range rules {
+			if rule == "required" {
+				*required = append(*required, name)
+			}
+		}
+		if _, isRef := schema["$ref"]; !isRef {
+			applyBindingRules(schema, rules)
+		}
+		properties[name] = schema
+	}
+}
+
+// applyBindingRules maps the validator rules that matter to clients onto the schema
+// Rules after "dive" apply to slice elements and are skipped
+func applyBindingRules(schema map[string]any, rules []string) {
+	for _, rule := range rules {
+		if rule == "dive" {
+			return
+		}
+		key, value, ok := strings.Cut(rule, "=")
+		if !ok {
+			if key == "email" {
+				schema["format"] = "email"
+			}
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		switch key {
+		case "oneof":
+			if schema["type"] == "string" {
+				schema["enum"] = strings.Fields(value)
+			}
+		case "min", "max":
+			if err != nil {
+				continue
+			}
+			switch schema["type"] {
+			case "string":
+				schema[key+"Length"] = n
+			case "array":
+				schema[key+"Items"] = n
+			case "integer", "number":
+				schema[map[string]string{"min": "minimum", "max": "maximum"}[key]] = n
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type testCreateRequest struct {
+	Name   string   `json:"name" binding:"required,max=100"`
+	Role   string   `json:"role,omitempty" binding:"omitempty,oneof=admin employee"`
+	Days   *int     `json:"days,omitempty" binding:"omitempty,min=0,max=365"`
+	Tags   []string `json:"tags,omitempty" binding:"omitempty,max=5,dive,max=20"`
+	Hidden string   `json:"-"`
+}
+
+type testItem struct {
+	ID       string          `json:"id"`
+	Snapshot json.RawMessage `json:"snapshot,omitempty"`
+}
+
+type testListResponse struct {
+	Items []*testItem `json:"items"`
+	Total int         `json:"total"`
+}
+
+func buildTestDoc(t *testing.T) map[string]any {
+	t.Helper()
+	routes := gin.RoutesInfo{
+		{Method: "POST", Path: "/api/v1/items", Handler: "vacaytracker-api/internal/handler.(*ItemHandler).Create-fm"},
+		{Method: "GET", Path: "/api/v1/items/:id", Handler: "vacaytracker-api/internal/handler.(*ItemHandler).Get-fm"},
+		{Method: "POST", Path: "/api/v1/auth/login", Handler: "vacaytracker-api/internal/handler.(*AuthHandler).Login-fm"},
+		{Method: "GET", Path: "/api/auth/login", Handler: "vacaytracker-api/internal/handler.(*AuthHandler).Login-fm"},
+		{Method: "GET", Path: "/health", Handler: "vacaytracker-api/internal/handler.(*HealthHandler).Check-fm"},
+	}
+	endpoints := map[string]Endpoint{
+		"ItemHandler.Create": {Summary: "Create an item", Request: testCreateRequest{}, Response: testListResponse{}, Status: 201},
+		"AuthHandler.Login":  {Summary: "Log in", Public: true},
+	}
+
+	raw, err := Build(Info{Title: "Test", Version: "1.0.0", PathPrefix: "/api/v1", ErrorResponse: testError{}, ErrorCodes: []string{"NOT_FOUND"}}, routes, endpoints)
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	return doc
+}
+
+// get walks nested JSON objects by key
+func get(t *testing.T, v any, keys ...string) any {
+	t.Helper()
+	for _, key := range keys {
+		m, ok := v.(map[string]any)
+		require.True(t, ok, "expected an object at %q", key)
+		v, ok = m[key]
+		require.True(t, ok, "missing key %q", key)
+	}
+	return v
+}
+
+func TestBuild_PathsAndOperations(t *testing.T) {
+	doc := buildTestDoc(t)
+
+	assert.Equal(t, "3.0.3", doc["openapi"])
+	paths := get(t, doc, "paths").(map[string]any)
+	assert.NotContains(t, paths, "/health", "only routes under the prefix are documented")
+	assert.NotContains(t, paths, "/api/auth/login")
+
+	create := get(t, doc, "paths", "/api/v1/items", "post")
+	assert.Equal(t, "Create an item", get(t, create, "summary"))
+	assert.Equal(t, "ItemHandler.Create", get(t, create, "operationId"))
+	assert.Equal(t, []any{"items"}, get(t, create, "tags"))
+	assert.Equal(t, "#/components/schemas/testCreateRequest", get(t, create, "requestBody", "content", "application/json", "schema", "$ref"))
+	assert.Equal(t, "#/components/schemas/testListResponse", get(t, create, "responses", "201", "content", "application/json", "schema", "$ref"))
+	assert.Equal(t, "#/components/schemas/testError", get(t, create, "responses", "default", "content", "application/json", "schema", "$ref"))
+	assert.NotNil(t, get(t, create, "security"))
+
+	// Undocumented handlers are still listed, with their path parameters
+	getItem := get(t, doc, "paths", "/api/v1/items/{id}", "get")
+	params := get(t, getItem, "parameters").([]any)
+	require.Len(t, params, 1)
+	assert.Equal(t, "id", get(t, params[0], "name"))
+	assert.Equal(t, "path", get(t, params[0], "in"))
+
+	login := get(t, doc, "paths", "/api/v1/auth/login", "post").(map[string]any)
+	assert.NotContains(t, login, "security")
+}
+
+func TestBuild_SchemasFollowTags(t *testing.T) {
+	doc := buildTestDoc(t)
+	schemas := get(t, doc, "components", "schemas")
+
+	req := get(t, schemas, "testCreateRequest")
+	assert.Equal(t, []any{"name"}, get(t, req, "required"))
+	props := get(t, req, "properties").(map[string]any)
+	assert.NotContains(t, props, "Hidden")
+	assert.Equal(t, float64(100), get(t, props, "name", "maxLength"))
+	assert.Equal(t, []any{"admin", "employee"}, get(t, props, "role", "enum"))
+	assert.Equal(t, float64(365), get(t, props, "days", "maximum"))
+	assert.Equal(t, float64(5), get(t, props, "tags", "maxItems"))
+	assert.Equal(t, "string", get(t, props, "tags", "items", "type"))
+
+	list := get(t, schemas, "testListResponse")
+	assert.Equal(t, "#/components/schemas/testItem", get(t, list, "properties", "items", "items", "$ref"))
+	assert.Equal(t, "integer", get(t, list, "properties", "total", "type"))
+	assert.Empty(t, get(t, schemas, "testItem", "properties", "snapshot"), "raw JSON accepts any value")
+
+	assert.Equal(t, []any{"NOT_FOUND"}, get(t, schemas, "testError", "properties", "code", "enum"))
+}
+
+func TestHandlerName(t *testing.T) {
+	assert.Equal(t, "VacationHandler.Create", handlerName("vacaytracker-api/internal/handler.(*VacationHandler).Create-fm"))
+}
@@ -3,35 +3,66 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	"vacaytracker-api/internal/domain"
 )
 
+// ErrNotUnderReview is returned by status updates when the request is no longer awaiting a decision,
+// typically because a concurrent review got there first
+var ErrNotUnderReview = errors.New("vacation request is no longer under review")
+
+// ErrBalanceBelowMinimum is returned by relative balance updates that would take a balance below the allowed minimum
+var ErrBalanceBelowMinimum = errors.New("vacation balance would fall below the minimum")
+
+// ErrEmailTaken is returned when an email change collides with an address held by another account,
+// including soft-deleted ones
+var ErrEmailTaken = errors.New("email address is already in use")
+
 // Transactor provides database transaction support
 type Transactor interface {
 	Transaction(fn func(tx *sql.Tx) error) error
 }
 
+// HealthChecker reports whether the database is reachable
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
 // UserRepository defines user data access operations
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, id string) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
-	GetAll(ctx context.Context, role *domain.Role, search string, limit, offset int) ([]*domain.User, int, error)
+	GetAll(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, limit, offset int) ([]*domain.User, int, error)
+	GetAllAfter(ctx context.Context, filter domain.UserFilter, afterID string, limit int) ([]*domain.User, error)
 	GetByRole(ctx context.Context, role domain.Role) ([]*domain.User, error)
+	ListByManager(ctx context.Context, managerID string) ([]*domain.User, error)
+	ListByTeam(ctx context.Context, teamID string) ([]*domain.User, error)
 	CountByRole(ctx context.Context, role domain.Role) (int, error)
+	CountActive(ctx context.Context, teamID string) (int, error)
 	Update(ctx context.Context, user *domain.User) error
+	UpdateTx(ctx context.Context, tx *sql.Tx, user *domain.User) error
 	UpdatePassword(ctx context.Context, id, passwordHash string) error
 	UpdateEmailPreferences(ctx context.Context, id string, prefs domain.EmailPreferences) error
+	TouchLastLogin(ctx context.Context, id string) error
+	SetPendingEmail(ctx context.Context, id, email string) error
+	ConfirmPendingEmail(ctx context.Context, id, email string) error
 	UpdateVacationBalance(ctx context.Context, id string, balance int) error
 	UpdateVacationBalanceTx(ctx context.Context, tx *sql.Tx, id string, balance int) error
+	AddVacationBalanceTx(ctx context.Context, tx *sql.Tx, id string, delta, floor int) (int, error)
+	CreditVacationBalanceTx(ctx context.Context, tx *sql.Tx, id string, amount, ceiling int) (int, error)
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	SetActive(ctx context.Context, id string, active bool) error
 	EmailExists(ctx context.Context, email string) (bool, error)
 	EmailExistsExcluding(ctx context.Context, email, excludeID string) (bool, error)
 	GetNewsletterRecipients(ctx context.Context) ([]*domain.User, error)
 	GetLowBalanceUsers(ctx context.Context, threshold int) ([]*domain.User, error)
 	UpdateAllBalances(ctx context.Context, balance int) (int64, error)
+	UpdateAllBalancesTx(ctx context.Context, tx *sql.Tx, balance int) (int64, error)
+	ListBalances(ctx context.Context, year int, sort domain.UserSort) ([]*EmployeeBalance, error)
 }
 
 // VacationRepository defines vacation request data access operations
@@ -39,14 +70,31 @@ type VacationRepository interface {
 	Create(ctx context.Context, req *domain.VacationRequest) error
 	CreateTx(ctx context.Context, tx *sql.Tx, req *domain.VacationRequest) error
 	GetByID(ctx context.Context, id string) (*domain.VacationRequest, error)
-	ListByUser(ctx context.Context, userID string, status *domain.VacationStatus, year *int) ([]*domain.VacationRequest, error)
-	ListPending(ctx context.Context) ([]*domain.VacationRequest, error)
-	ListTeam(ctx context.Context, month, year int) ([]*domain.TeamVacation, error)
+	GetByIDs(ctx context.Context, ids []string) ([]*domain.VacationRequest, error)
+	ListByUser(ctx context.Context, userID string, status *domain.VacationStatus, year *int, from, to string) ([]*domain.VacationRequest, error)
+	ListPending(ctx context.Context, from, to string) ([]*domain.VacationRequest, error)
+	ListStalePending(ctx context.Context, createdBefore time.Time) ([]*domain.VacationRequest, error)
+	ListApprovedStartingOn(ctx context.Context, date string) ([]*domain.VacationRequest, error)
+	ListCreatedBetween(ctx context.Context, from, to string) ([]*domain.VacationRequest, error)
+	ListTeam(ctx context.Context, month, year int, teamID string) ([]*domain.TeamVacation, error)
+	ListTeamWithPending(ctx context.Context, month, year int, teamID string) ([]*domain.TeamVacation, error)
+	ListTeamRange(ctx context.Context, from, to, teamID string) ([]*domain.TeamVacation, error)
+	ListUpcomingApproved(ctx context.Context, from, to string) ([]*domain.TeamVacation, error)
 	UpdateStatus(ctx context.Context, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error
 	UpdateStatusTx(ctx context.Context, tx *sql.Tx, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error
+	AdvanceApprovalStep(ctx context.Context, id string, step int, status domain.VacationStatus, reviewedBy string, comment *string) error
+	UpdateApprovalCommentTx(ctx context.Context, tx *sql.Tx, id string, comment string) error
+	UpdateBalanceOverrideTx(ctx context.Context, tx *sql.Tx, id string, reason string) error
+	AdjustDatesTx(ctx context.Context, tx *sql.Tx, id, startDate, endDate string, totalDays int) error
+	PromoteTentativeTx(ctx context.Context, tx *sql.Tx, id string, status domain.VacationStatus, totalDays int) error
+	ClaimStartReminder(ctx context.Context, id string) (bool, error)
+	UpdateTotalDays(ctx context.Context, id string, totalDays int) error
 	Delete(ctx context.Context, id string) error
-	HasOverlap(ctx context.Context, userID, startDate, endDate string) (bool, error)
-	GetMonthlyStats(ctx context.Context, year, month int) (*MonthlyStats, error)
+	DeleteTx(ctx context.Context, tx *sql.Tx, id string) error
+	HasOverlap(ctx context.Context, userID, startDate, endDate string, allowTouching bool, types []domain.RequestType) (bool, error)
+	GetMonthlyStats(ctx context.Context, year, month int, teamID string) (*MonthlyStats, error)
+	GetYearlyStats(ctx context.Context, year int) (*YearlyStats, error)
+	GetUserYearStats(ctx context.Context, userID string, year int) (*UserYearStats, error)
 }
 
 // SettingsRepository defines settings data access operations
@@ -54,6 +102,61 @@ type SettingsRepository interface {
 	Get(ctx context.Context) (*domain.Settings, error)
 	Update(ctx context.Context, settings *domain.Settings) error
 	UpdateLastNewsletterSent(ctx context.Context, sentAt time.Time) error
+	UpdateLastPersonalDigestSent(ctx context.Context, sentAt time.Time) error
+	ClaimAccrualMonthTx(ctx context.Context, tx *sql.Tx, month string) (bool, error)
+}
+
+// LedgerRepository defines balance ledger data access operations
+type LedgerRepository interface {
+	Create(ctx context.Context, entry *domain.LedgerEntry) error
+	CreateTx(ctx context.Context, tx *sql.Tx, entry *domain.LedgerEntry) error
+	ListByUser(ctx context.Context, userID string) ([]*domain.LedgerEntry, error)
+	SumByUser(ctx context.Context) (map[string]int, error)
+}
+
+// RefreshTokenRepository defines refresh token data access operations
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *domain.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	Revoke(ctx context.Context, id string) (bool, error)
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
+
+// EmailOutboxRepository defines email delivery queue data access operations
+type EmailOutboxRepository interface {
+	Create(ctx context.Context, msg *domain.EmailMessage) error
+	UpdateDelivery(ctx context.Context, msg *domain.EmailMessage) error
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.EmailMessage, error)
+	ListRecent(ctx context.Context, limit int) ([]*domain.EmailMessage, error)
+}
+
+// TeamRepository defines team data access operations
+type TeamRepository interface {
+	Create(ctx context.Context, team *domain.Team) error
+	GetByID(ctx context.Context, id string) (*domain.Team, error)
+	List(ctx context.Context) ([]*domain.Team, error)
+	Update(ctx context.Context, team *domain.Team) error
+	Delete(ctx context.Context, id string) error
+	NameExistsExcluding(ctx context.Context, name, excludeID string) (bool, error)
+}
+
+// CommentRepository defines request comment data access operations
+type CommentRepository interface {
+	Create(ctx context.Context, comment *domain.RequestComment) error
+	ListByRequest(ctx context.Context, requestID string) ([]*domain.RequestComment, error)
+}
+
+// AttachmentRepository defines request attachment metadata operations
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *domain.Attachment) error
+	GetByID(ctx context.Context, id string) (*domain.Attachment, error)
+	ListByRequest(ctx context.Context, requestID string) ([]*domain.Attachment, error)
+}
+
+// AuditRepository defines audit log data access operations
+type AuditRepository interface {
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+	List(ctx context.Context, filter domain.AuditFilter, limit, offset int) ([]*domain.AuditEntry, int, error)
 }
 
 // MonthlyStats holds aggregated vacation request statistics for a specific month
@@ -64,3 +167,29 @@ type MonthlyStats struct {
 	TotalPending   int
 	TotalDaysUsed  int
 }
+
+// YearlyStats holds aggregated vacation request statistics for a year
+// The totals use the same definitions as MonthlyStats; Months breaks them down by month, January first
+type YearlyStats struct {
+	MonthlyStats
+	Months [12]MonthlyStats
+}
+
+// UserYearStats holds one user's vacation request statistics for requests starting in a given year
+type UserYearStats struct {
+	TotalRequested int
+	TotalApproved  int
+	TotalRejected  int
+	TotalPending   int
+	TotalDaysUsed  int
+	DaysByMonth    [12]int // Approved days by start month, January first
+}
+
+// EmployeeBalance holds an active employee's current balance and approved days for a year
+type EmployeeBalance struct {
+	UserID          string
+	Name            string
+	Email           string
+	VacationBalance int
+	DaysUsed        int // Approved days of requests starting in the year
+}
@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"vacaytracker-api/internal/domain"
+)
+
+// AttachmentRepository handles request attachment metadata operations
+type AttachmentRepository struct {
+	db *DB
+}
+
+// NewAttachmentRepository creates a new AttachmentRepository
+func NewAttachmentRepository(db *DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+// Create inserts attachment metadata, filling in the creation time and, if unset, the ID
+func (r *AttachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) error {
+	if attachment.ID == "" {
+		attachment.ID = uuid.New().String()
+	}
+	attachment.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	query := `
+		INSERT INTO request_attachments (id, request_id, uploaded_by, file_name, content_type, size_bytes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		attachment.ID,
+		attachment.RequestID,
+		attachment.UploadedBy,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.CreatedAt.Format("2006-01-02 15:04:05"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an attachment by ID, or nil if it does not exist
+func (r *AttachmentRepository) GetByID(ctx context.Context, id string) (*domain.Attachment, error) {
+	query := `
+		SELECT id, request_id, uploaded_by, file_name, content_type, size_bytes, created_at
+		FROM request_attachments
+		WHERE id = ?
+	`
+
+	var attachment domain.Attachment
+	var createdAt string
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&attachment.ID, &attachment.RequestID, &attachment.UploadedBy, &attachment.FileName,
+		&attachment.ContentType, &attachment.Size, &createdAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	attachment.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+	return &attachment, nil
+}
+
+// ListByRequest retrieves a request's attachments, oldest first
+func (r *AttachmentRepository) ListByRequest(ctx context.Context, requestID string) ([]*domain.Attachment, error) {
+	query := `
+		SELECT id, request_id, uploaded_by, file_name, content_type, size_bytes, created_at
+		FROM request_attachments
+		WHERE request_id = ?
+		ORDER BY created_at ASC, rowid ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := make([]*domain.Attachment, 0)
+	for rows.Next() {
+		var attachment domain.Attachment
+		var createdAt string
+		if err := rows.Scan(
+			&attachment.ID, &attachment.RequestID, &attachment.UploadedBy, &attachment.FileName,
+			&attachment.ContentType, &attachment.Size, &createdAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachment.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		attachments = append(attachments, &attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachments: %w", err)
+	}
+
+	return attachments, nil
+}
@@ -0,0 +1,63 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository/sqlite"
+	"vacaytracker-api/internal/testutil"
+)
+
+func TestAttachmentCreate_AndGetByID(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	attachmentRepo := sqlite.NewAttachmentRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice Smith", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "vac1", "user1", "2027-06-14", "2027-06-18", 5, domain.StatusPending)
+
+	attachment := &domain.Attachment{RequestID: "vac1", UploadedBy: "user1", FileName: "note.pdf", ContentType: "application/pdf", Size: 2048}
+	require.NoError(t, attachmentRepo.Create(ctx, attachment))
+	require.NotEmpty(t, attachment.ID)
+
+	got, err := attachmentRepo.GetByID(ctx, attachment.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "vac1", got.RequestID)
+	assert.Equal(t, "user1", got.UploadedBy)
+	assert.Equal(t, "note.pdf", got.FileName)
+	assert.Equal(t, "application/pdf", got.ContentType)
+	assert.Equal(t, int64(2048), got.Size)
+	assert.Equal(t, attachment.CreatedAt, got.CreatedAt)
+
+	missing, err := attachmentRepo.GetByID(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestAttachmentList_RemovedWithRequest(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	attachmentRepo := sqlite.NewAttachmentRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice Smith", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "vac1", "user1", "2027-06-14", "2027-06-18", 5, domain.StatusPending)
+	require.NoError(t, attachmentRepo.Create(ctx, &domain.Attachment{RequestID: "vac1", UploadedBy: "user1", FileName: "a.pdf", ContentType: "application/pdf", Size: 1}))
+	require.NoError(t, attachmentRepo.Create(ctx, &domain.Attachment{RequestID: "vac1", UploadedBy: "user1", FileName: "b.png", ContentType: "image/png", Size: 2}))
+
+	attachments, err := attachmentRepo.ListByRequest(ctx, "vac1")
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	assert.Equal(t, "a.pdf", attachments[0].FileName)
+	assert.Equal(t, "b.png", attachments[1].FileName)
+
+	require.NoError(t, vacRepo.Delete(ctx, "vac1"))
+
+	attachments, err = attachmentRepo.ListByRequest(ctx, "vac1")
+	require.NoError(t, err)
+	assert.Empty(t, attachments)
+}
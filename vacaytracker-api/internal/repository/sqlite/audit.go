@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"vacaytracker-api/internal/domain"
+)
+
+// AuditRepository handles audit log database operations
+type AuditRepository struct {
+	db *DB
+}
+
+// NewAuditRepository creates a new AuditRepository
+func NewAuditRepository(db *DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Create inserts an audit entry and fills in its ID and creation time
+func (r *AuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	query := `
+		INSERT INTO audit_log (id, actor_id, action, target_type, target_id, before_state, after_state, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		entry.ID,
+		entry.ActorID,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		nullableJSON(entry.Before),
+		nullableJSON(entry.After),
+		entry.CreatedAt.Format("2006-01-02 15:04:05"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+	return nil
+}
+
+// List retrieves audit entries matching the filter, newest first, with actor names and the total count
+func (r *AuditRepository) List(ctx context.Context, filter domain.AuditFilter, limit, offset int) ([]*domain.AuditEntry, int, error) {
+	baseQuery := "FROM audit_log a LEFT JOIN users u ON a.actor_id = u.id WHERE 1=1"
+	args := []interface{}{}
+
+	if filter.From != "" {
+		baseQuery += " AND a.created_at >= ?"
+		args = append(args, filter.From)
+	}
+	if filter.To != "" {
+		// To is inclusive, so compare against the start of the following day
+		baseQuery += " AND a.created_at < date(?, '+1 day')"
+		args = append(args, filter.To)
+	}
+	if filter.ActorID != "" {
+		baseQuery += " AND a.actor_id = ?"
+		args = append(args, filter.ActorID)
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) "+baseQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	selectQuery := `
+		SELECT a.id, a.actor_id, COALESCE(u.name, ''), a.action, a.target_type, a.target_id, a.before_state, a.after_state, a.created_at
+	` + baseQuery + " ORDER BY a.created_at DESC, a.rowid DESC LIMIT ? OFFSET ?"
+	args = append(args, limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, selectQuery, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.AuditEntry, 0)
+	for rows.Next() {
+		var entry domain.AuditEntry
+		var before, after sql.NullString
+		var createdAt string
+		if err := rows.Scan(&entry.ID, &entry.ActorID, &entry.ActorName, &entry.Action, &entry.TargetType, &entry.TargetID, &before, &after, &createdAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if before.Valid {
+			entry.Before = json.RawMessage(before.String)
+		}
+		if after.Valid {
+			entry.After = json.RawMessage(after.String)
+		}
+		entry.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating audit entries: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// nullableJSON stores an empty snapshot as NULL
+func nullableJSON(raw json.RawMessage) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
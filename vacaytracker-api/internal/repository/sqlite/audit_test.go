@@ -0,0 +1,102 @@
+package sqlite_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository/sqlite"
+	"vacaytracker-api/internal/testutil"
+)
+
+func TestAuditCreate_ListsNewestFirstWithActor(t *testing.T) {
+	db, userRepo, _ := setupRepos(t)
+	auditRepo := sqlite.NewAuditRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "admin1", "admin@test.com", "Alice Admin", domain.RoleAdmin, 25)
+
+	first := &domain.AuditEntry{
+		ActorID:    "admin1",
+		Action:     domain.AuditBalanceUpdate,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   "user1",
+		Before:     json.RawMessage(`{"vacationBalance":20}`),
+		After:      json.RawMessage(`{"vacationBalance":25}`),
+	}
+	require.NoError(t, auditRepo.Create(ctx, first))
+	assert.NotEmpty(t, first.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+	require.NoError(t, auditRepo.Create(ctx, &domain.AuditEntry{
+		ActorID:    "gone",
+		Action:     domain.AuditTeamDelete,
+		TargetType: domain.AuditTargetTeam,
+		TargetID:   "team1",
+	}))
+
+	entries, total, err := auditRepo.List(ctx, domain.AuditFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, entries, 2)
+
+	// Same-second entries fall back to insertion order, newest first
+	assert.Equal(t, domain.AuditTeamDelete, entries[0].Action)
+	assert.Empty(t, entries[0].ActorName, "an unknown actor is still listed")
+	assert.Nil(t, entries[0].Before)
+	assert.Nil(t, entries[0].After)
+
+	assert.Equal(t, first.ID, entries[1].ID)
+	assert.Equal(t, "Alice Admin", entries[1].ActorName)
+	assert.JSONEq(t, `{"vacationBalance":20}`, string(entries[1].Before))
+	assert.JSONEq(t, `{"vacationBalance":25}`, string(entries[1].After))
+	assert.Equal(t, first.CreatedAt, entries[1].CreatedAt)
+}
+
+func TestAuditList_FiltersAndPaginates(t *testing.T) {
+	db, _, _ := setupRepos(t)
+	auditRepo := sqlite.NewAuditRepository(db)
+	ctx := context.Background()
+
+	insert := func(id, actorID, createdAt string) {
+		_, err := db.Exec(`INSERT INTO audit_log (id, actor_id, action, target_type, created_at) VALUES (?, ?, 'settings.update', 'settings', ?)`,
+			id, actorID, createdAt)
+		require.NoError(t, err)
+	}
+	insert("a1", "admin1", "2027-03-01 09:00:00")
+	insert("a2", "admin2", "2027-03-02 23:59:59")
+	insert("a3", "admin1", "2027-03-03 00:00:00")
+	insert("a4", "admin1", "2027-03-05 12:00:00")
+
+	ids := func(entries []*domain.AuditEntry) []string {
+		out := make([]string, len(entries))
+		for i, e := range entries {
+			out[i] = e.ID
+		}
+		return out
+	}
+
+	// To is inclusive of the whole day
+	entries, total, err := auditRepo.List(ctx, domain.AuditFilter{From: "2027-03-02", To: "2027-03-03"}, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []string{"a3", "a2"}, ids(entries))
+
+	entries, total, err = auditRepo.List(ctx, domain.AuditFilter{ActorID: "admin1"}, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []string{"a4", "a3"}, ids(entries))
+
+	entries, _, err = auditRepo.List(ctx, domain.AuditFilter{ActorID: "admin1"}, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1"}, ids(entries))
+
+	entries, total, err = auditRepo.List(ctx, domain.AuditFilter{ActorID: "nobody"}, 10, 0)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	assert.NotNil(t, entries)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"vacaytracker-api/internal/domain"
+)
+
+// CommentRepository handles request comment database operations
+type CommentRepository struct {
+	db *DB
+}
+
+// NewCommentRepository creates a new CommentRepository
+func NewCommentRepository(db *DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Create inserts a new comment and fills in its ID and creation time
+func (r *CommentRepository) Create(ctx context.Context, comment *domain.RequestComment) error {
+	if comment.ID == "" {
+		comment.ID = uuid.New().String()
+	}
+	comment.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	query := `
+		INSERT INTO request_comments (id, request_id, author_id, body, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		comment.ID,
+		comment.RequestID,
+		comment.AuthorID,
+		comment.Body,
+		comment.CreatedAt.Format("2006-01-02 15:04:05"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+	return nil
+}
+
+// ListByRequest retrieves a request's comments, oldest first, with author names
+func (r *CommentRepository) ListByRequest(ctx context.Context, requestID string) ([]*domain.RequestComment, error) {
+	query := `
+		SELECT c.id, c.request_id, c.author_id, u.name, c.body, c.created_at
+		FROM request_comments c
+		JOIN users u ON c.author_id = u.id
+		WHERE c.request_id = ?
+		ORDER BY c.created_at ASC, c.rowid ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := make([]*domain.RequestComment, 0)
+	for rows.Next() {
+		var comment domain.RequestComment
+		var createdAt string
+		if err := rows.Scan(&comment.ID, &comment.RequestID, &comment.AuthorID, &comment.AuthorName, &comment.Body, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comment.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		comments = append(comments, &comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating comments: %w", err)
+	}
+
+	return comments, nil
+}
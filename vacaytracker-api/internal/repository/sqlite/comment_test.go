@@ -0,0 +1,59 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository/sqlite"
+	"vacaytracker-api/internal/testutil"
+)
+
+func TestCommentCreate_ListsOldestFirstWithAuthor(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	commentRepo := sqlite.NewCommentRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice Smith", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "admin1", "bob@test.com", "Bob Admin", domain.RoleAdmin, 25)
+	testutil.CreateTestVacation(t, vacRepo, "vac1", "user1", "2027-06-14", "2027-06-18", 5, domain.StatusPending)
+
+	first := &domain.RequestComment{RequestID: "vac1", AuthorID: "user1", Body: "Is this week fine?"}
+	require.NoError(t, commentRepo.Create(ctx, first))
+	assert.NotEmpty(t, first.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+	require.NoError(t, commentRepo.Create(ctx, &domain.RequestComment{RequestID: "vac1", AuthorID: "admin1", Body: "Looks good"}))
+
+	comments, err := commentRepo.ListByRequest(ctx, "vac1")
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "Is this week fine?", comments[0].Body)
+	assert.Equal(t, "Alice Smith", comments[0].AuthorName)
+	assert.Equal(t, "Looks good", comments[1].Body)
+	assert.Equal(t, "Bob Admin", comments[1].AuthorName)
+	assert.Equal(t, first.CreatedAt, comments[0].CreatedAt)
+
+	empty, err := commentRepo.ListByRequest(ctx, "missing")
+	require.NoError(t, err)
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+}
+
+func TestCommentList_RemovedWithRequest(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	commentRepo := sqlite.NewCommentRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice Smith", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "vac1", "user1", "2027-06-14", "2027-06-18", 5, domain.StatusPending)
+	require.NoError(t, commentRepo.Create(ctx, &domain.RequestComment{RequestID: "vac1", AuthorID: "user1", Body: "hello"}))
+
+	require.NoError(t, vacRepo.Delete(ctx, "vac1"))
+
+	comments, err := commentRepo.ListByRequest(ctx, "vac1")
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+}
@@ -0,0 +1,146 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"vacaytracker-api/internal/domain"
+)
+
+// EmailOutboxRepository handles email delivery queue database operations
+type EmailOutboxRepository struct {
+	db *DB
+}
+
+// NewEmailOutboxRepository creates a new EmailOutboxRepository
+func NewEmailOutboxRepository(db *DB) *EmailOutboxRepository {
+	return &EmailOutboxRepository{db: db}
+}
+
+const emailOutboxColumns = `id, recipient, subject, html_body, text_body, tags, idempotency_key, reply_to,
+	status, attempts, last_error, next_attempt_at, sent_at, created_at, updated_at`
+
+// Create stores a newly queued email
+func (r *EmailOutboxRepository) Create(ctx context.Context, msg *domain.EmailMessage) error {
+	query := `
+		INSERT INTO email_outbox (id, recipient, subject, html_body, text_body, tags, idempotency_key, reply_to,
+			status, attempts, last_error, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query, msg.ID, msg.Recipient, msg.Subject, msg.HTMLBody, msg.TextBody,
+		strings.Join(msg.Tags, ","), msg.IdempotencyKey, msg.ReplyTo, msg.Status, msg.Attempts, msg.LastError, formatNullableTime(msg.NextAttemptAt))
+	if err != nil {
+		return fmt.Errorf("failed to create queued email: %w", err)
+	}
+	return nil
+}
+
+// UpdateDelivery records the outcome of a delivery attempt
+func (r *EmailOutboxRepository) UpdateDelivery(ctx context.Context, msg *domain.EmailMessage) error {
+	query := `
+		UPDATE email_outbox
+		SET status = ?, attempts = ?, last_error = ?, next_attempt_at = ?, sent_at = ?, updated_at = datetime('now')
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, msg.Status, msg.Attempts, msg.LastError,
+		formatNullableTime(msg.NextAttemptAt), formatNullableTime(msg.SentAt), msg.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update queued email: %w", err)
+	}
+	return nil
+}
+
+// ListDue returns pending and retrying emails whose next attempt is due, oldest first
+func (r *EmailOutboxRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.EmailMessage, error) {
+	query := `
+		SELECT ` + emailOutboxColumns + `
+		FROM email_outbox
+		WHERE status IN (?, ?) AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, domain.EmailStatusPending, domain.EmailStatusRetrying,
+		now.UTC().Format("2006-01-02 15:04:05"), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due emails: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEmailMessages(rows)
+}
+
+// ListRecent returns the most recently queued emails, newest first
+func (r *EmailOutboxRepository) ListRecent(ctx context.Context, limit int) ([]*domain.EmailMessage, error) {
+	query := `
+		SELECT ` + emailOutboxColumns + `
+		FROM email_outbox
+		ORDER BY created_at DESC, rowid DESC
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent emails: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEmailMessages(rows)
+}
+
+// scanEmailMessages scans email outbox rows
+func scanEmailMessages(rows *sql.Rows) ([]*domain.EmailMessage, error) {
+	var messages []*domain.EmailMessage
+	for rows.Next() {
+		var msg domain.EmailMessage
+		var tags, status, createdAt, updatedAt string
+		var nextAttemptAt, sentAt sql.NullString
+
+		err := rows.Scan(
+			&msg.ID, &msg.Recipient, &msg.Subject, &msg.HTMLBody, &msg.TextBody, &tags, &msg.IdempotencyKey, &msg.ReplyTo,
+			&status, &msg.Attempts, &msg.LastError, &nextAttemptAt, &sentAt, &createdAt, &updatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan queued email: %w", err)
+		}
+
+		msg.Status = domain.EmailStatus(status)
+		msg.Tags = []string{}
+		if tags != "" {
+			msg.Tags = strings.Split(tags, ",")
+		}
+		msg.NextAttemptAt = parseNullableTime(nextAttemptAt)
+		msg.SentAt = parseNullableTime(sentAt)
+		msg.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		msg.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
+
+		messages = append(messages, &msg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating queued emails: %w", err)
+	}
+
+	return messages, nil
+}
+
+// formatNullableTime formats an optional timestamp for storage
+func formatNullableTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+// parseNullableTime parses an optional stored timestamp
+func parseNullableTime(s sql.NullString) *time.Time {
+	if !s.Valid {
+		return nil
+	}
+	t, err := time.Parse("2006-01-02 15:04:05", s.String)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,95 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository/sqlite"
+	"vacaytracker-api/internal/testutil"
+)
+
+func TestEmailOutbox_CreateAndListDue(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewEmailOutboxRepository(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	due := now.Add(-time.Minute)
+	later := now.Add(time.Hour)
+
+	require.NoError(t, repo.Create(ctx, &domain.EmailMessage{
+		ID: "due", Recipient: "a@test.com", Subject: "Due", HTMLBody: "<p>hi</p>", TextBody: "hi",
+		Tags: []string{"vacation", "approved"}, IdempotencyKey: "approved-r1", ReplyTo: "hr@test.com",
+		Status: domain.EmailStatusPending, NextAttemptAt: &due,
+	}))
+	require.NoError(t, repo.Create(ctx, &domain.EmailMessage{
+		ID: "later", Recipient: "b@test.com", Subject: "Later", Status: domain.EmailStatusRetrying, NextAttemptAt: &later,
+	}))
+
+	list, err := repo.ListDue(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "due", list[0].ID)
+	assert.Equal(t, "<p>hi</p>", list[0].HTMLBody)
+	assert.Equal(t, []string{"vacation", "approved"}, list[0].Tags)
+	assert.Equal(t, "approved-r1", list[0].IdempotencyKey)
+	assert.Equal(t, "hr@test.com", list[0].ReplyTo)
+	require.NotNil(t, list[0].NextAttemptAt)
+	assert.True(t, list[0].NextAttemptAt.Equal(due))
+}
+
+func TestEmailOutbox_UpdateDelivery(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewEmailOutboxRepository(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	msg := &domain.EmailMessage{
+		ID: "m1", Recipient: "a@test.com", Subject: "Hello", Status: domain.EmailStatusPending, NextAttemptAt: &now,
+	}
+	require.NoError(t, repo.Create(ctx, msg))
+
+	msg.Status = domain.EmailStatusSent
+	msg.Attempts = 2
+	msg.NextAttemptAt = nil
+	msg.SentAt = &now
+	require.NoError(t, repo.UpdateDelivery(ctx, msg))
+
+	// Sent emails are no longer due
+	list, err := repo.ListDue(ctx, now.Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	recent, err := repo.ListRecent(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, recent, 1)
+	assert.Equal(t, domain.EmailStatusSent, recent[0].Status)
+	assert.Equal(t, 2, recent[0].Attempts)
+	assert.Nil(t, recent[0].NextAttemptAt)
+	require.NotNil(t, recent[0].SentAt)
+	assert.True(t, recent[0].SentAt.Equal(now))
+	assert.Equal(t, []string{}, recent[0].Tags)
+}
+
+func TestEmailOutbox_ListRecentNewestFirst(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewEmailOutboxRepository(db)
+	ctx := context.Background()
+
+	for _, id := range []string{"first", "second", "third"} {
+		require.NoError(t, repo.Create(ctx, &domain.EmailMessage{
+			ID: id, Recipient: "a@test.com", Subject: id, Status: domain.EmailStatusPending,
+		}))
+	}
+
+	recent, err := repo.ListRecent(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, recent, 2)
+	assert.Equal(t, "third", recent[0].ID)
+	assert.Equal(t, "second", recent[1].ID)
+}
@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"vacaytracker-api/internal/domain"
+)
+
+// LedgerRepository handles balance ledger database operations
+type LedgerRepository struct {
+	db *DB
+}
+
+// NewLedgerRepository creates a new LedgerRepository
+func NewLedgerRepository(db *DB) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// Create records a new ledger entry
+func (r *LedgerRepository) Create(ctx context.Context, entry *domain.LedgerEntry) error {
+	query := `
+		INSERT INTO balance_ledger (id, user_id, delta, reason, reference_id, note)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query, entry.ID, entry.UserID, entry.Delta, entry.Reason, entry.ReferenceID, entry.Note)
+	if err != nil {
+		return fmt.Errorf("failed to create ledger entry: %w", err)
+	}
+	return nil
+}
+
+// CreateTx records a new ledger entry within a transaction
+func (r *LedgerRepository) CreateTx(ctx context.Context, tx *sql.Tx, entry *domain.LedgerEntry) error {
+	query := `
+		INSERT INTO balance_ledger (id, user_id, delta, reason, reference_id, note)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	_, err := tx.ExecContext(ctx, query, entry.ID, entry.UserID, entry.Delta, entry.Reason, entry.ReferenceID, entry.Note)
+	if err != nil {
+		return fmt.Errorf("failed to create ledger entry: %w", err)
+	}
+	return nil
+}
+
+// ListByUser retrieves a user's ledger entries, oldest first
+func (r *LedgerRepository) ListByUser(ctx context.Context, userID string) ([]*domain.LedgerEntry, error) {
+	query := `
+		SELECT id, user_id, delta, reason, reference_id, note, created_at
+		FROM balance_ledger
+		WHERE user_id = ?
+		ORDER BY created_at ASC, rowid ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.LedgerEntry
+	for rows.Next() {
+		var entry domain.LedgerEntry
+		var referenceID, note sql.NullString
+		var createdAt string
+
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.Delta, &entry.Reason, &referenceID, &note, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan ledger entry: %w", err)
+		}
+		if referenceID.Valid {
+			entry.ReferenceID = &referenceID.String
+		}
+		if note.Valid {
+			entry.Note = &note.String
+		}
+		entry.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ledger entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// SumByUser returns the ledger-derived balance for every user with ledger entries
+func (r *LedgerRepository) SumByUser(ctx context.Context) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT user_id, SUM(delta) FROM balance_ledger GROUP BY user_id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	sums := make(map[string]int)
+	for rows.Next() {
+		var userID string
+		var sum int
+		if err := rows.Scan(&userID, &sum); err != nil {
+			return nil, fmt.Errorf("failed to scan ledger sum: %w", err)
+		}
+		sums[userID] = sum
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ledger sums: %w", err)
+	}
+
+	return sums, nil
+}
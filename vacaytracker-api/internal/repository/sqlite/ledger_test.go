@@ -0,0 +1,134 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository/sqlite"
+	"vacaytracker-api/internal/testutil"
+)
+
+// ---------------------------------------------------------------------------
+// 1. Opening entries
+// ---------------------------------------------------------------------------
+
+func TestLedger_UserCreateRecordsOpeningEntry(t *testing.T) {
+	db, userRepo, _ := setupRepos(t)
+	ledgerRepo := sqlite.NewLedgerRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice", domain.RoleEmployee, 25)
+
+	entries, err := ledgerRepo.ListByUser(ctx, "user1")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 25, entries[0].Delta)
+	assert.Equal(t, domain.LedgerOpening, entries[0].Reason)
+	assert.Nil(t, entries[0].ReferenceID)
+}
+
+// ---------------------------------------------------------------------------
+// 2. SumByUser
+// ---------------------------------------------------------------------------
+
+func TestLedger_SumByUser(t *testing.T) {
+	db, userRepo, _ := setupRepos(t)
+	ledgerRepo := sqlite.NewLedgerRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "user2", "bob@test.com", "Bob", domain.RoleEmployee, 20)
+
+	ref := "vac1"
+	require.NoError(t, ledgerRepo.Create(ctx, &domain.LedgerEntry{
+		ID: "l1", UserID: "user1", Delta: -5, Reason: domain.LedgerVacation, ReferenceID: &ref,
+	}))
+	require.NoError(t, ledgerRepo.Create(ctx, &domain.LedgerEntry{
+		ID: "l2", UserID: "user1", Delta: 2, Reason: domain.LedgerAdjustment,
+	}))
+
+	sums, err := ledgerRepo.SumByUser(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 22, sums["user1"])
+	assert.Equal(t, 20, sums["user2"])
+
+	entries, err := ledgerRepo.ListByUser(ctx, "user1")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, domain.LedgerVacation, entries[1].Reason)
+	require.NotNil(t, entries[1].ReferenceID)
+	assert.Equal(t, "vac1", *entries[1].ReferenceID)
+}
+
+func TestLedger_DirectBalanceUpdateDrifts(t *testing.T) {
+	db, userRepo, _ := setupRepos(t)
+	ledgerRepo := sqlite.NewLedgerRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice", domain.RoleEmployee, 25)
+
+	// Repository writes bypass the ledger; reconciliation is what catches this
+	require.NoError(t, userRepo.UpdateVacationBalance(ctx, "user1", 30))
+
+	sums, err := ledgerRepo.SumByUser(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 25, sums["user1"])
+}
+
+func TestLedger_InvalidReasonRejected(t *testing.T) {
+	db, userRepo, _ := setupRepos(t)
+	ledgerRepo := sqlite.NewLedgerRepository(db)
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice", domain.RoleEmployee, 25)
+
+	err := ledgerRepo.Create(context.Background(), &domain.LedgerEntry{
+		ID: "l1", UserID: "user1", Delta: 1, Reason: domain.LedgerReason("bogus"),
+	})
+	assert.Error(t, err)
+}
+
+func TestLedger_AccrualReasonAccepted(t *testing.T) {
+	db, userRepo, _ := setupRepos(t)
+	ledgerRepo := sqlite.NewLedgerRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice", domain.RoleEmployee, 20)
+
+	require.NoError(t, ledgerRepo.Create(ctx, &domain.LedgerEntry{
+		ID: "l1", UserID: "user1", Delta: 2, Reason: domain.LedgerAccrual,
+	}))
+
+	entries, err := ledgerRepo.ListByUser(ctx, "user1")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, domain.LedgerOpening, entries[0].Reason)
+	assert.Equal(t, domain.LedgerAccrual, entries[1].Reason)
+}
+
+func TestLedger_NoteRoundTrip(t *testing.T) {
+	db, userRepo, _ := setupRepos(t)
+	ledgerRepo := sqlite.NewLedgerRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice", domain.RoleEmployee, 25)
+
+	note := "Crunch bonus"
+	err := db.Transaction(func(tx *sql.Tx) error {
+		return ledgerRepo.CreateTx(ctx, tx, &domain.LedgerEntry{
+			ID: "l1", UserID: "user1", Delta: 2, Reason: domain.LedgerAdjustment, Note: &note,
+		})
+	})
+	require.NoError(t, err)
+
+	entries, err := ledgerRepo.ListByUser(ctx, "user1")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Nil(t, entries[0].Note)
+	require.NotNil(t, entries[1].Note)
+	assert.Equal(t, "Crunch bonus", *entries[1].Note)
+}
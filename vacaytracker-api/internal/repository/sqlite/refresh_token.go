@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"vacaytracker-api/internal/domain"
+)
+
+// RefreshTokenRepository handles refresh token database operations
+type RefreshTokenRepository struct {
+	db *DB
+}
+
+// NewRefreshTokenRepository creates a new RefreshTokenRepository
+func NewRefreshTokenRepository(db *DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+// Create stores a newly issued refresh token
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, token_hash, expires_at)
+		VALUES (?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query, token.ID, token.UserID, token.TokenHash,
+		token.ExpiresAt.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetByHash retrieves a refresh token by the hash of its value
+func (r *RefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = ?
+	`
+
+	var token domain.RefreshToken
+	var expiresAt, createdAt string
+	var revokedAt sql.NullString
+
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID, &token.UserID, &token.TokenHash, &expiresAt, &revokedAt, &createdAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	token.ExpiresAt, _ = time.Parse("2006-01-02 15:04:05", expiresAt)
+	token.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+	if revokedAt.Valid {
+		t, _ := time.Parse("2006-01-02 15:04:05", revokedAt.String)
+		token.RevokedAt = &t
+	}
+
+	return &token, nil
+}
+
+// Revoke marks a refresh token as revoked
+// Returns false if the token was already revoked, so concurrent refreshes can't both rotate it
+func (r *RefreshTokenRepository) Revoke(ctx context.Context, id string) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE refresh_tokens SET revoked_at = datetime('now') WHERE id = ? AND revoked_at IS NULL", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RevokeAllForUser revokes every active refresh token belonging to a user
+func (r *RefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE refresh_tokens SET revoked_at = datetime('now') WHERE user_id = ? AND revoked_at IS NULL", userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository/sqlite"
+	"vacaytracker-api/internal/testutil"
+)
+
+func TestRefreshToken_CreateGetRevoke(t *testing.T) {
+	db, userRepo, _ := setupRepos(t)
+	repo := sqlite.NewRefreshTokenRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice", domain.RoleEmployee, 25)
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	require.NoError(t, repo.Create(ctx, &domain.RefreshToken{
+		ID: "rt1", UserID: "user1", TokenHash: "hash1", ExpiresAt: expiresAt,
+	}))
+
+	got, err := repo.GetByHash(ctx, "hash1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "user1", got.UserID)
+	assert.True(t, got.ExpiresAt.Equal(expiresAt))
+	assert.False(t, got.IsRevoked())
+
+	revoked, err := repo.Revoke(ctx, "rt1")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// A second revoke loses the race
+	revoked, err = repo.Revoke(ctx, "rt1")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	got, err = repo.GetByHash(ctx, "hash1")
+	require.NoError(t, err)
+	assert.True(t, got.IsRevoked())
+
+	missing, err := repo.GetByHash(ctx, "nope")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestRefreshToken_RevokeAllForUser(t *testing.T) {
+	db, userRepo, _ := setupRepos(t)
+	repo := sqlite.NewRefreshTokenRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "user2", "bob@test.com", "Bob", domain.RoleEmployee, 25)
+
+	expiresAt := time.Now().Add(time.Hour)
+	require.NoError(t, repo.Create(ctx, &domain.RefreshToken{ID: "rt1", UserID: "user1", TokenHash: "h1", ExpiresAt: expiresAt}))
+	require.NoError(t, repo.Create(ctx, &domain.RefreshToken{ID: "rt2", UserID: "user1", TokenHash: "h2", ExpiresAt: expiresAt}))
+	require.NoError(t, repo.Create(ctx, &domain.RefreshToken{ID: "rt3", UserID: "user2", TokenHash: "h3", ExpiresAt: expiresAt}))
+
+	require.NoError(t, repo.RevokeAllForUser(ctx, "user1"))
+
+	for hash, wantRevoked := range map[string]bool{"h1": true, "h2": true, "h3": false} {
+		got, err := repo.GetByHash(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, wantRevoked, got.IsRevoked(), hash)
+	}
+}
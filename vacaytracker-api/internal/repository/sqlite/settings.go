@@ -22,21 +22,50 @@ func NewSettingsRepository(db *DB) *SettingsRepository {
 // Get retrieves the application settings
 func (r *SettingsRepository) Get(ctx context.Context) (*domain.Settings, error) {
 	query := `
-		SELECT id, weekend_policy, newsletter, default_vacation_days, vacation_reset_month, updated_at
+		SELECT id, weekend_policy, newsletter, digest_sections, default_vacation_days, vacation_reset_month, approval_levels, min_notice_days, approval_comment_required, max_consecutive_days, blackout_periods, long_vacation_days, cool_off_days, accrual_enabled, accrual_days_per_month, last_accrual_month, allow_negative_balance, max_overdraw_days, webhook_url, min_coverage, overlap_policy, overlap_allow_touching, rejection_reasons, default_new_user_role, balance_unit, hours_per_day, low_balance_threshold, rounding_mode, max_requests_per_year, count_rejected_requests, timezone, max_future_days, remote_blocks_leave, updated_at
 		FROM settings
 		WHERE id = 'settings'
 	`
 
 	var settings domain.Settings
-	var weekendPolicyJSON, newsletterJSON string
+	var weekendPolicyJSON, newsletterJSON, digestSectionsJSON, approvalLevelsJSON, blackoutPeriodsJSON, rejectionReasonsJSON string
+	var lastAccrualMonth sql.NullString
 	var updatedAt string
 
 	err := r.db.QueryRowContext(ctx, query).Scan(
 		&settings.ID,
 		&weekendPolicyJSON,
 		&newsletterJSON,
+		&digestSectionsJSON,
 		&settings.DefaultVacationDays,
 		&settings.VacationResetMonth,
+		&approvalLevelsJSON,
+		&settings.MinNoticeDays,
+		&settings.ApprovalCommentRequired,
+		&settings.MaxConsecutiveDays,
+		&blackoutPeriodsJSON,
+		&settings.LongVacationDays,
+		&settings.CoolOffDays,
+		&settings.AccrualEnabled,
+		&settings.AccrualDaysPerMonth,
+		&lastAccrualMonth,
+		&settings.AllowNegativeBalance,
+		&settings.MaxOverdrawDays,
+		&settings.WebhookURL,
+		&settings.MinCoverage,
+		&settings.OverlapPolicy,
+		&settings.OverlapAllowTouching,
+		&rejectionReasonsJSON,
+		&settings.DefaultNewUserRole,
+		&settings.BalanceUnit,
+		&settings.HoursPerDay,
+		&settings.LowBalanceThreshold,
+		&settings.RoundingMode,
+		&settings.MaxRequestsPerYear,
+		&settings.CountRejectedRequests,
+		&settings.Timezone,
+		&settings.MaxFutureDays,
+		&settings.RemoteBlocksLeave,
 		&updatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -50,6 +79,11 @@ func (r *SettingsRepository) Get(ctx context.Context) (*domain.Settings, error)
 
 	settings.WeekendPolicy, _ = domain.ParseWeekendPolicy(weekendPolicyJSON)
 	settings.Newsletter, _ = domain.ParseNewsletterConfig(newsletterJSON)
+	settings.DigestSections, _ = domain.ParseDigestSections(digestSectionsJSON)
+	settings.ApprovalLevels, _ = domain.ParseApprovalLevels(approvalLevelsJSON)
+	settings.BlackoutPeriods, _ = domain.ParseBlackoutPeriods(blackoutPeriodsJSON)
+	settings.RejectionReasons, _ = domain.ParseRejectionReasons(rejectionReasonsJSON)
+	settings.LastAccrualMonth = lastAccrualMonth.String
 	settings.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
 
 	return &settings, nil
@@ -67,21 +101,95 @@ func (r *SettingsRepository) Update(ctx context.Context, settings *domain.Settin
 		return fmt.Errorf("failed to serialize newsletter config: %w", err)
 	}
 
+	digestSectionsJSON, err := settings.DigestSections.ToJSONString()
+	if err != nil {
+		return fmt.Errorf("failed to serialize digest sections: %w", err)
+	}
+
+	approvalLevelsJSON, err := domain.ApprovalLevelsToJSONString(settings.ApprovalLevels)
+	if err != nil {
+		return fmt.Errorf("failed to serialize approval levels: %w", err)
+	}
+
+	blackoutPeriodsJSON, err := domain.BlackoutPeriodsToJSONString(settings.BlackoutPeriods)
+	if err != nil {
+		return fmt.Errorf("failed to serialize blackout periods: %w", err)
+	}
+
+	rejectionReasonsJSON, err := domain.RejectionReasonsToJSONString(settings.RejectionReasons)
+	if err != nil {
+		return fmt.Errorf("failed to serialize rejection reasons: %w", err)
+	}
+
 	query := `
-		INSERT INTO settings (id, weekend_policy, newsletter, default_vacation_days, vacation_reset_month)
-		VALUES ('settings', ?, ?, ?, ?)
+		INSERT INTO settings (id, weekend_policy, newsletter, digest_sections, default_vacation_days, vacation_reset_month, approval_levels, min_notice_days, approval_comment_required, max_consecutive_days, blackout_periods, long_vacation_days, cool_off_days, accrual_enabled, accrual_days_per_month, allow_negative_balance, max_overdraw_days, webhook_url, min_coverage, overlap_policy, overlap_allow_touching, rejection_reasons, default_new_user_role, balance_unit, hours_per_day, low_balance_threshold, rounding_mode, max_requests_per_year, count_rejected_requests, timezone, max_future_days, remote_blocks_leave)
+		VALUES ('settings', ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			weekend_policy = excluded.weekend_policy,
 			newsletter = excluded.newsletter,
+			digest_sections = excluded.digest_sections,
 			default_vacation_days = excluded.default_vacation_days,
-			vacation_reset_month = excluded.vacation_reset_month
+			vacation_reset_month = excluded.vacation_reset_month,
+			approval_levels = excluded.approval_levels,
+			min_notice_days = excluded.min_notice_days,
+			approval_comment_required = excluded.approval_comment_required,
+			max_consecutive_days = excluded.max_consecutive_days,
+			blackout_periods = excluded.blackout_periods,
+			long_vacation_days = excluded.long_vacation_days,
+			cool_off_days = excluded.cool_off_days,
+			accrual_enabled = excluded.accrual_enabled,
+			accrual_days_per_month = excluded.accrual_days_per_month,
+			allow_negative_balance = excluded.allow_negative_balance,
+			max_overdraw_days = excluded.max_overdraw_days,
+			webhook_url = excluded.webhook_url,
+			min_coverage = excluded.min_coverage,
+			overlap_policy = excluded.overlap_policy,
+			overlap_allow_touching = excluded.overlap_allow_touching,
+			rejection_reasons = excluded.rejection_reasons,
+			default_new_user_role = excluded.default_new_user_role,
+			balance_unit = excluded.balance_unit,
+			hours_per_day = excluded.hours_per_day,
+			low_balance_threshold = excluded.low_balance_threshold,
+			rounding_mode = excluded.rounding_mode,
+			max_requests_per_year = excluded.max_requests_per_year,
+			count_rejected_requests = excluded.count_rejected_requests,
+			timezone = excluded.timezone,
+			max_future_days = excluded.max_future_days,
+			remote_blocks_leave = excluded.remote_blocks_leave
 	`
 
 	_, err = r.db.ExecContext(ctx, query,
 		weekendPolicyJSON,
 		newsletterJSON,
+		digestSectionsJSON,
 		settings.DefaultVacationDays,
 		settings.VacationResetMonth,
+		approvalLevelsJSON,
+		settings.MinNoticeDays,
+		settings.ApprovalCommentRequired,
+		settings.MaxConsecutiveDays,
+		blackoutPeriodsJSON,
+		settings.LongVacationDays,
+		settings.CoolOffDays,
+		settings.AccrualEnabled,
+		settings.AccrualDaysPerMonth,
+		settings.AllowNegativeBalance,
+		settings.MaxOverdrawDays,
+		settings.WebhookURL,
+		settings.MinCoverage,
+		settings.OverlapPolicy,
+		settings.OverlapAllowTouching,
+		rejectionReasonsJSON,
+		settings.DefaultNewUserRole,
+		settings.BalanceUnit,
+		settings.HoursPerDay,
+		settings.LowBalanceThreshold,
+		settings.RoundingMode,
+		settings.MaxRequestsPerYear,
+		settings.CountRejectedRequests,
+		settings.Timezone,
+		settings.MaxFutureDays,
+		settings.RemoteBlocksLeave,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update settings: %w", err)
@@ -103,3 +211,33 @@ func (r *SettingsRepository) UpdateLastNewsletterSent(ctx context.Context, sentA
 	// Save using the existing Update method
 	return r.Update(ctx, settings)
 }
+
+// UpdateLastPersonalDigestSent updates only the newsletter lastPersonalDigestAt timestamp
+func (r *SettingsRepository) UpdateLastPersonalDigestSent(ctx context.Context, sentAt time.Time) error {
+	settings, err := r.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get settings for personal digest update: %w", err)
+	}
+
+	settings.Newsletter.LastPersonalDigestAt = &sentAt
+	return r.Update(ctx, settings)
+}
+
+// ClaimAccrualMonthTx marks month (YYYY-MM) as accrued within a transaction
+// Returns false if that month or a later one was already claimed, so each month is credited once
+func (r *SettingsRepository) ClaimAccrualMonthTx(ctx context.Context, tx *sql.Tx, month string) (bool, error) {
+	query := `
+		UPDATE settings SET last_accrual_month = ?
+		WHERE id = 'settings' AND (last_accrual_month IS NULL OR last_accrual_month < ?)
+	`
+	result, err := tx.ExecContext(ctx, query, month, month)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim accrual month: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
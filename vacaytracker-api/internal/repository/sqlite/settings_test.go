@@ -2,6 +2,7 @@ package sqlite_test
 
 import (
 	"context"
+	"database/sql"
 	"path/filepath"
 	"runtime"
 	"testing"
@@ -206,6 +207,399 @@ func TestSettingsUpdate_VacationResetMonth(t *testing.T) {
 	assert.Equal(t, 6, got.VacationResetMonth)
 }
 
+func TestSettingsUpdate_ApprovalLevels(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, settings.ApprovalLevels)
+
+	leadID := "lead-1"
+	settings.ApprovalLevels = []domain.ApprovalStep{
+		{Name: "Team Lead", ApproverID: &leadID},
+		{Name: "Admin"},
+	}
+
+	err = repo.Update(ctx, settings)
+	require.NoError(t, err)
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, got.ApprovalLevels, 2)
+	assert.Equal(t, "Team Lead", got.ApprovalLevels[0].Name)
+	require.NotNil(t, got.ApprovalLevels[0].ApproverID)
+	assert.Equal(t, "lead-1", *got.ApprovalLevels[0].ApproverID)
+	assert.Nil(t, got.ApprovalLevels[1].ApproverID)
+}
+
+func TestSettingsUpdate_MinNoticeDays(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, settings.MinNoticeDays)
+
+	settings.MinNoticeDays = 7
+	err = repo.Update(ctx, settings)
+	require.NoError(t, err)
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, got.MinNoticeDays)
+}
+
+func TestSettingsUpdate_MaxConsecutiveDays(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, settings.MaxConsecutiveDays)
+
+	settings.MaxConsecutiveDays = 15
+	err = repo.Update(ctx, settings)
+	require.NoError(t, err)
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 15, got.MaxConsecutiveDays)
+}
+
+func TestSettingsUpdate_BlackoutPeriods(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, settings.BlackoutPeriods)
+
+	settings.BlackoutPeriods = []domain.BlackoutPeriod{
+		{ID: "bo-1", StartDate: "2027-06-24", EndDate: "2027-06-30", Reason: "Quarter close"},
+	}
+	err = repo.Update(ctx, settings)
+	require.NoError(t, err)
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, got.BlackoutPeriods, 1)
+	assert.Equal(t, "bo-1", got.BlackoutPeriods[0].ID)
+	assert.Equal(t, "Quarter close", got.BlackoutPeriods[0].Reason)
+}
+
+func TestSettingsUpdate_Accrual(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.False(t, settings.AccrualEnabled)
+	assert.Equal(t, 2, settings.AccrualDaysPerMonth)
+	assert.Empty(t, settings.LastAccrualMonth)
+
+	settings.AccrualEnabled = true
+	settings.AccrualDaysPerMonth = 3
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.True(t, got.AccrualEnabled)
+	assert.Equal(t, 3, got.AccrualDaysPerMonth)
+}
+
+func TestSettingsUpdate_NegativeBalancePolicy(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.False(t, settings.AllowNegativeBalance)
+	assert.Equal(t, 0, settings.MaxOverdrawDays)
+
+	settings.AllowNegativeBalance = true
+	settings.MaxOverdrawDays = 5
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.True(t, got.AllowNegativeBalance)
+	assert.Equal(t, 5, got.MaxOverdrawDays)
+}
+
+func TestSettingsUpdate_WebhookURL(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, settings.WebhookURL)
+
+	settings.WebhookURL = "https://hooks.example.com/vacay"
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example.com/vacay", got.WebhookURL)
+}
+
+func TestSettingsUpdate_MinCoverage(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, settings.MinCoverage)
+
+	settings.MinCoverage = 3
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, got.MinCoverage)
+}
+
+func TestSettingsUpdate_DefaultNewUserRole(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleEmployee, settings.DefaultNewUserRole)
+
+	settings.DefaultNewUserRole = domain.Role("contractor")
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Role("contractor"), got.DefaultNewUserRole)
+}
+
+func TestSettingsUpdate_BalanceUnit(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BalanceUnitDays, settings.BalanceUnit)
+	assert.Equal(t, 8, settings.HoursPerDay)
+
+	settings.BalanceUnit = domain.BalanceUnitHours
+	settings.HoursPerDay = 7
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BalanceUnitHours, got.BalanceUnit)
+	assert.Equal(t, 7, got.HoursPerDay)
+}
+
+func TestSettingsUpdate_LowBalanceThreshold(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, settings.LowBalanceThreshold)
+
+	settings.LowBalanceThreshold = 0
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.LowBalanceThreshold)
+}
+
+func TestSettingsUpdate_RoundingMode(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoundingNone, settings.RoundingMode)
+
+	settings.RoundingMode = domain.RoundingCeil
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoundingCeil, got.RoundingMode)
+}
+
+func TestSettingsUpdate_MaxRequestsPerYear(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, settings.MaxRequestsPerYear)
+	assert.False(t, settings.CountRejectedRequests)
+
+	settings.MaxRequestsPerYear = 4
+	settings.CountRejectedRequests = true
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, got.MaxRequestsPerYear)
+	assert.True(t, got.CountRejectedRequests)
+}
+
+func TestSettingsUpdate_Timezone(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", settings.Timezone)
+
+	settings.Timezone = "Australia/Sydney"
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Australia/Sydney", got.Timezone)
+}
+
+func TestSettingsUpdate_MaxFutureDays(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, settings.MaxFutureDays)
+
+	settings.MaxFutureDays = 365
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 365, got.MaxFutureDays)
+}
+
+func TestSettingsUpdate_RemoteBlocksLeave(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.True(t, settings.RemoteBlocksLeave)
+
+	settings.RemoteBlocksLeave = false
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.False(t, got.RemoteBlocksLeave)
+}
+
+func TestSettingsUpdate_OverlapPolicy(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OverlapPolicyBlock, settings.OverlapPolicy)
+	assert.True(t, settings.OverlapAllowTouching)
+
+	settings.OverlapPolicy = domain.OverlapPolicyWarn
+	settings.OverlapAllowTouching = false
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OverlapPolicyWarn, got.OverlapPolicy)
+	assert.False(t, got.OverlapAllowTouching)
+}
+
+func TestSettingsUpdate_RejectionReasons(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, settings.RejectionReasons)
+
+	settings.RejectionReasons = []domain.RejectionReason{
+		{Code: "coverage", Label: "Not enough team coverage"},
+		{Code: "notice", Label: "Requested too late"},
+	}
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, settings.RejectionReasons, got.RejectionReasons)
+}
+
+func TestSettingsUpdate_DigestSections(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultDigestSections(), settings.DigestSections)
+
+	settings.DigestSections = domain.DigestSections{MonthlyStats: false, UpcomingVacations: true, LowBalance: false}
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DigestSections{UpcomingVacations: true}, got.DigestSections)
+}
+
+func TestSettingsClaimAccrualMonth_OncePerMonth(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	claim := func(month string) bool {
+		var claimed bool
+		require.NoError(t, db.Transaction(func(tx *sql.Tx) error {
+			var err error
+			claimed, err = repo.ClaimAccrualMonthTx(ctx, tx, month)
+			return err
+		}))
+		return claimed
+	}
+
+	assert.True(t, claim("2027-03"))
+	assert.False(t, claim("2027-03"), "same month must not be claimed twice")
+	assert.False(t, claim("2027-02"), "earlier months cannot be claimed after a later one")
+	assert.True(t, claim("2027-04"))
+
+	// Admin settings updates must not reset the claimed month
+	settings, err := repo.Get(ctx)
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(ctx, settings))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "2027-04", got.LastAccrualMonth)
+}
+
 func TestSettingsUpdateLastNewsletterSent(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	repo := sqlite.NewSettingsRepository(db)
@@ -229,6 +623,21 @@ func TestSettingsUpdateLastNewsletterSent(t *testing.T) {
 		"expected LastSentAt %v, got %v", sentAt, *got.Newsletter.LastSentAt)
 }
 
+func TestSettingsUpdateLastPersonalDigestSent(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	sentAt := time.Date(2026, 2, 16, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.UpdateLastPersonalDigestSent(ctx, sentAt))
+
+	got, err := repo.Get(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, got.Newsletter.LastPersonalDigestAt)
+	assert.True(t, got.Newsletter.LastPersonalDigestAt.Equal(sentAt))
+	assert.Nil(t, got.Newsletter.LastSentAt, "the newsletter timestamp is separate")
+}
+
 func TestSettingsUpdateLastNewsletterSent_PreservesOtherFields(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	repo := sqlite.NewSettingsRepository(db)
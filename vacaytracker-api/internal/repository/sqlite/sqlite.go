@@ -1,10 +1,12 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	_ "modernc.org/sqlite" // SQLite driver (CGo-free)
 )
@@ -14,8 +16,23 @@ type DB struct {
 	*sql.DB
 }
 
+// PoolConfig tunes the connection pool behind a DB
+type PoolConfig struct {
+	MaxOpenConns    int           // More than one lets WAL readers run alongside the single writer
+	MaxIdleConns    int           // Capped at MaxOpenConns by database/sql
+	ConnMaxLifetime time.Duration // 0 keeps connections open indefinitely
+}
+
+// DefaultPoolConfig returns a single-connection pool, which serializes all access
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns: 1,
+		MaxIdleConns: 1,
+	}
+}
+
 // New creates a new SQLite database connection
-func New(dbPath string) (*DB, error) {
+func New(dbPath string, pool PoolConfig) (*DB, error) {
 	// Ensure the directory exists
 	dir := filepath.Dir(dbPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -26,17 +43,20 @@ func New(dbPath string) (*DB, error) {
 	// WAL mode for better concurrent read performance
 	// Foreign keys enabled for referential integrity
 	// Busy timeout to handle concurrent access
-	dsn := fmt.Sprintf("%s?_pragma=journal_mode(WAL)&_pragma=foreign_keys(ON)&_pragma=busy_timeout(5000)", dbPath)
+	// Immediate transactions take the write lock up front, so with several connections a
+	// writer waits out the busy timeout instead of failing when it upgrades a read lock
+	dsn := fmt.Sprintf("%s?_pragma=journal_mode(WAL)&_pragma=foreign_keys(ON)&_pragma=busy_timeout(5000)&_txlock=immediate", dbPath)
 
 	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	// SQLite only supports one writer at a time
-	// Setting these to 1 prevents "database is locked" errors
-	db.SetMaxOpenConns(1)
-	db.SetMaxIdleConns(1)
+	// SQLite only supports one writer at a time; the busy timeout and immediate
+	// transactions queue writers when the pool allows more than one connection
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
 
 	// Verify the connection works
 	if err := db.Ping(); err != nil {
@@ -52,6 +72,15 @@ func (db *DB) Close() error {
 	return db.DB.Close()
 }
 
+// HealthCheck runs a trivial query to confirm the database answers
+func (db *DB) HealthCheck(ctx context.Context) error {
+	var one int
+	if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		return fmt.Errorf("database health check failed: %w", err)
+	}
+	return nil
+}
+
 // Transaction executes a function within a database transaction
 func (db *DB) Transaction(fn func(tx *sql.Tx) error) error {
 	tx, err := db.Begin()
@@ -0,0 +1,65 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/repository/sqlite"
+	"vacaytracker-api/internal/testutil"
+)
+
+func TestHealthCheck(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+
+	assert.NoError(t, db.HealthCheck(context.Background()))
+
+	db.DB.Close()
+	assert.Error(t, db.HealthCheck(context.Background()))
+}
+
+func TestTransaction_ConcurrentWritersWithPool(t *testing.T) {
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "pool.db"), sqlite.PoolConfig{MaxOpenConns: 8, MaxIdleConns: 8})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`CREATE TABLE counter (id INTEGER PRIMARY KEY, value INTEGER NOT NULL)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO counter (id, value) VALUES (1, 0)`)
+	require.NoError(t, err)
+
+	// Each transaction reads before it writes, which fails with "database is locked"
+	// on lock upgrade unless transactions take the write lock up front
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- db.Transaction(func(tx *sql.Tx) error {
+				var value int
+				if err := tx.QueryRow(`SELECT value FROM counter WHERE id = 1`).Scan(&value); err != nil {
+					return err
+				}
+				_, err := tx.Exec(`UPDATE counter SET value = ? WHERE id = 1`, value+1)
+				return err
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	var value int
+	require.NoError(t, db.QueryRow(`SELECT value FROM counter WHERE id = 1`).Scan(&value))
+	assert.Equal(t, writers, value)
+}
@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"vacaytracker-api/internal/domain"
+)
+
+// TeamRepository handles team database operations
+type TeamRepository struct {
+	db *DB
+}
+
+// NewTeamRepository creates a new TeamRepository
+func NewTeamRepository(db *DB) *TeamRepository {
+	return &TeamRepository{db: db}
+}
+
+// Create inserts a new team
+func (r *TeamRepository) Create(ctx context.Context, team *domain.Team) error {
+	if team.ID == "" {
+		team.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO teams (id, name, created_at, updated_at)
+		VALUES (?, ?, datetime('now'), datetime('now'))
+	`
+	if _, err := r.db.ExecContext(ctx, query, team.ID, team.Name); err != nil {
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a team by ID
+func (r *TeamRepository) GetByID(ctx context.Context, id string) (*domain.Team, error) {
+	query := `SELECT id, name, created_at, updated_at FROM teams WHERE id = ?`
+
+	var team domain.Team
+	var createdAt, updatedAt string
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&team.ID, &team.Name, &createdAt, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	team.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+	team.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
+	return &team, nil
+}
+
+// List retrieves all teams ordered by name
+func (r *TeamRepository) List(ctx context.Context) ([]*domain.Team, error) {
+	query := `SELECT id, name, created_at, updated_at FROM teams ORDER BY name ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := make([]*domain.Team, 0)
+	for rows.Next() {
+		var team domain.Team
+		var createdAt, updatedAt string
+		if err := rows.Scan(&team.ID, &team.Name, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		team.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		team.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
+		teams = append(teams, &team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating teams: %w", err)
+	}
+
+	return teams, nil
+}
+
+// Update renames a team
+func (r *TeamRepository) Update(ctx context.Context, team *domain.Team) error {
+	query := `UPDATE teams SET name = ?, updated_at = datetime('now') WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, team.Name, team.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update team: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Delete removes a team; its members become unassigned
+func (r *TeamRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM teams WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// NameExistsExcluding checks if a team name is in use by a team other than excludeID
+func (r *TeamRepository) NameExistsExcluding(ctx context.Context, name, excludeID string) (bool, error) {
+	query := `SELECT COUNT(*) FROM teams WHERE name = ? AND id != ?`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, name, excludeID).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check team name: %w", err)
+	}
+
+	return count > 0, nil
+}
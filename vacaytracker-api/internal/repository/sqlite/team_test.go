@@ -0,0 +1,128 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository/sqlite"
+	"vacaytracker-api/internal/testutil"
+)
+
+func TestTeam_CRUD(t *testing.T) {
+	db, _, _ := setupRepos(t)
+	repo := sqlite.NewTeamRepository(db)
+	ctx := context.Background()
+
+	team := &domain.Team{Name: "Engineering"}
+	require.NoError(t, repo.Create(ctx, team))
+	require.NotEmpty(t, team.ID)
+
+	got, err := repo.GetByID(ctx, team.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "Engineering", got.Name)
+
+	exists, err := repo.NameExistsExcluding(ctx, "Engineering", "")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = repo.NameExistsExcluding(ctx, "Engineering", team.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	team.Name = "Platform"
+	require.NoError(t, repo.Update(ctx, team))
+
+	require.NoError(t, repo.Create(ctx, &domain.Team{Name: "Design"}))
+	teams, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+	assert.Equal(t, "Design", teams[0].Name)
+	assert.Equal(t, "Platform", teams[1].Name)
+
+	require.NoError(t, repo.Delete(ctx, team.ID))
+	got, err = repo.GetByID(ctx, team.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	assert.ErrorIs(t, repo.Delete(ctx, team.ID), sql.ErrNoRows)
+}
+
+func TestTeam_DeleteUnassignsMembers(t *testing.T) {
+	db, userRepo, _ := setupRepos(t)
+	repo := sqlite.NewTeamRepository(db)
+	ctx := context.Background()
+
+	team := &domain.Team{Name: "Engineering"}
+	require.NoError(t, repo.Create(ctx, team))
+
+	user := testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+	user.TeamID = &team.ID
+	require.NoError(t, userRepo.Update(ctx, user))
+
+	require.NoError(t, repo.Delete(ctx, team.ID))
+
+	got, err := userRepo.GetByID(ctx, "user1")
+	require.NoError(t, err)
+	assert.Nil(t, got.TeamID)
+}
+
+func TestTeam_ScopesListTeamAndStats(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	repo := sqlite.NewTeamRepository(db)
+	ctx := context.Background()
+
+	team := &domain.Team{Name: "Engineering"}
+	require.NoError(t, repo.Create(ctx, team))
+
+	alice := testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+	alice.TeamID = &team.ID
+	require.NoError(t, userRepo.Update(ctx, alice))
+	testutil.CreateTestUser(t, userRepo, "user2", "b@test.com", "Bob", domain.RoleEmployee, 25)
+
+	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-10", "2027-06-15", 5, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "v2", "user2", "2027-06-20", "2027-06-25", 5, domain.StatusApproved)
+
+	results, err := vacRepo.ListTeam(ctx, 6, 2027, team.ID)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "v1", results[0].ID)
+
+	now := time.Now()
+	stats, err := vacRepo.GetMonthlyStats(ctx, now.Year(), int(now.Month()), team.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.TotalSubmitted)
+	assert.Equal(t, 5, stats.TotalDaysUsed)
+}
+
+func TestTeam_ListByTeam(t *testing.T) {
+	db, userRepo, _ := setupRepos(t)
+	repo := sqlite.NewTeamRepository(db)
+	ctx := context.Background()
+
+	team := &domain.Team{Name: "Engineering"}
+	require.NoError(t, repo.Create(ctx, team))
+
+	bob := testutil.CreateTestUser(t, userRepo, "user2", "b@test.com", "Bob", domain.RoleEmployee, 25)
+	bob.TeamID = &team.ID
+	require.NoError(t, userRepo.Update(ctx, bob))
+	alice := testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+	alice.TeamID = &team.ID
+	require.NoError(t, userRepo.Update(ctx, alice))
+	testutil.CreateTestUser(t, userRepo, "user3", "c@test.com", "Carol", domain.RoleEmployee, 25)
+
+	members, err := userRepo.ListByTeam(ctx, team.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, "Alice", members[0].Name)
+	assert.Equal(t, "Bob", members[1].Name)
+
+	none, err := userRepo.ListByTeam(ctx, "missing")
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
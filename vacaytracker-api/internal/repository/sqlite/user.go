@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository"
 )
 
 // UserRepository handles user database operations
@@ -38,8 +40,8 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	}
 
 	query := `
-		INSERT INTO users (id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'))
+		INSERT INTO users (id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, must_change_password, manager_id, team_id, locale, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'))
 	`
 
 	_, err = r.db.ExecContext(ctx, query,
@@ -51,19 +53,27 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 		user.VacationBalance,
 		user.StartDate,
 		emailPrefsJSON,
+		user.MustChangePassword,
+		user.ManagerID,
+		user.TeamID,
+		user.LocaleOrDefault(),
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
+	// New users start active (column default)
+	user.Active = true
+	user.Locale = user.LocaleOrDefault()
+
 	return nil
 }
 
 // GetByID retrieves a user by their ID
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, created_at, updated_at
+		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, must_change_password, manager_id, team_id, deleted_at, active, locale, pending_email, last_login_at, created_at, updated_at
 		FROM users
 		WHERE id = ?
 	`
@@ -74,30 +84,18 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User,
 // GetByEmail retrieves a user by their email address
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, created_at, updated_at
+		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, must_change_password, manager_id, team_id, deleted_at, active, locale, pending_email, last_login_at, created_at, updated_at
 		FROM users
-		WHERE email = ?
+		WHERE email = ? AND deleted_at IS NULL
 	`
 
 	return r.scanUser(r.db.QueryRowContext(ctx, query, email))
 }
 
 // GetAll retrieves all users with optional filtering and pagination
-func (r *UserRepository) GetAll(ctx context.Context, role *domain.Role, search string, limit, offset int) ([]*domain.User, int, error) {
-	// Build query with filters
-	baseQuery := "FROM users WHERE 1=1"
-	args := []interface{}{}
-
-	if role != nil {
-		baseQuery += " AND role = ?"
-		args = append(args, string(*role))
-	}
-
-	if search != "" {
-		baseQuery += " AND (name LIKE ? OR email LIKE ?)"
-		searchPattern := "%" + search + "%"
-		args = append(args, searchPattern, searchPattern)
-	}
+// With a search term and no explicit sort, users whose name (or a word in it) or email starts with the term come first
+func (r *UserRepository) GetAll(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, limit, offset int) ([]*domain.User, int, error) {
+	baseQuery, args, search := userFilterQuery(filter)
 
 	// Get total count
 	var total int
@@ -108,8 +106,13 @@ func (r *UserRepository) GetAll(ctx context.Context, role *domain.Role, search s
 
 	// Get users with pagination
 	selectQuery := `
-		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, created_at, updated_at
-	` + baseQuery + " ORDER BY created_at DESC LIMIT ? OFFSET ?"
+		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, must_change_password, manager_id, team_id, deleted_at, active, locale, pending_email, last_login_at, created_at, updated_at
+	` + baseQuery + " ORDER BY "
+	if search != "" && sort.Field == "" {
+		selectQuery += `CASE WHEN name LIKE ? ESCAPE '\' OR name LIKE ? ESCAPE '\' OR email LIKE ? ESCAPE '\' THEN 0 ELSE 1 END, `
+		args = append(args, search+"%", "% "+search+"%", search+"%")
+	}
+	selectQuery += userOrderBy(sort) + " LIMIT ? OFFSET ?"
 	args = append(args, limit, offset)
 
 	rows, err := r.db.QueryContext(ctx, selectQuery, args...)
@@ -126,12 +129,91 @@ func (r *UserRepository) GetAll(ctx context.Context, role *domain.Role, search s
 	return users, total, nil
 }
 
+// GetAllAfter retrieves up to limit users with an ID greater than afterID, ordered by ID
+// Unlike GetAll's offsets, the ID cursor can't skip or repeat rows when users are added between pages
+// An empty afterID starts from the first user
+func (r *UserRepository) GetAllAfter(ctx context.Context, filter domain.UserFilter, afterID string, limit int) ([]*domain.User, error) {
+	baseQuery, args, _ := userFilterQuery(filter)
+
+	query := `
+		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, must_change_password, manager_id, team_id, deleted_at, active, locale, pending_email, last_login_at, created_at, updated_at
+	` + baseQuery + " AND id > ? ORDER BY id ASC LIMIT ?"
+	args = append(args, afterID, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users after cursor: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanUsers(rows)
+}
+
+// userFilterQuery builds the FROM/WHERE clause shared by the user listings
+// It also returns the escaped search term, empty when there is none
+func userFilterQuery(filter domain.UserFilter) (string, []interface{}, string) {
+	baseQuery := "FROM users WHERE deleted_at IS NULL"
+	args := []interface{}{}
+
+	if filter.Role != nil {
+		baseQuery += " AND role = ?"
+		args = append(args, string(*filter.Role))
+	}
+
+	if filter.Active != nil {
+		baseQuery += " AND active = ?"
+		args = append(args, *filter.Active)
+	}
+
+	if email := strings.TrimSpace(filter.Email); email != "" {
+		baseQuery += " AND email = ? COLLATE NOCASE"
+		args = append(args, email)
+	}
+
+	// LIKE is case-insensitive for ASCII in SQLite; wildcards in the term are matched literally
+	search := escapeLike(strings.TrimSpace(filter.Search))
+	if search != "" {
+		baseQuery += ` AND (name LIKE ? ESCAPE '\' OR email LIKE ? ESCAPE '\' OR role = ? COLLATE NOCASE)`
+		searchPattern := "%" + search + "%"
+		args = append(args, searchPattern, searchPattern, strings.TrimSpace(filter.Search))
+	}
+
+	return baseQuery, args, search
+}
+
+// escapeLike escapes LIKE wildcards so a search term only matches literally
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term)
+}
+
+// userSortColumns whitelists the columns GetAll may order by, keeping user input out of the SQL
+var userSortColumns = map[domain.UserSortField]string{
+	domain.UserSortName:      "name COLLATE NOCASE",
+	domain.UserSortEmail:     "email COLLATE NOCASE",
+	domain.UserSortBalance:   "vacation_balance",
+	domain.UserSortCreatedAt: "created_at",
+}
+
+// userOrderBy builds the ORDER BY clause for a user listing
+// Unknown or empty fields fall back to newest first; id breaks ties so pagination is stable
+func userOrderBy(sort domain.UserSort) string {
+	column, ok := userSortColumns[sort.Field]
+	if !ok {
+		return "created_at DESC"
+	}
+	direction := "ASC"
+	if sort.Descending {
+		direction = "DESC"
+	}
+	return column + " " + direction + ", id ASC"
+}
+
 // GetByRole retrieves all users with a specific role
 func (r *UserRepository) GetByRole(ctx context.Context, role domain.Role) ([]*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, created_at, updated_at
+		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, must_change_password, manager_id, team_id, deleted_at, active, locale, pending_email, last_login_at, created_at, updated_at
 		FROM users
-		WHERE role = ?
+		WHERE role = ? AND deleted_at IS NULL
 		ORDER BY name ASC
 	`
 
@@ -144,9 +226,45 @@ func (r *UserRepository) GetByRole(ctx context.Context, role domain.Role) ([]*do
 	return r.scanUsers(rows)
 }
 
+// ListByManager retrieves a manager's direct reports
+func (r *UserRepository) ListByManager(ctx context.Context, managerID string) ([]*domain.User, error) {
+	query := `
+		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, must_change_password, manager_id, team_id, deleted_at, active, locale, pending_email, last_login_at, created_at, updated_at
+		FROM users
+		WHERE manager_id = ? AND deleted_at IS NULL
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, managerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users by manager: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanUsers(rows)
+}
+
+// ListByTeam retrieves all members of a team
+func (r *UserRepository) ListByTeam(ctx context.Context, teamID string) ([]*domain.User, error) {
+	query := `
+		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, must_change_password, manager_id, team_id, deleted_at, active, locale, pending_email, last_login_at, created_at, updated_at
+		FROM users
+		WHERE team_id = ? AND deleted_at IS NULL
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users by team: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanUsers(rows)
+}
+
 // CountByRole counts users with a specific role
 func (r *UserRepository) CountByRole(ctx context.Context, role domain.Role) (int, error) {
-	query := `SELECT COUNT(*) FROM users WHERE role = ?`
+	query := `SELECT COUNT(*) FROM users WHERE role = ? AND deleted_at IS NULL`
 
 	var count int
 	if err := r.db.QueryRowContext(ctx, query, string(role)).Scan(&count); err != nil {
@@ -156,6 +274,18 @@ func (r *UserRepository) CountByRole(ctx context.Context, role domain.Role) (int
 	return count, nil
 }
 
+// CountActive counts active users of any role, optionally only those in one team ("" counts everyone)
+func (r *UserRepository) CountActive(ctx context.Context, teamID string) (int, error) {
+	query := `SELECT COUNT(*) FROM users WHERE active = 1 AND deleted_at IS NULL AND (? = '' OR team_id = ?)`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, teamID, teamID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count active users: %w", err)
+	}
+
+	return count, nil
+}
+
 // Update updates an existing user
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	emailPrefsJSON, err := user.EmailPreferences.ToJSONString()
@@ -165,7 +295,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 
 	query := `
 		UPDATE users
-		SET email = ?, name = ?, role = ?, vacation_balance = ?, start_date = ?, email_preferences = ?
+		SET email = ?, name = ?, role = ?, vacation_balance = ?, start_date = ?, email_preferences = ?, manager_id = ?, team_id = ?, locale = ?
 		WHERE id = ?
 	`
 
@@ -176,6 +306,51 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 		user.VacationBalance,
 		user.StartDate,
 		emailPrefsJSON,
+		user.ManagerID,
+		user.TeamID,
+		user.LocaleOrDefault(),
+		user.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// UpdateTx updates an existing user within a transaction
+func (r *UserRepository) UpdateTx(ctx context.Context, tx *sql.Tx, user *domain.User) error {
+	emailPrefsJSON, err := user.EmailPreferences.ToJSONString()
+	if err != nil {
+		return fmt.Errorf("failed to serialize email preferences: %w", err)
+	}
+
+	query := `
+		UPDATE users
+		SET email = ?, name = ?, role = ?, vacation_balance = ?, start_date = ?, email_preferences = ?, manager_id = ?, team_id = ?, locale = ?
+		WHERE id = ?
+	`
+
+	result, err := tx.ExecContext(ctx, query,
+		user.Email,
+		user.Name,
+		string(user.Role),
+		user.VacationBalance,
+		user.StartDate,
+		emailPrefsJSON,
+		user.ManagerID,
+		user.TeamID,
+		user.LocaleOrDefault(),
 		user.ID,
 	)
 
@@ -195,9 +370,9 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
-// UpdatePassword updates a user's password hash
+// UpdatePassword updates a user's password hash and clears any forced password change
 func (r *UserRepository) UpdatePassword(ctx context.Context, id, passwordHash string) error {
-	query := `UPDATE users SET password_hash = ? WHERE id = ?`
+	query := `UPDATE users SET password_hash = ?, must_change_password = 0 WHERE id = ?`
 
 	result, err := r.db.ExecContext(ctx, query, passwordHash, id)
 	if err != nil {
@@ -242,6 +417,74 @@ func (r *UserRepository) UpdateEmailPreferences(ctx context.Context, id string,
 	return nil
 }
 
+// TouchLastLogin records now as the user's last successful login
+func (r *UserRepository) TouchLastLogin(ctx context.Context, id string) error {
+	query := `UPDATE users SET last_login_at = datetime('now') WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to touch last login: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetPendingEmail records the address a user asked to switch to, replacing any earlier request
+func (r *UserRepository) SetPendingEmail(ctx context.Context, id, email string) error {
+	query := `UPDATE users SET pending_email = ? WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, email, id)
+	if err != nil {
+		return fmt.Errorf("failed to set pending email: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ConfirmPendingEmail makes the pending email the user's email and clears it
+// Returns sql.ErrNoRows unless email is still the user's pending email,
+// and repository.ErrEmailTaken if another account, even a deleted one, holds the address
+func (r *UserRepository) ConfirmPendingEmail(ctx context.Context, id, email string) error {
+	query := `UPDATE users SET email = pending_email, pending_email = NULL WHERE id = ? AND pending_email = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id, email)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrEmailTaken
+		}
+		return fmt.Errorf("failed to confirm pending email: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
 // UpdateVacationBalance updates a user's vacation balance
 func (r *UserRepository) UpdateVacationBalance(ctx context.Context, id string, balance int) error {
 	query := `UPDATE users SET vacation_balance = ? WHERE id = ?`
@@ -284,9 +527,68 @@ func (r *UserRepository) UpdateVacationBalanceTx(ctx context.Context, tx *sql.Tx
 	return nil
 }
 
-// Delete removes a user from the database
+// AddVacationBalanceTx adds delta (negative to deduct) to a user's balance within a transaction and returns the new balance
+// A deduction that would leave the balance below floor is refused with repository.ErrBalanceBelowMinimum; credits always apply
+func (r *UserRepository) AddVacationBalanceTx(ctx context.Context, tx *sql.Tx, id string, delta, floor int) (int, error) {
+	query := `
+		UPDATE users SET vacation_balance = vacation_balance + ?
+		WHERE id = ? AND (? >= 0 OR vacation_balance + ? >= ?)
+	`
+
+	result, err := tx.ExecContext(ctx, query, delta, id, delta, delta, floor)
+	if err != nil {
+		return 0, fmt.Errorf("failed to adjust vacation balance: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	var balance int
+	err = tx.QueryRowContext(ctx, `SELECT vacation_balance FROM users WHERE id = ?`, id).Scan(&balance)
+	if err == sql.ErrNoRows {
+		return 0, sql.ErrNoRows
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get vacation balance: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return balance, repository.ErrBalanceBelowMinimum
+	}
+
+	return balance, nil
+}
+
+// CreditVacationBalanceTx adds up to amount to a user's balance within a transaction without taking it past ceiling
+// The balance is read and raised inside the transaction; returns the amount actually credited, 0 at or above the ceiling
+func (r *UserRepository) CreditVacationBalanceTx(ctx context.Context, tx *sql.Tx, id string, amount, ceiling int) (int, error) {
+	var balance int
+	err := tx.QueryRowContext(ctx, `SELECT vacation_balance FROM users WHERE id = ?`, id).Scan(&balance)
+	if err == sql.ErrNoRows {
+		return 0, sql.ErrNoRows
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get vacation balance: %w", err)
+	}
+
+	credit := min(amount, ceiling-balance)
+	if credit <= 0 {
+		return 0, nil
+	}
+
+	query := `UPDATE users SET vacation_balance = vacation_balance + ? WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, query, credit, id); err != nil {
+		return 0, fmt.Errorf("failed to credit vacation balance: %w", err)
+	}
+
+	return credit, nil
+}
+
+// Delete soft-deletes a user so their vacation history is kept
 func (r *UserRepository) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM users WHERE id = ?`
+	query := `UPDATE users SET deleted_at = datetime('now') WHERE id = ? AND deleted_at IS NULL`
 
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -305,7 +607,49 @@ func (r *UserRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// EmailExists checks if an email address is already in use
+// SetActive activates or deactivates a user
+func (r *UserRepository) SetActive(ctx context.Context, id string, active bool) error {
+	query := `UPDATE users SET active = ? WHERE id = ? AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, active, id)
+	if err != nil {
+		return fmt.Errorf("failed to update user status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Restore clears a user's soft delete
+func (r *UserRepository) Restore(ctx context.Context, id string) error {
+	query := `UPDATE users SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// EmailExists checks if an email address is already in use, including by deleted users
 func (r *UserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
 	query := `SELECT COUNT(*) FROM users WHERE email = ?`
 
@@ -332,9 +676,9 @@ func (r *UserRepository) EmailExistsExcluding(ctx context.Context, email, exclud
 // GetNewsletterRecipients returns users who have weeklyDigest email preference enabled
 func (r *UserRepository) GetNewsletterRecipients(ctx context.Context) ([]*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, created_at, updated_at
+		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, must_change_password, manager_id, team_id, deleted_at, active, locale, pending_email, last_login_at, created_at, updated_at
 		FROM users
-		WHERE json_extract(email_preferences, '$.weeklyDigest') = 1
+		WHERE json_extract(email_preferences, '$.weeklyDigest') = 1 AND deleted_at IS NULL
 		ORDER BY name ASC
 	`
 
@@ -350,9 +694,9 @@ func (r *UserRepository) GetNewsletterRecipients(ctx context.Context) ([]*domain
 // GetLowBalanceUsers returns users with vacation balance at or below the threshold
 func (r *UserRepository) GetLowBalanceUsers(ctx context.Context, threshold int) ([]*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, created_at, updated_at
+		SELECT id, email, password_hash, name, role, vacation_balance, start_date, email_preferences, must_change_password, manager_id, team_id, deleted_at, active, locale, pending_email, last_login_at, created_at, updated_at
 		FROM users
-		WHERE vacation_balance <= ? AND role = 'employee'
+		WHERE vacation_balance <= ? AND role = 'employee' AND deleted_at IS NULL
 		ORDER BY vacation_balance ASC
 	`
 
@@ -367,7 +711,7 @@ func (r *UserRepository) GetLowBalanceUsers(ctx context.Context, threshold int)
 
 // UpdateAllBalances resets vacation balance for all employees to the specified value
 func (r *UserRepository) UpdateAllBalances(ctx context.Context, balance int) (int64, error) {
-	query := `UPDATE users SET vacation_balance = ? WHERE role = 'employee'`
+	query := `UPDATE users SET vacation_balance = ? WHERE role = 'employee' AND deleted_at IS NULL`
 
 	result, err := r.db.ExecContext(ctx, query, balance)
 	if err != nil {
@@ -382,11 +726,69 @@ func (r *UserRepository) UpdateAllBalances(ctx context.Context, balance int) (in
 	return rowsAffected, nil
 }
 
+// UpdateAllBalancesTx resets vacation balance for all employees to the specified value within a transaction
+func (r *UserRepository) UpdateAllBalancesTx(ctx context.Context, tx *sql.Tx, balance int) (int64, error) {
+	query := `UPDATE users SET vacation_balance = ? WHERE role = 'employee' AND deleted_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, query, balance)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update all balances: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// ListBalances returns every active employee's balance with the approved days of requests starting in the given leave year
+// Ordered by balance, lowest first, unless sort says otherwise
+func (r *UserRepository) ListBalances(ctx context.Context, year int, sort domain.UserSort) ([]*repository.EmployeeBalance, error) {
+	if sort.Field == "" {
+		sort.Field = domain.UserSortBalance
+	}
+
+	// The aggregate is wrapped so userOrderBy's unqualified columns only see users
+	query := `
+		SELECT id, name, email, vacation_balance, days_used FROM (
+			SELECT u.id, u.name, u.email, u.vacation_balance, u.created_at, COALESCE(SUM(vr.total_days), 0) AS days_used
+			FROM users u
+			LEFT JOIN vacation_requests vr
+				ON vr.user_id = u.id AND vr.status = 'approved' AND vr.type = 'vacation'
+				AND vr.start_date >= ` + leaveYearStartSQL + ` AND vr.start_date < ` + leaveYearStartSQL + `
+			WHERE u.role = 'employee' AND u.deleted_at IS NULL AND u.active = 1
+			GROUP BY u.id
+		)
+		ORDER BY ` + userOrderBy(sort)
+
+	rows, err := r.db.QueryContext(ctx, query, year, year+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query balances: %w", err)
+	}
+	defer rows.Close()
+
+	balances := make([]*repository.EmployeeBalance, 0)
+	for rows.Next() {
+		var b repository.EmployeeBalance
+		if err := rows.Scan(&b.UserID, &b.Name, &b.Email, &b.VacationBalance, &b.DaysUsed); err != nil {
+			return nil, fmt.Errorf("failed to scan balance: %w", err)
+		}
+		balances = append(balances, &b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate balances: %w", err)
+	}
+
+	return balances, nil
+}
+
 // scanUser scans a single user row
 func (r *UserRepository) scanUser(row *sql.Row) (*domain.User, error) {
 	var user domain.User
 	var role string
-	var startDate sql.NullString
+	var startDate, managerID, teamID, deletedAt, pendingEmail, lastLoginAt sql.NullString
 	var emailPrefsJSON string
 	var createdAt, updatedAt string
 
@@ -399,6 +801,14 @@ func (r *UserRepository) scanUser(row *sql.Row) (*domain.User, error) {
 		&user.VacationBalance,
 		&startDate,
 		&emailPrefsJSON,
+		&user.MustChangePassword,
+		&managerID,
+		&teamID,
+		&deletedAt,
+		&user.Active,
+		&user.Locale,
+		&pendingEmail,
+		&lastLoginAt,
 		&createdAt,
 		&updatedAt,
 	)
@@ -415,6 +825,23 @@ func (r *UserRepository) scanUser(row *sql.Row) (*domain.User, error) {
 	if startDate.Valid {
 		user.StartDate = &startDate.String
 	}
+	if managerID.Valid {
+		user.ManagerID = &managerID.String
+	}
+	if teamID.Valid {
+		user.TeamID = &teamID.String
+	}
+	if deletedAt.Valid {
+		t, _ := time.Parse("2006-01-02 15:04:05", deletedAt.String)
+		user.DeletedAt = &t
+	}
+	if pendingEmail.Valid {
+		user.PendingEmail = &pendingEmail.String
+	}
+	if lastLoginAt.Valid {
+		t, _ := time.Parse("2006-01-02 15:04:05", lastLoginAt.String)
+		user.LastLoginAt = &t
+	}
 
 	user.EmailPreferences, _ = domain.ParseEmailPreferences(emailPrefsJSON)
 
@@ -431,7 +858,7 @@ func (r *UserRepository) scanUsers(rows *sql.Rows) ([]*domain.User, error) {
 	for rows.Next() {
 		var user domain.User
 		var role string
-		var startDate sql.NullString
+		var startDate, managerID, teamID, deletedAt, pendingEmail, lastLoginAt sql.NullString
 		var emailPrefsJSON string
 		var createdAt, updatedAt string
 
@@ -444,6 +871,14 @@ func (r *UserRepository) scanUsers(rows *sql.Rows) ([]*domain.User, error) {
 			&user.VacationBalance,
 			&startDate,
 			&emailPrefsJSON,
+			&user.MustChangePassword,
+			&managerID,
+			&teamID,
+			&deletedAt,
+			&user.Active,
+			&user.Locale,
+			&pendingEmail,
+			&lastLoginAt,
 			&createdAt,
 			&updatedAt,
 		)
@@ -457,6 +892,23 @@ func (r *UserRepository) scanUsers(rows *sql.Rows) ([]*domain.User, error) {
 		if startDate.Valid {
 			user.StartDate = &startDate.String
 		}
+		if managerID.Valid {
+			user.ManagerID = &managerID.String
+		}
+		if teamID.Valid {
+			user.TeamID = &teamID.String
+		}
+		if deletedAt.Valid {
+			t, _ := time.Parse("2006-01-02 15:04:05", deletedAt.String)
+			user.DeletedAt = &t
+		}
+		if pendingEmail.Valid {
+			user.PendingEmail = &pendingEmail.String
+		}
+		if lastLoginAt.Valid {
+			t, _ := time.Parse("2006-01-02 15:04:05", lastLoginAt.String)
+			user.LastLoginAt = &t
+		}
 
 		user.EmailPreferences, _ = domain.ParseEmailPreferences(emailPrefsJSON)
 
@@ -472,3 +924,8 @@ func (r *UserRepository) scanUsers(rows *sql.Rows) ([]*domain.User, error) {
 
 	return users, nil
 }
+
+// isUniqueViolation reports whether err is SQLite rejecting a write on a UNIQUE index
+func isUniqueViolation(err error) bool {
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
@@ -5,11 +5,13 @@ import (
 	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository"
 	"vacaytracker-api/internal/repository/sqlite"
 	"vacaytracker-api/internal/testutil"
 )
@@ -196,25 +198,25 @@ func TestUserGetAll_Pagination(t *testing.T) {
 	}
 
 	// Fetch first page (limit 2, offset 0)
-	users, total, err := repo.GetAll(ctx, nil, "", 2, 0)
+	users, total, err := repo.GetAll(ctx, domain.UserFilter{}, domain.UserSort{}, 2, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 5, total)
 	assert.Len(t, users, 2)
 
 	// Fetch second page
-	users, total, err = repo.GetAll(ctx, nil, "", 2, 2)
+	users, total, err = repo.GetAll(ctx, domain.UserFilter{}, domain.UserSort{}, 2, 2)
 	require.NoError(t, err)
 	assert.Equal(t, 5, total)
 	assert.Len(t, users, 2)
 
 	// Fetch third page (only 1 remaining)
-	users, total, err = repo.GetAll(ctx, nil, "", 2, 4)
+	users, total, err = repo.GetAll(ctx, domain.UserFilter{}, domain.UserSort{}, 2, 4)
 	require.NoError(t, err)
 	assert.Equal(t, 5, total)
 	assert.Len(t, users, 1)
 
 	// Beyond range
-	users, total, err = repo.GetAll(ctx, nil, "", 2, 10)
+	users, total, err = repo.GetAll(ctx, domain.UserFilter{}, domain.UserSort{}, 2, 10)
 	require.NoError(t, err)
 	assert.Equal(t, 5, total)
 	assert.Len(t, users, 0)
@@ -237,7 +239,7 @@ func TestUserGetAll_RoleFilter(t *testing.T) {
 
 	// Filter admins
 	adminRole := domain.RoleAdmin
-	users, total, err := repo.GetAll(ctx, &adminRole, "", 100, 0)
+	users, total, err := repo.GetAll(ctx, domain.UserFilter{Role: &adminRole}, domain.UserSort{}, 100, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 2, total)
 	assert.Len(t, users, 2)
@@ -247,7 +249,7 @@ func TestUserGetAll_RoleFilter(t *testing.T) {
 
 	// Filter employees
 	empRole := domain.RoleEmployee
-	users, total, err = repo.GetAll(ctx, &empRole, "", 100, 0)
+	users, total, err = repo.GetAll(ctx, domain.UserFilter{Role: &empRole}, domain.UserSort{}, 100, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 3, total)
 	assert.Len(t, users, 3)
@@ -270,20 +272,20 @@ func TestUserGetAll_Search(t *testing.T) {
 	testutil.CreateTestUser(t, repo, "s-3", "echo@example.com", "Echo Chamber", domain.RoleEmployee, 25)
 
 	// Search by name substring — "Brown" only matches one user by name
-	users, total, err := repo.GetAll(ctx, nil, "Brown", 100, 0)
+	users, total, err := repo.GetAll(ctx, domain.UserFilter{Search: "Brown"}, domain.UserSort{}, 100, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 1, total)
 	assert.Len(t, users, 1)
 	assert.Equal(t, "Charlie Brown", users[0].Name)
 
 	// Search by email substring — "charlie" matches both by email (LIKE is case-insensitive in SQLite)
-	users, total, err = repo.GetAll(ctx, nil, "charlie", 100, 0)
+	users, total, err = repo.GetAll(ctx, domain.UserFilter{Search: "charlie"}, domain.UserSort{}, 100, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 2, total)
 	assert.Len(t, users, 2)
 
 	// Search that matches no one
-	users, total, err = repo.GetAll(ctx, nil, "zzzzz", 100, 0)
+	users, total, err = repo.GetAll(ctx, domain.UserFilter{Search: "zzzzz"}, domain.UserSort{}, 100, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 0, total)
 	assert.Len(t, users, 0)
@@ -341,6 +343,33 @@ func TestUserCountByRole(t *testing.T) {
 	assert.Equal(t, 1, empCount)
 }
 
+func TestUserCountActive(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "cnt-a1", "a1@example.com", "Admin A", domain.RoleAdmin, 0)
+	emp := testutil.CreateTestUser(t, repo, "cnt-e1", "e1@example.com", "Emp A", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, repo, "cnt-e2", "e2@example.com", "Emp B", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, repo, "cnt-e3", "e3@example.com", "Emp C", domain.RoleEmployee, 25)
+	require.NoError(t, repo.SetActive(ctx, "cnt-e2", false))
+	require.NoError(t, repo.Delete(ctx, "cnt-e3"))
+
+	count, err := repo.CountActive(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "inactive and deleted users are left out")
+
+	team := &domain.Team{Name: "Platform"}
+	require.NoError(t, teamRepo.Create(ctx, team))
+	emp.TeamID = &team.ID
+	require.NoError(t, repo.Update(ctx, emp))
+
+	count, err = repo.CountActive(ctx, team.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
 // ---------------------------------------------------------------------------
 // 12. Update
 // ---------------------------------------------------------------------------
@@ -427,6 +456,32 @@ func TestUserUpdatePassword(t *testing.T) {
 	assert.Equal(t, "new-hashed-password", fetched.PasswordHash)
 }
 
+func TestUserUpdatePassword_ClearsMustChangePassword(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, &domain.User{
+		ID:                 "pwd-2",
+		Email:              "temp@example.com",
+		PasswordHash:       "temp-hash",
+		Name:               "Temp User",
+		Role:               domain.RoleEmployee,
+		EmailPreferences:   domain.DefaultEmailPreferences(),
+		MustChangePassword: true,
+	}))
+
+	fetched, err := repo.GetByID(ctx, "pwd-2")
+	require.NoError(t, err)
+	assert.True(t, fetched.MustChangePassword)
+
+	require.NoError(t, repo.UpdatePassword(ctx, "pwd-2", "chosen-hash"))
+
+	fetched, err = repo.GetByID(ctx, "pwd-2")
+	require.NoError(t, err)
+	assert.False(t, fetched.MustChangePassword)
+}
+
 // ---------------------------------------------------------------------------
 // 15. UpdatePassword non-existent user
 // ---------------------------------------------------------------------------
@@ -584,9 +639,42 @@ func TestUserDelete(t *testing.T) {
 	err := repo.Delete(ctx, "del-1")
 	require.NoError(t, err)
 
+	// Soft-deleted: still reachable by ID for history, hidden from lookups by email and listings
 	fetched, err := repo.GetByID(ctx, "del-1")
+	require.NoError(t, err)
+	require.NotNil(t, fetched)
+	assert.True(t, fetched.IsDeleted())
+
+	byEmail, err := repo.GetByEmail(ctx, "del@example.com")
 	assert.NoError(t, err)
-	assert.Nil(t, fetched, "deleted user should not be found")
+	assert.Nil(t, byEmail, "deleted user should not be found by email")
+
+	users, total, err := repo.GetAll(ctx, domain.UserFilter{}, domain.UserSort{}, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+	assert.Equal(t, 0, total)
+
+	// Deleting twice reports not found
+	assert.ErrorIs(t, repo.Delete(ctx, "del-1"), sql.ErrNoRows)
+}
+
+func TestUserRestore(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "del-1", "del@example.com", "Delete Me", domain.RoleEmployee, 25)
+	require.NoError(t, repo.Delete(ctx, "del-1"))
+
+	require.NoError(t, repo.Restore(ctx, "del-1"))
+
+	fetched, err := repo.GetByEmail(ctx, "del@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, fetched)
+	assert.False(t, fetched.IsDeleted())
+
+	// Restoring an active user reports not found
+	assert.ErrorIs(t, repo.Restore(ctx, "del-1"), sql.ErrNoRows)
 }
 
 // ---------------------------------------------------------------------------
@@ -786,6 +874,122 @@ func TestUserUpdateAllBalances(t *testing.T) {
 	assert.Equal(t, 99, admin.VacationBalance, "admin balance should not be changed")
 }
 
+func TestUserAddVacationBalanceTx(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "add-1", "add1@example.com", "Add User", domain.RoleEmployee, 3)
+
+	var balance int
+	err := db.Transaction(func(tx *sql.Tx) error {
+		var err error
+		balance, err = repo.AddVacationBalanceTx(ctx, tx, "add-1", -2, 0)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, balance)
+
+	// A deduction below the floor is refused, a credit always applies
+	err = db.Transaction(func(tx *sql.Tx) error {
+		_, err := repo.AddVacationBalanceTx(ctx, tx, "add-1", -2, 0)
+		return err
+	})
+	assert.ErrorIs(t, err, repository.ErrBalanceBelowMinimum)
+
+	err = db.Transaction(func(tx *sql.Tx) error {
+		var err error
+		balance, err = repo.AddVacationBalanceTx(ctx, tx, "add-1", 4, 10)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, balance)
+
+	err = db.Transaction(func(tx *sql.Tx) error {
+		_, err := repo.AddVacationBalanceTx(ctx, tx, "no-such-id", 1, 0)
+		return err
+	})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUserCreditVacationBalanceTx(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "cr-1", "cr1@example.com", "Low", domain.RoleEmployee, 10)
+	testutil.CreateTestUser(t, repo, "cr-2", "cr2@example.com", "Near Cap", domain.RoleEmployee, 24)
+	testutil.CreateTestUser(t, repo, "cr-3", "cr3@example.com", "At Cap", domain.RoleEmployee, 25)
+
+	credited := map[string]int{}
+	err := db.Transaction(func(tx *sql.Tx) error {
+		for _, id := range []string{"cr-1", "cr-2", "cr-3"} {
+			credit, err := repo.CreditVacationBalanceTx(ctx, tx, id, 2, 25)
+			if err != nil {
+				return err
+			}
+			credited[id] = credit
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"cr-1": 2, "cr-2": 1, "cr-3": 0}, credited)
+
+	for id, want := range map[string]int{"cr-1": 12, "cr-2": 25, "cr-3": 25} {
+		user, err := repo.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, want, user.VacationBalance, id)
+	}
+
+	err = db.Transaction(func(tx *sql.Tx) error {
+		_, err := repo.CreditVacationBalanceTx(ctx, tx, "no-such-id", 2, 25)
+		return err
+	})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUserUpdateAllBalancesTx_RollbackOnError(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "ubtx-emp-1", "ubtx1@example.com", "Emp One", domain.RoleEmployee, 10)
+
+	err := db.Transaction(func(tx *sql.Tx) error {
+		affected, err := repo.UpdateAllBalancesTx(ctx, tx, 25)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, int64(1), affected)
+		return fmt.Errorf("simulated error after reset")
+	})
+	assert.Error(t, err)
+
+	emp, err := repo.GetByID(ctx, "ubtx-emp-1")
+	require.NoError(t, err)
+	assert.Equal(t, 10, emp.VacationBalance, "the reset is rolled back")
+}
+
+func TestUserUpdateTx(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	user := testutil.CreateTestUser(t, repo, "utx-1", "utx@example.com", "Tx User", domain.RoleEmployee, 25)
+	user.Name = "Renamed"
+	user.VacationBalance = 20
+
+	err := db.Transaction(func(tx *sql.Tx) error {
+		return repo.UpdateTx(ctx, tx, user)
+	})
+	require.NoError(t, err)
+
+	fetched, err := repo.GetByID(ctx, "utx-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", fetched.Name)
+	assert.Equal(t, 20, fetched.VacationBalance)
+}
+
 func TestUserUpdateAllBalances_NoEmployees(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	repo := sqlite.NewUserRepository(db)
@@ -839,13 +1043,111 @@ func TestUserGetAll_CombinedRoleAndSearch(t *testing.T) {
 
 	// Search for "Alice" among employees only
 	empRole := domain.RoleEmployee
-	users, total, err := repo.GetAll(ctx, &empRole, "Alice", 100, 0)
+	users, total, err := repo.GetAll(ctx, domain.UserFilter{Role: &empRole, Search: "Alice"}, domain.UserSort{}, 100, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 1, total)
 	require.Len(t, users, 1)
 	assert.Equal(t, "Alice Employee", users[0].Name)
 }
 
+func TestUserGetAll_ActiveFilter(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "af-1", "active.admin@example.com", "Active Admin", domain.RoleAdmin, 0)
+	testutil.CreateTestUser(t, repo, "af-2", "gone.admin@example.com", "Gone Admin", domain.RoleAdmin, 0)
+	testutil.CreateTestUser(t, repo, "af-3", "gone.emp@example.com", "Gone Employee", domain.RoleEmployee, 25)
+	require.NoError(t, repo.SetActive(ctx, "af-2", false))
+	require.NoError(t, repo.SetActive(ctx, "af-3", false))
+
+	// Inactive admins
+	adminRole := domain.RoleAdmin
+	inactive := false
+	users, total, err := repo.GetAll(ctx, domain.UserFilter{Role: &adminRole, Active: &inactive}, domain.UserSort{}, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, users, 1)
+	assert.Equal(t, "af-2", users[0].ID)
+
+	active := true
+	_, total, err = repo.GetAll(ctx, domain.UserFilter{Active: &active}, domain.UserSort{}, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+}
+
+func TestUserGetAll_ExactEmail(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "ee-1", "sam@example.com", "Sam", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, repo, "ee-2", "sam@example.com.au", "Sam AU", domain.RoleEmployee, 25)
+
+	users, total, err := repo.GetAll(ctx, domain.UserFilter{Email: "SAM@example.com"}, domain.UserSort{}, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, users, 1)
+	assert.Equal(t, "ee-1", users[0].ID)
+}
+
+func TestUserGetAll_SearchRoleAndWildcards(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "sr-1", "root@example.com", "Root", domain.RoleAdmin, 0)
+	testutil.CreateTestUser(t, repo, "sr-2", "dana@example.com", "Dana", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, repo, "sr-3", "under_score@example.com", "Under Score", domain.RoleEmployee, 25)
+
+	// The role name matches regardless of case
+	users, total, err := repo.GetAll(ctx, domain.UserFilter{Search: "ADMIN"}, domain.UserSort{}, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, users, 1)
+	assert.Equal(t, "sr-1", users[0].ID)
+
+	// "_" is matched literally rather than as a single-character wildcard
+	users, total, err = repo.GetAll(ctx, domain.UserFilter{Search: "o_t"}, domain.UserSort{}, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, users)
+
+	_, total, err = repo.GetAll(ctx, domain.UserFilter{Search: "under_"}, domain.UserSort{}, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+}
+
+func TestUserGetAll_SearchPrefixMatchesFirst(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "pf-1", "mjones@example.com", "Mary Jones", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, repo, "pf-2", "bjo@example.com", "Bjorn Borg", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, repo, "pf-3", "john@example.com", "John Smith", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, repo, "pf-4", "other@example.com", "Other", domain.RoleEmployee, 25)
+
+	users, total, err := repo.GetAll(ctx, domain.UserFilter{Search: "jo"}, domain.UserSort{}, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, users, 3)
+	assert.ElementsMatch(t, []string{"pf-1", "pf-3"}, []string{users[0].ID, users[1].ID})
+	assert.Equal(t, "pf-2", users[2].ID)
+
+	// Pages follow the same order
+	page, total, err := repo.GetAll(ctx, domain.UserFilter{Search: "jo"}, domain.UserSort{}, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, page, 1)
+	assert.Equal(t, "pf-2", page[0].ID)
+
+	// An explicit sort takes precedence over prefix ranking
+	users, _, err = repo.GetAll(ctx, domain.UserFilter{Search: "jo"}, domain.UserSort{Field: domain.UserSortName}, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pf-2", "pf-3", "pf-1"}, []string{users[0].ID, users[1].ID, users[2].ID})
+}
+
 func TestUserGetAll_OrderByCreatedAtDesc(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	repo := sqlite.NewUserRepository(db)
@@ -857,8 +1159,267 @@ func TestUserGetAll_OrderByCreatedAtDesc(t *testing.T) {
 	testutil.CreateTestUser(t, repo, "ord-2", "ord2@example.com", "Second Created", domain.RoleEmployee, 25)
 	testutil.CreateTestUser(t, repo, "ord-3", "ord3@example.com", "Third Created", domain.RoleEmployee, 25)
 
-	users, total, err := repo.GetAll(ctx, nil, "", 100, 0)
+	users, total, err := repo.GetAll(ctx, domain.UserFilter{}, domain.UserSort{}, 100, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 3, total)
 	assert.Len(t, users, 3)
 }
+
+func TestUserGetAll_SortByBalanceAndName(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "u1", "carol@example.com", "carol", domain.RoleEmployee, 12)
+	testutil.CreateTestUser(t, repo, "u2", "alice@example.com", "Alice", domain.RoleEmployee, 3)
+	testutil.CreateTestUser(t, repo, "u3", "bob@example.com", "Bob", domain.RoleEmployee, 20)
+
+	users, _, err := repo.GetAll(ctx, domain.UserFilter{}, domain.UserSort{Field: domain.UserSortBalance}, 100, 0)
+	require.NoError(t, err)
+	require.Len(t, users, 3)
+	assert.Equal(t, []string{"u2", "u1", "u3"}, []string{users[0].ID, users[1].ID, users[2].ID})
+
+	users, _, err = repo.GetAll(ctx, domain.UserFilter{}, domain.UserSort{Field: domain.UserSortBalance, Descending: true}, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "u3", users[0].ID)
+
+	// Names sort case-insensitively
+	users, _, err = repo.GetAll(ctx, domain.UserFilter{}, domain.UserSort{Field: domain.UserSortName}, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice", "Bob", "carol"}, []string{users[0].Name, users[1].Name, users[2].Name})
+}
+
+func TestUserGetAllAfter(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "u3", "carol@example.com", "Carol", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, repo, "u1", "alice@example.com", "Alice", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, repo, "u2", "bob@example.com", "Bob", domain.RoleAdmin, 25)
+
+	users, err := repo.GetAllAfter(ctx, domain.UserFilter{}, "", 2)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, []string{"u1", "u2"}, []string{users[0].ID, users[1].ID})
+
+	// A user added before the cursor doesn't shift the next page
+	testutil.CreateTestUser(t, repo, "u0", "zed@example.com", "Zed", domain.RoleEmployee, 25)
+
+	users, err = repo.GetAllAfter(ctx, domain.UserFilter{}, "u2", 2)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "u3", users[0].ID)
+
+	role := domain.RoleEmployee
+	users, err = repo.GetAllAfter(ctx, domain.UserFilter{Role: &role}, "u0", 10)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, []string{"u1", "u3"}, []string{users[0].ID, users[1].ID})
+}
+
+// ---------------------------------------------------------------------------
+// ListByManager returns direct reports only
+// ---------------------------------------------------------------------------
+
+func TestUserListByManager(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "mgr-1", "mgr@example.com", "Manager", domain.RoleEmployee, 25)
+	report := testutil.CreateTestUser(t, repo, "rep-1", "rep@example.com", "Report", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, repo, "other-1", "other@example.com", "Other", domain.RoleEmployee, 25)
+
+	managerID := "mgr-1"
+	report.ManagerID = &managerID
+	require.NoError(t, repo.Update(ctx, report))
+
+	reports, err := repo.ListByManager(ctx, "mgr-1")
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, "rep-1", reports[0].ID)
+	require.NotNil(t, reports[0].ManagerID)
+	assert.Equal(t, "mgr-1", *reports[0].ManagerID)
+
+	none, err := repo.ListByManager(ctx, "other-1")
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+// ---------------------------------------------------------------------------
+// TouchLastLogin records the login time; new users have none
+// ---------------------------------------------------------------------------
+
+func TestUserTouchLastLogin(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	created := testutil.CreateTestUser(t, repo, "login-1", "login@example.com", "Login", domain.RoleEmployee, 25)
+	assert.Nil(t, created.LastLoginAt)
+
+	require.NoError(t, repo.TouchLastLogin(ctx, "login-1"))
+
+	fetched, err := repo.GetByID(ctx, "login-1")
+	require.NoError(t, err)
+	require.NotNil(t, fetched.LastLoginAt)
+	assert.WithinDuration(t, time.Now(), *fetched.LastLoginAt, time.Minute)
+
+	assert.ErrorIs(t, repo.TouchLastLogin(ctx, "missing"), sql.ErrNoRows)
+}
+
+// ---------------------------------------------------------------------------
+// SetActive toggles the active flag; new users start active
+// ---------------------------------------------------------------------------
+
+func TestUserSetActive(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	created := testutil.CreateTestUser(t, repo, "act-1", "act@example.com", "Active", domain.RoleEmployee, 25)
+	assert.True(t, created.Active)
+
+	require.NoError(t, repo.SetActive(ctx, "act-1", false))
+
+	fetched, err := repo.GetByID(ctx, "act-1")
+	require.NoError(t, err)
+	assert.False(t, fetched.Active)
+
+	assert.ErrorIs(t, repo.SetActive(ctx, "missing", true), sql.ErrNoRows)
+}
+
+func TestUserListBalances_LeaveYear(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	vacRepo := sqlite.NewVacationRepository(db)
+	ctx := context.Background()
+
+	settings := domain.DefaultSettings()
+	settings.VacationResetMonth = 4
+	require.NoError(t, sqlite.NewSettingsRepository(db).Update(ctx, &settings))
+
+	testutil.CreateTestUser(t, repo, "u1", "alice@example.com", "Alice", domain.RoleEmployee, 20)
+	testutil.CreateTestVacation(t, vacRepo, "before", "u1", "2027-03-29", "2027-03-31", 3, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "first", "u1", "2027-04-01", "2027-04-02", 2, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "last", "u1", "2028-03-27", "2028-03-31", 5, domain.StatusApproved)
+
+	balances, err := repo.ListBalances(ctx, 2027, domain.UserSort{})
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	assert.Equal(t, 7, balances[0].DaysUsed)
+}
+
+func TestUserListBalances(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	vacRepo := sqlite.NewVacationRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "u1", "alice@example.com", "Alice", domain.RoleEmployee, 20)
+	testutil.CreateTestUser(t, repo, "u2", "bob@example.com", "Bob", domain.RoleEmployee, 5)
+	testutil.CreateTestUser(t, repo, "u3", "carol@example.com", "Carol", domain.RoleEmployee, 1)
+	testutil.CreateTestUser(t, repo, "admin", "admin@example.com", "Admin", domain.RoleAdmin, 0)
+	require.NoError(t, repo.SetActive(ctx, "u3", false))
+
+	testutil.CreateTestVacation(t, vacRepo, "v1", "u1", "2027-03-01", "2027-03-03", 3, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "v2", "u1", "2027-07-05", "2027-07-06", 2, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "v3", "u1", "2027-08-02", "2027-08-02", 1, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "v4", "u1", "2026-03-02", "2026-03-02", 1, domain.StatusApproved)
+
+	balances, err := repo.ListBalances(ctx, 2027, domain.UserSort{})
+	require.NoError(t, err)
+	require.Len(t, balances, 2, "inactive employees and admins are left out")
+
+	// Lowest balance first by default
+	assert.Equal(t, "u2", balances[0].UserID)
+	assert.Equal(t, 5, balances[0].VacationBalance)
+	assert.Equal(t, 0, balances[0].DaysUsed)
+	assert.Equal(t, "u1", balances[1].UserID)
+	assert.Equal(t, "Alice", balances[1].Name)
+	assert.Equal(t, "alice@example.com", balances[1].Email)
+	assert.Equal(t, 5, balances[1].DaysUsed, "only approved requests starting in the year count")
+
+	balances, err = repo.ListBalances(ctx, 2027, domain.UserSort{Field: domain.UserSortName})
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, "u1", balances[0].UserID)
+
+	balances, err = repo.ListBalances(ctx, 2027, domain.UserSort{Field: domain.UserSortCreatedAt, Descending: true})
+	require.NoError(t, err)
+	assert.Len(t, balances, 2)
+}
+
+func TestUserLocale(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	created := testutil.CreateTestUser(t, repo, "loc-1", "loc@example.com", "Locale", domain.RoleEmployee, 25)
+	assert.Equal(t, domain.LocaleEnglish, created.Locale)
+
+	created.Locale = domain.LocaleGerman
+	require.NoError(t, repo.Update(ctx, created))
+
+	fetched, err := repo.GetByID(ctx, "loc-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.LocaleGerman, fetched.Locale)
+}
+
+// ---------------------------------------------------------------------------
+// Pending email is held apart from the login email until confirmed
+// ---------------------------------------------------------------------------
+
+func TestUserPendingEmail(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "pend-1", "old@example.com", "Pending", domain.RoleEmployee, 25)
+
+	require.NoError(t, repo.SetPendingEmail(ctx, "pend-1", "new@example.com"))
+
+	fetched, err := repo.GetByEmail(ctx, "old@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, fetched)
+	require.NotNil(t, fetched.PendingEmail)
+	assert.Equal(t, "new@example.com", *fetched.PendingEmail)
+
+	// Only the address still pending can be confirmed
+	assert.ErrorIs(t, repo.ConfirmPendingEmail(ctx, "pend-1", "other@example.com"), sql.ErrNoRows)
+
+	require.NoError(t, repo.ConfirmPendingEmail(ctx, "pend-1", "new@example.com"))
+
+	fetched, err = repo.GetByID(ctx, "pend-1")
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", fetched.Email)
+	assert.Nil(t, fetched.PendingEmail)
+
+	old, err := repo.GetByEmail(ctx, "old@example.com")
+	require.NoError(t, err)
+	assert.Nil(t, old)
+
+	// Confirming twice finds nothing pending
+	assert.ErrorIs(t, repo.ConfirmPendingEmail(ctx, "pend-1", "new@example.com"), sql.ErrNoRows)
+	assert.ErrorIs(t, repo.SetPendingEmail(ctx, "missing", "x@example.com"), sql.ErrNoRows)
+}
+
+func TestUserConfirmPendingEmail_HeldByDeletedUser(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := sqlite.NewUserRepository(db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, repo, "gone-1", "taken@example.com", "Gone", domain.RoleEmployee, 25)
+	require.NoError(t, repo.Delete(ctx, "gone-1"))
+	testutil.CreateTestUser(t, repo, "pend-1", "old@example.com", "Pending", domain.RoleEmployee, 25)
+
+	// The deleted row still holds the address in the unique index
+	require.NoError(t, repo.SetPendingEmail(ctx, "pend-1", "taken@example.com"))
+	assert.ErrorIs(t, repo.ConfirmPendingEmail(ctx, "pend-1", "taken@example.com"), repository.ErrEmailTaken)
+
+	fetched, err := repo.GetByID(ctx, "pend-1")
+	require.NoError(t, err)
+	assert.Equal(t, "old@example.com", fetched.Email)
+}
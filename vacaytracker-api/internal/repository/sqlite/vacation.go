@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"vacaytracker-api/internal/domain"
@@ -28,8 +29,8 @@ func (r *VacationRepository) GetDB() *DB {
 // Create creates a new vacation request
 func (r *VacationRepository) Create(ctx context.Context, req *domain.VacationRequest) error {
 	query := `
-		INSERT INTO vacation_requests (id, user_id, start_date, end_date, total_days, reason, status)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO vacation_requests (id, user_id, start_date, end_date, total_days, reason, status, type)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		req.ID,
@@ -39,6 +40,7 @@ func (r *VacationRepository) Create(ctx context.Context, req *domain.VacationReq
 		req.TotalDays,
 		req.Reason,
 		req.Status,
+		requestTypeOf(req),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create vacation request: %w", err)
@@ -49,8 +51,8 @@ func (r *VacationRepository) Create(ctx context.Context, req *domain.VacationReq
 // CreateTx creates a new vacation request within a transaction
 func (r *VacationRepository) CreateTx(ctx context.Context, tx *sql.Tx, req *domain.VacationRequest) error {
 	query := `
-		INSERT INTO vacation_requests (id, user_id, start_date, end_date, total_days, reason, status)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO vacation_requests (id, user_id, start_date, end_date, total_days, reason, status, type)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err := tx.ExecContext(ctx, query,
 		req.ID,
@@ -60,6 +62,7 @@ func (r *VacationRepository) CreateTx(ctx context.Context, tx *sql.Tx, req *doma
 		req.TotalDays,
 		req.Reason,
 		req.Status,
+		requestTypeOf(req),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create vacation request: %w", err)
@@ -67,27 +70,72 @@ func (r *VacationRepository) CreateTx(ctx context.Context, tx *sql.Tx, req *doma
 	return nil
 }
 
+// requestTypeOf returns the type to store for req; requests created without one are paid vacation
+func requestTypeOf(req *domain.VacationRequest) domain.RequestType {
+	if req.Type == "" {
+		return domain.RequestTypeVacation
+	}
+	return req.Type
+}
+
 // GetByID retrieves a vacation request by ID with user info
 func (r *VacationRepository) GetByID(ctx context.Context, id string) (*domain.VacationRequest, error) {
 	query := `
 		SELECT vr.id, vr.user_id, u.name, u.email, vr.start_date, vr.end_date, vr.total_days,
-		       vr.reason, vr.status, vr.reviewed_by, vr.reviewed_at, vr.rejection_reason,
-		       vr.created_at, vr.updated_at
+		       vr.reason, vr.status, vr.type, vr.approval_step, vr.reviewed_by, rv.name, vr.reviewed_at, vr.rejection_reason, vr.approval_comment,
+		       vr.balance_override_reason, vr.original_start_date, vr.original_end_date, vr.created_at, vr.updated_at
 		FROM vacation_requests vr
 		JOIN users u ON vr.user_id = u.id
+		LEFT JOIN users rv ON vr.reviewed_by = rv.id
 		WHERE vr.id = ?
 	`
 	return r.scanRequest(r.db.QueryRowContext(ctx, query, id))
 }
 
+// GetByIDs retrieves the vacation requests with the given IDs, newest first; unknown IDs are skipped
+func (r *VacationRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.VacationRequest, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT vr.id, vr.user_id, u.name, u.email, vr.start_date, vr.end_date, vr.total_days,
+		       vr.reason, vr.status, vr.type, vr.approval_step, vr.reviewed_by, rv.name, vr.reviewed_at, vr.rejection_reason, vr.approval_comment,
+		       vr.balance_override_reason, vr.original_start_date, vr.original_end_date, vr.created_at, vr.updated_at
+		FROM vacation_requests vr
+		JOIN users u ON vr.user_id = u.id
+		LEFT JOIN users rv ON vr.reviewed_by = rv.id
+		WHERE vr.id IN (?` + strings.Repeat(", ?", len(ids)-1) + `)
+		ORDER BY vr.created_at DESC
+	`
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	return r.queryRequests(ctx, query, args...)
+}
+
+// leaveYearStartSQL renders the first day of leave year ? using the configured reset month,
+// so with an April reset leave year 2027 runs from 2027-04-01 up to 2028-04-01
+const leaveYearStartSQL = `printf('%04d-%02d-01', ?, COALESCE((SELECT vacation_reset_month FROM settings WHERE id = 'settings'), 1))`
+
+// leaveYearMonthStartSQL renders the first day of a calendar month within a leave year; it takes the year, then the month twice
+// Months before the reset month fall in the following calendar year, so with an April reset
+// February of leave year 2027 starts on 2028-02-01
+const leaveYearMonthStartSQL = `printf('%04d-%02d-01', ? + (? < COALESCE((SELECT vacation_reset_month FROM settings WHERE id = 'settings'), 1)), ?)`
+
 // ListByUser retrieves vacation requests for a specific user
-func (r *VacationRepository) ListByUser(ctx context.Context, userID string, status *domain.VacationStatus, year *int) ([]*domain.VacationRequest, error) {
+// year is a leave year starting in the settings' vacation reset month (a calendar year by default)
+// from and to (YYYY-MM-DD, inclusive) restrict results to requests overlapping that range; "" leaves that side open
+func (r *VacationRepository) ListByUser(ctx context.Context, userID string, status *domain.VacationStatus, year *int, from, to string) ([]*domain.VacationRequest, error) {
 	query := `
 		SELECT vr.id, vr.user_id, u.name, u.email, vr.start_date, vr.end_date, vr.total_days,
-		       vr.reason, vr.status, vr.reviewed_by, vr.reviewed_at, vr.rejection_reason,
-		       vr.created_at, vr.updated_at
+		       vr.reason, vr.status, vr.type, vr.approval_step, vr.reviewed_by, rv.name, vr.reviewed_at, vr.rejection_reason, vr.approval_comment,
+		       vr.balance_override_reason, vr.original_start_date, vr.original_end_date, vr.created_at, vr.updated_at
 		FROM vacation_requests vr
 		JOIN users u ON vr.user_id = u.id
+		LEFT JOIN users rv ON vr.reviewed_by = rv.id
 		WHERE vr.user_id = ?
 	`
 	args := []interface{}{userID}
@@ -98,52 +146,146 @@ func (r *VacationRepository) ListByUser(ctx context.Context, userID string, stat
 	}
 
 	if year != nil {
-		query += " AND strftime('%Y', vr.start_date) = ?"
-		args = append(args, fmt.Sprintf("%d", *year))
+		query += " AND vr.start_date >= " + leaveYearStartSQL + " AND vr.start_date < " + leaveYearStartSQL
+		args = append(args, *year, *year+1)
 	}
 
+	query, args = appendOverlapFilter(query, args, from, to)
+
 	query += " ORDER BY vr.created_at DESC"
 
 	return r.queryRequests(ctx, query, args...)
 }
 
-// ListPending retrieves all pending vacation requests
-func (r *VacationRepository) ListPending(ctx context.Context) ([]*domain.VacationRequest, error) {
+// ListPending retrieves all vacation requests still awaiting an approval decision
+// from and to filter by overlapping dates the same way as ListByUser
+func (r *VacationRepository) ListPending(ctx context.Context, from, to string) ([]*domain.VacationRequest, error) {
 	query := `
 		SELECT vr.id, vr.user_id, u.name, u.email, vr.start_date, vr.end_date, vr.total_days,
-		       vr.reason, vr.status, vr.reviewed_by, vr.reviewed_at, vr.rejection_reason,
-		       vr.created_at, vr.updated_at
+		       vr.reason, vr.status, vr.type, vr.approval_step, vr.reviewed_by, rv.name, vr.reviewed_at, vr.rejection_reason, vr.approval_comment,
+		       vr.balance_override_reason, vr.original_start_date, vr.original_end_date, vr.created_at, vr.updated_at
 		FROM vacation_requests vr
 		JOIN users u ON vr.user_id = u.id
-		WHERE vr.status = 'pending'
+		LEFT JOIN users rv ON vr.reviewed_by = rv.id
+		WHERE vr.status IN ('pending', 'awaiting_final')
+	`
+	query, args := appendOverlapFilter(query, nil, from, to)
+
+	query += " ORDER BY vr.created_at ASC"
+
+	return r.queryRequests(ctx, query, args...)
+}
+
+// ListStalePending retrieves requests still awaiting a decision that were created before createdBefore, oldest first
+func (r *VacationRepository) ListStalePending(ctx context.Context, createdBefore time.Time) ([]*domain.VacationRequest, error) {
+	query := `
+		SELECT vr.id, vr.user_id, u.name, u.email, vr.start_date, vr.end_date, vr.total_days,
+		       vr.reason, vr.status, vr.type, vr.approval_step, vr.reviewed_by, rv.name, vr.reviewed_at, vr.rejection_reason, vr.approval_comment,
+		       vr.balance_override_reason, vr.original_start_date, vr.original_end_date, vr.created_at, vr.updated_at
+		FROM vacation_requests vr
+		JOIN users u ON vr.user_id = u.id
+		LEFT JOIN users rv ON vr.reviewed_by = rv.id
+		WHERE vr.status IN ('pending', 'awaiting_final') AND vr.created_at < ?
 		ORDER BY vr.created_at ASC
 	`
-	return r.queryRequests(ctx, query)
+	return r.queryRequests(ctx, query, createdBefore.UTC().Format("2006-01-02 15:04:05"))
+}
+
+// ListApprovedStartingOn retrieves approved requests whose first day is date (YYYY-MM-DD)
+// and whose start reminder hasn't been sent yet
+func (r *VacationRepository) ListApprovedStartingOn(ctx context.Context, date string) ([]*domain.VacationRequest, error) {
+	query := `
+		SELECT vr.id, vr.user_id, u.name, u.email, vr.start_date, vr.end_date, vr.total_days,
+		       vr.reason, vr.status, vr.type, vr.approval_step, vr.reviewed_by, rv.name, vr.reviewed_at, vr.rejection_reason, vr.approval_comment,
+		       vr.balance_override_reason, vr.original_start_date, vr.original_end_date, vr.created_at, vr.updated_at
+		FROM vacation_requests vr
+		JOIN users u ON vr.user_id = u.id
+		LEFT JOIN users rv ON vr.reviewed_by = rv.id
+		WHERE vr.status = ? AND vr.start_date = ? AND vr.start_reminder_sent_at IS NULL
+		ORDER BY vr.created_at ASC
+	`
+	return r.queryRequests(ctx, query, domain.StatusApproved, date)
+}
+
+// appendOverlapFilter restricts a vacation query to requests overlapping from..to
+// An empty bound leaves that side of the range open
+func appendOverlapFilter(query string, args []interface{}, from, to string) (string, []interface{}) {
+	if to != "" {
+		query += " AND vr.start_date <= ?"
+		args = append(args, to)
+	}
+	if from != "" {
+		query += " AND vr.end_date >= ?"
+		args = append(args, from)
+	}
+	return query, args
+}
+
+// ListCreatedBetween retrieves all vacation requests submitted within a date range (inclusive)
+// Dates are in YYYY-MM-DD format
+func (r *VacationRepository) ListCreatedBetween(ctx context.Context, from, to string) ([]*domain.VacationRequest, error) {
+	query := `
+		SELECT vr.id, vr.user_id, u.name, u.email, vr.start_date, vr.end_date, vr.total_days,
+		       vr.reason, vr.status, vr.type, vr.approval_step, vr.reviewed_by, rv.name, vr.reviewed_at, vr.rejection_reason, vr.approval_comment,
+		       vr.balance_override_reason, vr.original_start_date, vr.original_end_date, vr.created_at, vr.updated_at
+		FROM vacation_requests vr
+		JOIN users u ON vr.user_id = u.id
+		LEFT JOIN users rv ON vr.reviewed_by = rv.id
+		WHERE date(vr.created_at) >= ? AND date(vr.created_at) <= ?
+		ORDER BY vr.created_at ASC
+	`
+	return r.queryRequests(ctx, query, from, to)
 }
 
 // ListTeam retrieves approved vacations for team calendar view
-func (r *VacationRepository) ListTeam(ctx context.Context, month, year int) ([]*domain.TeamVacation, error) {
-	// Get start and end of month
-	startOfMonth := fmt.Sprintf("%d-%02d-01", year, month)
-	endOfMonth := fmt.Sprintf("%d-%02d-31", year, month)
+// An empty teamID lists vacations across the whole company
+func (r *VacationRepository) ListTeam(ctx context.Context, month, year int, teamID string) ([]*domain.TeamVacation, error) {
+	startOfMonth, endOfMonth := monthBounds(month, year)
+	return r.listTeamRange(ctx, startOfMonth, endOfMonth, teamID, false)
+}
+
+// ListTeamWithPending retrieves approved vacations plus requests still under review for team calendar view
+// Each entry's Status tells the two apart; an empty teamID lists vacations across the whole company
+func (r *VacationRepository) ListTeamWithPending(ctx context.Context, month, year int, teamID string) ([]*domain.TeamVacation, error) {
+	startOfMonth, endOfMonth := monthBounds(month, year)
+	return r.listTeamRange(ctx, startOfMonth, endOfMonth, teamID, true)
+}
 
+// ListTeamRange retrieves approved vacations overlapping from..to (YYYY-MM-DD, inclusive)
+// A non-empty teamID limits the results to that team's members
+func (r *VacationRepository) ListTeamRange(ctx context.Context, from, to, teamID string) ([]*domain.TeamVacation, error) {
+	return r.listTeamRange(ctx, from, to, teamID, false)
+}
+
+// monthBounds returns the first and last possible dates of a month as YYYY-MM-DD strings
+func monthBounds(month, year int) (string, string) {
+	return fmt.Sprintf("%d-%02d-01", year, month), fmt.Sprintf("%d-%02d-31", year, month)
+}
+
+// listTeamRange retrieves vacations of active users overlapping from..to, optionally limited to one team
+// Only approved vacations are listed unless includePending also asks for pending and awaiting_final requests
+func (r *VacationRepository) listTeamRange(ctx context.Context, from, to, teamID string, includePending bool) ([]*domain.TeamVacation, error) {
 	query := `
-		SELECT vr.id, vr.user_id, u.name, vr.start_date, vr.end_date, vr.total_days
+		SELECT vr.id, vr.user_id, u.name, vr.start_date, vr.end_date, vr.total_days, vr.status, vr.type
 		FROM vacation_requests vr
 		JOIN users u ON vr.user_id = u.id
-		WHERE vr.status = 'approved'
+		WHERE (vr.status = 'approved' OR (? AND vr.status IN ('pending', 'awaiting_final')))
 		AND (
 			(vr.start_date >= ? AND vr.start_date <= ?)
 			OR (vr.end_date >= ? AND vr.end_date <= ?)
 			OR (vr.start_date <= ? AND vr.end_date >= ?)
 		)
+		AND (? = '' OR u.team_id = ?)
+		AND u.active = 1
 		ORDER BY vr.start_date ASC
 	`
 
 	rows, err := r.db.QueryContext(ctx, query,
-		startOfMonth, endOfMonth,
-		startOfMonth, endOfMonth,
-		startOfMonth, endOfMonth,
+		includePending,
+		from, to,
+		from, to,
+		from, to,
+		teamID, teamID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list team vacations: %w", err)
@@ -153,7 +295,7 @@ func (r *VacationRepository) ListTeam(ctx context.Context, month, year int) ([]*
 	var vacations []*domain.TeamVacation
 	for rows.Next() {
 		var v domain.TeamVacation
-		if err := rows.Scan(&v.ID, &v.UserID, &v.UserName, &v.StartDate, &v.EndDate, &v.TotalDays); err != nil {
+		if err := rows.Scan(&v.ID, &v.UserID, &v.UserName, &v.StartDate, &v.EndDate, &v.TotalDays, &v.Status, &v.Type); err != nil {
 			return nil, fmt.Errorf("failed to scan team vacation: %w", err)
 		}
 		vacations = append(vacations, &v)
@@ -166,15 +308,50 @@ func (r *VacationRepository) ListTeam(ctx context.Context, month, year int) ([]*
 	return vacations, nil
 }
 
-// UpdateStatus updates the status of a vacation request
+// ListUpcomingApproved retrieves approved vacations of active users starting between from and to (inclusive)
+func (r *VacationRepository) ListUpcomingApproved(ctx context.Context, from, to string) ([]*domain.TeamVacation, error) {
+	query := `
+		SELECT vr.id, vr.user_id, u.name, vr.start_date, vr.end_date, vr.total_days, vr.status, vr.type
+		FROM vacation_requests vr
+		JOIN users u ON vr.user_id = u.id
+		WHERE vr.status = 'approved'
+		AND vr.start_date >= ? AND vr.start_date <= ?
+		AND u.active = 1 AND u.deleted_at IS NULL
+		ORDER BY vr.start_date ASC, u.name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upcoming vacations: %w", err)
+	}
+	defer rows.Close()
+
+	var vacations []*domain.TeamVacation
+	for rows.Next() {
+		var v domain.TeamVacation
+		if err := rows.Scan(&v.ID, &v.UserID, &v.UserName, &v.StartDate, &v.EndDate, &v.TotalDays, &v.Status, &v.Type); err != nil {
+			return nil, fmt.Errorf("failed to scan upcoming vacation: %w", err)
+		}
+		vacations = append(vacations, &v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating upcoming vacations: %w", err)
+	}
+
+	return vacations, nil
+}
+
+// UpdateStatus records a review decision on a vacation request that is still under review
+// Returns repository.ErrNotUnderReview when the request was already decided or doesn't exist
 func (r *VacationRepository) UpdateStatus(ctx context.Context, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
 	query := `
 		UPDATE vacation_requests
 		SET status = ?, reviewed_by = ?, reviewed_at = ?, rejection_reason = ?
-		WHERE id = ?
+		WHERE id = ? AND status IN (?, ?)
 	`
-	result, err := r.db.ExecContext(ctx, query, status, reviewedBy, now, rejectionReason, id)
+	result, err := r.db.ExecContext(ctx, query, status, reviewedBy, now, rejectionReason, id, domain.StatusPending, domain.StatusAwaitingFinal)
 	if err != nil {
 		return fmt.Errorf("failed to update vacation status: %w", err)
 	}
@@ -184,20 +361,20 @@ func (r *VacationRepository) UpdateStatus(ctx context.Context, id string, status
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("vacation request not found")
+		return repository.ErrNotUnderReview
 	}
 	return nil
 }
 
-// UpdateStatusTx updates the status of a vacation request within a transaction
+// UpdateStatusTx records a review decision within a transaction; see UpdateStatus
 func (r *VacationRepository) UpdateStatusTx(ctx context.Context, tx *sql.Tx, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
 	query := `
 		UPDATE vacation_requests
 		SET status = ?, reviewed_by = ?, reviewed_at = ?, rejection_reason = ?
-		WHERE id = ?
+		WHERE id = ? AND status IN (?, ?)
 	`
-	result, err := tx.ExecContext(ctx, query, status, reviewedBy, now, rejectionReason, id)
+	result, err := tx.ExecContext(ctx, query, status, reviewedBy, now, rejectionReason, id, domain.StatusPending, domain.StatusAwaitingFinal)
 	if err != nil {
 		return fmt.Errorf("failed to update vacation status: %w", err)
 	}
@@ -207,7 +384,142 @@ func (r *VacationRepository) UpdateStatusTx(ctx context.Context, tx *sql.Tx, id
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("vacation request not found")
+		return repository.ErrNotUnderReview
+	}
+	return nil
+}
+
+// AdvanceApprovalStep moves a request from the level before step to step of the approval chain
+// Returns repository.ErrNotUnderReview when the request was decided or advanced in the meantime, or doesn't exist
+func (r *VacationRepository) AdvanceApprovalStep(ctx context.Context, id string, step int, status domain.VacationStatus, reviewedBy string, comment *string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	query := `
+		UPDATE vacation_requests
+		SET status = ?, approval_step = ?, reviewed_by = ?, reviewed_at = ?, approval_comment = COALESCE(?, approval_comment)
+		WHERE id = ? AND status IN (?, ?) AND approval_step = ?
+	`
+	result, err := r.db.ExecContext(ctx, query, status, step, reviewedBy, now, comment,
+		id, domain.StatusPending, domain.StatusAwaitingFinal, step-1)
+	if err != nil {
+		return fmt.Errorf("failed to advance approval step: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return repository.ErrNotUnderReview
+	}
+	return nil
+}
+
+// UpdateApprovalCommentTx stores the approver's comment within a transaction
+func (r *VacationRepository) UpdateApprovalCommentTx(ctx context.Context, tx *sql.Tx, id string, comment string) error {
+	_, err := tx.ExecContext(ctx, "UPDATE vacation_requests SET approval_comment = ? WHERE id = ?", comment, id)
+	if err != nil {
+		return fmt.Errorf("failed to update approval comment: %w", err)
+	}
+	return nil
+}
+
+// UpdateBalanceOverrideTx records why a request was approved despite insufficient balance within a transaction
+func (r *VacationRepository) UpdateBalanceOverrideTx(ctx context.Context, tx *sql.Tx, id string, reason string) error {
+	_, err := tx.ExecContext(ctx, "UPDATE vacation_requests SET balance_override_reason = ? WHERE id = ?", reason, id)
+	if err != nil {
+		return fmt.Errorf("failed to update balance override: %w", err)
+	}
+	return nil
+}
+
+// AdjustDatesTx narrows a request under review to the approved range within a transaction
+// The submitted range is kept in original_start_date/original_end_date; returns repository.ErrNotUnderReview
+// when the request was decided in the meantime or doesn't exist
+func (r *VacationRepository) AdjustDatesTx(ctx context.Context, tx *sql.Tx, id, startDate, endDate string, totalDays int) error {
+	query := `
+		UPDATE vacation_requests
+		SET original_start_date = COALESCE(original_start_date, start_date),
+		    original_end_date = COALESCE(original_end_date, end_date),
+		    start_date = ?, end_date = ?, total_days = ?
+		WHERE id = ? AND status IN (?, ?)
+	`
+	result, err := tx.ExecContext(ctx, query, startDate, endDate, totalDays, id, domain.StatusPending, domain.StatusAwaitingFinal)
+	if err != nil {
+		return fmt.Errorf("failed to adjust request dates: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return repository.ErrNotUnderReview
+	}
+	return nil
+}
+
+// ClaimStartReminder marks the start reminder of a request as sent
+// Returns false when it was already claimed, so only one caller sends the email
+func (r *VacationRepository) ClaimStartReminder(ctx context.Context, id string) (bool, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	query := `
+		UPDATE vacation_requests SET start_reminder_sent_at = ?
+		WHERE id = ? AND start_reminder_sent_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, now, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim start reminder: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// PromoteTentativeTx turns a tentative request into a submitted one within a transaction
+// totalDays is recalculated at submission time since settings may have changed
+func (r *VacationRepository) PromoteTentativeTx(ctx context.Context, tx *sql.Tx, id string, status domain.VacationStatus, totalDays int) error {
+	query := `
+		UPDATE vacation_requests
+		SET status = ?, total_days = ?
+		WHERE id = ? AND status = 'tentative'
+	`
+	result, err := tx.ExecContext(ctx, query, status, totalDays, id)
+	if err != nil {
+		return fmt.Errorf("failed to submit tentative request: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("tentative vacation request not found")
+	}
+	return nil
+}
+
+// UpdateTotalDays stores a recomputed total for a request that is still under review
+// Returns repository.ErrNotUnderReview when the request was decided in the meantime or doesn't exist
+func (r *VacationRepository) UpdateTotalDays(ctx context.Context, id string, totalDays int) error {
+	query := `
+		UPDATE vacation_requests
+		SET total_days = ?
+		WHERE id = ? AND status IN (?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query, totalDays, id, domain.StatusPending, domain.StatusAwaitingFinal)
+	if err != nil {
+		return fmt.Errorf("failed to update total days: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return repository.ErrNotUnderReview
 	}
 	return nil
 }
@@ -229,24 +541,41 @@ func (r *VacationRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// GetMonthlyStats returns aggregated statistics for vacation requests in a specific month
-func (r *VacationRepository) GetMonthlyStats(ctx context.Context, year, month int) (*repository.MonthlyStats, error) {
-	yearStr := fmt.Sprintf("%d", year)
-	monthStr := fmt.Sprintf("%02d", month)
+// DeleteTx deletes a vacation request within a transaction
+func (r *VacationRepository) DeleteTx(ctx context.Context, tx *sql.Tx, id string) error {
+	result, err := tx.ExecContext(ctx, "DELETE FROM vacation_requests WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete vacation request: %w", err)
+	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("vacation request not found")
+	}
+	return nil
+}
+
+// GetMonthlyStats returns aggregated statistics for vacation requests created in a calendar month of a leave year
+// A non-empty teamID limits the statistics to that team's members
+func (r *VacationRepository) GetMonthlyStats(ctx context.Context, year, month int, teamID string) (*repository.MonthlyStats, error) {
 	query := `
 		SELECT
 			COUNT(*) as total,
 			COALESCE(SUM(CASE WHEN status = 'approved' THEN 1 ELSE 0 END), 0) as approved,
 			COALESCE(SUM(CASE WHEN status = 'rejected' THEN 1 ELSE 0 END), 0) as rejected,
-			COALESCE(SUM(CASE WHEN status = 'pending' THEN 1 ELSE 0 END), 0) as pending,
-			COALESCE(SUM(CASE WHEN status = 'approved' THEN total_days ELSE 0 END), 0) as days_used
+			COALESCE(SUM(CASE WHEN status IN ('pending', 'awaiting_final') THEN 1 ELSE 0 END), 0) as pending,
+			COALESCE(SUM(CASE WHEN status = 'approved' AND type = 'vacation' THEN total_days ELSE 0 END), 0) as days_used
 		FROM vacation_requests
-		WHERE strftime('%Y', created_at) = ? AND strftime('%m', created_at) = ?
+		WHERE created_at >= ` + leaveYearMonthStartSQL + ` AND created_at < date(` + leaveYearMonthStartSQL + `, '+1 month')
+		AND status != 'tentative'
+		AND (? = '' OR user_id IN (SELECT id FROM users WHERE team_id = ?))
 	`
 
 	var stats repository.MonthlyStats
-	err := r.db.QueryRowContext(ctx, query, yearStr, monthStr).Scan(
+	err := r.db.QueryRowContext(ctx, query, year, month, month, year, month, month, teamID, teamID).Scan(
 		&stats.TotalSubmitted,
 		&stats.TotalApproved,
 		&stats.TotalRejected,
@@ -260,25 +589,126 @@ func (r *VacationRepository) GetMonthlyStats(ctx context.Context, year, month in
 	return &stats, nil
 }
 
-// HasOverlap checks if a user has any pending or approved vacation requests that overlap with the given date range
-func (r *VacationRepository) HasOverlap(ctx context.Context, userID, startDate, endDate string) (bool, error) {
+// GetYearlyStats returns aggregated statistics for vacation requests created in a specific leave year
+// Figures match GetMonthlyStats for each month, so the yearly totals equal the sum of the twelve months;
+// Months is indexed by calendar month whichever month the leave year starts in
+func (r *VacationRepository) GetYearlyStats(ctx context.Context, year int) (*repository.YearlyStats, error) {
+	query := `
+		SELECT
+			CAST(strftime('%m', created_at) AS INTEGER) as month,
+			COUNT(*) as total,
+			COALESCE(SUM(CASE WHEN status = 'approved' THEN 1 ELSE 0 END), 0) as approved,
+			COALESCE(SUM(CASE WHEN status = 'rejected' THEN 1 ELSE 0 END), 0) as rejected,
+			COALESCE(SUM(CASE WHEN status IN ('pending', 'awaiting_final') THEN 1 ELSE 0 END), 0) as pending,
+			COALESCE(SUM(CASE WHEN status = 'approved' AND type = 'vacation' THEN total_days ELSE 0 END), 0) as days_used
+		FROM vacation_requests
+		WHERE created_at >= ` + leaveYearStartSQL + ` AND created_at < ` + leaveYearStartSQL + `
+		AND status != 'tentative'
+		GROUP BY month
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, year, year+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get yearly stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats repository.YearlyStats
+	for rows.Next() {
+		var month int
+		var m repository.MonthlyStats
+		if err := rows.Scan(&month, &m.TotalSubmitted, &m.TotalApproved, &m.TotalRejected, &m.TotalPending, &m.TotalDaysUsed); err != nil {
+			return nil, fmt.Errorf("failed to scan yearly stats: %w", err)
+		}
+		if month < 1 || month > 12 {
+			continue
+		}
+		stats.Months[month-1] = m
+		stats.TotalSubmitted += m.TotalSubmitted
+		stats.TotalApproved += m.TotalApproved
+		stats.TotalRejected += m.TotalRejected
+		stats.TotalPending += m.TotalPending
+		stats.TotalDaysUsed += m.TotalDaysUsed
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate yearly stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// GetUserYearStats returns a user's vacation request statistics for requests starting in the given leave year
+// Tentative requests are not counted; days used are approved vacation days, attributed to the start month
+func (r *VacationRepository) GetUserYearStats(ctx context.Context, userID string, year int) (*repository.UserYearStats, error) {
+	query := `
+		SELECT
+			CAST(strftime('%m', start_date) AS INTEGER) as month,
+			COUNT(*) as total,
+			COALESCE(SUM(CASE WHEN status = 'approved' THEN 1 ELSE 0 END), 0) as approved,
+			COALESCE(SUM(CASE WHEN status = 'rejected' THEN 1 ELSE 0 END), 0) as rejected,
+			COALESCE(SUM(CASE WHEN status IN ('pending', 'awaiting_final') THEN 1 ELSE 0 END), 0) as pending,
+			COALESCE(SUM(CASE WHEN status = 'approved' AND type = 'vacation' THEN total_days ELSE 0 END), 0) as days_used
+		FROM vacation_requests
+		WHERE user_id = ? AND start_date >= ` + leaveYearStartSQL + ` AND start_date < ` + leaveYearStartSQL + `
+		AND status != 'tentative'
+		GROUP BY month
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, year, year+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user year stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats repository.UserYearStats
+	for rows.Next() {
+		var month, total, approved, rejected, pending, daysUsed int
+		if err := rows.Scan(&month, &total, &approved, &rejected, &pending, &daysUsed); err != nil {
+			return nil, fmt.Errorf("failed to scan user year stats: %w", err)
+		}
+		stats.TotalRequested += total
+		stats.TotalApproved += approved
+		stats.TotalRejected += rejected
+		stats.TotalPending += pending
+		stats.TotalDaysUsed += daysUsed
+		if month >= 1 && month <= 12 {
+			stats.DaysByMonth[month-1] = daysUsed
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate user year stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// HasOverlap checks if a user has any open or approved vacation requests that overlap with the given date range
+// Dates are inclusive, so a request sharing any day with the range overlaps it, as that day would be deducted twice;
+// requests touching the range on consecutive days (ending the day before it starts or starting the day after it ends)
+// only overlap it without allowTouching
+// Only requests of the given types count; nil counts every type
+func (r *VacationRepository) HasOverlap(ctx context.Context, userID, startDate, endDate string, allowTouching bool, types []domain.RequestType) (bool, error) {
+	startMargin, endMargin := "-1 day", "+1 day"
+	if allowTouching {
+		startMargin, endMargin = "+0 days", "+0 days"
+	}
+
 	query := `
 		SELECT COUNT(*) FROM vacation_requests
 		WHERE user_id = ?
-		AND status IN ('pending', 'approved')
-		AND (
-			(start_date <= ? AND end_date >= ?)
-			OR (start_date <= ? AND end_date >= ?)
-			OR (start_date >= ? AND end_date <= ?)
-		)
+		AND status IN ('pending', 'awaiting_final', 'approved')
+		AND start_date <= date(?, ?) AND end_date >= date(?, ?)
 	`
+	args := []interface{}{userID, endDate, endMargin, startDate, startMargin}
+	if len(types) > 0 {
+		query += " AND type IN (?" + strings.Repeat(", ?", len(types)-1) + ")"
+		for _, t := range types {
+			args = append(args, t)
+		}
+	}
+
 	var count int
-	err := r.db.QueryRowContext(ctx, query,
-		userID,
-		endDate, startDate,
-		startDate, startDate,
-		startDate, endDate,
-	).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check for overlapping requests: %w", err)
 	}
@@ -288,7 +718,7 @@ func (r *VacationRepository) HasOverlap(ctx context.Context, userID, startDate,
 // scanRequest scans a single row into a VacationRequest
 func (r *VacationRepository) scanRequest(row *sql.Row) (*domain.VacationRequest, error) {
 	var req domain.VacationRequest
-	var reason, reviewedBy, rejectionReason sql.NullString
+	var reason, reviewedBy, reviewedByName, rejectionReason, approvalComment, balanceOverrideReason, originalStart, originalEnd sql.NullString
 	var reviewedAt sql.NullString
 	var createdAt, updatedAt string
 
@@ -302,9 +732,16 @@ func (r *VacationRepository) scanRequest(row *sql.Row) (*domain.VacationRequest,
 		&req.TotalDays,
 		&reason,
 		&req.Status,
+		&req.Type,
+		&req.ApprovalStep,
 		&reviewedBy,
+		&reviewedByName,
 		&reviewedAt,
 		&rejectionReason,
+		&approvalComment,
+		&balanceOverrideReason,
+		&originalStart,
+		&originalEnd,
 		&createdAt,
 		&updatedAt,
 	)
@@ -321,6 +758,7 @@ func (r *VacationRepository) scanRequest(row *sql.Row) (*domain.VacationRequest,
 	if reviewedBy.Valid {
 		req.ReviewedBy = &reviewedBy.String
 	}
+	req.ReviewedByName = reviewedByName.String
 	if reviewedAt.Valid {
 		t, _ := time.Parse(time.RFC3339, reviewedAt.String)
 		req.ReviewedAt = &t
@@ -328,8 +766,18 @@ func (r *VacationRepository) scanRequest(row *sql.Row) (*domain.VacationRequest,
 	if rejectionReason.Valid {
 		req.RejectionReason = &rejectionReason.String
 	}
-	req.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-	req.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	if approvalComment.Valid {
+		req.ApprovalComment = &approvalComment.String
+	}
+	if balanceOverrideReason.Valid {
+		req.BalanceOverrideReason = &balanceOverrideReason.String
+	}
+	if originalStart.Valid && originalEnd.Valid {
+		req.OriginalStartDate = &originalStart.String
+		req.OriginalEndDate = &originalEnd.String
+	}
+	req.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+	req.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
 
 	return &req, nil
 }
@@ -345,7 +793,7 @@ func (r *VacationRepository) queryRequests(ctx context.Context, query string, ar
 	var requests []*domain.VacationRequest
 	for rows.Next() {
 		var req domain.VacationRequest
-		var reason, reviewedBy, rejectionReason sql.NullString
+		var reason, reviewedBy, reviewedByName, rejectionReason, approvalComment, balanceOverrideReason, originalStart, originalEnd sql.NullString
 		var reviewedAt sql.NullString
 		var createdAt, updatedAt string
 
@@ -359,9 +807,16 @@ func (r *VacationRepository) queryRequests(ctx context.Context, query string, ar
 			&req.TotalDays,
 			&reason,
 			&req.Status,
+			&req.Type,
+			&req.ApprovalStep,
 			&reviewedBy,
+			&reviewedByName,
 			&reviewedAt,
 			&rejectionReason,
+			&approvalComment,
+			&balanceOverrideReason,
+			&originalStart,
+			&originalEnd,
 			&createdAt,
 			&updatedAt,
 		)
@@ -375,6 +830,7 @@ func (r *VacationRepository) queryRequests(ctx context.Context, query string, ar
 		if reviewedBy.Valid {
 			req.ReviewedBy = &reviewedBy.String
 		}
+		req.ReviewedByName = reviewedByName.String
 		if reviewedAt.Valid {
 			t, _ := time.Parse(time.RFC3339, reviewedAt.String)
 			req.ReviewedAt = &t
@@ -382,8 +838,18 @@ func (r *VacationRepository) queryRequests(ctx context.Context, query string, ar
 		if rejectionReason.Valid {
 			req.RejectionReason = &rejectionReason.String
 		}
-		req.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-		req.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+		if approvalComment.Valid {
+			req.ApprovalComment = &approvalComment.String
+		}
+		if balanceOverrideReason.Valid {
+			req.BalanceOverrideReason = &balanceOverrideReason.String
+		}
+		if originalStart.Valid && originalEnd.Valid {
+			req.OriginalStartDate = &originalStart.String
+			req.OriginalEndDate = &originalEnd.String
+		}
+		req.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		req.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
 
 		requests = append(requests, &req)
 	}
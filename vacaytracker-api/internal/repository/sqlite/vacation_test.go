@@ -3,6 +3,7 @@ package sqlite_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -113,6 +114,30 @@ func TestVacationGetByID_NotFound(t *testing.T) {
 	assert.Nil(t, got)
 }
 
+func TestVacationGetByIDs(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "user2", "bob@test.com", "Bob", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "vac1", "user1", "2027-06-14", "2027-06-18", 5, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "vac2", "user2", "2027-07-05", "2027-07-06", 2, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "vac3", "user1", "2027-08-02", "2027-08-02", 1, domain.StatusPending)
+
+	got, err := vacRepo.GetByIDs(ctx, []string{"vac1", "vac2", "nonexistent"})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	ids := []string{got[0].ID, got[1].ID}
+	assert.ElementsMatch(t, []string{"vac1", "vac2"}, ids)
+	for _, req := range got {
+		assert.NotEmpty(t, req.UserName)
+	}
+
+	got, err = vacRepo.GetByIDs(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
 // ---------------------------------------------------------------------------
 // 4. CreateTx
 // ---------------------------------------------------------------------------
@@ -162,7 +187,7 @@ func TestVacationListByUser_NoFilters(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "v2", "user1", "2027-02-10", "2027-02-14", 5, domain.StatusApproved)
 	testutil.CreateTestVacation(t, vacRepo, "v3", "user1", "2027-03-10", "2027-03-12", 3, domain.StatusRejected)
 
-	results, err := vacRepo.ListByUser(ctx, "user1", nil, nil)
+	results, err := vacRepo.ListByUser(ctx, "user1", nil, nil, "", "")
 	require.NoError(t, err)
 	require.Len(t, results, 3)
 
@@ -191,7 +216,7 @@ func TestVacationListByUser_FilterByStatus(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "v2", "user1", "2027-02-10", "2027-02-14", 5, domain.StatusApproved)
 	testutil.CreateTestVacation(t, vacRepo, "v3", "user1", "2027-03-10", "2027-03-12", 3, domain.StatusPending)
 
-	results, err := vacRepo.ListByUser(ctx, "user1", statusPtr(domain.StatusPending), nil)
+	results, err := vacRepo.ListByUser(ctx, "user1", statusPtr(domain.StatusPending), nil, "", "")
 	require.NoError(t, err)
 	require.Len(t, results, 2)
 
@@ -213,12 +238,56 @@ func TestVacationListByUser_FilterByYear(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "v2027", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusPending)
 	testutil.CreateTestVacation(t, vacRepo, "v2028", "user1", "2028-06-01", "2028-06-05", 5, domain.StatusPending)
 
-	results, err := vacRepo.ListByUser(ctx, "user1", nil, intPtr(2027))
+	results, err := vacRepo.ListByUser(ctx, "user1", nil, intPtr(2027), "", "")
 	require.NoError(t, err)
 	require.Len(t, results, 1)
 	assert.Equal(t, "v2027", results[0].ID)
 }
 
+func TestVacationListByUser_FilterByLeaveYear(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	settings := domain.DefaultSettings()
+	settings.VacationResetMonth = 4
+	require.NoError(t, sqlite.NewSettingsRepository(db).Update(ctx, &settings))
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+
+	testutil.CreateTestVacation(t, vacRepo, "before", "user1", "2027-03-29", "2027-03-31", 3, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "first", "user1", "2027-04-01", "2027-04-02", 2, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "last", "user1", "2028-03-27", "2028-03-31", 5, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "after", "user1", "2028-04-03", "2028-04-04", 2, domain.StatusPending)
+
+	results, err := vacRepo.ListByUser(ctx, "user1", nil, intPtr(2027), "", "")
+	require.NoError(t, err)
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	assert.ElementsMatch(t, []string{"first", "last"}, ids)
+}
+
+func TestVacationListByUser_IncludesReviewerName(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "admin1", "admin@test.com", "Admin", domain.RoleAdmin, 25)
+	testutil.CreateTestVacation(t, vacRepo, "reviewed", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "open", "user1", "2027-07-01", "2027-07-02", 2, domain.StatusPending)
+	require.NoError(t, vacRepo.UpdateStatus(ctx, "reviewed", domain.StatusApproved, "admin1", nil))
+
+	results, err := vacRepo.ListByUser(ctx, "user1", nil, nil, "", "")
+	require.NoError(t, err)
+	names := map[string]string{}
+	for _, r := range results {
+		names[r.ID] = r.ReviewedByName
+	}
+	assert.Equal(t, "Admin", names["reviewed"])
+	assert.Empty(t, names["open"])
+}
+
 // ---------------------------------------------------------------------------
 // 8. ListByUser both filters
 // ---------------------------------------------------------------------------
@@ -233,12 +302,53 @@ func TestVacationListByUser_BothFilters(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "v2", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusPending)
 	testutil.CreateTestVacation(t, vacRepo, "v3", "user1", "2028-03-01", "2028-03-03", 3, domain.StatusApproved)
 
-	results, err := vacRepo.ListByUser(ctx, "user1", statusPtr(domain.StatusApproved), intPtr(2027))
+	results, err := vacRepo.ListByUser(ctx, "user1", statusPtr(domain.StatusApproved), intPtr(2027), "", "")
 	require.NoError(t, err)
 	require.Len(t, results, 1)
 	assert.Equal(t, "v1", results[0].ID)
 }
 
+func TestVacationListByUser_DateRange(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+
+	testutil.CreateTestVacation(t, vacRepo, "july", "user1", "2027-07-26", "2027-08-02", 6, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "august", "user1", "2027-08-16", "2027-08-20", 5, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "september", "user1", "2027-09-01", "2027-09-03", 3, domain.StatusPending)
+
+	// Everything overlapping August
+	results, err := vacRepo.ListByUser(ctx, "user1", nil, nil, "2027-08-01", "2027-08-31")
+	require.NoError(t, err)
+	ids := []string{}
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	assert.ElementsMatch(t, []string{"july", "august"}, ids)
+
+	// Open-ended ranges and combination with the status filter
+	results, err = vacRepo.ListByUser(ctx, "user1", statusPtr(domain.StatusPending), nil, "2027-08-21", "")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "september", results[0].ID)
+}
+
+func TestVacationListPending_DateRange(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+
+	testutil.CreateTestVacation(t, vacRepo, "vp1", "user1", "2027-04-01", "2027-04-03", 3, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "vp2", "user1", "2027-05-01", "2027-05-03", 3, domain.StatusPending)
+
+	results, err := vacRepo.ListPending(ctx, "", "2027-04-30")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "vp1", results[0].ID)
+}
+
 // ---------------------------------------------------------------------------
 // 9. ListByUser empty result
 // ---------------------------------------------------------------------------
@@ -249,7 +359,7 @@ func TestVacationListByUser_Empty(t *testing.T) {
 
 	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
 
-	results, err := vacRepo.ListByUser(ctx, "user1", nil, nil)
+	results, err := vacRepo.ListByUser(ctx, "user1", nil, nil, "", "")
 	require.NoError(t, err)
 	assert.Empty(t, results)
 }
@@ -267,7 +377,7 @@ func TestVacationListPending(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "vp1", "user1", "2027-04-01", "2027-04-03", 3, domain.StatusPending)
 	testutil.CreateTestVacation(t, vacRepo, "vp2", "user1", "2027-05-01", "2027-05-03", 3, domain.StatusPending)
 
-	results, err := vacRepo.ListPending(ctx)
+	results, err := vacRepo.ListPending(ctx, "", "")
 	require.NoError(t, err)
 	require.Len(t, results, 2)
 
@@ -276,6 +386,64 @@ func TestVacationListPending(t *testing.T) {
 	assert.Equal(t, "vp2", results[1].ID)
 }
 
+func TestVacationListStalePending(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+
+	testutil.CreateTestVacation(t, vacRepo, "old", "user1", "2027-04-01", "2027-04-03", 3, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "older", "user1", "2027-05-01", "2027-05-03", 3, domain.StatusAwaitingFinal)
+	testutil.CreateTestVacation(t, vacRepo, "fresh", "user1", "2027-06-01", "2027-06-03", 3, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "decided", "user1", "2027-07-01", "2027-07-03", 3, domain.StatusApproved)
+
+	now := time.Now().UTC()
+	backdate := func(id string, age time.Duration) {
+		_, err := db.ExecContext(ctx, "UPDATE vacation_requests SET created_at = ? WHERE id = ?", now.Add(-age).Format("2006-01-02 15:04:05"), id)
+		require.NoError(t, err)
+	}
+	backdate("old", 10*24*time.Hour)
+	backdate("older", 20*24*time.Hour)
+	backdate("decided", 30*24*time.Hour)
+
+	results, err := vacRepo.ListStalePending(ctx, now.AddDate(0, 0, -7))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// Oldest first; fresh and decided requests are left out
+	assert.Equal(t, "older", results[0].ID)
+	assert.Equal(t, "old", results[1].ID)
+}
+
+func TestVacationListApprovedStartingOn_SkipsReminded(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+
+	testutil.CreateTestVacation(t, vacRepo, "tomorrow", "user1", "2027-06-14", "2027-06-18", 5, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "pending", "user1", "2027-06-14", "2027-06-14", 1, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "later", "user1", "2027-06-21", "2027-06-21", 1, domain.StatusApproved)
+
+	results, err := vacRepo.ListApprovedStartingOn(ctx, "2027-06-14")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "tomorrow", results[0].ID)
+
+	claimed, err := vacRepo.ClaimStartReminder(ctx, "tomorrow")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// A second claim, e.g. after a restart, loses and the request is no longer listed
+	claimed, err = vacRepo.ClaimStartReminder(ctx, "tomorrow")
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	results, err = vacRepo.ListApprovedStartingOn(ctx, "2027-06-14")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
 // ---------------------------------------------------------------------------
 // 11. ListPending excludes approved/rejected
 // ---------------------------------------------------------------------------
@@ -290,13 +458,39 @@ func TestVacationListPending_ExcludesNonPending(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "va", "user1", "2027-05-01", "2027-05-03", 3, domain.StatusApproved)
 	testutil.CreateTestVacation(t, vacRepo, "vr", "user1", "2027-06-01", "2027-06-03", 3, domain.StatusRejected)
 
-	results, err := vacRepo.ListPending(ctx)
+	results, err := vacRepo.ListPending(ctx, "", "")
 	require.NoError(t, err)
 	require.Len(t, results, 1)
 	assert.Equal(t, "vp", results[0].ID)
 	assert.Equal(t, domain.StatusPending, results[0].Status)
 }
 
+// ---------------------------------------------------------------------------
+// 11b. ListCreatedBetween
+// ---------------------------------------------------------------------------
+
+func TestVacationListCreatedBetween(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+
+	testutil.CreateTestVacation(t, vacRepo, "vp", "user1", "2027-04-01", "2027-04-03", 3, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "va", "user1", "2027-05-01", "2027-05-03", 3, domain.StatusApproved)
+
+	today := time.Now().UTC().Format("2006-01-02")
+	results, err := vacRepo.ListCreatedBetween(ctx, today, today)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "User", results[0].UserName)
+	assert.False(t, results[0].CreatedAt.IsZero())
+
+	// Range entirely in the past returns nothing
+	results, err = vacRepo.ListCreatedBetween(ctx, "2000-01-01", "2000-12-31")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
 // ---------------------------------------------------------------------------
 // 12. ListTeam
 // ---------------------------------------------------------------------------
@@ -313,7 +507,7 @@ func TestVacationListTeam(t *testing.T) {
 	// Approved vacation within June 2027 for another user
 	testutil.CreateTestVacation(t, vacRepo, "v2", "user2", "2027-06-20", "2027-06-25", 5, domain.StatusApproved)
 
-	results, err := vacRepo.ListTeam(ctx, 6, 2027)
+	results, err := vacRepo.ListTeam(ctx, 6, 2027, "")
 	require.NoError(t, err)
 	require.Len(t, results, 2)
 
@@ -338,18 +532,58 @@ func TestVacationListTeam_CrossMonthSpanning(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "vspan", "user1", "2027-06-28", "2027-07-05", 6, domain.StatusApproved)
 
 	// Should appear in June
-	juneResults, err := vacRepo.ListTeam(ctx, 6, 2027)
+	juneResults, err := vacRepo.ListTeam(ctx, 6, 2027, "")
 	require.NoError(t, err)
 	require.Len(t, juneResults, 1)
 	assert.Equal(t, "vspan", juneResults[0].ID)
 
 	// Should also appear in July
-	julyResults, err := vacRepo.ListTeam(ctx, 7, 2027)
+	julyResults, err := vacRepo.ListTeam(ctx, 7, 2027, "")
 	require.NoError(t, err)
 	require.Len(t, julyResults, 1)
 	assert.Equal(t, "vspan", julyResults[0].ID)
 }
 
+func TestVacationListTeamRange(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "before", "user1", "2027-05-03", "2027-05-07", 5, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "edge", "user1", "2027-05-31", "2027-06-02", 3, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "inside", "user1", "2027-06-14", "2027-06-18", 5, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "pending", "user1", "2027-06-21", "2027-06-22", 2, domain.StatusPending)
+
+	results, err := vacRepo.ListTeamRange(ctx, "2027-06-01", "2027-06-30", "")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "edge", results[0].ID)
+	assert.Equal(t, "inside", results[1].ID)
+}
+
+func TestVacationListUpcomingApproved(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "user2", "b@test.com", "Bob", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "gone", "c@test.com", "Carol", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "started", "user1", "2027-05-28", "2027-06-03", 5, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "later", "user1", "2027-06-10", "2027-06-11", 2, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "first", "user2", "2027-06-01", "2027-06-02", 2, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "pending", "user2", "2027-06-07", "2027-06-08", 2, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "beyond", "user2", "2027-06-16", "2027-06-17", 2, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "deleted", "gone", "2027-06-04", "2027-06-04", 1, domain.StatusApproved)
+	require.NoError(t, userRepo.Delete(ctx, "gone"))
+
+	results, err := vacRepo.ListUpcomingApproved(ctx, "2027-06-01", "2027-06-15")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "first", results[0].ID)
+	assert.Equal(t, "Bob", results[0].UserName)
+	assert.Equal(t, "later", results[1].ID)
+}
+
 // ---------------------------------------------------------------------------
 // 14. ListTeam excludes non-approved
 // ---------------------------------------------------------------------------
@@ -364,10 +598,34 @@ func TestVacationListTeam_ExcludesNonApproved(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "vp", "user1", "2027-06-18", "2027-06-20", 3, domain.StatusPending)
 	testutil.CreateTestVacation(t, vacRepo, "vr", "user1", "2027-06-22", "2027-06-25", 4, domain.StatusRejected)
 
-	results, err := vacRepo.ListTeam(ctx, 6, 2027)
+	results, err := vacRepo.ListTeam(ctx, 6, 2027, "")
 	require.NoError(t, err)
 	require.Len(t, results, 1)
 	assert.Equal(t, "va", results[0].ID)
+	assert.Equal(t, domain.StatusApproved, results[0].Status)
+}
+
+func TestVacationListTeamWithPending(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+
+	testutil.CreateTestVacation(t, vacRepo, "va", "user1", "2027-06-10", "2027-06-15", 5, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "vp", "user1", "2027-06-18", "2027-06-20", 3, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "vf", "user1", "2027-06-21", "2027-06-21", 1, domain.StatusAwaitingFinal)
+	testutil.CreateTestVacation(t, vacRepo, "vr", "user1", "2027-06-22", "2027-06-25", 4, domain.StatusRejected)
+	testutil.CreateTestVacation(t, vacRepo, "vt", "user1", "2027-06-28", "2027-06-29", 2, domain.StatusTentative)
+
+	results, err := vacRepo.ListTeamWithPending(ctx, 6, 2027, "")
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "va", results[0].ID)
+	assert.Equal(t, domain.StatusApproved, results[0].Status)
+	assert.Equal(t, "vp", results[1].ID)
+	assert.Equal(t, domain.StatusPending, results[1].Status)
+	assert.Equal(t, "vf", results[2].ID)
+	assert.Equal(t, domain.StatusAwaitingFinal, results[2].Status)
 }
 
 // ---------------------------------------------------------------------------
@@ -394,6 +652,7 @@ func TestVacationUpdateStatus_Approved(t *testing.T) {
 	assert.Equal(t, domain.StatusApproved, got.Status)
 	require.NotNil(t, got.ReviewedBy)
 	assert.Equal(t, "admin1", *got.ReviewedBy)
+	assert.Equal(t, "Admin", got.ReviewedByName)
 	require.NotNil(t, got.ReviewedAt)
 	assert.True(t, got.ReviewedAt.After(before) || got.ReviewedAt.Equal(before),
 		"reviewedAt should be after (or equal to) the time just before the update")
@@ -402,6 +661,168 @@ func TestVacationUpdateStatus_Approved(t *testing.T) {
 	assert.Nil(t, got.RejectionReason)
 }
 
+// ---------------------------------------------------------------------------
+// 15b. AdvanceApprovalStep
+// ---------------------------------------------------------------------------
+
+func TestVacationAdvanceApprovalStep(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "lead1", "lead@test.com", "Lead", domain.RoleAdmin, 25)
+	testutil.CreateTestVacation(t, vacRepo, "vac1", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusPending)
+
+	err := vacRepo.AdvanceApprovalStep(ctx, "vac1", 1, domain.StatusAwaitingFinal, "lead1", nil)
+	require.NoError(t, err)
+
+	got, err := vacRepo.GetByID(ctx, "vac1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, domain.StatusAwaitingFinal, got.Status)
+	assert.Equal(t, 1, got.ApprovalStep)
+	require.NotNil(t, got.ReviewedBy)
+	assert.Equal(t, "lead1", *got.ReviewedBy)
+
+	// Awaiting-final requests still show up for review and block overlapping dates
+	pending, err := vacRepo.ListPending(ctx, "", "")
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "vac1", pending[0].ID)
+
+	overlap, err := vacRepo.HasOverlap(ctx, "user1", "2027-06-03", "2027-06-04", true, nil)
+	require.NoError(t, err)
+	assert.True(t, overlap)
+}
+
+func TestVacationApprovalComment(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "alice@test.com", "Alice", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "lead1", "lead@test.com", "Lead", domain.RoleAdmin, 25)
+	testutil.CreateTestVacation(t, vacRepo, "vac1", "user1", "2027-06-14", "2027-06-18", 5, domain.StatusPending)
+
+	comment := "Coverage confirmed"
+	err := vacRepo.AdvanceApprovalStep(ctx, "vac1", 1, domain.StatusAwaitingFinal, "lead1", &comment)
+	require.NoError(t, err)
+
+	req, err := vacRepo.GetByID(ctx, "vac1")
+	require.NoError(t, err)
+	require.NotNil(t, req.ApprovalComment)
+	assert.Equal(t, "Coverage confirmed", *req.ApprovalComment)
+
+	// A later level without a comment keeps the earlier one
+	err = vacRepo.AdvanceApprovalStep(ctx, "vac1", 2, domain.StatusAwaitingFinal, "lead1", nil)
+	require.NoError(t, err)
+	req, err = vacRepo.GetByID(ctx, "vac1")
+	require.NoError(t, err)
+	require.NotNil(t, req.ApprovalComment)
+	assert.Equal(t, "Coverage confirmed", *req.ApprovalComment)
+
+	err = db.Transaction(func(tx *sql.Tx) error {
+		return vacRepo.UpdateApprovalCommentTx(ctx, tx, "vac1", "Final sign-off")
+	})
+	require.NoError(t, err)
+	req, err = vacRepo.GetByID(ctx, "vac1")
+	require.NoError(t, err)
+	assert.Equal(t, "Final sign-off", *req.ApprovalComment)
+}
+
+func TestVacationAdvanceApprovalStep_NonExistent(t *testing.T) {
+	_, _, vacRepo := setupRepos(t)
+
+	err := vacRepo.AdvanceApprovalStep(context.Background(), "nope", 1, domain.StatusAwaitingFinal, "lead1", nil)
+	assert.ErrorIs(t, err, repository.ErrNotUnderReview)
+}
+
+func TestVacationAdvanceApprovalStep_AlreadyActedOn(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "lead1", "lead@test.com", "Lead", domain.RoleAdmin, 25)
+	testutil.CreateTestVacation(t, vacRepo, "vac1", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "vac2", "user1", "2027-07-01", "2027-07-05", 5, domain.StatusRejected)
+
+	// A second approver acting on the same level loses the race
+	require.NoError(t, vacRepo.AdvanceApprovalStep(ctx, "vac1", 1, domain.StatusAwaitingFinal, "lead1", nil))
+	err := vacRepo.AdvanceApprovalStep(ctx, "vac1", 1, domain.StatusAwaitingFinal, "lead1", nil)
+	assert.ErrorIs(t, err, repository.ErrNotUnderReview)
+
+	// A decided request can't be pulled back into the chain
+	err = vacRepo.AdvanceApprovalStep(ctx, "vac2", 1, domain.StatusAwaitingFinal, "lead1", nil)
+	assert.ErrorIs(t, err, repository.ErrNotUnderReview)
+
+	got, err := vacRepo.GetByID(ctx, "vac2")
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusRejected, got.Status)
+	assert.Equal(t, 0, got.ApprovalStep)
+}
+
+func TestVacationUpdateBalanceOverrideTx(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 2)
+	testutil.CreateTestVacation(t, vacRepo, "vac1", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusPending)
+
+	req, err := vacRepo.GetByID(ctx, "vac1")
+	require.NoError(t, err)
+	assert.Nil(t, req.BalanceOverrideReason)
+
+	err = db.Transaction(func(tx *sql.Tx) error {
+		return vacRepo.UpdateBalanceOverrideTx(ctx, tx, "vac1", "Advance on next year")
+	})
+	require.NoError(t, err)
+
+	reqs, err := vacRepo.ListByUser(ctx, "user1", nil, nil, "", "")
+	require.NoError(t, err)
+	require.Len(t, reqs, 1)
+	require.NotNil(t, reqs[0].BalanceOverrideReason)
+	assert.Equal(t, "Advance on next year", *reqs[0].BalanceOverrideReason)
+}
+
+// ---------------------------------------------------------------------------
+// 15c. Tentative requests
+// ---------------------------------------------------------------------------
+
+func TestVacationTentative_IgnoredUntilPromoted(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "vt", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusTentative)
+
+	overlap, err := vacRepo.HasOverlap(ctx, "user1", "2027-06-03", "2027-06-04", true, nil)
+	require.NoError(t, err)
+	assert.False(t, overlap, "tentative requests must not block other dates")
+
+	pending, err := vacRepo.ListPending(ctx, "", "")
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	err = db.Transaction(func(tx *sql.Tx) error {
+		return vacRepo.PromoteTentativeTx(ctx, tx, "vt", domain.StatusPending, 4)
+	})
+	require.NoError(t, err)
+
+	got, err := vacRepo.GetByID(ctx, "vt")
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusPending, got.Status)
+	assert.Equal(t, 4, got.TotalDays)
+
+	overlap, err = vacRepo.HasOverlap(ctx, "user1", "2027-06-03", "2027-06-04", true, nil)
+	require.NoError(t, err)
+	assert.True(t, overlap)
+
+	// Promoting again fails since the request is no longer tentative
+	err = db.Transaction(func(tx *sql.Tx) error {
+		return vacRepo.PromoteTentativeTx(ctx, tx, "vt", domain.StatusPending, 4)
+	})
+	assert.Error(t, err)
+}
+
 // ---------------------------------------------------------------------------
 // 16. UpdateStatus to rejected with reason
 // ---------------------------------------------------------------------------
@@ -466,8 +887,103 @@ func TestVacationUpdateStatus_NonExistent(t *testing.T) {
 	ctx := context.Background()
 
 	err := vacRepo.UpdateStatus(ctx, "nonexistent", domain.StatusApproved, "admin1", nil)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "vacation request not found")
+	assert.ErrorIs(t, err, repository.ErrNotUnderReview)
+}
+
+func TestVacationUpdateStatusTx_AlreadyDecided(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "admin1", "admin@test.com", "Admin", domain.RoleAdmin, 25)
+	testutil.CreateTestVacation(t, vacRepo, "vac1", "user1", "2027-09-01", "2027-09-05", 5, domain.StatusPending)
+
+	approve := func() error {
+		return db.Transaction(func(tx *sql.Tx) error {
+			return vacRepo.UpdateStatusTx(ctx, tx, "vac1", domain.StatusApproved, "admin1", nil)
+		})
+	}
+	require.NoError(t, approve())
+
+	// The second decision must not overwrite the first
+	assert.ErrorIs(t, approve(), repository.ErrNotUnderReview)
+	reason := "too late"
+	assert.ErrorIs(t, vacRepo.UpdateStatus(ctx, "vac1", domain.StatusRejected, "admin1", &reason), repository.ErrNotUnderReview)
+
+	got, err := vacRepo.GetByID(ctx, "vac1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusApproved, got.Status)
+	assert.Nil(t, got.RejectionReason)
+}
+
+func TestVacationUpdateTotalDays(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "pending", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "approved", "user1", "2027-07-01", "2027-07-05", 3, domain.StatusApproved)
+
+	require.NoError(t, vacRepo.UpdateTotalDays(ctx, "pending", 4))
+	got, err := vacRepo.GetByID(ctx, "pending")
+	require.NoError(t, err)
+	assert.Equal(t, 4, got.TotalDays)
+
+	// Decided requests already settled their balance and are left alone
+	assert.ErrorIs(t, vacRepo.UpdateTotalDays(ctx, "approved", 1), repository.ErrNotUnderReview)
+	got, err = vacRepo.GetByID(ctx, "approved")
+	require.NoError(t, err)
+	assert.Equal(t, 3, got.TotalDays)
+}
+
+func TestVacationAdjustDatesTx(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "pending", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "approved", "user1", "2027-07-01", "2027-07-05", 3, domain.StatusApproved)
+
+	got, err := vacRepo.GetByID(ctx, "pending")
+	require.NoError(t, err)
+	assert.Nil(t, got.OriginalStartDate)
+	assert.Nil(t, got.OriginalEndDate)
+
+	err = db.Transaction(func(tx *sql.Tx) error {
+		return vacRepo.AdjustDatesTx(ctx, tx, "pending", "2027-06-02", "2027-06-03", 2)
+	})
+	require.NoError(t, err)
+
+	reqs, err := vacRepo.ListByUser(ctx, "user1", nil, nil, "", "")
+	require.NoError(t, err)
+	var adjusted *domain.VacationRequest
+	for _, r := range reqs {
+		if r.ID == "pending" {
+			adjusted = r
+		}
+	}
+	require.NotNil(t, adjusted)
+	assert.Equal(t, "2027-06-02", adjusted.StartDate)
+	assert.Equal(t, "2027-06-03", adjusted.EndDate)
+	assert.Equal(t, 2, adjusted.TotalDays)
+	require.NotNil(t, adjusted.OriginalStartDate)
+	require.NotNil(t, adjusted.OriginalEndDate)
+	assert.Equal(t, "2027-06-01", *adjusted.OriginalStartDate)
+	assert.Equal(t, "2027-06-05", *adjusted.OriginalEndDate)
+
+	// Adjusting again keeps the range that was originally submitted
+	err = db.Transaction(func(tx *sql.Tx) error {
+		return vacRepo.AdjustDatesTx(ctx, tx, "pending", "2027-06-02", "2027-06-02", 1)
+	})
+	require.NoError(t, err)
+	got, err = vacRepo.GetByID(ctx, "pending")
+	require.NoError(t, err)
+	assert.Equal(t, "2027-06-01", *got.OriginalStartDate)
+
+	err = db.Transaction(func(tx *sql.Tx) error {
+		return vacRepo.AdjustDatesTx(ctx, tx, "approved", "2027-07-02", "2027-07-03", 2)
+	})
+	assert.ErrorIs(t, err, repository.ErrNotUnderReview)
 }
 
 // ---------------------------------------------------------------------------
@@ -509,6 +1025,33 @@ func TestVacationDelete_NonExistent(t *testing.T) {
 	assert.Contains(t, err.Error(), "vacation request not found")
 }
 
+func TestVacationDeleteTx_RollsBack(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "vac-del", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusApproved)
+
+	err := db.Transaction(func(tx *sql.Tx) error {
+		require.NoError(t, vacRepo.DeleteTx(ctx, tx, "vac-del"))
+		return errors.New("abort")
+	})
+	require.Error(t, err)
+
+	got, err := vacRepo.GetByID(ctx, "vac-del")
+	require.NoError(t, err)
+	assert.NotNil(t, got, "rolled back delete must keep the request")
+
+	err = db.Transaction(func(tx *sql.Tx) error {
+		return vacRepo.DeleteTx(ctx, tx, "vac-del")
+	})
+	require.NoError(t, err)
+
+	got, err = vacRepo.GetByID(ctx, "vac-del")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
 // ---------------------------------------------------------------------------
 // 21. HasOverlap true
 // ---------------------------------------------------------------------------
@@ -521,7 +1064,7 @@ func TestVacationHasOverlap_True(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-10", "2027-06-20", 9, domain.StatusApproved)
 
 	// New range overlaps with existing
-	overlap, err := vacRepo.HasOverlap(ctx, "user1", "2027-06-15", "2027-06-25")
+	overlap, err := vacRepo.HasOverlap(ctx, "user1", "2027-06-15", "2027-06-25", true, nil)
 	require.NoError(t, err)
 	assert.True(t, overlap)
 }
@@ -538,7 +1081,7 @@ func TestVacationHasOverlap_False(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-10", "2027-06-20", 9, domain.StatusApproved)
 
 	// Completely after existing range
-	overlap, err := vacRepo.HasOverlap(ctx, "user1", "2027-07-01", "2027-07-10")
+	overlap, err := vacRepo.HasOverlap(ctx, "user1", "2027-07-01", "2027-07-10", true, nil)
 	require.NoError(t, err)
 	assert.False(t, overlap)
 }
@@ -555,7 +1098,7 @@ func TestVacationHasOverlap_ExcludesRejected(t *testing.T) {
 	// Rejected request — should not count as overlap
 	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-10", "2027-06-20", 9, domain.StatusRejected)
 
-	overlap, err := vacRepo.HasOverlap(ctx, "user1", "2027-06-15", "2027-06-25")
+	overlap, err := vacRepo.HasOverlap(ctx, "user1", "2027-06-15", "2027-06-25", true, nil)
 	require.NoError(t, err)
 	assert.False(t, overlap)
 }
@@ -573,9 +1116,9 @@ func TestVacationHasOverlap_VariousPatterns(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-10", "2027-06-20", 9, domain.StatusPending)
 
 	tests := []struct {
-		name      string
-		start     string
-		end       string
+		name        string
+		start       string
+		end         string
 		wantOverlap bool
 	}{
 		{
@@ -648,7 +1191,38 @@ func TestVacationHasOverlap_VariousPatterns(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			overlap, err := vacRepo.HasOverlap(ctx, "user1", tt.start, tt.end)
+			overlap, err := vacRepo.HasOverlap(ctx, "user1", tt.start, tt.end, true, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOverlap, overlap)
+		})
+	}
+}
+
+func TestVacationHasOverlap_WithoutTouching(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "u@test.com", "User", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-10", "2027-06-20", 9, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "v2", "user1", "2027-07-01", "2027-07-01", 1, domain.StatusApproved)
+
+	tests := []struct {
+		name        string
+		start       string
+		end         string
+		wantOverlap bool
+	}{
+		{name: "ends the day before", start: "2027-06-01", end: "2027-06-09", wantOverlap: true},
+		{name: "starts the day after", start: "2027-06-21", end: "2027-06-25", wantOverlap: true},
+		{name: "shares a boundary day", start: "2027-06-20", end: "2027-06-25", wantOverlap: true},
+		{name: "day after a single day request", start: "2027-07-02", end: "2027-07-03", wantOverlap: true},
+		{name: "one day gap before", start: "2027-06-01", end: "2027-06-08", wantOverlap: false},
+		{name: "one day gap after", start: "2027-06-22", end: "2027-06-25", wantOverlap: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlap, err := vacRepo.HasOverlap(ctx, "user1", tt.start, tt.end, false, nil)
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantOverlap, overlap)
 		})
@@ -667,7 +1241,7 @@ func TestVacationHasOverlap_PendingAlsoCounts(t *testing.T) {
 	// Pending request
 	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-10", "2027-06-20", 9, domain.StatusPending)
 
-	overlap, err := vacRepo.HasOverlap(ctx, "user1", "2027-06-15", "2027-06-25")
+	overlap, err := vacRepo.HasOverlap(ctx, "user1", "2027-06-15", "2027-06-25", true, nil)
 	require.NoError(t, err)
 	assert.True(t, overlap)
 }
@@ -687,11 +1261,43 @@ func TestVacationHasOverlap_DifferentUser(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-10", "2027-06-20", 9, domain.StatusApproved)
 
 	// user2 checks overlap for the same range — should be false
-	overlap, err := vacRepo.HasOverlap(ctx, "user2", "2027-06-10", "2027-06-20")
+	overlap, err := vacRepo.HasOverlap(ctx, "user2", "2027-06-10", "2027-06-20", true, nil)
 	require.NoError(t, err)
 	assert.False(t, overlap)
 }
 
+// ---------------------------------------------------------------------------
+// 24d. HasOverlap restricted to request types
+// ---------------------------------------------------------------------------
+
+func TestVacationHasOverlap_Types(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+	require.NoError(t, vacRepo.Create(ctx, &domain.VacationRequest{
+		ID:        "r1",
+		UserID:    "user1",
+		StartDate: "2027-06-14",
+		EndDate:   "2027-06-18",
+		TotalDays: 5,
+		Status:    domain.StatusApproved,
+		Type:      domain.RequestTypeRemote,
+	}))
+
+	overlap, err := vacRepo.HasOverlap(ctx, "user1", "2027-06-15", "2027-06-16", true, nil)
+	require.NoError(t, err)
+	assert.True(t, overlap, "nil types count every request")
+
+	overlap, err = vacRepo.HasOverlap(ctx, "user1", "2027-06-15", "2027-06-16", true, []domain.RequestType{domain.RequestTypeVacation})
+	require.NoError(t, err)
+	assert.False(t, overlap)
+
+	overlap, err = vacRepo.HasOverlap(ctx, "user1", "2027-06-15", "2027-06-16", true, []domain.RequestType{domain.RequestTypeRemote})
+	require.NoError(t, err)
+	assert.True(t, overlap)
+}
+
 // ---------------------------------------------------------------------------
 // 25. GetMonthlyStats
 // ---------------------------------------------------------------------------
@@ -714,7 +1320,7 @@ func TestVacationGetMonthlyStats(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "s3", "user2", "2027-08-01", "2027-08-10", 8, domain.StatusApproved)
 	testutil.CreateTestVacation(t, vacRepo, "s4", "user2", "2027-09-01", "2027-09-02", 2, domain.StatusRejected)
 
-	stats, err := vacRepo.GetMonthlyStats(ctx, year, month)
+	stats, err := vacRepo.GetMonthlyStats(ctx, year, month, "")
 	require.NoError(t, err)
 	require.NotNil(t, stats)
 
@@ -735,7 +1341,7 @@ func TestVacationGetMonthlyStats_Empty(t *testing.T) {
 	ctx := context.Background()
 
 	// Query a month with no data
-	stats, err := vacRepo.GetMonthlyStats(ctx, 2020, 1)
+	stats, err := vacRepo.GetMonthlyStats(ctx, 2020, 1, "")
 	require.NoError(t, err)
 	require.NotNil(t, stats)
 
@@ -746,6 +1352,138 @@ func TestVacationGetMonthlyStats_Empty(t *testing.T) {
 	assert.Equal(t, 0, stats.TotalDaysUsed)
 }
 
+// ---------------------------------------------------------------------------
+// 25c. GetYearlyStats matches GetMonthlyStats
+// ---------------------------------------------------------------------------
+
+func TestVacationGetYearlyStats(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+
+	// CreateTestVacation sets CreatedAt to time.Now(), so everything lands in the current month.
+	now := time.Now()
+	testutil.CreateTestVacation(t, vacRepo, "y1", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "y2", "user1", "2027-07-01", "2027-07-03", 3, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "y3", "user1", "2027-08-01", "2027-08-10", 8, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "y4", "user1", "2027-09-01", "2027-09-02", 2, domain.StatusRejected)
+	testutil.CreateTestVacation(t, vacRepo, "y5", "user1", "2027-10-01", "2027-10-02", 2, domain.StatusTentative)
+
+	yearly, err := vacRepo.GetYearlyStats(ctx, now.Year())
+	require.NoError(t, err)
+	require.NotNil(t, yearly)
+
+	monthly, err := vacRepo.GetMonthlyStats(ctx, now.Year(), int(now.Month()), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, yearly.TotalSubmitted)
+	assert.Equal(t, 2, yearly.TotalApproved)
+	assert.Equal(t, 1, yearly.TotalRejected)
+	assert.Equal(t, 1, yearly.TotalPending)
+	assert.Equal(t, 11, yearly.TotalDaysUsed)
+	assert.Equal(t, *monthly, yearly.Months[now.Month()-1])
+	assert.Equal(t, *monthly, yearly.MonthlyStats)
+
+	empty, err := vacRepo.GetYearlyStats(ctx, 2020)
+	require.NoError(t, err)
+	assert.Equal(t, 0, empty.TotalSubmitted)
+}
+
+func TestVacationGetYearlyStats_LeaveYear(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	settings := domain.DefaultSettings()
+	settings.VacationResetMonth = 4
+	require.NoError(t, sqlite.NewSettingsRepository(db).Update(ctx, &settings))
+
+	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+	created := map[string]string{
+		"before": "2027-03-31 23:00:00",
+		"first":  "2027-04-01 08:00:00",
+		"last":   "2028-02-10 09:00:00",
+		"after":  "2028-04-01 08:00:00",
+	}
+	for id, at := range created {
+		testutil.CreateTestVacation(t, vacRepo, id, "user1", "2028-06-01", "2028-06-02", 2, domain.StatusApproved)
+		_, err := db.ExecContext(ctx, "UPDATE vacation_requests SET created_at = ? WHERE id = ?", at, id)
+		require.NoError(t, err)
+	}
+
+	yearly, err := vacRepo.GetYearlyStats(ctx, 2027)
+	require.NoError(t, err)
+	assert.Equal(t, 2, yearly.TotalSubmitted)
+	assert.Equal(t, 1, yearly.Months[time.April-1].TotalSubmitted)
+	assert.Equal(t, 1, yearly.Months[time.February-1].TotalSubmitted)
+
+	// February of leave year 2027 is February 2028
+	feb, err := vacRepo.GetMonthlyStats(ctx, 2027, 2, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, feb.TotalSubmitted)
+	assert.Equal(t, 2, feb.TotalDaysUsed)
+
+	march, err := vacRepo.GetMonthlyStats(ctx, 2026, 3, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, march.TotalSubmitted, "March 2027 belongs to leave year 2026")
+}
+
+// ---------------------------------------------------------------------------
+// 25c. GetUserYearStats
+// ---------------------------------------------------------------------------
+
+func TestVacationGetUserYearStats(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "user2", "b@test.com", "Bob", domain.RoleEmployee, 25)
+
+	testutil.CreateTestVacation(t, vacRepo, "jan", "user1", "2027-01-11", "2027-01-13", 3, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "jan2", "user1", "2027-01-25", "2027-01-26", 2, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "jun", "user1", "2027-06-01", "2027-06-05", 5, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "rejected", "user1", "2027-03-01", "2027-03-02", 2, domain.StatusRejected)
+	testutil.CreateTestVacation(t, vacRepo, "pending", "user1", "2027-09-01", "2027-09-02", 2, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "tentative", "user1", "2027-10-01", "2027-10-02", 2, domain.StatusTentative)
+	testutil.CreateTestVacation(t, vacRepo, "other-year", "user1", "2026-12-01", "2026-12-02", 2, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "other-user", "user2", "2027-01-04", "2027-01-08", 5, domain.StatusApproved)
+
+	stats, err := vacRepo.GetUserYearStats(ctx, "user1", 2027)
+	require.NoError(t, err)
+	require.NotNil(t, stats)
+
+	assert.Equal(t, 5, stats.TotalRequested)
+	assert.Equal(t, 3, stats.TotalApproved)
+	assert.Equal(t, 1, stats.TotalRejected)
+	assert.Equal(t, 1, stats.TotalPending)
+	assert.Equal(t, 10, stats.TotalDaysUsed)
+	assert.Equal(t, 5, stats.DaysByMonth[0])
+	assert.Equal(t, 0, stats.DaysByMonth[2])
+	assert.Equal(t, 5, stats.DaysByMonth[5])
+}
+
+func TestVacationGetUserYearStats_LeaveYear(t *testing.T) {
+	db, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	settings := domain.DefaultSettings()
+	settings.VacationResetMonth = 4
+	require.NoError(t, sqlite.NewSettingsRepository(db).Update(ctx, &settings))
+
+	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "before", "user1", "2027-03-29", "2027-03-31", 3, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "first", "user1", "2027-04-01", "2027-04-02", 2, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "last", "user1", "2028-03-27", "2028-03-31", 5, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "after", "user1", "2028-04-03", "2028-04-04", 2, domain.StatusApproved)
+
+	stats, err := vacRepo.GetUserYearStats(ctx, "user1", 2027)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.TotalRequested)
+	assert.Equal(t, 7, stats.TotalDaysUsed)
+	assert.Equal(t, 2, stats.DaysByMonth[time.April-1])
+	assert.Equal(t, 5, stats.DaysByMonth[time.March-1])
+}
+
 // ---------------------------------------------------------------------------
 // Additional: ListByUser returns only the specified user's requests
 // ---------------------------------------------------------------------------
@@ -761,7 +1499,7 @@ func TestVacationListByUser_IsolatedByUser(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "v2", "user2", "2027-06-10", "2027-06-15", 5, domain.StatusPending)
 	testutil.CreateTestVacation(t, vacRepo, "v3", "user1", "2027-07-01", "2027-07-05", 5, domain.StatusApproved)
 
-	results, err := vacRepo.ListByUser(ctx, "user1", nil, nil)
+	results, err := vacRepo.ListByUser(ctx, "user1", nil, nil, "", "")
 	require.NoError(t, err)
 	require.Len(t, results, 2)
 
@@ -780,7 +1518,7 @@ func TestVacationListTeam_Empty(t *testing.T) {
 
 	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
 
-	results, err := vacRepo.ListTeam(ctx, 12, 2030)
+	results, err := vacRepo.ListTeam(ctx, 12, 2030, "")
 	require.NoError(t, err)
 	assert.Empty(t, results)
 }
@@ -797,7 +1535,7 @@ func TestVacationListTeam_WrongMonth(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-10", "2027-06-15", 5, domain.StatusApproved)
 
 	// Query July — should not include the June vacation
-	results, err := vacRepo.ListTeam(ctx, 7, 2027)
+	results, err := vacRepo.ListTeam(ctx, 7, 2027, "")
 	require.NoError(t, err)
 	assert.Empty(t, results)
 }
@@ -813,7 +1551,7 @@ func TestVacationListTeam_FieldValues(t *testing.T) {
 	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice Wonder", domain.RoleEmployee, 25)
 	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-10", "2027-06-15", 5, domain.StatusApproved)
 
-	results, err := vacRepo.ListTeam(ctx, 6, 2027)
+	results, err := vacRepo.ListTeam(ctx, 6, 2027, "")
 	require.NoError(t, err)
 	require.Len(t, results, 1)
 
@@ -866,8 +1604,7 @@ func TestVacationUpdateStatusTx_NonExistent(t *testing.T) {
 		txErr = vacRepo.UpdateStatusTx(ctx, tx, "nonexistent", domain.StatusApproved, "admin1", nil)
 		return txErr
 	})
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "vacation request not found")
+	assert.ErrorIs(t, err, repository.ErrNotUnderReview)
 }
 
 // ---------------------------------------------------------------------------
@@ -946,7 +1683,7 @@ func TestVacationListPending_MultipleUsers(t *testing.T) {
 	testutil.CreateTestVacation(t, vacRepo, "vp2", "user2", "2027-05-01", "2027-05-03", 3, domain.StatusPending)
 	testutil.CreateTestVacation(t, vacRepo, "va1", "user1", "2027-06-01", "2027-06-03", 3, domain.StatusApproved)
 
-	results, err := vacRepo.ListPending(ctx)
+	results, err := vacRepo.ListPending(ctx, "", "")
 	require.NoError(t, err)
 	require.Len(t, results, 2)
 
@@ -964,3 +1701,63 @@ func TestVacationListPending_MultipleUsers(t *testing.T) {
 
 // Ensure the unused import does not cause a compilation error.
 var _ repository.MonthlyStats
+
+// ---------------------------------------------------------------------------
+// ListTeam excludes deactivated users
+// ---------------------------------------------------------------------------
+
+func TestVacationListTeam_ExcludesInactiveUsers(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+	testutil.CreateTestUser(t, userRepo, "user2", "b@test.com", "Bob", domain.RoleEmployee, 25)
+	require.NoError(t, userRepo.SetActive(ctx, "user2", false))
+
+	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-10", "2027-06-15", 5, domain.StatusApproved)
+	testutil.CreateTestVacation(t, vacRepo, "v2", "user2", "2027-06-20", "2027-06-25", 5, domain.StatusApproved)
+
+	results, err := vacRepo.ListTeam(ctx, 6, 2027, "")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "v1", results[0].ID)
+}
+
+// ---------------------------------------------------------------------------
+// Request types: remote days are listed but never count as days used
+// ---------------------------------------------------------------------------
+
+func TestVacationRequestType_RemoteNotCountedAsUsed(t *testing.T) {
+	_, userRepo, vacRepo := setupRepos(t)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "user1", "a@test.com", "Alice", domain.RoleEmployee, 25)
+	testutil.CreateTestVacation(t, vacRepo, "v1", "user1", "2027-06-07", "2027-06-09", 3, domain.StatusApproved)
+	require.NoError(t, vacRepo.Create(ctx, &domain.VacationRequest{
+		ID:        "r1",
+		UserID:    "user1",
+		StartDate: "2027-06-14",
+		EndDate:   "2027-06-18",
+		TotalDays: 5,
+		Status:    domain.StatusApproved,
+		Type:      domain.RequestTypeRemote,
+	}))
+
+	vacation, err := vacRepo.GetByID(ctx, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RequestTypeVacation, vacation.Type, "requests default to paid vacation")
+	remote, err := vacRepo.GetByID(ctx, "r1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RequestTypeRemote, remote.Type)
+
+	team, err := vacRepo.ListTeam(ctx, 6, 2027, "")
+	require.NoError(t, err)
+	require.Len(t, team, 2)
+	assert.Equal(t, domain.RequestTypeVacation, team[0].Type)
+	assert.Equal(t, domain.RequestTypeRemote, team[1].Type)
+
+	stats, err := vacRepo.GetUserYearStats(ctx, "user1", 2027)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.TotalApproved)
+	assert.Equal(t, 3, stats.TotalDaysUsed)
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/repository"
+)
+
+// allowedAttachmentTypes are the MIME types accepted for upload, detected from the file contents
+var allowedAttachmentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// AttachmentService stores files uploaded to vacation requests
+// Metadata goes to the database and the bytes to <dir>/<requestID>/<attachmentID>;
+// only the request owner and admins may upload or download
+type AttachmentService struct {
+	attachmentRepo repository.AttachmentRepository
+	vacationRepo   repository.VacationRepository
+	dir            string
+	maxSize        int64
+}
+
+// NewAttachmentService creates a new AttachmentService
+func NewAttachmentService(attachmentRepo repository.AttachmentRepository, vacationRepo repository.VacationRepository, dir string, maxSize int64) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo: attachmentRepo,
+		vacationRepo:   vacationRepo,
+		dir:            dir,
+		maxSize:        maxSize,
+	}
+}
+
+// MaxSize returns the upload size limit in bytes
+func (s *AttachmentService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// List returns the attachments on a request, oldest first
+func (s *AttachmentService) List(ctx context.Context, requestID, userID string, isAdmin bool) ([]*domain.Attachment, error) {
+	if _, err := s.authorizedRequest(ctx, requestID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.ListByRequest(ctx, requestID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list attachments")
+	}
+	return attachments, nil
+}
+
+// Upload stores a file on a request
+// The type is sniffed from the contents rather than trusted from the client
+func (s *AttachmentService) Upload(ctx context.Context, requestID, userID string, isAdmin bool, fileName string, content io.Reader) (*domain.Attachment, error) {
+	request, err := s.authorizedRequest(ctx, requestID, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, dto.ErrInternalErrorWithMessage("failed to read upload")
+	}
+	if n == 0 {
+		return nil, dto.ErrValidationError("file must not be empty")
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	if !allowedAttachmentTypes[contentType] {
+		return nil, dto.ErrValidationError(fmt.Sprintf("file type %s is not allowed; upload a PDF, JPEG or PNG", contentType))
+	}
+
+	attachment := &domain.Attachment{
+		ID:          uuid.New().String(),
+		RequestID:   request.ID,
+		UploadedBy:  userID,
+		FileName:    attachmentFileName(fileName),
+		ContentType: contentType,
+	}
+
+	if err := os.MkdirAll(filepath.Join(s.dir, request.ID), 0o750); err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to store attachment")
+	}
+	path := s.path(attachment)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to store attachment")
+	}
+
+	// Read one byte past the limit so an oversized upload is detected without buffering it
+	written, err := io.Copy(file, io.LimitReader(io.MultiReader(bytes.NewReader(head), content), s.maxSize+1))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, dto.ErrInternalErrorWithMessage("failed to store attachment")
+	}
+	if written > s.maxSize {
+		os.Remove(path)
+		return nil, dto.ErrFileTooLargeError(s.maxSize)
+	}
+	attachment.Size = written
+
+	if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+		os.Remove(path)
+		return nil, dto.ErrInternalErrorWithMessage("failed to save attachment")
+	}
+	return attachment, nil
+}
+
+// Open returns an attachment on a request together with the path of its file
+func (s *AttachmentService) Open(ctx context.Context, requestID, attachmentID, userID string, isAdmin bool) (*domain.Attachment, string, error) {
+	if _, err := s.authorizedRequest(ctx, requestID, userID, isAdmin); err != nil {
+		return nil, "", err
+	}
+
+	attachment, err := s.attachmentRepo.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, "", dto.ErrInternalErrorWithMessage("failed to get attachment")
+	}
+	if attachment == nil || attachment.RequestID != requestID {
+		return nil, "", dto.ErrNotFoundError("attachment")
+	}
+
+	path := s.path(attachment)
+	if _, err := os.Stat(path); err != nil {
+		return nil, "", dto.ErrNotFoundError("attachment")
+	}
+	return attachment, path, nil
+}
+
+// RemoveForRequest deletes the files of a request's attachments
+// The metadata rows go with the request itself through the foreign key
+func (s *AttachmentService) RemoveForRequest(requestID string) error {
+	if requestID == "" {
+		return nil
+	}
+	return os.RemoveAll(filepath.Join(s.dir, requestID))
+}
+
+// path is where an attachment's bytes live on disk
+func (s *AttachmentService) path(attachment *domain.Attachment) string {
+	return filepath.Join(s.dir, attachment.RequestID, attachment.ID)
+}
+
+// authorizedRequest loads a request whose attachments the user may access
+func (s *AttachmentService) authorizedRequest(ctx context.Context, requestID, userID string, isAdmin bool) (*domain.VacationRequest, error) {
+	request, err := s.vacationRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation request")
+	}
+	if request == nil {
+		return nil, dto.ErrNotFoundError("vacation request")
+	}
+	if request.UserID != userID && !isAdmin {
+		return nil, dto.ErrForbiddenError("you can only access attachments on your own requests")
+	}
+	return request, nil
+}
+
+// attachmentFileName keeps only the base name of an uploaded file, capped to a sane length
+func attachmentFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[:255])
+	}
+	return name
+}
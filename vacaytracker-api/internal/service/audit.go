@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/repository"
+)
+
+// auditRedactedFields are snapshot keys whose values never reach the audit log
+// Matched case-insensitively at any depth
+var auditRedactedFields = map[string]bool{
+	"password":     true,
+	"passwordhash": true,
+	"token":        true,
+	"secret":       true,
+	"webhookurl":   true, // May embed credentials, e.g. a Slack hook
+}
+
+// AuditService records admin mutations and lists them for compliance review
+type AuditService struct {
+	auditRepo repository.AuditRepository
+}
+
+// NewAuditService creates a new AuditService
+func NewAuditService(auditRepo repository.AuditRepository) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+// Record stores an audit entry with JSON snapshots of the target before and after the change
+// Pass nil for a snapshot that doesn't apply, e.g. before on a creation
+func (s *AuditService) Record(ctx context.Context, actorID string, action domain.AuditAction, targetType, targetID string, before, after interface{}) error {
+	beforeJSON, err := auditSnapshot(before)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot %s before %s: %w", targetType, action, err)
+	}
+	afterJSON, err := auditSnapshot(after)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot %s after %s: %w", targetType, action, err)
+	}
+
+	return s.auditRepo.Create(ctx, &domain.AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+	})
+}
+
+// List returns a page of audit entries, newest first, and the total number matching the filter
+func (s *AuditService) List(ctx context.Context, filter domain.AuditFilter, page, limit int) ([]*domain.AuditEntry, int, error) {
+	entries, total, err := s.auditRepo.List(ctx, filter, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, dto.ErrInternalErrorWithMessage("failed to list audit log")
+	}
+	if entries == nil {
+		entries = []*domain.AuditEntry{}
+	}
+	return entries, total, nil
+}
+
+// auditSnapshot marshals v to JSON with sensitive fields removed; nil yields no snapshot
+func auditSnapshot(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Round-trip through a generic value so redaction works for any shape
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+	if generic == nil {
+		return nil, nil
+	}
+	return json.Marshal(redactAuditFields(generic))
+}
+
+// redactAuditFields drops sensitive keys from decoded JSON objects, recursing into nested values
+func redactAuditFields(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			if auditRedactedFields[strings.ToLower(key)] {
+				delete(value, key)
+				continue
+			}
+			value[key] = redactAuditFields(nested)
+		}
+	case []interface{}:
+		for i, nested := range value {
+			value[i] = redactAuditFields(nested)
+		}
+	}
+	return v
+}
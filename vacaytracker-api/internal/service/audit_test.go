@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/service"
+	"vacaytracker-api/internal/testutil"
+)
+
+// =========================================================================
+// AuditService
+// =========================================================================
+
+func TestAuditRecord_SnapshotsWithoutSecrets(t *testing.T) {
+	var created *domain.AuditEntry
+	svc := service.NewAuditService(&testutil.MockAuditRepository{
+		CreateFn: func(_ context.Context, entry *domain.AuditEntry) error {
+			created = entry
+			return nil
+		},
+	})
+
+	before := map[string]interface{}{
+		"name":       "Alice",
+		"password":   "hunter2",
+		"webhookUrl": "https://hooks.slack.com/services/T000/B000/XXXX",
+		"nested":     []interface{}{map[string]interface{}{"PasswordHash": "$2a$10$abc", "keep": 1}},
+	}
+	after := &domain.User{ID: "user-1", Name: "Alice Smith", PasswordHash: "$2a$10$def"}
+
+	err := svc.Record(context.Background(), "admin-1", domain.AuditUserUpdate, domain.AuditTargetUser, "user-1", before, after)
+	require.NoError(t, err)
+	require.NotNil(t, created)
+
+	assert.Equal(t, "admin-1", created.ActorID)
+	assert.Equal(t, domain.AuditUserUpdate, created.Action)
+	assert.Equal(t, domain.AuditTargetUser, created.TargetType)
+	assert.Equal(t, "user-1", created.TargetID)
+	assert.JSONEq(t, `{"name":"Alice","nested":[{"keep":1}]}`, string(created.Before))
+	assert.Contains(t, string(created.After), `"name":"Alice Smith"`)
+	assert.NotContains(t, string(created.After), "$2a$10$def")
+}
+
+func TestAuditRecord_NilSnapshotsAreOmitted(t *testing.T) {
+	var created *domain.AuditEntry
+	svc := service.NewAuditService(&testutil.MockAuditRepository{
+		CreateFn: func(_ context.Context, entry *domain.AuditEntry) error {
+			created = entry
+			return nil
+		},
+	})
+
+	var missing *domain.User
+	err := svc.Record(context.Background(), "admin-1", domain.AuditUserDelete, domain.AuditTargetUser, "user-1", missing, nil)
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Nil(t, created.Before)
+	assert.Nil(t, created.After)
+}
+
+func TestAuditRecord_UnmarshalableSnapshot(t *testing.T) {
+	svc := service.NewAuditService(&testutil.MockAuditRepository{
+		CreateFn: func(_ context.Context, _ *domain.AuditEntry) error {
+			t.Fatal("nothing must be stored")
+			return nil
+		},
+	})
+
+	err := svc.Record(context.Background(), "admin-1", domain.AuditSettingsUpdate, domain.AuditTargetSettings, "settings", nil, make(chan int))
+	assert.Error(t, err)
+}
+
+func TestAuditList_Paginates(t *testing.T) {
+	svc := service.NewAuditService(&testutil.MockAuditRepository{
+		ListFn: func(_ context.Context, filter domain.AuditFilter, limit, offset int) ([]*domain.AuditEntry, int, error) {
+			assert.Equal(t, "admin-1", filter.ActorID)
+			assert.Equal(t, 20, limit)
+			assert.Equal(t, 40, offset)
+			return nil, 41, nil
+		},
+	})
+
+	entries, total, err := svc.List(context.Background(), domain.AuditFilter{ActorID: "admin-1"}, 3, 20)
+	require.NoError(t, err)
+	assert.NotNil(t, entries)
+	assert.Empty(t, entries)
+	assert.Equal(t, 41, total)
+}
+
+func TestAuditList_RepositoryError(t *testing.T) {
+	svc := service.NewAuditService(&testutil.MockAuditRepository{
+		ListFn: func(_ context.Context, _ domain.AuditFilter, _, _ int) ([]*domain.AuditEntry, int, error) {
+			return nil, 0, errors.New("db down")
+		},
+	})
+
+	_, _, err := svc.List(context.Background(), domain.AuditFilter{}, 1, 20)
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrInternal, appErr.Code)
+}
@@ -2,13 +2,20 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
+	"vacaytracker-api/internal/config"
 	"vacaytracker-api/internal/domain"
 	"vacaytracker-api/internal/dto"
 	"vacaytracker-api/internal/repository"
@@ -20,33 +27,112 @@ type JWTClaims struct {
 	Email  string      `json:"email"`
 	Name   string      `json:"name"`
 	Role   domain.Role `json:"role"`
+	// Purpose is empty for access tokens and set for single-use tokens such as password resets
+	Purpose string `json:"purpose,omitempty"`
+	// Preference names the email preference an unsubscribe token turns off
+	Preference string `json:"pref,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// TokenPurposePasswordReset marks tokens that may only be used to reset a password
+const TokenPurposePasswordReset = "password_reset"
+
+// TokenPurposeUnsubscribe marks tokens embedded in emails that turn off one email preference
+const TokenPurposeUnsubscribe = "unsubscribe"
+
+// TokenPurposeEmailChange marks tokens emailed to a new address to confirm an email change
+const TokenPurposeEmailChange = "email_change"
+
+// passwordResetExpiry is how long an emailed reset link stays valid
+const passwordResetExpiry = 30 * time.Minute
+
+// unsubscribeExpiry is how long an emailed unsubscribe link stays valid
+// Generous so that links in older digests still work
+const unsubscribeExpiry = 180 * 24 * time.Hour
+
+// emailChangeExpiry is how long an emailed email change confirmation link stays valid
+const emailChangeExpiry = 24 * time.Hour
+
 // AuthService handles authentication operations
 type AuthService struct {
-	userRepo  repository.UserRepository
-	jwtSecret []byte
-	jwtExpiry time.Duration
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	jwtSecret        []byte
+	jwtExpiry        time.Duration
+	refreshExpiry    time.Duration
+	issuer           string    // iss claim of issued tokens
+	audience         string    // aud claim of issued tokens, required on validation; empty disables the check
+	audienceSince    time.Time // Start of the rollout grace for tokens from before the issuer and audience, see SetIssuerAndAudience
+	denylist         *tokenDenylist
 }
 
 // NewAuthService creates a new AuthService
-func NewAuthService(userRepo repository.UserRepository, jwtSecret string) *AuthService {
+func NewAuthService(userRepo repository.UserRepository, refreshTokenRepo repository.RefreshTokenRepository, jwtSecret string) *AuthService {
 	return &AuthService{
-		userRepo:  userRepo,
-		jwtSecret: []byte(jwtSecret),
-		jwtExpiry: 24 * time.Hour, // 24 hour token expiry
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		jwtSecret:        []byte(jwtSecret),
+		jwtExpiry:        24 * time.Hour,      // 24 hour token expiry, see SetTokenTTL
+		refreshExpiry:    30 * 24 * time.Hour, // 30 day refresh token expiry
+		issuer:           config.DefaultJWTIssuer,
+		denylist:         newTokenDenylist(),
 	}
 }
 
-// HashPassword hashes a password using bcrypt
-func (s *AuthService) HashPassword(password string) (string, error) {
-	// Validate password length (bcrypt silently truncates at 72 bytes)
+// SetTokenTTL overrides how long newly issued access tokens stay valid
+// Tokens already issued keep their original expiry
+func (s *AuthService) SetTokenTTL(ttl time.Duration) {
+	s.jwtExpiry = ttl
+}
+
+// SetIssuerAndAudience sets the iss and aud claims of newly issued tokens, which validation then requires
+// For one access token lifetime after since, tokens from config.DefaultJWTIssuer and tokens without an audience
+// issued before since stay valid, so sessions survive the rollout; a zero since accepts only the configured claims.
+// An empty issuer keeps the default
+func (s *AuthService) SetIssuerAndAudience(issuer, audience string, since time.Time) {
+	if issuer != "" {
+		s.issuer = issuer
+	}
+	s.audience = audience
+	s.audienceSince = since
+}
+
+// inRolloutGrace reports whether tokens from before SetIssuerAndAudience are still accepted at now
+func (s *AuthService) inRolloutGrace(now time.Time) bool {
+	return !s.audienceSince.IsZero() && now.Before(s.audienceSince.Add(s.jwtExpiry))
+}
+
+// registeredClaims returns the standard claims of a token for subject issued at now and valid for ttl
+func (s *AuthService) registeredClaims(subject string, now time.Time, ttl time.Duration) jwt.RegisteredClaims {
+	claims := jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Issuer:    s.issuer,
+		Subject:   subject,
+	}
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
+	return claims
+}
+
+// ValidatePassword checks a new password against the length policy
+func (s *AuthService) ValidatePassword(password string) error {
+	// bcrypt silently truncates at 72 bytes
 	if len(password) < 6 {
-		return "", fmt.Errorf("password must be at least 6 characters")
+		return fmt.Errorf("password must be at least 6 characters")
 	}
 	if len(password) > 72 {
-		return "", fmt.Errorf("password cannot exceed 72 characters")
+		return fmt.Errorf("password cannot exceed 72 characters")
+	}
+	return nil
+}
+
+// HashPassword hashes a password using bcrypt
+func (s *AuthService) HashPassword(password string) (string, error) {
+	if err := s.ValidatePassword(password); err != nil {
+		return "", err
 	}
 
 	// Cost of 10 is a good balance between security and performance
@@ -68,18 +154,14 @@ func (s *AuthService) GenerateToken(user *domain.User) (string, error) {
 	now := time.Now()
 
 	claims := JWTClaims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Name:   user.Name,
-		Role:   user.Role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(s.jwtExpiry)),
-			IssuedAt:  jwt.NewNumericDate(now),
-			NotBefore: jwt.NewNumericDate(now),
-			Issuer:    "vacaytracker",
-			Subject:   user.ID,
-		},
+		UserID:           user.ID,
+		Email:            user.Email,
+		Name:             user.Name,
+		Role:             user.Role,
+		RegisteredClaims: s.registeredClaims(user.ID, now, s.jwtExpiry),
 	}
+	// A unique ID lets Logout revoke this token before it expires
+	claims.ID = uuid.New().String()
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
@@ -92,7 +174,26 @@ func (s *AuthService) GenerateToken(user *domain.User) (string, error) {
 }
 
 // ValidateToken validates a JWT token and returns the claims
+// Purpose-bound tokens (e.g. password resets) and tokens revoked by Logout are not accepted as access tokens
 func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Purpose != "" {
+		return nil, dto.ErrTokenInvalidError()
+	}
+
+	if claims.ID != "" && s.denylist.Contains(claims.ID, time.Now()) {
+		return nil, dto.ErrTokenInvalidError()
+	}
+
+	return claims, nil
+}
+
+// parseToken verifies a token's signature and expiry and returns its claims
+func (s *AuthService) parseToken(tokenString string) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		// Validate signing method
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -109,13 +210,209 @@ func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	}
 
 	claims, ok := token.Claims.(*JWTClaims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || !s.acceptsIssuerAndAudience(claims) {
 		return nil, dto.ErrTokenInvalidError()
 	}
 
 	return claims, nil
 }
 
+// acceptsIssuerAndAudience checks a token was issued for this service
+// See SetIssuerAndAudience for the tokens accepted during a rollout
+func (s *AuthService) acceptsIssuerAndAudience(claims *JWTClaims) bool {
+	grace := s.inRolloutGrace(time.Now())
+	if claims.Issuer != s.issuer && !(grace && claims.Issuer == config.DefaultJWTIssuer) {
+		return false
+	}
+	if s.audience == "" {
+		return true
+	}
+	if len(claims.Audience) == 0 {
+		return grace && claims.IssuedAt != nil && claims.IssuedAt.Before(s.audienceSince)
+	}
+	for _, audience := range claims.Audience {
+		if audience == s.audience {
+			return true
+		}
+	}
+	return false
+}
+
+// GeneratePasswordResetToken creates a short-lived token that can only be used to reset a password
+// The token ID is derived from the current password hash, so it stops working once the password changes
+func (s *AuthService) GeneratePasswordResetToken(user *domain.User) (string, error) {
+	now := time.Now()
+
+	claims := JWTClaims{
+		UserID:           user.ID,
+		Email:            user.Email,
+		Purpose:          TokenPurposePasswordReset,
+		RegisteredClaims: s.registeredClaims(user.ID, now, passwordResetExpiry),
+	}
+	claims.ID = passwordFingerprint(user.PasswordHash)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	signedToken, err := token.SignedString(s.jwtSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signedToken, nil
+}
+
+// GenerateUnsubscribeToken creates a long-lived token that turns off one email preference for a user
+func (s *AuthService) GenerateUnsubscribeToken(user *domain.User, preference string) (string, error) {
+	now := time.Now()
+
+	claims := JWTClaims{
+		UserID:           user.ID,
+		Purpose:          TokenPurposeUnsubscribe,
+		Preference:       preference,
+		RegisteredClaims: s.registeredClaims(user.ID, now, unsubscribeExpiry),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	signedToken, err := token.SignedString(s.jwtSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signedToken, nil
+}
+
+// GenerateEmailChangeToken creates a token confirming that the user controls newEmail
+// The token carries the new address, so a later change request invalidates it
+func (s *AuthService) GenerateEmailChangeToken(user *domain.User, newEmail string) (string, error) {
+	now := time.Now()
+
+	claims := JWTClaims{
+		UserID:           user.ID,
+		Email:            newEmail,
+		Purpose:          TokenPurposeEmailChange,
+		RegisteredClaims: s.registeredClaims(user.ID, now, emailChangeExpiry),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	signedToken, err := token.SignedString(s.jwtSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signedToken, nil
+}
+
+// passwordFingerprint returns a short digest of a password hash for binding reset tokens
+func passwordFingerprint(passwordHash string) string {
+	sum := sha256.Sum256([]byte(passwordHash))
+	return hex.EncodeToString(sum[:8])
+}
+
+// GenerateRefreshToken issues a new long-lived refresh token for a user
+// The returned value is only ever seen by the client; a hash of it is stored
+func (s *AuthService) GenerateRefreshToken(ctx context.Context, user *domain.User) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	value := hex.EncodeToString(raw)
+
+	token := &domain.RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		TokenHash: hashRefreshToken(value),
+		ExpiresAt: time.Now().Add(s.refreshExpiry),
+	}
+	if err := s.refreshTokenRepo.Create(ctx, token); err != nil {
+		return "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return value, nil
+}
+
+// RefreshAccessToken exchanges a refresh token for a new access token and a new refresh token
+// The presented refresh token is revoked (rotated); presenting an already-rotated token revokes
+// all of the user's refresh tokens, since it indicates the token was stolen
+func (s *AuthService) RefreshAccessToken(ctx context.Context, refreshToken string) (string, string, *domain.User, error) {
+	stored, err := s.refreshTokenRepo.GetByHash(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		return "", "", nil, dto.ErrInternalError()
+	}
+	if stored == nil {
+		return "", "", nil, dto.ErrTokenInvalidError()
+	}
+
+	if stored.IsRevoked() {
+		if err := s.refreshTokenRepo.RevokeAllForUser(ctx, stored.UserID); err != nil {
+			return "", "", nil, dto.ErrInternalError()
+		}
+		return "", "", nil, dto.ErrTokenInvalidError()
+	}
+	if stored.IsExpired(time.Now()) {
+		return "", "", nil, dto.ErrTokenExpiredError()
+	}
+
+	// Rotate: only one caller can revoke a given token
+	revoked, err := s.refreshTokenRepo.Revoke(ctx, stored.ID)
+	if err != nil {
+		return "", "", nil, dto.ErrInternalError()
+	}
+	if !revoked {
+		return "", "", nil, dto.ErrTokenInvalidError()
+	}
+
+	user, err := s.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil || user == nil || user.IsDeleted() {
+		return "", "", nil, dto.ErrTokenInvalidError()
+	}
+	if !user.Active {
+		return "", "", nil, dto.ErrAccountDisabledError()
+	}
+
+	accessToken, err := s.GenerateToken(user)
+	if err != nil {
+		return "", "", nil, dto.ErrInternalError()
+	}
+	newRefreshToken, err := s.GenerateRefreshToken(ctx, user)
+	if err != nil {
+		return "", "", nil, dto.ErrInternalError()
+	}
+
+	return accessToken, newRefreshToken, user, nil
+}
+
+// Logout revokes the access token described by claims until it expires
+// A refresh token belonging to the same user is revoked too; unknown refresh tokens are ignored so logout is idempotent
+// Tokens issued before access tokens carried an ID can't be revoked and simply run out
+func (s *AuthService) Logout(ctx context.Context, claims *JWTClaims, refreshToken string) error {
+	if claims.ID != "" && claims.ExpiresAt != nil {
+		s.denylist.Add(claims.ID, claims.ExpiresAt.Time, time.Now())
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+	stored, err := s.refreshTokenRepo.GetByHash(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		return dto.ErrInternalError()
+	}
+	if stored == nil || stored.UserID != claims.UserID || stored.IsRevoked() {
+		return nil
+	}
+	if _, err := s.refreshTokenRepo.Revoke(ctx, stored.ID); err != nil {
+		return dto.ErrInternalError()
+	}
+	return nil
+}
+
+// hashRefreshToken returns the stored form of a refresh token value
+func hashRefreshToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
 // Login authenticates a user and returns a token
 func (s *AuthService) Login(ctx context.Context, email, password string) (string, *domain.User, error) {
 	// Find user by email
@@ -129,26 +426,49 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (string
 		return "", nil, dto.ErrInvalidCredentialsError()
 	}
 
+	// Checked after the password so the code doesn't reveal which emails have accounts
+	if !user.Active {
+		return "", nil, dto.ErrAccountDisabledError()
+	}
+
 	// Generate token
 	token, err := s.GenerateToken(user)
 	if err != nil {
 		return "", nil, dto.ErrInternalError()
 	}
 
+	// Best-effort: a failed write shouldn't keep the user from logging in
+	if err := s.userRepo.TouchLastLogin(ctx, user.ID); err != nil {
+		log.Printf("ERROR: failed to record last login for user %s: %v", user.ID, err)
+	}
+
 	return token, user, nil
 }
 
-// GetUserByID retrieves a user by their ID
+// GetUserByID retrieves a user by their ID; deleted users are treated as not found
 func (s *AuthService) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
 	user, err := s.userRepo.GetByID(ctx, id)
-	if err != nil || user == nil {
+	if err != nil || user == nil || user.IsDeleted() {
 		return nil, dto.ErrUserNotFoundError()
 	}
 	return user, nil
 }
 
+// IsManager reports whether a user has any direct reports
+func (s *AuthService) IsManager(ctx context.Context, userID string) (bool, error) {
+	reports, err := s.userRepo.ListByManager(ctx, userID)
+	if err != nil {
+		return false, dto.ErrInternalError()
+	}
+	return len(reports) > 0, nil
+}
+
 // ChangePassword changes a user's password
 func (s *AuthService) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error {
+	if err := s.ValidatePassword(newPassword); err != nil {
+		return dto.ErrValidationError(err.Error())
+	}
+
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil || user == nil {
@@ -171,9 +491,158 @@ func (s *AuthService) ChangePassword(ctx context.Context, userID, currentPasswor
 		return dto.ErrInternalError()
 	}
 
+	// Sign out every session that may have used the old password
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return dto.ErrInternalError()
+	}
+
 	return nil
 }
 
+// RequestPasswordReset generates a reset token for the user with the given email
+// Returns a nil user (and no error) when the email is unknown so callers can avoid leaking it
+func (s *AuthService) RequestPasswordReset(ctx context.Context, email string) (*domain.User, string, error) {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, "", dto.ErrInternalError()
+	}
+	if user == nil {
+		return nil, "", nil
+	}
+
+	token, err := s.GeneratePasswordResetToken(user)
+	if err != nil {
+		return nil, "", dto.ErrInternalError()
+	}
+
+	return user, token, nil
+}
+
+// ResetPassword sets a new password using a password reset token
+func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	claims, err := s.parseToken(token)
+	if err != nil {
+		return err
+	}
+	if claims.Purpose != TokenPurposePasswordReset {
+		return dto.ErrTokenInvalidError()
+	}
+
+	// Get user
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil || user == nil || user.IsDeleted() {
+		return dto.ErrTokenInvalidError()
+	}
+
+	// Reject tokens issued before the password last changed (including by this token)
+	if claims.ID != passwordFingerprint(user.PasswordHash) {
+		return dto.ErrTokenInvalidError()
+	}
+
+	// Hash new password
+	newHash, err := s.HashPassword(newPassword)
+	if err != nil {
+		return dto.ErrValidationError(err.Error())
+	}
+
+	// Update password
+	if err := s.userRepo.UpdatePassword(ctx, user.ID, newHash); err != nil {
+		return dto.ErrInternalError()
+	}
+
+	// Sign out every session that may have used the old password
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		return dto.ErrInternalError()
+	}
+
+	return nil
+}
+
+// RequestEmailChange stores newEmail as the user's pending email and returns a token to confirm it
+// The user keeps logging in with their current email until the change is confirmed
+func (s *AuthService) RequestEmailChange(ctx context.Context, userID, currentPassword, newEmail string) (*domain.User, string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil || user.IsDeleted() {
+		return nil, "", dto.ErrUserNotFoundError()
+	}
+
+	if !s.VerifyPassword(currentPassword, user.PasswordHash) {
+		return nil, "", dto.ErrInvalidCredentialsError()
+	}
+
+	if newEmail == user.Email {
+		return nil, "", dto.ErrValidationError("new email must differ from the current email")
+	}
+
+	exists, err := s.userRepo.EmailExistsExcluding(ctx, newEmail, user.ID)
+	if err != nil {
+		return nil, "", dto.ErrInternalErrorWithMessage("failed to check email")
+	}
+	if exists {
+		return nil, "", dto.ErrConflictError("email already exists")
+	}
+
+	if err := s.userRepo.SetPendingEmail(ctx, user.ID, newEmail); err != nil {
+		return nil, "", dto.ErrInternalError()
+	}
+	user.PendingEmail = &newEmail
+
+	token, err := s.GenerateEmailChangeToken(user, newEmail)
+	if err != nil {
+		return nil, "", dto.ErrInternalError()
+	}
+
+	return user, token, nil
+}
+
+// ConfirmEmailChange applies the pending email named in an email change token
+// and returns the updated user along with the address it replaced
+func (s *AuthService) ConfirmEmailChange(ctx context.Context, token string) (*domain.User, string, error) {
+	claims, err := s.parseToken(token)
+	if err != nil {
+		return nil, "", err
+	}
+	if claims.Purpose != TokenPurposeEmailChange {
+		return nil, "", dto.ErrTokenInvalidError()
+	}
+
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil || user == nil || user.IsDeleted() {
+		return nil, "", dto.ErrTokenInvalidError()
+	}
+
+	// Reject tokens for an address the user has since replaced or already confirmed
+	if user.PendingEmail == nil || *user.PendingEmail != claims.Email {
+		return nil, "", dto.ErrTokenInvalidError()
+	}
+
+	// The address may have been taken since the change was requested
+	exists, err := s.userRepo.EmailExistsExcluding(ctx, claims.Email, user.ID)
+	if err != nil {
+		return nil, "", dto.ErrInternalErrorWithMessage("failed to check email")
+	}
+	if exists {
+		return nil, "", dto.ErrConflictError("email already exists")
+	}
+
+	if err := s.userRepo.ConfirmPendingEmail(ctx, user.ID, claims.Email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", dto.ErrTokenInvalidError()
+		}
+		// Claimed since the check above, or held by a soft-deleted account
+		if errors.Is(err, repository.ErrEmailTaken) {
+			return nil, "", dto.ErrConflictError("email already exists")
+		}
+		return nil, "", dto.ErrInternalError()
+	}
+
+	previousEmail := user.Email
+	user.Email = claims.Email
+	user.PendingEmail = nil
+
+	return user, previousEmail, nil
+}
+
 // UpdateEmailPreferences updates a user's email notification preferences
 func (s *AuthService) UpdateEmailPreferences(ctx context.Context, userID string, updates *dto.UpdateEmailPreferencesRequest) (*domain.User, error) {
 	// Get current user
@@ -202,6 +671,35 @@ func (s *AuthService) UpdateEmailPreferences(ctx context.Context, userID string,
 	return s.userRepo.GetByID(ctx, userID)
 }
 
+// Unsubscribe turns off the email preference named in an unsubscribe token
+// Returns the updated user and the preference that was disabled
+func (s *AuthService) Unsubscribe(ctx context.Context, token string) (*domain.User, string, error) {
+	claims, err := s.parseToken(token)
+	if err != nil {
+		return nil, "", err
+	}
+	if claims.Purpose != TokenPurposeUnsubscribe {
+		return nil, "", dto.ErrTokenInvalidError()
+	}
+
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil || user == nil || user.IsDeleted() {
+		return nil, "", dto.ErrTokenInvalidError()
+	}
+
+	prefs := user.EmailPreferences
+	if !prefs.Disable(claims.Preference) {
+		return nil, "", dto.ErrTokenInvalidError()
+	}
+
+	if err := s.userRepo.UpdateEmailPreferences(ctx, user.ID, prefs); err != nil {
+		return nil, "", dto.ErrInternalError()
+	}
+	user.EmailPreferences = prefs
+
+	return user, claims.Preference, nil
+}
+
 // CreateInitialAdmin creates the initial admin user if it doesn't exist
 func (s *AuthService) CreateInitialAdmin(ctx context.Context, email, password, name string, defaultBalance int) error {
 	// Check if admin already exists
@@ -222,13 +720,14 @@ func (s *AuthService) CreateInitialAdmin(ctx context.Context, email, password, n
 
 	// Create admin user
 	admin := &domain.User{
-		ID:               "usr_admin001",
-		Email:            email,
-		PasswordHash:     hash,
-		Name:             name,
-		Role:             domain.RoleAdmin,
-		VacationBalance:  defaultBalance,
-		EmailPreferences: domain.DefaultEmailPreferences(),
+		ID:                 "usr_admin001",
+		Email:              email,
+		PasswordHash:       hash,
+		Name:               name,
+		Role:               domain.RoleAdmin,
+		VacationBalance:    defaultBalance,
+		EmailPreferences:   domain.DefaultEmailPreferences(),
+		MustChangePassword: true, // Password comes from configuration
 	}
 
 	if err := s.userRepo.Create(ctx, admin); err != nil {
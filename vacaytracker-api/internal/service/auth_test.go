@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"strings"
 	"testing"
@@ -13,6 +14,7 @@ import (
 
 	"vacaytracker-api/internal/domain"
 	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/repository"
 	"vacaytracker-api/internal/service"
 	"vacaytracker-api/internal/testutil"
 )
@@ -21,7 +23,7 @@ const testJWTSecret = "test-secret-key-that-is-long-enough"
 
 // newTestAuthService creates an AuthService with a mock repo and the default test secret.
 func newTestAuthService(repo *testutil.MockUserRepository) *service.AuthService {
-	return service.NewAuthService(repo, testJWTSecret)
+	return service.NewAuthService(repo, &testutil.MockRefreshTokenRepository{}, testJWTSecret)
 }
 
 // testUser returns a sample domain.User for testing.
@@ -38,6 +40,7 @@ func testUser() *domain.User {
 			WeeklyDigest:      false,
 			TeamNotifications: true,
 		},
+		Active:    true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -193,6 +196,27 @@ func TestGenerateToken(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, domain.RoleAdmin, claims.Role)
 	})
+
+	t.Run("expires after the default 24 hours", func(t *testing.T) {
+		tokenStr, err := svc.GenerateToken(testUser())
+		require.NoError(t, err)
+
+		claims, err := svc.ValidateToken(tokenStr)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), claims.ExpiresAt.Time, 5*time.Second)
+	})
+
+	t.Run("expires after the configured TTL", func(t *testing.T) {
+		shortLived := newTestAuthService(&testutil.MockUserRepository{})
+		shortLived.SetTokenTTL(15 * time.Minute)
+
+		tokenStr, err := shortLived.GenerateToken(testUser())
+		require.NoError(t, err)
+
+		claims, err := shortLived.ValidateToken(tokenStr)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+	})
 }
 
 // --------------------------------------------------------------------------
@@ -263,7 +287,7 @@ func TestValidateToken(t *testing.T) {
 
 	t.Run("wrong signing key returns token invalid error", func(t *testing.T) {
 		// Generate a token with a different secret
-		otherSvc := service.NewAuthService(&testutil.MockUserRepository{}, "completely-different-secret-key!!")
+		otherSvc := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, "completely-different-secret-key!!")
 		user := testUser()
 		tokenStr, err := otherSvc.GenerateToken(user)
 		require.NoError(t, err)
@@ -297,6 +321,88 @@ func TestValidateToken(t *testing.T) {
 	})
 }
 
+func TestIssuerAndAudience(t *testing.T) {
+	user := testUser()
+
+	// signed crafts an access token for user with the shared test secret
+	signed := func(t *testing.T, issuer string, audience jwt.ClaimStrings, issuedAt time.Time) string {
+		t.Helper()
+		claims := service.JWTClaims{
+			UserID: user.ID,
+			Role:   user.Role,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+				IssuedAt:  jwt.NewNumericDate(issuedAt),
+				Issuer:    issuer,
+				Audience:  audience,
+			},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("issued tokens carry the configured claims", func(t *testing.T) {
+		svc := newTestAuthService(&testutil.MockUserRepository{})
+		svc.SetIssuerAndAudience("gateway.example.com", "vacaytracker-api", time.Time{})
+
+		tokenStr, err := svc.GenerateToken(user)
+		require.NoError(t, err)
+
+		claims, err := svc.ValidateToken(tokenStr)
+		require.NoError(t, err)
+		assert.Equal(t, "gateway.example.com", claims.Issuer)
+		assert.Equal(t, jwt.ClaimStrings{"vacaytracker-api"}, claims.Audience)
+	})
+
+	t.Run("rejects other audiences and issuers", func(t *testing.T) {
+		svc := newTestAuthService(&testutil.MockUserRepository{})
+		svc.SetIssuerAndAudience("gateway.example.com", "vacaytracker-api", time.Time{})
+
+		_, err := svc.ValidateToken(signed(t, "gateway.example.com", jwt.ClaimStrings{"billing-api"}, time.Now()))
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+
+		_, err = svc.ValidateToken(signed(t, "someone-else", jwt.ClaimStrings{"vacaytracker-api"}, time.Now()))
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("accepts tokens issued before the audience was configured", func(t *testing.T) {
+		svc := newTestAuthService(&testutil.MockUserRepository{})
+		legacy, err := svc.GenerateToken(user)
+		require.NoError(t, err)
+
+		svc.SetIssuerAndAudience("gateway.example.com", "vacaytracker-api", time.Now())
+
+		claims, err := svc.ValidateToken(legacy)
+		require.NoError(t, err)
+		assert.Equal(t, "vacaytracker", claims.Issuer)
+
+		// A token without an audience minted after the rollout didn't come from this service
+		_, err = svc.ValidateToken(signed(t, "vacaytracker", nil, time.Now().Add(time.Minute)))
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("rejects older tokens once a token lifetime has passed since the rollout", func(t *testing.T) {
+		svc := newTestAuthService(&testutil.MockUserRepository{})
+		svc.SetTokenTTL(time.Hour)
+		svc.SetIssuerAndAudience("gateway.example.com", "vacaytracker-api", time.Now().Add(-2*time.Hour))
+
+		_, err := svc.ValidateToken(signed(t, "vacaytracker", nil, time.Now().Add(-3*time.Hour)))
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+
+		_, err = svc.ValidateToken(signed(t, "vacaytracker", jwt.ClaimStrings{"vacaytracker-api"}, time.Now()))
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("no grace without a rollout time", func(t *testing.T) {
+		svc := newTestAuthService(&testutil.MockUserRepository{})
+		svc.SetIssuerAndAudience("gateway.example.com", "vacaytracker-api", time.Time{})
+
+		_, err := svc.ValidateToken(signed(t, "vacaytracker", nil, time.Now().Add(-time.Minute)))
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+}
+
 // --------------------------------------------------------------------------
 // Login
 // --------------------------------------------------------------------------
@@ -336,6 +442,57 @@ func TestLogin(t *testing.T) {
 		assert.Equal(t, user.ID, claims.UserID)
 	})
 
+	t.Run("records last login best-effort", func(t *testing.T) {
+		svc := newTestAuthService(&testutil.MockUserRepository{})
+		password := "securePassword123"
+		hash, err := svc.HashPassword(password)
+		require.NoError(t, err)
+
+		user := testUser()
+		user.PasswordHash = hash
+
+		var touched string
+		repo := &testutil.MockUserRepository{
+			GetByEmailFn: func(_ context.Context, _ string) (*domain.User, error) {
+				return user, nil
+			},
+			TouchLastLoginFn: func(_ context.Context, id string) error {
+				touched = id
+				return errors.New("database is locked")
+			},
+		}
+		svc = newTestAuthService(repo)
+
+		token, returnedUser, err := svc.Login(ctx, user.Email, password)
+		require.NoError(t, err)
+		assert.NotEmpty(t, token)
+		require.NotNil(t, returnedUser)
+		assert.Equal(t, user.ID, touched)
+	})
+
+	t.Run("deactivated user", func(t *testing.T) {
+		svc := newTestAuthService(&testutil.MockUserRepository{})
+		password := "securePassword123"
+		hash, err := svc.HashPassword(password)
+		require.NoError(t, err)
+
+		user := testUser()
+		user.PasswordHash = hash
+		user.Active = false
+
+		repo := &testutil.MockUserRepository{
+			GetByEmailFn: func(_ context.Context, _ string) (*domain.User, error) {
+				return user, nil
+			},
+		}
+		svc = newTestAuthService(repo)
+
+		token, returnedUser, err := svc.Login(ctx, user.Email, password)
+		assert.Empty(t, token)
+		assert.Nil(t, returnedUser)
+		assertAppError(t, err, dto.ErrAccountDisabled)
+	})
+
 	t.Run("wrong email - user not found", func(t *testing.T) {
 		repo := &testutil.MockUserRepository{
 			GetByEmailFn: func(_ context.Context, email string) (*domain.User, error) {
@@ -438,6 +595,22 @@ func TestGetUserByID(t *testing.T) {
 		assert.Nil(t, result)
 		assertAppError(t, err, dto.ErrUserNotFound)
 	})
+
+	t.Run("soft-deleted user is not found", func(t *testing.T) {
+		user := testUser()
+		deletedAt := time.Now()
+		user.DeletedAt = &deletedAt
+		repo := &testutil.MockUserRepository{
+			GetByIDFn: func(_ context.Context, id string) (*domain.User, error) {
+				return user, nil
+			},
+		}
+		svc := newTestAuthService(repo)
+
+		result, err := svc.GetUserByID(ctx, user.ID)
+		assert.Nil(t, result)
+		assertAppError(t, err, dto.ErrUserNotFound)
+	})
 }
 
 // --------------------------------------------------------------------------
@@ -480,6 +653,31 @@ func TestChangePassword(t *testing.T) {
 		assert.True(t, svc.VerifyPassword("newPassword456", updatedHash))
 	})
 
+	t.Run("revokes refresh tokens", func(t *testing.T) {
+		svc := newTestAuthService(&testutil.MockUserRepository{})
+		hash, err := svc.HashPassword("oldPassword123")
+		require.NoError(t, err)
+
+		user := testUser()
+		user.PasswordHash = hash
+
+		repo := &testutil.MockUserRepository{
+			GetByIDFn: func(_ context.Context, id string) (*domain.User, error) {
+				return user, nil
+			},
+		}
+		refreshRepo, store := newRefreshTokenStore()
+		svc = service.NewAuthService(repo, refreshRepo, testJWTSecret)
+
+		_, err = svc.GenerateRefreshToken(ctx, user)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.ChangePassword(ctx, user.ID, "oldPassword123", "newPassword456"))
+		for _, token := range store {
+			assert.True(t, token.IsRevoked(), "sessions from before the change are signed out")
+		}
+	})
+
 	t.Run("wrong current password", func(t *testing.T) {
 		svc := newTestAuthService(&testutil.MockUserRepository{})
 		hash, err := svc.HashPassword("correctOldPassword")
@@ -528,8 +726,8 @@ func TestChangePassword(t *testing.T) {
 		svc = newTestAuthService(repo)
 
 		err = svc.ChangePassword(ctx, user.ID, currentPassword, "short")
-		// HashPassword will fail, which triggers ErrInternalError
-		assertAppError(t, err, dto.ErrInternal)
+		assertAppError(t, err, dto.ErrValidation)
+		assert.Contains(t, err.Error(), "at least 6 characters")
 	})
 
 	t.Run("new password too long", func(t *testing.T) {
@@ -549,7 +747,7 @@ func TestChangePassword(t *testing.T) {
 		svc = newTestAuthService(repo)
 
 		err = svc.ChangePassword(ctx, user.ID, currentPassword, strings.Repeat("a", 73))
-		assertAppError(t, err, dto.ErrInternal)
+		assertAppError(t, err, dto.ErrValidation)
 	})
 
 	t.Run("repo UpdatePassword error", func(t *testing.T) {
@@ -576,10 +774,389 @@ func TestChangePassword(t *testing.T) {
 	})
 }
 
+// --------------------------------------------------------------------------
+// Password reset
+// --------------------------------------------------------------------------
+
+func TestRequestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("known email returns purpose-bound token", func(t *testing.T) {
+		user := testUser()
+		user.PasswordHash = "hash"
+		repo := &testutil.MockUserRepository{
+			GetByEmailFn: func(_ context.Context, email string) (*domain.User, error) {
+				return user, nil
+			},
+		}
+		svc := newTestAuthService(repo)
+
+		got, token, err := svc.RequestPasswordReset(ctx, user.Email)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.NotEmpty(t, token)
+
+		// Reset tokens must not work as access tokens
+		_, err = svc.ValidateToken(token)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("unknown email returns nil user without error", func(t *testing.T) {
+		svc := newTestAuthService(&testutil.MockUserRepository{})
+
+		got, token, err := svc.RequestPasswordReset(ctx, "nobody@example.com")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+		assert.Empty(t, token)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		repo := &testutil.MockUserRepository{
+			GetByEmailFn: func(_ context.Context, email string) (*domain.User, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		svc := newTestAuthService(repo)
+
+		_, _, err := svc.RequestPasswordReset(ctx, "employee@example.com")
+		assertAppError(t, err, dto.ErrInternal)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	newResetFixture := func(t *testing.T) (*service.AuthService, *domain.User, *string) {
+		t.Helper()
+		user := testUser()
+		user.PasswordHash = "$2a$10$originalhash"
+		var updatedHash string
+		repo := &testutil.MockUserRepository{
+			GetByIDFn: func(_ context.Context, id string) (*domain.User, error) {
+				if id == user.ID {
+					return user, nil
+				}
+				return nil, nil
+			},
+			UpdatePasswordFn: func(_ context.Context, id, passwordHash string) error {
+				updatedHash = passwordHash
+				user.PasswordHash = passwordHash
+				return nil
+			},
+		}
+		return newTestAuthService(repo), user, &updatedHash
+	}
+
+	t.Run("success", func(t *testing.T) {
+		svc, user, updatedHash := newResetFixture(t)
+		token, err := svc.GeneratePasswordResetToken(user)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.ResetPassword(ctx, token, "brandNewPass1"))
+		assert.True(t, svc.VerifyPassword("brandNewPass1", *updatedHash))
+	})
+
+	t.Run("token cannot be reused after reset", func(t *testing.T) {
+		svc, user, _ := newResetFixture(t)
+		token, err := svc.GeneratePasswordResetToken(user)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.ResetPassword(ctx, token, "brandNewPass1"))
+		err = svc.ResetPassword(ctx, token, "anotherPass2")
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("access token rejected", func(t *testing.T) {
+		svc, user, updatedHash := newResetFixture(t)
+		token, err := svc.GenerateToken(user)
+		require.NoError(t, err)
+
+		err = svc.ResetPassword(ctx, token, "brandNewPass1")
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+		assert.Empty(t, *updatedHash)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		svc, user, _ := newResetFixture(t)
+		claims := service.JWTClaims{
+			UserID:  user.ID,
+			Purpose: service.TokenPurposePasswordReset,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+			},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+		require.NoError(t, err)
+
+		err = svc.ResetPassword(ctx, token, "brandNewPass1")
+		assertAppError(t, err, dto.ErrAuthTokenExpired)
+	})
+
+	t.Run("password too short", func(t *testing.T) {
+		svc, user, _ := newResetFixture(t)
+		token, err := svc.GeneratePasswordResetToken(user)
+		require.NoError(t, err)
+
+		err = svc.ResetPassword(ctx, token, "short")
+		assertAppError(t, err, dto.ErrValidation)
+	})
+}
+
+// --------------------------------------------------------------------------
+// RequestEmailChange / ConfirmEmailChange
+// --------------------------------------------------------------------------
+
+func TestEmailChange(t *testing.T) {
+	ctx := context.Background()
+
+	// newEmailChangeFixture returns a service whose repo stores the pending email on the user;
+	// addresses added to the returned set belong to other accounts
+	newEmailChangeFixture := func(t *testing.T) (*service.AuthService, *domain.User, map[string]bool) {
+		t.Helper()
+		user := testUser()
+		hash, err := newTestAuthService(&testutil.MockUserRepository{}).HashPassword("currentPass1")
+		require.NoError(t, err)
+		user.PasswordHash = hash
+
+		taken := make(map[string]bool)
+		repo := &testutil.MockUserRepository{
+			GetByIDFn: func(_ context.Context, id string) (*domain.User, error) {
+				if id == user.ID {
+					copied := *user
+					return &copied, nil
+				}
+				return nil, nil
+			},
+			EmailExistsExcludingFn: func(_ context.Context, email, excludeID string) (bool, error) {
+				return taken[email], nil
+			},
+			SetPendingEmailFn: func(_ context.Context, id, email string) error {
+				user.PendingEmail = &email
+				return nil
+			},
+			ConfirmPendingEmailFn: func(_ context.Context, id, email string) error {
+				if user.PendingEmail == nil || *user.PendingEmail != email {
+					return sql.ErrNoRows
+				}
+				user.Email = email
+				user.PendingEmail = nil
+				return nil
+			},
+		}
+		return newTestAuthService(repo), user, taken
+	}
+
+	t.Run("login email only changes once confirmed", func(t *testing.T) {
+		svc, user, _ := newEmailChangeFixture(t)
+
+		_, token, err := svc.RequestEmailChange(ctx, user.ID, "currentPass1", "new@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "employee@example.com", user.Email)
+		require.NotNil(t, user.PendingEmail)
+		assert.Equal(t, "new@example.com", *user.PendingEmail)
+
+		updated, previousEmail, err := svc.ConfirmEmailChange(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, "employee@example.com", previousEmail)
+		assert.Equal(t, "new@example.com", updated.Email)
+		assert.Nil(t, updated.PendingEmail)
+		assert.Equal(t, "new@example.com", user.Email)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		svc, user, _ := newEmailChangeFixture(t)
+
+		_, _, err := svc.RequestEmailChange(ctx, user.ID, "wrongPass1", "new@example.com")
+		assertAppError(t, err, dto.ErrInvalidCredentials)
+		assert.Nil(t, user.PendingEmail)
+	})
+
+	t.Run("same as current email", func(t *testing.T) {
+		svc, user, _ := newEmailChangeFixture(t)
+
+		_, _, err := svc.RequestEmailChange(ctx, user.ID, "currentPass1", user.Email)
+		assertAppError(t, err, dto.ErrValidation)
+	})
+
+	t.Run("email used by another account", func(t *testing.T) {
+		svc, user, taken := newEmailChangeFixture(t)
+		taken["taken@example.com"] = true
+
+		_, _, err := svc.RequestEmailChange(ctx, user.ID, "currentPass1", "taken@example.com")
+		assertAppError(t, err, dto.ErrAlreadyExists)
+		assert.Nil(t, user.PendingEmail)
+	})
+
+	t.Run("email taken before confirmation", func(t *testing.T) {
+		svc, user, taken := newEmailChangeFixture(t)
+
+		_, token, err := svc.RequestEmailChange(ctx, user.ID, "currentPass1", "new@example.com")
+		require.NoError(t, err)
+		taken["new@example.com"] = true
+
+		_, _, err = svc.ConfirmEmailChange(ctx, token)
+		assertAppError(t, err, dto.ErrAlreadyExists)
+		assert.Equal(t, "employee@example.com", user.Email)
+	})
+
+	t.Run("email held by a deleted account", func(t *testing.T) {
+		user := testUser()
+		pending := "new@example.com"
+		user.PendingEmail = &pending
+		// The address can be claimed between the existence check and the update
+		svc := newTestAuthService(&testutil.MockUserRepository{
+			GetByIDFn: func(_ context.Context, _ string) (*domain.User, error) {
+				copied := *user
+				return &copied, nil
+			},
+			ConfirmPendingEmailFn: func(_ context.Context, _, _ string) error {
+				return repository.ErrEmailTaken
+			},
+		})
+
+		token, err := svc.GenerateEmailChangeToken(user, pending)
+		require.NoError(t, err)
+
+		_, _, err = svc.ConfirmEmailChange(ctx, token)
+		assertAppError(t, err, dto.ErrAlreadyExists)
+	})
+
+	t.Run("superseded by a newer request", func(t *testing.T) {
+		svc, user, _ := newEmailChangeFixture(t)
+
+		_, first, err := svc.RequestEmailChange(ctx, user.ID, "currentPass1", "first@example.com")
+		require.NoError(t, err)
+		_, _, err = svc.RequestEmailChange(ctx, user.ID, "currentPass1", "second@example.com")
+		require.NoError(t, err)
+
+		_, _, err = svc.ConfirmEmailChange(ctx, first)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+		assert.Equal(t, "employee@example.com", user.Email)
+	})
+
+	t.Run("token cannot be reused", func(t *testing.T) {
+		svc, user, _ := newEmailChangeFixture(t)
+
+		_, token, err := svc.RequestEmailChange(ctx, user.ID, "currentPass1", "new@example.com")
+		require.NoError(t, err)
+		_, _, err = svc.ConfirmEmailChange(ctx, token)
+		require.NoError(t, err)
+
+		_, _, err = svc.ConfirmEmailChange(ctx, token)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("other token purposes rejected", func(t *testing.T) {
+		svc, user, _ := newEmailChangeFixture(t)
+		pending := "new@example.com"
+		user.PendingEmail = &pending
+
+		resetToken, err := svc.GeneratePasswordResetToken(user)
+		require.NoError(t, err)
+		_, _, err = svc.ConfirmEmailChange(ctx, resetToken)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+
+		accessToken, err := svc.GenerateToken(user)
+		require.NoError(t, err)
+		_, _, err = svc.ConfirmEmailChange(ctx, accessToken)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("token is not an access token", func(t *testing.T) {
+		svc, user, _ := newEmailChangeFixture(t)
+		token, err := svc.GenerateEmailChangeToken(user, "new@example.com")
+		require.NoError(t, err)
+
+		_, err = svc.ValidateToken(token)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+}
+
 // --------------------------------------------------------------------------
 // UpdateEmailPreferences
 // --------------------------------------------------------------------------
 
+func TestUnsubscribe(t *testing.T) {
+	ctx := context.Background()
+
+	newUnsubscribeFixture := func(t *testing.T) (*service.AuthService, *domain.User, *domain.EmailPreferences) {
+		t.Helper()
+		user := testUser()
+		user.EmailPreferences = domain.EmailPreferences{VacationUpdates: true, WeeklyDigest: true, TeamNotifications: true}
+		saved := &domain.EmailPreferences{}
+		repo := &testutil.MockUserRepository{
+			GetByIDFn: func(_ context.Context, id string) (*domain.User, error) {
+				if id == user.ID {
+					return user, nil
+				}
+				return nil, nil
+			},
+			UpdateEmailPreferencesFn: func(_ context.Context, id string, prefs domain.EmailPreferences) error {
+				*saved = prefs
+				return nil
+			},
+		}
+		return newTestAuthService(repo), user, saved
+	}
+
+	t.Run("disables only the named preference", func(t *testing.T) {
+		svc, user, saved := newUnsubscribeFixture(t)
+		token, err := svc.GenerateUnsubscribeToken(user, domain.EmailPrefWeeklyDigest)
+		require.NoError(t, err)
+
+		updated, preference, err := svc.Unsubscribe(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, domain.EmailPrefWeeklyDigest, preference)
+		assert.False(t, updated.EmailPreferences.WeeklyDigest)
+		assert.Equal(t, domain.EmailPreferences{VacationUpdates: true, WeeklyDigest: false, TeamNotifications: true}, *saved)
+	})
+
+	t.Run("unsubscribe token is not an access token", func(t *testing.T) {
+		svc, user, _ := newUnsubscribeFixture(t)
+		token, err := svc.GenerateUnsubscribeToken(user, domain.EmailPrefWeeklyDigest)
+		require.NoError(t, err)
+
+		_, err = svc.ValidateToken(token)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("access token rejected", func(t *testing.T) {
+		svc, user, saved := newUnsubscribeFixture(t)
+		token, err := svc.GenerateToken(user)
+		require.NoError(t, err)
+
+		_, _, err = svc.Unsubscribe(ctx, token)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+		assert.Equal(t, domain.EmailPreferences{}, *saved)
+	})
+
+	t.Run("unknown preference rejected", func(t *testing.T) {
+		svc, user, _ := newUnsubscribeFixture(t)
+		token, err := svc.GenerateUnsubscribeToken(user, "everything")
+		require.NoError(t, err)
+
+		_, _, err = svc.Unsubscribe(ctx, token)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		svc, user, _ := newUnsubscribeFixture(t)
+		claims := service.JWTClaims{
+			UserID:     user.ID,
+			Purpose:    service.TokenPurposeUnsubscribe,
+			Preference: domain.EmailPrefWeeklyDigest,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+			},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+		require.NoError(t, err)
+
+		_, _, err = svc.Unsubscribe(ctx, token)
+		assertAppError(t, err, dto.ErrAuthTokenExpired)
+	})
+}
+
 func TestUpdateEmailPreferences(t *testing.T) {
 	ctx := context.Background()
 
@@ -619,7 +1196,7 @@ func TestUpdateEmailPreferences(t *testing.T) {
 
 		// Check that only VacationUpdates was changed in saved prefs
 		assert.False(t, savedPrefs.VacationUpdates)
-		assert.False(t, savedPrefs.WeeklyDigest)    // unchanged
+		assert.False(t, savedPrefs.WeeklyDigest)     // unchanged
 		assert.True(t, savedPrefs.TeamNotifications) // unchanged
 	})
 
@@ -789,6 +1366,9 @@ func TestCreateInitialAdmin(t *testing.T) {
 		// Verify default email preferences
 		defaults := domain.DefaultEmailPreferences()
 		assert.Equal(t, defaults, createdUser.EmailPreferences)
+
+		// The configured password must be changed on first login
+		assert.True(t, createdUser.MustChangePassword)
 	})
 
 	t.Run("does nothing when admin already exists", func(t *testing.T) {
@@ -852,10 +1432,190 @@ func TestCreateInitialAdmin(t *testing.T) {
 	})
 }
 
+// --------------------------------------------------------------------------
+// Refresh tokens
+// --------------------------------------------------------------------------
+
+// newRefreshTokenStore returns a mock refresh token repository backed by a map.
+func newRefreshTokenStore() (*testutil.MockRefreshTokenRepository, map[string]*domain.RefreshToken) {
+	byHash := make(map[string]*domain.RefreshToken)
+	repo := &testutil.MockRefreshTokenRepository{
+		CreateFn: func(_ context.Context, token *domain.RefreshToken) error {
+			byHash[token.TokenHash] = token
+			return nil
+		},
+		GetByHashFn: func(_ context.Context, tokenHash string) (*domain.RefreshToken, error) {
+			return byHash[tokenHash], nil
+		},
+		RevokeFn: func(_ context.Context, id string) (bool, error) {
+			for _, token := range byHash {
+				if token.ID == id && token.RevokedAt == nil {
+					now := time.Now()
+					token.RevokedAt = &now
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		RevokeAllForUserFn: func(_ context.Context, userID string) error {
+			now := time.Now()
+			for _, token := range byHash {
+				if token.UserID == userID && token.RevokedAt == nil {
+					token.RevokedAt = &now
+				}
+			}
+			return nil
+		},
+	}
+	return repo, byHash
+}
+
+func TestRefreshAccessToken(t *testing.T) {
+	ctx := context.Background()
+	user := testUser()
+	userRepo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, id string) (*domain.User, error) {
+			if id == user.ID {
+				return user, nil
+			}
+			return nil, nil
+		},
+	}
+
+	t.Run("rotates refresh token", func(t *testing.T) {
+		refreshRepo, store := newRefreshTokenStore()
+		svc := service.NewAuthService(userRepo, refreshRepo, testJWTSecret)
+
+		refreshToken, err := svc.GenerateRefreshToken(ctx, user)
+		require.NoError(t, err)
+		require.Len(t, store, 1)
+		for hash := range store {
+			assert.NotEqual(t, refreshToken, hash, "only the hash is stored")
+		}
+
+		accessToken, newRefreshToken, got, err := svc.RefreshAccessToken(ctx, refreshToken)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, got.ID)
+		assert.NotEqual(t, refreshToken, newRefreshToken)
+
+		claims, err := svc.ValidateToken(accessToken)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, claims.UserID)
+
+		// The old token was rotated out
+		_, _, _, err = svc.RefreshAccessToken(ctx, refreshToken)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("reuse of rotated token revokes all tokens", func(t *testing.T) {
+		refreshRepo, _ := newRefreshTokenStore()
+		svc := service.NewAuthService(userRepo, refreshRepo, testJWTSecret)
+
+		first, err := svc.GenerateRefreshToken(ctx, user)
+		require.NoError(t, err)
+		_, second, _, err := svc.RefreshAccessToken(ctx, first)
+		require.NoError(t, err)
+
+		_, _, _, err = svc.RefreshAccessToken(ctx, first)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+
+		_, _, _, err = svc.RefreshAccessToken(ctx, second)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		refreshRepo, store := newRefreshTokenStore()
+		svc := service.NewAuthService(userRepo, refreshRepo, testJWTSecret)
+
+		refreshToken, err := svc.GenerateRefreshToken(ctx, user)
+		require.NoError(t, err)
+		for _, token := range store {
+			token.ExpiresAt = time.Now().Add(-time.Minute)
+		}
+
+		_, _, _, err = svc.RefreshAccessToken(ctx, refreshToken)
+		assertAppError(t, err, dto.ErrAuthTokenExpired)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		refreshRepo, _ := newRefreshTokenStore()
+		svc := service.NewAuthService(userRepo, refreshRepo, testJWTSecret)
+
+		_, _, _, err := svc.RefreshAccessToken(ctx, "not-a-real-token")
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+
+	t.Run("access token is not a refresh token", func(t *testing.T) {
+		refreshRepo, _ := newRefreshTokenStore()
+		svc := service.NewAuthService(userRepo, refreshRepo, testJWTSecret)
+
+		accessToken, err := svc.GenerateToken(user)
+		require.NoError(t, err)
+
+		_, _, _, err = svc.RefreshAccessToken(ctx, accessToken)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+	})
+}
+
 // --------------------------------------------------------------------------
 // Integration-style: Login then ValidateToken round-trip
 // --------------------------------------------------------------------------
 
+func TestLogout(t *testing.T) {
+	ctx := context.Background()
+	user := testUser()
+
+	t.Run("revokes the access token and the caller's refresh token", func(t *testing.T) {
+		refreshRepo, _ := newRefreshTokenStore()
+		svc := service.NewAuthService(&testutil.MockUserRepository{}, refreshRepo, testJWTSecret)
+
+		accessToken, err := svc.GenerateToken(user)
+		require.NoError(t, err)
+		otherToken, err := svc.GenerateToken(user)
+		require.NoError(t, err)
+		refreshToken, err := svc.GenerateRefreshToken(ctx, user)
+		require.NoError(t, err)
+
+		claims, err := svc.ValidateToken(accessToken)
+		require.NoError(t, err)
+		require.NotEmpty(t, claims.ID)
+
+		require.NoError(t, svc.Logout(ctx, claims, refreshToken))
+
+		_, err = svc.ValidateToken(accessToken)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+		_, _, _, err = svc.RefreshAccessToken(ctx, refreshToken)
+		assertAppError(t, err, dto.ErrAuthTokenInvalid)
+
+		// Other sessions of the same user stay logged in
+		_, err = svc.ValidateToken(otherToken)
+		require.NoError(t, err)
+
+		// Logging out twice is harmless
+		require.NoError(t, svc.Logout(ctx, claims, refreshToken))
+	})
+
+	t.Run("leaves another user's refresh token alone", func(t *testing.T) {
+		refreshRepo, store := newRefreshTokenStore()
+		svc := service.NewAuthService(&testutil.MockUserRepository{}, refreshRepo, testJWTSecret)
+
+		other := testUser()
+		other.ID = "usr_other"
+		otherRefresh, err := svc.GenerateRefreshToken(ctx, other)
+		require.NoError(t, err)
+
+		accessToken, err := svc.GenerateToken(user)
+		require.NoError(t, err)
+		claims, err := svc.ValidateToken(accessToken)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.Logout(ctx, claims, otherRefresh))
+		for _, token := range store {
+			assert.Nil(t, token.RevokedAt)
+		}
+	})
+}
+
 func TestLoginAndValidateTokenRoundTrip(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"vacaytracker-api/internal/domain"
+)
+
+// calendarProdID identifies this application in exported calendars
+const calendarProdID = "-//VacayTracker//Vacation Calendar//EN"
+
+// CalendarEvent is a single all-day event in an exported calendar
+type CalendarEvent struct {
+	UID       string
+	Summary   string
+	StartDate string // Format: YYYY-MM-DD
+	EndDate   string // Format: YYYY-MM-DD (inclusive)
+}
+
+// TeamVacationEvents converts team vacations into calendar events
+func TeamVacationEvents(vacations []*domain.TeamVacation) []CalendarEvent {
+	events := make([]CalendarEvent, 0, len(vacations))
+	for _, v := range vacations {
+		events = append(events, CalendarEvent{
+			UID:       v.ID,
+			Summary:   v.UserName + " - " + calendarLabel(v.Type),
+			StartDate: v.StartDate,
+			EndDate:   v.EndDate,
+		})
+	}
+	return events
+}
+
+// VacationRequestEvents converts vacation requests into calendar events
+func VacationRequestEvents(requests []*domain.VacationRequest) []CalendarEvent {
+	events := make([]CalendarEvent, 0, len(requests))
+	for _, r := range requests {
+		summary := calendarLabel(r.Type)
+		if r.Reason != nil && *r.Reason != "" {
+			summary += ": " + *r.Reason
+		}
+		events = append(events, CalendarEvent{
+			UID:       r.ID,
+			Summary:   summary,
+			StartDate: r.StartDate,
+			EndDate:   r.EndDate,
+		})
+	}
+	return events
+}
+
+// calendarLabel names a request type in event summaries, so remote days don't read as leave
+// Requests stored before types existed are vacations
+func calendarLabel(requestType domain.RequestType) string {
+	switch requestType {
+	case "", domain.RequestTypeVacation:
+		return "Vacation"
+	case domain.RequestTypeRemote:
+		return "Remote"
+	default:
+		name := string(requestType)
+		return strings.ToUpper(name[:1]) + name[1:]
+	}
+}
+
+// RenderICalendar renders events as an RFC 5545 VCALENDAR document
+// Events with unparseable dates are skipped
+func RenderICalendar(name string, events []CalendarEvent, now time.Time) string {
+	var b strings.Builder
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	writeLine := func(line string) {
+		b.WriteString(foldICalLine(line))
+		b.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:" + calendarProdID)
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("METHOD:PUBLISH")
+	writeLine("X-WR-CALNAME:" + escapeICalText(name))
+
+	for _, e := range events {
+		start, err := time.Parse("2006-01-02", e.StartDate)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse("2006-01-02", e.EndDate)
+		if err != nil {
+			continue
+		}
+
+		writeLine("BEGIN:VEVENT")
+		// Stable UID so calendar clients de-duplicate on refresh
+		writeLine("UID:" + e.UID + "@vacaytracker")
+		writeLine("DTSTAMP:" + stamp)
+		writeLine("DTSTART;VALUE=DATE:" + start.Format("20060102"))
+		// All-day DTEND is exclusive, so the event ends the day after EndDate
+		writeLine("DTEND;VALUE=DATE:" + end.AddDate(0, 0, 1).Format("20060102"))
+		writeLine("SUMMARY:" + escapeICalText(e.Summary))
+		writeLine("TRANSP:TRANSPARENT")
+		writeLine("END:VEVENT")
+	}
+
+	writeLine("END:VCALENDAR")
+	return b.String()
+}
+
+// escapeICalText escapes TEXT values per RFC 5545 section 3.3.11
+func escapeICalText(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(s)
+}
+
+// foldICalLine folds content lines longer than 75 octets per RFC 5545 section 3.1
+func foldICalLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1 // the leading space counts towards the next line
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
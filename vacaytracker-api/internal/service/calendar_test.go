@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/service"
+)
+
+func TestRenderICalendar_AllDayEvents(t *testing.T) {
+	now := time.Date(2027, 5, 1, 12, 0, 0, 0, time.UTC)
+	events := service.TeamVacationEvents([]*domain.TeamVacation{
+		{ID: "vac-1", UserName: "Alice", StartDate: "2027-06-14", EndDate: "2027-06-18"},
+	})
+
+	ics := service.RenderICalendar("Team Vacations", events, now)
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "VERSION:2.0\r\n")
+	assert.Contains(t, ics, "UID:vac-1@vacaytracker\r\n")
+	assert.Contains(t, ics, "DTSTAMP:20270501T120000Z\r\n")
+	assert.Contains(t, ics, "DTSTART;VALUE=DATE:20270614\r\n")
+	// DTEND is exclusive for all-day events
+	assert.Contains(t, ics, "DTEND;VALUE=DATE:20270619\r\n")
+	assert.Contains(t, ics, "SUMMARY:Alice - Vacation\r\n")
+	assert.Equal(t, 1, strings.Count(ics, "BEGIN:VEVENT"))
+}
+
+func TestRenderICalendar_EscapesAndFolds(t *testing.T) {
+	reason := "Trip; beach, sun " + strings.Repeat("x", 80)
+	events := service.VacationRequestEvents([]*domain.VacationRequest{
+		{ID: "vac-2", StartDate: "2027-07-01", EndDate: "2027-07-01", Reason: &reason},
+	})
+
+	ics := service.RenderICalendar("My Vacations", events, time.Now())
+
+	assert.Contains(t, ics, `SUMMARY:Vacation: Trip\; beach\, sun`)
+	assert.Contains(t, ics, "DTEND;VALUE=DATE:20270702\r\n")
+	for _, line := range strings.Split(ics, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+}
+
+func TestRenderICalendar_RemoteDaysLabelled(t *testing.T) {
+	events := service.TeamVacationEvents([]*domain.TeamVacation{
+		{ID: "vac-1", UserName: "Alice", StartDate: "2027-06-14", EndDate: "2027-06-14", Type: domain.RequestTypeRemote},
+		{ID: "vac-2", UserName: "Bob", StartDate: "2027-06-14", EndDate: "2027-06-18", Type: domain.RequestTypeVacation},
+	})
+	ics := service.RenderICalendar("Team Vacations", events, time.Now())
+
+	assert.Contains(t, ics, "SUMMARY:Alice - Remote\r\n")
+	assert.Contains(t, ics, "SUMMARY:Bob - Vacation\r\n")
+
+	events = service.VacationRequestEvents([]*domain.VacationRequest{
+		{ID: "vac-3", StartDate: "2027-07-01", EndDate: "2027-07-01", Type: domain.RequestTypeRemote},
+	})
+	ics = service.RenderICalendar("My Vacations", events, time.Now())
+
+	assert.Contains(t, ics, "SUMMARY:Remote\r\n")
+	assert.NotContains(t, ics, "Vacation\r\n")
+}
+
+func TestRenderICalendar_Empty(t *testing.T) {
+	ics := service.RenderICalendar("Team Vacations", nil, time.Now())
+
+	assert.Contains(t, ics, "BEGIN:VCALENDAR")
+	assert.NotContains(t, ics, "BEGIN:VEVENT")
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/repository"
+)
+
+// CommentService handles the discussion thread on vacation requests
+// Only the request owner and admins may read or write a thread
+type CommentService struct {
+	commentRepo  repository.CommentRepository
+	vacationRepo repository.VacationRepository
+	userRepo     repository.UserRepository
+}
+
+// NewCommentService creates a new CommentService
+func NewCommentService(commentRepo repository.CommentRepository, vacationRepo repository.VacationRepository, userRepo repository.UserRepository) *CommentService {
+	return &CommentService{
+		commentRepo:  commentRepo,
+		vacationRepo: vacationRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// List returns the comments on a request, oldest first
+func (s *CommentService) List(ctx context.Context, requestID, userID string, isAdmin bool) ([]*domain.RequestComment, error) {
+	if _, err := s.authorizedRequest(ctx, requestID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.ListByRequest(ctx, requestID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list comments")
+	}
+	if comments == nil {
+		comments = []*domain.RequestComment{}
+	}
+	return comments, nil
+}
+
+// Add posts a comment on a request and returns it with the request it belongs to
+func (s *CommentService) Add(ctx context.Context, requestID, userID string, isAdmin bool, body string) (*domain.RequestComment, *domain.VacationRequest, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, nil, dto.ErrValidationError("comment must not be empty")
+	}
+
+	request, err := s.authorizedRequest(ctx, requestID, userID, isAdmin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, dto.ErrInternalErrorWithMessage("failed to get user")
+	}
+	if author == nil {
+		return nil, nil, dto.ErrNotFoundError("user")
+	}
+
+	comment := &domain.RequestComment{
+		RequestID:  requestID,
+		AuthorID:   userID,
+		AuthorName: author.Name,
+		Body:       body,
+	}
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return nil, nil, dto.ErrInternalErrorWithMessage("failed to add comment")
+	}
+	return comment, request, nil
+}
+
+// authorizedRequest loads a request the user may comment on
+func (s *CommentService) authorizedRequest(ctx context.Context, requestID, userID string, isAdmin bool) (*domain.VacationRequest, error) {
+	request, err := s.vacationRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation request")
+	}
+	if request == nil {
+		return nil, dto.ErrNotFoundError("vacation request")
+	}
+	if request.UserID != userID && !isAdmin {
+		return nil, dto.ErrForbiddenError("you can only comment on your own requests")
+	}
+	return request, nil
+}
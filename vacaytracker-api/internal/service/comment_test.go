@@ -0,0 +1,77 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/service"
+	"vacaytracker-api/internal/testutil"
+)
+
+// =========================================================================
+// CommentService
+// =========================================================================
+
+func newCommentService(commentRepo *testutil.MockCommentRepository) *service.CommentService {
+	vacationRepo := &testutil.MockVacationRepository{
+		GetByIDFn: func(_ context.Context, id string) (*domain.VacationRequest, error) {
+			if id != "vac-1" {
+				return nil, nil
+			}
+			return &domain.VacationRequest{ID: id, UserID: "owner-1", Status: domain.StatusPending}, nil
+		},
+	}
+	userRepo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, id string) (*domain.User, error) {
+			return &domain.User{ID: id, Name: "Admin"}, nil
+		},
+	}
+	return service.NewCommentService(commentRepo, vacationRepo, userRepo)
+}
+
+func TestCommentAdd_AdminOnAnyRequest(t *testing.T) {
+	var created *domain.RequestComment
+	svc := newCommentService(&testutil.MockCommentRepository{
+		CreateFn: func(_ context.Context, comment *domain.RequestComment) error {
+			created = comment
+			return nil
+		},
+	})
+
+	comment, request, err := svc.Add(context.Background(), "vac-1", "admin-1", true, " Please confirm cover ")
+
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, "Please confirm cover", comment.Body)
+	assert.Equal(t, "Admin", comment.AuthorName)
+	assert.Equal(t, "owner-1", request.UserID)
+}
+
+func TestCommentAdd_EmptyBody(t *testing.T) {
+	svc := newCommentService(&testutil.MockCommentRepository{})
+
+	_, _, err := svc.Add(context.Background(), "vac-1", "owner-1", false, "  ")
+
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
+func TestCommentList_ForbiddenForOtherEmployees(t *testing.T) {
+	svc := newCommentService(&testutil.MockCommentRepository{})
+
+	_, err := svc.List(context.Background(), "vac-1", "someone-else", false)
+
+	assertVacationAppError(t, err, dto.ErrForbidden)
+}
+
+func TestCommentList_RequestNotFound(t *testing.T) {
+	svc := newCommentService(&testutil.MockCommentRepository{})
+
+	_, err := svc.List(context.Background(), "missing", "owner-1", false)
+
+	assertVacationAppError(t, err, dto.ErrNotFound)
+}
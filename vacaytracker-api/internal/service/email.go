@@ -9,7 +9,9 @@ import (
 	"html/template"
 	"log"
 	"math"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/resend/resend-go/v2"
@@ -22,20 +24,100 @@ import (
 type EmailService struct {
 	cfg    *config.Config
 	client *resend.Client
+	queue  *EmailQueue // nil sends directly from a goroutine
 
 	// Pre-compiled templates for performance
-	welcomeHTMLTmpl        *template.Template
-	welcomeTextTmpl        *template.Template
-	requestSubmittedHTML   *template.Template
-	requestSubmittedText   *template.Template
-	requestApprovedHTML    *template.Template
-	requestApprovedText    *template.Template
-	requestRejectedHTML    *template.Template
-	requestRejectedText    *template.Template
-	adminNewRequestHTML    *template.Template
-	adminNewRequestText    *template.Template
+	// locales is keyed by locale and always contains domain.DefaultLocale
+	locales                map[string]*localeTemplates
 	newsletterHTMLTmpl     *template.Template
 	newsletterTextTmpl     *template.Template
+	personalDigestHTMLTmpl *template.Template
+	personalDigestTextTmpl *template.Template
+	passwordResetHTMLTmpl  *template.Template
+	passwordResetTextTmpl  *template.Template
+	emailChangeHTMLTmpl    *template.Template
+	emailChangeTextTmpl    *template.Template
+	emailChangedHTMLTmpl   *template.Template
+	emailChangedTextTmpl   *template.Template
+	lowBalanceHTMLTmpl     *template.Template
+	lowBalanceTextTmpl     *template.Template
+	teammateOffHTMLTmpl    *template.Template
+	teammateOffTextTmpl    *template.Template
+
+	// teammateOffSent holds recent teammate-off send times per recipient for throttling
+	teammateOffMu   sync.Mutex
+	teammateOffSent map[string][]time.Time
+}
+
+// Teammate-off emails are throttled per recipient so a batch of approvals doesn't flood inboxes
+const (
+	teammateOffEmailLimit  = 3
+	teammateOffEmailWindow = time.Hour
+)
+
+// localeTemplates holds the subjects and pre-compiled templates of one locale
+type localeTemplates struct {
+	welcomeSubject          string
+	welcomeHTML             *template.Template
+	welcomeText             *template.Template
+	requestSubmittedSubject string
+	requestSubmittedHTML    *template.Template
+	requestSubmittedText    *template.Template
+	requestApprovedSubject  string
+	requestApprovedHTML     *template.Template
+	requestApprovedText     *template.Template
+	requestRejectedSubject  string
+	requestRejectedHTML     *template.Template
+	requestRejectedText     *template.Template
+	adminNewRequestSubject  string
+	adminNewRequestHTML     *template.Template
+	adminNewRequestText     *template.Template
+	adminWithdrawnSubject   string
+	adminWithdrawnHTML      *template.Template
+	adminWithdrawnText      *template.Template
+	requestCommentSubject   string
+	requestCommentHTML      *template.Template
+	requestCommentText      *template.Template
+	leaveReminderSubject    string
+	leaveReminderHTML       *template.Template
+	leaveReminderText       *template.Template
+}
+
+// localeTemplateSource holds the raw subjects and template strings of one locale
+type localeTemplateSource struct {
+	welcomeSubject, welcomeHTML, welcomeText                            string
+	requestSubmittedSubject, requestSubmittedHTML, requestSubmittedText string
+	requestApprovedSubject, requestApprovedHTML, requestApprovedText    string
+	requestRejectedSubject, requestRejectedHTML, requestRejectedText    string
+	adminNewRequestSubject, adminNewRequestHTML, adminNewRequestText    string
+	adminWithdrawnSubject, adminWithdrawnHTML, adminWithdrawnText       string
+	requestCommentSubject, requestCommentHTML, requestCommentText       string
+	leaveReminderSubject, leaveReminderHTML, leaveReminderText          string
+}
+
+// localeTemplateSources lists the translated templates for each supported locale
+// Password reset, low balance, teammate off, newsletter and personal digest emails are English-only for now
+var localeTemplateSources = map[string]localeTemplateSource{
+	domain.LocaleEnglish: {
+		welcomeEmailSubject, welcomeEmailHTML, welcomeEmailText,
+		requestSubmittedSubject, requestSubmittedHTML, requestSubmittedText,
+		requestApprovedSubject, requestApprovedHTML, requestApprovedText,
+		requestRejectedSubject, requestRejectedHTML, requestRejectedText,
+		adminNewRequestSubject, adminNewRequestHTML, adminNewRequestText,
+		adminRequestWithdrawnSubject, adminRequestWithdrawnHTML, adminRequestWithdrawnText,
+		requestCommentSubject, requestCommentHTML, requestCommentText,
+		leaveReminderSubject, leaveReminderHTML, leaveReminderText,
+	},
+	domain.LocaleGerman: {
+		welcomeEmailSubjectDE, welcomeEmailHTMLDE, welcomeEmailTextDE,
+		requestSubmittedSubjectDE, requestSubmittedHTMLDE, requestSubmittedTextDE,
+		requestApprovedSubjectDE, requestApprovedHTMLDE, requestApprovedTextDE,
+		requestRejectedSubjectDE, requestRejectedHTMLDE, requestRejectedTextDE,
+		adminNewRequestSubjectDE, adminNewRequestHTMLDE, adminNewRequestTextDE,
+		adminRequestWithdrawnSubjectDE, adminRequestWithdrawnHTMLDE, adminRequestWithdrawnTextDE,
+		requestCommentSubjectDE, requestCommentHTMLDE, requestCommentTextDE,
+		leaveReminderSubjectDE, leaveReminderHTMLDE, leaveReminderTextDE,
+	},
 }
 
 // Retry configuration
@@ -48,7 +130,8 @@ const (
 // NewEmailService creates a new EmailService with pre-compiled templates
 func NewEmailService(cfg *config.Config) *EmailService {
 	svc := &EmailService{
-		cfg: cfg,
+		cfg:             cfg,
+		teammateOffSent: make(map[string][]time.Time),
 	}
 
 	// Initialize Resend client if API key is configured
@@ -66,67 +149,129 @@ func NewEmailService(cfg *config.Config) *EmailService {
 func (s *EmailService) compileTemplates() {
 	var err error
 
-	// Welcome email templates
-	s.welcomeHTMLTmpl, err = template.New("welcomeHTML").Parse(welcomeEmailHTML)
+	// Localized templates
+	s.locales = make(map[string]*localeTemplates, len(localeTemplateSources))
+	for locale, src := range localeTemplateSources {
+		s.locales[locale] = compileLocaleTemplates(locale, src)
+	}
+
+	// Newsletter templates
+	s.newsletterHTMLTmpl, err = template.New("newsletterHTML").Parse(newsletterHTML)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile welcome HTML template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile newsletter HTML template: %v", err)
 	}
-	s.welcomeTextTmpl, err = template.New("welcomeText").Parse(welcomeEmailText)
+	s.newsletterTextTmpl, err = template.New("newsletterText").Parse(newsletterText)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile welcome text template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile newsletter text template: %v", err)
 	}
 
-	// Request submitted templates
-	s.requestSubmittedHTML, err = template.New("requestSubmittedHTML").Parse(requestSubmittedHTML)
+	// Personal digest templates
+	s.personalDigestHTMLTmpl, err = template.New("personalDigestHTML").Parse(personalDigestHTML)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile request submitted HTML template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile personal digest HTML template: %v", err)
 	}
-	s.requestSubmittedText, err = template.New("requestSubmittedText").Parse(requestSubmittedText)
+	s.personalDigestTextTmpl, err = template.New("personalDigestText").Parse(personalDigestText)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile request submitted text template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile personal digest text template: %v", err)
 	}
 
-	// Request approved templates
-	s.requestApprovedHTML, err = template.New("requestApprovedHTML").Parse(requestApprovedHTML)
+	// Password reset templates
+	s.passwordResetHTMLTmpl, err = template.New("passwordResetHTML").Parse(passwordResetHTML)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile request approved HTML template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile password reset HTML template: %v", err)
 	}
-	s.requestApprovedText, err = template.New("requestApprovedText").Parse(requestApprovedText)
+	s.passwordResetTextTmpl, err = template.New("passwordResetText").Parse(passwordResetText)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile request approved text template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile password reset text template: %v", err)
 	}
 
-	// Request rejected templates
-	s.requestRejectedHTML, err = template.New("requestRejectedHTML").Parse(requestRejectedHTML)
+	// Email change templates
+	s.emailChangeHTMLTmpl, err = template.New("emailChangeHTML").Parse(emailChangeHTML)
+	if err != nil {
+		log.Printf("[EMAIL] Warning: Failed to compile email change HTML template: %v", err)
+	}
+	s.emailChangeTextTmpl, err = template.New("emailChangeText").Parse(emailChangeText)
+	if err != nil {
+		log.Printf("[EMAIL] Warning: Failed to compile email change text template: %v", err)
+	}
+	s.emailChangedHTMLTmpl, err = template.New("emailChangedHTML").Parse(emailChangedHTML)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile request rejected HTML template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile email changed HTML template: %v", err)
 	}
-	s.requestRejectedText, err = template.New("requestRejectedText").Parse(requestRejectedText)
+	s.emailChangedTextTmpl, err = template.New("emailChangedText").Parse(emailChangedText)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile request rejected text template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile email changed text template: %v", err)
 	}
 
-	// Admin new request templates
-	s.adminNewRequestHTML, err = template.New("adminNewRequestHTML").Parse(adminNewRequestHTML)
+	// Low balance templates
+	s.lowBalanceHTMLTmpl, err = template.New("lowBalanceHTML").Parse(lowBalanceHTML)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile admin new request HTML template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile low balance HTML template: %v", err)
 	}
-	s.adminNewRequestText, err = template.New("adminNewRequestText").Parse(adminNewRequestText)
+	s.lowBalanceTextTmpl, err = template.New("lowBalanceText").Parse(lowBalanceText)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile admin new request text template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile low balance text template: %v", err)
 	}
 
-	// Newsletter templates
-	s.newsletterHTMLTmpl, err = template.New("newsletterHTML").Parse(newsletterHTML)
+	// Teammate off templates
+	s.teammateOffHTMLTmpl, err = template.New("teammateOffHTML").Parse(teammateOffHTML)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile newsletter HTML template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile teammate off HTML template: %v", err)
 	}
-	s.newsletterTextTmpl, err = template.New("newsletterText").Parse(newsletterText)
+	s.teammateOffTextTmpl, err = template.New("teammateOffText").Parse(teammateOffText)
 	if err != nil {
-		log.Printf("[EMAIL] Warning: Failed to compile newsletter text template: %v", err)
+		log.Printf("[EMAIL] Warning: Failed to compile teammate off text template: %v", err)
 	}
 }
 
+// compileLocaleTemplates pre-compiles one locale's templates
+// A template that fails to compile is left nil and the matching send is skipped
+func compileLocaleTemplates(locale string, src localeTemplateSource) *localeTemplates {
+	parse := func(name, text string) *template.Template {
+		tmpl, err := template.New(locale + "/" + name).Parse(text)
+		if err != nil {
+			log.Printf("[EMAIL] Warning: Failed to compile %s template for locale %s: %v", name, locale, err)
+			return nil
+		}
+		return tmpl
+	}
+
+	return &localeTemplates{
+		welcomeSubject:          src.welcomeSubject,
+		welcomeHTML:             parse("welcomeHTML", src.welcomeHTML),
+		welcomeText:             parse("welcomeText", src.welcomeText),
+		requestSubmittedSubject: src.requestSubmittedSubject,
+		requestSubmittedHTML:    parse("requestSubmittedHTML", src.requestSubmittedHTML),
+		requestSubmittedText:    parse("requestSubmittedText", src.requestSubmittedText),
+		requestApprovedSubject:  src.requestApprovedSubject,
+		requestApprovedHTML:     parse("requestApprovedHTML", src.requestApprovedHTML),
+		requestApprovedText:     parse("requestApprovedText", src.requestApprovedText),
+		requestRejectedSubject:  src.requestRejectedSubject,
+		requestRejectedHTML:     parse("requestRejectedHTML", src.requestRejectedHTML),
+		requestRejectedText:     parse("requestRejectedText", src.requestRejectedText),
+		adminNewRequestSubject:  src.adminNewRequestSubject,
+		adminNewRequestHTML:     parse("adminNewRequestHTML", src.adminNewRequestHTML),
+		adminNewRequestText:     parse("adminNewRequestText", src.adminNewRequestText),
+		adminWithdrawnSubject:   src.adminWithdrawnSubject,
+		adminWithdrawnHTML:      parse("adminWithdrawnHTML", src.adminWithdrawnHTML),
+		adminWithdrawnText:      parse("adminWithdrawnText", src.adminWithdrawnText),
+		requestCommentSubject:   src.requestCommentSubject,
+		requestCommentHTML:      parse("requestCommentHTML", src.requestCommentHTML),
+		requestCommentText:      parse("requestCommentText", src.requestCommentText),
+		leaveReminderSubject:    src.leaveReminderSubject,
+		leaveReminderHTML:       parse("leaveReminderHTML", src.leaveReminderHTML),
+		leaveReminderText:       parse("leaveReminderText", src.leaveReminderText),
+	}
+}
+
+// templatesFor returns the templates for a locale, falling back to English
+func (s *EmailService) templatesFor(locale string) *localeTemplates {
+	if t, ok := s.locales[locale]; ok {
+		return t
+	}
+	return s.locales[domain.DefaultLocale]
+}
+
 // SendOptions contains optional parameters for sending emails
 type SendOptions struct {
 	IdempotencyKey string   // Prevents duplicate sends within 24 hours
@@ -146,32 +291,7 @@ func (s *EmailService) Send(ctx context.Context, to, subject, htmlBody, textBody
 		return nil
 	}
 
-	fromAddress := fmt.Sprintf("%s <%s>", s.cfg.EmailFromName, s.cfg.EmailFromAddress)
-
-	params := &resend.SendEmailRequest{
-		From:    fromAddress,
-		To:      []string{to},
-		Subject: subject,
-		Html:    htmlBody,
-		Text:    textBody,
-	}
-
-	// Apply optional parameters
-	if opts != nil {
-		if opts.ReplyTo != "" {
-			params.ReplyTo = opts.ReplyTo
-		}
-		if len(opts.Tags) > 0 {
-			tags := make([]resend.Tag, len(opts.Tags))
-			for i, tag := range opts.Tags {
-				tags[i] = resend.Tag{
-					Name:  tag, // Each tag name must be unique
-					Value: "true",
-				}
-			}
-			params.Tags = tags
-		}
-	}
+	params := s.buildSendRequest(to, subject, htmlBody, textBody, opts)
 
 	// Execute with retry logic
 	var lastErr error
@@ -209,6 +329,37 @@ func (s *EmailService) Send(ctx context.Context, to, subject, htmlBody, textBody
 	return fmt.Errorf("email failed after %d retries: %w", maxRetries, lastErr)
 }
 
+// buildSendRequest assembles the Resend request for one email
+// The configured EMAIL_REPLY_TO applies to every email unless the caller names a more specific reply-to
+func (s *EmailService) buildSendRequest(to, subject, htmlBody, textBody string, opts *SendOptions) *resend.SendEmailRequest {
+	params := &resend.SendEmailRequest{
+		From:    s.cfg.EmailFrom(),
+		To:      []string{to},
+		Subject: subject,
+		Html:    htmlBody,
+		Text:    textBody,
+		ReplyTo: s.cfg.EmailReplyTo,
+	}
+
+	// Apply optional parameters
+	if opts != nil {
+		if opts.ReplyTo != "" {
+			params.ReplyTo = opts.ReplyTo
+		}
+		if len(opts.Tags) > 0 {
+			tags := make([]resend.Tag, len(opts.Tags))
+			for i, tag := range opts.Tags {
+				tags[i] = resend.Tag{
+					Name:  tag, // Each tag name must be unique
+					Value: "true",
+				}
+			}
+			params.Tags = tags
+		}
+	}
+	return params
+}
+
 // sendEmail sends an email via the Resend client
 // Note: IdempotencyKey in SendOptions is generated for logging/debugging but
 // not currently passed to Resend API (SDK v2 doesn't expose this header yet)
@@ -277,11 +428,30 @@ func generateIdempotencyKey(to, subject string, uniqueData ...string) string {
 	return hex.EncodeToString(h.Sum(nil))[:32]
 }
 
+// SetQueue routes SendAsync through a persistent delivery queue
+func (s *EmailService) SetQueue(q *EmailQueue) {
+	s.queue = q
+}
+
+// RecentDeliveries returns the most recently queued emails with their delivery status
+// Returns an empty list when no queue is configured
+func (s *EmailService) RecentDeliveries(ctx context.Context, limit int) ([]*domain.EmailMessage, error) {
+	if s.queue == nil {
+		return []*domain.EmailMessage{}, nil
+	}
+	return s.queue.Recent(ctx, limit)
+}
+
 // SendAsync sends an email asynchronously (non-blocking)
+// With a queue configured the email is persisted and retried until delivered
 func (s *EmailService) SendAsync(to, subject, htmlBody, textBody string, opts *SendOptions) {
 	if !s.cfg.EmailEnabled() {
 		return
 	}
+	if s.queue != nil {
+		s.queue.Enqueue(to, subject, htmlBody, textBody, opts)
+		return
+	}
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 		defer cancel()
@@ -294,25 +464,28 @@ func (s *EmailService) SendAsync(to, subject, htmlBody, textBody string, opts *S
 
 // SendWelcome sends a welcome email to a new user with idempotency protection
 func (s *EmailService) SendWelcome(user *domain.User, tempPassword string) {
-	if s.welcomeHTMLTmpl == nil || s.welcomeTextTmpl == nil {
+	t := s.templatesFor(user.LocaleOrDefault())
+	if t.welcomeHTML == nil || t.welcomeText == nil {
 		log.Printf("[EMAIL ERROR] Welcome email templates not initialized")
 		return
 	}
 
 	data := welcomeEmailData{
 		AppURL:       s.cfg.AppURL,
+		AppName:      s.cfg.BrandName(),
+		LogoURL:      s.cfg.BrandLogoURL(),
 		UserName:     user.Name,
 		UserEmail:    user.Email,
 		TempPassword: tempPassword,
 	}
 
-	htmlBody, err := s.executeTemplate(s.welcomeHTMLTmpl, data)
+	htmlBody, err := s.executeTemplate(t.welcomeHTML, data)
 	if err != nil {
 		log.Printf("[EMAIL ERROR] Failed to render welcome email HTML: %v", err)
 		return
 	}
 
-	textBody, err := s.executeTemplate(s.welcomeTextTmpl, data)
+	textBody, err := s.executeTemplate(t.welcomeText, data)
 	if err != nil {
 		log.Printf("[EMAIL ERROR] Failed to render welcome email text: %v", err)
 		return
@@ -320,11 +493,239 @@ func (s *EmailService) SendWelcome(user *domain.User, tempPassword string) {
 
 	// Use idempotency key for welcome emails to prevent duplicate password emails
 	opts := &SendOptions{
-		IdempotencyKey: generateIdempotencyKey(user.Email, welcomeEmailSubject, user.ID),
+		IdempotencyKey: generateIdempotencyKey(user.Email, t.welcomeSubject, user.ID),
 		Tags:           []string{"welcome", "onboarding"},
 	}
 
-	s.SendAsync(user.Email, welcomeEmailSubject, htmlBody, textBody, opts)
+	s.SendAsync(user.Email, s.brandSubject(t.welcomeSubject), htmlBody, textBody, opts)
+}
+
+// SendPasswordReset sends a password reset link to a user
+// Sent regardless of email preferences since the user explicitly asked for it
+func (s *EmailService) SendPasswordReset(user *domain.User, token string) {
+	if s.passwordResetHTMLTmpl == nil || s.passwordResetTextTmpl == nil {
+		log.Printf("[EMAIL ERROR] Password reset email templates not initialized")
+		return
+	}
+
+	data := passwordResetEmailData{
+		AppURL:           s.cfg.AppURL,
+		AppName:          s.cfg.BrandName(),
+		LogoURL:          s.cfg.BrandLogoURL(),
+		UserName:         user.Name,
+		ResetURL:         s.cfg.AppURL + "/reset-password?token=" + url.QueryEscape(token),
+		ExpiresInMinutes: int(passwordResetExpiry / time.Minute),
+	}
+
+	htmlBody, err := s.executeTemplate(s.passwordResetHTMLTmpl, data)
+	if err != nil {
+		log.Printf("[EMAIL ERROR] Failed to render password reset email HTML: %v", err)
+		return
+	}
+
+	textBody, err := s.executeTemplate(s.passwordResetTextTmpl, data)
+	if err != nil {
+		log.Printf("[EMAIL ERROR] Failed to render password reset email text: %v", err)
+		return
+	}
+
+	opts := &SendOptions{
+		Tags: []string{"password-reset"},
+	}
+
+	s.SendAsync(user.Email, s.brandSubject(passwordResetSubject), htmlBody, textBody, opts)
+}
+
+// SendEmailChangeConfirmation sends a link confirming an email change to the new address
+// Sent regardless of email preferences since the user explicitly asked for it
+func (s *EmailService) SendEmailChangeConfirmation(user *domain.User, newEmail, token string) {
+	if s.emailChangeHTMLTmpl == nil || s.emailChangeTextTmpl == nil {
+		log.Printf("[EMAIL ERROR] Email change templates not initialized")
+		return
+	}
+
+	data := emailChangeEmailData{
+		AppURL:         s.cfg.AppURL,
+		AppName:        s.cfg.BrandName(),
+		LogoURL:        s.cfg.BrandLogoURL(),
+		UserName:       user.Name,
+		NewEmail:       newEmail,
+		ConfirmURL:     s.cfg.AppURL + "/confirm-email?token=" + url.QueryEscape(token),
+		ExpiresInHours: int(emailChangeExpiry / time.Hour),
+	}
+
+	htmlBody, err := s.executeTemplate(s.emailChangeHTMLTmpl, data)
+	if err != nil {
+		log.Printf("[EMAIL ERROR] Failed to render email change HTML: %v", err)
+		return
+	}
+
+	textBody, err := s.executeTemplate(s.emailChangeTextTmpl, data)
+	if err != nil {
+		log.Printf("[EMAIL ERROR] Failed to render email change text: %v", err)
+		return
+	}
+
+	opts := &SendOptions{
+		Tags: []string{"email-change"},
+	}
+
+	s.SendAsync(newEmail, s.brandSubject(emailChangeSubject), htmlBody, textBody, opts)
+}
+
+// SendEmailChangedNotice tells the previous address that the account's email was changed
+// Sent regardless of email preferences so the owner notices a change they didn't make
+func (s *EmailService) SendEmailChangedNotice(user *domain.User, oldEmail string) {
+	if s.emailChangedHTMLTmpl == nil || s.emailChangedTextTmpl == nil {
+		log.Printf("[EMAIL ERROR] Email changed templates not initialized")
+		return
+	}
+
+	data := emailChangedEmailData{
+		AppURL:   s.cfg.AppURL,
+		AppName:  s.cfg.BrandName(),
+		LogoURL:  s.cfg.BrandLogoURL(),
+		UserName: user.Name,
+		OldEmail: oldEmail,
+		NewEmail: user.Email,
+	}
+
+	htmlBody, err := s.executeTemplate(s.emailChangedHTMLTmpl, data)
+	if err != nil {
+		log.Printf("[EMAIL ERROR] Failed to render email changed HTML: %v", err)
+		return
+	}
+
+	textBody, err := s.executeTemplate(s.emailChangedTextTmpl, data)
+	if err != nil {
+		log.Printf("[EMAIL ERROR] Failed to render email changed text: %v", err)
+		return
+	}
+
+	opts := &SendOptions{
+		Tags: []string{"email-change"},
+	}
+
+	s.SendAsync(oldEmail, s.brandSubject(emailChangedSubject), htmlBody, textBody, opts)
+}
+
+// SendLowBalance tells a recipient that an approval left employee's balance at or below the low threshold
+// The recipient is the employee or their manager; vacation is the approved request that crossed it
+func (s *EmailService) SendLowBalance(recipient, employee *domain.User, vacation *domain.VacationRequest) {
+	if vacation.LowBalanceAlert == nil {
+		return
+	}
+	if !recipient.EmailPreferences.VacationUpdates {
+		log.Printf("[EMAIL] Skipping low balance email for %s - user preferences disabled", recipient.Email)
+		return
+	}
+	if s.lowBalanceHTMLTmpl == nil || s.lowBalanceTextTmpl == nil {
+		log.Printf("[EMAIL ERROR] Low balance email templates not initialized")
+		return
+	}
+
+	data := lowBalanceEmailData{
+		AppURL:        s.cfg.AppURL,
+		AppName:       s.cfg.BrandName(),
+		LogoURL:       s.cfg.BrandLogoURL(),
+		RecipientName: recipient.Name,
+		EmployeeName:  employee.Name,
+		Balance:       vacation.LowBalanceAlert.Balance,
+		Unit:          string(vacation.LowBalanceAlert.Unit),
+		ForManager:    recipient.ID != employee.ID,
+	}
+
+	htmlBody, err := s.executeTemplate(s.lowBalanceHTMLTmpl, data)
+	if err != nil {
+		log.Printf("[EMAIL ERROR] Failed to render low balance email HTML: %v", err)
+		return
+	}
+
+	textBody, err := s.executeTemplate(s.lowBalanceTextTmpl, data)
+	if err != nil {
+		log.Printf("[EMAIL ERROR] Failed to render low balance email text: %v", err)
+		return
+	}
+
+	opts := &SendOptions{
+		IdempotencyKey: generateIdempotencyKey(recipient.Email, lowBalanceSubject, vacation.ID),
+		Tags:           []string{"vacation", "low-balance"},
+	}
+
+	s.SendAsync(recipient.Email, s.brandSubject(lowBalanceSubject), htmlBody, textBody, opts)
+}
+
+// SendTeammateOff tells each teammate who opted into team notifications that employee will be off
+// The employee themselves and recipients over the throttle limit are skipped
+func (s *EmailService) SendTeammateOff(teammates []*domain.User, employee *domain.User, vacation *domain.VacationRequest) {
+	if s.teammateOffHTMLTmpl == nil || s.teammateOffTextTmpl == nil {
+		log.Printf("[EMAIL ERROR] Teammate off email templates not initialized")
+		return
+	}
+
+	for _, teammate := range teammates {
+		if teammate.ID == employee.ID {
+			continue
+		}
+		if !teammate.EmailPreferences.TeamNotifications {
+			log.Printf("[EMAIL] Skipping teammate off email for %s - user preferences disabled", teammate.Email)
+			continue
+		}
+		if !s.allowTeammateOff(teammate.ID, time.Now()) {
+			log.Printf("[EMAIL] Skipping teammate off email for %s - throttled", teammate.Email)
+			continue
+		}
+
+		data := teammateOffEmailData{
+			AppURL:        s.cfg.AppURL,
+			AppName:       s.cfg.BrandName(),
+			LogoURL:       s.cfg.BrandLogoURL(),
+			RecipientName: teammate.Name,
+			EmployeeName:  employee.Name,
+			StartDate:     vacation.StartDate,
+			EndDate:       vacation.EndDate,
+		}
+
+		htmlBody, err := s.executeTemplate(s.teammateOffHTMLTmpl, data)
+		if err != nil {
+			log.Printf("[EMAIL ERROR] Failed to render teammate off email HTML for %s: %v", teammate.Email, err)
+			continue
+		}
+
+		textBody, err := s.executeTemplate(s.teammateOffTextTmpl, data)
+		if err != nil {
+			log.Printf("[EMAIL ERROR] Failed to render teammate off email text for %s: %v", teammate.Email, err)
+			continue
+		}
+
+		opts := &SendOptions{
+			IdempotencyKey: generateIdempotencyKey(teammate.Email, teammateOffSubject, vacation.ID),
+			Tags:           []string{"vacation", "teammate-off"},
+		}
+
+		s.SendAsync(teammate.Email, s.brandSubject(teammateOffSubject), htmlBody, textBody, opts)
+	}
+}
+
+// allowTeammateOff records a teammate-off send to recipientID at now and reports whether
+// it stays within teammateOffEmailLimit for the trailing teammateOffEmailWindow
+func (s *EmailService) allowTeammateOff(recipientID string, now time.Time) bool {
+	s.teammateOffMu.Lock()
+	defer s.teammateOffMu.Unlock()
+
+	cutoff := now.Add(-teammateOffEmailWindow)
+	recent := s.teammateOffSent[recipientID][:0]
+	for _, sentAt := range s.teammateOffSent[recipientID] {
+		if sentAt.After(cutoff) {
+			recent = append(recent, sentAt)
+		}
+	}
+	if len(recent) >= teammateOffEmailLimit {
+		s.teammateOffSent[recipientID] = recent
+		return false
+	}
+	s.teammateOffSent[recipientID] = append(recent, now)
+	return true
 }
 
 // SendRequestSubmitted sends an email when a vacation request is submitted
@@ -334,37 +735,40 @@ func (s *EmailService) SendRequestSubmitted(user *domain.User, vacation *domain.
 		return
 	}
 
-	if s.requestSubmittedHTML == nil || s.requestSubmittedText == nil {
+	t := s.templatesFor(user.LocaleOrDefault())
+	if t.requestSubmittedHTML == nil || t.requestSubmittedText == nil {
 		log.Printf("[EMAIL ERROR] Request submitted email templates not initialized")
 		return
 	}
 
 	data := vacationEmailData{
 		AppURL:    s.cfg.AppURL,
+		AppName:   s.cfg.BrandName(),
+		LogoURL:   s.cfg.BrandLogoURL(),
 		UserName:  user.Name,
 		StartDate: vacation.StartDate,
 		EndDate:   vacation.EndDate,
 		TotalDays: vacation.TotalDays,
 	}
 
-	htmlBody, err := s.executeTemplate(s.requestSubmittedHTML, data)
+	htmlBody, err := s.executeTemplate(t.requestSubmittedHTML, data)
 	if err != nil {
 		log.Printf("[EMAIL ERROR] Failed to render request submitted email HTML: %v", err)
 		return
 	}
 
-	textBody, err := s.executeTemplate(s.requestSubmittedText, data)
+	textBody, err := s.executeTemplate(t.requestSubmittedText, data)
 	if err != nil {
 		log.Printf("[EMAIL ERROR] Failed to render request submitted email text: %v", err)
 		return
 	}
 
 	opts := &SendOptions{
-		IdempotencyKey: generateIdempotencyKey(user.Email, requestSubmittedSubject, vacation.ID),
+		IdempotencyKey: generateIdempotencyKey(user.Email, t.requestSubmittedSubject, vacation.ID),
 		Tags:           []string{"vacation", "submitted"},
 	}
 
-	s.SendAsync(user.Email, requestSubmittedSubject, htmlBody, textBody, opts)
+	s.SendAsync(user.Email, s.brandSubject(t.requestSubmittedSubject), htmlBody, textBody, opts)
 }
 
 // SendRequestApproved sends an email when a vacation request is approved
@@ -374,37 +778,91 @@ func (s *EmailService) SendRequestApproved(user *domain.User, vacation *domain.V
 		return
 	}
 
-	if s.requestApprovedHTML == nil || s.requestApprovedText == nil {
+	t := s.templatesFor(user.LocaleOrDefault())
+	if t.requestApprovedHTML == nil || t.requestApprovedText == nil {
 		log.Printf("[EMAIL ERROR] Request approved email templates not initialized")
 		return
 	}
 
 	data := vacationEmailData{
 		AppURL:    s.cfg.AppURL,
+		AppName:   s.cfg.BrandName(),
+		LogoURL:   s.cfg.BrandLogoURL(),
 		UserName:  user.Name,
 		StartDate: vacation.StartDate,
 		EndDate:   vacation.EndDate,
 		TotalDays: vacation.TotalDays,
 	}
+	if vacation.ApprovalComment != nil {
+		data.Reason = *vacation.ApprovalComment
+	}
+	if vacation.OriginalStartDate != nil && vacation.OriginalEndDate != nil {
+		data.OriginalStartDate = *vacation.OriginalStartDate
+		data.OriginalEndDate = *vacation.OriginalEndDate
+	}
 
-	htmlBody, err := s.executeTemplate(s.requestApprovedHTML, data)
+	htmlBody, err := s.executeTemplate(t.requestApprovedHTML, data)
 	if err != nil {
 		log.Printf("[EMAIL ERROR] Failed to render approved email HTML: %v", err)
 		return
 	}
 
-	textBody, err := s.executeTemplate(s.requestApprovedText, data)
+	textBody, err := s.executeTemplate(t.requestApprovedText, data)
 	if err != nil {
 		log.Printf("[EMAIL ERROR] Failed to render approved email text: %v", err)
 		return
 	}
 
 	opts := &SendOptions{
-		IdempotencyKey: generateIdempotencyKey(user.Email, requestApprovedSubject, vacation.ID, "approved"),
+		IdempotencyKey: generateIdempotencyKey(user.Email, t.requestApprovedSubject, vacation.ID, "approved"),
 		Tags:           []string{"vacation", "approved"},
 	}
 
-	s.SendAsync(user.Email, requestApprovedSubject, htmlBody, textBody, opts)
+	s.SendAsync(user.Email, s.brandSubject(t.requestApprovedSubject), htmlBody, textBody, opts)
+}
+
+// SendLeaveReminder reminds an employee the day before their approved leave starts
+func (s *EmailService) SendLeaveReminder(user *domain.User, vacation *domain.VacationRequest, unit domain.BalanceUnit) {
+	if !user.EmailPreferences.VacationUpdates {
+		log.Printf("[EMAIL] Skipping leave reminder for %s - user preferences disabled", user.Email)
+		return
+	}
+
+	t := s.templatesFor(user.LocaleOrDefault())
+	if t.leaveReminderHTML == nil || t.leaveReminderText == nil {
+		log.Printf("[EMAIL ERROR] Leave reminder email templates not initialized")
+		return
+	}
+
+	data := vacationEmailData{
+		AppURL:    s.cfg.AppURL,
+		AppName:   s.cfg.BrandName(),
+		LogoURL:   s.cfg.BrandLogoURL(),
+		UserName:  user.Name,
+		StartDate: vacation.StartDate,
+		EndDate:   vacation.EndDate,
+		TotalDays: vacation.TotalDays,
+		Unit:      string(unit),
+	}
+
+	htmlBody, err := s.executeTemplate(t.leaveReminderHTML, data)
+	if err != nil {
+		log.Printf("[EMAIL ERROR] Failed to render leave reminder HTML: %v", err)
+		return
+	}
+
+	textBody, err := s.executeTemplate(t.leaveReminderText, data)
+	if err != nil {
+		log.Printf("[EMAIL ERROR] Failed to render leave reminder text: %v", err)
+		return
+	}
+
+	opts := &SendOptions{
+		IdempotencyKey: generateIdempotencyKey(user.Email, t.leaveReminderSubject, vacation.ID),
+		Tags:           []string{"vacation", "leave-reminder"},
+	}
+
+	s.SendAsync(user.Email, s.brandSubject(t.leaveReminderSubject), htmlBody, textBody, opts)
 }
 
 // SendRequestRejected sends an email when a vacation request is rejected
@@ -414,13 +872,16 @@ func (s *EmailService) SendRequestRejected(user *domain.User, vacation *domain.V
 		return
 	}
 
-	if s.requestRejectedHTML == nil || s.requestRejectedText == nil {
+	t := s.templatesFor(user.LocaleOrDefault())
+	if t.requestRejectedHTML == nil || t.requestRejectedText == nil {
 		log.Printf("[EMAIL ERROR] Request rejected email templates not initialized")
 		return
 	}
 
 	data := vacationEmailData{
 		AppURL:    s.cfg.AppURL,
+		AppName:   s.cfg.BrandName(),
+		LogoURL:   s.cfg.BrandLogoURL(),
 		UserName:  user.Name,
 		StartDate: vacation.StartDate,
 		EndDate:   vacation.EndDate,
@@ -428,59 +889,129 @@ func (s *EmailService) SendRequestRejected(user *domain.User, vacation *domain.V
 		Reason:    reason,
 	}
 
-	htmlBody, err := s.executeTemplate(s.requestRejectedHTML, data)
+	htmlBody, err := s.executeTemplate(t.requestRejectedHTML, data)
 	if err != nil {
 		log.Printf("[EMAIL ERROR] Failed to render rejected email HTML: %v", err)
 		return
 	}
 
-	textBody, err := s.executeTemplate(s.requestRejectedText, data)
+	textBody, err := s.executeTemplate(t.requestRejectedText, data)
 	if err != nil {
 		log.Printf("[EMAIL ERROR] Failed to render rejected email text: %v", err)
 		return
 	}
 
 	opts := &SendOptions{
-		IdempotencyKey: generateIdempotencyKey(user.Email, requestRejectedSubject, vacation.ID, "rejected"),
+		IdempotencyKey: generateIdempotencyKey(user.Email, t.requestRejectedSubject, vacation.ID, "rejected"),
 		Tags:           []string{"vacation", "rejected"},
 	}
 
-	s.SendAsync(user.Email, requestRejectedSubject, htmlBody, textBody, opts)
+	s.SendAsync(user.Email, s.brandSubject(t.requestRejectedSubject), htmlBody, textBody, opts)
 }
 
 // SendAdminNewRequest sends an email to admins when a new vacation request is submitted
+// Each admin receives the notification in their own locale
 func (s *EmailService) SendAdminNewRequest(admins []*domain.User, requester *domain.User, vacation *domain.VacationRequest) {
-	if s.adminNewRequestHTML == nil || s.adminNewRequestText == nil {
-		log.Printf("[EMAIL ERROR] Admin new request email templates not initialized")
-		return
-	}
-
 	requestReason := ""
 	if vacation.Reason != nil {
 		requestReason = *vacation.Reason
 	}
 
+	data := adminNotificationData{
+		AppURL:         s.cfg.AppURL,
+		AppName:        s.cfg.BrandName(),
+		LogoURL:        s.cfg.BrandLogoURL(),
+		RequesterName:  requester.Name,
+		StartDate:      vacation.StartDate,
+		EndDate:        vacation.EndDate,
+		TotalDays:      vacation.TotalDays,
+		RequestReason:  requestReason,
+		OverlapWarning: vacation.OverlapWarning,
+	}
+
+	s.sendToAdmins(admins, requester, vacation, data, []string{"admin", "vacation-request"}, func(t *localeTemplates) (string, *template.Template, *template.Template) {
+		return t.adminNewRequestSubject, t.adminNewRequestHTML, t.adminNewRequestText
+	})
+}
+
+// SendAdminRequestWithdrawn notifies admins when an employee cancels an approved vacation
+func (s *EmailService) SendAdminRequestWithdrawn(admins []*domain.User, requester *domain.User, vacation *domain.VacationRequest) {
 	data := adminNotificationData{
 		AppURL:        s.cfg.AppURL,
+		AppName:       s.cfg.BrandName(),
+		LogoURL:       s.cfg.BrandLogoURL(),
 		RequesterName: requester.Name,
 		StartDate:     vacation.StartDate,
 		EndDate:       vacation.EndDate,
 		TotalDays:     vacation.TotalDays,
-		RequestReason: requestReason,
 	}
 
-	htmlBody, err := s.executeTemplate(s.adminNewRequestHTML, data)
+	s.sendToAdmins(admins, requester, vacation, data, []string{"admin", "vacation-withdrawn"}, func(t *localeTemplates) (string, *template.Template, *template.Template) {
+		return t.adminWithdrawnSubject, t.adminWithdrawnHTML, t.adminWithdrawnText
+	})
+}
+
+// SendRequestComment emails a new comment on a vacation request to one recipient
+// The request owner is governed by their vacation update preference, admins by team notifications
+func (s *EmailService) SendRequestComment(recipient *domain.User, vacation *domain.VacationRequest, comment *domain.RequestComment) {
+	isOwner := recipient.ID == vacation.UserID
+	if isOwner && !recipient.EmailPreferences.VacationUpdates || !isOwner && !recipient.EmailPreferences.TeamNotifications {
+		log.Printf("[EMAIL] Skipping comment email for %s - user preferences disabled", recipient.Email)
+		return
+	}
+
+	t := s.templatesFor(recipient.LocaleOrDefault())
+	if t.requestCommentHTML == nil || t.requestCommentText == nil {
+		log.Printf("[EMAIL ERROR] Request comment email templates not initialized")
+		return
+	}
+
+	path := "/admin"
+	if isOwner {
+		path = "/employee"
+	}
+	data := commentEmailData{
+		AppURL:        s.cfg.AppURL,
+		AppName:       s.cfg.BrandName(),
+		LogoURL:       s.cfg.BrandLogoURL(),
+		Path:          path,
+		RecipientName: recipient.Name,
+		AuthorName:    comment.AuthorName,
+		RequesterName: vacation.UserName,
+		StartDate:     vacation.StartDate,
+		EndDate:       vacation.EndDate,
+		Body:          comment.Body,
+	}
+
+	htmlBody, err := s.executeTemplate(t.requestCommentHTML, data)
 	if err != nil {
-		log.Printf("[EMAIL ERROR] Failed to render admin notification email HTML: %v", err)
+		log.Printf("[EMAIL ERROR] Failed to render comment email HTML: %v", err)
 		return
 	}
 
-	textBody, err := s.executeTemplate(s.adminNewRequestText, data)
+	textBody, err := s.executeTemplate(t.requestCommentText, data)
 	if err != nil {
-		log.Printf("[EMAIL ERROR] Failed to render admin notification email text: %v", err)
+		log.Printf("[EMAIL ERROR] Failed to render comment email text: %v", err)
 		return
 	}
 
+	opts := &SendOptions{
+		IdempotencyKey: generateIdempotencyKey(recipient.Email, t.requestCommentSubject, vacation.ID, comment.ID),
+		Tags:           []string{"vacation", "comment"},
+	}
+
+	s.SendAsync(recipient.Email, s.brandSubject(t.requestCommentSubject), htmlBody, textBody, opts)
+}
+
+// sendToAdmins renders an admin notification with the templates chosen by pick and
+// sends it to every admin who wants team notifications, each in their own locale
+func (s *EmailService) sendToAdmins(admins []*domain.User, requester *domain.User, vacation *domain.VacationRequest, data adminNotificationData, tags []string, pick func(t *localeTemplates) (string, *template.Template, *template.Template)) {
+	// Render once per locale rather than once per admin
+	type renderedEmail struct {
+		subject, htmlBody, textBody string
+	}
+	rendered := make(map[string]*renderedEmail)
+
 	for _, admin := range admins {
 		// Check if admin wants team notifications
 		if !admin.EmailPreferences.TeamNotifications {
@@ -488,16 +1019,47 @@ func (s *EmailService) SendAdminNewRequest(admins []*domain.User, requester *dom
 			continue
 		}
 
+		locale := admin.LocaleOrDefault()
+		email, ok := rendered[locale]
+		if !ok {
+			// A locale that fails to render only skips its own admins
+			subject, htmlTmpl, textTmpl := pick(s.templatesFor(locale))
+			if htmlTmpl == nil || textTmpl == nil {
+				log.Printf("[EMAIL ERROR] Admin notification email templates not initialized for locale %s", locale)
+				continue
+			}
+
+			htmlBody, err := s.executeTemplate(htmlTmpl, data)
+			if err != nil {
+				log.Printf("[EMAIL ERROR] Failed to render admin notification email HTML for %s: %v", admin.Email, err)
+				continue
+			}
+
+			textBody, err := s.executeTemplate(textTmpl, data)
+			if err != nil {
+				log.Printf("[EMAIL ERROR] Failed to render admin notification email text for %s: %v", admin.Email, err)
+				continue
+			}
+
+			email = &renderedEmail{subject: s.brandSubject(subject), htmlBody: htmlBody, textBody: textBody}
+			rendered[locale] = email
+		}
+
 		opts := &SendOptions{
-			IdempotencyKey: generateIdempotencyKey(admin.Email, adminNewRequestSubject, vacation.ID),
+			IdempotencyKey: generateIdempotencyKey(admin.Email, email.subject, vacation.ID),
 			ReplyTo:        requester.Email, // Allow admin to reply directly to requester
-			Tags:           []string{"admin", "vacation-request"},
+			Tags:           tags,
 		}
 
-		s.SendAsync(admin.Email, adminNewRequestSubject, htmlBody, textBody, opts)
+		s.SendAsync(admin.Email, email.subject, email.htmlBody, email.textBody, opts)
 	}
 }
 
+// brandSubject fills the configured app name into a subject line
+func (s *EmailService) brandSubject(subject string) string {
+	return strings.ReplaceAll(subject, "{{.AppName}}", s.cfg.BrandName())
+}
+
 // executeTemplate executes a pre-compiled template with the given data
 func (s *EmailService) executeTemplate(tmpl *template.Template, data interface{}) (string, error) {
 	if tmpl == nil {
@@ -522,6 +1084,16 @@ func (s *EmailService) RenderNewsletterText(data interface{}) (string, error) {
 	return s.executeTemplate(s.newsletterTextTmpl, data)
 }
 
+// RenderPersonalDigestHTML renders the personal digest HTML template with the given data
+func (s *EmailService) RenderPersonalDigestHTML(data interface{}) (string, error) {
+	return s.executeTemplate(s.personalDigestHTMLTmpl, data)
+}
+
+// RenderPersonalDigestText renders the personal digest text template with the given data
+func (s *EmailService) RenderPersonalDigestText(data interface{}) (string, error) {
+	return s.executeTemplate(s.personalDigestTextTmpl, data)
+}
+
 // EmailPreview contains the rendered email content for preview
 type EmailPreview struct {
 	Subject  string
@@ -531,25 +1103,28 @@ type EmailPreview struct {
 
 // PreviewWelcome renders a preview of the welcome email
 func (s *EmailService) PreviewWelcome(userName, userEmail, tempPassword, appURL string) (*EmailPreview, error) {
+	en := s.templatesFor(domain.DefaultLocale)
 	data := welcomeEmailData{
 		AppURL:       appURL,
+		AppName:      s.cfg.BrandName(),
+		LogoURL:      s.cfg.BrandLogoURL(),
 		UserName:     userName,
 		UserEmail:    userEmail,
 		TempPassword: tempPassword,
 	}
 
-	htmlBody, err := s.executeTemplate(s.welcomeHTMLTmpl, data)
+	htmlBody, err := s.executeTemplate(en.welcomeHTML, data)
 	if err != nil {
 		return nil, err
 	}
 
-	textBody, err := s.executeTemplate(s.welcomeTextTmpl, data)
+	textBody, err := s.executeTemplate(en.welcomeText, data)
 	if err != nil {
 		return nil, err
 	}
 
 	return &EmailPreview{
-		Subject:  welcomeEmailSubject,
+		Subject:  s.brandSubject(welcomeEmailSubject),
 		HTMLBody: htmlBody,
 		TextBody: textBody,
 	}, nil
@@ -557,26 +1132,29 @@ func (s *EmailService) PreviewWelcome(userName, userEmail, tempPassword, appURL
 
 // PreviewRequestSubmitted renders a preview of the request submitted email
 func (s *EmailService) PreviewRequestSubmitted(userName, startDate, endDate string, totalDays int, appURL string) (*EmailPreview, error) {
+	en := s.templatesFor(domain.DefaultLocale)
 	data := vacationEmailData{
 		AppURL:    appURL,
+		AppName:   s.cfg.BrandName(),
+		LogoURL:   s.cfg.BrandLogoURL(),
 		UserName:  userName,
 		StartDate: startDate,
 		EndDate:   endDate,
 		TotalDays: totalDays,
 	}
 
-	htmlBody, err := s.executeTemplate(s.requestSubmittedHTML, data)
+	htmlBody, err := s.executeTemplate(en.requestSubmittedHTML, data)
 	if err != nil {
 		return nil, err
 	}
 
-	textBody, err := s.executeTemplate(s.requestSubmittedText, data)
+	textBody, err := s.executeTemplate(en.requestSubmittedText, data)
 	if err != nil {
 		return nil, err
 	}
 
 	return &EmailPreview{
-		Subject:  requestSubmittedSubject,
+		Subject:  s.brandSubject(requestSubmittedSubject),
 		HTMLBody: htmlBody,
 		TextBody: textBody,
 	}, nil
@@ -584,26 +1162,29 @@ func (s *EmailService) PreviewRequestSubmitted(userName, startDate, endDate stri
 
 // PreviewRequestApproved renders a preview of the request approved email
 func (s *EmailService) PreviewRequestApproved(userName, startDate, endDate string, totalDays int, appURL string) (*EmailPreview, error) {
+	en := s.templatesFor(domain.DefaultLocale)
 	data := vacationEmailData{
 		AppURL:    appURL,
+		AppName:   s.cfg.BrandName(),
+		LogoURL:   s.cfg.BrandLogoURL(),
 		UserName:  userName,
 		StartDate: startDate,
 		EndDate:   endDate,
 		TotalDays: totalDays,
 	}
 
-	htmlBody, err := s.executeTemplate(s.requestApprovedHTML, data)
+	htmlBody, err := s.executeTemplate(en.requestApprovedHTML, data)
 	if err != nil {
 		return nil, err
 	}
 
-	textBody, err := s.executeTemplate(s.requestApprovedText, data)
+	textBody, err := s.executeTemplate(en.requestApprovedText, data)
 	if err != nil {
 		return nil, err
 	}
 
 	return &EmailPreview{
-		Subject:  requestApprovedSubject,
+		Subject:  s.brandSubject(requestApprovedSubject),
 		HTMLBody: htmlBody,
 		TextBody: textBody,
 	}, nil
@@ -611,8 +1192,11 @@ func (s *EmailService) PreviewRequestApproved(userName, startDate, endDate strin
 
 // PreviewRequestRejected renders a preview of the request rejected email
 func (s *EmailService) PreviewRequestRejected(userName, startDate, endDate string, totalDays int, reason, appURL string) (*EmailPreview, error) {
+	en := s.templatesFor(domain.DefaultLocale)
 	data := vacationEmailData{
 		AppURL:    appURL,
+		AppName:   s.cfg.BrandName(),
+		LogoURL:   s.cfg.BrandLogoURL(),
 		UserName:  userName,
 		StartDate: startDate,
 		EndDate:   endDate,
@@ -620,18 +1204,18 @@ func (s *EmailService) PreviewRequestRejected(userName, startDate, endDate strin
 		Reason:    reason,
 	}
 
-	htmlBody, err := s.executeTemplate(s.requestRejectedHTML, data)
+	htmlBody, err := s.executeTemplate(en.requestRejectedHTML, data)
 	if err != nil {
 		return nil, err
 	}
 
-	textBody, err := s.executeTemplate(s.requestRejectedText, data)
+	textBody, err := s.executeTemplate(en.requestRejectedText, data)
 	if err != nil {
 		return nil, err
 	}
 
 	return &EmailPreview{
-		Subject:  requestRejectedSubject,
+		Subject:  s.brandSubject(requestRejectedSubject),
 		HTMLBody: htmlBody,
 		TextBody: textBody,
 	}, nil
@@ -639,8 +1223,11 @@ func (s *EmailService) PreviewRequestRejected(userName, startDate, endDate strin
 
 // PreviewAdminNewRequest renders a preview of the admin notification email
 func (s *EmailService) PreviewAdminNewRequest(requesterName, startDate, endDate string, totalDays int, requestReason, appURL string) (*EmailPreview, error) {
+	en := s.templatesFor(domain.DefaultLocale)
 	data := adminNotificationData{
 		AppURL:        appURL,
+		AppName:       s.cfg.BrandName(),
+		LogoURL:       s.cfg.BrandLogoURL(),
 		RequesterName: requesterName,
 		StartDate:     startDate,
 		EndDate:       endDate,
@@ -648,18 +1235,18 @@ func (s *EmailService) PreviewAdminNewRequest(requesterName, startDate, endDate
 		RequestReason: requestReason,
 	}
 
-	htmlBody, err := s.executeTemplate(s.adminNewRequestHTML, data)
+	htmlBody, err := s.executeTemplate(en.adminNewRequestHTML, data)
 	if err != nil {
 		return nil, err
 	}
 
-	textBody, err := s.executeTemplate(s.adminNewRequestText, data)
+	textBody, err := s.executeTemplate(en.adminNewRequestText, data)
 	if err != nil {
 		return nil, err
 	}
 
 	return &EmailPreview{
-		Subject:  adminNewRequestSubject,
+		Subject:  s.brandSubject(adminNewRequestSubject),
 		HTMLBody: htmlBody,
 		TextBody: textBody,
 	}, nil
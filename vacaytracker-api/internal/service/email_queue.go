@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository"
+)
+
+// Email queue configuration
+const (
+	emailQueueSize          = 100
+	emailQueueMaxAttempts   = 5
+	emailQueueRetryDelay    = time.Minute
+	emailQueueMaxRetryDelay = time.Hour
+	emailQueueSendTimeout   = 2 * time.Minute
+	// emailQueuePendingGrace is how long a pending email may wait for the worker before
+	// the scheduler sweep picks it up (e.g. the buffer was full or the process restarted)
+	emailQueuePendingGrace = 5 * time.Minute
+	emailQueueSweepLimit   = 50
+)
+
+// EmailQueue persists outgoing emails and delivers them from a background worker
+// Failed deliveries are retried with backoff by RetryDue, which the scheduler calls periodically
+type EmailQueue struct {
+	repo repository.EmailOutboxRepository
+	jobs chan *domain.EmailMessage
+	done chan struct{}
+	wg   sync.WaitGroup
+	mu   sync.Mutex // serializes deliveries between the worker and the scheduler sweep
+
+	// send delivers a single email; defaults to EmailService.Send and is overridable in tests
+	send func(ctx context.Context, to, subject, htmlBody, textBody string, opts *SendOptions) error
+	now  func() time.Time
+}
+
+// NewEmailQueue creates a new EmailQueue delivering through the given EmailService
+// Call Start to run the worker and EmailService.SetQueue to route SendAsync through it
+func NewEmailQueue(emailService *EmailService, repo repository.EmailOutboxRepository) *EmailQueue {
+	return &EmailQueue{
+		repo: repo,
+		jobs: make(chan *domain.EmailMessage, emailQueueSize),
+		done: make(chan struct{}),
+		send: emailService.Send,
+		now:  time.Now,
+	}
+}
+
+// Start runs the delivery worker
+func (q *EmailQueue) Start() {
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		for {
+			select {
+			case msg := <-q.jobs:
+				q.deliver(context.Background(), msg)
+			case <-q.done:
+				return
+			}
+		}
+	}()
+
+	log.Println("[EMAIL] Delivery queue started")
+}
+
+// Stop stops the delivery worker; emails still buffered stay pending and are swept on next start
+func (q *EmailQueue) Stop() {
+	close(q.done)
+	q.wg.Wait()
+}
+
+// Enqueue persists an email and hands it to the worker without blocking
+func (q *EmailQueue) Enqueue(to, subject, htmlBody, textBody string, opts *SendOptions) {
+	nextAttempt := q.now().Add(emailQueuePendingGrace)
+	msg := &domain.EmailMessage{
+		ID:            uuid.New().String(),
+		Recipient:     to,
+		Subject:       subject,
+		HTMLBody:      htmlBody,
+		TextBody:      textBody,
+		Tags:          []string{},
+		Status:        domain.EmailStatusPending,
+		NextAttemptAt: &nextAttempt,
+	}
+	if opts != nil {
+		if len(opts.Tags) > 0 {
+			msg.Tags = opts.Tags
+		}
+		msg.IdempotencyKey = opts.IdempotencyKey
+		msg.ReplyTo = opts.ReplyTo
+	}
+
+	if err := q.repo.Create(context.Background(), msg); err != nil {
+		// Without a row there is nothing to retry from, so fall back to a direct send
+		log.Printf("[EMAIL ERROR] Failed to queue email to %s, sending directly: %v", to, err)
+		go q.deliver(context.Background(), msg)
+		return
+	}
+
+	select {
+	case q.jobs <- msg:
+	default:
+		log.Printf("[EMAIL] Queue full, email %s to %s left for the retry sweep", msg.ID, to)
+	}
+}
+
+// RetryDue delivers pending and retrying emails whose next attempt is due
+// Returns the number of emails attempted
+func (q *EmailQueue) RetryDue(ctx context.Context) (int, error) {
+	due, err := q.repo.ListDue(ctx, q.now(), emailQueueSweepLimit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, msg := range due {
+		q.deliver(ctx, msg)
+	}
+	return len(due), nil
+}
+
+// Recent returns the most recently queued emails, newest first
+func (q *EmailQueue) Recent(ctx context.Context, limit int) ([]*domain.EmailMessage, error) {
+	return q.repo.ListRecent(ctx, limit)
+}
+
+// deliver attempts to send an email and records the outcome
+func (q *EmailQueue) deliver(ctx context.Context, msg *domain.EmailMessage) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	sendCtx, cancel := context.WithTimeout(ctx, emailQueueSendTimeout)
+	err := q.send(sendCtx, msg.Recipient, msg.Subject, msg.HTMLBody, msg.TextBody, &SendOptions{
+		IdempotencyKey: msg.IdempotencyKey,
+		ReplyTo:        msg.ReplyTo,
+		Tags:           msg.Tags,
+	})
+	cancel()
+
+	now := q.now()
+	msg.Attempts++
+	if err == nil {
+		msg.Status = domain.EmailStatusSent
+		msg.LastError = ""
+		msg.NextAttemptAt = nil
+		msg.SentAt = &now
+	} else {
+		msg.LastError = err.Error()
+		if isRetryableError(err) && msg.Attempts < emailQueueMaxAttempts {
+			next := now.Add(emailQueueBackoff(msg.Attempts))
+			msg.Status = domain.EmailStatusRetrying
+			msg.NextAttemptAt = &next
+			log.Printf("[EMAIL] Delivery of %s to %s failed (attempt %d/%d), retrying at %s: %v",
+				msg.ID, msg.Recipient, msg.Attempts, emailQueueMaxAttempts, next.Format(time.RFC3339), err)
+		} else {
+			msg.Status = domain.EmailStatusFailed
+			msg.NextAttemptAt = nil
+			log.Printf("[EMAIL ERROR] Giving up on email %s to %s after %d attempt(s): %v",
+				msg.ID, msg.Recipient, msg.Attempts, err)
+		}
+	}
+
+	if err := q.repo.UpdateDelivery(context.Background(), msg); err != nil {
+		log.Printf("[EMAIL ERROR] Failed to record delivery status for %s: %v", msg.ID, err)
+	}
+}
+
+// emailQueueBackoff returns the delay before the next delivery attempt
+// Doubles from emailQueueRetryDelay per attempt, capped at emailQueueMaxRetryDelay
+func emailQueueBackoff(attempts int) time.Duration {
+	delay := float64(emailQueueRetryDelay) * math.Pow(2, float64(attempts-1))
+	if delay > float64(emailQueueMaxRetryDelay) {
+		delay = float64(emailQueueMaxRetryDelay)
+	}
+	return time.Duration(delay)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/config"
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/testutil"
+)
+
+// newTestEmailQueue returns a queue whose sends are answered by send and whose clock is fixed at now
+func newTestEmailQueue(repo *testutil.MockEmailOutboxRepository, now time.Time, send func(to string) error) *EmailQueue {
+	q := NewEmailQueue(NewEmailService(&config.Config{}), repo)
+	q.now = func() time.Time { return now }
+	q.send = func(_ context.Context, to, _, _, _ string, _ *SendOptions) error {
+		return send(to)
+	}
+	return q
+}
+
+func TestEmailQueue_DeliverSuccess(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	var updated *domain.EmailMessage
+	repo := &testutil.MockEmailOutboxRepository{
+		UpdateDeliveryFn: func(ctx context.Context, msg *domain.EmailMessage) error {
+			updated = msg
+			return nil
+		},
+	}
+	q := newTestEmailQueue(repo, now, func(string) error { return nil })
+
+	q.deliver(context.Background(), &domain.EmailMessage{ID: "m1", Recipient: "a@test.com", Status: domain.EmailStatusPending})
+
+	require.NotNil(t, updated)
+	assert.Equal(t, domain.EmailStatusSent, updated.Status)
+	assert.Equal(t, 1, updated.Attempts)
+	assert.Nil(t, updated.NextAttemptAt)
+	require.NotNil(t, updated.SentAt)
+	assert.True(t, updated.SentAt.Equal(now))
+}
+
+func TestEmailQueue_DeliverReplaysSendOptions(t *testing.T) {
+	var opts *SendOptions
+	q := newTestEmailQueue(&testutil.MockEmailOutboxRepository{}, time.Now(), nil)
+	q.send = func(_ context.Context, _, _, _, _ string, o *SendOptions) error {
+		opts = o
+		return nil
+	}
+
+	q.deliver(context.Background(), &domain.EmailMessage{
+		ID:             "m1",
+		Recipient:      "a@test.com",
+		Tags:           []string{"welcome"},
+		IdempotencyKey: "welcome-u1",
+		ReplyTo:        "hr@test.com",
+	})
+
+	require.NotNil(t, opts)
+	assert.Equal(t, "welcome-u1", opts.IdempotencyKey)
+	assert.Equal(t, "hr@test.com", opts.ReplyTo)
+	assert.Equal(t, []string{"welcome"}, opts.Tags)
+}
+
+func TestEmailQueue_DeliverTransientFailureSchedulesRetry(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	var updated *domain.EmailMessage
+	repo := &testutil.MockEmailOutboxRepository{
+		UpdateDeliveryFn: func(ctx context.Context, msg *domain.EmailMessage) error {
+			updated = msg
+			return nil
+		},
+	}
+	q := newTestEmailQueue(repo, now, func(string) error { return errors.New("503 service unavailable") })
+
+	q.deliver(context.Background(), &domain.EmailMessage{ID: "m1", Recipient: "a@test.com", Attempts: 1})
+
+	require.NotNil(t, updated)
+	assert.Equal(t, domain.EmailStatusRetrying, updated.Status)
+	assert.Equal(t, 2, updated.Attempts)
+	assert.Equal(t, "503 service unavailable", updated.LastError)
+	require.NotNil(t, updated.NextAttemptAt)
+	assert.Equal(t, now.Add(2*time.Minute), *updated.NextAttemptAt)
+}
+
+func TestEmailQueue_DeliverGivesUp(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		attempts int
+		err      error
+	}{
+		{"permanent error", 0, errors.New("422 invalid recipient")},
+		{"attempts exhausted", emailQueueMaxAttempts - 1, errors.New("timeout")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated *domain.EmailMessage
+			repo := &testutil.MockEmailOutboxRepository{
+				UpdateDeliveryFn: func(ctx context.Context, msg *domain.EmailMessage) error {
+					updated = msg
+					return nil
+				},
+			}
+			q := newTestEmailQueue(repo, now, func(string) error { return tt.err })
+
+			q.deliver(context.Background(), &domain.EmailMessage{ID: "m1", Attempts: tt.attempts})
+
+			require.NotNil(t, updated)
+			assert.Equal(t, domain.EmailStatusFailed, updated.Status)
+			assert.Nil(t, updated.NextAttemptAt)
+		})
+	}
+}
+
+func TestEmailQueue_EnqueuePersistsAndDelivers(t *testing.T) {
+	var mu sync.Mutex
+	var created *domain.EmailMessage
+	delivered := make(chan string, 1)
+	repo := &testutil.MockEmailOutboxRepository{
+		CreateFn: func(ctx context.Context, msg *domain.EmailMessage) error {
+			mu.Lock()
+			defer mu.Unlock()
+			copied := *msg
+			created = &copied
+			return nil
+		},
+	}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	q := newTestEmailQueue(repo, now, func(to string) error {
+		delivered <- to
+		return nil
+	})
+	q.Start()
+	defer q.Stop()
+
+	q.Enqueue("a@test.com", "Subject", "<p>hi</p>", "hi", &SendOptions{
+		IdempotencyKey: "welcome-u1",
+		ReplyTo:        "hr@test.com",
+		Tags:           []string{"welcome"},
+	})
+
+	select {
+	case to := <-delivered:
+		assert.Equal(t, "a@test.com", to)
+	case <-time.After(2 * time.Second):
+		t.Fatal("queued email was not delivered")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotNil(t, created)
+	assert.Equal(t, domain.EmailStatusPending, created.Status)
+	assert.Equal(t, []string{"welcome"}, created.Tags)
+	assert.Equal(t, "welcome-u1", created.IdempotencyKey)
+	assert.Equal(t, "hr@test.com", created.ReplyTo)
+	require.NotNil(t, created.NextAttemptAt)
+	assert.Equal(t, now.Add(emailQueuePendingGrace), *created.NextAttemptAt)
+}
+
+func TestEmailQueue_RetryDue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	var listedAt time.Time
+	var statuses []domain.EmailStatus
+	repo := &testutil.MockEmailOutboxRepository{
+		ListDueFn: func(ctx context.Context, at time.Time, limit int) ([]*domain.EmailMessage, error) {
+			listedAt = at
+			return []*domain.EmailMessage{
+				{ID: "ok", Recipient: "ok@test.com", Status: domain.EmailStatusRetrying, Attempts: 1},
+				{ID: "down", Recipient: "down@test.com", Status: domain.EmailStatusPending},
+			}, nil
+		},
+		UpdateDeliveryFn: func(ctx context.Context, msg *domain.EmailMessage) error {
+			statuses = append(statuses, msg.Status)
+			return nil
+		},
+	}
+	q := newTestEmailQueue(repo, now, func(to string) error {
+		if to == "down@test.com" {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	count, err := q.RetryDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.True(t, listedAt.Equal(now))
+	assert.Equal(t, []domain.EmailStatus{domain.EmailStatusSent, domain.EmailStatusRetrying}, statuses)
+}
+
+func TestEmailQueueBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, emailQueueBackoff(1))
+	assert.Equal(t, 2*time.Minute, emailQueueBackoff(2))
+	assert.Equal(t, 8*time.Minute, emailQueueBackoff(4))
+	assert.Equal(t, time.Hour, emailQueueBackoff(10))
+}
@@ -1,8 +1,11 @@
 package service
 
 // Email template data structures
+// AppName and LogoURL carry the configured branding; subjects may contain {{.AppName}}, filled in by brandSubject
 type welcomeEmailData struct {
 	AppURL       string
+	AppName      string
+	LogoURL      string
 	UserName     string
 	UserEmail    string
 	TempPassword string
@@ -10,31 +13,103 @@ type welcomeEmailData struct {
 
 type vacationEmailData struct {
 	AppURL    string
+	AppName   string
+	LogoURL   string
 	UserName  string
 	StartDate string
 	EndDate   string
 	TotalDays int
-	Reason    string // Only used for rejections
+	Unit      string // days or hours; only set where the template prints it
+	Reason    string // Rejection reason, or the approval comment for approvals
+
+	// Submitted range when the approval adjusted the dates; empty otherwise
+	OriginalStartDate string
+	OriginalEndDate   string
 }
 
-type adminNotificationData struct {
+type passwordResetEmailData struct {
+	AppURL           string
+	AppName          string
+	LogoURL          string
+	UserName         string
+	ResetURL         string
+	ExpiresInMinutes int
+}
+
+type emailChangeEmailData struct {
+	AppURL         string
+	AppName        string
+	LogoURL        string
+	UserName       string
+	NewEmail       string
+	ConfirmURL     string
+	ExpiresInHours int
+}
+
+type emailChangedEmailData struct {
+	AppURL   string
+	AppName  string
+	LogoURL  string
+	UserName string
+	OldEmail string
+	NewEmail string
+}
+
+type lowBalanceEmailData struct {
+	AppURL        string
+	AppName       string
+	LogoURL       string
+	RecipientName string
+	EmployeeName  string
+	Balance       int
+	Unit          string // days or hours
+	ForManager    bool   // Sent to the employee's manager rather than the employee
+}
+
+type teammateOffEmailData struct {
+	AppURL        string
+	AppName       string
+	LogoURL       string
+	RecipientName string
+	EmployeeName  string
+	StartDate     string
+	EndDate       string
+}
+
+type commentEmailData struct {
 	AppURL        string
+	AppName       string
+	LogoURL       string
+	Path          string // Where the request can be viewed, relative to AppURL
+	RecipientName string
+	AuthorName    string
 	RequesterName string
 	StartDate     string
 	EndDate       string
-	TotalDays     int
-	RequestReason string
+	Body          string
+}
+
+type adminNotificationData struct {
+	AppURL         string
+	AppName        string
+	LogoURL        string
+	RequesterName  string
+	StartDate      string
+	EndDate        string
+	TotalDays      int
+	RequestReason  string
+	OverlapWarning bool // The request overlaps another of the employee's requests
 }
 
 // Welcome email templates
-const welcomeEmailSubject = "Welcome to VacayTracker!"
+const welcomeEmailSubject = "Welcome to {{.AppName}}!"
 
 const welcomeEmailHTML = `<!DOCTYPE html>
 <html lang="en">
 <head>
     <meta charset="utf-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Welcome to VacayTracker</title>
+    <title>Welcome to {{.AppName}}</title>
     <!--[if mso]>
     <noscript>
         <xml>
@@ -48,7 +123,7 @@ const welcomeEmailHTML = `<!DOCTYPE html>
 <body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
     <!-- Preheader text (shows in inbox preview) -->
     <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
-        Your VacayTracker account is ready! Log in to start tracking your time off.
+        Your {{.AppName}} account is ready! Log in to start tracking your time off.
         &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
     </div>
     <table role="presentation" style="width: 100%; border-collapse: collapse;">
@@ -58,7 +133,7 @@ const welcomeEmailHTML = `<!DOCTYPE html>
                     <!-- Header with Logo -->
                     <tr>
                         <td style="padding: 32px 40px 24px; text-align: center;">
-                            <img src="{{.AppURL}}/logo.png" width="64" height="64" alt="VacayTracker" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
                             <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Welcome Aboard!</h1>
                         </td>
                     </tr>
@@ -73,7 +148,7 @@ const welcomeEmailHTML = `<!DOCTYPE html>
                                 Ahoy, <strong style="color: #00384F;">{{.UserName}}</strong>!
                             </p>
                             <p style="margin: 0 0 24px; color: #374151; font-size: 16px; line-height: 1.6;">
-                                Your VacayTracker account has been created. You can now start planning your well-deserved time off!
+                                Your {{.AppName}} account has been created. You can now start planning your well-deserved time off!
                             </p>
                             <!-- Credentials Box -->
                             <div style="background-color: #f0f9ff; border-radius: 12px; padding: 20px; margin: 0 0 24px;">
@@ -99,14 +174,14 @@ const welcomeEmailHTML = `<!DOCTYPE html>
                             </p>
                             <!-- CTA Button -->
                             <div style="text-align: center;">
-                                <a href="{{.AppURL}}" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Login to VacayTracker</a>
+                                <a href="{{.AppURL}}" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Login to {{.AppName}}</a>
                             </div>
                         </td>
                     </tr>
                     <!-- Footer -->
                     <tr>
                         <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
-                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">VacayTracker</p>
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
                             <p style="margin: 0; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
                         </td>
                     </tr>
@@ -119,7 +194,7 @@ const welcomeEmailHTML = `<!DOCTYPE html>
 
 const welcomeEmailText = `Welcome Aboard, {{.UserName}}!
 
-Your VacayTracker account has been created. You can now start planning your well-deserved time off!
+Your {{.AppName}} account has been created. You can now start planning your well-deserved time off!
 
 Your login credentials:
 - Email: {{.UserEmail}}
@@ -130,7 +205,7 @@ Please change your password after your first login for security purposes.
 Login at: {{.AppURL}}
 
 ---
-VacayTracker - Your vacation tracking companion`
+{{.AppName}} - Your vacation tracking companion`
 
 // Request submitted email templates
 const requestSubmittedSubject = "Vacation Request Submitted"
@@ -164,7 +239,7 @@ const requestSubmittedHTML = `<!DOCTYPE html>
                     <!-- Header with Logo -->
                     <tr>
                         <td style="padding: 32px 40px 24px; text-align: center;">
-                            <img src="{{.AppURL}}/logo.png" width="64" height="64" alt="VacayTracker" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
                             <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Request Submitted</h1>
                         </td>
                     </tr>
@@ -211,7 +286,7 @@ const requestSubmittedHTML = `<!DOCTYPE html>
                     <!-- Footer -->
                     <tr>
                         <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
-                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">VacayTracker</p>
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
                             <p style="margin: 0; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
                         </td>
                     </tr>
@@ -236,7 +311,7 @@ You'll receive another email once your request has been reviewed.
 View your dashboard at: {{.AppURL}}/employee
 
 ---
-VacayTracker - Your vacation tracking companion`
+{{.AppName}} - Your vacation tracking companion`
 
 // Request approved email templates
 const requestApprovedSubject = "Vacation Request Approved!"
@@ -270,7 +345,7 @@ const requestApprovedHTML = `<!DOCTYPE html>
                     <!-- Header with Logo -->
                     <tr>
                         <td style="padding: 32px 40px 24px; text-align: center;">
-                            <img src="{{.AppURL}}/logo.png" width="64" height="64" alt="VacayTracker" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
                             <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">You're All Set!</h1>
                         </td>
                     </tr>
@@ -305,6 +380,19 @@ const requestApprovedHTML = `<!DOCTYPE html>
                                     </tr>
                                 </table>
                             </div>
+                            {{if .OriginalStartDate}}
+                            <!-- Adjusted Dates Box -->
+                            <div style="background-color: #fffbeb; border-radius: 12px; padding: 16px 20px; margin: 0 0 24px;">
+                                <p style="margin: 0; color: #92400e; font-size: 14px; line-height: 1.5;">You requested {{.OriginalStartDate}} to {{.OriginalEndDate}}; the dates above were approved instead. Days outside them stay in your balance.</p>
+                            </div>
+                            {{end}}
+                            {{if .Reason}}
+                            <!-- Comment Box -->
+                            <div style="background-color: #f9fafb; border-radius: 12px; padding: 16px 20px; margin: 0 0 24px;">
+                                <p style="margin: 0 0 8px; color: #0D83A2; font-size: 14px; font-weight: 600;">Approver Comment</p>
+                                <p style="margin: 0; color: #374151; font-size: 14px; line-height: 1.5;">{{.Reason}}</p>
+                            </div>
+                            {{end}}
                             <!-- CTA Button -->
                             <div style="text-align: center;">
                                 <a href="{{.AppURL}}/employee" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">View Dashboard</a>
@@ -314,7 +402,7 @@ const requestApprovedHTML = `<!DOCTYPE html>
                     <!-- Footer -->
                     <tr>
                         <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
-                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">VacayTracker</p>
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
                             <p style="margin: 0; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
                         </td>
                     </tr>
@@ -333,11 +421,15 @@ Approved Vacation:
 - Start Date: {{.StartDate}}
 - End Date: {{.EndDate}}
 - Total Days: {{.TotalDays}}
-
+{{if .OriginalStartDate}}
+You requested {{.OriginalStartDate}} to {{.OriginalEndDate}}; the dates above were approved instead. Days outside them stay in your balance.
+{{end}}{{if .Reason}}
+Approver comment: {{.Reason}}
+{{end}}
 View your dashboard at: {{.AppURL}}/employee
 
 ---
-VacayTracker - Your vacation tracking companion`
+{{.AppName}} - Your vacation tracking companion`
 
 // Request rejected email templates
 const requestRejectedSubject = "Vacation Request Update"
@@ -371,7 +463,7 @@ const requestRejectedHTML = `<!DOCTYPE html>
                     <!-- Header with Logo -->
                     <tr>
                         <td style="padding: 32px 40px 24px; text-align: center;">
-                            <img src="{{.AppURL}}/logo.png" width="64" height="64" alt="VacayTracker" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
                             <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Request Update</h1>
                         </td>
                     </tr>
@@ -425,7 +517,7 @@ const requestRejectedHTML = `<!DOCTYPE html>
                     <!-- Footer -->
                     <tr>
                         <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;" class="email-footer">
-                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;" class="text-heading">VacayTracker</p>
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;" class="text-heading">{{.AppName}}</p>
                             <p style="margin: 0; color: #6b7280; font-size: 12px;" class="text-secondary">Your vacation tracking companion</p>
                         </td>
                     </tr>
@@ -452,7 +544,7 @@ Please contact your manager if you have questions or would like to submit a new
 View your dashboard at: {{.AppURL}}/employee
 
 ---
-VacayTracker - Your vacation tracking companion`
+{{.AppName}} - Your vacation tracking companion`
 
 // Admin notification email templates
 const adminNewRequestSubject = "New Vacation Request Pending"
@@ -486,7 +578,7 @@ const adminNewRequestHTML = `<!DOCTYPE html>
                     <!-- Header with Logo -->
                     <tr>
                         <td style="padding: 32px 40px 24px; text-align: center;">
-                            <img src="{{.AppURL}}/logo.png" width="64" height="64" alt="VacayTracker" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
                             <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">New Request</h1>
                         </td>
                     </tr>
@@ -527,6 +619,11 @@ const adminNewRequestHTML = `<!DOCTYPE html>
                                     <p style="margin: 0; color: #374151; font-size: 14px;">{{.RequestReason}}</p>
                                 </div>
                                 {{end}}
+                                {{if .OverlapWarning}}
+                                <div style="margin-top: 16px; padding: 12px 16px; background-color: #fffbeb; border: 1px solid #fde68a; border-radius: 8px;">
+                                    <p style="margin: 0; color: #92400e; font-size: 14px;">This request overlaps another of the employee's requests.</p>
+                                </div>
+                                {{end}}
                             </div>
                             <!-- CTA Button -->
                             <div style="text-align: center;">
@@ -537,7 +634,7 @@ const adminNewRequestHTML = `<!DOCTYPE html>
                     <!-- Footer -->
                     <tr>
                         <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
-                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">VacayTracker</p>
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
                             <p style="margin: 0; color: #6b7280; font-size: 12px;">Admin Notification</p>
                         </td>
                     </tr>
@@ -558,8 +655,649 @@ Request Details:
 - End Date: {{.EndDate}}
 - Total Days: {{.TotalDays}}
 {{if .RequestReason}}- Reason: {{.RequestReason}}{{end}}
-
+{{if .OverlapWarning}}
+Warning: this request overlaps another of the employee's requests.
+{{end}}
 Review this request at: {{.AppURL}}/admin
 
 ---
-VacayTracker - Admin Notification`
+{{.AppName}} - Admin Notification`
+
+// Admin withdrawal notification email templates
+const adminRequestWithdrawnSubject = "Approved Vacation Withdrawn"
+
+const adminRequestWithdrawnHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Vacation Withdrawn</title>
+    <!--[if mso]>
+    <noscript>
+        <xml>
+            <o:OfficeDocumentSettings>
+                <o:PixelsPerInch>96</o:PixelsPerInch>
+            </o:OfficeDocumentSettings>
+        </xml>
+    </noscript>
+    <![endif]-->
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        {{.RequesterName}} has withdrawn approved vacation of {{.TotalDays}} days.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Vacation Withdrawn</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar (Purple for Admin) -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #8b5cf6 0%, #a78bfa 100%); background-color: #8b5cf6;" bgcolor="#8b5cf6"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 24px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                An employee has cancelled vacation that was already approved. The days have been credited back to their balance.
+                            </p>
+                            <!-- Details Box -->
+                            <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 24px;">
+                                <div style="display: inline-block; padding: 4px 12px; background-color: #f3f0ff; color: #5b21b6; font-size: 12px; font-weight: 600; border-radius: 20px; margin-bottom: 12px;">Withdrawn</div>
+                                <table role="presentation" style="width: 100%; border-collapse: collapse;">
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Employee</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.RequesterName}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Start Date</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.StartDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">End Date</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.EndDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Total Days</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.TotalDays}}</td>
+                                    </tr>
+                                </table>
+                            </div>
+                            <!-- CTA Button -->
+                            <div style="text-align: center;">
+                                <a href="{{.AppURL}}/admin" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Open Dashboard</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Admin Notification</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const adminRequestWithdrawnText = `Approved Vacation Withdrawn
+
+An employee has cancelled vacation that was already approved. The days have been credited back to their balance.
+
+Request Details:
+- Employee: {{.RequesterName}}
+- Start Date: {{.StartDate}}
+- End Date: {{.EndDate}}
+- Total Days: {{.TotalDays}}
+
+View the dashboard at: {{.AppURL}}/admin
+
+---
+{{.AppName}} - Admin Notification`
+
+// Request comment email templates
+const requestCommentSubject = "New Comment on a Vacation Request"
+
+const requestCommentHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>New Comment</title>
+    <!--[if mso]>
+    <noscript>
+        <xml>
+            <o:OfficeDocumentSettings>
+                <o:PixelsPerInch>96</o:PixelsPerInch>
+            </o:OfficeDocumentSettings>
+        </xml>
+    </noscript>
+    <![endif]-->
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        {{.AuthorName}} commented on the vacation request for {{.StartDate}} to {{.EndDate}}.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">New Comment</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #8b5cf6 0%, #a78bfa 100%); background-color: #8b5cf6;" bgcolor="#8b5cf6"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 24px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Hi {{.RecipientName}}, {{.AuthorName}} left a comment on a vacation request.
+                            </p>
+                            <!-- Details Box -->
+                            <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 24px;">
+                                <div style="display: inline-block; padding: 4px 12px; background-color: #f3f0ff; color: #5b21b6; font-size: 12px; font-weight: 600; border-radius: 20px; margin-bottom: 12px;">Comment</div>
+                                <table role="presentation" style="width: 100%; border-collapse: collapse;">
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Employee</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.RequesterName}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Start Date</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.StartDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">End Date</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.EndDate}}</td>
+                                    </tr>
+                                </table>
+                                <div style="margin-top: 16px; padding-top: 16px; border-top: 1px solid #e2e8f0;">
+                                    <p style="margin: 0 0 4px; color: #6b7280; font-size: 14px;">{{.AuthorName}} wrote</p>
+                                    <p style="margin: 0; color: #374151; font-size: 14px; white-space: pre-line;">{{.Body}}</p>
+                                </div>
+                            </div>
+                            <!-- CTA Button -->
+                            <div style="text-align: center;">
+                                <a href="{{.AppURL}}{{.Path}}" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">View Request</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const requestCommentText = `New Comment on a Vacation Request
+
+Hi {{.RecipientName}}, {{.AuthorName}} left a comment on a vacation request.
+
+Request Details:
+- Employee: {{.RequesterName}}
+- Start Date: {{.StartDate}}
+- End Date: {{.EndDate}}
+
+{{.AuthorName}} wrote:
+{{.Body}}
+
+View the request at: {{.AppURL}}{{.Path}}
+
+---
+{{.AppName}} - Your vacation tracking companion`
+
+// Password reset email templates
+const passwordResetSubject = "Reset Your {{.AppName}} Password"
+
+const passwordResetHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Reset Your Password</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        Use this link to choose a new {{.AppName}} password.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Reset Your Password</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #0D83A2 0%, #15ABCB 100%); background-color: #0D83A2;" bgcolor="#0D83A2"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Hi <strong style="color: #00384F;">{{.UserName}}</strong>,
+                            </p>
+                            <p style="margin: 0 0 28px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                We received a request to reset your {{.AppName}} password. Click the button below to choose a new one.
+                            </p>
+                            <!-- CTA Button -->
+                            <div style="text-align: center; margin: 0 0 28px;">
+                                <a href="{{.ResetURL}}" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Reset Password</a>
+                            </div>
+                            <!-- Security Note -->
+                            <p style="margin: 0; color: #991b1b; font-size: 14px; line-height: 1.5; padding: 12px 16px; background-color: #fef2f2; border-radius: 8px;">
+                                <strong>Note:</strong> This link expires in {{.ExpiresInMinutes}} minutes and can only be used once. If you didn't request a reset, you can safely ignore this email.
+                            </p>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const passwordResetText = `Hi {{.UserName}},
+
+We received a request to reset your {{.AppName}} password.
+
+Reset it here: {{.ResetURL}}
+
+This link expires in {{.ExpiresInMinutes}} minutes and can only be used once.
+If you didn't request a reset, you can safely ignore this email.
+
+---
+{{.AppName}} - Your vacation tracking companion`
+
+// Email change confirmation templates
+const emailChangeSubject = "Confirm Your New {{.AppName}} Email"
+
+const emailChangeHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Confirm Your New Email</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        Confirm this address to use it for your {{.AppName}} account.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Confirm Your New Email</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #0D83A2 0%, #15ABCB 100%); background-color: #0D83A2;" bgcolor="#0D83A2"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Hi <strong style="color: #00384F;">{{.UserName}}</strong>,
+                            </p>
+                            <p style="margin: 0 0 28px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                We received a request to change the email address of your {{.AppName}} account to <strong style="color: #00384F;">{{.NewEmail}}</strong>. Click the button below to confirm it. Until you do, keep signing in with your current email.
+                            </p>
+                            <!-- CTA Button -->
+                            <div style="text-align: center; margin: 0 0 28px;">
+                                <a href="{{.ConfirmURL}}" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Confirm Email</a>
+                            </div>
+                            <!-- Security Note -->
+                            <p style="margin: 0; color: #991b1b; font-size: 14px; line-height: 1.5; padding: 12px 16px; background-color: #fef2f2; border-radius: 8px;">
+                                <strong>Note:</strong> This link expires in {{.ExpiresInHours}} hours. If you didn't request this change, you can safely ignore this email and your account will keep its current address.
+                            </p>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const emailChangeText = `Hi {{.UserName}},
+
+We received a request to change the email address of your {{.AppName}} account to {{.NewEmail}}.
+Until you confirm it, keep signing in with your current email.
+
+Confirm it here: {{.ConfirmURL}}
+
+This link expires in {{.ExpiresInHours}} hours.
+If you didn't request this change, you can safely ignore this email and your account will keep its current address.
+
+---
+{{.AppName}} - Your vacation tracking companion`
+
+// Email changed notice templates, sent to the previous address once a change is confirmed
+const emailChangedSubject = "Your {{.AppName}} Email Was Changed"
+
+const emailChangedHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Your Email Was Changed</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        Your {{.AppName}} account now uses a different email address.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Your Email Was Changed</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #0D83A2 0%, #15ABCB 100%); background-color: #0D83A2;" bgcolor="#0D83A2"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Hi <strong style="color: #00384F;">{{.UserName}}</strong>,
+                            </p>
+                            <p style="margin: 0 0 28px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                The email address of your {{.AppName}} account was changed from <strong style="color: #00384F;">{{.OldEmail}}</strong> to <strong style="color: #00384F;">{{.NewEmail}}</strong>. From now on, sign in with the new address; this address won't receive further {{.AppName}} emails.
+                            </p>
+                            <!-- Security Note -->
+                            <p style="margin: 0; color: #991b1b; font-size: 14px; line-height: 1.5; padding: 12px 16px; background-color: #fef2f2; border-radius: 8px;">
+                                <strong>Note:</strong> If you didn't make this change, contact your administrator right away so they can restore your account.
+                            </p>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const emailChangedText = `Hi {{.UserName}},
+
+The email address of your {{.AppName}} account was changed from {{.OldEmail}} to {{.NewEmail}}.
+From now on, sign in with the new address; this address won't receive further {{.AppName}} emails.
+
+If you didn't make this change, contact your administrator right away so they can restore your account.
+
+---
+{{.AppName}} - Your vacation tracking companion`
+
+// Low balance email templates
+const lowBalanceSubject = "{{.AppName}}: Low Vacation Balance"
+
+const lowBalanceHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Low Vacation Balance</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        {{if .ForManager}}{{.EmployeeName}} has{{else}}You have{{end}} {{.Balance}} {{.Unit}} of vacation left.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Low Vacation Balance</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background-color: #f59e0b;" bgcolor="#f59e0b"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Hi <strong style="color: #00384F;">{{.RecipientName}}</strong>,
+                            </p>
+                            <p style="margin: 0 0 28px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                {{if .ForManager}}After their latest approved request, <strong style="color: #00384F;">{{.EmployeeName}}</strong> has{{else}}After your latest approved request, you have{{end}} <strong style="color: #00384F;">{{.Balance}} {{.Unit}}</strong> of vacation left.
+                            </p>
+                            <!-- CTA Button -->
+                            <div style="text-align: center; margin: 0;">
+                                <a href="{{.AppURL}}" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Open {{.AppName}}</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const lowBalanceText = `Hi {{.RecipientName}},
+
+{{if .ForManager}}After their latest approved request, {{.EmployeeName}} has{{else}}After your latest approved request, you have{{end}} {{.Balance}} {{.Unit}} of vacation left.
+
+Open {{.AppName}}: {{.AppURL}}
+
+---
+{{.AppName}} - Your vacation tracking companion`
+
+// Teammate off email templates
+const teammateOffSubject = "{{.AppName}}: A Teammate Is Off"
+
+const teammateOffHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>A Teammate Is Off</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        {{.EmployeeName}} is off from {{.StartDate}} to {{.EndDate}}.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">A Teammate Is Off</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background-color: #0D83A2;" bgcolor="#0D83A2"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Hi <strong style="color: #00384F;">{{.RecipientName}}</strong>,
+                            </p>
+                            <p style="margin: 0 0 28px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                <strong style="color: #00384F;">{{.EmployeeName}}</strong> is off from <strong style="color: #00384F;">{{.StartDate}}</strong> to <strong style="color: #00384F;">{{.EndDate}}</strong>.
+                            </p>
+                            <!-- CTA Button -->
+                            <div style="text-align: center; margin: 0;">
+                                <a href="{{.AppURL}}/employee" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">View Team Calendar</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const teammateOffText = `Hi {{.RecipientName}},
+
+{{.EmployeeName}} is off from {{.StartDate}} to {{.EndDate}}.
+
+View the team calendar: {{.AppURL}}/employee
+
+---
+{{.AppName}} - Your vacation tracking companion`
+
+// Leave start reminder email templates
+const leaveReminderSubject = "{{.AppName}}: Your Leave Starts Tomorrow"
+
+const leaveReminderHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Your Leave Starts Tomorrow</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        You're off from {{.StartDate}} to {{.EndDate}}. Enjoy!
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Your Leave Starts Tomorrow</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background-color: #0D83A2;" bgcolor="#0D83A2"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Hi <strong style="color: #00384F;">{{.UserName}}</strong>,
+                            </p>
+                            <p style="margin: 0 0 28px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                A reminder that you're off from <strong style="color: #00384F;">{{.StartDate}}</strong> to <strong style="color: #00384F;">{{.EndDate}}</strong> ({{.TotalDays}} {{.Unit}}). Don't forget to set your out-of-office and hand over anything urgent. Enjoy your time off!
+                            </p>
+                            <!-- CTA Button -->
+                            <div style="text-align: center; margin: 0;">
+                                <a href="{{.AppURL}}/employee" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">View My Requests</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const leaveReminderText = `Hi {{.UserName}},
+
+A reminder that you're off from {{.StartDate}} to {{.EndDate}} ({{.TotalDays}} {{.Unit}}).
+Don't forget to set your out-of-office and hand over anything urgent. Enjoy your time off!
+
+View your requests: {{.AppURL}}/employee
+
+---
+{{.AppName}} - Your vacation tracking companion`
@@ -0,0 +1,844 @@
+package service
+
+// German (de) email templates
+// Same layout as the English templates in email_templates.go; only the copy is translated
+
+// Welcome email templates (de)
+const welcomeEmailSubjectDE = "Willkommen bei {{.AppName}}!"
+
+const welcomeEmailHTMLDE = `<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Willkommen bei {{.AppName}}</title>
+    <!--[if mso]>
+    <noscript>
+        <xml>
+            <o:OfficeDocumentSettings>
+                <o:PixelsPerInch>96</o:PixelsPerInch>
+            </o:OfficeDocumentSettings>
+        </xml>
+    </noscript>
+    <![endif]-->
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        Ihr {{.AppName}}-Konto ist bereit! Melden Sie sich an, um Ihre Urlaubstage zu verwalten.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Willkommen an Bord!</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #0D83A2 0%, #15ABCB 100%); background-color: #0D83A2;" bgcolor="#0D83A2"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Ahoi, <strong style="color: #00384F;">{{.UserName}}</strong>!
+                            </p>
+                            <p style="margin: 0 0 24px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Ihr {{.AppName}}-Konto wurde erstellt. Sie können jetzt mit der Planung Ihres wohlverdienten Urlaubs beginnen!
+                            </p>
+                            <!-- Credentials Box -->
+                            <div style="background-color: #f0f9ff; border-radius: 12px; padding: 20px; margin: 0 0 24px;">
+                                <p style="margin: 0 0 12px; color: #0D83A2; font-size: 14px; font-weight: 600;">Ihre Zugangsdaten</p>
+                                <table role="presentation" style="width: 100%; border-collapse: collapse;">
+                                    <tr>
+                                        <td style="padding: 6px 0; color: #6b7280; font-size: 14px;">E-Mail</td>
+                                        <td style="padding: 6px 0; color: #00384F; font-size: 14px; font-weight: 500; text-align: right;">
+                                            <code style="background-color: #e0f2fe; padding: 3px 8px; border-radius: 4px; font-family: monospace;">{{.UserEmail}}</code>
+                                        </td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 6px 0; color: #6b7280; font-size: 14px;">Temporäres Passwort</td>
+                                        <td style="padding: 6px 0; color: #00384F; font-size: 14px; font-weight: 500; text-align: right;">
+                                            <code style="background-color: #e0f2fe; padding: 3px 8px; border-radius: 4px; font-family: monospace;">{{.TempPassword}}</code>
+                                        </td>
+                                    </tr>
+                                </table>
+                            </div>
+                            <!-- Security Note -->
+                            <p style="margin: 0 0 28px; color: #991b1b; font-size: 14px; line-height: 1.5; padding: 12px 16px; background-color: #fef2f2; border-radius: 8px;">
+                                <strong>Wichtig:</strong> Bitte ändern Sie Ihr Passwort aus Sicherheitsgründen nach der ersten Anmeldung.
+                            </p>
+                            <!-- CTA Button -->
+                            <div style="text-align: center;">
+                                <a href="{{.AppURL}}" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Bei {{.AppName}} anmelden</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Ihr Begleiter für die Urlaubsplanung</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const welcomeEmailTextDE = `Willkommen an Bord, {{.UserName}}!
+
+Ihr {{.AppName}}-Konto wurde erstellt. Sie können jetzt mit der Planung Ihres wohlverdienten Urlaubs beginnen!
+
+Ihre Zugangsdaten:
+- E-Mail: {{.UserEmail}}
+- Temporäres Passwort: {{.TempPassword}}
+
+Bitte ändern Sie Ihr Passwort aus Sicherheitsgründen nach der ersten Anmeldung.
+
+Anmelden unter: {{.AppURL}}
+
+---
+{{.AppName}} - Ihr Begleiter für die Urlaubsplanung`
+
+// Request submitted email templates (de)
+const requestSubmittedSubjectDE = "Urlaubsantrag eingereicht"
+
+const requestSubmittedHTMLDE = `<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Urlaubsantrag eingereicht</title>
+    <!--[if mso]>
+    <noscript>
+        <xml>
+            <o:OfficeDocumentSettings>
+                <o:PixelsPerInch>96</o:PixelsPerInch>
+            </o:OfficeDocumentSettings>
+        </xml>
+    </noscript>
+    <![endif]-->
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        Ihr Urlaubsantrag wurde eingereicht und wartet auf Genehmigung.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Antrag eingereicht</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar (Amber for Pending) -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #f59e0b 0%, #fbbf24 100%); background-color: #f59e0b;" bgcolor="#f59e0b"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Hallo <strong style="color: #00384F;">{{.UserName}}</strong>,
+                            </p>
+                            <p style="margin: 0 0 24px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Ihr Urlaubsantrag wurde eingereicht und wartet auf Genehmigung.
+                            </p>
+                            <!-- Details Box -->
+                            <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 24px;">
+                                <div style="display: inline-block; padding: 4px 12px; background-color: #fffbeb; color: #92400e; font-size: 12px; font-weight: 600; border-radius: 20px; margin-bottom: 12px;">Wird geprüft</div>
+                                <table role="presentation" style="width: 100%; border-collapse: collapse;">
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Startdatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.StartDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Enddatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.EndDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Anzahl Tage</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.TotalDays}}</td>
+                                    </tr>
+                                </table>
+                            </div>
+                            <p style="margin: 0 0 28px; color: #6b7280; font-size: 14px; line-height: 1.6;">
+                                Sie erhalten eine weitere E-Mail, sobald Ihr Antrag geprüft wurde.
+                            </p>
+                            <!-- CTA Button -->
+                            <div style="text-align: center;">
+                                <a href="{{.AppURL}}/employee" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Zum Dashboard</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Ihr Begleiter für die Urlaubsplanung</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const requestSubmittedTextDE = `Hallo {{.UserName}},
+
+Ihr Urlaubsantrag wurde eingereicht und wartet auf Genehmigung.
+
+Antragsdetails:
+- Startdatum: {{.StartDate}}
+- Enddatum: {{.EndDate}}
+- Anzahl Tage: {{.TotalDays}}
+
+Sie erhalten eine weitere E-Mail, sobald Ihr Antrag geprüft wurde.
+
+Zum Dashboard: {{.AppURL}}/employee
+
+---
+{{.AppName}} - Ihr Begleiter für die Urlaubsplanung`
+
+// Request approved email templates (de)
+const requestApprovedSubjectDE = "Urlaubsantrag genehmigt!"
+
+const requestApprovedHTMLDE = `<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Urlaubsantrag genehmigt</title>
+    <!--[if mso]>
+    <noscript>
+        <xml>
+            <o:OfficeDocumentSettings>
+                <o:PixelsPerInch>96</o:PixelsPerInch>
+            </o:OfficeDocumentSettings>
+        </xml>
+    </noscript>
+    <![endif]-->
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        Gute Nachrichten! Ihr Urlaubsantrag wurde genehmigt. Zeit für die Planung!
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Alles erledigt!</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar (Green for Approved) -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #22c55e 0%, #4ade80 100%); background-color: #22c55e;" bgcolor="#22c55e"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Gute Nachrichten, <strong style="color: #00384F;">{{.UserName}}</strong>!
+                            </p>
+                            <p style="margin: 0 0 24px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Ihr Urlaubsantrag wurde genehmigt. Zeit, Ihre Reise zu planen!
+                            </p>
+                            <!-- Details Box -->
+                            <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 28px;">
+                                <div style="display: inline-block; padding: 4px 12px; background-color: #f0fdf4; color: #166534; font-size: 12px; font-weight: 600; border-radius: 20px; margin-bottom: 12px;">Genehmigt</div>
+                                <table role="presentation" style="width: 100%; border-collapse: collapse;">
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Startdatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.StartDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Enddatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.EndDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Anzahl Tage</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.TotalDays}}</td>
+                                    </tr>
+                                </table>
+                            </div>
+                            {{if .OriginalStartDate}}
+                            <!-- Adjusted Dates Box -->
+                            <div style="background-color: #fffbeb; border-radius: 12px; padding: 16px 20px; margin: 0 0 24px;">
+                                <p style="margin: 0; color: #92400e; font-size: 14px; line-height: 1.5;">Beantragt hatten Sie {{.OriginalStartDate}} bis {{.OriginalEndDate}}; genehmigt wurde der oben genannte Zeitraum. Die übrigen Tage bleiben in Ihrem Guthaben.</p>
+                            </div>
+                            {{end}}
+                            {{if .Reason}}
+                            <!-- Comment Box -->
+                            <div style="background-color: #f9fafb; border-radius: 12px; padding: 16px 20px; margin: 0 0 24px;">
+                                <p style="margin: 0 0 8px; color: #0D83A2; font-size: 14px; font-weight: 600;">Kommentar zur Genehmigung</p>
+                                <p style="margin: 0; color: #374151; font-size: 14px; line-height: 1.5;">{{.Reason}}</p>
+                            </div>
+                            {{end}}
+                            <!-- CTA Button -->
+                            <div style="text-align: center;">
+                                <a href="{{.AppURL}}/employee" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Zum Dashboard</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Ihr Begleiter für die Urlaubsplanung</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const requestApprovedTextDE = `Gute Nachrichten, {{.UserName}}!
+
+Ihr Urlaubsantrag wurde genehmigt. Zeit, Ihre Reise zu planen!
+
+Genehmigter Urlaub:
+- Startdatum: {{.StartDate}}
+- Enddatum: {{.EndDate}}
+- Anzahl Tage: {{.TotalDays}}
+{{if .OriginalStartDate}}
+Beantragt hatten Sie {{.OriginalStartDate}} bis {{.OriginalEndDate}}; genehmigt wurde der oben genannte Zeitraum. Die übrigen Tage bleiben in Ihrem Guthaben.
+{{end}}{{if .Reason}}
+Kommentar zur Genehmigung: {{.Reason}}
+{{end}}
+Zum Dashboard: {{.AppURL}}/employee
+
+---
+{{.AppName}} - Ihr Begleiter für die Urlaubsplanung`
+
+// Request rejected email templates (de)
+const requestRejectedSubjectDE = "Neuigkeiten zu Ihrem Urlaubsantrag"
+
+const requestRejectedHTMLDE = `<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Neuigkeiten zu Ihrem Urlaubsantrag</title>
+    <!--[if mso]>
+    <noscript>
+        <xml>
+            <o:OfficeDocumentSettings>
+                <o:PixelsPerInch>96</o:PixelsPerInch>
+            </o:OfficeDocumentSettings>
+        </xml>
+    </noscript>
+    <![endif]-->
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        Ihr Urlaubsantrag erfordert Ihre Aufmerksamkeit. Die Details finden Sie in dieser E-Mail.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Antragsstatus</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar (Red for Rejected) -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #ef4444 0%, #f87171 100%); background-color: #ef4444;" bgcolor="#ef4444"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Hallo <strong style="color: #00384F;">{{.UserName}}</strong>,
+                            </p>
+                            <p style="margin: 0 0 24px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Leider konnte Ihr Urlaubsantrag derzeit nicht genehmigt werden.
+                            </p>
+                            <!-- Details Box -->
+                            <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 24px;">
+                                <div style="display: inline-block; padding: 4px 12px; background-color: #fef2f2; color: #991b1b; font-size: 12px; font-weight: 600; border-radius: 20px; margin-bottom: 12px;">Nicht genehmigt</div>
+                                <table role="presentation" style="width: 100%; border-collapse: collapse;">
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Startdatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.StartDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Enddatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.EndDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Anzahl Tage</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.TotalDays}}</td>
+                                    </tr>
+                                </table>
+                            </div>
+                            {{if .Reason}}
+                            <!-- Reason Box -->
+                            <div style="background-color: #f9fafb; border-radius: 12px; padding: 16px 20px; margin: 0 0 24px;">
+                                <p style="margin: 0 0 8px; color: #0D83A2; font-size: 14px; font-weight: 600;">Begründung</p>
+                                <p style="margin: 0; color: #374151; font-size: 14px; line-height: 1.5;">{{.Reason}}</p>
+                            </div>
+                            {{end}}
+                            <p style="margin: 0 0 28px; color: #6b7280; font-size: 14px; line-height: 1.6;">
+                                Bitte wenden Sie sich an Ihre Führungskraft, wenn Sie Fragen haben oder einen neuen Antrag für andere Daten stellen möchten.
+                            </p>
+                            <!-- CTA Button -->
+                            <div style="text-align: center;">
+                                <a href="{{.AppURL}}/employee" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Zum Dashboard</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;" class="email-footer">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;" class="text-heading">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;" class="text-secondary">Ihr Begleiter für die Urlaubsplanung</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const requestRejectedTextDE = `Hallo {{.UserName}},
+
+Leider konnte Ihr Urlaubsantrag derzeit nicht genehmigt werden.
+
+Antragsdetails:
+- Startdatum: {{.StartDate}}
+- Enddatum: {{.EndDate}}
+- Anzahl Tage: {{.TotalDays}}
+{{if .Reason}}
+Begründung: {{.Reason}}
+{{end}}
+Bitte wenden Sie sich an Ihre Führungskraft, wenn Sie Fragen haben oder einen neuen Antrag für andere Daten stellen möchten.
+
+Zum Dashboard: {{.AppURL}}/employee
+
+---
+{{.AppName}} - Ihr Begleiter für die Urlaubsplanung`
+
+// Admin notification email templates (de)
+const adminNewRequestSubjectDE = "Neuer Urlaubsantrag ausstehend"
+
+const adminNewRequestHTMLDE = `<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Neuer Urlaubsantrag</title>
+    <!--[if mso]>
+    <noscript>
+        <xml>
+            <o:OfficeDocumentSettings>
+                <o:PixelsPerInch>96</o:PixelsPerInch>
+            </o:OfficeDocumentSettings>
+        </xml>
+    </noscript>
+    <![endif]-->
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        {{.RequesterName}} hat einen Urlaubsantrag über {{.TotalDays}} Tage eingereicht. Prüfung erforderlich.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Neuer Antrag</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar (Purple for Admin) -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #8b5cf6 0%, #a78bfa 100%); background-color: #8b5cf6;" bgcolor="#8b5cf6"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 24px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Ein neuer Urlaubsantrag erfordert Ihre Aufmerksamkeit.
+                            </p>
+                            <!-- Details Box -->
+                            <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 24px;">
+                                <div style="display: inline-block; padding: 4px 12px; background-color: #f3f0ff; color: #5b21b6; font-size: 12px; font-weight: 600; border-radius: 20px; margin-bottom: 12px;">Handlung erforderlich</div>
+                                <table role="presentation" style="width: 100%; border-collapse: collapse;">
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Mitarbeiter/in</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.RequesterName}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Startdatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.StartDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Enddatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.EndDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Anzahl Tage</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.TotalDays}}</td>
+                                    </tr>
+                                </table>
+                                {{if .RequestReason}}
+                                <div style="margin-top: 16px; padding-top: 16px; border-top: 1px solid #e2e8f0;">
+                                    <p style="margin: 0 0 4px; color: #6b7280; font-size: 14px;">Begründung</p>
+                                    <p style="margin: 0; color: #374151; font-size: 14px;">{{.RequestReason}}</p>
+                                </div>
+                                {{end}}
+                                {{if .OverlapWarning}}
+                                <div style="margin-top: 16px; padding: 12px 16px; background-color: #fffbeb; border: 1px solid #fde68a; border-radius: 8px;">
+                                    <p style="margin: 0; color: #92400e; font-size: 14px;">Dieser Antrag überschneidet sich mit einem anderen Antrag der Person.</p>
+                                </div>
+                                {{end}}
+                            </div>
+                            <!-- CTA Button -->
+                            <div style="text-align: center;">
+                                <a href="{{.AppURL}}/admin" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Antrag prüfen</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Admin-Benachrichtigung</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const adminNewRequestTextDE = `Neuer Urlaubsantrag ausstehend
+
+Ein neuer Urlaubsantrag erfordert Ihre Aufmerksamkeit.
+
+Antragsdetails:
+- Mitarbeiter/in: {{.RequesterName}}
+- Startdatum: {{.StartDate}}
+- Enddatum: {{.EndDate}}
+- Anzahl Tage: {{.TotalDays}}
+{{if .RequestReason}}- Begründung: {{.RequestReason}}{{end}}
+{{if .OverlapWarning}}
+Hinweis: Dieser Antrag überschneidet sich mit einem anderen Antrag der Person.
+{{end}}
+Antrag prüfen unter: {{.AppURL}}/admin
+
+---
+{{.AppName}} - Admin-Benachrichtigung`
+
+// Admin withdrawal notification email templates (de)
+const adminRequestWithdrawnSubjectDE = "Genehmigter Urlaub zurückgezogen"
+
+const adminRequestWithdrawnHTMLDE = `<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Urlaub zurückgezogen</title>
+    <!--[if mso]>
+    <noscript>
+        <xml>
+            <o:OfficeDocumentSettings>
+                <o:PixelsPerInch>96</o:PixelsPerInch>
+            </o:OfficeDocumentSettings>
+        </xml>
+    </noscript>
+    <![endif]-->
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        {{.RequesterName}} hat genehmigten Urlaub über {{.TotalDays}} Tage zurückgezogen.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Urlaub zurückgezogen</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar (Purple for Admin) -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #8b5cf6 0%, #a78bfa 100%); background-color: #8b5cf6;" bgcolor="#8b5cf6"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 24px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Eine Person hat bereits genehmigten Urlaub storniert. Die Tage wurden ihrem Urlaubskonto wieder gutgeschrieben.
+                            </p>
+                            <!-- Details Box -->
+                            <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 24px;">
+                                <div style="display: inline-block; padding: 4px 12px; background-color: #f3f0ff; color: #5b21b6; font-size: 12px; font-weight: 600; border-radius: 20px; margin-bottom: 12px;">Zurückgezogen</div>
+                                <table role="presentation" style="width: 100%; border-collapse: collapse;">
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Mitarbeiter/in</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.RequesterName}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Startdatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.StartDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Enddatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.EndDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Anzahl Tage</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.TotalDays}}</td>
+                                    </tr>
+                                </table>
+                            </div>
+                            <!-- CTA Button -->
+                            <div style="text-align: center;">
+                                <a href="{{.AppURL}}/admin" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Zum Dashboard</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Admin-Benachrichtigung</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const adminRequestWithdrawnTextDE = `Genehmigter Urlaub zurückgezogen
+
+Eine Person hat bereits genehmigten Urlaub storniert. Die Tage wurden ihrem Urlaubskonto wieder gutgeschrieben.
+
+Antragsdetails:
+- Mitarbeiter/in: {{.RequesterName}}
+- Startdatum: {{.StartDate}}
+- Enddatum: {{.EndDate}}
+- Anzahl Tage: {{.TotalDays}}
+
+Zum Dashboard: {{.AppURL}}/admin
+
+---
+{{.AppName}} - Admin-Benachrichtigung`
+
+// Request comment email templates (de)
+const requestCommentSubjectDE = "Neuer Kommentar zu einem Urlaubsantrag"
+
+const requestCommentHTMLDE = `<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Neuer Kommentar</title>
+    <!--[if mso]>
+    <noscript>
+        <xml>
+            <o:OfficeDocumentSettings>
+                <o:PixelsPerInch>96</o:PixelsPerInch>
+            </o:OfficeDocumentSettings>
+        </xml>
+    </noscript>
+    <![endif]-->
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        {{.AuthorName}} hat den Urlaubsantrag vom {{.StartDate}} bis {{.EndDate}} kommentiert.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Neuer Kommentar</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #8b5cf6 0%, #a78bfa 100%); background-color: #8b5cf6;" bgcolor="#8b5cf6"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 24px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Hallo {{.RecipientName}}, {{.AuthorName}} hat einen Urlaubsantrag kommentiert.
+                            </p>
+                            <!-- Details Box -->
+                            <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 24px;">
+                                <div style="display: inline-block; padding: 4px 12px; background-color: #f3f0ff; color: #5b21b6; font-size: 12px; font-weight: 600; border-radius: 20px; margin-bottom: 12px;">Kommentar</div>
+                                <table role="presentation" style="width: 100%; border-collapse: collapse;">
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Mitarbeiter/in</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.RequesterName}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Startdatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.StartDate}}</td>
+                                    </tr>
+                                    <tr>
+                                        <td style="padding: 8px 0; color: #6b7280; font-size: 14px;">Enddatum</td>
+                                        <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 600; text-align: right;">{{.EndDate}}</td>
+                                    </tr>
+                                </table>
+                                <div style="margin-top: 16px; padding-top: 16px; border-top: 1px solid #e2e8f0;">
+                                    <p style="margin: 0 0 4px; color: #6b7280; font-size: 14px;">{{.AuthorName}} schrieb</p>
+                                    <p style="margin: 0; color: #374151; font-size: 14px; white-space: pre-line;">{{.Body}}</p>
+                                </div>
+                            </div>
+                            <!-- CTA Button -->
+                            <div style="text-align: center;">
+                                <a href="{{.AppURL}}{{.Path}}" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Antrag ansehen</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Ihr Begleiter für die Urlaubsplanung</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const requestCommentTextDE = `Neuer Kommentar zu einem Urlaubsantrag
+
+Hallo {{.RecipientName}}, {{.AuthorName}} hat einen Urlaubsantrag kommentiert.
+
+Antragsdetails:
+- Mitarbeiter/in: {{.RequesterName}}
+- Startdatum: {{.StartDate}}
+- Enddatum: {{.EndDate}}
+
+{{.AuthorName}} schrieb:
+{{.Body}}
+
+Antrag ansehen: {{.AppURL}}{{.Path}}
+
+---
+{{.AppName}} - Ihr Begleiter für die Urlaubsplanung`
+
+// Leave start reminder email templates (de)
+const leaveReminderSubjectDE = "{{.AppName}}: Ihr Urlaub beginnt morgen"
+
+const leaveReminderHTMLDE = `<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Ihr Urlaub beginnt morgen</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        Sie sind vom {{.StartDate}} bis {{.EndDate}} abwesend. Genießen Sie die Zeit!
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Ihr Urlaub beginnt morgen</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background-color: #0D83A2;" bgcolor="#0D83A2"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Hallo <strong style="color: #00384F;">{{.UserName}}</strong>,
+                            </p>
+                            <p style="margin: 0 0 28px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Zur Erinnerung: Sie sind vom <strong style="color: #00384F;">{{.StartDate}}</strong> bis <strong style="color: #00384F;">{{.EndDate}}</strong> abwesend ({{.TotalDays}} {{if eq .Unit "hours"}}Stunden{{else}}Tage{{end}}). Denken Sie an Ihre Abwesenheitsnotiz und übergeben Sie dringende Aufgaben. Genießen Sie Ihre freie Zeit!
+                            </p>
+                            <!-- CTA Button -->
+                            <div style="text-align: center; margin: 0;">
+                                <a href="{{.AppURL}}/employee" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">Meine Anträge ansehen</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0; color: #6b7280; font-size: 12px;">Ihr Begleiter für die Urlaubsplanung</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const leaveReminderTextDE = `Hallo {{.UserName}},
+
+Zur Erinnerung: Sie sind vom {{.StartDate}} bis {{.EndDate}} abwesend ({{.TotalDays}} {{if eq .Unit "hours"}}Stunden{{else}}Tage{{end}}).
+Denken Sie an Ihre Abwesenheitsnotiz und übergeben Sie dringende Aufgaben. Genießen Sie Ihre freie Zeit!
+
+Meine Anträge ansehen: {{.AppURL}}/employee
+
+---
+{{.AppName}} - Ihr Begleiter für die Urlaubsplanung`
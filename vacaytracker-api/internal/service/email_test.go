@@ -0,0 +1,204 @@
+package service
+
+import (
+	"html/template"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/config"
+	"vacaytracker-api/internal/domain"
+)
+
+func TestEmailService_CompilesEveryLocale(t *testing.T) {
+	svc := NewEmailService(&config.Config{AppURL: "http://localhost:3000"})
+	data := vacationEmailData{AppURL: "http://localhost:3000", UserName: "Alex", StartDate: "2026-07-06", EndDate: "2026-07-10", TotalDays: 5, Reason: "Team offsite"}
+
+	for _, locale := range domain.SupportedLocales() {
+		tmpl, ok := svc.locales[locale]
+		require.True(t, ok, locale)
+
+		for _, tpl := range []*template.Template{
+			tmpl.requestSubmittedHTML, tmpl.requestSubmittedText,
+			tmpl.requestApprovedHTML, tmpl.requestApprovedText,
+			tmpl.requestRejectedHTML, tmpl.requestRejectedText,
+		} {
+			out, err := svc.executeTemplate(tpl, data)
+			require.NoError(t, err, locale)
+			assert.Contains(t, out, "Alex")
+		}
+
+		welcome, err := svc.executeTemplate(tmpl.welcomeText, welcomeEmailData{UserName: "Alex", UserEmail: "alex@example.com", TempPassword: "tmp"})
+		require.NoError(t, err, locale)
+		assert.Contains(t, welcome, "alex@example.com")
+
+		for _, tpl := range []*template.Template{
+			tmpl.adminNewRequestHTML, tmpl.adminNewRequestText,
+			tmpl.adminWithdrawnHTML, tmpl.adminWithdrawnText,
+		} {
+			admin, err := svc.executeTemplate(tpl, adminNotificationData{RequesterName: "Alex", TotalDays: 5})
+			require.NoError(t, err, locale)
+			assert.Contains(t, admin, "Alex")
+		}
+
+		for _, tpl := range []*template.Template{tmpl.requestCommentHTML, tmpl.requestCommentText} {
+			comment, err := svc.executeTemplate(tpl, commentEmailData{AuthorName: "Sam", RequesterName: "Alex", Body: "Can we shift this?"})
+			require.NoError(t, err, locale)
+			assert.Contains(t, comment, "Can we shift this?")
+		}
+	}
+}
+
+func TestEmailService_AdminNewRequestOverlapWarning(t *testing.T) {
+	svc := NewEmailService(&config.Config{AppURL: "http://localhost:3000"})
+	tmpl := svc.templatesFor(domain.LocaleEnglish)
+
+	out, err := svc.executeTemplate(tmpl.adminNewRequestText, adminNotificationData{RequesterName: "Alex", OverlapWarning: true})
+	require.NoError(t, err)
+	assert.Contains(t, out, "overlaps another of the employee's requests")
+
+	out, err = svc.executeTemplate(tmpl.adminNewRequestHTML, adminNotificationData{RequesterName: "Alex"})
+	require.NoError(t, err)
+	assert.NotContains(t, out, "overlaps another")
+}
+
+func TestEmailService_LowBalanceTemplates(t *testing.T) {
+	svc := NewEmailService(&config.Config{AppURL: "http://localhost:3000"})
+
+	out, err := svc.executeTemplate(svc.lowBalanceTextTmpl, lowBalanceEmailData{RecipientName: "Alex", EmployeeName: "Alex", Balance: 4, Unit: "days"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "you have 4 days of vacation left")
+
+	out, err = svc.executeTemplate(svc.lowBalanceHTMLTmpl, lowBalanceEmailData{RecipientName: "Sam", EmployeeName: "Alex", Balance: 32, Unit: "hours", ForManager: true})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Alex</strong> has")
+	assert.Contains(t, out, "32 hours")
+}
+
+func TestEmailService_TeammateOffTemplates(t *testing.T) {
+	svc := NewEmailService(&config.Config{AppURL: "http://localhost:3000"})
+
+	out, err := svc.executeTemplate(svc.teammateOffTextTmpl, teammateOffEmailData{RecipientName: "Sam", EmployeeName: "Alex", StartDate: "2027-06-10", EndDate: "2027-06-15"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Alex is off from 2027-06-10 to 2027-06-15")
+}
+
+func TestEmailService_LeaveReminderTemplates(t *testing.T) {
+	svc := NewEmailService(&config.Config{AppURL: "http://localhost:3000"})
+
+	out, err := svc.executeTemplate(svc.templatesFor(domain.LocaleEnglish).leaveReminderText, vacationEmailData{UserName: "Alex", StartDate: "2027-06-14", EndDate: "2027-06-18", TotalDays: 5, Unit: "days"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "you're off from 2027-06-14 to 2027-06-18 (5 days)")
+
+	// The German copy translates the balance unit rather than printing it verbatim
+	de := svc.templatesFor(domain.LocaleGerman)
+	out, err = svc.executeTemplate(de.leaveReminderText, vacationEmailData{UserName: "Alex", StartDate: "2027-06-14", EndDate: "2027-06-18", TotalDays: 40, Unit: "hours"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "abwesend (40 Stunden)")
+
+	out, err = svc.executeTemplate(de.leaveReminderHTML, vacationEmailData{UserName: "Alex", StartDate: "2027-06-14", EndDate: "2027-06-18", TotalDays: 5, Unit: "days"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "(5 Tage)")
+}
+
+func TestEmailService_AllowTeammateOffThrottles(t *testing.T) {
+	svc := NewEmailService(&config.Config{})
+	now := time.Date(2027, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	for i := 0; i < teammateOffEmailLimit; i++ {
+		assert.True(t, svc.allowTeammateOff("u1", now.Add(time.Duration(i)*time.Minute)))
+	}
+	assert.False(t, svc.allowTeammateOff("u1", now.Add(10*time.Minute)), "limit reached within the window")
+	assert.True(t, svc.allowTeammateOff("u2", now.Add(10*time.Minute)), "limits are per recipient")
+
+	// Once the first send ages out of the window there is room again
+	assert.True(t, svc.allowTeammateOff("u1", now.Add(teammateOffEmailWindow+time.Second)))
+}
+
+func TestEmailService_TemplatesFor(t *testing.T) {
+	svc := NewEmailService(&config.Config{AppURL: "http://localhost:3000"})
+
+	de := svc.templatesFor(domain.LocaleGerman)
+	assert.Equal(t, "Urlaubsantrag genehmigt!", de.requestApprovedSubject)
+
+	out, err := svc.executeTemplate(de.requestApprovedText, vacationEmailData{UserName: "Alex", TotalDays: 3})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Gute Nachrichten, Alex!")
+
+	// Unknown locales fall back to English
+	assert.Equal(t, requestApprovedSubject, svc.templatesFor("fr").requestApprovedSubject)
+	assert.Equal(t, requestApprovedSubject, svc.templatesFor("").requestApprovedSubject)
+}
+
+func TestEmailService_PreviewUsesBranding(t *testing.T) {
+	svc := NewEmailService(&config.Config{
+		AppURL:  "http://localhost:3000",
+		AppName: "Acme Leave",
+		LogoURL: "https://cdn.example.com/acme.png",
+	})
+
+	preview, err := svc.PreviewWelcome("Alex", "alex@example.com", "tmp", "http://localhost:3000")
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome to Acme Leave!", preview.Subject)
+	assert.Contains(t, preview.HTMLBody, `src="https://cdn.example.com/acme.png"`)
+	assert.Contains(t, preview.TextBody, "Acme Leave")
+	assert.NotContains(t, preview.HTMLBody, config.DefaultAppName)
+
+	// Defaults keep the original name and logo
+	svc = NewEmailService(&config.Config{AppURL: "http://localhost:3000"})
+	preview, err = svc.PreviewWelcome("Alex", "alex@example.com", "tmp", "http://localhost:3000")
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome to VacayTracker!", preview.Subject)
+	assert.Contains(t, preview.HTMLBody, `src="http://localhost:3000/logo.png"`)
+}
+
+func TestEmailService_BuildSendRequestUsesConfiguredSender(t *testing.T) {
+	svc := NewEmailService(&config.Config{
+		AppURL:           "http://localhost:3000",
+		EmailFromAddress: "noreply@example.com",
+		EmailFromName:    "HR Team",
+		EmailReplyTo:     "hr@example.com",
+	})
+
+	params := svc.buildSendRequest("alex@example.com", "Welcome", "<p>Hi</p>", "Hi", nil)
+	assert.Equal(t, "HR Team <noreply@example.com>", params.From)
+	assert.Equal(t, "hr@example.com", params.ReplyTo)
+
+	// A per-email reply-to, like the requester on admin notifications, wins
+	params = svc.buildSendRequest("admin@example.com", "New request", "", "", &SendOptions{ReplyTo: "alex@example.com"})
+	assert.Equal(t, "alex@example.com", params.ReplyTo)
+
+	// Without EMAIL_REPLY_TO replies go to the sender
+	svc = NewEmailService(&config.Config{AppURL: "http://localhost:3000", EmailFromAddress: "noreply@example.com"})
+	assert.Empty(t, svc.buildSendRequest("alex@example.com", "Welcome", "", "", nil).ReplyTo)
+}
+
+func TestEmailService_ValidateTemplates(t *testing.T) {
+	svc := NewEmailService(&config.Config{AppURL: "http://localhost:3000"})
+
+	checked, failures := svc.ValidateTemplates()
+	assert.Empty(t, failures)
+	assert.Greater(t, checked, 0)
+}
+
+func TestValidateTemplates_ReportsParseAndRenderErrors(t *testing.T) {
+	vacation := []templateSample{
+		{"with reason", vacationEmailData{Reason: "Offsite"}},
+		{"without reason", vacationEmailData{}},
+	}
+
+	checked, failures := validateTemplates([]templateCheck{
+		{"ok", "{{if .Reason}}{{.Reason}}{{end}}", vacation},
+		{"unclosed", "{{if .Reason}}{{.Reason}}", vacation},
+		{"unknown field", "{{if .Reason}}{{.Comment}}{{end}}", vacation},
+	})
+
+	assert.Equal(t, 5, checked)
+	require.Len(t, failures, 2)
+	assert.Equal(t, "unclosed", failures[0].Template)
+	assert.Empty(t, failures[0].Sample)
+	assert.Equal(t, "unknown field", failures[1].Template)
+	assert.Equal(t, "with reason", failures[1].Sample, "only the branch that renders .Comment fails")
+}
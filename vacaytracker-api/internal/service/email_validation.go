@@ -0,0 +1,213 @@
+package service
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository"
+)
+
+// TemplateError describes an email template that failed to parse or render
+type TemplateError struct {
+	Template string // Locale-qualified template name, e.g. "de/requestRejectedHTML"
+	Sample   string // Sample data set the render failed with; empty for parse errors
+	Err      string
+}
+
+// templateSample is a named set of synthetic data to render a template with
+type templateSample struct {
+	name string
+	data interface{}
+}
+
+// templateCheck pairs a raw template with the sample data it must render
+type templateCheck struct {
+	name    string
+	source  string
+	samples []templateSample
+}
+
+// ValidateTemplates parses every email template from source and renders it with synthetic data,
+// covering very long values, missing branding and both sides of each optional section
+// It returns how many renders were attempted and the failures; subjects are checked for unfilled placeholders
+func (s *EmailService) ValidateTemplates() (int, []TemplateError) {
+	checked, failures := validateTemplates(s.templateChecks())
+
+	subjects := map[string]string{
+		"passwordResetSubject":  passwordResetSubject,
+		"emailChangeSubject":    emailChangeSubject,
+		"emailChangedSubject":   emailChangedSubject,
+		"lowBalanceSubject":     lowBalanceSubject,
+		"teammateOffSubject":    teammateOffSubject,
+		"newsletterSubject":     newsletterSubject,
+		"personalDigestSubject": personalDigestSubject,
+	}
+	for _, locale := range sortedLocales() {
+		src := localeTemplateSources[locale]
+		subjects[locale+"/welcomeSubject"] = src.welcomeSubject
+		subjects[locale+"/requestSubmittedSubject"] = src.requestSubmittedSubject
+		subjects[locale+"/requestApprovedSubject"] = src.requestApprovedSubject
+		subjects[locale+"/requestRejectedSubject"] = src.requestRejectedSubject
+		subjects[locale+"/adminNewRequestSubject"] = src.adminNewRequestSubject
+		subjects[locale+"/adminWithdrawnSubject"] = src.adminWithdrawnSubject
+		subjects[locale+"/requestCommentSubject"] = src.requestCommentSubject
+		subjects[locale+"/leaveReminderSubject"] = src.leaveReminderSubject
+	}
+	names := make([]string, 0, len(subjects))
+	for name := range subjects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		checked++
+		if branded := s.brandSubject(subjects[name]); strings.Contains(branded, "{{") {
+			failures = append(failures, TemplateError{Template: name, Err: "unfilled placeholder in subject: " + branded})
+		}
+	}
+
+	return checked, failures
+}
+
+// validateTemplates parses each check's source and executes it with every sample
+func validateTemplates(checks []templateCheck) (int, []TemplateError) {
+	checked := 0
+	failures := []TemplateError{}
+	for _, check := range checks {
+		tmpl, err := template.New(check.name).Parse(check.source)
+		if err != nil {
+			checked++
+			failures = append(failures, TemplateError{Template: check.name, Err: err.Error()})
+			continue
+		}
+		for _, sample := range check.samples {
+			checked++
+			if err := tmpl.Execute(io.Discard, sample.data); err != nil {
+				failures = append(failures, TemplateError{Template: check.name, Sample: sample.name, Err: err.Error()})
+			}
+		}
+	}
+	return checked, failures
+}
+
+// sortedLocales returns the locales with translated templates in a stable order
+func sortedLocales() []string {
+	locales := make([]string, 0, len(localeTemplateSources))
+	for locale := range localeTemplateSources {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// templateChecks lists every email template with synthetic samples for its data struct
+// Each template gets a "full" sample with long values and every optional field set,
+// and a "minimal" sample with zero values, which also leaves the logo and app name empty
+func (s *EmailService) templateChecks() []templateCheck {
+	appURL, appName, logoURL := s.cfg.AppURL, s.cfg.BrandName(), s.cfg.BrandLogoURL()
+	longName := strings.TrimSpace(strings.Repeat("Maximiliane-Alexandra ", 10))
+	longText := strings.TrimSpace(strings.Repeat("A rather long explanation with <markup> & symbols. ", 20))
+
+	welcome := []templateSample{
+		{"full", welcomeEmailData{AppURL: appURL, AppName: appName, LogoURL: logoURL, UserName: longName, UserEmail: "very.long.address." + strings.Repeat("x", 60) + "@example.com", TempPassword: "Tmp-Pa$$w0rd<&>"}},
+		{"minimal", welcomeEmailData{}},
+	}
+	vacation := []templateSample{
+		{"full", vacationEmailData{AppURL: appURL, AppName: appName, LogoURL: logoURL, UserName: longName, StartDate: "2027-06-14", EndDate: "2027-06-18", TotalDays: 5, Unit: string(domain.BalanceUnitDays), Reason: longText, OriginalStartDate: "2027-06-07", OriginalEndDate: "2027-06-18"}},
+		{"minimal", vacationEmailData{}},
+	}
+	admin := []templateSample{
+		{"full", adminNotificationData{AppURL: appURL, AppName: appName, LogoURL: logoURL, RequesterName: longName, StartDate: "2027-06-14", EndDate: "2027-06-18", TotalDays: 5, RequestReason: longText, OverlapWarning: true}},
+		{"minimal", adminNotificationData{}},
+	}
+	comment := []templateSample{
+		{"full", commentEmailData{AppURL: appURL, AppName: appName, LogoURL: logoURL, Path: "/admin", RecipientName: longName, AuthorName: longName, RequesterName: longName, StartDate: "2027-06-14", EndDate: "2027-06-18", Body: longText}},
+		{"minimal", commentEmailData{}},
+	}
+
+	var checks []templateCheck
+	for _, locale := range sortedLocales() {
+		src := localeTemplateSources[locale]
+		checks = append(checks,
+			templateCheck{locale + "/welcomeHTML", src.welcomeHTML, welcome},
+			templateCheck{locale + "/welcomeText", src.welcomeText, welcome},
+			templateCheck{locale + "/requestSubmittedHTML", src.requestSubmittedHTML, vacation},
+			templateCheck{locale + "/requestSubmittedText", src.requestSubmittedText, vacation},
+			templateCheck{locale + "/requestApprovedHTML", src.requestApprovedHTML, vacation},
+			templateCheck{locale + "/requestApprovedText", src.requestApprovedText, vacation},
+			templateCheck{locale + "/requestRejectedHTML", src.requestRejectedHTML, vacation},
+			templateCheck{locale + "/requestRejectedText", src.requestRejectedText, vacation},
+			templateCheck{locale + "/adminNewRequestHTML", src.adminNewRequestHTML, admin},
+			templateCheck{locale + "/adminNewRequestText", src.adminNewRequestText, admin},
+			templateCheck{locale + "/adminWithdrawnHTML", src.adminWithdrawnHTML, admin},
+			templateCheck{locale + "/adminWithdrawnText", src.adminWithdrawnText, admin},
+			templateCheck{locale + "/requestCommentHTML", src.requestCommentHTML, comment},
+			templateCheck{locale + "/requestCommentText", src.requestCommentText, comment},
+			templateCheck{locale + "/leaveReminderHTML", src.leaveReminderHTML, vacation},
+			templateCheck{locale + "/leaveReminderText", src.leaveReminderText, vacation},
+		)
+	}
+
+	passwordReset := []templateSample{
+		{"full", passwordResetEmailData{AppURL: appURL, AppName: appName, LogoURL: logoURL, UserName: longName, ResetURL: appURL + "/reset-password?token=" + strings.Repeat("t", 200), ExpiresInMinutes: 60}},
+		{"minimal", passwordResetEmailData{}},
+	}
+	emailChange := []templateSample{
+		{"full", emailChangeEmailData{AppURL: appURL, AppName: appName, LogoURL: logoURL, UserName: longName, NewEmail: "new." + strings.Repeat("x", 60) + "@example.com", ConfirmURL: appURL + "/confirm-email?token=" + strings.Repeat("t", 200), ExpiresInHours: 24}},
+		{"minimal", emailChangeEmailData{}},
+	}
+	emailChanged := []templateSample{
+		{"full", emailChangedEmailData{AppURL: appURL, AppName: appName, LogoURL: logoURL, UserName: longName, OldEmail: "old." + strings.Repeat("x", 60) + "@example.com", NewEmail: "new." + strings.Repeat("x", 60) + "@example.com"}},
+		{"minimal", emailChangedEmailData{}},
+	}
+	lowBalance := []templateSample{
+		{"employee", lowBalanceEmailData{AppURL: appURL, AppName: appName, LogoURL: logoURL, RecipientName: longName, EmployeeName: longName, Balance: 2, Unit: string(domain.BalanceUnitDays)}},
+		{"manager", lowBalanceEmailData{AppURL: appURL, AppName: appName, LogoURL: logoURL, RecipientName: longName, EmployeeName: longName, Balance: 16, Unit: string(domain.BalanceUnitHours), ForManager: true}},
+		{"minimal", lowBalanceEmailData{}},
+	}
+	teammateOff := []templateSample{
+		{"full", teammateOffEmailData{AppURL: appURL, AppName: appName, LogoURL: logoURL, RecipientName: longName, EmployeeName: longName, StartDate: "2027-06-14", EndDate: "2027-06-18"}},
+		{"minimal", teammateOffEmailData{}},
+	}
+	newsletter := []templateSample{
+		{"full", &NewsletterData{
+			AppURL: appURL, AppName: appName, LogoURL: logoURL, RecipientName: longName, Period: "June 2027",
+			Stats:             &repository.MonthlyStats{TotalSubmitted: 12, TotalApproved: 8, TotalRejected: 1, TotalPending: 3, TotalDaysUsed: 40},
+			UpcomingVacations: []*domain.TeamVacation{{ID: "sample", UserName: longName, StartDate: "2027-06-14", EndDate: "2027-06-18", TotalDays: 5}},
+			LowBalanceUsers:   []LowBalanceUser{{UserName: longName, RemainingDays: 1}},
+			HasStats:          true, HasUpcoming: true, HasLowBalance: true,
+			UnsubscribeURL: appURL + "/api/v1/email/unsubscribe?token=sample",
+			Unit:           string(domain.BalanceUnitDays),
+		}},
+		{"empty", &NewsletterData{IsEmpty: true}},
+	}
+	digest := []templateSample{
+		{"full", &PersonalDigestData{
+			AppURL: appURL, AppName: appName, LogoURL: logoURL, RecipientName: longName, RemainingDays: 12,
+			Upcoming:       []*domain.VacationRequest{{ID: "sample", StartDate: "2027-06-14", EndDate: "2027-06-18", TotalDays: 5}},
+			HasUpcoming:    true,
+			UnsubscribeURL: appURL + "/api/v1/email/unsubscribe?token=sample",
+			Unit:           string(domain.BalanceUnitHours),
+		}},
+		{"minimal", &PersonalDigestData{}},
+	}
+
+	return append(checks,
+		templateCheck{"passwordResetHTML", passwordResetHTML, passwordReset},
+		templateCheck{"passwordResetText", passwordResetText, passwordReset},
+		templateCheck{"emailChangeHTML", emailChangeHTML, emailChange},
+		templateCheck{"emailChangeText", emailChangeText, emailChange},
+		templateCheck{"emailChangedHTML", emailChangedHTML, emailChanged},
+		templateCheck{"emailChangedText", emailChangedText, emailChanged},
+		templateCheck{"lowBalanceHTML", lowBalanceHTML, lowBalance},
+		templateCheck{"lowBalanceText", lowBalanceText, lowBalance},
+		templateCheck{"teammateOffHTML", teammateOffHTML, teammateOff},
+		templateCheck{"teammateOffText", teammateOffText, teammateOff},
+		templateCheck{"newsletterHTML", newsletterHTML, newsletter},
+		templateCheck{"newsletterText", newsletterText, newsletter},
+		templateCheck{"personalDigestHTML", personalDigestHTML, digest},
+		templateCheck{"personalDigestText", personalDigestText, digest},
+	)
+}
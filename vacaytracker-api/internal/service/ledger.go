@@ -0,0 +1,18 @@
+package service
+
+import (
+	"github.com/google/uuid"
+
+	"vacaytracker-api/internal/domain"
+)
+
+// newLedgerEntry builds a balance ledger entry for a change of delta days
+func newLedgerEntry(userID string, delta int, reason domain.LedgerReason, referenceID *string) *domain.LedgerEntry {
+	return &domain.LedgerEntry{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Delta:       delta,
+		Reason:      reason,
+		ReferenceID: referenceID,
+	}
+}
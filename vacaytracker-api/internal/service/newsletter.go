@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
+	"sort"
 	"time"
 
 	"vacaytracker-api/internal/config"
@@ -13,20 +15,39 @@ import (
 )
 
 const (
-	// LowBalanceThreshold is the number of vacation days below which users get a reminder
+	// LowBalanceThreshold is the number of vacation days at or below which users get a reminder
 	LowBalanceThreshold = 5
 )
 
 // NewsletterData holds all content for the newsletter
 type NewsletterData struct {
 	AppURL            string
+	AppName           string
+	LogoURL           string
 	RecipientName     string
 	Period            string
 	Stats             *repository.MonthlyStats
 	UpcomingVacations []*domain.TeamVacation
 	LowBalanceUsers   []LowBalanceUser
+	HasStats          bool // Whether the statistics section is included
 	HasUpcoming       bool
 	HasLowBalance     bool
+	IsEmpty           bool   // No section has anything to show; the email says so instead of being blank
+	UnsubscribeURL    string // Turns off the recipient's weekly digest without logging in
+	Unit              string // days or hours, the unit of balances and request totals
+}
+
+// PersonalDigestData holds the content of one employee's personal weekly digest
+type PersonalDigestData struct {
+	AppURL         string
+	AppName        string
+	LogoURL        string
+	RecipientName  string
+	RemainingDays  int
+	Upcoming       []*domain.VacationRequest // Approved requests ending today or later, soonest first
+	HasUpcoming    bool
+	UnsubscribeURL string
+	Unit           string // days or hours, the unit of balances and request totals
 }
 
 // LowBalanceUser represents a user with low vacation balance
@@ -42,6 +63,7 @@ type NewsletterService struct {
 	vacationRepo repository.VacationRepository
 	settingsRepo repository.SettingsRepository
 	emailService *EmailService
+	authService  *AuthService
 }
 
 // NewNewsletterService creates a new NewsletterService
@@ -51,6 +73,7 @@ func NewNewsletterService(
 	vacationRepo repository.VacationRepository,
 	settingsRepo repository.SettingsRepository,
 	emailService *EmailService,
+	authService *AuthService,
 ) *NewsletterService {
 	return &NewsletterService{
 		cfg:          cfg,
@@ -58,6 +81,7 @@ func NewNewsletterService(
 		vacationRepo: vacationRepo,
 		settingsRepo: settingsRepo,
 		emailService: emailService,
+		authService:  authService,
 	}
 }
 
@@ -69,21 +93,30 @@ func (s *NewsletterService) GetRecipients(ctx context.Context) ([]*domain.User,
 // GetStats returns aggregated statistics for the previous month
 func (s *NewsletterService) GetStats(ctx context.Context) (*repository.MonthlyStats, string, error) {
 	// Get previous month
-	now := time.Now()
-	prevMonth := now.AddDate(0, -1, 0)
-	year := prevMonth.Year()
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	prevMonth := time.Now().AddDate(0, -1, 0)
+	year := LeaveYearOf(settings, prevMonth)
 	month := int(prevMonth.Month())
 
-	stats, err := s.vacationRepo.GetMonthlyStats(ctx, year, month)
+	stats, err := s.vacationRepo.GetMonthlyStats(ctx, year, month, "")
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to get monthly stats: %w", err)
 	}
 
-	period := prevMonth.Format("January 2006")
-	return stats, period, nil
+	return stats, newsletterPeriod(), nil
+}
+
+// newsletterPeriod returns the name of the month the newsletter summarizes (the previous one)
+func newsletterPeriod() string {
+	return time.Now().AddDate(0, -1, 0).Format("January 2006")
 }
 
 // GetUpcomingVacations returns approved vacations for the next month
+// Remote days are left out since nobody is away for them
 func (s *NewsletterService) GetUpcomingVacations(ctx context.Context) ([]*domain.TeamVacation, error) {
 	// Get next month
 	now := time.Now()
@@ -91,7 +124,17 @@ func (s *NewsletterService) GetUpcomingVacations(ctx context.Context) ([]*domain
 	year := nextMonth.Year()
 	month := int(nextMonth.Month())
 
-	return s.vacationRepo.ListTeam(ctx, month, year)
+	vacations, err := s.vacationRepo.ListTeam(ctx, month, year, "")
+	if err != nil {
+		return nil, err
+	}
+	upcoming := make([]*domain.TeamVacation, 0, len(vacations))
+	for _, v := range vacations {
+		if v.Type != domain.RequestTypeRemote {
+			upcoming = append(upcoming, v)
+		}
+	}
+	return upcoming, nil
 }
 
 // GetLowBalanceUsers returns users with vacation balance at or below the threshold
@@ -112,33 +155,54 @@ func (s *NewsletterService) GetLowBalanceUsers(ctx context.Context) ([]LowBalanc
 	return result, nil
 }
 
-// BuildNewsletterData assembles all newsletter content for a specific recipient
+// BuildNewsletterData assembles the newsletter content for a specific recipient
+// Only the sections enabled in settings are loaded
 func (s *NewsletterService) BuildNewsletterData(ctx context.Context, recipientName string) (*NewsletterData, error) {
-	stats, period, err := s.GetStats(ctx)
+	settings, err := s.settingsRepo.Get(ctx)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+	sections := settings.DigestSections
+
+	data := &NewsletterData{
+		AppURL:        s.cfg.AppURL,
+		AppName:       s.cfg.BrandName(),
+		LogoURL:       s.cfg.BrandLogoURL(),
+		RecipientName: recipientName,
+		Period:        newsletterPeriod(),
+		Unit:          string(settings.BalanceUnit),
 	}
 
-	upcoming, err := s.GetUpcomingVacations(ctx)
-	if err != nil {
-		return nil, err
+	if sections.MonthlyStats {
+		stats, period, err := s.GetStats(ctx)
+		if err != nil {
+			return nil, err
+		}
+		data.Stats = stats
+		data.Period = period
+		data.HasStats = true
 	}
 
-	lowBalance, err := s.GetLowBalanceUsers(ctx)
-	if err != nil {
-		return nil, err
+	if sections.UpcomingVacations {
+		upcoming, err := s.GetUpcomingVacations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		data.UpcomingVacations = upcoming
+		data.HasUpcoming = len(upcoming) > 0
 	}
 
-	return &NewsletterData{
-		AppURL:            s.cfg.AppURL,
-		RecipientName:     recipientName,
-		Period:            period,
-		Stats:             stats,
-		UpcomingVacations: upcoming,
-		LowBalanceUsers:   lowBalance,
-		HasUpcoming:       len(upcoming) > 0,
-		HasLowBalance:     len(lowBalance) > 0,
-	}, nil
+	if sections.LowBalance {
+		lowBalance, err := s.GetLowBalanceUsers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		data.LowBalanceUsers = lowBalance
+		data.HasLowBalance = len(lowBalance) > 0
+	}
+
+	data.IsEmpty = !data.HasStats && !data.HasUpcoming && !data.HasLowBalance
+	return data, nil
 }
 
 // GeneratePreview creates a preview of the newsletter without sending
@@ -153,6 +217,7 @@ func (s *NewsletterService) GeneratePreview(ctx context.Context) (*dto.Newslette
 	if err != nil {
 		return nil, fmt.Errorf("failed to build newsletter data: %w", err)
 	}
+	data.UnsubscribeURL = s.cfg.AppURL + "/api/v1/email/unsubscribe?token=preview"
 
 	// Render templates using pre-compiled newsletter templates
 	htmlBody, err := s.emailService.RenderNewsletterHTML(data)
@@ -172,7 +237,7 @@ func (s *NewsletterService) GeneratePreview(ctx context.Context) (*dto.Newslette
 	}
 
 	return &dto.NewsletterPreviewResponse{
-		Subject:        newsletterSubject,
+		Subject:        s.emailService.brandSubject(newsletterSubject),
 		HTMLBody:       htmlBody,
 		TextBody:       textBody,
 		Recipients:     recipientEmails,
@@ -201,6 +266,13 @@ func (s *NewsletterService) Send(ctx context.Context) (int, error) {
 			continue
 		}
 
+		unsubscribeURL, err := s.UnsubscribeURL(recipient)
+		if err != nil {
+			log.Printf("[NEWSLETTER ERROR] Failed to create unsubscribe link for %s: %v", recipient.Email, err)
+			continue
+		}
+		data.UnsubscribeURL = unsubscribeURL
+
 		// Render templates using pre-compiled newsletter templates
 		htmlBody, err := s.emailService.RenderNewsletterHTML(data)
 		if err != nil {
@@ -219,7 +291,7 @@ func (s *NewsletterService) Send(ctx context.Context) (int, error) {
 			IdempotencyKey: generateIdempotencyKey(recipient.Email, newsletterSubject, data.Period),
 			Tags:           []string{"newsletter", "monthly-summary"},
 		}
-		s.emailService.SendAsync(recipient.Email, newsletterSubject, htmlBody, textBody, opts)
+		s.emailService.SendAsync(recipient.Email, s.emailService.brandSubject(newsletterSubject), htmlBody, textBody, opts)
 		sentCount++
 	}
 
@@ -234,6 +306,149 @@ func (s *NewsletterService) Send(ctx context.Context) (int, error) {
 	return sentCount, nil
 }
 
+// BuildPersonalDigestData assembles an employee's upcoming approved leave and remaining balance as of now
+func (s *NewsletterService) BuildPersonalDigestData(ctx context.Context, user *domain.User, now time.Time) (*PersonalDigestData, error) {
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	approved := domain.StatusApproved
+	upcoming, err := s.vacationRepo.ListByUser(ctx, user.ID, &approved, nil, now.Format("2006-01-02"), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upcoming vacations: %w", err)
+	}
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].StartDate < upcoming[j].StartDate
+	})
+
+	return &PersonalDigestData{
+		AppURL:        s.cfg.AppURL,
+		AppName:       s.cfg.BrandName(),
+		LogoURL:       s.cfg.BrandLogoURL(),
+		RecipientName: user.Name,
+		RemainingDays: user.VacationBalance,
+		Upcoming:      upcoming,
+		HasUpcoming:   len(upcoming) > 0,
+		Unit:          string(settings.BalanceUnit),
+	}, nil
+}
+
+// SendPersonalDigests emails every active employee who opted in to the weekly digest
+// a summary of their own upcoming leave and balance, and records the run
+func (s *NewsletterService) SendPersonalDigests(ctx context.Context, now time.Time) (int, error) {
+	recipients, err := s.GetRecipients(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get recipients: %w", err)
+	}
+
+	sentCount := 0
+	for _, recipient := range recipients {
+		// Admins get the newsletter instead; the preference is re-checked rather than trusting the query
+		if !recipient.IsEmployee() || !recipient.Active || !recipient.EmailPreferences.WeeklyDigest {
+			continue
+		}
+
+		data, err := s.BuildPersonalDigestData(ctx, recipient, now)
+		if err != nil {
+			log.Printf("[NEWSLETTER ERROR] Failed to build personal digest for %s: %v", recipient.Email, err)
+			continue
+		}
+
+		unsubscribeURL, err := s.UnsubscribeURL(recipient)
+		if err != nil {
+			log.Printf("[NEWSLETTER ERROR] Failed to create unsubscribe link for %s: %v", recipient.Email, err)
+			continue
+		}
+		data.UnsubscribeURL = unsubscribeURL
+
+		htmlBody, err := s.emailService.RenderPersonalDigestHTML(data)
+		if err != nil {
+			log.Printf("[NEWSLETTER ERROR] Failed to render personal digest HTML for %s: %v", recipient.Email, err)
+			continue
+		}
+
+		textBody, err := s.emailService.RenderPersonalDigestText(data)
+		if err != nil {
+			log.Printf("[NEWSLETTER ERROR] Failed to render personal digest text for %s: %v", recipient.Email, err)
+			continue
+		}
+
+		opts := &SendOptions{
+			IdempotencyKey: generateIdempotencyKey(recipient.Email, personalDigestSubject, now.Format("2006-01-02")),
+			Tags:           []string{"newsletter", "personal-digest"},
+		}
+		s.emailService.SendAsync(recipient.Email, s.emailService.brandSubject(personalDigestSubject), htmlBody, textBody, opts)
+		sentCount++
+	}
+
+	// Recorded even when nobody opted in so the run isn't repeated later the same day
+	if err := s.settingsRepo.UpdateLastPersonalDigestSent(ctx, now); err != nil {
+		log.Printf("[NEWSLETTER ERROR] Failed to update last personal digest timestamp: %v", err)
+	}
+
+	log.Printf("[NEWSLETTER] Personal digest sent to %d employees", sentCount)
+	return sentCount, nil
+}
+
+// SendLeaveReminders emails employees whose approved leave starts the day after now in the configured time zone
+// Each request is claimed before its email is queued, so a restart or a repeated run doesn't send it twice
+func (s *NewsletterService) SendLeaveReminders(ctx context.Context, now time.Time) (int, error) {
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	tomorrow := settings.Today(now).AddDate(0, 0, 1).Format("2006-01-02")
+	requests, err := s.vacationRepo.ListApprovedStartingOn(ctx, tomorrow)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list leave starting %s: %w", tomorrow, err)
+	}
+
+	sentCount := 0
+	for _, vacation := range requests {
+		// Remote days aren't time off
+		if vacation.IsRemote() {
+			continue
+		}
+
+		user, err := s.userRepo.GetByID(ctx, vacation.UserID)
+		if err != nil || user == nil {
+			log.Printf("[NEWSLETTER ERROR] Failed to load user %s for leave reminder: %v", vacation.UserID, err)
+			continue
+		}
+		if !user.Active || !user.EmailPreferences.VacationUpdates {
+			continue
+		}
+
+		claimed, err := s.vacationRepo.ClaimStartReminder(ctx, vacation.ID)
+		if err != nil {
+			log.Printf("[NEWSLETTER ERROR] Failed to claim leave reminder for request %s: %v", vacation.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		s.emailService.SendLeaveReminder(user, vacation, settings.BalanceUnit)
+		sentCount++
+	}
+
+	if sentCount > 0 {
+		log.Printf("[NEWSLETTER] Leave reminders sent to %d employees", sentCount)
+	}
+	return sentCount, nil
+}
+
+// UnsubscribeURL returns a link that turns off the recipient's weekly digest without logging in
+func (s *NewsletterService) UnsubscribeURL(recipient *domain.User) (string, error) {
+	token, err := s.authService.GenerateUnsubscribeToken(recipient, domain.EmailPrefWeeklyDigest)
+	if err != nil {
+		return "", err
+	}
+	return s.cfg.AppURL + "/api/v1/email/unsubscribe?token=" + url.QueryEscape(token), nil
+}
+
 // UpdateLastSent updates the lastSentAt timestamp in settings
 func (s *NewsletterService) UpdateLastSent(ctx context.Context) error {
 	return s.settingsRepo.UpdateLastNewsletterSent(ctx, time.Now())
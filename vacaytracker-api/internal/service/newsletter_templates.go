@@ -2,14 +2,14 @@ package service
 
 // Newsletter email templates
 
-const newsletterSubject = "VacayTracker Monthly Summary"
+const newsletterSubject = "{{.AppName}} Monthly Summary"
 
 const newsletterHTML = `<!DOCTYPE html>
 <html lang="en">
 <head>
     <meta charset="utf-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>VacayTracker Monthly Summary</title>
+    <title>{{.AppName}} Monthly Summary</title>
     <!--[if mso]>
     <noscript>
         <xml>
@@ -33,7 +33,7 @@ const newsletterHTML = `<!DOCTYPE html>
                     <!-- Header with Logo -->
                     <tr>
                         <td style="padding: 32px 40px 24px; text-align: center;">
-                            <img src="{{.AppURL}}/logo.png" width="64" height="64" alt="VacayTracker" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
                             <h1 style="margin: 0 0 8px; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Monthly Summary</h1>
                             <p style="margin: 0; color: #0D83A2; font-size: 16px; font-weight: 500;">{{.Period}}</p>
                         </td>
@@ -49,9 +49,10 @@ const newsletterHTML = `<!DOCTYPE html>
                                 Ahoy, <strong style="color: #00384F;">{{.RecipientName}}</strong>!
                             </p>
                             <p style="margin: 0 0 28px; color: #374151; font-size: 16px; line-height: 1.6;">
-                                Here's your monthly vacation summary from VacayTracker.
+                                Here's your monthly vacation summary from {{.AppName}}.
                             </p>
 
+                            {{if .HasStats}}
                             <!-- Statistics Section -->
                             <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 24px;">
                                 <h2 style="margin: 0 0 16px; color: #0D83A2; font-size: 16px; font-weight: 600;">Monthly Statistics</h2>
@@ -78,6 +79,14 @@ const newsletterHTML = `<!DOCTYPE html>
                                     </tr>
                                 </table>
                             </div>
+                            {{end}}
+
+                            {{if .IsEmpty}}
+                            <!-- Nothing To Report -->
+                            <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 24px; text-align: center;">
+                                <p style="margin: 0; color: #6b7280; font-size: 14px;">There's nothing new to report this time. Enjoy the calm seas!</p>
+                            </div>
+                            {{end}}
 
                             {{if .HasUpcoming}}
                             <!-- Upcoming Vacations Section -->
@@ -90,7 +99,7 @@ const newsletterHTML = `<!DOCTYPE html>
                                     <tr>
                                         <td style="padding: 12px 0; color: #374151; font-size: 14px; border-bottom: 1px solid #e2e8f0;">
                                             <strong style="color: #00384F;">{{.UserName}}</strong><br>
-                                            <span style="color: #6b7280; font-size: 13px;">{{.StartDate}} - {{.EndDate}} ({{.TotalDays}} days)</span>
+                                            <span style="color: #6b7280; font-size: 13px;">{{.StartDate}} - {{.EndDate}} ({{.TotalDays}} {{$.Unit}})</span>
                                         </td>
                                     </tr>
                                     {{end}}
@@ -108,7 +117,7 @@ const newsletterHTML = `<!DOCTYPE html>
                                     {{range .LowBalanceUsers}}
                                     <tr>
                                         <td style="padding: 8px 0; color: #00384F; font-size: 14px; font-weight: 500;">{{.UserName}}</td>
-                                        <td style="padding: 8px 0; color: #92400e; font-size: 14px; font-weight: 600; text-align: right;">{{.RemainingDays}} days</td>
+                                        <td style="padding: 8px 0; color: #92400e; font-size: 14px; font-weight: 600; text-align: right;">{{.RemainingDays}} {{$.Unit}}</td>
                                     </tr>
                                     {{end}}
                                 </table>
@@ -124,11 +133,14 @@ const newsletterHTML = `<!DOCTYPE html>
                     <!-- Footer -->
                     <tr>
                         <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
-                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">VacayTracker</p>
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
                             <p style="margin: 0 0 8px; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
                             <p style="margin: 0; color: #9ca3af; font-size: 11px;">
                                 You're receiving this because you opted in to digest emails.
-                            </p>
+                            </p>{{if .UnsubscribeURL}}
+                            <p style="margin: 8px 0 0; font-size: 11px;">
+                                <a href="{{.UnsubscribeURL}}" style="color: #0a6a84; text-decoration: underline;">Unsubscribe from the digest</a>
+                            </p>{{end}}
                         </td>
                     </tr>
                 </table>
@@ -138,24 +150,29 @@ const newsletterHTML = `<!DOCTYPE html>
 </body>
 </html>`
 
-const newsletterText = `VacayTracker Monthly Summary - {{.Period}}
+const newsletterText = `{{.AppName}} Monthly Summary - {{.Period}}
 
 Ahoy, {{.RecipientName}}!
 
-Here's your monthly vacation summary from VacayTracker.
+Here's your monthly vacation summary from {{.AppName}}.
 
+{{if .HasStats}}
 === MONTHLY STATISTICS ===
 Requests Submitted: {{.Stats.TotalSubmitted}}
 Approved: {{.Stats.TotalApproved}}
 Rejected: {{.Stats.TotalRejected}}
 Pending: {{.Stats.TotalPending}}
 Total Days Used: {{.Stats.TotalDaysUsed}}
+{{end}}
+{{if .IsEmpty}}
+There's nothing new to report this time. Enjoy the calm seas!
+{{end}}
 
 {{if .HasUpcoming}}
 === UPCOMING VACATIONS ===
 Team members on vacation next month:
 {{range .UpcomingVacations}}
-- {{.UserName}}: {{.StartDate}} - {{.EndDate}} ({{.TotalDays}} days)
+- {{.UserName}}: {{.StartDate}} - {{.EndDate}} ({{.TotalDays}} {{$.Unit}})
 {{end}}
 {{end}}
 
@@ -163,12 +180,120 @@ Team members on vacation next month:
 === LOW BALANCE REMINDER ===
 The following team members have low vacation balances:
 {{range .LowBalanceUsers}}
-- {{.UserName}}: {{.RemainingDays}} days remaining
+- {{.UserName}}: {{.RemainingDays}} {{$.Unit}} remaining
 {{end}}
 {{end}}
 
 View your dashboard at: {{.AppURL}}/employee
 
 ---
-VacayTracker - Your vacation tracking companion
-You're receiving this because you opted in to weekly digest emails.`
+{{.AppName}} - Your vacation tracking companion
+You're receiving this because you opted in to weekly digest emails.{{if .UnsubscribeURL}}
+Unsubscribe: {{.UnsubscribeURL}}{{end}}`
+
+// Personal weekly digest templates
+
+const personalDigestSubject = "Your Week in {{.AppName}}"
+
+const personalDigestHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Your Week in {{.AppName}}</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #e6f7f9;">
+    <!-- Preheader text (shows in inbox preview) -->
+    <div style="display: none; max-height: 0; overflow: hidden; mso-hide: all;">
+        You have {{.RemainingDays}} {{.Unit}} of vacation left. See your upcoming time off.
+        &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847; &#847;
+    </div>
+    <table role="presentation" style="width: 100%; border-collapse: collapse;">
+        <tr>
+            <td align="center" style="padding: 40px 20px;">
+                <table role="presentation" style="width: 600px; max-width: 100%; border-collapse: collapse; background-color: #ffffff; border-radius: 16px; box-shadow: 0 4px 20px rgba(13, 131, 162, 0.08);">
+                    <!-- Header with Logo -->
+                    <tr>
+                        <td style="padding: 32px 40px 24px; text-align: center;">
+                            <img src="{{.LogoURL}}" width="64" height="64" alt="{{.AppName}}" style="height: 64px; width: 64px; display: block; margin: 0 auto 16px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; font-size: 18px; font-weight: 600; color: #0D83A2;">
+                            <h1 style="margin: 0; color: #00384F; font-size: 24px; font-weight: 600; letter-spacing: -0.5px;">Your Week</h1>
+                        </td>
+                    </tr>
+                    <!-- Status Bar (Ocean brand) -->
+                    <tr>
+                        <td style="padding: 0; height: 4px; background: linear-gradient(90deg, #0D83A2 0%, #18C8D3 100%); background-color: #0D83A2;" bgcolor="#0D83A2"></td>
+                    </tr>
+                    <!-- Content -->
+                    <tr>
+                        <td style="padding: 32px 40px;">
+                            <p style="margin: 0 0 20px; color: #374151; font-size: 16px; line-height: 1.6;">
+                                Ahoy, <strong style="color: #00384F;">{{.RecipientName}}</strong>!
+                            </p>
+
+                            <!-- Balance -->
+                            <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 24px; text-align: center;">
+                                <p style="margin: 0 0 4px; color: #6b7280; font-size: 14px;">Remaining balance</p>
+                                <p style="margin: 0; color: #0D83A2; font-size: 28px; font-weight: 700;">{{.RemainingDays}} {{$.Unit}}</p>
+                            </div>
+
+                            <!-- Upcoming Leave -->
+                            <div style="background-color: #f8fafc; border: 1px solid #e2e8f0; border-radius: 12px; padding: 20px; margin: 0 0 24px;">
+                                <h2 style="margin: 0 0 12px; color: #00384F; font-size: 16px; font-weight: 600;">Your Upcoming Vacations</h2>
+                                {{if .HasUpcoming}}
+                                <table role="presentation" style="width: 100%; border-collapse: collapse;">
+                                    {{range .Upcoming}}
+                                    <tr>
+                                        <td style="padding: 12px 0; color: #374151; font-size: 14px; border-bottom: 1px solid #e2e8f0;">
+                                            {{.StartDate}} - {{.EndDate}} <span style="color: #6b7280; font-size: 13px;">({{.TotalDays}} {{$.Unit}})</span>
+                                        </td>
+                                    </tr>
+                                    {{end}}
+                                </table>
+                                {{else}}
+                                <p style="margin: 0; color: #6b7280; font-size: 14px;">No approved vacations coming up. Time to plan your next voyage?</p>
+                                {{end}}
+                            </div>
+
+                            <!-- CTA Button -->
+                            <div style="text-align: center;">
+                                <a href="{{.AppURL}}/employee" style="display: inline-block; padding: 14px 32px; background-color: #0D83A2; color: #ffffff; text-decoration: none; border-radius: 8px; font-weight: 600; font-size: 16px; box-shadow: 0 2px 8px rgba(13, 131, 162, 0.25);">View Dashboard</a>
+                            </div>
+                        </td>
+                    </tr>
+                    <!-- Footer -->
+                    <tr>
+                        <td style="padding: 24px 40px; background-color: #e6f7f9; border-radius: 0 0 16px 16px; text-align: center; border-top: 1px solid #cceff3;">
+                            <p style="margin: 0 0 4px; color: #0a6a84; font-size: 13px; font-weight: 500;">{{.AppName}}</p>
+                            <p style="margin: 0 0 8px; color: #6b7280; font-size: 12px;">Your vacation tracking companion</p>
+                            <p style="margin: 0; color: #9ca3af; font-size: 11px;">
+                                You're receiving this because you opted in to digest emails.
+                            </p>{{if .UnsubscribeURL}}
+                            <p style="margin: 8px 0 0; font-size: 11px;">
+                                <a href="{{.UnsubscribeURL}}" style="color: #0a6a84; text-decoration: underline;">Unsubscribe from the digest</a>
+                            </p>{{end}}
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`
+
+const personalDigestText = `Your Week in {{.AppName}}
+
+Ahoy, {{.RecipientName}}!
+
+Remaining balance: {{.RemainingDays}} {{.Unit}}
+
+=== YOUR UPCOMING VACATIONS ===
+{{if .HasUpcoming}}{{range .Upcoming}}- {{.StartDate}} - {{.EndDate}} ({{.TotalDays}} {{$.Unit}})
+{{end}}{{else}}No approved vacations coming up. Time to plan your next voyage?
+{{end}}
+View your dashboard: {{.AppURL}}/employee
+
+---
+{{.AppName}} - Your vacation tracking companion
+You're receiving this because you opted in to digest emails.{{if .UnsubscribeURL}}
+Unsubscribe: {{.UnsubscribeURL}}{{end}}
+`
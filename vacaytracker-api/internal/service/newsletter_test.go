@@ -1,10 +1,19 @@
 package service
 
 import (
+	"context"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/config"
 	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository"
+	"vacaytracker-api/internal/testutil"
 )
 
 func TestShouldSendNewsletter(t *testing.T) {
@@ -84,6 +93,7 @@ func TestShouldSendNewsletter(t *testing.T) {
 				Newsletter: domain.NewsletterConfig{
 					Enabled:   true,
 					Frequency: "weekly",
+					DayOfWeek: int(time.Monday),
 				},
 			},
 			// December 15, 2025 is a Monday
@@ -96,6 +106,7 @@ func TestShouldSendNewsletter(t *testing.T) {
 				Newsletter: domain.NewsletterConfig{
 					Enabled:   true,
 					Frequency: "weekly",
+					DayOfWeek: int(time.Monday),
 				},
 			},
 			now:      time.Date(2025, 12, 16, 9, 0, 0, 0, time.UTC), // Tuesday
@@ -107,12 +118,51 @@ func TestShouldSendNewsletter(t *testing.T) {
 				Newsletter: domain.NewsletterConfig{
 					Enabled:    true,
 					Frequency:  "weekly",
+					DayOfWeek:  int(time.Monday),
 					LastSentAt: timePtr(time.Date(2025, 12, 15, 6, 0, 0, 0, time.UTC)),
 				},
 			},
 			now:      time.Date(2025, 12, 15, 9, 0, 0, 0, time.UTC), // Monday
 			expected: false,
 		},
+		{
+			name: "weekly - configured Friday",
+			settings: domain.Settings{
+				Newsletter: domain.NewsletterConfig{
+					Enabled:   true,
+					Frequency: "weekly",
+					DayOfWeek: int(time.Friday),
+				},
+			},
+			now:      time.Date(2025, 12, 19, 9, 0, 0, 0, time.UTC), // Friday
+			expected: true,
+		},
+		{
+			name: "weekly - correct day, before the configured hour",
+			settings: domain.Settings{
+				Newsletter: domain.NewsletterConfig{
+					Enabled:   true,
+					Frequency: "weekly",
+					DayOfWeek: int(time.Monday),
+					Hour:      10,
+				},
+			},
+			now:      time.Date(2025, 12, 15, 9, 59, 0, 0, time.UTC), // Monday
+			expected: false,
+		},
+		{
+			name: "monthly - correct day, at the configured hour",
+			settings: domain.Settings{
+				Newsletter: domain.NewsletterConfig{
+					Enabled:    true,
+					Frequency:  "monthly",
+					DayOfMonth: 15,
+					Hour:       9,
+				},
+			},
+			now:      time.Date(2025, 12, 15, 9, 0, 0, 0, time.UTC),
+			expected: true,
+		},
 		{
 			name: "invalid frequency",
 			settings: domain.Settings{
@@ -190,3 +240,245 @@ func TestIsSameDay(t *testing.T) {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestNewsletterUnsubscribeURL(t *testing.T) {
+	cfg := &config.Config{AppURL: "http://localhost:3000"}
+	authService := NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, "test-secret-key-that-is-at-least-32-chars")
+	emailService := NewEmailService(cfg)
+	svc := NewNewsletterService(cfg, &testutil.MockUserRepository{}, &testutil.MockVacationRepository{}, &testutil.MockSettingsRepository{}, emailService, authService)
+
+	link, err := svc.UnsubscribeURL(&domain.User{ID: "user-1"})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(link, "http://localhost:3000/api/v1/email/unsubscribe?token="))
+
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	claims, err := authService.parseToken(parsed.Query().Get("token"))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.UserID)
+	assert.Equal(t, TokenPurposeUnsubscribe, claims.Purpose)
+	assert.Equal(t, domain.EmailPrefWeeklyDigest, claims.Preference)
+
+	// The link is rendered in both newsletter bodies
+	data := &NewsletterData{AppURL: cfg.AppURL, Stats: &repository.MonthlyStats{}, UnsubscribeURL: link}
+	text, err := emailService.RenderNewsletterText(data)
+	require.NoError(t, err)
+	assert.Contains(t, text, "Unsubscribe: "+link)
+	html, err := emailService.RenderNewsletterHTML(data)
+	require.NoError(t, err)
+	assert.Contains(t, html, "Unsubscribe from the digest")
+}
+
+// newDigestTestService returns a newsletter service whose settings use sections
+// and whose repositories fail the test when a disabled section is loaded
+func newDigestTestService(t *testing.T, sections domain.DigestSections) *NewsletterService {
+	t.Helper()
+	cfg := &config.Config{AppURL: "http://localhost:3000"}
+	settingsRepo := &testutil.MockSettingsRepository{
+		GetFn: func(ctx context.Context) (*domain.Settings, error) {
+			settings := domain.DefaultSettings()
+			settings.DigestSections = sections
+			return &settings, nil
+		},
+	}
+	vacationRepo := &testutil.MockVacationRepository{
+		GetMonthlyStatsFn: func(ctx context.Context, year, month int, teamID string) (*repository.MonthlyStats, error) {
+			if !sections.MonthlyStats {
+				t.Error("monthly stats loaded while the section is disabled")
+			}
+			return &repository.MonthlyStats{TotalPending: 4}, nil
+		},
+		ListTeamFn: func(ctx context.Context, month, year int, teamID string) ([]*domain.TeamVacation, error) {
+			if !sections.UpcomingVacations {
+				t.Error("upcoming vacations loaded while the section is disabled")
+			}
+			return []*domain.TeamVacation{{UserName: "Alex", StartDate: "2026-07-06", EndDate: "2026-07-10", TotalDays: 5}}, nil
+		},
+	}
+	userRepo := &testutil.MockUserRepository{
+		GetLowBalanceUsersFn: func(ctx context.Context, threshold int) ([]*domain.User, error) {
+			if !sections.LowBalance {
+				t.Error("low balances loaded while the section is disabled")
+			}
+			return []*domain.User{{Name: "Sam", VacationBalance: 2}}, nil
+		},
+	}
+	return NewNewsletterService(cfg, userRepo, vacationRepo, settingsRepo, NewEmailService(cfg), nil)
+}
+
+func TestBuildNewsletterData_Sections(t *testing.T) {
+	svc := newDigestTestService(t, domain.DigestSections{UpcomingVacations: true})
+
+	data, err := svc.BuildNewsletterData(context.Background(), "Robin")
+	require.NoError(t, err)
+	assert.False(t, data.HasStats)
+	assert.True(t, data.HasUpcoming)
+	assert.False(t, data.HasLowBalance)
+	assert.False(t, data.IsEmpty)
+
+	text, err := svc.emailService.RenderNewsletterText(data)
+	require.NoError(t, err)
+	assert.NotContains(t, text, "MONTHLY STATISTICS")
+	assert.Contains(t, text, "UPCOMING VACATIONS")
+	assert.NotContains(t, text, "LOW BALANCE")
+}
+
+func TestBuildNewsletterData_AllSectionsDisabled(t *testing.T) {
+	svc := newDigestTestService(t, domain.DigestSections{})
+
+	data, err := svc.BuildNewsletterData(context.Background(), "Robin")
+	require.NoError(t, err)
+	assert.True(t, data.IsEmpty)
+	assert.NotEmpty(t, data.Period)
+
+	for _, render := range []func(interface{}) (string, error){svc.emailService.RenderNewsletterText, svc.emailService.RenderNewsletterHTML} {
+		body, err := render(data)
+		require.NoError(t, err)
+		assert.Contains(t, body, "Robin")
+		assert.Contains(t, body, "nothing new to report")
+		assert.Contains(t, body, data.Period)
+	}
+}
+
+func TestGeneratePreview_ReflectsSections(t *testing.T) {
+	svc := newDigestTestService(t, domain.DigestSections{MonthlyStats: true, LowBalance: true})
+
+	preview, err := svc.GeneratePreview(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, preview.TextBody, "Pending: 4")
+	assert.Contains(t, preview.TextBody, "Sam: 2 days remaining")
+	assert.NotContains(t, preview.TextBody, "UPCOMING VACATIONS")
+}
+
+func TestSendPersonalDigests_OnlyOptedInActiveEmployees(t *testing.T) {
+	cfg := &config.Config{AppURL: "http://localhost:3000"}
+	now := time.Date(2026, 7, 6, 9, 0, 0, 0, time.UTC)
+
+	optedIn := domain.EmailPreferences{WeeklyDigest: true}
+	userRepo := &testutil.MockUserRepository{
+		GetNewsletterRecipientsFn: func(ctx context.Context) ([]*domain.User, error) {
+			return []*domain.User{
+				{ID: "emp-1", Email: "emp1@test.com", Name: "Robin", Role: domain.RoleEmployee, Active: true, VacationBalance: 12, EmailPreferences: optedIn},
+				{ID: "admin-1", Email: "admin@test.com", Name: "Ada", Role: domain.RoleAdmin, Active: true, EmailPreferences: optedIn},
+				{ID: "emp-2", Email: "emp2@test.com", Name: "Sam", Role: domain.RoleEmployee, Active: false, EmailPreferences: optedIn},
+				{ID: "emp-3", Email: "emp3@test.com", Name: "Kim", Role: domain.RoleEmployee, Active: true},
+			}, nil
+		},
+	}
+	var listedFor []string
+	vacationRepo := &testutil.MockVacationRepository{
+		ListByUserFn: func(ctx context.Context, userID string, status *domain.VacationStatus, year *int, from, to string) ([]*domain.VacationRequest, error) {
+			listedFor = append(listedFor, userID)
+			require.NotNil(t, status)
+			assert.Equal(t, domain.StatusApproved, *status)
+			assert.Equal(t, "2026-07-06", from)
+			return nil, nil
+		},
+	}
+	var recorded *time.Time
+	settingsRepo := &testutil.MockSettingsRepository{
+		UpdateLastPersonalDigestSentFn: func(ctx context.Context, sentAt time.Time) error {
+			recorded = &sentAt
+			return nil
+		},
+	}
+	authService := NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, "test-secret-key-that-is-at-least-32-chars")
+	svc := NewNewsletterService(cfg, userRepo, vacationRepo, settingsRepo, NewEmailService(cfg), authService)
+
+	count, err := svc.SendPersonalDigests(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{"emp-1"}, listedFor)
+	require.NotNil(t, recorded)
+	assert.True(t, recorded.Equal(now))
+}
+
+func TestSendLeaveReminders_ClaimsEachRequestOnce(t *testing.T) {
+	cfg := &config.Config{AppURL: "http://localhost:3000"}
+	// 23:30 UTC is already the 14th in Berlin, so tomorrow there is the 15th
+	now := time.Date(2027, 6, 13, 23, 30, 0, 0, time.UTC)
+
+	settingsRepo := &testutil.MockSettingsRepository{
+		GetFn: func(ctx context.Context) (*domain.Settings, error) {
+			settings := domain.DefaultSettings()
+			settings.Timezone = "Europe/Berlin"
+			return &settings, nil
+		},
+	}
+	var listedFor string
+	claimed := map[string]bool{}
+	vacationRepo := &testutil.MockVacationRepository{
+		ListApprovedStartingOnFn: func(ctx context.Context, date string) ([]*domain.VacationRequest, error) {
+			listedFor = date
+			return []*domain.VacationRequest{
+				{ID: "vac-1", UserID: "emp-1", StartDate: "2027-06-15", EndDate: "2027-06-18", TotalDays: 4, Type: domain.RequestTypeVacation},
+				{ID: "vac-2", UserID: "emp-2", StartDate: "2027-06-15", EndDate: "2027-06-15", TotalDays: 1, Type: domain.RequestTypeVacation},
+				{ID: "remote", UserID: "emp-1", StartDate: "2027-06-15", EndDate: "2027-06-15", TotalDays: 1, Type: domain.RequestTypeRemote},
+				{ID: "vac-3", UserID: "emp-3", StartDate: "2027-06-15", EndDate: "2027-06-16", TotalDays: 2, Type: domain.RequestTypeVacation},
+			}, nil
+		},
+		ClaimStartReminderFn: func(ctx context.Context, id string) (bool, error) {
+			if claimed[id] {
+				return false, nil
+			}
+			claimed[id] = true
+			return true, nil
+		},
+	}
+	userRepo := &testutil.MockUserRepository{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.User, error) {
+			users := map[string]*domain.User{
+				"emp-1": {ID: "emp-1", Email: "emp1@test.com", Name: "Robin", Role: domain.RoleEmployee, Active: true, EmailPreferences: domain.EmailPreferences{VacationUpdates: true}},
+				"emp-2": {ID: "emp-2", Email: "emp2@test.com", Name: "Sam", Role: domain.RoleEmployee, Active: true},
+				"emp-3": {ID: "emp-3", Email: "emp3@test.com", Name: "Kim", Role: domain.RoleEmployee, Active: false, EmailPreferences: domain.EmailPreferences{VacationUpdates: true}},
+			}
+			return users[id], nil
+		},
+	}
+	svc := NewNewsletterService(cfg, userRepo, vacationRepo, settingsRepo, NewEmailService(cfg), nil)
+
+	count, err := svc.SendLeaveReminders(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "2027-06-15", listedFor)
+	// Remote days, opted-out and deactivated employees are never claimed
+	assert.Equal(t, map[string]bool{"vac-1": true}, claimed)
+
+	// A second run, e.g. after a restart, finds the request already claimed
+	count, err = svc.SendLeaveReminders(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestBuildPersonalDigestData(t *testing.T) {
+	cfg := &config.Config{AppURL: "http://localhost:3000"}
+	vacationRepo := &testutil.MockVacationRepository{
+		ListByUserFn: func(ctx context.Context, userID string, status *domain.VacationStatus, year *int, from, to string) ([]*domain.VacationRequest, error) {
+			// Newest first, as the repository orders by creation
+			return []*domain.VacationRequest{
+				{ID: "v2", StartDate: "2026-08-10", EndDate: "2026-08-14", TotalDays: 5},
+				{ID: "v1", StartDate: "2026-07-20", EndDate: "2026-07-21", TotalDays: 2},
+			}, nil
+		},
+	}
+	svc := NewNewsletterService(cfg, &testutil.MockUserRepository{}, vacationRepo, &testutil.MockSettingsRepository{}, NewEmailService(cfg), nil)
+
+	user := &domain.User{ID: "emp-1", Name: "Robin", VacationBalance: 12}
+	data, err := svc.BuildPersonalDigestData(context.Background(), user, time.Date(2026, 7, 6, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 12, data.RemainingDays)
+	require.True(t, data.HasUpcoming)
+	assert.Equal(t, "v1", data.Upcoming[0].ID, "soonest first")
+
+	text, err := svc.emailService.RenderPersonalDigestText(data)
+	require.NoError(t, err)
+	assert.Contains(t, text, "Robin")
+	assert.Contains(t, text, "Remaining balance: 12 days")
+	assert.Contains(t, text, "- 2026-07-20 - 2026-07-21 (2 days)")
+
+	data.Upcoming, data.HasUpcoming = nil, false
+	html, err := svc.emailService.RenderPersonalDigestHTML(data)
+	require.NoError(t, err)
+	assert.Contains(t, html, "No approved vacations coming up")
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/repository"
+)
+
+// dashboardUpcomingDays is how far ahead the admin dashboard lists approved vacations
+const dashboardUpcomingDays = 14
+
+// ReportService builds read-only reports over vacation data
+type ReportService struct {
+	vacationRepo repository.VacationRepository
+	userRepo     repository.UserRepository
+	settingsRepo repository.SettingsRepository
+}
+
+// NewReportService creates a new ReportService
+func NewReportService(vacationRepo repository.VacationRepository, userRepo repository.UserRepository, settingsRepo repository.SettingsRepository) *ReportService {
+	return &ReportService{
+		vacationRepo: vacationRepo,
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+	}
+}
+
+// Dashboard aggregates the admin dashboard summary as of now
+// Employees at or below lowBalanceThreshold days are counted as low balance
+func (s *ReportService) Dashboard(ctx context.Context, now time.Time, lowBalanceThreshold int) (*dto.AdminDashboardResponse, error) {
+	employees, err := s.userRepo.CountByRole(ctx, domain.RoleEmployee)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to count users")
+	}
+	admins, err := s.userRepo.CountByRole(ctx, domain.RoleAdmin)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to count users")
+	}
+
+	pending, err := s.vacationRepo.ListPending(ctx, "", "")
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list pending requests")
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	stats, err := s.vacationRepo.GetMonthlyStats(ctx, LeaveYearOf(settings, now), int(now.Month()), "")
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get monthly stats")
+	}
+
+	from := now.Format("2006-01-02")
+	to := now.AddDate(0, 0, dashboardUpcomingDays).Format("2006-01-02")
+	upcoming, err := s.vacationRepo.ListUpcomingApproved(ctx, from, to)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list upcoming vacations")
+	}
+	if upcoming == nil {
+		upcoming = []*domain.TeamVacation{}
+	}
+
+	lowBalance, err := s.userRepo.GetLowBalanceUsers(ctx, lowBalanceThreshold)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list low balance users")
+	}
+
+	return &dto.AdminDashboardResponse{
+		TotalUsers:          employees + admins,
+		TotalEmployees:      employees,
+		PendingRequests:     len(pending),
+		DaysUsedThisMonth:   stats.TotalDaysUsed,
+		UpcomingFrom:        from,
+		UpcomingTo:          to,
+		UpcomingVacations:   upcoming,
+		LowBalanceCount:     len(lowBalance),
+		LowBalanceThreshold: lowBalanceThreshold,
+	}, nil
+}
+
+// YearlyStats returns vacation request statistics for requests created in the given leave year, with a per-month breakdown
+func (s *ReportService) YearlyStats(ctx context.Context, year int) (*dto.YearlyStatsResponse, error) {
+	stats, err := s.vacationRepo.GetYearlyStats(ctx, year)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get yearly stats")
+	}
+
+	resp := &dto.YearlyStatsResponse{
+		Year:          year,
+		StatsResponse: toStatsResponse(stats.MonthlyStats),
+		Months:        make([]dto.MonthStatsResponse, 12),
+	}
+	for i, month := range stats.Months {
+		resp.Months[i] = dto.MonthStatsResponse{Month: i + 1, StatsResponse: toStatsResponse(month)}
+	}
+
+	return resp, nil
+}
+
+// toStatsResponse converts repository stats to their response form
+func toStatsResponse(stats repository.MonthlyStats) dto.StatsResponse {
+	return dto.StatsResponse{
+		TotalSubmitted: stats.TotalSubmitted,
+		Approved:       stats.TotalApproved,
+		Rejected:       stats.TotalRejected,
+		Pending:        stats.TotalPending,
+		DaysUsed:       stats.TotalDaysUsed,
+	}
+}
+
+// ComplianceReport assembles a compliance report for requests submitted between from and to (YYYY-MM-DD, inclusive)
+func (s *ReportService) ComplianceReport(ctx context.Context, from, to string) (*dto.ComplianceReportResponse, error) {
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, dto.ErrValidationError("invalid from date, expected YYYY-MM-DD")
+	}
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return nil, dto.ErrValidationError("invalid to date, expected YYYY-MM-DD")
+	}
+	if toDate.Before(fromDate) {
+		return nil, dto.ErrValidationError("to date must be after or equal to from date")
+	}
+
+	requests, err := s.vacationRepo.ListCreatedBetween(ctx, from, to)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list vacation requests")
+	}
+
+	report := &dto.ComplianceReportResponse{
+		From:       from,
+		To:         to,
+		Rejections: []*dto.ComplianceRejection{},
+	}
+
+	var turnaroundTotal time.Duration
+	reviewedCount := 0
+
+	for _, req := range requests {
+		// Tentative requests were never submitted for review
+		if req.IsTentative() {
+			continue
+		}
+		report.TotalRequests++
+
+		switch req.Status {
+		case domain.StatusApproved:
+			report.Approved++
+		case domain.StatusRejected:
+			report.Rejected++
+			report.Rejections = append(report.Rejections, toComplianceRejection(req))
+		case domain.StatusPending, domain.StatusAwaitingFinal:
+			report.Pending++
+		}
+
+		// Turnaround only counts requests that went through a review
+		if req.ReviewedAt != nil && !req.CreatedAt.IsZero() && req.ReviewedAt.After(req.CreatedAt) {
+			turnaroundTotal += req.ReviewedAt.Sub(req.CreatedAt)
+			reviewedCount++
+		}
+	}
+
+	decided := report.Approved + report.Rejected
+	if decided > 0 {
+		report.ApprovalRate = float64(report.Approved) / float64(decided)
+	}
+	if reviewedCount > 0 {
+		report.AverageTurnaroundHours = turnaroundTotal.Hours() / float64(reviewedCount)
+	}
+
+	return report, nil
+}
+
+// toComplianceRejection converts a rejected request into a report entry
+func toComplianceRejection(req *domain.VacationRequest) *dto.ComplianceRejection {
+	rejection := &dto.ComplianceRejection{
+		RequestID:  req.ID,
+		UserID:     req.UserID,
+		UserName:   req.UserName,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		TotalDays:  req.TotalDays,
+		Reason:     req.RejectionReason,
+		ReviewedBy: req.ReviewedBy,
+	}
+	if req.ReviewedAt != nil {
+		formatted := req.ReviewedAt.Format("2006-01-02T15:04:05Z")
+		rejection.ReviewedAt = &formatted
+	}
+	return rejection
+}
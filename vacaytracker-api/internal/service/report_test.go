@@ -0,0 +1,232 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/repository"
+	"vacaytracker-api/internal/service"
+	"vacaytracker-api/internal/testutil"
+)
+
+// =========================================================================
+// Dashboard
+// =========================================================================
+
+func TestDashboard_Aggregates(t *testing.T) {
+	vr := &testutil.MockVacationRepository{}
+	ur := &testutil.MockUserRepository{}
+	svc := service.NewReportService(vr, ur, &testutil.MockSettingsRepository{})
+
+	ur.CountByRoleFn = func(_ context.Context, role domain.Role) (int, error) {
+		if role == domain.RoleAdmin {
+			return 2, nil
+		}
+		return 10, nil
+	}
+	var gotThreshold int
+	ur.GetLowBalanceUsersFn = func(_ context.Context, threshold int) ([]*domain.User, error) {
+		gotThreshold = threshold
+		return []*domain.User{testUser(), testUser()}, nil
+	}
+	vr.ListPendingFn = func(_ context.Context, _, _ string) ([]*domain.VacationRequest, error) {
+		return []*domain.VacationRequest{newPendingRequest("p1", "emp-1", 2)}, nil
+	}
+	var gotYear, gotMonth int
+	vr.GetMonthlyStatsFn = func(_ context.Context, year, month int, _ string) (*repository.MonthlyStats, error) {
+		gotYear, gotMonth = year, month
+		return &repository.MonthlyStats{TotalDaysUsed: 17}, nil
+	}
+	var gotFrom, gotTo string
+	vr.ListUpcomingApprovedFn = func(_ context.Context, from, to string) ([]*domain.TeamVacation, error) {
+		gotFrom, gotTo = from, to
+		return []*domain.TeamVacation{{ID: "v1", UserName: "Alice"}}, nil
+	}
+
+	now := time.Date(2027, 6, 20, 10, 0, 0, 0, time.UTC)
+	dashboard, err := svc.Dashboard(context.Background(), now, 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, dashboard.TotalUsers)
+	assert.Equal(t, 10, dashboard.TotalEmployees)
+	assert.Equal(t, 1, dashboard.PendingRequests)
+	assert.Equal(t, 17, dashboard.DaysUsedThisMonth)
+	assert.Equal(t, 2027, gotYear)
+	assert.Equal(t, 6, gotMonth)
+	assert.Equal(t, "2027-06-20", gotFrom)
+	assert.Equal(t, "2027-07-04", gotTo)
+	assert.Equal(t, "2027-06-20", dashboard.UpcomingFrom)
+	assert.Equal(t, "2027-07-04", dashboard.UpcomingTo)
+	require.Len(t, dashboard.UpcomingVacations, 1)
+	assert.Equal(t, "v1", dashboard.UpcomingVacations[0].ID)
+	assert.Equal(t, 2, dashboard.LowBalanceCount)
+	assert.Equal(t, 3, gotThreshold)
+	assert.Equal(t, 3, dashboard.LowBalanceThreshold)
+}
+
+func TestDashboard_EmptyUpcomingIsNotNil(t *testing.T) {
+	svc := service.NewReportService(&testutil.MockVacationRepository{}, &testutil.MockUserRepository{}, &testutil.MockSettingsRepository{})
+
+	dashboard, err := svc.Dashboard(context.Background(), time.Now(), service.LowBalanceThreshold)
+	require.NoError(t, err)
+	assert.NotNil(t, dashboard.UpcomingVacations)
+	assert.Empty(t, dashboard.UpcomingVacations)
+}
+
+func TestDashboard_MonthOfLeaveYear(t *testing.T) {
+	vr := &testutil.MockVacationRepository{}
+	var gotYear, gotMonth int
+	vr.GetMonthlyStatsFn = func(_ context.Context, year, month int, _ string) (*repository.MonthlyStats, error) {
+		gotYear, gotMonth = year, month
+		return &repository.MonthlyStats{}, nil
+	}
+	sr := &testutil.MockSettingsRepository{}
+	sr.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		settings := domain.DefaultSettings()
+		settings.VacationResetMonth = 4
+		return &settings, nil
+	}
+	svc := service.NewReportService(vr, &testutil.MockUserRepository{}, sr)
+
+	_, err := svc.Dashboard(context.Background(), time.Date(2028, 2, 10, 9, 0, 0, 0, time.UTC), service.LowBalanceThreshold)
+	require.NoError(t, err)
+	assert.Equal(t, 2027, gotYear, "February 2028 falls in the leave year starting April 2027")
+	assert.Equal(t, 2, gotMonth)
+}
+
+func TestDashboard_RepoError(t *testing.T) {
+	vr := &testutil.MockVacationRepository{}
+	vr.ListPendingFn = func(_ context.Context, _, _ string) ([]*domain.VacationRequest, error) {
+		return nil, errors.New("db down")
+	}
+	svc := service.NewReportService(vr, &testutil.MockUserRepository{}, &testutil.MockSettingsRepository{})
+
+	_, err := svc.Dashboard(context.Background(), time.Now(), service.LowBalanceThreshold)
+	assertAppError(t, err, dto.ErrInternal)
+}
+
+// =========================================================================
+// YearlyStats
+// =========================================================================
+
+func TestYearlyStats_MapsMonths(t *testing.T) {
+	vr := &testutil.MockVacationRepository{}
+	vr.GetYearlyStatsFn = func(_ context.Context, year int) (*repository.YearlyStats, error) {
+		assert.Equal(t, 2027, year)
+		stats := &repository.YearlyStats{}
+		stats.Months[2] = repository.MonthlyStats{TotalSubmitted: 3, TotalApproved: 2, TotalRejected: 1, TotalDaysUsed: 7}
+		stats.MonthlyStats = stats.Months[2]
+		return stats, nil
+	}
+	svc := service.NewReportService(vr, &testutil.MockUserRepository{}, &testutil.MockSettingsRepository{})
+
+	stats, err := svc.YearlyStats(context.Background(), 2027)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2027, stats.Year)
+	assert.Equal(t, 3, stats.TotalSubmitted)
+	assert.Equal(t, 7, stats.DaysUsed)
+	require.Len(t, stats.Months, 12)
+	assert.Equal(t, 1, stats.Months[0].Month)
+	assert.Equal(t, 0, stats.Months[0].TotalSubmitted)
+	assert.Equal(t, 3, stats.Months[2].Month)
+	assert.Equal(t, 2, stats.Months[2].Approved)
+	assert.Equal(t, 1, stats.Months[2].Rejected)
+}
+
+func TestYearlyStats_RepoError(t *testing.T) {
+	vr := &testutil.MockVacationRepository{}
+	vr.GetYearlyStatsFn = func(_ context.Context, _ int) (*repository.YearlyStats, error) {
+		return nil, errors.New("db down")
+	}
+	svc := service.NewReportService(vr, &testutil.MockUserRepository{}, &testutil.MockSettingsRepository{})
+
+	_, err := svc.YearlyStats(context.Background(), 2027)
+	assertAppError(t, err, dto.ErrInternal)
+}
+
+// =========================================================================
+// ComplianceReport
+// =========================================================================
+
+func TestComplianceReport_CountsAndApprovalRate(t *testing.T) {
+	vr := &testutil.MockVacationRepository{}
+	svc := service.NewReportService(vr, &testutil.MockUserRepository{}, &testutil.MockSettingsRepository{})
+
+	created := time.Date(2027, 3, 1, 9, 0, 0, 0, time.UTC)
+	withReview := func(r *domain.VacationRequest, hours int) *domain.VacationRequest {
+		r.CreatedAt = created
+		reviewedAt := created.Add(time.Duration(hours) * time.Hour)
+		r.ReviewedAt = &reviewedAt
+		return r
+	}
+
+	var gotFrom, gotTo string
+	vr.ListCreatedBetweenFn = func(_ context.Context, from, to string) ([]*domain.VacationRequest, error) {
+		gotFrom, gotTo = from, to
+		return []*domain.VacationRequest{
+			withReview(newApprovedRequest("a1", "emp-1", 2), 2),
+			withReview(newApprovedRequest("a2", "emp-1", 3), 4),
+			withReview(newApprovedRequest("a3", "emp-2", 1), 6),
+			withReview(newRejectedRequest("r1", "emp-2", 5), 8),
+			newPendingRequest("p1", "emp-3", 2),
+		}, nil
+	}
+
+	report, err := svc.ComplianceReport(context.Background(), "2027-03-01", "2027-03-31")
+	require.NoError(t, err)
+
+	assert.Equal(t, "2027-03-01", gotFrom)
+	assert.Equal(t, "2027-03-31", gotTo)
+	assert.Equal(t, 5, report.TotalRequests)
+	assert.Equal(t, 3, report.Approved)
+	assert.Equal(t, 1, report.Rejected)
+	assert.Equal(t, 1, report.Pending)
+	assert.InDelta(t, 0.75, report.ApprovalRate, 0.0001)
+	assert.InDelta(t, 5.0, report.AverageTurnaroundHours, 0.0001)
+
+	require.Len(t, report.Rejections, 1)
+	assert.Equal(t, "r1", report.Rejections[0].RequestID)
+	require.NotNil(t, report.Rejections[0].Reason)
+	assert.Equal(t, "not enough coverage", *report.Rejections[0].Reason)
+}
+
+func TestComplianceReport_EmptyPeriod(t *testing.T) {
+	vr := &testutil.MockVacationRepository{}
+	svc := service.NewReportService(vr, &testutil.MockUserRepository{}, &testutil.MockSettingsRepository{})
+
+	report, err := svc.ComplianceReport(context.Background(), "2027-03-01", "2027-03-31")
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.TotalRequests)
+	assert.Equal(t, 0.0, report.ApprovalRate)
+	assert.NotNil(t, report.Rejections)
+	assert.Empty(t, report.Rejections)
+}
+
+func TestComplianceReport_InvalidRange(t *testing.T) {
+	svc := service.NewReportService(&testutil.MockVacationRepository{}, &testutil.MockUserRepository{}, &testutil.MockSettingsRepository{})
+
+	_, err := svc.ComplianceReport(context.Background(), "2027-03-31", "2027-03-01")
+	assertVacationAppError(t, err, dto.ErrValidation)
+
+	_, err = svc.ComplianceReport(context.Background(), "03/01/2027", "2027-03-31")
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
+func TestComplianceReport_RepoError(t *testing.T) {
+	vr := &testutil.MockVacationRepository{}
+	vr.ListCreatedBetweenFn = func(_ context.Context, _, _ string) ([]*domain.VacationRequest, error) {
+		return nil, errors.New("db down")
+	}
+	svc := service.NewReportService(vr, &testutil.MockUserRepository{}, &testutil.MockSettingsRepository{})
+
+	_, err := svc.ComplianceReport(context.Background(), "2027-03-01", "2027-03-31")
+	assertVacationAppError(t, err, dto.ErrInternal)
+}
@@ -7,13 +7,32 @@ import (
 	"time"
 
 	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/metrics"
 	"vacaytracker-api/internal/repository"
 )
 
+// newsletterCheckInterval is how often the newsletter schedule is checked
+// Settings are re-read on every check, so schedule changes apply within a minute
+const newsletterCheckInterval = time.Minute
+
+// metricsRefreshInterval is how often domain gauges are recomputed when metrics are enabled
+const metricsRefreshInterval = time.Minute
+
+// emailRetryInterval is how often due emails in the delivery queue are retried
+const emailRetryInterval = time.Minute
+
+// leaveReminderHour is the local hour from which "your leave starts tomorrow" reminders go out
+const leaveReminderHour = 8
+
 // Scheduler handles background scheduled tasks
 type Scheduler struct {
 	newsletterService *NewsletterService
+	vacationService   *VacationService
+	vacationRepo      repository.VacationRepository
 	settingsRepo      repository.SettingsRepository
+	emailQueue        *EmailQueue      // nil when emails are sent without a queue
+	metrics           *metrics.Metrics // nil when metrics are disabled
+	lastNewsletterRun time.Time        // Guards against re-running a due send that found no recipients
 	ticker            *time.Ticker
 	done              chan bool
 	mu                sync.Mutex
@@ -21,19 +40,29 @@ type Scheduler struct {
 }
 
 // NewScheduler creates a new background scheduler
+// Pass a nil emailQueue to skip email retries and a nil metrics to skip refreshing the domain gauges
 func NewScheduler(
 	newsletterService *NewsletterService,
+	vacationService *VacationService,
+	vacationRepo repository.VacationRepository,
 	settingsRepo repository.SettingsRepository,
+	emailQueue *EmailQueue,
+	m *metrics.Metrics,
 ) *Scheduler {
 	return &Scheduler{
 		newsletterService: newsletterService,
+		vacationService:   vacationService,
+		vacationRepo:      vacationRepo,
 		settingsRepo:      settingsRepo,
+		emailQueue:        emailQueue,
+		metrics:           m,
 		done:              make(chan bool),
 	}
 }
 
 // Start begins the scheduler loop
-// Checks every hour if newsletter should be sent
+// Checks every minute if the newsletter or personal digest is due and every hour if balances should be accrued
+// or leave reminders sent, retries due emails every minute, and refreshes metrics gauges every minute when metrics are enabled
 func (s *Scheduler) Start() {
 	s.mu.Lock()
 	if s.running {
@@ -43,25 +72,61 @@ func (s *Scheduler) Start() {
 	s.running = true
 	s.mu.Unlock()
 
-	// Check every hour
+	// Check accrual every hour
 	s.ticker = time.NewTicker(1 * time.Hour)
+	newsletterTicker := time.NewTicker(newsletterCheckInterval)
+
+	// A nil channel never fires, so the email and metrics cases are inert when disabled
+	var emailTick <-chan time.Time
+	var emailTicker *time.Ticker
+	if s.emailQueue != nil {
+		emailTicker = time.NewTicker(emailRetryInterval)
+		emailTick = emailTicker.C
+	}
+
+	var metricsTick <-chan time.Time
+	var metricsTicker *time.Ticker
+	if s.metrics != nil {
+		metricsTicker = time.NewTicker(metricsRefreshInterval)
+		metricsTick = metricsTicker.C
+	}
 
 	go func() {
 		// Check immediately on startup
 		s.checkAndSendNewsletter()
+		s.checkAndSendPersonalDigest()
+		s.checkAndAccrue()
+		s.checkAndSendLeaveReminders()
+		s.retryEmails()
+		s.refreshMetrics()
 
 		for {
 			select {
 			case <-s.ticker.C:
+				s.checkAndAccrue()
+				s.checkAndSendLeaveReminders()
+			case <-newsletterTicker.C:
 				s.checkAndSendNewsletter()
+				s.checkAndSendPersonalDigest()
+			case <-emailTick:
+				s.retryEmails()
+			case <-metricsTick:
+				s.refreshMetrics()
 			case <-s.done:
 				s.ticker.Stop()
+				newsletterTicker.Stop()
+				if emailTicker != nil {
+					emailTicker.Stop()
+				}
+				if metricsTicker != nil {
+					metricsTicker.Stop()
+				}
 				return
 			}
 		}
 	}()
 
-	log.Println("[SCHEDULER] Newsletter scheduler started (checking every hour)")
+	log.Println("[SCHEDULER] Scheduler started")
 }
 
 // Stop gracefully stops the scheduler
@@ -72,13 +137,26 @@ func (s *Scheduler) Stop() {
 	if s.running {
 		s.done <- true
 		s.running = false
-		log.Println("[SCHEDULER] Newsletter scheduler stopped")
+		log.Println("[SCHEDULER] Scheduler stopped")
 	}
 }
 
+// Running reports whether the scheduler loop has been started and not stopped
+func (s *Scheduler) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
 // checkAndSendNewsletter determines if newsletter should be sent
 func (s *Scheduler) checkAndSendNewsletter() {
 	ctx := context.Background()
+	now := time.Now()
+
+	// A due send that reached no recipients doesn't record LastSentAt; don't repeat it all day
+	if isSameDay(s.lastNewsletterRun, now) {
+		return
+	}
 
 	settings, err := s.settingsRepo.Get(ctx)
 	if err != nil {
@@ -90,7 +168,7 @@ func (s *Scheduler) checkAndSendNewsletter() {
 		return
 	}
 
-	if !s.shouldSendNewsletter(settings) {
+	if !s.shouldSendNewsletterAt(settings, now) {
 		return
 	}
 
@@ -101,45 +179,127 @@ func (s *Scheduler) checkAndSendNewsletter() {
 		log.Printf("[SCHEDULER] Failed to send newsletter: %v", err)
 		return
 	}
+	s.lastNewsletterRun = now
 
 	log.Printf("[SCHEDULER] Newsletter sent to %d recipients", count)
 }
 
-// shouldSendNewsletter checks if it's time to send based on config
-func (s *Scheduler) shouldSendNewsletter(settings *domain.Settings) bool {
-	return s.shouldSendNewsletterAt(settings, time.Now())
+// checkAndSendPersonalDigest sends employees their personal weekly digest when it is due
+func (s *Scheduler) checkAndSendPersonalDigest() {
+	ctx := context.Background()
+	now := time.Now()
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		log.Printf("[SCHEDULER] Failed to get settings: %v", err)
+		return
+	}
+
+	if !settings.Newsletter.PersonalDigestDue(now) {
+		return
+	}
+
+	log.Println("[SCHEDULER] Triggering scheduled personal digest send")
+
+	if _, err := s.newsletterService.SendPersonalDigests(ctx, now); err != nil {
+		log.Printf("[SCHEDULER] Failed to send personal digests: %v", err)
+	}
+}
+
+// checkAndAccrue runs the monthly balance accrual; AccrueMonthly skips months already credited
+func (s *Scheduler) checkAndAccrue() {
+	count, err := s.vacationService.AccrueMonthly(context.Background(), time.Now())
+	if err != nil {
+		log.Printf("[SCHEDULER] Failed to accrue balances: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Printf("[SCHEDULER] Accrued vacation days for %d employees", count)
+	}
 }
 
-// shouldSendNewsletterAt checks if it's time to send based on config at a specific time
+// checkAndSendLeaveReminders sends tomorrow's leave reminders once the morning has begun in the configured time zone
+// Requests already reminded are skipped, so the hourly runs after the first only pick up late approvals
+func (s *Scheduler) checkAndSendLeaveReminders() {
+	ctx := context.Background()
+	now := time.Now()
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		log.Printf("[SCHEDULER] Failed to get settings: %v", err)
+		return
+	}
+
+	if !leaveRemindersDueAt(settings, now) {
+		return
+	}
+
+	if _, err := s.newsletterService.SendLeaveReminders(ctx, now); err != nil {
+		log.Printf("[SCHEDULER] Failed to send leave reminders: %v", err)
+	}
+}
+
+// leaveRemindersDueAt reports whether now is past leaveReminderHour in the settings' time zone
+func leaveRemindersDueAt(settings *domain.Settings, now time.Time) bool {
+	return now.In(settings.Location()).Hour() >= leaveReminderHour
+}
+
+// retryEmails delivers queued emails whose next attempt is due; no-op without a queue
+func (s *Scheduler) retryEmails() {
+	if s.emailQueue == nil {
+		return
+	}
+
+	count, err := s.emailQueue.RetryDue(context.Background())
+	if err != nil {
+		log.Printf("[SCHEDULER] Failed to retry queued emails: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Printf("[SCHEDULER] Retried %d queued email(s)", count)
+	}
+}
+
+// refreshMetrics recomputes the domain gauges; no-op when metrics are disabled
+func (s *Scheduler) refreshMetrics() {
+	s.refreshMetricsAt(context.Background(), time.Now())
+}
+
+// refreshMetricsAt recomputes the domain gauges as of now
 // This is separated for testability
-func (s *Scheduler) shouldSendNewsletterAt(settings *domain.Settings, now time.Time) bool {
-	config := settings.Newsletter
+func (s *Scheduler) refreshMetricsAt(ctx context.Context, now time.Time) {
+	if s.metrics == nil {
+		return
+	}
 
-	// Check if newsletter is enabled
-	if !config.Enabled {
-		return false
+	pending, err := s.vacationRepo.ListPending(ctx, "", "")
+	if err != nil {
+		log.Printf("[SCHEDULER] Failed to count pending requests for metrics: %v", err)
+	} else {
+		s.metrics.SetPendingRequests(len(pending))
 	}
 
-	// Check if already sent today
-	if config.LastSentAt != nil {
-		lastSent := *config.LastSentAt
-		if isSameDay(lastSent, now) {
-			return false
-		}
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		log.Printf("[SCHEDULER] Failed to get settings for metrics: %v", err)
+		return
 	}
 
-	switch config.Frequency {
-	case "monthly":
-		// Send on configured day of month
-		return now.Day() == config.DayOfMonth
-	case "weekly":
-		// Send on Monday (weekday 1)
-		return now.Weekday() == time.Monday
-	default:
-		return false
+	stats, err := s.vacationRepo.GetMonthlyStats(ctx, LeaveYearOf(settings, now), int(now.Month()), "")
+	if err != nil {
+		log.Printf("[SCHEDULER] Failed to get monthly stats for metrics: %v", err)
+	} else {
+		s.metrics.SetDaysUsedThisMonth(stats.TotalDaysUsed)
 	}
 }
 
+// shouldSendNewsletterAt checks if it's time to send based on config at a specific time
+// This is separated for testability
+func (s *Scheduler) shouldSendNewsletterAt(settings *domain.Settings, now time.Time) bool {
+	next := settings.Newsletter.NextSendAt(now)
+	return next != nil && !next.After(now)
+}
+
 // isSameDay checks if two times are on the same calendar day
 func isSameDay(t1, t2 time.Time) bool {
 	y1, m1, d1 := t1.Date()
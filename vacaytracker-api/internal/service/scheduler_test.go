@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/metrics"
+	"vacaytracker-api/internal/repository"
+	"vacaytracker-api/internal/testutil"
+)
+
+func TestSchedulerRefreshMetrics_SetsDomainGauges(t *testing.T) {
+	var statsYear, statsMonth int
+	vacationRepo := &testutil.MockVacationRepository{
+		ListPendingFn: func(ctx context.Context, from, to string) ([]*domain.VacationRequest, error) {
+			return []*domain.VacationRequest{{ID: "a"}, {ID: "b"}, {ID: "c"}}, nil
+		},
+		GetMonthlyStatsFn: func(ctx context.Context, year, month int, teamID string) (*repository.MonthlyStats, error) {
+			statsYear, statsMonth = year, month
+			return &repository.MonthlyStats{TotalDaysUsed: 17}, nil
+		},
+	}
+
+	m := metrics.New()
+	s := NewScheduler(nil, nil, vacationRepo, &testutil.MockSettingsRepository{}, nil, m)
+	s.refreshMetricsAt(context.Background(), time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 2026, statsYear)
+	assert.Equal(t, 3, statsMonth)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, rec.Body.String(), "vacaytracker_pending_requests 3")
+	assert.Contains(t, rec.Body.String(), "vacaytracker_days_used_this_month 17")
+}
+
+func TestSchedulerRefreshMetrics_DisabledIsNoop(t *testing.T) {
+	vacationRepo := &testutil.MockVacationRepository{
+		ListPendingFn: func(ctx context.Context, from, to string) ([]*domain.VacationRequest, error) {
+			t.Fatal("repository should not be queried when metrics are disabled")
+			return nil, nil
+		},
+	}
+
+	s := NewScheduler(nil, nil, vacationRepo, &testutil.MockSettingsRepository{}, nil, nil)
+	s.refreshMetricsAt(context.Background(), time.Now())
+}
+
+func TestLeaveRemindersDueAt(t *testing.T) {
+	settings := domain.DefaultSettings()
+	settings.Timezone = "Europe/Berlin"
+
+	// 06:30 UTC is 08:30 in Berlin during summer time
+	assert.True(t, leaveRemindersDueAt(&settings, time.Date(2027, 6, 13, 6, 30, 0, 0, time.UTC)))
+	assert.False(t, leaveRemindersDueAt(&settings, time.Date(2027, 6, 13, 5, 30, 0, 0, time.UTC)))
+
+	settings.Timezone = "UTC"
+	assert.False(t, leaveRemindersDueAt(&settings, time.Date(2027, 6, 13, 6, 30, 0, 0, time.UTC)))
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/repository"
+)
+
+// TeamService handles team management business logic
+type TeamService struct {
+	teamRepo repository.TeamRepository
+}
+
+// NewTeamService creates a new TeamService
+func NewTeamService(teamRepo repository.TeamRepository) *TeamService {
+	return &TeamService{
+		teamRepo: teamRepo,
+	}
+}
+
+// List retrieves all teams
+func (s *TeamService) List(ctx context.Context) ([]*domain.Team, error) {
+	teams, err := s.teamRepo.List(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list teams")
+	}
+	if teams == nil {
+		teams = []*domain.Team{}
+	}
+	return teams, nil
+}
+
+// Get retrieves a team by ID
+func (s *TeamService) Get(ctx context.Context, id string) (*domain.Team, error) {
+	team, err := s.teamRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get team")
+	}
+	if team == nil {
+		return nil, dto.ErrNotFoundError("team")
+	}
+	return team, nil
+}
+
+// Create creates a new team with a unique name
+func (s *TeamService) Create(ctx context.Context, req dto.TeamRequest) (*domain.Team, error) {
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkName(ctx, name, ""); err != nil {
+		return nil, err
+	}
+
+	team := &domain.Team{Name: name}
+	if err := s.teamRepo.Create(ctx, team); err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to create team")
+	}
+	return team, nil
+}
+
+// Update renames a team
+func (s *TeamService) Update(ctx context.Context, id string, req dto.TeamRequest) (*domain.Team, error) {
+	team, err := s.teamRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get team")
+	}
+	if team == nil {
+		return nil, dto.ErrNotFoundError("team")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkName(ctx, name, id); err != nil {
+		return nil, err
+	}
+
+	team.Name = name
+	if err := s.teamRepo.Update(ctx, team); err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to update team")
+	}
+	return team, nil
+}
+
+// Delete removes a team; its members become unassigned
+func (s *TeamService) Delete(ctx context.Context, id string) error {
+	if err := s.teamRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.ErrNotFoundError("team")
+		}
+		return dto.ErrInternalErrorWithMessage("failed to delete team")
+	}
+	return nil
+}
+
+// checkName rejects blank names and names already used by another team
+func (s *TeamService) checkName(ctx context.Context, name, excludeID string) error {
+	if name == "" {
+		return dto.ErrValidationError("team name is required")
+	}
+
+	exists, err := s.teamRepo.NameExistsExcluding(ctx, name, excludeID)
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("failed to check team name")
+	}
+	if exists {
+		return dto.ErrConflictError("team name already exists")
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/service"
+	"vacaytracker-api/internal/testutil"
+)
+
+// =========================================================================
+// TeamService
+// =========================================================================
+
+func TestTeamCreate_TrimsName(t *testing.T) {
+	repo := &testutil.MockTeamRepository{}
+	var created *domain.Team
+	repo.CreateFn = func(_ context.Context, team *domain.Team) error {
+		created = team
+		return nil
+	}
+	svc := service.NewTeamService(repo)
+
+	team, err := svc.Create(context.Background(), dto.TeamRequest{Name: "  Engineering "})
+
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, "Engineering", team.Name)
+}
+
+func TestTeamCreate_DuplicateName(t *testing.T) {
+	repo := &testutil.MockTeamRepository{
+		NameExistsExcludingFn: func(_ context.Context, _, _ string) (bool, error) {
+			return true, nil
+		},
+	}
+	svc := service.NewTeamService(repo)
+
+	_, err := svc.Create(context.Background(), dto.TeamRequest{Name: "Engineering"})
+
+	assertVacationAppError(t, err, dto.ErrAlreadyExists)
+}
+
+func TestTeamUpdate_NotFound(t *testing.T) {
+	svc := service.NewTeamService(&testutil.MockTeamRepository{})
+
+	_, err := svc.Update(context.Background(), "missing", dto.TeamRequest{Name: "Design"})
+
+	assertVacationAppError(t, err, dto.ErrNotFound)
+}
+
+func TestTeamDelete_NotFound(t *testing.T) {
+	repo := &testutil.MockTeamRepository{
+		DeleteFn: func(_ context.Context, _ string) error {
+			return sql.ErrNoRows
+		},
+	}
+	svc := service.NewTeamService(repo)
+
+	err := svc.Delete(context.Background(), "missing")
+
+	assertVacationAppError(t, err, dto.ErrNotFound)
+}
+
+// =========================================================================
+// TeamScope
+// =========================================================================
+
+func TestTeamScope_DefaultsToOwnTeam(t *testing.T) {
+	d := newServiceBundle()
+	teamID := "team-1"
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, TeamID: &teamID}, nil
+	}
+
+	scope, err := d.svc.TeamScope(context.Background(), "emp-1", false, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "team-1", scope)
+}
+
+func TestTeamScope_UnassignedSeesCompany(t *testing.T) {
+	d := newServiceBundle()
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id}, nil
+	}
+
+	scope, err := d.svc.TeamScope(context.Background(), "emp-1", false, "")
+
+	require.NoError(t, err)
+	assert.Empty(t, scope)
+}
+
+func TestTeamScope_AdminOverride(t *testing.T) {
+	d := newServiceBundle()
+
+	scope, err := d.svc.TeamScope(context.Background(), "admin-1", true, "team-2")
+
+	require.NoError(t, err)
+	assert.Equal(t, "team-2", scope)
+}
+
+func TestTeamScope_EmployeeOverrideForbidden(t *testing.T) {
+	d := newServiceBundle()
+
+	_, err := d.svc.TeamScope(context.Background(), "emp-1", false, "team-2")
+
+	assertVacationAppError(t, err, dto.ErrForbidden)
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenDenylist remembers revoked access token IDs until the tokens would have expired anyway
+// It lives in memory, so revocations are per process and forgotten on restart
+type tokenDenylist struct {
+	mu      sync.Mutex
+	entries map[string]time.Time // Token ID -> token expiry
+}
+
+func newTokenDenylist() *tokenDenylist {
+	return &tokenDenylist{entries: make(map[string]time.Time)}
+}
+
+// Add denies the token with id until expiresAt
+// Expired entries are pruned on the way, so the list never outgrows the live tokens
+func (d *tokenDenylist) Add(id string, expiresAt, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for deniedID, expiry := range d.entries {
+		if !expiry.After(now) {
+			delete(d.entries, deniedID)
+		}
+	}
+	if expiresAt.After(now) {
+		d.entries[id] = expiresAt
+	}
+}
+
+// Contains reports whether the token with id was revoked and hasn't expired yet
+func (d *tokenDenylist) Contains(id string, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	expiry, ok := d.entries[id]
+	return ok && expiry.After(now)
+}
@@ -2,6 +2,10 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -12,20 +16,32 @@ import (
 
 // UserService handles user management business logic
 type UserService struct {
-	userRepo    repository.UserRepository
-	authService *AuthService
+	userRepo     repository.UserRepository
+	ledgerRepo   repository.LedgerRepository
+	transactor   repository.Transactor
+	teamRepo     repository.TeamRepository
+	settingsRepo repository.SettingsRepository
+	authService  *AuthService
 }
 
 // NewUserService creates a new UserService
-func NewUserService(userRepo repository.UserRepository, authService *AuthService) *UserService {
+func NewUserService(userRepo repository.UserRepository, ledgerRepo repository.LedgerRepository, transactor repository.Transactor, teamRepo repository.TeamRepository, settingsRepo repository.SettingsRepository, authService *AuthService) *UserService {
 	return &UserService{
-		userRepo:    userRepo,
-		authService: authService,
+		userRepo:     userRepo,
+		ledgerRepo:   ledgerRepo,
+		transactor:   transactor,
+		teamRepo:     teamRepo,
+		settingsRepo: settingsRepo,
+		authService:  authService,
 	}
 }
 
 // Create creates a new user
 func (s *UserService) Create(ctx context.Context, req dto.CreateUserRequest) (*domain.User, error) {
+	if err := s.authService.ValidatePassword(req.Password); err != nil {
+		return nil, dto.ErrValidationError(err.Error())
+	}
+
 	// Check if email exists
 	exists, err := s.userRepo.EmailExists(ctx, req.Email)
 	if err != nil {
@@ -41,10 +57,24 @@ func (s *UserService) Create(ctx context.Context, req dto.CreateUserRequest) (*d
 		return nil, dto.ErrInternalErrorWithMessage("failed to hash password")
 	}
 
-	// Set defaults
+	// Set defaults; mid-year hires get a pro-rated share of the annual entitlement
+	// An explicit balance is taken as-is, in the configured balance unit
 	balance := 25
 	if req.VacationBalance != nil {
 		balance = *req.VacationBalance
+	} else {
+		settings, err := s.settingsRepo.Get(ctx)
+		if err != nil {
+			return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+		}
+		if req.StartDate != "" {
+			prorated, err := s.ProratedBalance(ctx, req.StartDate)
+			if err != nil {
+				return nil, err
+			}
+			balance = prorated.ProratedDays
+		}
+		balance = settings.DaysToBalance(balance)
 	}
 
 	var startDate *string
@@ -52,15 +82,42 @@ func (s *UserService) Create(ctx context.Context, req dto.CreateUserRequest) (*d
 		startDate = &req.StartDate
 	}
 
+	role, err := s.resolveNewUserRole(ctx, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New().String()
+
+	var managerID *string
+	if req.ManagerID != "" {
+		if err := s.validateManager(ctx, id, req.ManagerID); err != nil {
+			return nil, err
+		}
+		managerID = &req.ManagerID
+	}
+
+	var teamID *string
+	if req.TeamID != "" {
+		if err := s.validateTeam(ctx, req.TeamID); err != nil {
+			return nil, err
+		}
+		teamID = &req.TeamID
+	}
+
 	user := &domain.User{
-		ID:               uuid.New().String(),
-		Email:            req.Email,
-		PasswordHash:     hash,
-		Name:             req.Name,
-		Role:             domain.Role(req.Role),
-		VacationBalance:  balance,
-		StartDate:        startDate,
-		EmailPreferences: domain.DefaultEmailPreferences(),
+		ID:                 id,
+		Email:              req.Email,
+		PasswordHash:       hash,
+		Name:               req.Name,
+		Role:               role,
+		VacationBalance:    balance,
+		StartDate:          startDate,
+		EmailPreferences:   domain.DefaultEmailPreferences(),
+		MustChangePassword: true, // Admin-chosen password is temporary
+		ManagerID:          managerID,
+		TeamID:             teamID,
+		Locale:             req.Locale,
 	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
@@ -70,6 +127,89 @@ func (s *UserService) Create(ctx context.Context, req dto.CreateUserRequest) (*d
 	return user, nil
 }
 
+// resolveNewUserRole returns the requested role, or the configured default when none was given
+func (s *UserService) resolveNewUserRole(ctx context.Context, requested string) (domain.Role, error) {
+	if requested != "" {
+		return domain.Role(requested), nil
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return "", dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+	if settings.DefaultNewUserRole == "" || settings.DefaultNewUserRole == domain.RoleAdmin {
+		return domain.RoleEmployee, nil
+	}
+	return settings.DefaultNewUserRole, nil
+}
+
+// ProratedBalance computes the entitlement for someone starting on startDate (YYYY-MM-DD)
+// covering the remainder of the leave year that starts in the configured reset month
+func (s *UserService) ProratedBalance(ctx context.Context, startDate string) (*dto.ProratedBalanceResponse, error) {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return nil, dto.ErrValidationError("start date must be in YYYY-MM-DD format")
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	return &dto.ProratedBalanceResponse{
+		StartDate:      startDate,
+		LeaveYearStart: settings.LeaveYearStart(start).Format("2006-01-02"),
+		AnnualDays:     settings.DefaultVacationDays,
+		ProratedDays:   settings.ProratedEntitlement(start, time.Now()),
+	}, nil
+}
+
+// maxManagerChainDepth bounds the walk up the manager chain when checking for cycles
+const maxManagerChainDepth = 50
+
+// validateManager checks that managerID can be assigned as userID's manager
+// The manager must exist and the assignment must not make anyone their own (indirect) manager
+func (s *UserService) validateManager(ctx context.Context, userID, managerID string) error {
+	if managerID == userID {
+		return dto.ErrValidationError("a user cannot be their own manager")
+	}
+
+	current := managerID
+	for depth := 0; depth < maxManagerChainDepth; depth++ {
+		manager, err := s.userRepo.GetByID(ctx, current)
+		if err != nil {
+			return dto.ErrInternalErrorWithMessage("failed to get manager")
+		}
+		if manager == nil {
+			if current == managerID {
+				return dto.ErrValidationError("manager not found")
+			}
+			return nil
+		}
+		if manager.ManagerID == nil {
+			return nil
+		}
+		if *manager.ManagerID == userID {
+			return dto.ErrValidationError("manager assignment would create a reporting cycle")
+		}
+		current = *manager.ManagerID
+	}
+
+	return nil
+}
+
+// validateTeam checks that teamID refers to an existing team
+func (s *UserService) validateTeam(ctx context.Context, teamID string) error {
+	team, err := s.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("failed to get team")
+	}
+	if team == nil {
+		return dto.ErrValidationError("team not found")
+	}
+	return nil
+}
+
 // Update updates a user's information
 func (s *UserService) Update(ctx context.Context, id string, req dto.UpdateUserRequest, currentUserID string) (*domain.User, error) {
 	user, err := s.userRepo.GetByID(ctx, id)
@@ -117,21 +257,53 @@ func (s *UserService) Update(ctx context.Context, id string, req dto.UpdateUserR
 	if req.Name != "" {
 		user.Name = req.Name
 	}
+	previousBalance := user.VacationBalance
 	if req.VacationBalance != nil {
 		user.VacationBalance = *req.VacationBalance
 	}
 	if req.StartDate != "" {
 		user.StartDate = &req.StartDate
 	}
+	if req.ManagerID != nil {
+		if *req.ManagerID == "" {
+			user.ManagerID = nil
+		} else {
+			if err := s.validateManager(ctx, id, *req.ManagerID); err != nil {
+				return nil, err
+			}
+			managerID := *req.ManagerID
+			user.ManagerID = &managerID
+		}
+	}
+	if req.TeamID != nil {
+		if *req.TeamID == "" {
+			user.TeamID = nil
+		} else {
+			if err := s.validateTeam(ctx, *req.TeamID); err != nil {
+				return nil, err
+			}
+			teamID := *req.TeamID
+			user.TeamID = &teamID
+		}
+	}
+	if req.Locale != "" {
+		user.Locale = req.Locale
+	}
 
-	if err := s.userRepo.Update(ctx, user); err != nil {
+	err = s.transactor.Transaction(func(tx *sql.Tx) error {
+		if err := s.userRepo.UpdateTx(ctx, tx, user); err != nil {
+			return err
+		}
+		return s.recordAdjustmentTx(ctx, tx, user.ID, user.VacationBalance-previousBalance, domain.LedgerAdjustment)
+	})
+	if err != nil {
 		return nil, dto.ErrInternalErrorWithMessage("failed to update user")
 	}
 
 	return user, nil
 }
 
-// Delete deletes a user
+// Delete soft-deletes a user, keeping their vacation history
 func (s *UserService) Delete(ctx context.Context, id, currentUserID string) error {
 	// Cannot delete self
 	if id == currentUserID {
@@ -142,7 +314,7 @@ func (s *UserService) Delete(ctx context.Context, id, currentUserID string) erro
 	if err != nil {
 		return dto.ErrInternalErrorWithMessage("failed to get user")
 	}
-	if user == nil {
+	if user == nil || user.IsDeleted() {
 		return dto.ErrNotFoundError("user")
 	}
 
@@ -164,6 +336,66 @@ func (s *UserService) Delete(ctx context.Context, id, currentUserID string) erro
 	return nil
 }
 
+// SetActive activates or deactivates a user; deactivated users cannot log in
+func (s *UserService) SetActive(ctx context.Context, id string, active bool, currentUserID string) (*domain.User, error) {
+	if !active && id == currentUserID {
+		return nil, dto.ErrForbiddenError("cannot deactivate your own account")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get user")
+	}
+	if user == nil || user.IsDeleted() {
+		return nil, dto.ErrNotFoundError("user")
+	}
+
+	// Cannot deactivate the last active admin
+	if !active && user.Active && user.Role == domain.RoleAdmin {
+		admins, err := s.userRepo.GetByRole(ctx, domain.RoleAdmin)
+		if err != nil {
+			return nil, dto.ErrInternalErrorWithMessage("failed to list admins")
+		}
+		activeAdmins := 0
+		for _, admin := range admins {
+			if admin.Active {
+				activeAdmins++
+			}
+		}
+		if activeAdmins <= 1 {
+			return nil, dto.ErrForbiddenError("cannot deactivate the last active admin")
+		}
+	}
+
+	if err := s.userRepo.SetActive(ctx, id, active); err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to update user status")
+	}
+
+	user.Active = active
+	return user, nil
+}
+
+// Restore undoes a soft delete
+func (s *UserService) Restore(ctx context.Context, id string) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get user")
+	}
+	if user == nil {
+		return nil, dto.ErrNotFoundError("user")
+	}
+	if !user.IsDeleted() {
+		return nil, dto.ErrValidationError("user is not deleted")
+	}
+
+	if err := s.userRepo.Restore(ctx, id); err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to restore user")
+	}
+
+	user.DeletedAt = nil
+	return user, nil
+}
+
 // GetByID retrieves a user by ID
 func (s *UserService) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	user, err := s.userRepo.GetByID(ctx, id)
@@ -176,8 +408,8 @@ func (s *UserService) GetByID(ctx context.Context, id string) (*domain.User, err
 	return user, nil
 }
 
-// List lists all users with optional filtering and pagination
-func (s *UserService) List(ctx context.Context, role *domain.Role, search string, page, limit int) ([]*domain.User, int, error) {
+// List lists all users with optional filtering, sorting and pagination
+func (s *UserService) List(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, page, limit int) ([]*domain.User, int, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -187,7 +419,7 @@ func (s *UserService) List(ctx context.Context, role *domain.Role, search string
 
 	offset := (page - 1) * limit
 
-	users, total, err := s.userRepo.GetAll(ctx, role, search, limit, offset)
+	users, total, err := s.userRepo.GetAll(ctx, filter, sort, limit, offset)
 	if err != nil {
 		return nil, 0, dto.ErrInternalErrorWithMessage("failed to list users")
 	}
@@ -195,6 +427,28 @@ func (s *UserService) List(ctx context.Context, role *domain.Role, search string
 	return users, total, nil
 }
 
+// ListAfter returns up to limit users after the afterID cursor, ordered by ID
+// nextCursor is the ID to pass as afterID for the following page, empty on the last page
+func (s *UserService) ListAfter(ctx context.Context, filter domain.UserFilter, afterID string, limit int) ([]*domain.User, string, error) {
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	// Fetch one extra user to learn whether another page follows
+	users, err := s.userRepo.GetAllAfter(ctx, filter, afterID, limit+1)
+	if err != nil {
+		return nil, "", dto.ErrInternalErrorWithMessage("failed to list users")
+	}
+
+	var nextCursor string
+	if len(users) > limit {
+		users = users[:limit]
+		nextCursor = users[limit-1].ID
+	}
+
+	return users, nextCursor, nil
+}
+
 // UpdateBalance updates a user's vacation balance
 func (s *UserService) UpdateBalance(ctx context.Context, id string, balance int) (*domain.User, error) {
 	user, err := s.userRepo.GetByID(ctx, id)
@@ -209,7 +463,13 @@ func (s *UserService) UpdateBalance(ctx context.Context, id string, balance int)
 		return nil, dto.ErrValidationError("vacation balance cannot be negative")
 	}
 
-	if err := s.userRepo.UpdateVacationBalance(ctx, id, balance); err != nil {
+	err = s.transactor.Transaction(func(tx *sql.Tx) error {
+		if err := s.userRepo.UpdateVacationBalanceTx(ctx, tx, id, balance); err != nil {
+			return err
+		}
+		return s.recordAdjustmentTx(ctx, tx, id, balance-user.VacationBalance, domain.LedgerAdjustment)
+	})
+	if err != nil {
 		return nil, dto.ErrInternalErrorWithMessage("failed to update vacation balance")
 	}
 
@@ -223,10 +483,241 @@ func (s *UserService) ResetAllBalances(ctx context.Context, defaultDays int) (in
 		return 0, dto.ErrValidationError("default vacation days cannot be negative")
 	}
 
-	count, err := s.userRepo.UpdateAllBalances(ctx, defaultDays)
+	// Capture current balances so the reset can be recorded per user
+	employees, err := s.userRepo.GetByRole(ctx, domain.RoleEmployee)
+	if err != nil {
+		return 0, dto.ErrInternalErrorWithMessage("failed to get employees")
+	}
+
+	var count int64
+	err = s.transactor.Transaction(func(tx *sql.Tx) error {
+		var err error
+		count, err = s.userRepo.UpdateAllBalancesTx(ctx, tx, defaultDays)
+		if err != nil {
+			return err
+		}
+		for _, change := range planReset(employees, defaultDays) {
+			if err := s.recordAdjustmentTx(ctx, tx, change.UserID, change.NewBalance-change.PreviousBalance, domain.LedgerReset); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return 0, dto.ErrInternalErrorWithMessage("failed to reset vacation balances")
 	}
 
 	return int(count), nil
 }
+
+// PreviewResetBalances reports each employee's balance before and after ResetAllBalances without changing anything
+func (s *UserService) PreviewResetBalances(ctx context.Context, defaultDays int) ([]*dto.BalanceAdjustment, error) {
+	if defaultDays < 0 {
+		return nil, dto.ErrValidationError("default vacation days cannot be negative")
+	}
+
+	employees, err := s.userRepo.GetByRole(ctx, domain.RoleEmployee)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get employees")
+	}
+
+	return planReset(employees, defaultDays), nil
+}
+
+// planReset computes the balance change a reset to defaultDays makes for each employee
+func planReset(employees []*domain.User, defaultDays int) []*dto.BalanceAdjustment {
+	changes := make([]*dto.BalanceAdjustment, 0, len(employees))
+	for _, employee := range employees {
+		changes = append(changes, &dto.BalanceAdjustment{
+			UserID:          employee.ID,
+			Name:            employee.Name,
+			PreviousBalance: employee.VacationBalance,
+			NewBalance:      defaultDays,
+		})
+	}
+	return changes
+}
+
+// AdjustBalances adds delta days to each listed user's balance in a single transaction,
+// recording a ledger adjustment carrying reason for each of them
+// Nothing changes if any user is unknown or would end up below the minimum balance the settings allow
+func (s *UserService) AdjustBalances(ctx context.Context, userIDs []string, delta int, reason string) ([]*dto.BalanceAdjustment, error) {
+	if delta == 0 {
+		return nil, dto.ErrValidationError("delta must not be zero")
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+	minBalance := settings.MinimumBalance()
+
+	seen := make(map[string]bool, len(userIDs))
+	adjustments := make([]*dto.BalanceAdjustment, 0, len(userIDs))
+	for _, id := range userIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		user, err := s.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, dto.ErrInternalErrorWithMessage("failed to get user")
+		}
+		if user == nil || user.IsDeleted() {
+			return nil, dto.ErrNotFoundError(fmt.Sprintf("user %s", id))
+		}
+
+		newBalance := user.VacationBalance + delta
+		if newBalance < minBalance {
+			return nil, dto.ErrValidationError(fmt.Sprintf("adjustment would leave %s with a balance of %d days (minimum %d)", user.Name, newBalance, minBalance))
+		}
+
+		adjustments = append(adjustments, &dto.BalanceAdjustment{
+			UserID:          user.ID,
+			Name:            user.Name,
+			PreviousBalance: user.VacationBalance,
+			NewBalance:      newBalance,
+		})
+	}
+
+	// Balances are changed relative to their current value, so a request approved since they were read
+	// isn't overwritten; the floor is checked again by the update itself
+	var shortUser *dto.BalanceAdjustment
+	err = s.transactor.Transaction(func(tx *sql.Tx) error {
+		for _, adjustment := range adjustments {
+			balance, err := s.userRepo.AddVacationBalanceTx(ctx, tx, adjustment.UserID, delta, minBalance)
+			if errors.Is(err, repository.ErrBalanceBelowMinimum) {
+				adjustment.NewBalance = balance + delta
+				shortUser = adjustment
+				return err
+			}
+			if err != nil {
+				return err
+			}
+			adjustment.PreviousBalance = balance - delta
+			adjustment.NewBalance = balance
+
+			entry := newLedgerEntry(adjustment.UserID, delta, domain.LedgerAdjustment, nil)
+			entry.Note = &reason
+			if err := s.ledgerRepo.CreateTx(ctx, tx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if shortUser != nil {
+		return nil, dto.ErrValidationError(fmt.Sprintf("adjustment would leave %s with a balance of %d days (minimum %d)", shortUser.Name, shortUser.NewBalance, minBalance))
+	}
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to adjust vacation balances")
+	}
+
+	return adjustments, nil
+}
+
+// ReconcileBalances compares every user's stored balance with the balance derived from the ledger
+// Only users whose balances disagree are returned, along with the number of users checked
+func (s *UserService) ReconcileBalances(ctx context.Context) ([]*dto.BalanceDiscrepancy, int, error) {
+	var users []*domain.User
+	for _, role := range []domain.Role{domain.RoleAdmin, domain.RoleEmployee} {
+		byRole, err := s.userRepo.GetByRole(ctx, role)
+		if err != nil {
+			return nil, 0, dto.ErrInternalErrorWithMessage("failed to list users")
+		}
+		users = append(users, byRole...)
+	}
+
+	sums, err := s.ledgerRepo.SumByUser(ctx)
+	if err != nil {
+		return nil, 0, dto.ErrInternalErrorWithMessage("failed to read balance ledger")
+	}
+
+	discrepancies := make([]*dto.BalanceDiscrepancy, 0)
+	for _, user := range users {
+		ledgerBalance := sums[user.ID]
+		if ledgerBalance == user.VacationBalance {
+			continue
+		}
+		discrepancies = append(discrepancies, &dto.BalanceDiscrepancy{
+			UserID:        user.ID,
+			Name:          user.Name,
+			Email:         user.Email,
+			StoredBalance: user.VacationBalance,
+			LedgerBalance: ledgerBalance,
+			Delta:         user.VacationBalance - ledgerBalance,
+		})
+	}
+
+	return discrepancies, len(users), nil
+}
+
+// ListBalances returns every active employee's balance and the days they used in year
+func (s *UserService) ListBalances(ctx context.Context, year int, sort domain.UserSort) (*dto.EmployeeBalancesResponse, error) {
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	balances, err := s.userRepo.ListBalances(ctx, year, sort)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list balances")
+	}
+
+	employees := make([]*dto.EmployeeBalanceItem, 0, len(balances))
+	for _, b := range balances {
+		employees = append(employees, &dto.EmployeeBalanceItem{
+			UserID:   b.UserID,
+			Name:     b.Name,
+			Email:    b.Email,
+			Balance:  b.VacationBalance,
+			DaysUsed: b.DaysUsed,
+		})
+	}
+
+	return &dto.EmployeeBalancesResponse{
+		Year:        year,
+		BalanceUnit: string(settings.BalanceUnit),
+		Employees:   employees,
+		Total:       len(employees),
+	}, nil
+}
+
+// NotificationRecipients reports which admins are emailed when a vacation request is submitted
+// Mirrors the delivery rules: inactive admins are skipped, as are admins with team notifications turned off
+func (s *UserService) NotificationRecipients(ctx context.Context) (*dto.NotificationRecipientsResponse, error) {
+	admins, err := s.userRepo.GetByRole(ctx, domain.RoleAdmin)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list admins")
+	}
+
+	resp := &dto.NotificationRecipientsResponse{Admins: make([]*dto.NotificationRecipient, 0, len(admins))}
+	for _, admin := range admins {
+		recipient := &dto.NotificationRecipient{
+			UserID: admin.ID,
+			Name:   admin.Name,
+			Email:  admin.Email,
+		}
+		switch {
+		case !admin.Active:
+			recipient.SkipReason = "inactive"
+		case !admin.EmailPreferences.TeamNotifications:
+			recipient.SkipReason = "team notifications turned off"
+		default:
+			recipient.Receives = true
+			resp.Recipients++
+		}
+		resp.Admins = append(resp.Admins, recipient)
+	}
+
+	return resp, nil
+}
+
+// recordAdjustmentTx writes a ledger entry for a balance change within the transaction that made it
+// A zero delta records nothing
+func (s *UserService) recordAdjustmentTx(ctx context.Context, tx *sql.Tx, userID string, delta int, reason domain.LedgerReason) error {
+	if delta == 0 {
+		return nil
+	}
+	return s.ledgerRepo.CreateTx(ctx, tx, newLedgerEntry(userID, delta, reason, nil))
+}
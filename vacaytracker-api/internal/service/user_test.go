@@ -2,14 +2,18 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vacaytracker-api/internal/domain"
 	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/repository"
 	"vacaytracker-api/internal/service"
 	"vacaytracker-api/internal/testutil"
 )
@@ -21,32 +25,36 @@ import (
 func intPtr(v int) *int { return &v }
 
 func newUserService(repo *testutil.MockUserRepository) *service.UserService {
-	authSvc := service.NewAuthService(&testutil.MockUserRepository{}, "test-secret-key-for-jwt-signing")
-	return service.NewUserService(repo, authSvc)
+	return newUserServiceWithLedger(repo, &testutil.MockLedgerRepository{})
+}
+
+func newUserServiceWithLedger(repo *testutil.MockUserRepository, ledger *testutil.MockLedgerRepository) *service.UserService {
+	authSvc := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, "test-secret-key-for-jwt-signing")
+	return service.NewUserService(repo, ledger, &testutil.MockTransactor{}, &testutil.MockTeamRepository{}, &testutil.MockSettingsRepository{}, authSvc)
 }
 
 func existingUser() *domain.User {
 	startDate := "2024-01-15"
 	return &domain.User{
-		ID:              "user-1",
-		Email:           "alice@example.com",
-		PasswordHash:    "$2a$10$fakehash",
-		Name:            "Alice",
-		Role:            domain.RoleEmployee,
-		VacationBalance: 25,
-		StartDate:       &startDate,
+		ID:               "user-1",
+		Email:            "alice@example.com",
+		PasswordHash:     "$2a$10$fakehash",
+		Name:             "Alice",
+		Role:             domain.RoleEmployee,
+		VacationBalance:  25,
+		StartDate:        &startDate,
 		EmailPreferences: domain.DefaultEmailPreferences(),
 	}
 }
 
 func existingAdmin() *domain.User {
 	return &domain.User{
-		ID:              "admin-1",
-		Email:           "admin@example.com",
-		PasswordHash:    "$2a$10$fakehash",
-		Name:            "Admin",
-		Role:            domain.RoleAdmin,
-		VacationBalance: 25,
+		ID:               "admin-1",
+		Email:            "admin@example.com",
+		PasswordHash:     "$2a$10$fakehash",
+		Name:             "Admin",
+		Role:             domain.RoleAdmin,
+		VacationBalance:  25,
 		EmailPreferences: domain.DefaultEmailPreferences(),
 	}
 }
@@ -85,10 +93,100 @@ func TestCreate_Success_DefaultBalance(t *testing.T) {
 	assert.NotEmpty(t, user.ID)
 	assert.NotEmpty(t, user.PasswordHash)
 	assert.Nil(t, user.StartDate)
+	assert.True(t, user.MustChangePassword, "admin-created users start with a temporary password")
 	// Ensure the same object was passed to repo.Create
 	assert.Equal(t, createdUser, user)
 }
 
+func TestCreate_DefaultRoleFromSettings(t *testing.T) {
+	repo := &testutil.MockUserRepository{
+		EmailExistsFn: func(_ context.Context, email string) (bool, error) {
+			return false, nil
+		},
+		CreateFn: func(_ context.Context, user *domain.User) error {
+			return nil
+		},
+	}
+	settingsRepo := &testutil.MockSettingsRepository{
+		GetFn: func(_ context.Context) (*domain.Settings, error) {
+			settings := domain.DefaultSettings()
+			settings.DefaultNewUserRole = domain.Role("contractor")
+			return &settings, nil
+		},
+	}
+	authSvc := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, "test-secret-key-for-jwt-signing")
+	svc := service.NewUserService(repo, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{}, &testutil.MockTeamRepository{}, settingsRepo, authSvc)
+
+	user, err := svc.Create(context.Background(), dto.CreateUserRequest{
+		Email:    "new@example.com",
+		Password: "securepassword",
+		Name:     "New User",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.Role("contractor"), user.Role)
+
+	// An explicit role always wins
+	user, err = svc.Create(context.Background(), dto.CreateUserRequest{
+		Email:    "boss@example.com",
+		Password: "securepassword",
+		Name:     "Boss",
+		Role:     "admin",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, user.Role)
+}
+
+func TestCreate_DefaultRoleNeverAdmin(t *testing.T) {
+	repo := &testutil.MockUserRepository{
+		EmailExistsFn: func(_ context.Context, email string) (bool, error) {
+			return false, nil
+		},
+		CreateFn: func(_ context.Context, user *domain.User) error {
+			return nil
+		},
+	}
+	settingsRepo := &testutil.MockSettingsRepository{
+		GetFn: func(_ context.Context) (*domain.Settings, error) {
+			settings := domain.DefaultSettings()
+			settings.DefaultNewUserRole = domain.RoleAdmin
+			return &settings, nil
+		},
+	}
+	authSvc := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, "test-secret-key-for-jwt-signing")
+	svc := service.NewUserService(repo, &testutil.MockLedgerRepository{}, &testutil.MockTransactor{}, &testutil.MockTeamRepository{}, settingsRepo, authSvc)
+
+	user, err := svc.Create(context.Background(), dto.CreateUserRequest{
+		Email:    "new@example.com",
+		Password: "securepassword",
+		Name:     "New User",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleEmployee, user.Role)
+}
+
+func TestCreate_Success_Locale(t *testing.T) {
+	repo := &testutil.MockUserRepository{
+		EmailExistsFn: func(_ context.Context, email string) (bool, error) {
+			return false, nil
+		},
+		CreateFn: func(_ context.Context, user *domain.User) error {
+			return nil
+		},
+	}
+
+	svc := newUserService(repo)
+	user, err := svc.Create(context.Background(), dto.CreateUserRequest{
+		Email:    "neu@example.com",
+		Password: "securepassword",
+		Name:     "Neue Person",
+		Role:     "employee",
+		Locale:   "de",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.LocaleGerman, user.Locale)
+}
+
 func TestCreate_Success_CustomBalance(t *testing.T) {
 	repo := &testutil.MockUserRepository{
 		EmailExistsFn: func(_ context.Context, _ string) (bool, error) {
@@ -141,6 +239,54 @@ func TestCreate_Success_ZeroBalance(t *testing.T) {
 	assert.Equal(t, 0, user.VacationBalance)
 }
 
+func TestCreate_ProratesBalanceFromStartDate(t *testing.T) {
+	repo := &testutil.MockUserRepository{
+		EmailExistsFn: func(_ context.Context, _ string) (bool, error) {
+			return false, nil
+		},
+		CreateFn: func(_ context.Context, _ *domain.User) error {
+			return nil
+		},
+	}
+
+	// Starting on July 1st of next year leaves roughly half the leave year
+	startDate := fmt.Sprintf("%d-07-01", time.Now().Year()+1)
+
+	svc := newUserService(repo)
+	user, err := svc.Create(context.Background(), dto.CreateUserRequest{
+		Email:     "midyear@example.com",
+		Password:  "securepassword",
+		Name:      "Mid Year",
+		Role:      "employee",
+		StartDate: startDate,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 13, user.VacationBalance)
+}
+
+func TestCreate_InvalidStartDate(t *testing.T) {
+	repo := &testutil.MockUserRepository{
+		EmailExistsFn: func(_ context.Context, _ string) (bool, error) {
+			return false, nil
+		},
+	}
+
+	svc := newUserService(repo)
+	_, err := svc.Create(context.Background(), dto.CreateUserRequest{
+		Email:     "bad@example.com",
+		Password:  "securepassword",
+		Name:      "Bad Date",
+		Role:      "employee",
+		StartDate: "01/07/2027",
+	})
+
+	require.Error(t, err)
+	appErr, ok := err.(*dto.AppError)
+	require.True(t, ok)
+	assert.Equal(t, dto.ErrValidation, appErr.Code)
+}
+
 func TestCreate_DuplicateEmail(t *testing.T) {
 	repo := &testutil.MockUserRepository{
 		EmailExistsFn: func(_ context.Context, _ string) (bool, error) {
@@ -205,7 +351,9 @@ func TestCreate_PasswordTooShort(t *testing.T) {
 	assert.Nil(t, user)
 	var appErr *dto.AppError
 	require.ErrorAs(t, err, &appErr)
-	assert.Equal(t, dto.ErrInternal, appErr.Code) // hash fails -> internal error
+	assert.Equal(t, dto.ErrValidation, appErr.Code)
+	assert.Equal(t, 400, appErr.HTTPStatus)
+	assert.Contains(t, appErr.Message, "at least 6 characters")
 }
 
 func TestCreate_RepoCreateError(t *testing.T) {
@@ -246,7 +394,7 @@ func TestUpdate_Success_ChangeName(t *testing.T) {
 			u := *original
 			return &u, nil
 		},
-		UpdateFn: func(_ context.Context, user *domain.User) error {
+		UpdateTxFn: func(_ context.Context, _ *sql.Tx, user *domain.User) error {
 			assert.Equal(t, "Updated Name", user.Name)
 			return nil
 		},
@@ -275,7 +423,7 @@ func TestUpdate_Success_ChangeEmail_Unique(t *testing.T) {
 			assert.Equal(t, "user-1", excludeID)
 			return false, nil
 		},
-		UpdateFn: func(_ context.Context, user *domain.User) error {
+		UpdateTxFn: func(_ context.Context, _ *sql.Tx, user *domain.User) error {
 			assert.Equal(t, "newemail@example.com", user.Email)
 			return nil
 		},
@@ -375,7 +523,7 @@ func TestUpdate_AllowDemoteWhenMultipleAdmins(t *testing.T) {
 		CountByRoleFn: func(_ context.Context, _ domain.Role) (int, error) {
 			return 3, nil // multiple admins
 		},
-		UpdateFn: func(_ context.Context, user *domain.User) error {
+		UpdateTxFn: func(_ context.Context, _ *sql.Tx, user *domain.User) error {
 			assert.Equal(t, domain.RoleEmployee, user.Role)
 			return nil
 		},
@@ -437,7 +585,7 @@ func TestUpdate_Success_ChangeBalance(t *testing.T) {
 			u := *original
 			return &u, nil
 		},
-		UpdateFn: func(_ context.Context, user *domain.User) error {
+		UpdateTxFn: func(_ context.Context, _ *sql.Tx, user *domain.User) error {
 			assert.Equal(t, 42, user.VacationBalance)
 			return nil
 		},
@@ -460,7 +608,7 @@ func TestUpdate_Success_ChangeStartDate(t *testing.T) {
 			u := *original
 			return &u, nil
 		},
-		UpdateFn: func(_ context.Context, user *domain.User) error {
+		UpdateTxFn: func(_ context.Context, _ *sql.Tx, user *domain.User) error {
 			require.NotNil(t, user.StartDate)
 			assert.Equal(t, "2025-03-01", *user.StartDate)
 			return nil
@@ -491,7 +639,7 @@ func TestUpdate_SameEmailNoConflictCheck(t *testing.T) {
 			emailCheckCalled = true
 			return false, nil
 		},
-		UpdateFn: func(_ context.Context, _ *domain.User) error {
+		UpdateTxFn: func(_ context.Context, _ *sql.Tx, _ *domain.User) error {
 			return nil
 		},
 	}
@@ -513,7 +661,7 @@ func TestUpdate_PromoteEmployeeToAdmin(t *testing.T) {
 			u := *emp
 			return &u, nil
 		},
-		UpdateFn: func(_ context.Context, user *domain.User) error {
+		UpdateTxFn: func(_ context.Context, _ *sql.Tx, user *domain.User) error {
 			assert.Equal(t, domain.RoleAdmin, user.Role)
 			return nil
 		},
@@ -536,7 +684,7 @@ func TestUpdate_RepoUpdateError(t *testing.T) {
 			u := *original
 			return &u, nil
 		},
-		UpdateFn: func(_ context.Context, _ *domain.User) error {
+		UpdateTxFn: func(_ context.Context, _ *sql.Tx, _ *domain.User) error {
 			return errors.New("db update failed")
 		},
 	}
@@ -553,6 +701,103 @@ func TestUpdate_RepoUpdateError(t *testing.T) {
 	assert.Equal(t, dto.ErrInternal, appErr.Code)
 }
 
+func TestUpdate_ManagerSelfAssignment(t *testing.T) {
+	original := existingUser()
+	repo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, _ string) (*domain.User, error) {
+			u := *original
+			return &u, nil
+		},
+	}
+
+	svc := newUserService(repo)
+	managerID := "user-1"
+	_, err := svc.Update(context.Background(), "user-1", dto.UpdateUserRequest{
+		ManagerID: &managerID,
+	}, "admin-1")
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrValidation, appErr.Code)
+}
+
+func TestUpdate_ManagerCycle(t *testing.T) {
+	reportsTo := "user-1"
+	users := map[string]*domain.User{
+		"user-1": existingUser(),
+		"mgr-1":  {ID: "mgr-1", Name: "Bob", Role: domain.RoleEmployee, ManagerID: &reportsTo},
+	}
+	repo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, id string) (*domain.User, error) {
+			if u, ok := users[id]; ok {
+				c := *u
+				return &c, nil
+			}
+			return nil, nil
+		},
+	}
+
+	svc := newUserService(repo)
+	managerID := "mgr-1"
+	_, err := svc.Update(context.Background(), "user-1", dto.UpdateUserRequest{
+		ManagerID: &managerID,
+	}, "admin-1")
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrValidation, appErr.Code)
+}
+
+func TestUpdate_ManagerNotFound(t *testing.T) {
+	original := existingUser()
+	repo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, id string) (*domain.User, error) {
+			if id == "user-1" {
+				u := *original
+				return &u, nil
+			}
+			return nil, nil
+		},
+	}
+
+	svc := newUserService(repo)
+	managerID := "ghost"
+	_, err := svc.Update(context.Background(), "user-1", dto.UpdateUserRequest{
+		ManagerID: &managerID,
+	}, "admin-1")
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrValidation, appErr.Code)
+}
+
+func TestUpdate_ClearManager(t *testing.T) {
+	original := existingUser()
+	oldManager := "mgr-1"
+	original.ManagerID = &oldManager
+	var saved *domain.User
+	repo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, _ string) (*domain.User, error) {
+			u := *original
+			return &u, nil
+		},
+		UpdateTxFn: func(_ context.Context, _ *sql.Tx, u *domain.User) error {
+			saved = u
+			return nil
+		},
+	}
+
+	svc := newUserService(repo)
+	empty := ""
+	_, err := svc.Update(context.Background(), "user-1", dto.UpdateUserRequest{
+		ManagerID: &empty,
+	}, "admin-1")
+
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Nil(t, saved.ManagerID)
+}
+
 // ---------------------------------------------------------------------------
 // Delete
 // ---------------------------------------------------------------------------
@@ -653,6 +898,116 @@ func TestDelete_UserNotFound(t *testing.T) {
 	assert.Equal(t, 404, appErr.HTTPStatus)
 }
 
+func TestDelete_AlreadyDeleted(t *testing.T) {
+	deleted := existingUser()
+	deletedAt := time.Now()
+	deleted.DeletedAt = &deletedAt
+	repo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return deleted, nil
+		},
+	}
+
+	svc := newUserService(repo)
+	err := svc.Delete(context.Background(), "user-1", "admin-1")
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrNotFound, appErr.Code)
+}
+
+func TestSetActive_Deactivate(t *testing.T) {
+	var gotActive *bool
+	repo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, _ string) (*domain.User, error) {
+			u := existingUser()
+			u.Active = true
+			return u, nil
+		},
+		SetActiveFn: func(_ context.Context, _ string, active bool) error {
+			gotActive = &active
+			return nil
+		},
+	}
+
+	svc := newUserService(repo)
+	user, err := svc.SetActive(context.Background(), "user-1", false, "admin-1")
+
+	require.NoError(t, err)
+	require.NotNil(t, gotActive)
+	assert.False(t, *gotActive)
+	assert.False(t, user.Active)
+}
+
+func TestSetActive_CannotDeactivateSelf(t *testing.T) {
+	svc := newUserService(&testutil.MockUserRepository{})
+	_, err := svc.SetActive(context.Background(), "admin-1", false, "admin-1")
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrForbidden, appErr.Code)
+}
+
+func TestSetActive_CannotDeactivateLastActiveAdmin(t *testing.T) {
+	admin := existingAdmin()
+	admin.Active = true
+	inactiveAdmin := existingAdmin()
+	inactiveAdmin.ID = "admin-2"
+	repo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return admin, nil
+		},
+		GetByRoleFn: func(_ context.Context, _ domain.Role) ([]*domain.User, error) {
+			return []*domain.User{admin, inactiveAdmin}, nil
+		},
+	}
+
+	svc := newUserService(repo)
+	_, err := svc.SetActive(context.Background(), "admin-1", false, "admin-3")
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrForbidden, appErr.Code)
+}
+
+func TestRestore_Success(t *testing.T) {
+	deleted := existingUser()
+	deletedAt := time.Now()
+	deleted.DeletedAt = &deletedAt
+	restored := false
+	repo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return deleted, nil
+		},
+		RestoreFn: func(_ context.Context, id string) error {
+			restored = id == "user-1"
+			return nil
+		},
+	}
+
+	svc := newUserService(repo)
+	user, err := svc.Restore(context.Background(), "user-1")
+
+	require.NoError(t, err)
+	assert.True(t, restored)
+	assert.False(t, user.IsDeleted())
+}
+
+func TestRestore_NotDeleted(t *testing.T) {
+	repo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return existingUser(), nil
+		},
+	}
+
+	svc := newUserService(repo)
+	_, err := svc.Restore(context.Background(), "user-1")
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrValidation, appErr.Code)
+}
+
 func TestDelete_GetByIDError(t *testing.T) {
 	repo := &testutil.MockUserRepository{
 		GetByIDFn: func(_ context.Context, _ string) (*domain.User, error) {
@@ -780,9 +1135,8 @@ func TestGetByID_RepoError(t *testing.T) {
 func TestList_Success_Defaults(t *testing.T) {
 	users := []*domain.User{existingUser(), existingAdmin()}
 	repo := &testutil.MockUserRepository{
-		GetAllFn: func(_ context.Context, role *domain.Role, search string, limit, offset int) ([]*domain.User, int, error) {
-			assert.Nil(t, role)
-			assert.Empty(t, search)
+		GetAllFn: func(_ context.Context, filter domain.UserFilter, _ domain.UserSort, limit, offset int) ([]*domain.User, int, error) {
+			assert.Equal(t, domain.UserFilter{}, filter)
 			assert.Equal(t, 20, limit)
 			assert.Equal(t, 0, offset) // page 1 -> offset 0
 			return users, 2, nil
@@ -790,7 +1144,7 @@ func TestList_Success_Defaults(t *testing.T) {
 	}
 
 	svc := newUserService(repo)
-	result, total, err := svc.List(context.Background(), nil, "", 1, 20)
+	result, total, err := svc.List(context.Background(), domain.UserFilter{}, domain.UserSort{}, 1, 20)
 
 	require.NoError(t, err)
 	assert.Len(t, result, 2)
@@ -873,7 +1227,7 @@ func TestList_PageNormalization(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := &testutil.MockUserRepository{
-				GetAllFn: func(_ context.Context, _ *domain.Role, _ string, limit, offset int) ([]*domain.User, int, error) {
+				GetAllFn: func(_ context.Context, _ domain.UserFilter, _ domain.UserSort, limit, offset int) ([]*domain.User, int, error) {
 					assert.Equal(t, tt.expectedLimit, limit, "limit mismatch")
 					assert.Equal(t, tt.expectedOffset, offset, "offset mismatch")
 					return nil, 0, nil
@@ -881,7 +1235,7 @@ func TestList_PageNormalization(t *testing.T) {
 			}
 
 			svc := newUserService(repo)
-			_, _, err := svc.List(context.Background(), nil, "", tt.page, tt.limit)
+			_, _, err := svc.List(context.Background(), domain.UserFilter{}, domain.UserSort{}, tt.page, tt.limit)
 			require.NoError(t, err)
 		})
 	}
@@ -890,15 +1244,15 @@ func TestList_PageNormalization(t *testing.T) {
 func TestList_WithRoleFilter(t *testing.T) {
 	adminRole := domain.RoleAdmin
 	repo := &testutil.MockUserRepository{
-		GetAllFn: func(_ context.Context, role *domain.Role, _ string, _ int, _ int) ([]*domain.User, int, error) {
-			require.NotNil(t, role)
-			assert.Equal(t, domain.RoleAdmin, *role)
+		GetAllFn: func(_ context.Context, filter domain.UserFilter, _ domain.UserSort, _ int, _ int) ([]*domain.User, int, error) {
+			require.NotNil(t, filter.Role)
+			assert.Equal(t, domain.RoleAdmin, *filter.Role)
 			return []*domain.User{existingAdmin()}, 1, nil
 		},
 	}
 
 	svc := newUserService(repo)
-	result, total, err := svc.List(context.Background(), &adminRole, "", 1, 20)
+	result, total, err := svc.List(context.Background(), domain.UserFilter{Role: &adminRole}, domain.UserSort{}, 1, 20)
 
 	require.NoError(t, err)
 	assert.Len(t, result, 1)
@@ -907,14 +1261,14 @@ func TestList_WithRoleFilter(t *testing.T) {
 
 func TestList_WithSearch(t *testing.T) {
 	repo := &testutil.MockUserRepository{
-		GetAllFn: func(_ context.Context, _ *domain.Role, search string, _ int, _ int) ([]*domain.User, int, error) {
-			assert.Equal(t, "alice", search)
+		GetAllFn: func(_ context.Context, filter domain.UserFilter, _ domain.UserSort, _ int, _ int) ([]*domain.User, int, error) {
+			assert.Equal(t, "alice", filter.Search)
 			return []*domain.User{existingUser()}, 1, nil
 		},
 	}
 
 	svc := newUserService(repo)
-	result, total, err := svc.List(context.Background(), nil, "alice", 1, 20)
+	result, total, err := svc.List(context.Background(), domain.UserFilter{Search: "alice"}, domain.UserSort{}, 1, 20)
 
 	require.NoError(t, err)
 	assert.Len(t, result, 1)
@@ -923,13 +1277,13 @@ func TestList_WithSearch(t *testing.T) {
 
 func TestList_RepoError(t *testing.T) {
 	repo := &testutil.MockUserRepository{
-		GetAllFn: func(_ context.Context, _ *domain.Role, _ string, _ int, _ int) ([]*domain.User, int, error) {
+		GetAllFn: func(_ context.Context, _ domain.UserFilter, _ domain.UserSort, _ int, _ int) ([]*domain.User, int, error) {
 			return nil, 0, errors.New("db error")
 		},
 	}
 
 	svc := newUserService(repo)
-	users, total, err := svc.List(context.Background(), nil, "", 1, 20)
+	users, total, err := svc.List(context.Background(), domain.UserFilter{}, domain.UserSort{}, 1, 20)
 
 	require.Error(t, err)
 	assert.Nil(t, users)
@@ -941,13 +1295,13 @@ func TestList_RepoError(t *testing.T) {
 
 func TestList_EmptyResult(t *testing.T) {
 	repo := &testutil.MockUserRepository{
-		GetAllFn: func(_ context.Context, _ *domain.Role, _ string, _ int, _ int) ([]*domain.User, int, error) {
+		GetAllFn: func(_ context.Context, _ domain.UserFilter, _ domain.UserSort, _ int, _ int) ([]*domain.User, int, error) {
 			return []*domain.User{}, 0, nil
 		},
 	}
 
 	svc := newUserService(repo)
-	result, total, err := svc.List(context.Background(), nil, "", 1, 20)
+	result, total, err := svc.List(context.Background(), domain.UserFilter{}, domain.UserSort{}, 1, 20)
 
 	require.NoError(t, err)
 	assert.Empty(t, result)
@@ -966,7 +1320,7 @@ func TestUpdateBalance_Success(t *testing.T) {
 			u := *original
 			return &u, nil
 		},
-		UpdateVacationBalanceFn: func(_ context.Context, id string, balance int) error {
+		UpdateVacationBalanceTxFn: func(_ context.Context, _ *sql.Tx, id string, balance int) error {
 			assert.Equal(t, "user-1", id)
 			assert.Equal(t, 30, balance)
 			return nil
@@ -989,7 +1343,7 @@ func TestUpdateBalance_Success_SetToZero(t *testing.T) {
 			u := *original
 			return &u, nil
 		},
-		UpdateVacationBalanceFn: func(_ context.Context, _ string, balance int) error {
+		UpdateVacationBalanceTxFn: func(_ context.Context, _ *sql.Tx, _ string, balance int) error {
 			assert.Equal(t, 0, balance)
 			return nil
 		},
@@ -1066,7 +1420,7 @@ func TestUpdateBalance_RepoUpdateError(t *testing.T) {
 			u := *original
 			return &u, nil
 		},
-		UpdateVacationBalanceFn: func(_ context.Context, _ string, _ int) error {
+		UpdateVacationBalanceTxFn: func(_ context.Context, _ *sql.Tx, _ string, _ int) error {
 			return errors.New("db update failed")
 		},
 	}
@@ -1087,7 +1441,7 @@ func TestUpdateBalance_RepoUpdateError(t *testing.T) {
 
 func TestResetAllBalances_Success(t *testing.T) {
 	repo := &testutil.MockUserRepository{
-		UpdateAllBalancesFn: func(_ context.Context, balance int) (int64, error) {
+		UpdateAllBalancesTxFn: func(_ context.Context, _ *sql.Tx, balance int) (int64, error) {
 			assert.Equal(t, 25, balance)
 			return 10, nil
 		},
@@ -1102,7 +1456,7 @@ func TestResetAllBalances_Success(t *testing.T) {
 
 func TestResetAllBalances_Success_ZeroDays(t *testing.T) {
 	repo := &testutil.MockUserRepository{
-		UpdateAllBalancesFn: func(_ context.Context, balance int) (int64, error) {
+		UpdateAllBalancesTxFn: func(_ context.Context, _ *sql.Tx, balance int) (int64, error) {
 			assert.Equal(t, 0, balance)
 			return 5, nil
 		},
@@ -1132,7 +1486,7 @@ func TestResetAllBalances_NegativeDays(t *testing.T) {
 
 func TestResetAllBalances_RepoError(t *testing.T) {
 	repo := &testutil.MockUserRepository{
-		UpdateAllBalancesFn: func(_ context.Context, _ int) (int64, error) {
+		UpdateAllBalancesTxFn: func(_ context.Context, _ *sql.Tx, _ int) (int64, error) {
 			return 0, errors.New("db error")
 		},
 	}
@@ -1149,7 +1503,7 @@ func TestResetAllBalances_RepoError(t *testing.T) {
 
 func TestResetAllBalances_NoUsersAffected(t *testing.T) {
 	repo := &testutil.MockUserRepository{
-		UpdateAllBalancesFn: func(_ context.Context, _ int) (int64, error) {
+		UpdateAllBalancesTxFn: func(_ context.Context, _ *sql.Tx, _ int) (int64, error) {
 			return 0, nil
 		},
 	}
@@ -1160,3 +1514,355 @@ func TestResetAllBalances_NoUsersAffected(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 0, count)
 }
+
+func TestResetAllBalances_RecordsLedgerResets(t *testing.T) {
+	repo := &testutil.MockUserRepository{
+		GetByRoleFn: func(_ context.Context, role domain.Role) ([]*domain.User, error) {
+			assert.Equal(t, domain.RoleEmployee, role)
+			return []*domain.User{
+				{ID: "emp-1", VacationBalance: 3},
+				{ID: "emp-2", VacationBalance: 25},
+			}, nil
+		},
+		UpdateAllBalancesTxFn: func(_ context.Context, _ *sql.Tx, _ int) (int64, error) {
+			return 2, nil
+		},
+	}
+	var recorded []*domain.LedgerEntry
+	ledger := &testutil.MockLedgerRepository{
+		CreateTxFn: func(_ context.Context, _ *sql.Tx, entry *domain.LedgerEntry) error {
+			recorded = append(recorded, entry)
+			return nil
+		},
+	}
+
+	svc := newUserServiceWithLedger(repo, ledger)
+	_, err := svc.ResetAllBalances(context.Background(), 25)
+
+	require.NoError(t, err)
+	// emp-2 already had 25 days, so only emp-1 gets an entry
+	require.Len(t, recorded, 1)
+	assert.Equal(t, "emp-1", recorded[0].UserID)
+	assert.Equal(t, 22, recorded[0].Delta)
+	assert.Equal(t, domain.LedgerReset, recorded[0].Reason)
+}
+
+func TestPreviewResetBalances_DoesNotWrite(t *testing.T) {
+	repo := &testutil.MockUserRepository{
+		GetByRoleFn: func(_ context.Context, _ domain.Role) ([]*domain.User, error) {
+			return []*domain.User{
+				{ID: "emp-1", Name: "Alice", VacationBalance: 3},
+				{ID: "emp-2", Name: "Bob", VacationBalance: 30},
+			}, nil
+		},
+		UpdateAllBalancesTxFn: func(_ context.Context, _ *sql.Tx, _ int) (int64, error) {
+			t.Fatal("preview must not update balances")
+			return 0, nil
+		},
+	}
+
+	svc := newUserService(repo)
+	balances, err := svc.PreviewResetBalances(context.Background(), 25)
+
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, "emp-1", balances[0].UserID)
+	assert.Equal(t, 3, balances[0].PreviousBalance)
+	assert.Equal(t, 25, balances[0].NewBalance)
+	assert.Equal(t, 30, balances[1].PreviousBalance)
+	assert.Equal(t, 25, balances[1].NewBalance)
+}
+
+func TestPreviewResetBalances_NegativeDays(t *testing.T) {
+	svc := newUserService(&testutil.MockUserRepository{})
+
+	_, err := svc.PreviewResetBalances(context.Background(), -1)
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrValidation, appErr.Code)
+}
+
+// ---------------------------------------------------------------------------
+// AdjustBalances
+// ---------------------------------------------------------------------------
+
+// adjustBalancesRepo serves users from a fixed set and records balance writes
+func adjustBalancesRepo(users map[string]*domain.User, written map[string]int) *testutil.MockUserRepository {
+	return &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, id string) (*domain.User, error) {
+			if u, ok := users[id]; ok {
+				copied := *u
+				return &copied, nil
+			}
+			return nil, nil
+		},
+		AddVacationBalanceTxFn: func(_ context.Context, _ *sql.Tx, id string, delta, floor int) (int, error) {
+			balance := users[id].VacationBalance
+			if delta < 0 && balance+delta < floor {
+				return balance, repository.ErrBalanceBelowMinimum
+			}
+			written[id] = balance + delta
+			return balance + delta, nil
+		},
+	}
+}
+
+func TestAdjustBalances_AppliesDeltaAndRecordsLedger(t *testing.T) {
+	users := map[string]*domain.User{
+		"u1": {ID: "u1", Name: "Ann", VacationBalance: 10},
+		"u2": {ID: "u2", Name: "Ben", VacationBalance: 0},
+	}
+	written := map[string]int{}
+	var recorded []*domain.LedgerEntry
+	ledger := &testutil.MockLedgerRepository{
+		CreateTxFn: func(_ context.Context, _ *sql.Tx, entry *domain.LedgerEntry) error {
+			recorded = append(recorded, entry)
+			return nil
+		},
+	}
+
+	svc := newUserServiceWithLedger(adjustBalancesRepo(users, written), ledger)
+	// u1 listed twice is only adjusted once
+	balances, err := svc.AdjustBalances(context.Background(), []string{"u1", "u2", "u1"}, 2, "Crunch bonus")
+
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, dto.BalanceAdjustment{UserID: "u1", Name: "Ann", PreviousBalance: 10, NewBalance: 12}, *balances[0])
+	assert.Equal(t, dto.BalanceAdjustment{UserID: "u2", Name: "Ben", PreviousBalance: 0, NewBalance: 2}, *balances[1])
+	assert.Equal(t, map[string]int{"u1": 12, "u2": 2}, written)
+
+	require.Len(t, recorded, 2)
+	for _, entry := range recorded {
+		assert.Equal(t, 2, entry.Delta)
+		assert.Equal(t, domain.LedgerAdjustment, entry.Reason)
+		require.NotNil(t, entry.Note)
+		assert.Equal(t, "Crunch bonus", *entry.Note)
+	}
+}
+
+func TestAdjustBalances_RejectsNegativeResult(t *testing.T) {
+	users := map[string]*domain.User{
+		"u1": {ID: "u1", Name: "Ann", VacationBalance: 10},
+		"u2": {ID: "u2", Name: "Ben", VacationBalance: 1},
+	}
+	written := map[string]int{}
+
+	svc := newUserService(adjustBalancesRepo(users, written))
+	_, err := svc.AdjustBalances(context.Background(), []string{"u1", "u2"}, -2, "Correction")
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrValidation, appErr.Code)
+	assert.Contains(t, appErr.Message, "Ben")
+	assert.Empty(t, written, "no balance may change when any user would go negative")
+}
+
+func TestAdjustBalances_FloorCheckedInsideTransaction(t *testing.T) {
+	users := map[string]*domain.User{
+		"u1": {ID: "u1", Name: "Ann", VacationBalance: 10},
+	}
+	repo := adjustBalancesRepo(users, map[string]int{})
+	// Ann's balance as read before the transaction; an approval has since taken it down to 3
+	repo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id, Name: "Ann", VacationBalance: 10}, nil
+	}
+	users["u1"].VacationBalance = 3
+
+	svc := newUserService(repo)
+	_, err := svc.AdjustBalances(context.Background(), []string{"u1"}, -5, "Correction")
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrValidation, appErr.Code)
+	assert.Contains(t, appErr.Message, "balance of -2 days")
+}
+
+func TestAdjustBalances_AllowsOverdrawWithinSettings(t *testing.T) {
+	users := map[string]*domain.User{
+		"u1": {ID: "u1", Name: "Ann", VacationBalance: 1},
+	}
+	written := map[string]int{}
+	settingsRepo := &testutil.MockSettingsRepository{
+		GetFn: func(_ context.Context) (*domain.Settings, error) {
+			settings := domain.DefaultSettings()
+			settings.AllowNegativeBalance = true
+			settings.MaxOverdrawDays = 3
+			return &settings, nil
+		},
+	}
+	authSvc := service.NewAuthService(&testutil.MockUserRepository{}, &testutil.MockRefreshTokenRepository{}, "test-secret-key-for-jwt-signing")
+	svc := service.NewUserService(adjustBalancesRepo(users, written), &testutil.MockLedgerRepository{}, &testutil.MockTransactor{}, &testutil.MockTeamRepository{}, settingsRepo, authSvc)
+
+	balances, err := svc.AdjustBalances(context.Background(), []string{"u1"}, -4, "Correction")
+	require.NoError(t, err)
+	assert.Equal(t, -3, balances[0].NewBalance)
+
+	_, err = svc.AdjustBalances(context.Background(), []string{"u1"}, -5, "Correction")
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrValidation, appErr.Code)
+}
+
+func TestAdjustBalances_UnknownUser(t *testing.T) {
+	users := map[string]*domain.User{
+		"u1": {ID: "u1", Name: "Ann", VacationBalance: 10},
+	}
+	written := map[string]int{}
+
+	svc := newUserService(adjustBalancesRepo(users, written))
+	_, err := svc.AdjustBalances(context.Background(), []string{"u1", "ghost"}, 2, "Crunch bonus")
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrNotFound, appErr.Code)
+	assert.Empty(t, written)
+}
+
+func TestAdjustBalances_TransactionErrorRollsBack(t *testing.T) {
+	users := map[string]*domain.User{
+		"u1": {ID: "u1", Name: "Ann", VacationBalance: 10},
+	}
+	ledger := &testutil.MockLedgerRepository{
+		CreateTxFn: func(_ context.Context, _ *sql.Tx, _ *domain.LedgerEntry) error {
+			return errors.New("ledger write failed")
+		},
+	}
+
+	svc := newUserServiceWithLedger(adjustBalancesRepo(users, map[string]int{}), ledger)
+	_, err := svc.AdjustBalances(context.Background(), []string{"u1"}, 2, "Crunch bonus")
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrInternal, appErr.Code)
+}
+
+// ---------------------------------------------------------------------------
+// Balance ledger
+// ---------------------------------------------------------------------------
+
+func TestUpdateBalance_RecordsLedgerAdjustment(t *testing.T) {
+	original := existingUser()
+	repo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, _ string) (*domain.User, error) {
+			u := *original
+			return &u, nil
+		},
+	}
+	var recorded *domain.LedgerEntry
+	ledger := &testutil.MockLedgerRepository{
+		CreateTxFn: func(_ context.Context, _ *sql.Tx, entry *domain.LedgerEntry) error {
+			recorded = entry
+			return nil
+		},
+	}
+
+	svc := newUserServiceWithLedger(repo, ledger)
+	_, err := svc.UpdateBalance(context.Background(), "user-1", original.VacationBalance+4)
+
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	assert.Equal(t, "user-1", recorded.UserID)
+	assert.Equal(t, 4, recorded.Delta)
+	assert.Equal(t, domain.LedgerAdjustment, recorded.Reason)
+}
+
+func TestUpdateBalance_LedgerErrorFails(t *testing.T) {
+	original := existingUser()
+	repo := &testutil.MockUserRepository{
+		GetByIDFn: func(_ context.Context, _ string) (*domain.User, error) {
+			u := *original
+			return &u, nil
+		},
+	}
+	ledger := &testutil.MockLedgerRepository{
+		CreateTxFn: func(_ context.Context, _ *sql.Tx, _ *domain.LedgerEntry) error {
+			return errors.New("ledger write failed")
+		},
+	}
+
+	svc := newUserServiceWithLedger(repo, ledger)
+	user, err := svc.UpdateBalance(context.Background(), "user-1", 12)
+
+	// The balance and its ledger entry share a transaction, so neither is kept
+	require.Error(t, err)
+	assert.Nil(t, user)
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrInternal, appErr.Code)
+}
+
+func TestReconcileBalances_ReportsMismatches(t *testing.T) {
+	repo := &testutil.MockUserRepository{
+		GetByRoleFn: func(_ context.Context, role domain.Role) ([]*domain.User, error) {
+			if role == domain.RoleAdmin {
+				return []*domain.User{{ID: "admin-1", Name: "Admin", VacationBalance: 25}}, nil
+			}
+			return []*domain.User{
+				{ID: "emp-1", Name: "Alice", Email: "alice@test.com", VacationBalance: 18},
+				{ID: "emp-2", Name: "Bob", VacationBalance: 20},
+				{ID: "emp-3", Name: "Carol", VacationBalance: 5},
+			}, nil
+		},
+	}
+	ledger := &testutil.MockLedgerRepository{
+		SumByUserFn: func(_ context.Context) (map[string]int, error) {
+			// emp-3 has no ledger entries at all
+			return map[string]int{"admin-1": 25, "emp-1": 15, "emp-2": 20}, nil
+		},
+	}
+
+	svc := newUserServiceWithLedger(repo, ledger)
+	discrepancies, checked, err := svc.ReconcileBalances(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, checked)
+	require.Len(t, discrepancies, 2)
+
+	assert.Equal(t, "emp-1", discrepancies[0].UserID)
+	assert.Equal(t, "alice@test.com", discrepancies[0].Email)
+	assert.Equal(t, 18, discrepancies[0].StoredBalance)
+	assert.Equal(t, 15, discrepancies[0].LedgerBalance)
+	assert.Equal(t, 3, discrepancies[0].Delta)
+
+	assert.Equal(t, "emp-3", discrepancies[1].UserID)
+	assert.Equal(t, 0, discrepancies[1].LedgerBalance)
+	assert.Equal(t, 5, discrepancies[1].Delta)
+}
+
+func TestNotificationRecipients_SkipReasons(t *testing.T) {
+	repo := &testutil.MockUserRepository{
+		GetByRoleFn: func(_ context.Context, role domain.Role) ([]*domain.User, error) {
+			return []*domain.User{
+				{ID: "a1", Name: "Ana", Active: true, EmailPreferences: domain.EmailPreferences{TeamNotifications: true}},
+				{ID: "a2", Name: "Ben", Active: false, EmailPreferences: domain.EmailPreferences{TeamNotifications: true}},
+				{ID: "a3", Name: "Cy", Active: true},
+			}, nil
+		},
+	}
+
+	resp, err := newUserService(repo).NotificationRecipients(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Recipients)
+	require.Len(t, resp.Admins, 3)
+	assert.True(t, resp.Admins[0].Receives)
+	assert.Empty(t, resp.Admins[0].SkipReason)
+	assert.Equal(t, "inactive", resp.Admins[1].SkipReason)
+	assert.Equal(t, "team notifications turned off", resp.Admins[2].SkipReason)
+}
+
+func TestReconcileBalances_LedgerError(t *testing.T) {
+	ledger := &testutil.MockLedgerRepository{
+		SumByUserFn: func(_ context.Context) (map[string]int, error) {
+			return nil, errors.New("db error")
+		},
+	}
+
+	svc := newUserServiceWithLedger(&testutil.MockUserRepository{}, ledger)
+	_, _, err := svc.ReconcileBalances(context.Background())
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrInternal, appErr.Code)
+}
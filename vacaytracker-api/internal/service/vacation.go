@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -32,6 +34,7 @@ type VacationService struct {
 	vacationRepo repository.VacationRepository
 	userRepo     repository.UserRepository
 	settingsRepo repository.SettingsRepository
+	ledgerRepo   repository.LedgerRepository
 	transactor   repository.Transactor
 }
 
@@ -40,12 +43,14 @@ func NewVacationService(
 	vacationRepo repository.VacationRepository,
 	userRepo repository.UserRepository,
 	settingsRepo repository.SettingsRepository,
+	ledgerRepo repository.LedgerRepository,
 	transactor repository.Transactor,
 ) *VacationService {
 	return &VacationService{
 		vacationRepo: vacationRepo,
 		userRepo:     userRepo,
 		settingsRepo: settingsRepo,
+		ledgerRepo:   ledgerRepo,
 		transactor:   transactor,
 	}
 }
@@ -68,23 +73,24 @@ func (s *VacationService) Create(ctx context.Context, userID string, req dto.Cre
 		return nil, dto.ErrValidationError("end date must be after or equal to start date")
 	}
 
-	// Check if start date is in the past
-	today := time.Now().UTC().Truncate(24 * time.Hour)
-	if startDate.Before(today) {
-		return nil, dto.ErrValidationError("start date cannot be in the past")
-	}
-
-	// Get settings for business day calculation
+	// Get settings for the time zone and business day calculation
 	settings, err := s.settingsRepo.Get(ctx)
 	if err != nil {
 		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
 	}
 
-	// Calculate business days
-	totalDays := calculateBusinessDays(startDate, endDate, settings.WeekendPolicy)
-	if totalDays == 0 {
+	// Check if start date is in the past, judged by the calendar date in the configured time zone
+	today := settings.Today(time.Now())
+	if startDate.Before(today) {
+		return nil, dto.ErrValidationError("start date cannot be in the past")
+	}
+
+	// Calculate business days; the request total and balance checks use the balance unit
+	businessDays := calculateBusinessDays(startDate, endDate, settings.WeekendPolicy)
+	if businessDays == 0 {
 		return nil, dto.ErrValidationError("selected dates result in zero vacation days")
 	}
+	totalDays := chargedDays(settings, businessDays)
 
 	// Get user and check balance
 	user, err := s.userRepo.GetByID(ctx, userID)
@@ -95,26 +101,59 @@ func (s *VacationService) Create(ctx context.Context, userID string, req dto.Cre
 		return nil, dto.ErrNotFoundError("user")
 	}
 
-	if user.VacationBalance < totalDays {
-		return nil, dto.ErrInsufficientBalanceError(totalDays, user.VacationBalance)
+	// The booking horizon applies to every request type, tentative ones included
+	if !(req.Force && user.IsAdmin()) {
+		if err := checkHorizon(settings, startDate, today); err != nil {
+			return nil, err
+		}
 	}
 
 	// Format dates for storage
 	startDateStr := startDate.Format("2006-01-02")
 	endDateStr := endDate.Format("2006-01-02")
 
-	// Check for overlapping requests
-	hasOverlap, err := s.vacationRepo.HasOverlap(ctx, userID, startDateStr, endDateStr)
-	if err != nil {
-		return nil, dto.ErrInternalErrorWithMessage("failed to check for overlapping requests")
+	requestType := domain.RequestTypeVacation
+	if req.Type != "" {
+		requestType = domain.RequestType(req.Type)
 	}
-	if hasOverlap {
-		return nil, dto.ErrOverlappingRequestError()
+
+	// Tentative requests skip notice, blackout, balance and overlap checks until they are submitted
+	// Remote days are only checked for overlaps; the other rules apply to paid leave
+	var overlapWarning bool
+	if !req.Tentative && requestType == domain.RequestTypeRemote {
+		overlapWarning, err = s.checkOverlap(ctx, settings, user, requestType, startDateStr, endDateStr)
+		if err != nil {
+			return nil, err
+		}
+	} else if !req.Tentative {
+		if err := checkNotice(settings, user, startDate, today); err != nil {
+			return nil, err
+		}
+		if !(req.Force && user.IsAdmin()) {
+			if err := checkBlackout(settings, startDateStr, endDateStr); err != nil {
+				return nil, err
+			}
+		}
+		if err := checkMaxConsecutiveDays(settings, user, businessDays); err != nil {
+			return nil, err
+		}
+		if err := s.checkCoolOff(ctx, settings, user, businessDays, startDate, endDate); err != nil {
+			return nil, err
+		}
+		if err := s.checkRequestCap(ctx, settings, user, startDate); err != nil {
+			return nil, err
+		}
+		overlapWarning, err = s.validateSubmission(ctx, settings, user, totalDays, startDateStr, endDateStr)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Create request - auto-approve for admins
+	// Create request - auto-approve for admins, bypassing the approval chain
 	status := domain.StatusPending
-	if user.IsAdmin() {
+	if req.Tentative {
+		status = domain.StatusTentative
+	} else if user.IsAdmin() {
 		status = domain.StatusApproved
 	}
 
@@ -125,6 +164,7 @@ func (s *VacationService) Create(ctx context.Context, userID string, req dto.Cre
 		EndDate:   endDateStr,
 		TotalDays: totalDays,
 		Status:    status,
+		Type:      requestType,
 	}
 
 	if req.Reason != "" {
@@ -132,25 +172,34 @@ func (s *VacationService) Create(ctx context.Context, userID string, req dto.Cre
 	}
 
 	// For admins, create request and deduct balance atomically
-	if user.IsAdmin() {
-		newBalance := user.VacationBalance - totalDays
-		if newBalance < 0 {
-			newBalance = 0
-		}
-
+	// The deduction applies to the balance as it stands then, so concurrent changes aren't overwritten
+	var lowAlert *domain.LowBalanceAlert
+	if status == domain.StatusApproved && !vacation.IsRemote() {
+		var balance int
 		err = s.transactor.Transaction(func(tx *sql.Tx) error {
 			if err := s.vacationRepo.CreateTx(ctx, tx, vacation); err != nil {
 				return err
 			}
-			if err := s.userRepo.UpdateVacationBalanceTx(ctx, tx, userID, newBalance); err != nil {
+			var err error
+			balance, err = s.userRepo.AddVacationBalanceTx(ctx, tx, userID, -totalDays, settings.MinimumBalance())
+			if err != nil {
+				return err
+			}
+			entry := newLedgerEntry(userID, -totalDays, domain.LedgerVacation, &vacation.ID)
+			if err := s.ledgerRepo.CreateTx(ctx, tx, entry); err != nil {
 				return err
 			}
 			return nil
 		})
 
+		if errors.Is(err, repository.ErrBalanceBelowMinimum) {
+			// The balance dropped since it was checked above
+			return nil, dto.ErrInsufficientBalanceError(totalDays, balance, string(settings.BalanceUnit))
+		}
 		if err != nil {
 			return nil, dto.ErrInternalErrorWithMessage("failed to create vacation request")
 		}
+		lowAlert = lowBalanceAlert(settings, balance+totalDays, balance)
 	} else {
 		if err := s.vacationRepo.Create(ctx, vacation); err != nil {
 			return nil, dto.ErrInternalErrorWithMessage("failed to create vacation request")
@@ -158,51 +207,63 @@ func (s *VacationService) Create(ctx context.Context, userID string, req dto.Cre
 	}
 
 	// Fetch the created request with user info
-	return s.vacationRepo.GetByID(ctx, vacation.ID)
+	created, err := s.vacationRepo.GetByID(ctx, vacation.ID)
+	if err != nil || created == nil {
+		return created, err
+	}
+	created.OverlapWarning = overlapWarning
+	created.DayBreakdown = dayBreakdown(startDate, endDate, settings.WeekendPolicy)
+	created.LowBalanceAlert = lowAlert
+	if !created.IsRemote() {
+		created.Warnings = requestWarnings(settings, businessDays, user.VacationBalance-totalDays)
+	}
+	return created, nil
 }
 
-// Cancel cancels a pending vacation request
-func (s *VacationService) Cancel(ctx context.Context, requestID, userID string) error {
+// Submit promotes a tentative request to a regular request
+// Dates, balance and overlaps are validated again since they may have changed since it was pencilled in
+func (s *VacationService) Submit(ctx context.Context, requestID, userID string) (*domain.VacationRequest, error) {
 	request, err := s.vacationRepo.GetByID(ctx, requestID)
 	if err != nil {
-		return dto.ErrInternalErrorWithMessage("failed to get vacation request")
+		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation request")
 	}
 	if request == nil {
-		return dto.ErrNotFoundError("vacation request")
+		return nil, dto.ErrNotFoundError("vacation request")
 	}
 
-	// Check ownership
 	if request.UserID != userID {
-		return dto.ErrForbiddenError("you can only cancel your own requests")
+		return nil, dto.ErrForbiddenError("you can only submit your own requests")
+	}
+	if !request.IsTentative() {
+		return nil, dto.ErrConflictError("request has already been submitted")
 	}
 
-	// Check status
-	if request.IsApproved() {
-		return dto.ErrForbiddenError("cannot cancel approved request")
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("invalid stored start date")
 	}
-	if request.IsRejected() {
-		return dto.ErrForbiddenError("cannot cancel rejected request")
+	endDate, err := time.Parse("2006-01-02", request.EndDate)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("invalid stored end date")
 	}
 
-	return s.vacationRepo.Delete(ctx, requestID)
-}
-
-// Approve approves a pending request and deducts balance atomically using a transaction
-func (s *VacationService) Approve(ctx context.Context, requestID, adminID string) (*domain.VacationRequest, error) {
-	request, err := s.vacationRepo.GetByID(ctx, requestID)
+	settings, err := s.settingsRepo.Get(ctx)
 	if err != nil {
-		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation request")
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
 	}
-	if request == nil {
-		return nil, dto.ErrNotFoundError("vacation request")
+
+	today := settings.Today(time.Now())
+	if startDate.Before(today) {
+		return nil, dto.ErrValidationError("start date cannot be in the past")
 	}
 
-	if !request.IsPending() {
-		return nil, dto.ErrConflictError("request has already been processed")
+	businessDays := calculateBusinessDays(startDate, endDate, settings.WeekendPolicy)
+	if businessDays == 0 {
+		return nil, dto.ErrValidationError("selected dates result in zero vacation days")
 	}
+	totalDays := chargedDays(settings, businessDays)
 
-	// Get user to check balance
-	user, err := s.userRepo.GetByID(ctx, request.UserID)
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, dto.ErrInternalErrorWithMessage("failed to get user")
 	}
@@ -210,63 +271,237 @@ func (s *VacationService) Approve(ctx context.Context, requestID, adminID string
 		return nil, dto.ErrNotFoundError("user")
 	}
 
-	// Check if user still has enough balance
-	if user.VacationBalance < request.TotalDays {
-		return nil, dto.ErrInsufficientBalanceError(request.TotalDays, user.VacationBalance)
+	var overlapWarning bool
+	if request.IsRemote() {
+		overlapWarning, err = s.checkOverlap(ctx, settings, user, request.Type, request.StartDate, request.EndDate)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err := checkNotice(settings, user, startDate, today); err != nil {
+			return nil, err
+		}
+		if err := checkBlackout(settings, request.StartDate, request.EndDate); err != nil {
+			return nil, err
+		}
+		if err := checkMaxConsecutiveDays(settings, user, businessDays); err != nil {
+			return nil, err
+		}
+		if err := s.checkCoolOff(ctx, settings, user, businessDays, startDate, endDate); err != nil {
+			return nil, err
+		}
+		if err := s.checkRequestCap(ctx, settings, user, startDate); err != nil {
+			return nil, err
+		}
+		overlapWarning, err = s.validateSubmission(ctx, settings, user, totalDays, request.StartDate, request.EndDate)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Calculate new balance
-	newBalance := user.VacationBalance - request.TotalDays
-	if newBalance < 0 {
-		newBalance = 0
+	// Admin submissions are auto-approved, same as on Create
+	status := domain.StatusPending
+	if user.IsAdmin() {
+		status = domain.StatusApproved
 	}
 
-	// Execute status update and balance deduction atomically in a transaction
+	// The deduction applies to the balance as it stands then, so concurrent changes aren't overwritten
+	var lowAlert *domain.LowBalanceAlert
+	var balance int
 	err = s.transactor.Transaction(func(tx *sql.Tx) error {
-		// Update status
-		if err := s.vacationRepo.UpdateStatusTx(ctx, tx, requestID, domain.StatusApproved, adminID, nil); err != nil {
+		if err := s.vacationRepo.PromoteTentativeTx(ctx, tx, requestID, status, totalDays); err != nil {
 			return err
 		}
-
-		// Deduct vacation balance
-		if err := s.userRepo.UpdateVacationBalanceTx(ctx, tx, request.UserID, newBalance); err != nil {
-			return err
+		if status == domain.StatusApproved && !request.IsRemote() {
+			var err error
+			balance, err = s.userRepo.AddVacationBalanceTx(ctx, tx, userID, -totalDays, settings.MinimumBalance())
+			if err != nil {
+				return err
+			}
+			lowAlert = lowBalanceAlert(settings, balance+totalDays, balance)
+			entry := newLedgerEntry(userID, -totalDays, domain.LedgerVacation, &requestID)
+			if err := s.ledgerRepo.CreateTx(ctx, tx, entry); err != nil {
+				return err
+			}
 		}
-
 		return nil
 	})
-
+	if errors.Is(err, repository.ErrBalanceBelowMinimum) {
+		// The balance dropped since it was checked above
+		return nil, dto.ErrInsufficientBalanceError(totalDays, balance, string(settings.BalanceUnit))
+	}
 	if err != nil {
-		return nil, dto.ErrInternalErrorWithMessage("failed to approve request")
+		return nil, dto.ErrInternalErrorWithMessage("failed to submit vacation request")
 	}
 
-	// Fetch updated request
-	return s.vacationRepo.GetByID(ctx, requestID)
+	submitted, err := s.vacationRepo.GetByID(ctx, requestID)
+	if err != nil || submitted == nil {
+		return submitted, err
+	}
+	submitted.OverlapWarning = overlapWarning
+	submitted.LowBalanceAlert = lowAlert
+	return submitted, nil
 }
 
-// Reject rejects a pending request
-func (s *VacationService) Reject(ctx context.Context, requestID, adminID string, reason *string) (*domain.VacationRequest, error) {
-	request, err := s.vacationRepo.GetByID(ctx, requestID)
+// checkNotice enforces the minimum notice period for a request entering review
+// Admins are exempt since their requests are auto-approved
+func checkNotice(settings *domain.Settings, user *domain.User, startDate, today time.Time) error {
+	if settings.MinNoticeDays <= 0 || user.IsAdmin() {
+		return nil
+	}
+
+	// Count business days from today up to, but not including, the start date
+	notice := 0
+	if startDate.After(today) {
+		notice = calculateBusinessDays(today, startDate.AddDate(0, 0, -1), settings.WeekendPolicy)
+	}
+	if notice < settings.MinNoticeDays {
+		return dto.ErrValidationError(fmt.Sprintf("requests require at least %d days notice", settings.MinNoticeDays))
+	}
+	return nil
+}
+
+// checkHorizon rejects requests starting more than MaxFutureDays calendar days after today
+func checkHorizon(settings *domain.Settings, startDate, today time.Time) error {
+	if settings.MaxFutureDays <= 0 {
+		return nil
+	}
+	if startDate.After(today.AddDate(0, 0, settings.MaxFutureDays)) {
+		return dto.ErrValidationError(fmt.Sprintf("requests cannot start more than %d days in advance", settings.MaxFutureDays))
+	}
+	return nil
+}
+
+// checkBlackout rejects requests overlapping a configured blackout period
+func checkBlackout(settings *domain.Settings, startDate, endDate string) error {
+	if blackout := settings.OverlappingBlackout(startDate, endDate); blackout != nil {
+		return dto.ErrBlackoutPeriodError(*blackout)
+	}
+	return nil
+}
+
+// checkBalance rejects requests that would take the balance below Settings.MinimumBalance
+// totalDays is in the balance unit
+func checkBalance(settings *domain.Settings, user *domain.User, totalDays int) error {
+	if user.VacationBalance-totalDays < settings.MinimumBalance() {
+		return dto.ErrInsufficientBalanceError(totalDays, user.VacationBalance, string(settings.BalanceUnit))
+	}
+	return nil
+}
+
+// checkMaxConsecutiveDays enforces the per-request length limit for employees
+func checkMaxConsecutiveDays(settings *domain.Settings, user *domain.User, businessDays int) error {
+	if settings.MaxConsecutiveDays <= 0 || user.IsAdmin() {
+		return nil
+	}
+	if businessDays > settings.MaxConsecutiveDays {
+		return dto.ErrMaxConsecutiveDaysError(businessDays, settings.MaxConsecutiveDays)
+	}
+	return nil
+}
+
+// checkCoolOff requires a gap of Settings.CoolOffDays between two long vacations of the same employee
+// The gap is enforced against approved long vacations on either side of the new request
+func (s *VacationService) checkCoolOff(ctx context.Context, settings *domain.Settings, user *domain.User, businessDays int, startDate, endDate time.Time) error {
+	if !settings.IsLongVacation(businessDays) || user.IsAdmin() {
+		return nil
+	}
+
+	approved := domain.StatusApproved
+	existing, err := s.vacationRepo.ListByUser(ctx, user.ID, &approved, nil, "", "")
 	if err != nil {
-		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation request")
+		return dto.ErrInternalErrorWithMessage("failed to list vacation requests")
 	}
-	if request == nil {
-		return nil, dto.ErrNotFoundError("vacation request")
+
+	for _, r := range existing {
+		if r.IsRemote() || !settings.IsLongVacation(settings.BalanceToDays(r.TotalDays)) {
+			continue
+		}
+		priorStart, err := time.Parse("2006-01-02", r.StartDate)
+		if err != nil {
+			continue
+		}
+		priorEnd, err := time.Parse("2006-01-02", r.EndDate)
+		if err != nil {
+			continue
+		}
+
+		var gap int
+		if priorEnd.Before(startDate) {
+			gap = int(startDate.Sub(priorEnd).Hours() / 24)
+		} else {
+			gap = int(priorStart.Sub(endDate).Hours() / 24)
+		}
+		if gap < settings.CoolOffDays {
+			return dto.ErrValidationError(fmt.Sprintf(
+				"long vacations must be at least %d days apart; the vacation from %s to %s is too close",
+				settings.CoolOffDays, r.StartDate, r.EndDate,
+			))
+		}
 	}
+	return nil
+}
 
-	if !request.IsPending() {
-		return nil, dto.ErrConflictError("request has already been processed")
+// chargedDays converts a request's business days into the total charged against the balance,
+// applying the rounding mode and balance unit
+func chargedDays(settings *domain.Settings, businessDays int) int {
+	return settings.DaysToBalance(settings.RoundBusinessDays(businessDays))
+}
+
+// checkRequestCap enforces Settings.MaxRequestsPerYear for employees within the leave year the new request starts in
+// Cancelled requests are deleted and tentative ones aren't filed yet, so neither counts, nor do remote days;
+// rejected requests only count with Settings.CountRejectedRequests
+func (s *VacationService) checkRequestCap(ctx context.Context, settings *domain.Settings, user *domain.User, startDate time.Time) error {
+	if settings.MaxRequestsPerYear <= 0 || user.IsAdmin() {
+		return nil
 	}
 
-	if err := s.vacationRepo.UpdateStatus(ctx, requestID, domain.StatusRejected, adminID, reason); err != nil {
-		return nil, dto.ErrInternalErrorWithMessage("failed to reject request")
+	year := LeaveYearOf(settings, startDate)
+	requests, err := s.vacationRepo.ListByUser(ctx, user.ID, nil, &year, "", "")
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("failed to count vacation requests")
 	}
 
-	return s.vacationRepo.GetByID(ctx, requestID)
+	count := 0
+	for _, request := range requests {
+		if request.IsTentative() || request.IsRemote() || (request.IsRejected() && !settings.CountRejectedRequests) {
+			continue
+		}
+		count++
+	}
+	if count >= settings.MaxRequestsPerYear {
+		return dto.ErrMaxRequestsPerYearError(count, settings.MaxRequestsPerYear)
+	}
+	return nil
 }
 
-// GetByID retrieves a vacation request by ID
-func (s *VacationService) GetByID(ctx context.Context, requestID string) (*domain.VacationRequest, error) {
+// validateSubmission checks balance and overlapping requests for a paid leave request entering review
+// Under the warn overlap policy an overlap is allowed and reported by returning true
+func (s *VacationService) validateSubmission(ctx context.Context, settings *domain.Settings, user *domain.User, totalDays int, startDate, endDate string) (bool, error) {
+	if err := checkBalance(settings, user, totalDays); err != nil {
+		return false, err
+	}
+	return s.checkOverlap(ctx, settings, user, domain.RequestTypeVacation, startDate, endDate)
+}
+
+// checkOverlap rejects a request of requestType overlapping another open or approved request of the user
+// of a conflicting type (see Settings.ConflictingTypes)
+// Under the warn overlap policy an overlap is allowed and reported by returning true
+func (s *VacationService) checkOverlap(ctx context.Context, settings *domain.Settings, user *domain.User, requestType domain.RequestType, startDate, endDate string) (bool, error) {
+	hasOverlap, err := s.vacationRepo.HasOverlap(ctx, user.ID, startDate, endDate, settings.OverlapAllowTouching, settings.ConflictingTypes(requestType))
+	if err != nil {
+		return false, dto.ErrInternalErrorWithMessage("failed to check for overlapping requests")
+	}
+	if hasOverlap && settings.OverlapPolicy != domain.OverlapPolicyWarn {
+		return false, dto.ErrOverlappingRequestError()
+	}
+	return hasOverlap, nil
+}
+
+// Cancel cancels a vacation request and returns it as it was before removal
+// Requests still under review are simply deleted; approved requests can be withdrawn
+// until their start date, which credits the deducted days back to the user's balance
+func (s *VacationService) Cancel(ctx context.Context, requestID, userID string) (*domain.VacationRequest, error) {
 	request, err := s.vacationRepo.GetByID(ctx, requestID)
 	if err != nil {
 		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation request")
@@ -274,98 +509,1460 @@ func (s *VacationService) GetByID(ctx context.Context, requestID string) (*domai
 	if request == nil {
 		return nil, dto.ErrNotFoundError("vacation request")
 	}
+
+	// Check ownership
+	if request.UserID != userID {
+		return nil, dto.ErrForbiddenError("you can only cancel your own requests")
+	}
+
+	// Check status
+	if request.IsRejected() {
+		return nil, dto.ErrForbiddenError("cannot cancel rejected request")
+	}
+	if request.IsApproved() {
+		if err := s.cancelApproved(ctx, request); err != nil {
+			return nil, err
+		}
+		return request, nil
+	}
+
+	if err := s.vacationRepo.Delete(ctx, requestID); err != nil {
+		return nil, err
+	}
 	return request, nil
 }
 
-// ListByUser retrieves vacation requests for a user
-func (s *VacationService) ListByUser(ctx context.Context, userID string, status *domain.VacationStatus, year *int) ([]*domain.VacationRequest, error) {
-	requests, err := s.vacationRepo.ListByUser(ctx, userID, status, year)
+// cancelApproved deletes an approved request that has not started yet and refunds
+// the days its approval deducted, recording the credit in the balance ledger
+// Remote days deducted nothing, so they are simply deleted
+func (s *VacationService) cancelApproved(ctx context.Context, request *domain.VacationRequest) error {
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
 	if err != nil {
-		return nil, dto.ErrInternalErrorWithMessage("failed to list vacation requests")
+		return dto.ErrInternalErrorWithMessage("invalid request start date")
 	}
-	return requests, nil
-}
-
-// ListPending retrieves all pending vacation requests (for admin)
-func (s *VacationService) ListPending(ctx context.Context) ([]*domain.VacationRequest, error) {
-	requests, err := s.vacationRepo.ListPending(ctx)
+	settings, err := s.settingsRepo.Get(ctx)
 	if err != nil {
-		return nil, dto.ErrInternalErrorWithMessage("failed to list pending requests")
+		return dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+	if !startDate.After(settings.Today(time.Now())) {
+		return dto.ErrForbiddenError("cannot cancel approved leave that has already started")
 	}
-	return requests, nil
-}
 
-// ListTeam retrieves team vacations for a given month/year
-func (s *VacationService) ListTeam(ctx context.Context, month, year int) ([]*domain.TeamVacation, error) {
-	if month < 1 || month > 12 {
-		return nil, dto.ErrValidationError("month must be between 1 and 12")
+	if request.IsRemote() {
+		if err := s.vacationRepo.Delete(ctx, request.ID); err != nil {
+			return dto.ErrInternalErrorWithMessage("failed to cancel request")
+		}
+		return nil
 	}
-	if year < 2000 || year > 2100 {
-		return nil, dto.ErrValidationError("invalid year")
+
+	user, err := s.userRepo.GetByID(ctx, request.UserID)
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("failed to get user")
+	}
+	if user == nil {
+		return dto.ErrNotFoundError("user")
 	}
 
-	vacations, err := s.vacationRepo.ListTeam(ctx, month, year)
+	refund, err := s.deductedDays(ctx, request)
 	if err != nil {
-		return nil, dto.ErrInternalErrorWithMessage("failed to list team vacations")
+		return dto.ErrInternalErrorWithMessage("failed to get balance history")
 	}
-	return vacations, nil
+
+	// Remove the request and restore the balance atomically in a transaction
+	// The refund is added to the balance as it stands then, so concurrent changes aren't overwritten
+	err = s.transactor.Transaction(func(tx *sql.Tx) error {
+		if err := s.vacationRepo.DeleteTx(ctx, tx, request.ID); err != nil {
+			return err
+		}
+
+		if _, err := s.userRepo.AddVacationBalanceTx(ctx, tx, request.UserID, refund, settings.MinimumBalance()); err != nil {
+			return err
+		}
+
+		// Record the refund in the balance ledger
+		entry := newLedgerEntry(request.UserID, refund, domain.LedgerVacation, &request.ID)
+		return s.ledgerRepo.CreateTx(ctx, tx, entry)
+	})
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("failed to cancel request")
+	}
+
+	return nil
 }
 
-// parseDDMMYYYY parses DD/MM/YYYY format to time.Time
-func parseDDMMYYYY(dateStr string) (time.Time, error) {
-	parts := strings.Split(dateStr, "/")
-	if len(parts) != 3 {
-		return time.Time{}, fmt.Errorf("invalid date format, expected DD/MM/YYYY")
+// deductedDays returns how many days approving the request took from the balance
+// Approval may deduct less than TotalDays when the balance is clamped at the minimum,
+// so the ledger is authoritative; requests approved before the ledger existed fall back to TotalDays
+func (s *VacationService) deductedDays(ctx context.Context, request *domain.VacationRequest) (int, error) {
+	entries, err := s.ledgerRepo.ListByUser(ctx, request.UserID)
+	if err != nil {
+		return 0, err
 	}
 
-	// Validate parts
-	if len(parts[0]) < 1 || len(parts[0]) > 2 {
-		return time.Time{}, fmt.Errorf("invalid day")
+	found := false
+	deducted := 0
+	for _, entry := range entries {
+		if entry.Reason != domain.LedgerVacation || entry.ReferenceID == nil || *entry.ReferenceID != request.ID {
+			continue
+		}
+		found = true
+		deducted -= entry.Delta
 	}
-	if len(parts[1]) < 1 || len(parts[1]) > 2 {
-		return time.Time{}, fmt.Errorf("invalid month")
+	if !found {
+		return request.TotalDays, nil
 	}
-	if len(parts[2]) != 4 {
-		return time.Time{}, fmt.Errorf("invalid year")
+	return deducted, nil
+}
+
+// Approve records an approval for the request's current level in the approval chain
+// Intermediate levels advance the request to the next approver; the final level
+// approves the request and deducts balance atomically using a transaction
+// comment is the approver's note, required when Settings.ApprovalCommentRequired is set
+func (s *VacationService) Approve(ctx context.Context, requestID, adminID string, comment *string) (*domain.VacationRequest, error) {
+	return s.approve(ctx, requestID, adminID, comment, "", nil)
+}
+
+// ApproveWithOverride approves like Approve but skips the balance check, letting the
+// balance go below the configured minimum for this one decision
+// overrideReason is required and is stored on the request when the final approval
+// actually needed the override; intermediate levels only skip the check
+func (s *VacationService) ApproveWithOverride(ctx context.Context, requestID, adminID string, comment *string, overrideReason string) (*domain.VacationRequest, error) {
+	overrideReason = strings.TrimSpace(overrideReason)
+	if overrideReason == "" {
+		return nil, dto.ErrValidationError("a reason is required to override the balance check")
 	}
+	return s.approve(ctx, requestID, adminID, comment, overrideReason, nil)
+}
 
-	// Pad day and month with zeros if needed
-	day := parts[0]
-	if len(day) == 1 {
-		day = "0" + day
+// ApproveWithDates approves a narrower range than submitted, as a counter-offer to the employee
+// startDate and endDate (DD/MM/YYYY) must lie within the requested range; the total is recomputed
+// and only the adjusted amount is deducted, so the employee keeps the difference
+// The submitted range is kept as OriginalStartDate/OriginalEndDate. Dates can only be adjusted
+// at the final approval level; a non-nil overrideReason skips the balance check like ApproveWithOverride
+func (s *VacationService) ApproveWithDates(ctx context.Context, requestID, adminID string, comment *string, startDate, endDate string, overrideReason *string) (*domain.VacationRequest, error) {
+	start, err := parseDDMMYYYY(startDate)
+	if err != nil {
+		return nil, dto.ErrValidationError(fmt.Sprintf("invalid start date format: %v", err))
 	}
-	month := parts[1]
-	if len(month) == 1 {
-		month = "0" + month
+	end, err := parseDDMMYYYY(endDate)
+	if err != nil {
+		return nil, dto.ErrValidationError(fmt.Sprintf("invalid end date format: %v", err))
+	}
+	if end.Before(start) {
+		return nil, dto.ErrValidationError("end date must be after or equal to start date")
 	}
 
-	// Rearrange to YYYY-MM-DD
-	isoDate := fmt.Sprintf("%s-%s-%s", parts[2], month, day)
-	return time.Parse("2006-01-02", isoDate)
+	reason := ""
+	if overrideReason != nil {
+		reason = strings.TrimSpace(*overrideReason)
+		if reason == "" {
+			return nil, dto.ErrValidationError("a reason is required to override the balance check")
+		}
+	}
+	return s.approve(ctx, requestID, adminID, comment, reason, &adjustedRange{start: start, end: end})
 }
 
-// calculateBusinessDays counts business days between two dates
-func calculateBusinessDays(start, end time.Time, policy domain.WeekendPolicy) int {
-	if !policy.ExcludeWeekends {
-		// Include all days
-		return int(end.Sub(start).Hours()/24) + 1
+// adjustedRange is the range an admin approves in place of the submitted one
+type adjustedRange struct {
+	start, end time.Time
+}
+
+// approve implements Approve; a non-empty overrideReason disables the balance guard
+// and a non-nil adjusted range replaces the submitted dates on the final approval
+func (s *VacationService) approve(ctx context.Context, requestID, adminID string, comment *string, overrideReason string, adjusted *adjustedRange) (*domain.VacationRequest, error) {
+	request, err := s.vacationRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation request")
+	}
+	if request == nil {
+		return nil, dto.ErrNotFoundError("vacation request")
 	}
 
-	count := 0
-	current := start
+	if !request.IsUnderReview() {
+		return nil, dto.ErrConflictError("request has already been processed")
+	}
 
-	// Create a map of excluded weekdays for faster lookup
-	excluded := make(map[time.Weekday]bool)
-	for _, day := range policy.ExcludedDays {
-		excluded[time.Weekday(day)] = true
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
 	}
 
-	for !current.After(end) {
-		if !excluded[current.Weekday()] {
-			count++
+	// Enforce the approver assigned to the current level, if any
+	if request.ApprovalStep < len(settings.ApprovalLevels) {
+		step := settings.ApprovalLevels[request.ApprovalStep]
+		if step.ApproverID != nil && *step.ApproverID != adminID {
+			return nil, dto.ErrForbiddenError(fmt.Sprintf("approval level %q is assigned to another approver", step.Name))
 		}
-		current = current.AddDate(0, 0, 1)
 	}
 
-	return count
+	if comment != nil && strings.TrimSpace(*comment) == "" {
+		comment = nil
+	}
+	if settings.ApprovalCommentRequired && comment == nil {
+		return nil, dto.ErrValidationError("an approval comment is required")
+	}
+
+	// Counter-offer: review the adjusted range from here on; the submitted dates are a plain approval
+	adjustDates := false
+	if adjusted != nil {
+		startDate, endDate := adjusted.start.Format("2006-01-02"), adjusted.end.Format("2006-01-02")
+		if startDate != request.StartDate || endDate != request.EndDate {
+			if !settings.IsFinalApprovalStep(request.ApprovalStep) {
+				return nil, dto.ErrValidationError("dates can only be adjusted at the final approval level")
+			}
+			if startDate < request.StartDate || endDate > request.EndDate {
+				return nil, dto.ErrValidationError("adjusted dates must lie within the requested range")
+			}
+			businessDays := calculateBusinessDays(adjusted.start, adjusted.end, settings.WeekendPolicy)
+			if businessDays == 0 {
+				return nil, dto.ErrValidationError("adjusted dates don't cover any working days")
+			}
+
+			narrowed := *request
+			narrowed.StartDate, narrowed.EndDate = startDate, endDate
+			narrowed.TotalDays = chargedDays(settings, businessDays)
+			request = &narrowed
+			adjustDates = true
+		}
+	}
+
+	// Get user to check balance
+	user, err := s.userRepo.GetByID(ctx, request.UserID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get user")
+	}
+	if user == nil {
+		return nil, dto.ErrNotFoundError("user")
+	}
+
+	// Check if user still has enough balance, unless an admin overrides it; remote days deduct nothing
+	var balanceErr error
+	if !request.IsRemote() {
+		balanceErr = checkBalance(settings, user, request.TotalDays)
+	}
+	override := overrideReason != "" && balanceErr != nil
+	if balanceErr != nil && !override {
+		return nil, balanceErr
+	}
+
+	// Intermediate level: hand the request over to the next approver
+	if !settings.IsFinalApprovalStep(request.ApprovalStep) {
+		err := s.vacationRepo.AdvanceApprovalStep(ctx, requestID, request.ApprovalStep+1, domain.StatusAwaitingFinal, adminID, comment)
+		if errors.Is(err, repository.ErrNotUnderReview) {
+			// Another approver acted on this level first
+			return nil, dto.ErrConflictError("request has already been processed")
+		}
+		if err != nil {
+			return nil, dto.ErrInternalErrorWithMessage("failed to approve request")
+		}
+		advanced, err := s.vacationRepo.GetByID(ctx, requestID)
+		if err != nil || advanced == nil {
+			return advanced, err
+		}
+		if !request.IsRemote() {
+			advanced.Warnings = requestWarnings(settings, requestBusinessDays(settings, request), user.VacationBalance-request.TotalDays)
+		}
+		return advanced, nil
+	}
+
+	// Final level: make sure enough employees stay available while this user is off
+	// Remote workers stay available, so remote days skip the check and keep the balance as is
+	if !request.IsRemote() {
+		if err := s.checkCoverage(ctx, settings, request); err != nil {
+			return nil, err
+		}
+	}
+
+	// An override deducts the full request even below the minimum
+	floor := settings.MinimumBalance()
+	if override {
+		floor = math.MinInt
+	}
+	previousBalance, newBalance := user.VacationBalance, user.VacationBalance
+
+	// Execute status update and balance deduction atomically in a transaction
+	err = s.transactor.Transaction(func(tx *sql.Tx) error {
+		// Store the adjusted range first, keeping the submitted one alongside
+		if adjustDates {
+			if err := s.vacationRepo.AdjustDatesTx(ctx, tx, requestID, request.StartDate, request.EndDate, request.TotalDays); err != nil {
+				return err
+			}
+		}
+
+		// Update status
+		if err := s.vacationRepo.UpdateStatusTx(ctx, tx, requestID, domain.StatusApproved, adminID, nil); err != nil {
+			return err
+		}
+
+		// Store the approval comment, keeping any left at an earlier level if none was given
+		if comment != nil {
+			if err := s.vacationRepo.UpdateApprovalCommentTx(ctx, tx, requestID, *comment); err != nil {
+				return err
+			}
+		}
+
+		// Record why the balance check was bypassed
+		if override {
+			if err := s.vacationRepo.UpdateBalanceOverrideTx(ctx, tx, requestID, overrideReason); err != nil {
+				return err
+			}
+		}
+
+		if request.IsRemote() {
+			return nil
+		}
+
+		// Deduct from the balance as it stands now, so concurrent approvals, accruals and refunds aren't overwritten
+		balance, err := s.userRepo.AddVacationBalanceTx(ctx, tx, request.UserID, -request.TotalDays, floor)
+		newBalance = balance
+		if err != nil {
+			return err
+		}
+		previousBalance = balance + request.TotalDays
+
+		// Record the deduction in the balance ledger
+		entry := newLedgerEntry(request.UserID, -request.TotalDays, domain.LedgerVacation, &requestID)
+		if err := s.ledgerRepo.CreateTx(ctx, tx, entry); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, repository.ErrNotUnderReview) {
+		// Lost the race against a concurrent review; nothing was deducted
+		return nil, dto.ErrConflictError("request has already been processed")
+	}
+	if errors.Is(err, repository.ErrBalanceBelowMinimum) {
+		// Another deduction landed since the balance was checked above
+		return nil, dto.ErrInsufficientBalanceError(request.TotalDays, newBalance, string(settings.BalanceUnit))
+	}
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to approve request")
+	}
+
+	// Fetch updated request
+	approved, err := s.vacationRepo.GetByID(ctx, requestID)
+	if err != nil || approved == nil {
+		return approved, err
+	}
+	approved.LowBalanceAlert = lowBalanceAlert(settings, previousBalance, newBalance)
+	if !request.IsRemote() {
+		approved.Warnings = requestWarnings(settings, requestBusinessDays(settings, request), newBalance)
+	}
+	return approved, nil
+}
+
+// RecomputeTotalDays recalculates the total of a request under review with the current settings and stores it
+// Nothing has been deducted for such requests yet, so the balance is left alone
+func (s *VacationService) RecomputeTotalDays(ctx context.Context, requestID string) (*dto.RecomputeTotalDaysResponse, error) {
+	request, err := s.vacationRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation request")
+	}
+	if request == nil {
+		return nil, dto.ErrNotFoundError("vacation request")
+	}
+	if !request.IsUnderReview() {
+		return nil, dto.ErrConflictError("only requests under review can be recomputed")
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	businessDays := requestBusinessDays(settings, request)
+	if businessDays == 0 {
+		return nil, dto.ErrValidationError("request no longer covers any working days")
+	}
+	totalDays := chargedDays(settings, businessDays)
+
+	if totalDays != request.TotalDays {
+		err := s.vacationRepo.UpdateTotalDays(ctx, requestID, totalDays)
+		if errors.Is(err, repository.ErrNotUnderReview) {
+			return nil, dto.ErrConflictError("request has already been processed")
+		}
+		if err != nil {
+			return nil, dto.ErrInternalErrorWithMessage("failed to update vacation request")
+		}
+	}
+
+	return &dto.RecomputeTotalDaysResponse{
+		RequestID:         requestID,
+		PreviousTotalDays: request.TotalDays,
+		NewTotalDays:      totalDays,
+	}, nil
+}
+
+// requestWarnings lists the soft conditions of a request that are reported but never block it:
+// leaving no balance once approved, and lasting longer than Settings.LongVacationDays
+func requestWarnings(settings *domain.Settings, businessDays, balanceAfter int) []string {
+	var warnings []string
+	if balanceAfter <= 0 {
+		warnings = append(warnings, "this request leaves no vacation balance")
+	}
+	if settings.LongVacationDays > 0 && businessDays > settings.LongVacationDays {
+		warnings = append(warnings, fmt.Sprintf("this request spans %d business days, more than the %d of a long vacation", businessDays, settings.LongVacationDays))
+	}
+	return warnings
+}
+
+// requestBusinessDays counts the business days of a stored request under the current weekend policy, or 0 if its dates don't parse
+func requestBusinessDays(settings *domain.Settings, request *domain.VacationRequest) int {
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		return 0
+	}
+	endDate, err := time.Parse("2006-01-02", request.EndDate)
+	if err != nil {
+		return 0
+	}
+	return calculateBusinessDays(startDate, endDate, settings.WeekendPolicy)
+}
+
+// lowBalanceAlert returns the alert for an approval that moved a balance from before to after,
+// or nil if it didn't cross Settings.LowBalanceThreshold
+func lowBalanceAlert(settings *domain.Settings, before, after int) *domain.LowBalanceAlert {
+	if !settings.CrossesLowBalance(before, after) {
+		return nil
+	}
+	return &domain.LowBalanceAlert{Balance: after, Unit: settings.BalanceUnit}
+}
+
+// Reject rejects a request at any level of the approval chain
+func (s *VacationService) Reject(ctx context.Context, requestID, adminID string, reason *string) (*domain.VacationRequest, error) {
+	request, err := s.vacationRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation request")
+	}
+	if request == nil {
+		return nil, dto.ErrNotFoundError("vacation request")
+	}
+
+	if !request.IsUnderReview() {
+		return nil, dto.ErrConflictError("request has already been processed")
+	}
+
+	if err := s.vacationRepo.UpdateStatus(ctx, requestID, domain.StatusRejected, adminID, reason); err != nil {
+		if errors.Is(err, repository.ErrNotUnderReview) {
+			return nil, dto.ErrConflictError("request has already been processed")
+		}
+		return nil, dto.ErrInternalErrorWithMessage("failed to reject request")
+	}
+
+	return s.vacationRepo.GetByID(ctx, requestID)
+}
+
+// ResolveRejectionReason builds the stored rejection reason from a predefined reason code
+// and optional free text; without a code the free text is used as-is
+// An unknown code is a validation error
+func (s *VacationService) ResolveRejectionReason(ctx context.Context, code, text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if code == "" {
+		return text, nil
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return "", dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	label, ok := settings.RejectionReasonLabel(code)
+	if !ok {
+		return "", dto.ErrValidationError(fmt.Sprintf("unknown rejection reason code %q", code))
+	}
+	if text == "" {
+		return label, nil
+	}
+	return label + ": " + text, nil
+}
+
+// defaultSuggestionCount is how many ranges Suggest returns when none is requested
+const defaultSuggestionCount = 3
+
+// maxSuggestionWindowDays bounds how far Suggest scans
+const maxSuggestionWindowDays = 366
+
+// Suggest finds up to req.Count non-overlapping date ranges of req.Days business days
+// within the search window that don't clash with the user's open or approved requests
+func (s *VacationService) Suggest(ctx context.Context, userID string, req dto.SuggestVacationRequest) ([]*dto.VacationSuggestion, error) {
+	from, err := parseDDMMYYYY(req.From)
+	if err != nil {
+		return nil, dto.ErrValidationError(fmt.Sprintf("invalid from date format: %v", err))
+	}
+	to, err := parseDDMMYYYY(req.To)
+	if err != nil {
+		return nil, dto.ErrValidationError(fmt.Sprintf("invalid to date format: %v", err))
+	}
+	if to.Before(from) {
+		return nil, dto.ErrValidationError("to date must be after or equal to from date")
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	// Never suggest dates in the past
+	today := settings.Today(time.Now())
+	if from.Before(today) {
+		from = today
+	}
+	if to.Before(from) {
+		return nil, dto.ErrValidationError("search window is entirely in the past")
+	}
+	if to.Sub(from).Hours()/24 > maxSuggestionWindowDays {
+		return nil, dto.ErrValidationError(fmt.Sprintf("search window cannot exceed %d days", maxSuggestionWindowDays))
+	}
+
+	count := req.Count
+	if count == 0 {
+		count = defaultSuggestionCount
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get user")
+	}
+	if user == nil {
+		return nil, dto.ErrNotFoundError("user")
+	}
+
+	// req.Days counts business days; the balance, and the totals suggested, may be kept in hours
+	totalDays := chargedDays(settings, req.Days)
+	if err := checkBalance(settings, user, totalDays); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.vacationRepo.ListByUser(ctx, userID, nil, nil, "", "")
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list vacation requests")
+	}
+
+	// Collect dates already taken by requests that would block a new one
+	busy := make(map[string]bool)
+	for _, r := range existing {
+		if !r.IsUnderReview() && !r.IsApproved() {
+			continue
+		}
+		start, err := time.Parse("2006-01-02", r.StartDate)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse("2006-01-02", r.EndDate)
+		if err != nil {
+			continue
+		}
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			busy[d.Format("2006-01-02")] = true
+		}
+	}
+	for _, b := range settings.BlackoutPeriods {
+		start, err := time.Parse("2006-01-02", b.StartDate)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse("2006-01-02", b.EndDate)
+		if err != nil {
+			continue
+		}
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			busy[d.Format("2006-01-02")] = true
+		}
+	}
+
+	suggestions := make([]*dto.VacationSuggestion, 0, count)
+	for start := from; !start.After(to) && len(suggestions) < count; start = start.AddDate(0, 0, 1) {
+		// Ranges start on a free business day
+		if busy[start.Format("2006-01-02")] || settings.WeekendPolicy.IsDayExcluded(int(start.Weekday())) {
+			continue
+		}
+
+		end, ok := findRangeEnd(start, to, req.Days, settings.WeekendPolicy, busy)
+		if !ok {
+			continue
+		}
+
+		candidate := &domain.VacationRequest{
+			UserID:    userID,
+			StartDate: start.Format("2006-01-02"),
+			EndDate:   end.Format("2006-01-02"),
+		}
+		if err := s.checkSuggestion(ctx, settings, user, candidate, start); err != nil {
+			if appErr, ok := err.(*dto.AppError); ok && appErr.Code != dto.ErrInternal {
+				continue
+			}
+			return nil, err
+		}
+
+		suggestions = append(suggestions, &dto.VacationSuggestion{
+			StartDate: candidate.StartDate,
+			EndDate:   candidate.EndDate,
+			TotalDays: totalDays,
+		})
+		// Continue after this range so suggestions don't overlap each other
+		start = end
+	}
+
+	return suggestions, nil
+}
+
+// checkSuggestion applies the rules a suggested range would meet once submitted and approved:
+// blackouts, the leave year's request cap and coverage
+func (s *VacationService) checkSuggestion(ctx context.Context, settings *domain.Settings, user *domain.User, candidate *domain.VacationRequest, start time.Time) error {
+	if err := checkBlackout(settings, candidate.StartDate, candidate.EndDate); err != nil {
+		return err
+	}
+	if err := s.checkRequestCap(ctx, settings, user, start); err != nil {
+		return err
+	}
+	return s.checkCoverage(ctx, settings, candidate)
+}
+
+// findRangeEnd walks forward from start until days business days are covered
+// Returns false if a busy date is hit or the range would pass the window end
+func findRangeEnd(start, windowEnd time.Time, days int, policy domain.WeekendPolicy, busy map[string]bool) (time.Time, bool) {
+	counted := 0
+	for d := start; !d.After(windowEnd); d = d.AddDate(0, 0, 1) {
+		if busy[d.Format("2006-01-02")] {
+			return time.Time{}, false
+		}
+		if !policy.IsDayExcluded(int(d.Weekday())) {
+			counted++
+		}
+		if counted == days {
+			return d, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// GetByID retrieves a vacation request by ID
+func (s *VacationService) GetByID(ctx context.Context, requestID string) (*domain.VacationRequest, error) {
+	request, err := s.vacationRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation request")
+	}
+	if request == nil {
+		return nil, dto.ErrNotFoundError("vacation request")
+	}
+	return request, nil
+}
+
+// maxBatchRequestIDs caps GetByIDs so one call can't turn into an unbounded IN clause
+const maxBatchRequestIDs = 100
+
+// GetByIDs retrieves several vacation requests at once for userID, newest first
+// Missing requests and requests the caller may not view (another user's, unless isAdmin) are silently left out
+func (s *VacationService) GetByIDs(ctx context.Context, ids []string, userID string, isAdmin bool) ([]*domain.VacationRequest, error) {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return []*domain.VacationRequest{}, nil
+	}
+	if len(unique) > maxBatchRequestIDs {
+		return nil, dto.ErrValidationError(fmt.Sprintf("at most %d request IDs can be fetched at once", maxBatchRequestIDs))
+	}
+
+	requests, err := s.vacationRepo.GetByIDs(ctx, unique)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation requests")
+	}
+
+	visible := make([]*domain.VacationRequest, 0, len(requests))
+	for _, request := range requests {
+		if isAdmin || request.UserID == userID {
+			visible = append(visible, request)
+		}
+	}
+	return visible, nil
+}
+
+// DayBreakdown classifies each date of a request under the current weekend policy
+// If the policy changed since the request was created, the business days may not add up to TotalDays
+func (s *VacationService) DayBreakdown(ctx context.Context, request *domain.VacationRequest) ([]domain.RequestDay, error) {
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("invalid request start date")
+	}
+	endDate, err := time.Parse("2006-01-02", request.EndDate)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("invalid request end date")
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	return dayBreakdown(startDate, endDate, settings.WeekendPolicy), nil
+}
+
+// maxWorkingDaysRange caps WorkingDays at about a year of dates per call
+const maxWorkingDaysRange = 366
+
+// WorkingDays classifies every date from..to (YYYY-MM-DD, inclusive) under the current weekend policy,
+// the same way Create counts the days of a request
+func (s *VacationService) WorkingDays(ctx context.Context, from, to string) ([]domain.RequestDay, error) {
+	if from == "" || to == "" {
+		return nil, dto.ErrValidationError("from and to dates are required")
+	}
+	if err := validateOverlapRange(from, to); err != nil {
+		return nil, err
+	}
+	fromDate, _ := time.Parse("2006-01-02", from)
+	toDate, _ := time.Parse("2006-01-02", to)
+	if int(toDate.Sub(fromDate).Hours()/24)+1 > maxWorkingDaysRange {
+		return nil, dto.ErrValidationError(fmt.Sprintf("date range cannot span more than %d days", maxWorkingDaysRange))
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	return dayBreakdown(fromDate, toDate, settings.WeekendPolicy), nil
+}
+
+// ListByUser retrieves vacation requests for a user
+// from and to (YYYY-MM-DD) are optional and keep only requests overlapping that range
+func (s *VacationService) ListByUser(ctx context.Context, userID string, status *domain.VacationStatus, year *int, from, to string) ([]*domain.VacationRequest, error) {
+	if err := validateOverlapRange(from, to); err != nil {
+		return nil, err
+	}
+
+	requests, err := s.vacationRepo.ListByUser(ctx, userID, status, year, from, to)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list vacation requests")
+	}
+	return requests, nil
+}
+
+// ListDrafts retrieves a user's tentative requests that have not been submitted yet
+func (s *VacationService) ListDrafts(ctx context.Context, userID string) ([]*domain.VacationRequest, error) {
+	status := domain.StatusTentative
+	requests, err := s.vacationRepo.ListByUser(ctx, userID, &status, nil, "", "")
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list draft requests")
+	}
+	return requests, nil
+}
+
+// ListPending retrieves all pending vacation requests (for admin), optionally limited to those overlapping from..to
+func (s *VacationService) ListPending(ctx context.Context, from, to string) ([]*domain.VacationRequest, error) {
+	if err := validateOverlapRange(from, to); err != nil {
+		return nil, err
+	}
+
+	requests, err := s.vacationRepo.ListPending(ctx, from, to)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list pending requests")
+	}
+	return requests, nil
+}
+
+// DefaultStaleDays is how long a request may await a decision before it counts as stale
+const DefaultStaleDays = 7
+
+// ListStale retrieves requests that have been awaiting a decision for more than days days as of now, oldest first
+func (s *VacationService) ListStale(ctx context.Context, now time.Time, days int) ([]*domain.VacationRequest, error) {
+	if days < 1 {
+		return nil, dto.ErrValidationError("days must be at least 1")
+	}
+
+	requests, err := s.vacationRepo.ListStalePending(ctx, now.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list stale requests")
+	}
+	return requests, nil
+}
+
+// ListPendingForManager retrieves requests awaiting review from a manager's direct reports
+func (s *VacationService) ListPendingForManager(ctx context.Context, managerID, from, to string) ([]*domain.VacationRequest, error) {
+	reports, err := s.userRepo.ListByManager(ctx, managerID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list direct reports")
+	}
+	reportIDs := make(map[string]bool, len(reports))
+	for _, report := range reports {
+		reportIDs[report.ID] = true
+	}
+
+	requests, err := s.ListPending(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	scoped := make([]*domain.VacationRequest, 0, len(requests))
+	for _, req := range requests {
+		if reportIDs[req.UserID] {
+			scoped = append(scoped, req)
+		}
+	}
+	return scoped, nil
+}
+
+// EnsureManagerOf checks that managerID is the direct manager of the request's owner
+func (s *VacationService) EnsureManagerOf(ctx context.Context, managerID, requestID string) error {
+	request, err := s.vacationRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("failed to get vacation request")
+	}
+	if request == nil {
+		return dto.ErrNotFoundError("vacation request")
+	}
+
+	owner, err := s.userRepo.GetByID(ctx, request.UserID)
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("failed to get user")
+	}
+	if owner == nil || owner.ManagerID == nil || *owner.ManagerID != managerID {
+		return dto.ErrForbiddenError("you can only review requests from your direct reports")
+	}
+
+	return nil
+}
+
+// TeamScope resolves which team's calendar a user sees
+// Users default to their own team (company-wide if unassigned); only admins may pick another team
+func (s *VacationService) TeamScope(ctx context.Context, userID string, isAdmin bool, requestedTeamID string) (string, error) {
+	if requestedTeamID != "" {
+		if !isAdmin {
+			return "", dto.ErrForbiddenError("only admins can view other teams")
+		}
+		return requestedTeamID, nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", dto.ErrInternalErrorWithMessage("failed to get user")
+	}
+	if user == nil {
+		return "", dto.ErrNotFoundError("user")
+	}
+	if user.TeamID == nil {
+		return "", nil
+	}
+	return *user.TeamID, nil
+}
+
+// ListTeam retrieves team vacations for a given month/year
+// An empty teamID lists vacations across the whole company; includePending adds requests still under review
+func (s *VacationService) ListTeam(ctx context.Context, month, year int, teamID string, includePending bool) ([]*domain.TeamVacation, error) {
+	if month < 1 || month > 12 {
+		return nil, dto.ErrValidationError("month must be between 1 and 12")
+	}
+	if year < 2000 || year > 2100 {
+		return nil, dto.ErrValidationError("invalid year")
+	}
+
+	var vacations []*domain.TeamVacation
+	var err error
+	if includePending {
+		vacations, err = s.vacationRepo.ListTeamWithPending(ctx, month, year, teamID)
+	} else {
+		vacations, err = s.vacationRepo.ListTeam(ctx, month, year, teamID)
+	}
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list team vacations")
+	}
+	return vacations, nil
+}
+
+// ListBlackouts returns the configured blackout periods
+func (s *VacationService) ListBlackouts(ctx context.Context) ([]domain.BlackoutPeriod, error) {
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+	if settings.BlackoutPeriods == nil {
+		return []domain.BlackoutPeriod{}, nil
+	}
+	return settings.BlackoutPeriods, nil
+}
+
+// AddBlackout adds a blackout period where new leave is disallowed
+func (s *VacationService) AddBlackout(ctx context.Context, req dto.CreateBlackoutRequest) (*domain.BlackoutPeriod, error) {
+	startDate, err := parseDDMMYYYY(req.StartDate)
+	if err != nil {
+		return nil, dto.ErrValidationError(fmt.Sprintf("invalid start date format: %v", err))
+	}
+	endDate, err := parseDDMMYYYY(req.EndDate)
+	if err != nil {
+		return nil, dto.ErrValidationError(fmt.Sprintf("invalid end date format: %v", err))
+	}
+	if endDate.Before(startDate) {
+		return nil, dto.ErrValidationError("end date must be after or equal to start date")
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	blackout := domain.BlackoutPeriod{
+		ID:        uuid.New().String(),
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Reason:    req.Reason,
+	}
+	settings.BlackoutPeriods = append(settings.BlackoutPeriods, blackout)
+
+	if err := s.settingsRepo.Update(ctx, settings); err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to save blackout period")
+	}
+	return &blackout, nil
+}
+
+// DeleteBlackout removes a blackout period
+// Existing requests inside the period are unaffected
+func (s *VacationService) DeleteBlackout(ctx context.Context, id string) error {
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	remaining := make([]domain.BlackoutPeriod, 0, len(settings.BlackoutPeriods))
+	for _, b := range settings.BlackoutPeriods {
+		if b.ID != id {
+			remaining = append(remaining, b)
+		}
+	}
+	if len(remaining) == len(settings.BlackoutPeriods) {
+		return dto.ErrNotFoundError("blackout period")
+	}
+
+	settings.BlackoutPeriods = remaining
+	if err := s.settingsRepo.Update(ctx, settings); err != nil {
+		return dto.ErrInternalErrorWithMessage("failed to delete blackout period")
+	}
+	return nil
+}
+
+// maxGanttRangeDays bounds the range a Gantt chart can cover
+const maxGanttRangeDays = 366
+
+// Gantt lays out approved team vacations between from and to (YYYY-MM-DD, inclusive) as chart rows
+// An empty teamID charts the whole company. Each segment is clipped to the range and positioned as a fraction of it
+func (s *VacationService) Gantt(ctx context.Context, from, to, teamID string) (*dto.GanttResponse, error) {
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, dto.ErrValidationError("invalid from date, expected YYYY-MM-DD")
+	}
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return nil, dto.ErrValidationError("invalid to date, expected YYYY-MM-DD")
+	}
+	if toDate.Before(fromDate) {
+		return nil, dto.ErrValidationError("to date must be after or equal to from date")
+	}
+
+	rangeDays := int(toDate.Sub(fromDate).Hours()/24) + 1
+	if rangeDays > maxGanttRangeDays {
+		return nil, dto.ErrValidationError(fmt.Sprintf("range cannot exceed %d days", maxGanttRangeDays))
+	}
+
+	vacations, err := s.vacationRepo.ListTeamRange(ctx, from, to, teamID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list team vacations")
+	}
+
+	members := make([]*dto.GanttMember, 0)
+	byUser := make(map[string]*dto.GanttMember)
+	for _, v := range vacations {
+		start, err := time.Parse("2006-01-02", v.StartDate)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse("2006-01-02", v.EndDate)
+		if err != nil {
+			continue
+		}
+		if start.Before(fromDate) {
+			start = fromDate
+		}
+		if end.After(toDate) {
+			end = toDate
+		}
+
+		member, ok := byUser[v.UserID]
+		if !ok {
+			member = &dto.GanttMember{
+				UserID:   v.UserID,
+				UserName: v.UserName,
+				Segments: []*dto.GanttSegment{},
+			}
+			byUser[v.UserID] = member
+			members = append(members, member)
+		}
+
+		offsetDays := int(start.Sub(fromDate).Hours() / 24)
+		widthDays := int(end.Sub(start).Hours()/24) + 1
+		member.Segments = append(member.Segments, &dto.GanttSegment{
+			ID:        v.ID,
+			StartDate: v.StartDate,
+			EndDate:   v.EndDate,
+			TotalDays: v.TotalDays,
+			Type:      v.Type,
+			Offset:    float64(offsetDays) / float64(rangeDays),
+			Width:     float64(widthDays) / float64(rangeDays),
+		})
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].UserName < members[j].UserName
+	})
+
+	return &dto.GanttResponse{
+		From:    from,
+		To:      to,
+		Days:    rangeDays,
+		Members: members,
+	}, nil
+}
+
+// TeamWeeks groups a month of approved team vacations into ISO weeks, listing who is off on each business day
+func (s *VacationService) TeamWeeks(ctx context.Context, month, year int, teamID string) (*dto.TeamWeeksResponse, error) {
+	vacations, err := s.ListTeam(ctx, month, year, teamID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	sort.SliceStable(vacations, func(i, j int) bool {
+		return vacations[i].UserName < vacations[j].UserName
+	})
+
+	resp := &dto.TeamWeeksResponse{
+		Month:  month,
+		Year:   year,
+		TeamID: teamID,
+		Weeks:  []*dto.TeamWeek{},
+	}
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	var week *dto.TeamWeek
+	for current := first; current.Month() == first.Month(); current = current.AddDate(0, 0, 1) {
+		isoYear, isoWeek := current.ISOWeek()
+		if week == nil || week.Year != isoYear || week.Week != isoWeek {
+			week = &dto.TeamWeek{Year: isoYear, Week: isoWeek, Days: []*dto.TeamWeekDay{}}
+			resp.Weeks = append(resp.Weeks, week)
+		}
+
+		day := &dto.TeamWeekDay{
+			Date:        current.Format("2006-01-02"),
+			BusinessDay: !settings.WeekendPolicy.IsDayExcluded(int(current.Weekday())),
+			Off:         []*dto.TeamWeekAbsence{},
+		}
+		if day.BusinessDay {
+			for _, v := range vacations {
+				if v.Type == domain.RequestTypeRemote {
+					continue
+				}
+				if v.StartDate <= day.Date && v.EndDate >= day.Date {
+					day.Off = append(day.Off, &dto.TeamWeekAbsence{
+						UserID:    v.UserID,
+						UserName:  v.UserName,
+						RequestID: v.ID,
+					})
+				}
+			}
+		}
+		week.Days = append(week.Days, day)
+	}
+
+	return resp, nil
+}
+
+// maxCoverageRangeDays bounds the range a coverage check can cover
+const maxCoverageRangeDays = 366
+
+// CoverageForRange lists, for each date between start and end (YYYY-MM-DD, inclusive),
+// the employees with approved leave; a non-empty teamID limits it to that team
+func (s *VacationService) CoverageForRange(ctx context.Context, start, end, teamID string) (*dto.CoverageResponse, error) {
+	startDate, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		return nil, dto.ErrValidationError("invalid from date, expected YYYY-MM-DD")
+	}
+	endDate, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		return nil, dto.ErrValidationError("invalid to date, expected YYYY-MM-DD")
+	}
+	if endDate.Before(startDate) {
+		return nil, dto.ErrValidationError("to date must be after or equal to from date")
+	}
+	if int(endDate.Sub(startDate).Hours()/24)+1 > maxCoverageRangeDays {
+		return nil, dto.ErrValidationError(fmt.Sprintf("range cannot exceed %d days", maxCoverageRangeDays))
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	vacations, err := s.vacationRepo.ListTeamRange(ctx, start, end, teamID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list team vacations")
+	}
+
+	resp := &dto.CoverageResponse{
+		From:   start,
+		To:     end,
+		TeamID: teamID,
+		Days:   []*dto.CoverageDay{},
+	}
+	for current := startDate; !current.After(endDate); current = current.AddDate(0, 0, 1) {
+		date := current.Format("2006-01-02")
+		names := absentOn(vacations, date, "")
+		resp.Days = append(resp.Days, &dto.CoverageDay{
+			Date:        date,
+			BusinessDay: !settings.WeekendPolicy.IsDayExcluded(int(current.Weekday())),
+			Count:       len(names),
+			Names:       names,
+		})
+	}
+
+	return resp, nil
+}
+
+// checkCoverage ensures approving request leaves at least Settings.MinCoverage people
+// available on each of its business days; 0 disables the check
+// Coverage is company-wide: the headcount is every active user, whatever their role, the same people
+// whose approved leave ListTeamRange reports, so an admin requester counts on both sides
+func (s *VacationService) checkCoverage(ctx context.Context, settings *domain.Settings, request *domain.VacationRequest) error {
+	if settings.MinCoverage <= 0 {
+		return nil
+	}
+
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("invalid request start date")
+	}
+	endDate, err := time.Parse("2006-01-02", request.EndDate)
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("invalid request end date")
+	}
+
+	headcount, err := s.userRepo.CountActive(ctx, "")
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("failed to count active users")
+	}
+
+	vacations, err := s.vacationRepo.ListTeamRange(ctx, request.StartDate, request.EndDate, "")
+	if err != nil {
+		return dto.ErrInternalErrorWithMessage("failed to list team vacations")
+	}
+
+	for current := startDate; !current.After(endDate); current = current.AddDate(0, 0, 1) {
+		if settings.WeekendPolicy.IsDayExcluded(int(current.Weekday())) {
+			continue
+		}
+		date := current.Format("2006-01-02")
+		off := len(absentOn(vacations, date, request.UserID)) + 1 // Including the requester
+		if available := headcount - off; available < settings.MinCoverage {
+			return dto.ErrCoverageExceededError(date, off, available, settings.MinCoverage)
+		}
+	}
+	return nil
+}
+
+// absentOn returns the sorted names of users whose vacation covers date (YYYY-MM-DD), skipping excludeUserID
+// Remote days don't make anyone unavailable and are ignored
+func absentOn(vacations []*domain.TeamVacation, date, excludeUserID string) []string {
+	names := []string{}
+	seen := make(map[string]bool)
+	for _, v := range vacations {
+		if v.UserID == excludeUserID || seen[v.UserID] || v.Type == domain.RequestTypeRemote {
+			continue
+		}
+		if v.StartDate <= date && v.EndDate >= date {
+			seen[v.UserID] = true
+			names = append(names, v.UserName)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LeaveYearOf returns the leave year containing date, named after the calendar year it starts in
+// With VacationResetMonth=4, 2028-03-15 falls in leave year 2027; the default reset month of 1 gives calendar years
+func LeaveYearOf(settings *domain.Settings, date time.Time) int {
+	return settings.LeaveYearStart(date).Year()
+}
+
+// CurrentLeaveYear returns the leave year containing today in the configured time zone
+func (s *VacationService) CurrentLeaveYear(ctx context.Context, now time.Time) (int, error) {
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return 0, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+	return LeaveYearOf(settings, settings.Today(now)), nil
+}
+
+// Statement assembles a user's leave entitlement statement for a leave year from the balance ledger
+// Figures satisfy opening + grants - taken + carryover = closing
+func (s *VacationService) Statement(ctx context.Context, userID string, year int) (*dto.LeaveStatementResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get user")
+	}
+	if user == nil {
+		return nil, dto.ErrNotFoundError("user")
+	}
+
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get settings")
+	}
+
+	entries, err := s.ledgerRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list ledger entries")
+	}
+
+	statement := &dto.LeaveStatementResponse{
+		UserID:   user.ID,
+		UserName: user.Name,
+		Year:     year,
+		Requests: []*dto.VacationRequestResponse{},
+	}
+
+	for _, entry := range entries {
+		entryYear := LeaveYearOf(settings, entry.CreatedAt)
+		if entryYear < year {
+			statement.OpeningBalance += entry.Delta
+			continue
+		}
+		if entryYear > year {
+			continue
+		}
+
+		switch entry.Reason {
+		case domain.LedgerOpening, domain.LedgerAdjustment, domain.LedgerAccrual:
+			statement.Grants += entry.Delta
+		case domain.LedgerVacation:
+			statement.Taken -= entry.Delta
+		case domain.LedgerReset:
+			statement.Carryover += entry.Delta
+		}
+	}
+	statement.ClosingBalance = statement.OpeningBalance + statement.Grants - statement.Taken + statement.Carryover
+
+	approved := domain.StatusApproved
+	requests, err := s.vacationRepo.ListByUser(ctx, userID, &approved, &year, "", "")
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list vacation requests")
+	}
+	for _, req := range requests {
+		if req.IsRemote() {
+			continue
+		}
+		statement.Requests = append(statement.Requests, dto.ToVacationRequestResponse(req))
+	}
+
+	return statement, nil
+}
+
+// AnnualReport assembles a user's vacation report for requests starting in the given year
+func (s *VacationService) AnnualReport(ctx context.Context, userID string, year int) (*dto.AnnualReportResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get user")
+	}
+	if user == nil {
+		return nil, dto.ErrNotFoundError("user")
+	}
+
+	stats, err := s.vacationRepo.GetUserYearStats(ctx, userID, year)
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to get vacation stats")
+	}
+
+	report := &dto.AnnualReportResponse{
+		UserID:           user.ID,
+		UserName:         user.Name,
+		Year:             year,
+		TotalRequested:   stats.TotalRequested,
+		Approved:         stats.TotalApproved,
+		Rejected:         stats.TotalRejected,
+		Pending:          stats.TotalPending,
+		DaysUsed:         stats.TotalDaysUsed,
+		RemainingBalance: user.VacationBalance,
+		Months:           make([]dto.MonthUsageResponse, 12),
+		Requests:         []*dto.VacationRequestResponse{},
+	}
+	for i, days := range stats.DaysByMonth {
+		report.Months[i] = dto.MonthUsageResponse{Month: i + 1, DaysUsed: days}
+	}
+
+	approved := domain.StatusApproved
+	requests, err := s.vacationRepo.ListByUser(ctx, userID, &approved, &year, "", "")
+	if err != nil {
+		return nil, dto.ErrInternalErrorWithMessage("failed to list vacation requests")
+	}
+	for _, req := range requests {
+		if req.IsRemote() {
+			continue
+		}
+		report.Requests = append(report.Requests, dto.ToVacationRequestResponse(req))
+	}
+
+	return report, nil
+}
+
+// AccrueMonthly credits AccrualDaysPerMonth to every active employee, capped at DefaultVacationDays
+// The month is claimed in the same transaction as the credits so each month is credited only once
+func (s *VacationService) AccrueMonthly(ctx context.Context, now time.Time) (int, error) {
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get settings: %w", err)
+	}
+	if !settings.AccrualEnabled || settings.AccrualDaysPerMonth <= 0 {
+		return 0, nil
+	}
+
+	month := now.Format("2006-01")
+	if settings.LastAccrualMonth >= month {
+		return 0, nil
+	}
+
+	employees, err := s.userRepo.GetByRole(ctx, domain.RoleEmployee)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get employees: %w", err)
+	}
+
+	credited := 0
+	err = s.transactor.Transaction(func(tx *sql.Tx) error {
+		claimed, err := s.settingsRepo.ClaimAccrualMonthTx(ctx, tx, month)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return nil
+		}
+
+		amount := settings.DaysToBalance(settings.AccrualDaysPerMonth)
+		maxBalance := settings.DaysToBalance(settings.DefaultVacationDays)
+		for _, employee := range employees {
+			if !employee.Active {
+				continue
+			}
+			// The balance is read and raised inside the transaction, and approvals, refunds and adjustments
+			// also change it relative to its current value, so neither side overwrites the other
+			delta, err := s.userRepo.CreditVacationBalanceTx(ctx, tx, employee.ID, amount, maxBalance)
+			if err != nil {
+				return err
+			}
+			if delta <= 0 {
+				continue
+			}
+
+			if err := s.ledgerRepo.CreateTx(ctx, tx, newLedgerEntry(employee.ID, delta, domain.LedgerAccrual, nil)); err != nil {
+				return err
+			}
+			credited++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to accrue balances: %w", err)
+	}
+
+	return credited, nil
+}
+
+// validateOverlapRange checks optional from/to list filters (YYYY-MM-DD)
+func validateOverlapRange(from, to string) error {
+	var fromDate, toDate time.Time
+	var err error
+	if from != "" {
+		if fromDate, err = time.Parse("2006-01-02", from); err != nil {
+			return dto.ErrValidationError("invalid from date, expected YYYY-MM-DD")
+		}
+	}
+	if to != "" {
+		if toDate, err = time.Parse("2006-01-02", to); err != nil {
+			return dto.ErrValidationError("invalid to date, expected YYYY-MM-DD")
+		}
+	}
+	if from != "" && to != "" && toDate.Before(fromDate) {
+		return dto.ErrValidationError("to date must be after or equal to from date")
+	}
+	return nil
+}
+
+// parseDDMMYYYY parses DD/MM/YYYY format to time.Time
+func parseDDMMYYYY(dateStr string) (time.Time, error) {
+	parts := strings.Split(dateStr, "/")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("invalid date format, expected DD/MM/YYYY")
+	}
+
+	// Validate parts
+	if len(parts[0]) < 1 || len(parts[0]) > 2 {
+		return time.Time{}, fmt.Errorf("invalid day")
+	}
+	if len(parts[1]) < 1 || len(parts[1]) > 2 {
+		return time.Time{}, fmt.Errorf("invalid month")
+	}
+	if len(parts[2]) != 4 {
+		return time.Time{}, fmt.Errorf("invalid year")
+	}
+
+	// Pad day and month with zeros if needed
+	day := parts[0]
+	if len(day) == 1 {
+		day = "0" + day
+	}
+	month := parts[1]
+	if len(month) == 1 {
+		month = "0" + month
+	}
+
+	// Rearrange to YYYY-MM-DD
+	isoDate := fmt.Sprintf("%s-%s-%s", parts[2], month, day)
+	return time.Parse("2006-01-02", isoDate)
+}
+
+// calculateBusinessDays counts business days between two dates
+// Days excluded by the weekend policy (e.g. Fri/Sat for some regions) are skipped
+func calculateBusinessDays(start, end time.Time, policy domain.WeekendPolicy) int {
+	count := 0
+	for current := start; !current.After(end); current = current.AddDate(0, 0, 1) {
+		if classifyDay(current, policy) == domain.DayBusiness {
+			count++
+		}
+	}
+	return count
+}
+
+// dayBreakdown lists every date from start to end inclusive with its classification
+// The business days are exactly those counted by calculateBusinessDays
+func dayBreakdown(start, end time.Time, policy domain.WeekendPolicy) []domain.RequestDay {
+	var days []domain.RequestDay
+	for current := start; !current.After(end); current = current.AddDate(0, 0, 1) {
+		days = append(days, domain.RequestDay{
+			Date: current.Format("2006-01-02"),
+			Type: classifyDay(current, policy),
+		})
+	}
+	return days
+}
+
+// classifyDay reports whether a date counts against the vacation balance under the weekend policy
+func classifyDay(date time.Time, policy domain.WeekendPolicy) domain.DayType {
+	if policy.IsDayExcluded(int(date.Weekday())) {
+		return domain.DayWeekend
+	}
+	return domain.DayBusiness
 }
@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"math"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,6 +15,8 @@ import (
 
 	"vacaytracker-api/internal/domain"
 	"vacaytracker-api/internal/dto"
+	"vacaytracker-api/internal/repository"
+	"vacaytracker-api/internal/repository/sqlite"
 	"vacaytracker-api/internal/service"
 	"vacaytracker-api/internal/testutil"
 )
@@ -28,6 +33,7 @@ func newTestEmployee(id string, balance int) *domain.User {
 		Name:            "Test Employee",
 		Role:            domain.RoleEmployee,
 		VacationBalance: balance,
+		Active:          true,
 	}
 }
 
@@ -39,6 +45,7 @@ func newTestAdmin(id string, balance int) *domain.User {
 		Name:            "Test Admin",
 		Role:            domain.RoleAdmin,
 		VacationBalance: balance,
+		Active:          true,
 	}
 }
 
@@ -97,6 +104,7 @@ type serviceDeps struct {
 	vacationRepo *testutil.MockVacationRepository
 	userRepo     *testutil.MockUserRepository
 	settingsRepo *testutil.MockSettingsRepository
+	ledgerRepo   *testutil.MockLedgerRepository
 	transactor   *testutil.MockTransactor
 }
 
@@ -104,13 +112,23 @@ func newServiceBundle() *serviceDeps {
 	vr := &testutil.MockVacationRepository{}
 	ur := &testutil.MockUserRepository{}
 	sr := &testutil.MockSettingsRepository{}
+	lr := &testutil.MockLedgerRepository{}
 	tx := &testutil.MockTransactor{}
-	svc := service.NewVacationService(vr, ur, sr, tx)
+	svc := service.NewVacationService(vr, ur, sr, lr, tx)
+	// Relative balance updates apply to whatever balance GetByIDFn returns, unless a test overrides them
+	ur.AddVacationBalanceTxFn = func(ctx context.Context, _ *sql.Tx, id string, delta, _ int) (int, error) {
+		user, err := ur.GetByID(ctx, id)
+		if err != nil || user == nil {
+			return 0, err
+		}
+		return user.VacationBalance + delta, nil
+	}
 	return &serviceDeps{
 		svc:          svc,
 		vacationRepo: vr,
 		userRepo:     ur,
 		settingsRepo: sr,
+		ledgerRepo:   lr,
 		transactor:   tx,
 	}
 }
@@ -131,7 +149,7 @@ func TestCreate_EmployeeCreatesPendingRequest(t *testing.T) {
 		}
 		return nil, nil
 	}
-	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string) (bool, error) {
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
 		return false, nil
 	}
 	var createdReq *domain.VacationRequest
@@ -162,6 +180,176 @@ func TestCreate_EmployeeCreatesPendingRequest(t *testing.T) {
 	assert.Nil(t, result.Reason)
 }
 
+func TestCreate_IncludesDayBreakdown(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	employee := newTestEmployee(userID, 20)
+
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return employee, nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateFn = func(_ context.Context, req *domain.VacationRequest) error {
+		createdReq = req
+		return nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
+
+	// 18/06/2027 is Friday, 21/06/2027 is Monday => 2 business days around a weekend
+	result, err := d.svc.Create(ctx, userID, dto.CreateVacationRequest{
+		StartDate: "18/06/2027",
+		EndDate:   "21/06/2027",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.TotalDays)
+	assert.Equal(t, []domain.RequestDay{
+		{Date: "2027-06-18", Type: domain.DayBusiness},
+		{Date: "2027-06-19", Type: domain.DayWeekend},
+		{Date: "2027-06-20", Type: domain.DayWeekend},
+		{Date: "2027-06-21", Type: domain.DayBusiness},
+	}, result.DayBreakdown)
+}
+
+func TestDayBreakdown_UsesWeekendPolicy(t *testing.T) {
+	d := newServiceBundle()
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		settings := domain.DefaultSettings()
+		settings.WeekendPolicy = domain.WeekendPolicy{ExcludeWeekends: true, ExcludedDays: []int{5, 6}} // Fri-Sat weekend
+		return &settings, nil
+	}
+
+	days, err := d.svc.DayBreakdown(context.Background(), &domain.VacationRequest{
+		StartDate: "2027-06-17", // Thursday
+		EndDate:   "2027-06-20", // Sunday
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.RequestDay{
+		{Date: "2027-06-17", Type: domain.DayBusiness},
+		{Date: "2027-06-18", Type: domain.DayWeekend},
+		{Date: "2027-06-19", Type: domain.DayWeekend},
+		{Date: "2027-06-20", Type: domain.DayBusiness},
+	}, days)
+}
+
+func TestWorkingDays_UsesWeekendPolicy(t *testing.T) {
+	d := newServiceBundle()
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		settings := domain.DefaultSettings()
+		settings.WeekendPolicy = domain.WeekendPolicy{ExcludeWeekends: true, ExcludedDays: []int{5, 6}} // Fri-Sat weekend
+		return &settings, nil
+	}
+
+	days, err := d.svc.WorkingDays(context.Background(), "2027-06-17", "2027-06-19")
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.RequestDay{
+		{Date: "2027-06-17", Type: domain.DayBusiness},
+		{Date: "2027-06-18", Type: domain.DayWeekend},
+		{Date: "2027-06-19", Type: domain.DayWeekend},
+	}, days)
+}
+
+func TestWorkingDays_ValidatesRange(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	days, err := d.svc.WorkingDays(ctx, "2027-01-01", "2027-12-31")
+	require.NoError(t, err)
+	assert.Len(t, days, 365)
+
+	for _, tt := range []struct{ from, to string }{
+		{"", "2027-06-19"},
+		{"2027-06-17", ""},
+		{"17/06/2027", "2027-06-19"},
+		{"2027-06-19", "2027-06-17"},
+		{"2027-01-01", "2028-01-02"}, // 367 days
+	} {
+		_, err := d.svc.WorkingDays(ctx, tt.from, tt.to)
+		assertVacationAppError(t, err, dto.ErrValidation)
+	}
+}
+
+func TestCreate_WarnsWhenLeavingNoBalance(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 5), nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateFn = func(_ context.Context, req *domain.VacationRequest) error {
+		createdReq = req
+		return nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
+
+	// 5 business days use up the whole balance, which is allowed but worth flagging
+	result, err := d.svc.Create(ctx, "emp-1", dto.CreateVacationRequest{
+		StartDate: "14/06/2027",
+		EndDate:   "18/06/2027",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusPending, result.Status)
+	assert.Equal(t, []string{"this request leaves no vacation balance"}, result.Warnings)
+}
+
+func TestCreate_MaxRequestsPerYear(t *testing.T) {
+	newBundle := func(countRejected bool) *serviceDeps {
+		d := newServiceBundle()
+		d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+			settings := domain.DefaultSettings()
+			settings.MaxRequestsPerYear = 2
+			settings.CountRejectedRequests = countRejected
+			return &settings, nil
+		}
+		d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+			return newTestEmployee("emp-1", 20), nil
+		}
+		d.vacationRepo.ListByUserFn = func(_ context.Context, _ string, status *domain.VacationStatus, year *int, _, _ string) ([]*domain.VacationRequest, error) {
+			assert.Nil(t, status)
+			require.NotNil(t, year)
+			assert.Equal(t, 2027, *year, "counted in the leave year the request starts in")
+			rejected := newPendingRequest("req-2", "emp-1", 2)
+			rejected.Status = domain.StatusRejected
+			draft := newPendingRequest("req-3", "emp-1", 2)
+			draft.Status = domain.StatusTentative
+			return []*domain.VacationRequest{newApprovedRequest("req-1", "emp-1", 3), rejected, draft}, nil
+		}
+		var createdReq *domain.VacationRequest
+		d.vacationRepo.CreateFn = func(_ context.Context, req *domain.VacationRequest) error {
+			createdReq = req
+			return nil
+		}
+		d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+			return createdReq, nil
+		}
+		return d
+	}
+	req := dto.CreateVacationRequest{StartDate: "14/06/2027", EndDate: "18/06/2027"}
+
+	t.Run("rejected and tentative requests don't count", func(t *testing.T) {
+		_, err := newBundle(false).svc.Create(context.Background(), "emp-1", req)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejected requests count when configured", func(t *testing.T) {
+		_, err := newBundle(true).svc.Create(context.Background(), "emp-1", req)
+		var appErr *dto.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, dto.ErrValidation, appErr.Code)
+		assert.Equal(t, 2, appErr.Details["requests"])
+		assert.Equal(t, 2, appErr.Details["maxRequestsPerYear"])
+	})
+}
+
 func TestCreate_EmployeeWithReason(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
@@ -174,7 +362,7 @@ func TestCreate_EmployeeWithReason(t *testing.T) {
 		}
 		return nil, nil
 	}
-	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string) (bool, error) {
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
 		return false, nil
 	}
 	var createdReq *domain.VacationRequest
@@ -213,7 +401,7 @@ func TestCreate_AdminAutoApproves(t *testing.T) {
 		}
 		return nil, nil
 	}
-	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string) (bool, error) {
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
 		return false, nil
 	}
 
@@ -223,11 +411,12 @@ func TestCreate_AdminAutoApproves(t *testing.T) {
 		createdReq = req
 		return nil
 	}
-	d.userRepo.UpdateVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, id string, balance int) error {
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, id string, delta, floor int) (int, error) {
 		assert.Equal(t, adminID, id)
-		assert.Equal(t, 15, balance) // 20 - 5
+		assert.Equal(t, -5, delta)
+		assert.Equal(t, 0, floor)
 		balanceUpdated = true
-		return nil
+		return 20 + delta, nil
 	}
 	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
 		if createdReq != nil && createdReq.ID == id {
@@ -301,6 +490,52 @@ func TestCreate_StartInPast(t *testing.T) {
 	assert.Contains(t, err.Error(), "start date cannot be in the past")
 }
 
+func TestCreate_TodayFollowsConfiguredTimezone(t *testing.T) {
+	// Pago Pago (UTC-11) may still be on the previous UTC day, Kiritimati (UTC+14) on the next
+	// Every day counts so the local date never falls on a weekend
+	everyDay := domain.WeekendPolicy{ExcludeWeekends: false}
+	localToday := func(zone string) time.Time {
+		loc, err := time.LoadLocation(zone)
+		require.NoError(t, err)
+		year, month, day := time.Now().In(loc).Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		zone    string
+		start   time.Time
+		wantErr bool
+	}{
+		{"local today behind UTC", "Pacific/Pago_Pago", localToday("Pacific/Pago_Pago"), false},
+		{"local yesterday ahead of UTC", "Pacific/Kiritimati", localToday("Pacific/Kiritimati").AddDate(0, 0, -1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newServiceBundle()
+			settings := domain.DefaultSettings()
+			settings.Timezone = tt.zone
+			settings.WeekendPolicy = everyDay
+			d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+				return &settings, nil
+			}
+			d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+				return newTestEmployee("emp-1", 20), nil
+			}
+
+			date := tt.start.Format("02/01/2006")
+			_, err := d.svc.Create(context.Background(), "emp-1", dto.CreateVacationRequest{StartDate: date, EndDate: date})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "start date cannot be in the past")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestCreate_ZeroBusinessDays_WeekendOnly(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
@@ -317,6 +552,27 @@ func TestCreate_ZeroBusinessDays_WeekendOnly(t *testing.T) {
 	assert.Contains(t, err.Error(), "zero vacation days")
 }
 
+func TestCreate_ZeroBusinessDays_RegionalWeekend(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(ctx context.Context) (*domain.Settings, error) {
+		s := domain.DefaultSettings()
+		s.WeekendPolicy.ExcludedDays = []int{5, 6} // Friday, Saturday
+		return &s, nil
+	}
+
+	// 18/06/2027 is Friday, 19/06/2027 is Saturday
+	_, err := d.svc.Create(ctx, "emp-1", dto.CreateVacationRequest{
+		StartDate: "18/06/2027",
+		EndDate:   "19/06/2027",
+	})
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrValidation)
+	assert.Contains(t, err.Error(), "zero vacation days")
+}
+
 func TestCreate_InsufficientBalance(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
@@ -340,6 +596,100 @@ func TestCreate_InsufficientBalance(t *testing.T) {
 	assertVacationAppError(t, err, dto.ErrInsufficientBalance)
 }
 
+func TestCreate_HoursBalanceUnit(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	employee := newTestEmployee(userID, 30) // 30 hours available
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		s := domain.DefaultSettings()
+		s.BalanceUnit = domain.BalanceUnitHours
+		s.HoursPerDay = 8
+		return &s, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		if id == userID {
+			return employee, nil
+		}
+		return nil, nil
+	}
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
+		return false, nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateFn = func(_ context.Context, req *domain.VacationRequest) error {
+		createdReq = req
+		return nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
+
+	// 14/06/2027 Mon - 16/06/2027 Wed => 3 business days = 24 hours
+	result, err := d.svc.Create(ctx, userID, dto.CreateVacationRequest{
+		StartDate: "14/06/2027",
+		EndDate:   "16/06/2027",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 24, result.TotalDays)
+
+	// 5 business days = 40 hours, more than the 30 available
+	_, err = d.svc.Create(ctx, userID, dto.CreateVacationRequest{
+		StartDate: "21/06/2027",
+		EndDate:   "25/06/2027",
+	})
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrInsufficientBalance)
+	assert.Contains(t, err.Error(), "40 hours")
+}
+
+func TestCreate_CeilRoundingMode(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	employee := newTestEmployee(userID, 25)
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		s := domain.DefaultSettings()
+		s.RoundingMode = domain.RoundingCeil
+		return &s, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		if id == userID {
+			return employee, nil
+		}
+		return nil, nil
+	}
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
+		return false, nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateFn = func(_ context.Context, req *domain.VacationRequest) error {
+		createdReq = req
+		return nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
+
+	// 14/06/2027 Mon - 16/06/2027 Wed => 3 business days, rounded up to a 5-day week
+	result, err := d.svc.Create(ctx, userID, dto.CreateVacationRequest{
+		StartDate: "14/06/2027",
+		EndDate:   "16/06/2027",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.TotalDays)
+
+	// 21/06/2027 Mon - 28/06/2027 Mon => 6 business days, rounded up to two weeks
+	result, err = d.svc.Create(ctx, userID, dto.CreateVacationRequest{
+		StartDate: "21/06/2027",
+		EndDate:   "28/06/2027",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, result.TotalDays)
+}
+
 func TestCreate_OverlappingRequest(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
@@ -352,7 +702,7 @@ func TestCreate_OverlappingRequest(t *testing.T) {
 		}
 		return nil, nil
 	}
-	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string) (bool, error) {
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
 		return true, nil
 	}
 
@@ -370,7 +720,7 @@ func TestCreate_UserNotFound(t *testing.T) {
 	ctx := context.Background()
 
 	// userRepo.GetByID returns nil by default (user not found)
-	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string) (bool, error) {
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
 		return false, nil
 	}
 
@@ -412,7 +762,7 @@ func TestCreate_SingleDay(t *testing.T) {
 		}
 		return nil, nil
 	}
-	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string) (bool, error) {
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
 		return false, nil
 	}
 	var createdReq *domain.VacationRequest
@@ -450,7 +800,7 @@ func TestCreate_CreateRepoError(t *testing.T) {
 		}
 		return nil, nil
 	}
-	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string) (bool, error) {
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
 		return false, nil
 	}
 	d.vacationRepo.CreateFn = func(_ context.Context, _ *domain.VacationRequest) error {
@@ -478,7 +828,7 @@ func TestCreate_AdminTransactionError(t *testing.T) {
 		}
 		return nil, nil
 	}
-	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string) (bool, error) {
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
 		return false, nil
 	}
 	d.transactor.TransactionFn = func(_ func(tx *sql.Tx) error) error {
@@ -506,7 +856,7 @@ func TestCreate_HasOverlapRepoError(t *testing.T) {
 		}
 		return nil, nil
 	}
-	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string) (bool, error) {
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
 		return false, errors.New("db error")
 	}
 
@@ -519,306 +869,2080 @@ func TestCreate_HasOverlapRepoError(t *testing.T) {
 	assertVacationAppError(t, err, dto.ErrInternal)
 }
 
-// =========================================================================
-// Cancel
-// =========================================================================
-
-func TestCancel_Success(t *testing.T) {
+func TestCreate_OverlapWarnPolicyAllowsAndFlags(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
-	userID := "emp-1"
-	requestID := "req-1"
 
-	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
-		if id == requestID {
-			return newPendingRequest(requestID, userID, 5), nil
-		}
-		return nil, nil
+	settings := domain.DefaultSettings()
+	settings.OverlapPolicy = domain.OverlapPolicyWarn
+	settings.OverlapAllowTouching = true
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return &settings, nil
 	}
-	deleted := false
-	d.vacationRepo.DeleteFn = func(_ context.Context, id string) error {
-		assert.Equal(t, requestID, id)
-		deleted = true
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	var gotAllowTouching bool
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, allowTouching bool, _ []domain.RequestType) (bool, error) {
+		gotAllowTouching = allowTouching
+		return true, nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateFn = func(_ context.Context, req *domain.VacationRequest) error {
+		createdReq = req
 		return nil
 	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
 
-	err := d.svc.Cancel(ctx, requestID, userID)
+	result, err := d.svc.Create(ctx, "emp-1", dto.CreateVacationRequest{
+		StartDate: "16/06/2027",
+		EndDate:   "18/06/2027",
+	})
 
 	require.NoError(t, err)
-	assert.True(t, deleted)
+	assert.True(t, gotAllowTouching)
+	assert.True(t, result.OverlapWarning)
+	assert.Equal(t, domain.StatusPending, result.Status)
 }
 
-func TestCancel_NotFound(t *testing.T) {
+func TestCreate_OverlapWarnPolicyNoOverlapNoFlag(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	// GetByID returns nil by default
-	err := d.svc.Cancel(ctx, "nonexistent", "emp-1")
+	settings := domain.DefaultSettings()
+	settings.OverlapPolicy = domain.OverlapPolicyWarn
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateFn = func(_ context.Context, req *domain.VacationRequest) error {
+		createdReq = req
+		return nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
 
-	require.Error(t, err)
-	assertVacationAppError(t, err, dto.ErrNotFound)
+	result, err := d.svc.Create(ctx, "emp-1", dto.CreateVacationRequest{
+		StartDate: "16/06/2027",
+		EndDate:   "18/06/2027",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.OverlapWarning)
 }
 
-func TestCancel_NotOwner(t *testing.T) {
+func TestCreate_MinNoticeDaysBlocksShortNotice(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
-	requestID := "req-1"
 
-	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
-		if id == requestID {
-			return newPendingRequest(requestID, "emp-1", 5), nil
-		}
-		return nil, nil
+	settings := domain.DefaultSettings()
+	settings.MinNoticeDays = 7
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
 	}
 
-	err := d.svc.Cancel(ctx, requestID, "emp-2") // different user
+	start := time.Now().UTC().AddDate(0, 0, 2).Format("02/01/2006")
+	end := time.Now().UTC().AddDate(0, 0, 6).Format("02/01/2006")
+	_, err := d.svc.Create(ctx, "emp-1", dto.CreateVacationRequest{StartDate: start, EndDate: end})
 
 	require.Error(t, err)
-	assertVacationAppError(t, err, dto.ErrForbidden)
-	assert.Contains(t, err.Error(), "your own requests")
+	assertVacationAppError(t, err, dto.ErrValidation)
+	assert.Contains(t, err.Error(), "requests require at least 7 days notice")
 }
 
-func TestCancel_AlreadyApproved(t *testing.T) {
+func TestCreate_MinNoticeDaysPastDateReportedSeparately(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
-	userID := "emp-1"
-	requestID := "req-1"
 
-	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
-		if id == requestID {
-			return newApprovedRequest(requestID, userID, 5), nil
-		}
-		return nil, nil
+	settings := domain.DefaultSettings()
+	settings.MinNoticeDays = 7
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return &settings, nil
 	}
 
-	err := d.svc.Cancel(ctx, requestID, userID)
+	_, err := d.svc.Create(ctx, "emp-1", dto.CreateVacationRequest{
+		StartDate: "01/01/2020",
+		EndDate:   "05/01/2020",
+	})
 
 	require.Error(t, err)
-	assertVacationAppError(t, err, dto.ErrForbidden)
-	assert.Contains(t, err.Error(), "approved")
+	assert.Contains(t, err.Error(), "start date cannot be in the past")
 }
 
-func TestCancel_AlreadyRejected(t *testing.T) {
+func TestCreate_MinNoticeDaysAdminBypasses(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
-	userID := "emp-1"
-	requestID := "req-1"
 
-	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
-		if id == requestID {
-			return newRejectedRequest(requestID, userID, 5), nil
-		}
-		return nil, nil
+	settings := domain.DefaultSettings()
+	settings.MinNoticeDays = 7
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return &settings, nil
 	}
-
-	err := d.svc.Cancel(ctx, requestID, userID)
-
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestAdmin("admin-1", 20), nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateTxFn = func(_ context.Context, _ *sql.Tx, req *domain.VacationRequest) error {
+		createdReq = req
+		return nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
+
+	start := time.Now().UTC().AddDate(0, 0, 1).Format("02/01/2006")
+	end := time.Now().UTC().AddDate(0, 0, 7).Format("02/01/2006")
+	result, err := d.svc.Create(ctx, "admin-1", dto.CreateVacationRequest{StartDate: start, EndDate: end})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusApproved, result.Status)
+}
+
+func TestCreate_MaxConsecutiveDays(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   int
+		user    *domain.User
+		wantErr bool
+	}{
+		{"unlimited", 0, newTestEmployee("emp-1", 20), false},
+		{"exactly at limit", 5, newTestEmployee("emp-1", 20), false},
+		{"one over limit", 4, newTestEmployee("emp-1", 20), true},
+		{"admin overrides limit", 4, newTestAdmin("admin-1", 20), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newServiceBundle()
+			ctx := context.Background()
+
+			settings := domain.DefaultSettings()
+			settings.MaxConsecutiveDays = tt.limit
+			d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+				return &settings, nil
+			}
+			d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+				return tt.user, nil
+			}
+			d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+				return newPendingRequest(id, tt.user.ID, 5), nil
+			}
+
+			// 14/06/2027 (Mon) to 18/06/2027 (Fri) => 5 business days
+			_, err := d.svc.Create(ctx, tt.user.ID, dto.CreateVacationRequest{
+				StartDate: "14/06/2027",
+				EndDate:   "18/06/2027",
+			})
+
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			var appErr *dto.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, dto.ErrValidation, appErr.Code)
+			assert.Equal(t, tt.limit, appErr.Details["maxConsecutiveDays"])
+			assert.Equal(t, 5, appErr.Details["requested"])
+		})
+	}
+}
+
+// newBlackoutSettings returns settings with a quarter-close blackout covering 14-18 June 2027.
+func newBlackoutSettings() *domain.Settings {
+	settings := domain.DefaultSettings()
+	settings.BlackoutPeriods = []domain.BlackoutPeriod{
+		{ID: "bo-1", StartDate: "2027-06-16", EndDate: "2027-06-30", Reason: "Quarter close"},
+	}
+	return &settings
+}
+
+func TestCreate_BlackoutPeriodBlocksRequest(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return newBlackoutSettings(), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+
+	_, err := d.svc.Create(ctx, "emp-1", dto.CreateVacationRequest{
+		StartDate: "14/06/2027",
+		EndDate:   "18/06/2027",
+		Force:     true, // ignored for employees
+	})
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, dto.ErrValidation, appErr.Code)
+	assert.Equal(t, "Quarter close", appErr.Details["reason"])
+	assert.Equal(t, "2027-06-16", appErr.Details["blackoutStart"])
+}
+
+func TestCreate_BlackoutPeriodAdminNeedsForce(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return newBlackoutSettings(), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestAdmin("admin-1", 20), nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateTxFn = func(_ context.Context, _ *sql.Tx, req *domain.VacationRequest) error {
+		createdReq = req
+		return nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
+
+	req := dto.CreateVacationRequest{StartDate: "14/06/2027", EndDate: "18/06/2027"}
+	_, err := d.svc.Create(ctx, "admin-1", req)
+	assertVacationAppError(t, err, dto.ErrValidation)
+
+	req.Force = true
+	result, err := d.svc.Create(ctx, "admin-1", req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusApproved, result.Status)
+}
+
+func TestCreate_MaxFutureDays(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		settings := domain.DefaultSettings()
+		settings.MaxFutureDays = 30
+		return &settings, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		if id == "admin-1" {
+			return newTestAdmin("admin-1", 20), nil
+		}
+		return newTestEmployee("emp-1", 20), nil
+	}
+
+	today := time.Now().UTC()
+	rangeFrom := func(offset int) dto.CreateVacationRequest {
+		return dto.CreateVacationRequest{
+			StartDate: today.AddDate(0, 0, offset).Format("02/01/2006"),
+			EndDate:   today.AddDate(0, 0, offset+6).Format("02/01/2006"),
+		}
+	}
+
+	// The last day of the horizon is still allowed
+	_, err := d.svc.Create(ctx, "emp-1", rangeFrom(30))
+	require.NoError(t, err)
+
+	tooFar := rangeFrom(31)
+	_, err = d.svc.Create(ctx, "emp-1", tooFar)
+	assertVacationAppError(t, err, dto.ErrValidation)
+
+	tooFar.Tentative = true
+	_, err = d.svc.Create(ctx, "emp-1", tooFar)
+	assertVacationAppError(t, err, dto.ErrValidation)
+
+	// Force is ignored for employees; admins can use it to book past the horizon
+	tooFar.Tentative, tooFar.Force = false, true
+	_, err = d.svc.Create(ctx, "emp-1", tooFar)
+	assertVacationAppError(t, err, dto.ErrValidation)
+
+	_, err = d.svc.Create(ctx, "admin-1", rangeFrom(31))
+	assertVacationAppError(t, err, dto.ErrValidation)
+
+	_, err = d.svc.Create(ctx, "admin-1", tooFar)
+	require.NoError(t, err)
+}
+
+func TestAddBlackout_StoresPeriod(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	var saved *domain.Settings
+	d.settingsRepo.UpdateFn = func(_ context.Context, settings *domain.Settings) error {
+		saved = settings
+		return nil
+	}
+
+	blackout, err := d.svc.AddBlackout(ctx, dto.CreateBlackoutRequest{
+		StartDate: "24/06/2027",
+		EndDate:   "30/06/2027",
+		Reason:    "Quarter close",
+	})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, blackout.ID)
+	assert.Equal(t, "2027-06-24", blackout.StartDate)
+	assert.Equal(t, "2027-06-30", blackout.EndDate)
+	require.NotNil(t, saved)
+	require.Len(t, saved.BlackoutPeriods, 1)
+	assert.Equal(t, blackout.ID, saved.BlackoutPeriods[0].ID)
+}
+
+func TestAddBlackout_InvalidRange(t *testing.T) {
+	d := newServiceBundle()
+
+	_, err := d.svc.AddBlackout(context.Background(), dto.CreateBlackoutRequest{
+		StartDate: "30/06/2027",
+		EndDate:   "24/06/2027",
+		Reason:    "Quarter close",
+	})
+
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
+func TestDeleteBlackout(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return newBlackoutSettings(), nil
+	}
+	var saved *domain.Settings
+	d.settingsRepo.UpdateFn = func(_ context.Context, settings *domain.Settings) error {
+		saved = settings
+		return nil
+	}
+
+	err := d.svc.DeleteBlackout(ctx, "missing")
+	assertVacationAppError(t, err, dto.ErrNotFound)
+
+	err = d.svc.DeleteBlackout(ctx, "bo-1")
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Empty(t, saved.BlackoutPeriods)
+}
+
+// newCoolOffBundle returns a service where requests over 4 days need a 30 day gap,
+// and emp-1 already has an approved 5 day vacation from 3 to 7 May 2027.
+func newCoolOffBundle(t *testing.T) *serviceDeps {
+	d := newServiceBundle()
+
+	settings := domain.DefaultSettings()
+	settings.LongVacationDays = 4
+	settings.CoolOffDays = 30
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.vacationRepo.ListByUserFn = func(_ context.Context, userID string, status *domain.VacationStatus, _ *int, _, _ string) ([]*domain.VacationRequest, error) {
+		require.NotNil(t, status)
+		assert.Equal(t, domain.StatusApproved, *status)
+		prior := newApprovedRequest("prior", userID, 5)
+		prior.StartDate = "2027-05-03"
+		prior.EndDate = "2027-05-07"
+		return []*domain.VacationRequest{prior}, nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest(id, "emp-1", 5), nil
+	}
+	return d
+}
+
+func TestCreate_CoolOff_TooSoonBlocked(t *testing.T) {
+	d := newCoolOffBundle(t)
+
+	// 24/05/2027 is 17 days after the prior vacation ends
+	_, err := d.svc.Create(context.Background(), "emp-1", dto.CreateVacationRequest{
+		StartDate: "24/05/2027",
+		EndDate:   "28/05/2027",
+	})
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrValidation)
+	assert.Contains(t, err.Error(), "at least 30 days apart")
+}
+
+func TestCreate_CoolOff_AfterGapAllowed(t *testing.T) {
+	d := newCoolOffBundle(t)
+
+	// 07/06/2027 is exactly 31 days after the prior vacation ends
+	_, err := d.svc.Create(context.Background(), "emp-1", dto.CreateVacationRequest{
+		StartDate: "07/06/2027",
+		EndDate:   "11/06/2027",
+	})
+
+	require.NoError(t, err)
+}
+
+func TestCreate_CoolOff_ShortRequestUnaffected(t *testing.T) {
+	d := newCoolOffBundle(t)
+	d.vacationRepo.ListByUserFn = func(_ context.Context, _ string, _ *domain.VacationStatus, _ *int, _, _ string) ([]*domain.VacationRequest, error) {
+		t.Fatal("short requests must not look up prior vacations")
+		return nil, nil
+	}
+
+	_, err := d.svc.Create(context.Background(), "emp-1", dto.CreateVacationRequest{
+		StartDate: "10/05/2027",
+		EndDate:   "12/05/2027",
+	})
+
+	require.NoError(t, err)
+}
+
+// =========================================================================
+// Tentative requests
+// =========================================================================
+
+// newTentativeRequest returns a domain.VacationRequest in tentative status.
+// 2027-06-14 is a Monday and 2027-06-18 a Friday, so the range is 5 business days.
+func newTentativeRequest(id, userID string) *domain.VacationRequest {
+	r := newPendingRequest(id, userID, 5)
+	r.Status = domain.StatusTentative
+	r.StartDate = "2027-06-14"
+	r.EndDate = "2027-06-18"
+	return r
+}
+
+func TestCreate_TentativeSkipsBalanceAndOverlap(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	employee := newTestEmployee(userID, 2) // not enough for 5 days
+
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return employee, nil
+	}
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
+		t.Fatal("overlap must not be checked for tentative requests")
+		return true, nil
+	}
+	d.transactor.TransactionFn = func(_ func(tx *sql.Tx) error) error {
+		t.Fatal("tentative requests must not touch the balance")
+		return nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateFn = func(_ context.Context, req *domain.VacationRequest) error {
+		createdReq = req
+		return nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
+
+	result, err := d.svc.Create(ctx, userID, dto.CreateVacationRequest{
+		StartDate: "14/06/2027",
+		EndDate:   "18/06/2027",
+		Tentative: true,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, domain.StatusTentative, result.Status)
+	assert.Equal(t, 5, result.TotalDays)
+}
+
+func TestCreate_TentativeAdminNotAutoApproved(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	adminID := "admin-1"
+
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestAdmin(adminID, 20), nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateFn = func(_ context.Context, req *domain.VacationRequest) error {
+		createdReq = req
+		return nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
+
+	result, err := d.svc.Create(ctx, adminID, dto.CreateVacationRequest{
+		StartDate: "14/06/2027",
+		EndDate:   "18/06/2027",
+		Tentative: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusTentative, result.Status)
+}
+
+func TestCreate_RemoteSkipsPaidLeaveRules(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	employee := newTestEmployee("emp-1", 0)
+
+	settings := domain.DefaultSettings()
+	settings.MinNoticeDays = 365
+	settings.MaxConsecutiveDays = 2
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return employee, nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateFn = func(_ context.Context, req *domain.VacationRequest) error {
+		createdReq = req
+		return nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
+
+	result, err := d.svc.Create(ctx, employee.ID, dto.CreateVacationRequest{
+		StartDate: "14/06/2027",
+		EndDate:   "18/06/2027",
+		Type:      "remote",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, domain.StatusPending, result.Status)
+	assert.Equal(t, domain.RequestTypeRemote, result.Type)
+	assert.Empty(t, result.Warnings)
+}
+
+func TestCreate_RemoteStillChecksOverlap(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
+		return true, nil
+	}
+
+	_, err := d.svc.Create(ctx, "emp-1", dto.CreateVacationRequest{
+		StartDate: "16/06/2027",
+		EndDate:   "20/06/2027",
+		Type:      "remote",
+	})
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrOverlappingRequest)
+}
+
+func TestCreate_OverlapScopeByType(t *testing.T) {
+	tests := []struct {
+		name              string
+		requestType       string
+		remoteBlocksLeave bool
+		wantTypes         []domain.RequestType
+	}{
+		{"vacation, any type conflicts", "", true, nil},
+		{"remote, any type conflicts", "remote", true, nil},
+		{"vacation, only vacation conflicts", "", false, []domain.RequestType{domain.RequestTypeVacation}},
+		{"remote, only remote conflicts", "remote", false, []domain.RequestType{domain.RequestTypeRemote}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newServiceBundle()
+			ctx := context.Background()
+
+			settings := domain.DefaultSettings()
+			settings.RemoteBlocksLeave = tt.remoteBlocksLeave
+			d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+				return &settings, nil
+			}
+			d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+				return newTestEmployee("emp-1", 20), nil
+			}
+			var gotTypes []domain.RequestType
+			d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, types []domain.RequestType) (bool, error) {
+				gotTypes = types
+				return false, nil
+			}
+
+			_, err := d.svc.Create(ctx, "emp-1", dto.CreateVacationRequest{
+				StartDate: "16/06/2027",
+				EndDate:   "18/06/2027",
+				Type:      tt.requestType,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTypes, gotTypes)
+		})
+	}
+}
+
+func TestCreate_RemoteAdminAutoApprovesWithoutDeduction(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	admin := newTestAdmin("admin-1", 20)
+
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return admin, nil
+	}
+	var createdReq *domain.VacationRequest
+	d.vacationRepo.CreateFn = func(_ context.Context, req *domain.VacationRequest) error {
+		createdReq = req
+		return nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return createdReq, nil
+	}
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, _, _ int) (int, error) {
+		t.Fatal("remote days must not change the balance")
+		return 0, nil
+	}
+
+	result, err := d.svc.Create(ctx, admin.ID, dto.CreateVacationRequest{
+		StartDate: "14/06/2027",
+		EndDate:   "18/06/2027",
+		Type:      "remote",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusApproved, result.Status)
+	assert.Nil(t, result.LowBalanceAlert)
+}
+
+func TestApprove_RemoteDoesNotDeduct(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	request := newPendingRequest("req-1", "emp-1", 5)
+	request.Type = domain.RequestTypeRemote
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return request, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 0), nil
+	}
+	var statusUpdated bool
+	d.vacationRepo.UpdateStatusTxFn = func(_ context.Context, _ *sql.Tx, _ string, status domain.VacationStatus, _ string, _ *string) error {
+		assert.Equal(t, domain.StatusApproved, status)
+		statusUpdated = true
+		return nil
+	}
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, _, _ int) (int, error) {
+		t.Fatal("remote days must not change the balance")
+		return 0, nil
+	}
+	d.ledgerRepo.CreateTxFn = func(_ context.Context, _ *sql.Tx, _ *domain.LedgerEntry) error {
+		t.Fatal("remote days must not be ledgered")
+		return nil
+	}
+
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
+
+	require.NoError(t, err)
+	assert.True(t, statusUpdated)
+}
+
+func TestCancel_ApprovedRemoteDeletesWithoutRefund(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	request := newApprovedRequest("req-1", "emp-1", 5)
+	request.Type = domain.RequestTypeRemote
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return request, nil
+	}
+	var deletedID string
+	d.vacationRepo.DeleteFn = func(_ context.Context, id string) error {
+		deletedID = id
+		return nil
+	}
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, _, _ int) (int, error) {
+		t.Fatal("remote days must not change the balance")
+		return 0, nil
+	}
+
+	_, err := d.svc.Cancel(ctx, "req-1", "emp-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "req-1", deletedID)
+}
+
+func TestSubmit_PromotesToPending(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	requestID := "req-1"
+
+	tentative := newTentativeRequest(requestID, userID)
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return tentative, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee(userID, 20), nil
+	}
+	overlapChecked := false
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, uid, start, end string, _ bool, _ []domain.RequestType) (bool, error) {
+		assert.Equal(t, userID, uid)
+		assert.Equal(t, "2027-06-14", start)
+		assert.Equal(t, "2027-06-18", end)
+		overlapChecked = true
+		return false, nil
+	}
+	var promotedStatus domain.VacationStatus
+	var promotedDays int
+	d.vacationRepo.PromoteTentativeTxFn = func(_ context.Context, _ *sql.Tx, id string, status domain.VacationStatus, totalDays int) error {
+		assert.Equal(t, requestID, id)
+		promotedStatus = status
+		promotedDays = totalDays
+		return nil
+	}
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, _, _ int) (int, error) {
+		t.Fatal("submitting for review must not deduct balance")
+		return 0, nil
+	}
+
+	_, err := d.svc.Submit(ctx, requestID, userID)
+
+	require.NoError(t, err)
+	assert.True(t, overlapChecked)
+	assert.Equal(t, domain.StatusPending, promotedStatus)
+	assert.Equal(t, 5, promotedDays)
+}
+
+func TestSubmit_InsufficientBalance(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newTentativeRequest("req-1", userID), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee(userID, 3), nil
+	}
+
+	_, err := d.svc.Submit(ctx, "req-1", userID)
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrInsufficientBalance)
+}
+
+func TestSubmit_Overlap(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newTentativeRequest("req-1", userID), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee(userID, 20), nil
+	}
+	d.vacationRepo.HasOverlapFn = func(_ context.Context, _, _, _ string, _ bool, _ []domain.RequestType) (bool, error) {
+		return true, nil
+	}
+
+	_, err := d.svc.Submit(ctx, "req-1", userID)
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrOverlappingRequest)
+}
+
+func TestSubmit_NotTentative(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 5), nil
+	}
+
+	_, err := d.svc.Submit(ctx, "req-1", "emp-1")
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrAlreadyExists)
+}
+
+func TestSubmit_NotOwner(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newTentativeRequest("req-1", "emp-1"), nil
+	}
+
+	_, err := d.svc.Submit(ctx, "req-1", "emp-2")
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrForbidden)
+}
+
+// =========================================================================
+// Cancel
+// =========================================================================
+
+func TestCancel_Success(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	requestID := "req-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == requestID {
+			return newPendingRequest(requestID, userID, 5), nil
+		}
+		return nil, nil
+	}
+	deleted := false
+	d.vacationRepo.DeleteFn = func(_ context.Context, id string) error {
+		assert.Equal(t, requestID, id)
+		deleted = true
+		return nil
+	}
+
+	cancelled, err := d.svc.Cancel(ctx, requestID, userID)
+
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	assert.Equal(t, requestID, cancelled.ID)
+}
+
+func TestCancel_NotFound(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	// GetByID returns nil by default
+	_, err := d.svc.Cancel(ctx, "nonexistent", "emp-1")
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrNotFound)
+}
+
+func TestCancel_NotOwner(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	requestID := "req-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == requestID {
+			return newPendingRequest(requestID, "emp-1", 5), nil
+		}
+		return nil, nil
+	}
+
+	_, err := d.svc.Cancel(ctx, requestID, "emp-2") // different user
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrForbidden)
+	assert.Contains(t, err.Error(), "your own requests")
+}
+
+func TestCancel_ApprovedFutureRequestRestoresBalance(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	requestID := "req-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == requestID {
+			return newApprovedRequest(requestID, userID, 5), nil
+		}
+		return nil, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee(userID, 10), nil
+	}
+	var deletedID string
+	d.vacationRepo.DeleteTxFn = func(_ context.Context, _ *sql.Tx, id string) error {
+		deletedID = id
+		return nil
+	}
+	var refunded int
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, delta, _ int) (int, error) {
+		refunded = delta
+		return 10 + delta, nil
+	}
+	var entry *domain.LedgerEntry
+	d.ledgerRepo.CreateTxFn = func(_ context.Context, _ *sql.Tx, e *domain.LedgerEntry) error {
+		entry = e
+		return nil
+	}
+
+	cancelled, err := d.svc.Cancel(ctx, requestID, userID)
+
+	require.NoError(t, err)
+	assert.True(t, cancelled.IsApproved())
+	assert.Equal(t, requestID, deletedID)
+	assert.Equal(t, 5, refunded)
+	require.NotNil(t, entry)
+	assert.Equal(t, 5, entry.Delta)
+	assert.Equal(t, domain.LedgerVacation, entry.Reason)
+	require.NotNil(t, entry.ReferenceID)
+	assert.Equal(t, requestID, *entry.ReferenceID)
+}
+
+func TestCancel_ApprovedRefundsLedgeredDeduction(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	requestID := "req-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newApprovedRequest(requestID, userID, 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee(userID, 0), nil
+	}
+	// The balance was clamped at zero on approval, so only 3 days were actually deducted
+	otherID := "req-2"
+	d.ledgerRepo.ListByUserFn = func(_ context.Context, _ string) ([]*domain.LedgerEntry, error) {
+		return []*domain.LedgerEntry{
+			{UserID: userID, Delta: 3, Reason: domain.LedgerOpening},
+			{UserID: userID, Delta: -3, Reason: domain.LedgerVacation, ReferenceID: &requestID},
+			{UserID: userID, Delta: -2, Reason: domain.LedgerVacation, ReferenceID: &otherID},
+		}, nil
+	}
+	var refunded int
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, delta, _ int) (int, error) {
+		refunded = delta
+		return delta, nil
+	}
+
+	_, err := d.svc.Cancel(ctx, requestID, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, refunded)
+}
+
+func TestCancel_ApprovedAlreadyStarted(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	requestID := "req-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		r := newApprovedRequest(requestID, userID, 5)
+		r.StartDate = time.Now().UTC().Format("2006-01-02")
+		return r, nil
+	}
+	d.vacationRepo.DeleteTxFn = func(_ context.Context, _ *sql.Tx, _ string) error {
+		t.Fatal("started leave must not be deleted")
+		return nil
+	}
+
+	_, err := d.svc.Cancel(ctx, requestID, userID)
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrForbidden)
+	assert.Contains(t, err.Error(), "already started")
+}
+
+func TestCancel_ApprovedTransactionError(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newApprovedRequest("req-1", "emp-1", 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 10), nil
+	}
+	d.transactor.TransactionFn = func(_ func(tx *sql.Tx) error) error {
+		return errors.New("tx failed")
+	}
+
+	_, err := d.svc.Cancel(ctx, "req-1", "emp-1")
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrInternal)
+}
+
+func TestCancel_AlreadyRejected(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	requestID := "req-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == requestID {
+			return newRejectedRequest(requestID, userID, 5), nil
+		}
+		return nil, nil
+	}
+
+	_, err := d.svc.Cancel(ctx, requestID, userID)
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrForbidden)
+	assert.Contains(t, err.Error(), "rejected")
+}
+
+func TestCancel_GetByIDRepoError(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return nil, errors.New("db failure")
+	}
+
+	_, err := d.svc.Cancel(ctx, "req-1", "emp-1")
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrInternal)
+}
+
+// =========================================================================
+// Approve
+// =========================================================================
+
+func TestCurrentLeaveYear(t *testing.T) {
+	d := newServiceBundle()
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		settings := domain.DefaultSettings()
+		settings.VacationResetMonth = 4
+		settings.Timezone = "Europe/Berlin"
+		return &settings, nil
+	}
+
+	year, err := d.svc.CurrentLeaveYear(context.Background(), time.Date(2027, 2, 10, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 2026, year, "February 2027 still falls in the leave year starting April 2026")
+
+	// Already April 1st in Berlin while still March 31st in UTC
+	year, err = d.svc.CurrentLeaveYear(context.Background(), time.Date(2027, 3, 31, 23, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 2027, year)
+}
+
+func TestApprove_Success(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	adminID := "admin-1"
+	userID := "emp-1"
+	requestID := "req-1"
+	totalDays := 5
+	initialBalance := 20
+
+	pendingReq := newPendingRequest(requestID, userID, totalDays)
+	user := newTestEmployee(userID, initialBalance)
+
+	callCount := 0
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == requestID {
+			callCount++
+			if callCount == 1 {
+				return pendingReq, nil
+			}
+			// After approval, return an approved copy
+			approved := *pendingReq
+			approved.Status = domain.StatusApproved
+			return &approved, nil
+		}
+		return nil, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		if id == userID {
+			return user, nil
+		}
+		return nil, nil
+	}
+
+	var statusUpdated, balanceDeducted bool
+	d.vacationRepo.UpdateStatusTxFn = func(_ context.Context, _ *sql.Tx, id string, status domain.VacationStatus, reviewedBy string, reason *string) error {
+		assert.Equal(t, requestID, id)
+		assert.Equal(t, domain.StatusApproved, status)
+		assert.Equal(t, adminID, reviewedBy)
+		assert.Nil(t, reason)
+		statusUpdated = true
+		return nil
+	}
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, id string, delta, _ int) (int, error) {
+		assert.Equal(t, userID, id)
+		assert.Equal(t, -totalDays, delta)
+		balanceDeducted = true
+		return initialBalance + delta, nil // 20 - 5 = 15
+	}
+
+	result, err := d.svc.Approve(ctx, requestID, adminID, nil)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, domain.StatusApproved, result.Status)
+	assert.True(t, statusUpdated)
+	assert.True(t, balanceDeducted)
+	assert.Nil(t, result.LowBalanceAlert, "15 days left stays above the default threshold")
+}
+
+func TestApprove_LowBalanceAlertOnCrossing(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	user := newTestEmployee("emp-1", 8)
+
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return user, nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest(id, "emp-1", 3), nil
+	}
+
+	// 8 - 3 = 5 reaches the default threshold of 5
+	result, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
+	require.NoError(t, err)
+	require.NotNil(t, result.LowBalanceAlert)
+	assert.Equal(t, 5, result.LowBalanceAlert.Balance)
+	assert.Equal(t, domain.BalanceUnitDays, result.LowBalanceAlert.Unit)
+
+	// Later approvals below the line don't alert again
+	user.VacationBalance = 5
+	result, err = d.svc.Approve(ctx, "req-2", "admin-1", nil)
+	require.NoError(t, err)
+	assert.Nil(t, result.LowBalanceAlert)
+}
+
+func TestApprove_WarnsOnLongVacation(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		settings := domain.DefaultSettings()
+		settings.LongVacationDays = 2
+		return &settings, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest(id, "emp-1", 3), nil
+	}
+
+	result, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
+
+	require.NoError(t, err)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "3 business days")
+}
+
+func TestRecomputeTotalDays_UpdatesStaleTotal(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	// 2027-06-16 (Wed) to 2027-06-20 (Sun) is 3 business days
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest(id, "emp-1", 5), nil
+	}
+	var stored int
+	d.vacationRepo.UpdateTotalDaysFn = func(_ context.Context, id string, totalDays int) error {
+		assert.Equal(t, "req-1", id)
+		stored = totalDays
+		return nil
+	}
+
+	result, err := d.svc.RecomputeTotalDays(ctx, "req-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.PreviousTotalDays)
+	assert.Equal(t, 3, result.NewTotalDays)
+	assert.Equal(t, 3, stored)
+}
+
+func TestRecomputeTotalDays_OnlyUnderReview(t *testing.T) {
+	d := newServiceBundle()
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newApprovedRequest(id, "emp-1", 5), nil
+	}
+	d.vacationRepo.UpdateTotalDaysFn = func(_ context.Context, _ string, _ int) error {
+		t.Fatal("approved requests must not be recomputed")
+		return nil
+	}
+
+	_, err := d.svc.RecomputeTotalDays(context.Background(), "req-1")
+	assertVacationAppError(t, err, dto.ErrAlreadyExists)
+}
+
+func TestApprove_NotFound(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	_, err := d.svc.Approve(ctx, "nonexistent", "admin-1", nil)
+
 	require.Error(t, err)
-	assertVacationAppError(t, err, dto.ErrForbidden)
-	assert.Contains(t, err.Error(), "rejected")
+	assertVacationAppError(t, err, dto.ErrNotFound)
+}
+
+func TestApprove_AlreadyProcessed_Approved(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	requestID := "req-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == requestID {
+			return newApprovedRequest(requestID, "emp-1", 5), nil
+		}
+		return nil, nil
+	}
+
+	_, err := d.svc.Approve(ctx, requestID, "admin-1", nil)
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrAlreadyExists) // ErrConflictError uses ErrAlreadyExists code
+	assert.Contains(t, err.Error(), "already been processed")
+}
+
+func TestApprove_AlreadyProcessed_Rejected(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	requestID := "req-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == requestID {
+			return newRejectedRequest(requestID, "emp-1", 5), nil
+		}
+		return nil, nil
+	}
+
+	_, err := d.svc.Approve(ctx, requestID, "admin-1", nil)
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrAlreadyExists)
+}
+
+func TestApprove_InsufficientBalance(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	requestID := "req-1"
+
+	pendingReq := newPendingRequest(requestID, userID, 10)
+	user := newTestEmployee(userID, 3) // only 3 days left
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == requestID {
+			return pendingReq, nil
+		}
+		return nil, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		if id == userID {
+			return user, nil
+		}
+		return nil, nil
+	}
+
+	_, err := d.svc.Approve(ctx, requestID, "admin-1", nil)
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrInsufficientBalance)
+
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, 10, appErr.Details["requested"])
+	assert.Equal(t, 3, appErr.Details["available"])
+	assert.Equal(t, -7, appErr.Details["resultingBalance"])
+}
+
+func TestApproveWithOverride_AllowsNegativeBalance(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	requestID := "req-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest(requestID, userID, 10), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee(userID, 3), nil
+	}
+	var newBalance int
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, delta, floor int) (int, error) {
+		assert.Equal(t, math.MinInt, floor, "an override deducts without a floor")
+		newBalance = 3 + delta
+		return newBalance, nil
+	}
+	var recorded string
+	d.vacationRepo.UpdateBalanceOverrideTxFn = func(_ context.Context, _ *sql.Tx, id, reason string) error {
+		assert.Equal(t, requestID, id)
+		recorded = reason
+		return nil
+	}
+	var delta int
+	d.ledgerRepo.CreateTxFn = func(_ context.Context, _ *sql.Tx, e *domain.LedgerEntry) error {
+		delta = e.Delta
+		return nil
+	}
+
+	_, err := d.svc.ApproveWithOverride(ctx, requestID, "admin-1", nil, "  contract renewal pending  ")
+
+	require.NoError(t, err)
+	assert.Equal(t, -7, newBalance)
+	assert.Equal(t, -10, delta)
+	assert.Equal(t, "contract renewal pending", recorded)
+}
+
+func TestApproveWithOverride_NotRecordedWhenBalanceSuffices(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.vacationRepo.UpdateBalanceOverrideTxFn = func(_ context.Context, _ *sql.Tx, _, _ string) error {
+		t.Fatal("override must not be recorded when the balance suffices")
+		return nil
+	}
+
+	_, err := d.svc.ApproveWithOverride(ctx, "req-1", "admin-1", nil, "just in case")
+
+	require.NoError(t, err)
+}
+
+func TestApproveWithOverride_RequiresReason(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	_, err := d.svc.ApproveWithOverride(ctx, "req-1", "admin-1", nil, "   ")
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
+func TestApproveWithDates_DeductsAdjustedRange(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+	requestID := "req-1"
+
+	// Submitted Wed 2027-06-16 to Sun 06-20: three business days
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest(requestID, userID, 3), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee(userID, 20), nil
+	}
+	var adjustedStart, adjustedEnd string
+	var adjustedTotal int
+	d.vacationRepo.AdjustDatesTxFn = func(_ context.Context, _ *sql.Tx, id, startDate, endDate string, totalDays int) error {
+		assert.Equal(t, requestID, id)
+		adjustedStart, adjustedEnd, adjustedTotal = startDate, endDate, totalDays
+		return nil
+	}
+	var newBalance int
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, delta, _ int) (int, error) {
+		newBalance = 20 + delta
+		return newBalance, nil
+	}
+	var delta int
+	d.ledgerRepo.CreateTxFn = func(_ context.Context, _ *sql.Tx, e *domain.LedgerEntry) error {
+		delta = e.Delta
+		return nil
+	}
+
+	_, err := d.svc.ApproveWithDates(ctx, requestID, "admin-1", nil, "17/06/2027", "18/06/2027", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "2027-06-17", adjustedStart)
+	assert.Equal(t, "2027-06-18", adjustedEnd)
+	assert.Equal(t, 2, adjustedTotal)
+	assert.Equal(t, 18, newBalance, "only the approved two days are deducted")
+	assert.Equal(t, -2, delta)
+}
+
+func TestApproveWithDates_UnchangedRangeIsPlainApproval(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 3), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.vacationRepo.AdjustDatesTxFn = func(_ context.Context, _ *sql.Tx, _, _, _ string, _ int) error {
+		t.Fatal("the submitted range must not be recorded as an adjustment")
+		return nil
+	}
+
+	_, err := d.svc.ApproveWithDates(ctx, "req-1", "admin-1", nil, "16/06/2027", "20/06/2027", nil)
+
+	require.NoError(t, err)
+}
+
+func TestApproveWithDates_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		settings   *domain.Settings
+		start, end string
+	}{
+		{"invalid date", nil, "2027-06-17", "18/06/2027"},
+		{"end before start", nil, "18/06/2027", "17/06/2027"},
+		{"starts before the request", nil, "15/06/2027", "18/06/2027"},
+		{"ends after the request", nil, "17/06/2027", "21/06/2027"},
+		{"weekend only", nil, "19/06/2027", "20/06/2027"},
+		{"intermediate level", newTwoLevelSettings("lead-1"), "17/06/2027", "18/06/2027"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newServiceBundle()
+			if tt.settings != nil {
+				d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+					return tt.settings, nil
+				}
+			}
+			d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+				return newPendingRequest("req-1", "emp-1", 3), nil
+			}
+			d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+				return newTestEmployee("emp-1", 20), nil
+			}
+			d.transactor.TransactionFn = func(_ func(tx *sql.Tx) error) error {
+				t.Fatal("nothing may be approved")
+				return nil
+			}
+
+			_, err := d.svc.ApproveWithDates(context.Background(), "req-1", "lead-1", nil, tt.start, tt.end, nil)
+
+			require.Error(t, err)
+			assertVacationAppError(t, err, dto.ErrValidation)
+		})
+	}
+}
+
+func TestApproveWithDates_ChecksBalanceAgainstAdjustedTotal(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	// Two days left: the full request doesn't fit, the narrowed one does
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 3), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 2), nil
+	}
+
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
+	require.Error(t, err)
+
+	_, err = d.svc.ApproveWithDates(ctx, "req-1", "admin-1", nil, "16/06/2027", "17/06/2027", nil)
+	require.NoError(t, err)
+}
+
+func TestResolveRejectionReason(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	settings := domain.DefaultSettings()
+	settings.RejectionReasons = []domain.RejectionReason{{Code: "coverage", Label: "Not enough team coverage"}}
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+
+	got, err := d.svc.ResolveRejectionReason(ctx, "coverage", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Not enough team coverage", got)
+
+	got, err = d.svc.ResolveRejectionReason(ctx, "coverage", " two others are off ")
+	require.NoError(t, err)
+	assert.Equal(t, "Not enough team coverage: two others are off", got)
+
+	got, err = d.svc.ResolveRejectionReason(ctx, "", "Project deadline")
+	require.NoError(t, err)
+	assert.Equal(t, "Project deadline", got)
+
+	_, err = d.svc.ResolveRejectionReason(ctx, "budget", "")
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
+func overdrawSettings(maxOverdraw int) *domain.Settings {
+	settings := domain.DefaultSettings()
+	settings.AllowNegativeBalance = true
+	settings.MaxOverdrawDays = maxOverdraw
+	return &settings
+}
+
+func TestApprove_OverdrawWithinLimit(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return overdrawSettings(5), nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest(id, "emp-1", 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee(id, 2), nil
+	}
+	var newBalance int
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, delta, floor int) (int, error) {
+		assert.Equal(t, -5, floor, "the overdraft limit is the floor")
+		newBalance = 2 + delta
+		return newBalance, nil
+	}
+
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, -3, newBalance)
+}
+
+func TestApprove_OverdrawBeyondLimit(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return overdrawSettings(2), nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest(id, "emp-1", 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee(id, 2), nil
+	}
+
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
+
+	assertVacationAppError(t, err, dto.ErrInsufficientBalance)
+	var appErr *dto.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, -3, appErr.Details["resultingBalance"])
+}
+
+func TestCreate_OverdrawAllowed(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return overdrawSettings(3), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee(id, 2), nil
+	}
+
+	// 14/06/2027 Mon - 18/06/2027 Fri => 5 business days, leaving -3
+	_, err := d.svc.Create(ctx, "emp-1", dto.CreateVacationRequest{
+		StartDate: "14/06/2027",
+		EndDate:   "18/06/2027",
+	})
+
+	require.NoError(t, err)
 }
 
-func TestCancel_GetByIDRepoError(t *testing.T) {
+func TestApprove_UserNotFound(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
+	requestID := "req-1"
 
-	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
-		return nil, errors.New("db failure")
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		if id == requestID {
+			return newPendingRequest(requestID, "ghost-user", 5), nil
+		}
+		return nil, nil
 	}
+	// userRepo.GetByID returns nil by default => user not found
 
-	err := d.svc.Cancel(ctx, "req-1", "emp-1")
+	_, err := d.svc.Approve(ctx, requestID, "admin-1", nil)
 
 	require.Error(t, err)
-	assertVacationAppError(t, err, dto.ErrInternal)
+	assertVacationAppError(t, err, dto.ErrNotFound)
 }
 
-// =========================================================================
-// Approve
-// =========================================================================
-
-func TestApprove_Success(t *testing.T) {
+func TestApprove_TransactionError(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
-	adminID := "admin-1"
 	userID := "emp-1"
 	requestID := "req-1"
-	totalDays := 5
-	initialBalance := 20
 
-	pendingReq := newPendingRequest(requestID, userID, totalDays)
-	user := newTestEmployee(userID, initialBalance)
-
-	callCount := 0
 	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
 		if id == requestID {
-			callCount++
-			if callCount == 1 {
-				return pendingReq, nil
-			}
-			// After approval, return an approved copy
-			approved := *pendingReq
-			approved.Status = domain.StatusApproved
-			return &approved, nil
+			return newPendingRequest(requestID, userID, 5), nil
 		}
 		return nil, nil
 	}
 	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
 		if id == userID {
-			return user, nil
+			return newTestEmployee(userID, 20), nil
 		}
 		return nil, nil
 	}
+	d.transactor.TransactionFn = func(_ func(tx *sql.Tx) error) error {
+		return errors.New("transaction failed")
+	}
 
-	var statusUpdated, balanceDeducted bool
-	d.vacationRepo.UpdateStatusTxFn = func(_ context.Context, _ *sql.Tx, id string, status domain.VacationStatus, reviewedBy string, reason *string) error {
-		assert.Equal(t, requestID, id)
-		assert.Equal(t, domain.StatusApproved, status)
-		assert.Equal(t, adminID, reviewedBy)
-		assert.Nil(t, reason)
-		statusUpdated = true
-		return nil
+	_, err := d.svc.Approve(ctx, requestID, "admin-1", nil)
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrInternal)
+}
+
+func TestApprove_LosesRaceToConcurrentReview(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 5), nil
 	}
-	d.userRepo.UpdateVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, id string, balance int) error {
-		assert.Equal(t, userID, id)
-		assert.Equal(t, initialBalance-totalDays, balance) // 20 - 5 = 15
-		balanceDeducted = true
-		return nil
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	// Another admin decided the request between our read and our update
+	d.vacationRepo.UpdateStatusTxFn = func(_ context.Context, _ *sql.Tx, _ string, _ domain.VacationStatus, _ string, _ *string) error {
+		return repository.ErrNotUnderReview
+	}
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, _, _ int) (int, error) {
+		t.Fatal("balance must not be deducted")
+		return 0, nil
+	}
+
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
+
+	assertVacationAppError(t, err, dto.ErrAlreadyExists)
+}
+
+func TestApprove_ConcurrentApprovalsDeductOnce(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userRepo := sqlite.NewUserRepository(db)
+	vacRepo := sqlite.NewVacationRepository(db)
+	ledgerRepo := sqlite.NewLedgerRepository(db)
+	svc := service.NewVacationService(vacRepo, userRepo, sqlite.NewSettingsRepository(db), ledgerRepo, db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "emp-1", "emp@test.com", "Employee", domain.RoleEmployee, 20)
+	testutil.CreateTestUser(t, userRepo, "admin-1", "admin@test.com", "Admin", domain.RoleAdmin, 25)
+	testutil.CreateTestVacation(t, vacRepo, "req-1", "emp-1", "2027-06-16", "2027-06-20", 5, domain.StatusPending)
+
+	// Simulates a double-click: several approvals of the same request in flight at once
+	const attempts = 5
+	errs := make([]error, attempts)
+	var start, wg sync.WaitGroup
+	start.Add(1)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			start.Wait()
+			_, errs[i] = svc.Approve(ctx, "req-1", "admin-1", nil)
+		}(i)
 	}
+	start.Done()
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assertVacationAppError(t, err, dto.ErrAlreadyExists)
+	}
+	assert.Equal(t, 1, succeeded)
 
-	result, err := d.svc.Approve(ctx, requestID, adminID)
+	user, err := userRepo.GetByID(ctx, "emp-1")
+	require.NoError(t, err)
+	assert.Equal(t, 15, user.VacationBalance)
 
+	entries, err := ledgerRepo.ListByUser(ctx, "emp-1")
 	require.NoError(t, err)
-	require.NotNil(t, result)
-	assert.Equal(t, domain.StatusApproved, result.Status)
-	assert.True(t, statusUpdated)
-	assert.True(t, balanceDeducted)
+	deductions := 0
+	for _, e := range entries {
+		if e.Reason == domain.LedgerVacation {
+			deductions++
+		}
+	}
+	assert.Equal(t, 1, deductions)
 }
 
-func TestApprove_NotFound(t *testing.T) {
+func TestApprove_ConcurrentApprovalsOfOneUserBothDeduct(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userRepo := sqlite.NewUserRepository(db)
+	vacRepo := sqlite.NewVacationRepository(db)
+	ledgerRepo := sqlite.NewLedgerRepository(db)
+	svc := service.NewVacationService(vacRepo, userRepo, sqlite.NewSettingsRepository(db), ledgerRepo, db)
+	ctx := context.Background()
+
+	testutil.CreateTestUser(t, userRepo, "emp-1", "emp@test.com", "Employee", domain.RoleEmployee, 20)
+	testutil.CreateTestUser(t, userRepo, "admin-1", "admin@test.com", "Admin", domain.RoleAdmin, 25)
+	testutil.CreateTestVacation(t, vacRepo, "req-1", "emp-1", "2027-06-16", "2027-06-20", 5, domain.StatusPending)
+	testutil.CreateTestVacation(t, vacRepo, "req-2", "emp-1", "2027-07-14", "2027-07-16", 3, domain.StatusPending)
+
+	// Two different requests of the same user approved at once must both be deducted
+	errs := make([]error, 2)
+	var start, wg sync.WaitGroup
+	start.Add(1)
+	for i, id := range []string{"req-1", "req-2"} {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			start.Wait()
+			_, errs[i] = svc.Approve(ctx, id, "admin-1", nil)
+		}(i, id)
+	}
+	start.Done()
+	wg.Wait()
+
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+
+	user, err := userRepo.GetByID(ctx, "emp-1")
+	require.NoError(t, err)
+	assert.Equal(t, 12, user.VacationBalance)
+
+	// The ledger agrees with the stored balance
+	entries, err := ledgerRepo.ListByUser(ctx, "emp-1")
+	require.NoError(t, err)
+	deducted := 0
+	for _, e := range entries {
+		if e.Reason == domain.LedgerVacation {
+			deducted += e.Delta
+		}
+	}
+	assert.Equal(t, -8, deducted)
+}
+
+func TestApprove_BalanceDroppedSinceCheck(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	_, err := d.svc.Approve(ctx, "nonexistent", "admin-1")
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest(id, "emp-1", 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 6), nil
+	}
+	// Another approval took the balance to 2 after it was read
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, _, _ int) (int, error) {
+		return 2, repository.ErrBalanceBelowMinimum
+	}
+	d.ledgerRepo.CreateTxFn = func(_ context.Context, _ *sql.Tx, _ *domain.LedgerEntry) error {
+		t.Fatal("nothing was deducted, so nothing may be recorded")
+		return nil
+	}
+
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
 
-	require.Error(t, err)
-	assertVacationAppError(t, err, dto.ErrNotFound)
+	assertVacationAppError(t, err, dto.ErrInsufficientBalance)
 }
 
-func TestApprove_AlreadyProcessed_Approved(t *testing.T) {
+// newTwoLevelSettings returns settings with a team lead level followed by a final admin level.
+func newTwoLevelSettings(leadID string) *domain.Settings {
+	settings := domain.DefaultSettings()
+	settings.ApprovalLevels = []domain.ApprovalStep{
+		{Name: "Team Lead", ApproverID: &leadID},
+		{Name: "Admin"},
+	}
+	return &settings
+}
+
+func TestApprove_MultiLevel_FirstLevelAdvances(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
+	userID := "emp-1"
 	requestID := "req-1"
 
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return newTwoLevelSettings("lead-1"), nil
+	}
 	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
-		if id == requestID {
-			return newApprovedRequest(requestID, "emp-1", 5), nil
-		}
-		return nil, nil
+		return newPendingRequest(requestID, userID, 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee(userID, 20), nil
+	}
+
+	var advancedTo int
+	var advancedStatus domain.VacationStatus
+	d.vacationRepo.AdvanceApprovalStepFn = func(_ context.Context, id string, step int, status domain.VacationStatus, reviewedBy string, _ *string) error {
+		assert.Equal(t, requestID, id)
+		assert.Equal(t, "lead-1", reviewedBy)
+		advancedTo = step
+		advancedStatus = status
+		return nil
+	}
+	d.transactor.TransactionFn = func(_ func(tx *sql.Tx) error) error {
+		t.Fatal("balance must not be deducted before the final level")
+		return nil
+	}
+
+	_, err := d.svc.Approve(ctx, requestID, "lead-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, advancedTo)
+	assert.Equal(t, domain.StatusAwaitingFinal, advancedStatus)
+}
+
+func TestApprove_MultiLevel_AdvanceLosesRace(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return newTwoLevelSettings("lead-1"), nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.vacationRepo.AdvanceApprovalStepFn = func(_ context.Context, _ string, _ int, _ domain.VacationStatus, _ string, _ *string) error {
+		return repository.ErrNotUnderReview
 	}
 
-	_, err := d.svc.Approve(ctx, requestID, "admin-1")
+	_, err := d.svc.Approve(ctx, "req-1", "lead-1", nil)
 
 	require.Error(t, err)
 	assertVacationAppError(t, err, dto.ErrAlreadyExists) // ErrConflictError uses ErrAlreadyExists code
 	assert.Contains(t, err.Error(), "already been processed")
 }
 
-func TestApprove_AlreadyProcessed_Rejected(t *testing.T) {
+func TestApprove_MultiLevel_WrongApprover(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
-	requestID := "req-1"
 
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return newTwoLevelSettings("lead-1"), nil
+	}
 	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
-		if id == requestID {
-			return newRejectedRequest(requestID, "emp-1", 5), nil
-		}
-		return nil, nil
+		return newPendingRequest("req-1", "emp-1", 5), nil
 	}
 
-	_, err := d.svc.Approve(ctx, requestID, "admin-1")
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
 
 	require.Error(t, err)
-	assertVacationAppError(t, err, dto.ErrAlreadyExists)
+	assertVacationAppError(t, err, dto.ErrForbidden)
 }
 
-func TestApprove_InsufficientBalance(t *testing.T) {
+func TestApprove_MultiLevel_FinalLevelDeductsBalance(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 	userID := "emp-1"
 	requestID := "req-1"
 
-	pendingReq := newPendingRequest(requestID, userID, 10)
-	user := newTestEmployee(userID, 3) // only 3 days left
+	awaiting := newPendingRequest(requestID, userID, 5)
+	awaiting.Status = domain.StatusAwaitingFinal
+	awaiting.ApprovalStep = 1
 
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return newTwoLevelSettings("lead-1"), nil
+	}
 	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
-		if id == requestID {
-			return pendingReq, nil
-		}
-		return nil, nil
+		return awaiting, nil
 	}
 	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
-		if id == userID {
-			return user, nil
-		}
-		return nil, nil
+		return newTestEmployee(userID, 20), nil
+	}
+	d.vacationRepo.AdvanceApprovalStepFn = func(_ context.Context, _ string, _ int, _ domain.VacationStatus, _ string, _ *string) error {
+		t.Fatal("final level must not advance the chain")
+		return nil
 	}
 
-	_, err := d.svc.Approve(ctx, requestID, "admin-1")
+	var finalStatus domain.VacationStatus
+	var newBalance int
+	d.vacationRepo.UpdateStatusTxFn = func(_ context.Context, _ *sql.Tx, _ string, status domain.VacationStatus, _ string, _ *string) error {
+		finalStatus = status
+		return nil
+	}
+	d.userRepo.AddVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, delta, _ int) (int, error) {
+		newBalance = 20 + delta
+		return newBalance, nil
+	}
 
-	require.Error(t, err)
-	assertVacationAppError(t, err, dto.ErrInsufficientBalance)
+	_, err := d.svc.Approve(ctx, requestID, "admin-1", nil)
 
-	var appErr *dto.AppError
-	require.ErrorAs(t, err, &appErr)
-	assert.Equal(t, 10, appErr.Details["requested"])
-	assert.Equal(t, 3, appErr.Details["available"])
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusApproved, finalStatus)
+	assert.Equal(t, 15, newBalance)
+}
+
+func TestApprove_CommentRequired_BlocksWithoutComment(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	settings := domain.DefaultSettings()
+	settings.ApprovalCommentRequired = true
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 5), nil
+	}
+	d.transactor.TransactionFn = func(_ func(tx *sql.Tx) error) error {
+		t.Fatal("request must not be approved without a comment")
+		return nil
+	}
+
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
+	assertVacationAppError(t, err, dto.ErrValidation)
+
+	blank := "   "
+	_, err = d.svc.Approve(ctx, "req-1", "admin-1", &blank)
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
+func TestApprove_CommentRequired_StoresComment(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	settings := domain.DefaultSettings()
+	settings.ApprovalCommentRequired = true
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return &settings, nil
+	}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	var stored string
+	d.vacationRepo.UpdateApprovalCommentTxFn = func(_ context.Context, _ *sql.Tx, id string, comment string) error {
+		assert.Equal(t, "req-1", id)
+		stored = comment
+		return nil
+	}
+
+	comment := "Coverage confirmed with the team"
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", &comment)
+
+	require.NoError(t, err)
+	assert.Equal(t, comment, stored)
+}
+
+func TestApprove_CommentNotRequired_AllowsNoComment(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.vacationRepo.UpdateApprovalCommentTxFn = func(_ context.Context, _ *sql.Tx, _ string, _ string) error {
+		t.Fatal("no comment should be stored")
+		return nil
+	}
+
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
+
+	require.NoError(t, err)
+}
+
+func coverageSettings(minCoverage int) func(context.Context) (*domain.Settings, error) {
+	return func(_ context.Context) (*domain.Settings, error) {
+		settings := domain.DefaultSettings()
+		settings.MinCoverage = minCoverage
+		return &settings, nil
+	}
+}
+
+func TestApprove_MinCoverage_BlocksWhenTooManyOff(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = coverageSettings(2)
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 3), nil // 2027-06-16 (Wed) to 2027-06-20 (Sun)
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.userRepo.CountActiveFn = func(_ context.Context, teamID string) (int, error) {
+		assert.Empty(t, teamID)
+		return 4, nil
+	}
+	d.vacationRepo.ListTeamRangeFn = func(_ context.Context, from, to, teamID string) ([]*domain.TeamVacation, error) {
+		assert.Equal(t, "2027-06-16", from)
+		assert.Equal(t, "2027-06-20", to)
+		assert.Empty(t, teamID)
+		return []*domain.TeamVacation{
+			{ID: "v1", UserID: "emp-2", UserName: "Bob", StartDate: "2027-06-14", EndDate: "2027-06-16"},
+			{ID: "v2", UserID: "emp-3", UserName: "Carol", StartDate: "2027-06-18", EndDate: "2027-06-25"},
+			{ID: "v3", UserID: "emp-4", UserName: "Dan", StartDate: "2027-06-18", EndDate: "2027-06-18"},
+		}, nil
+	}
+	d.vacationRepo.UpdateStatusTxFn = func(_ context.Context, _ *sql.Tx, _ string, _ domain.VacationStatus, _ string, _ *string) error {
+		t.Fatal("request must not be approved")
+		return nil
+	}
+
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
+
+	// On the 18th Carol, Dan and the requester are off, leaving 1 of 4
+	assertVacationAppError(t, err, dto.ErrCoverageExceeded)
+	appErr := err.(*dto.AppError)
+	assert.Equal(t, "2027-06-18", appErr.Details["date"])
+	assert.Equal(t, 1, appErr.Details["available"])
+}
+
+func TestApprove_MinCoverage_AllowsWhenEnoughAvailable(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = coverageSettings(2)
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 3), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.userRepo.CountActiveFn = func(_ context.Context, teamID string) (int, error) {
+		assert.Empty(t, teamID)
+		return 4, nil
+	}
+	d.vacationRepo.ListTeamRangeFn = func(_ context.Context, _, _, _ string) ([]*domain.TeamVacation, error) {
+		return []*domain.TeamVacation{
+			// Weekend-only overlap does not count against coverage
+			{ID: "v1", UserID: "emp-2", UserName: "Bob", StartDate: "2027-06-19", EndDate: "2027-06-20"},
+			{ID: "v2", UserID: "emp-3", UserName: "Carol", StartDate: "2027-06-19", EndDate: "2027-06-20"},
+			{ID: "v3", UserID: "emp-4", UserName: "Dan", StartDate: "2027-06-16", EndDate: "2027-06-16"},
+		}, nil
+	}
+
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
+
+	require.NoError(t, err)
 }
 
-func TestApprove_UserNotFound(t *testing.T) {
+func TestApprove_MinCoverage_DisabledSkipsCheck(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
-	requestID := "req-1"
 
-	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
-		if id == requestID {
-			return newPendingRequest(requestID, "ghost-user", 5), nil
-		}
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 3), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.vacationRepo.ListTeamRangeFn = func(_ context.Context, _, _, _ string) ([]*domain.TeamVacation, error) {
+		t.Fatal("coverage should not be checked")
 		return nil, nil
 	}
-	// userRepo.GetByID returns nil by default => user not found
 
-	_, err := d.svc.Approve(ctx, requestID, "admin-1")
+	_, err := d.svc.Approve(ctx, "req-1", "admin-1", nil)
 
-	require.Error(t, err)
-	assertVacationAppError(t, err, dto.ErrNotFound)
+	require.NoError(t, err)
 }
 
-func TestApprove_TransactionError(t *testing.T) {
+func TestReject_AwaitingFinal(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
-	userID := "emp-1"
-	requestID := "req-1"
+
+	awaiting := newPendingRequest("req-1", "emp-1", 5)
+	awaiting.Status = domain.StatusAwaitingFinal
+	awaiting.ApprovalStep = 1
 
 	d.vacationRepo.GetByIDFn = func(_ context.Context, id string) (*domain.VacationRequest, error) {
-		if id == requestID {
-			return newPendingRequest(requestID, userID, 5), nil
-		}
-		return nil, nil
-	}
-	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
-		if id == userID {
-			return newTestEmployee(userID, 20), nil
-		}
-		return nil, nil
+		return awaiting, nil
 	}
-	d.transactor.TransactionFn = func(_ func(tx *sql.Tx) error) error {
-		return errors.New("transaction failed")
+	var rejected bool
+	d.vacationRepo.UpdateStatusFn = func(_ context.Context, _ string, status domain.VacationStatus, _ string, _ *string) error {
+		assert.Equal(t, domain.StatusRejected, status)
+		rejected = true
+		return nil
 	}
 
-	_, err := d.svc.Approve(ctx, requestID, "admin-1")
+	_, err := d.svc.Reject(ctx, "req-1", "admin-1", nil)
 
-	require.Error(t, err)
-	assertVacationAppError(t, err, dto.ErrInternal)
+	require.NoError(t, err)
+	assert.True(t, rejected)
 }
 
 // =========================================================================
@@ -927,6 +3051,22 @@ func TestReject_AlreadyProcessed(t *testing.T) {
 	assert.Contains(t, err.Error(), "already been processed")
 }
 
+func TestReject_LosesRaceToConcurrentReview(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 5), nil
+	}
+	d.vacationRepo.UpdateStatusFn = func(_ context.Context, _ string, _ domain.VacationStatus, _ string, _ *string) error {
+		return repository.ErrNotUnderReview
+	}
+
+	_, err := d.svc.Reject(ctx, "req-1", "admin-1", nil)
+
+	assertVacationAppError(t, err, dto.ErrAlreadyExists)
+}
+
 func TestReject_UpdateStatusError(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
@@ -948,6 +3088,210 @@ func TestReject_UpdateStatusError(t *testing.T) {
 	assertVacationAppError(t, err, dto.ErrInternal)
 }
 
+// =========================================================================
+// Suggest
+// =========================================================================
+
+func TestSuggest_AvoidsApprovedLeave(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	userID := "emp-1"
+
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee(userID, 20), nil
+	}
+	d.vacationRepo.ListByUserFn = func(_ context.Context, _ string, _ *domain.VacationStatus, _ *int, _, _ string) ([]*domain.VacationRequest, error) {
+		approved := newApprovedRequest("req-1", userID, 5)
+		approved.StartDate = "2027-06-14"
+		approved.EndDate = "2027-06-18"
+		rejected := newRejectedRequest("req-2", userID, 5)
+		rejected.StartDate = "2027-06-21"
+		rejected.EndDate = "2027-06-25"
+		return []*domain.VacationRequest{approved, rejected}, nil
+	}
+
+	// 07/06/2027 is a Monday, 25/06/2027 a Friday: three working weeks, the middle one taken
+	suggestions, err := d.svc.Suggest(ctx, userID, dto.SuggestVacationRequest{
+		Days: 5,
+		From: "07/06/2027",
+		To:   "25/06/2027",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, suggestions, 2)
+	assert.Equal(t, "2027-06-07", suggestions[0].StartDate)
+	assert.Equal(t, "2027-06-11", suggestions[0].EndDate)
+	assert.Equal(t, "2027-06-21", suggestions[1].StartDate)
+	assert.Equal(t, "2027-06-25", suggestions[1].EndDate)
+	for _, sug := range suggestions {
+		assert.Equal(t, 5, sug.TotalDays)
+	}
+}
+
+func TestSuggest_RespectsCount(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+
+	suggestions, err := d.svc.Suggest(ctx, "emp-1", dto.SuggestVacationRequest{
+		Days:  2,
+		From:  "07/06/2027",
+		To:    "30/07/2027",
+		Count: 1,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "2027-06-07", suggestions[0].StartDate)
+	assert.Equal(t, "2027-06-08", suggestions[0].EndDate)
+}
+
+func TestSuggest_SkipsRangesShortOfCoverage(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = coverageSettings(2)
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.userRepo.CountActiveFn = func(_ context.Context, _ string) (int, error) {
+		return 3, nil
+	}
+	d.vacationRepo.ListTeamRangeFn = func(_ context.Context, _, _, _ string) ([]*domain.TeamVacation, error) {
+		return []*domain.TeamVacation{
+			{ID: "v1", UserID: "emp-2", UserName: "Bob", StartDate: "2027-06-10", EndDate: "2027-06-10"},
+		}, nil
+	}
+
+	// With Bob off on the 10th only one of three would be left, so the first week can't be suggested
+	suggestions, err := d.svc.Suggest(ctx, "emp-1", dto.SuggestVacationRequest{
+		Days:  2,
+		From:  "07/06/2027",
+		To:    "18/06/2027",
+		Count: 2,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, suggestions, 2)
+	assert.Equal(t, "2027-06-07", suggestions[0].StartDate)
+	assert.Equal(t, "2027-06-08", suggestions[0].EndDate)
+	assert.Equal(t, "2027-06-11", suggestions[1].StartDate, "ranges covering the 10th are skipped")
+	assert.Equal(t, "2027-06-14", suggestions[1].EndDate)
+}
+
+func TestSuggest_RequestCapReached(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		settings := domain.DefaultSettings()
+		settings.MaxRequestsPerYear = 1
+		return &settings, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.vacationRepo.ListByUserFn = func(_ context.Context, _ string, _ *domain.VacationStatus, _ *int, _, _ string) ([]*domain.VacationRequest, error) {
+		approved := newApprovedRequest("req-1", "emp-1", 2)
+		approved.StartDate = "2027-03-01"
+		approved.EndDate = "2027-03-02"
+		return []*domain.VacationRequest{approved}, nil
+	}
+
+	suggestions, err := d.svc.Suggest(ctx, "emp-1", dto.SuggestVacationRequest{
+		Days: 2,
+		From: "07/06/2027",
+		To:   "25/06/2027",
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, suggestions, "2027 already holds the one request allowed")
+}
+
+func TestSuggest_InsufficientBalance(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 3), nil
+	}
+
+	_, err := d.svc.Suggest(ctx, "emp-1", dto.SuggestVacationRequest{
+		Days: 5,
+		From: "07/06/2027",
+		To:   "25/06/2027",
+	})
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrInsufficientBalance)
+}
+
+func TestSuggest_InsufficientBalanceInHours(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		settings := domain.DefaultSettings()
+		settings.BalanceUnit = domain.BalanceUnitHours
+		return &settings, nil
+	}
+	// 20 hours covers two and a half 8-hour days, not the five asked for
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+
+	_, err := d.svc.Suggest(ctx, "emp-1", dto.SuggestVacationRequest{
+		Days: 5,
+		From: "07/06/2027",
+		To:   "25/06/2027",
+	})
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrInsufficientBalance)
+}
+
+func TestSuggest_TotalsInHours(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		settings := domain.DefaultSettings()
+		settings.BalanceUnit = domain.BalanceUnitHours
+		return &settings, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, id string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 80), nil
+	}
+
+	suggestions, err := d.svc.Suggest(ctx, "emp-1", dto.SuggestVacationRequest{
+		Days:  2,
+		From:  "07/06/2027",
+		To:    "25/06/2027",
+		Count: 1,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, 16, suggestions[0].TotalDays, "two 8-hour days, in the unit the balance is kept in")
+}
+
+func TestSuggest_InvalidWindow(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	_, err := d.svc.Suggest(ctx, "emp-1", dto.SuggestVacationRequest{
+		Days: 5,
+		From: "25/06/2027",
+		To:   "07/06/2027",
+	})
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
 // =========================================================================
 // GetByID
 // =========================================================================
@@ -998,6 +3342,57 @@ func TestVacationGetByID_RepoError(t *testing.T) {
 	assertVacationAppError(t, err, dto.ErrInternal)
 }
 
+// =========================================================================
+// GetByIDs
+// =========================================================================
+
+func TestVacationGetByIDs_FiltersToVisibleRequests(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	var fetched []string
+	d.vacationRepo.GetByIDsFn = func(_ context.Context, ids []string) ([]*domain.VacationRequest, error) {
+		fetched = ids
+		return []*domain.VacationRequest{
+			newPendingRequest("req-1", "emp-1", 2),
+			newPendingRequest("req-2", "emp-2", 3),
+		}, nil
+	}
+
+	// Duplicates and blanks are dropped before querying
+	result, err := d.svc.GetByIDs(ctx, []string{"req-1", "", "req-2", "req-1", "missing"}, "emp-1", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-1", "req-2", "missing"}, fetched)
+	require.Len(t, result, 1)
+	assert.Equal(t, "req-1", result[0].ID)
+
+	// Admins see every requested request
+	result, err = d.svc.GetByIDs(ctx, []string{"req-1", "req-2"}, "admin-1", true)
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+}
+
+func TestVacationGetByIDs_Limits(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.GetByIDsFn = func(_ context.Context, _ []string) ([]*domain.VacationRequest, error) {
+		t.Fatal("repository should not be queried")
+		return nil, nil
+	}
+
+	result, err := d.svc.GetByIDs(ctx, []string{""}, "emp-1", false)
+	require.NoError(t, err)
+	assert.Empty(t, result)
+
+	ids := make([]string, 101)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("req-%d", i)
+	}
+	_, err = d.svc.GetByIDs(ctx, ids, "emp-1", false)
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
 // =========================================================================
 // ListByUser
 // =========================================================================
@@ -1011,14 +3406,14 @@ func TestListByUser_NoFilters(t *testing.T) {
 		newApprovedRequest("req-2", userID, 3),
 	}
 
-	d.vacationRepo.ListByUserFn = func(_ context.Context, uid string, status *domain.VacationStatus, year *int) ([]*domain.VacationRequest, error) {
+	d.vacationRepo.ListByUserFn = func(_ context.Context, uid string, status *domain.VacationStatus, year *int, _, _ string) ([]*domain.VacationRequest, error) {
 		assert.Equal(t, userID, uid)
 		assert.Nil(t, status)
 		assert.Nil(t, year)
 		return expected, nil
 	}
 
-	results, err := d.svc.ListByUser(ctx, userID, nil, nil)
+	results, err := d.svc.ListByUser(ctx, userID, nil, nil, "", "")
 
 	require.NoError(t, err)
 	assert.Len(t, results, 2)
@@ -1030,7 +3425,7 @@ func TestListByUser_FilterByStatus(t *testing.T) {
 	userID := "emp-1"
 	status := domain.StatusPending
 
-	d.vacationRepo.ListByUserFn = func(_ context.Context, uid string, s *domain.VacationStatus, year *int) ([]*domain.VacationRequest, error) {
+	d.vacationRepo.ListByUserFn = func(_ context.Context, uid string, s *domain.VacationStatus, year *int, _, _ string) ([]*domain.VacationRequest, error) {
 		assert.Equal(t, userID, uid)
 		require.NotNil(t, s)
 		assert.Equal(t, domain.StatusPending, *s)
@@ -1038,7 +3433,7 @@ func TestListByUser_FilterByStatus(t *testing.T) {
 		return []*domain.VacationRequest{newPendingRequest("req-1", userID, 5)}, nil
 	}
 
-	results, err := d.svc.ListByUser(ctx, userID, &status, nil)
+	results, err := d.svc.ListByUser(ctx, userID, &status, nil, "", "")
 
 	require.NoError(t, err)
 	assert.Len(t, results, 1)
@@ -1050,7 +3445,7 @@ func TestListByUser_FilterByYear(t *testing.T) {
 	userID := "emp-1"
 	year := 2027
 
-	d.vacationRepo.ListByUserFn = func(_ context.Context, uid string, status *domain.VacationStatus, y *int) ([]*domain.VacationRequest, error) {
+	d.vacationRepo.ListByUserFn = func(_ context.Context, uid string, status *domain.VacationStatus, y *int, _, _ string) ([]*domain.VacationRequest, error) {
 		assert.Equal(t, userID, uid)
 		assert.Nil(t, status)
 		require.NotNil(t, y)
@@ -1058,7 +3453,7 @@ func TestListByUser_FilterByYear(t *testing.T) {
 		return []*domain.VacationRequest{newPendingRequest("req-1", userID, 5)}, nil
 	}
 
-	results, err := d.svc.ListByUser(ctx, userID, nil, &year)
+	results, err := d.svc.ListByUser(ctx, userID, nil, &year, "", "")
 
 	require.NoError(t, err)
 	assert.Len(t, results, 1)
@@ -1071,7 +3466,7 @@ func TestListByUser_FilterByStatusAndYear(t *testing.T) {
 	status := domain.StatusApproved
 	year := 2027
 
-	d.vacationRepo.ListByUserFn = func(_ context.Context, uid string, s *domain.VacationStatus, y *int) ([]*domain.VacationRequest, error) {
+	d.vacationRepo.ListByUserFn = func(_ context.Context, uid string, s *domain.VacationStatus, y *int, _, _ string) ([]*domain.VacationRequest, error) {
 		assert.Equal(t, userID, uid)
 		require.NotNil(t, s)
 		assert.Equal(t, domain.StatusApproved, *s)
@@ -1080,7 +3475,7 @@ func TestListByUser_FilterByStatusAndYear(t *testing.T) {
 		return []*domain.VacationRequest{}, nil
 	}
 
-	results, err := d.svc.ListByUser(ctx, userID, &status, &year)
+	results, err := d.svc.ListByUser(ctx, userID, &status, &year, "", "")
 
 	require.NoError(t, err)
 	assert.Empty(t, results)
@@ -1090,16 +3485,54 @@ func TestListByUser_RepoError(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	d.vacationRepo.ListByUserFn = func(_ context.Context, _ string, _ *domain.VacationStatus, _ *int) ([]*domain.VacationRequest, error) {
+	d.vacationRepo.ListByUserFn = func(_ context.Context, _ string, _ *domain.VacationStatus, _ *int, _, _ string) ([]*domain.VacationRequest, error) {
 		return nil, errors.New("db error")
 	}
 
-	_, err := d.svc.ListByUser(ctx, "emp-1", nil, nil)
+	_, err := d.svc.ListByUser(ctx, "emp-1", nil, nil, "", "")
 
 	require.Error(t, err)
 	assertVacationAppError(t, err, dto.ErrInternal)
 }
 
+func TestListByUser_PassesDateRange(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	var gotFrom, gotTo string
+	d.vacationRepo.ListByUserFn = func(_ context.Context, _ string, _ *domain.VacationStatus, _ *int, from, to string) ([]*domain.VacationRequest, error) {
+		gotFrom, gotTo = from, to
+		return nil, nil
+	}
+
+	_, err := d.svc.ListByUser(ctx, "emp-1", nil, nil, "2027-08-01", "2027-08-31")
+
+	require.NoError(t, err)
+	assert.Equal(t, "2027-08-01", gotFrom)
+	assert.Equal(t, "2027-08-31", gotTo)
+}
+
+func TestListByUser_InvalidDateRange(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		from, to string
+	}{
+		{"bad from", "01/08/2027", ""},
+		{"bad to", "", "2027-13-01"},
+		{"to before from", "2027-08-31", "2027-08-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := d.svc.ListByUser(ctx, "emp-1", nil, nil, tt.from, tt.to)
+			assertVacationAppError(t, err, dto.ErrValidation)
+		})
+	}
+}
+
 // =========================================================================
 // ListPending
 // =========================================================================
@@ -1112,44 +3545,137 @@ func TestListPending_Success(t *testing.T) {
 		newPendingRequest("req-2", "emp-2", 3),
 	}
 
-	d.vacationRepo.ListPendingFn = func(_ context.Context) ([]*domain.VacationRequest, error) {
+	d.vacationRepo.ListPendingFn = func(_ context.Context, _, _ string) ([]*domain.VacationRequest, error) {
 		return expected, nil
 	}
 
-	results, err := d.svc.ListPending(ctx)
+	results, err := d.svc.ListPending(ctx, "", "")
+
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, domain.StatusPending, results[0].Status)
+	assert.Equal(t, domain.StatusPending, results[1].Status)
+}
+
+func TestListPending_Empty(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.ListPendingFn = func(_ context.Context, _, _ string) ([]*domain.VacationRequest, error) {
+		return []*domain.VacationRequest{}, nil
+	}
+
+	results, err := d.svc.ListPending(ctx, "", "")
+
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestListPending_RepoError(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.ListPendingFn = func(_ context.Context, _, _ string) ([]*domain.VacationRequest, error) {
+		return nil, errors.New("db error")
+	}
+
+	_, err := d.svc.ListPending(ctx, "", "")
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrInternal)
+}
+
+func TestListStale(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	now := time.Date(2027, 6, 15, 9, 0, 0, 0, time.UTC)
+
+	var cutoff time.Time
+	d.vacationRepo.ListStalePendingFn = func(_ context.Context, createdBefore time.Time) ([]*domain.VacationRequest, error) {
+		cutoff = createdBefore
+		return []*domain.VacationRequest{newPendingRequest("req-1", "emp-1", 3)}, nil
+	}
+
+	requests, err := d.svc.ListStale(ctx, now, 10)
+
+	require.NoError(t, err)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, time.Date(2027, 6, 5, 9, 0, 0, 0, time.UTC), cutoff)
+
+	_, err = d.svc.ListStale(ctx, now, 0)
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
+// =========================================================================
+// ListPendingForManager / EnsureManagerOf
+// =========================================================================
+
+func TestListPendingForManager_OnlyDirectReports(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.userRepo.ListByManagerFn = func(_ context.Context, managerID string) ([]*domain.User, error) {
+		assert.Equal(t, "mgr-1", managerID)
+		return []*domain.User{{ID: "emp-1"}}, nil
+	}
+	d.vacationRepo.ListPendingFn = func(_ context.Context, _, _ string) ([]*domain.VacationRequest, error) {
+		return []*domain.VacationRequest{
+			newPendingRequest("req-1", "emp-1", 5),
+			newPendingRequest("req-2", "emp-2", 3),
+		}, nil
+	}
+
+	results, err := d.svc.ListPendingForManager(ctx, "mgr-1", "", "")
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "req-1", results[0].ID)
+}
+
+func TestEnsureManagerOf_DirectReport(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	managerID := "mgr-1"
+
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return &domain.User{ID: "emp-1", ManagerID: &managerID}, nil
+	}
+
+	err := d.svc.EnsureManagerOf(ctx, managerID, "req-1")
 
 	require.NoError(t, err)
-	assert.Len(t, results, 2)
-	assert.Equal(t, domain.StatusPending, results[0].Status)
-	assert.Equal(t, domain.StatusPending, results[1].Status)
 }
 
-func TestListPending_Empty(t *testing.T) {
+func TestEnsureManagerOf_NotDirectReport(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
+	otherManager := "mgr-2"
 
-	d.vacationRepo.ListPendingFn = func(_ context.Context) ([]*domain.VacationRequest, error) {
-		return []*domain.VacationRequest{}, nil
+	d.vacationRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.VacationRequest, error) {
+		return newPendingRequest("req-1", "emp-1", 5), nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return &domain.User{ID: "emp-1", ManagerID: &otherManager}, nil
 	}
 
-	results, err := d.svc.ListPending(ctx)
+	err := d.svc.EnsureManagerOf(ctx, "mgr-1", "req-1")
 
-	require.NoError(t, err)
-	assert.Empty(t, results)
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrForbidden)
 }
 
-func TestListPending_RepoError(t *testing.T) {
+func TestEnsureManagerOf_RequestNotFound(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	d.vacationRepo.ListPendingFn = func(_ context.Context) ([]*domain.VacationRequest, error) {
-		return nil, errors.New("db error")
-	}
-
-	_, err := d.svc.ListPending(ctx)
+	err := d.svc.EnsureManagerOf(ctx, "mgr-1", "missing")
 
 	require.Error(t, err)
-	assertVacationAppError(t, err, dto.ErrInternal)
+	assertVacationAppError(t, err, dto.ErrNotFound)
 }
 
 // =========================================================================
@@ -1178,13 +3704,13 @@ func TestListTeam_Success(t *testing.T) {
 		},
 	}
 
-	d.vacationRepo.ListTeamFn = func(_ context.Context, month, year int) ([]*domain.TeamVacation, error) {
+	d.vacationRepo.ListTeamFn = func(_ context.Context, month, year int, _ string) ([]*domain.TeamVacation, error) {
 		assert.Equal(t, 6, month)
 		assert.Equal(t, 2027, year)
 		return expected, nil
 	}
 
-	results, err := d.svc.ListTeam(ctx, 6, 2027)
+	results, err := d.svc.ListTeam(ctx, 6, 2027, "", false)
 
 	require.NoError(t, err)
 	assert.Len(t, results, 2)
@@ -1192,11 +3718,35 @@ func TestListTeam_Success(t *testing.T) {
 	assert.Equal(t, "Bob", results[1].UserName)
 }
 
+func TestListTeam_IncludePendingUsesPendingVariant(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.ListTeamFn = func(_ context.Context, _, _ int, _ string) ([]*domain.TeamVacation, error) {
+		t.Fatal("approved-only listing should not be used")
+		return nil, nil
+	}
+	d.vacationRepo.ListTeamWithPendingFn = func(_ context.Context, month, year int, teamID string) ([]*domain.TeamVacation, error) {
+		assert.Equal(t, 6, month)
+		assert.Equal(t, 2027, year)
+		assert.Equal(t, "team-1", teamID)
+		return []*domain.TeamVacation{
+			{ID: "req-1", UserID: "emp-1", UserName: "Alice", Status: domain.StatusPending},
+		}, nil
+	}
+
+	results, err := d.svc.ListTeam(ctx, 6, 2027, "team-1", true)
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, domain.StatusPending, results[0].Status)
+}
+
 func TestListTeam_InvalidMonth_Zero(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	_, err := d.svc.ListTeam(ctx, 0, 2027)
+	_, err := d.svc.ListTeam(ctx, 0, 2027, "", false)
 
 	require.Error(t, err)
 	assertVacationAppError(t, err, dto.ErrValidation)
@@ -1207,7 +3757,7 @@ func TestListTeam_InvalidMonth_Thirteen(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	_, err := d.svc.ListTeam(ctx, 13, 2027)
+	_, err := d.svc.ListTeam(ctx, 13, 2027, "", false)
 
 	require.Error(t, err)
 	assertVacationAppError(t, err, dto.ErrValidation)
@@ -1218,7 +3768,7 @@ func TestListTeam_InvalidMonth_Negative(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	_, err := d.svc.ListTeam(ctx, -1, 2027)
+	_, err := d.svc.ListTeam(ctx, -1, 2027, "", false)
 
 	require.Error(t, err)
 	assertVacationAppError(t, err, dto.ErrValidation)
@@ -1228,7 +3778,7 @@ func TestListTeam_InvalidYear_TooLow(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	_, err := d.svc.ListTeam(ctx, 6, 1999)
+	_, err := d.svc.ListTeam(ctx, 6, 1999, "", false)
 
 	require.Error(t, err)
 	assertVacationAppError(t, err, dto.ErrValidation)
@@ -1239,7 +3789,7 @@ func TestListTeam_InvalidYear_TooHigh(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	_, err := d.svc.ListTeam(ctx, 6, 2101)
+	_, err := d.svc.ListTeam(ctx, 6, 2101, "", false)
 
 	require.Error(t, err)
 	assertVacationAppError(t, err, dto.ErrValidation)
@@ -1250,12 +3800,12 @@ func TestListTeam_BoundaryMonth_One(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	d.vacationRepo.ListTeamFn = func(_ context.Context, month, year int) ([]*domain.TeamVacation, error) {
+	d.vacationRepo.ListTeamFn = func(_ context.Context, month, year int, _ string) ([]*domain.TeamVacation, error) {
 		assert.Equal(t, 1, month)
 		return []*domain.TeamVacation{}, nil
 	}
 
-	results, err := d.svc.ListTeam(ctx, 1, 2027)
+	results, err := d.svc.ListTeam(ctx, 1, 2027, "", false)
 
 	require.NoError(t, err)
 	assert.Empty(t, results)
@@ -1265,12 +3815,12 @@ func TestListTeam_BoundaryMonth_Twelve(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	d.vacationRepo.ListTeamFn = func(_ context.Context, month, year int) ([]*domain.TeamVacation, error) {
+	d.vacationRepo.ListTeamFn = func(_ context.Context, month, year int, _ string) ([]*domain.TeamVacation, error) {
 		assert.Equal(t, 12, month)
 		return []*domain.TeamVacation{}, nil
 	}
 
-	results, err := d.svc.ListTeam(ctx, 12, 2027)
+	results, err := d.svc.ListTeam(ctx, 12, 2027, "", false)
 
 	require.NoError(t, err)
 	assert.Empty(t, results)
@@ -1280,12 +3830,12 @@ func TestListTeam_BoundaryYear_2000(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	d.vacationRepo.ListTeamFn = func(_ context.Context, month, year int) ([]*domain.TeamVacation, error) {
+	d.vacationRepo.ListTeamFn = func(_ context.Context, month, year int, _ string) ([]*domain.TeamVacation, error) {
 		assert.Equal(t, 2000, year)
 		return []*domain.TeamVacation{}, nil
 	}
 
-	results, err := d.svc.ListTeam(ctx, 6, 2000)
+	results, err := d.svc.ListTeam(ctx, 6, 2000, "", false)
 
 	require.NoError(t, err)
 	assert.Empty(t, results)
@@ -1295,12 +3845,12 @@ func TestListTeam_BoundaryYear_2100(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	d.vacationRepo.ListTeamFn = func(_ context.Context, month, year int) ([]*domain.TeamVacation, error) {
+	d.vacationRepo.ListTeamFn = func(_ context.Context, month, year int, _ string) ([]*domain.TeamVacation, error) {
 		assert.Equal(t, 2100, year)
 		return []*domain.TeamVacation{}, nil
 	}
 
-	results, err := d.svc.ListTeam(ctx, 6, 2100)
+	results, err := d.svc.ListTeam(ctx, 6, 2100, "", false)
 
 	require.NoError(t, err)
 	assert.Empty(t, results)
@@ -1310,12 +3860,542 @@ func TestListTeam_RepoError(t *testing.T) {
 	d := newServiceBundle()
 	ctx := context.Background()
 
-	d.vacationRepo.ListTeamFn = func(_ context.Context, _, _ int) ([]*domain.TeamVacation, error) {
+	d.vacationRepo.ListTeamFn = func(_ context.Context, _, _ int, _ string) ([]*domain.TeamVacation, error) {
 		return nil, errors.New("db error")
 	}
 
-	_, err := d.svc.ListTeam(ctx, 6, 2027)
+	_, err := d.svc.ListTeam(ctx, 6, 2027, "", false)
+
+	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrInternal)
+}
+
+// =========================================================================
+// TeamWeeks
+// =========================================================================
+
+func TestTeamWeeks_GroupsMonthByISOWeek(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.ListTeamFn = func(_ context.Context, month, year int, teamID string) ([]*domain.TeamVacation, error) {
+		assert.Equal(t, 6, month)
+		assert.Equal(t, 2027, year)
+		assert.Equal(t, "team-1", teamID)
+		return []*domain.TeamVacation{
+			{ID: "v1", UserID: "emp-2", UserName: "Bob", StartDate: "2027-06-03", EndDate: "2027-06-08", TotalDays: 4},
+			{ID: "v2", UserID: "emp-1", UserName: "Alice", StartDate: "2027-05-31", EndDate: "2027-06-03", TotalDays: 4},
+		}, nil
+	}
+
+	resp, err := d.svc.TeamWeeks(ctx, 6, 2027, "team-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "team-1", resp.TeamID)
+
+	// 1 June 2027 is a Tuesday, so the first and last weeks are partial
+	require.Len(t, resp.Weeks, 5)
+	assert.Equal(t, 22, resp.Weeks[0].Week)
+	assert.Equal(t, 2027, resp.Weeks[0].Year)
+	assert.Len(t, resp.Weeks[0].Days, 6)
+	assert.Len(t, resp.Weeks[1].Days, 7)
+	assert.Equal(t, 26, resp.Weeks[4].Week)
+	assert.Len(t, resp.Weeks[4].Days, 3)
+
+	// Thursday 3 June: both off, ordered by name
+	thursday := resp.Weeks[0].Days[2]
+	assert.Equal(t, "2027-06-03", thursday.Date)
+	assert.True(t, thursday.BusinessDay)
+	require.Len(t, thursday.Off, 2)
+	assert.Equal(t, "Alice", thursday.Off[0].UserName)
+	assert.Equal(t, "v2", thursday.Off[0].RequestID)
+	assert.Equal(t, "Bob", thursday.Off[1].UserName)
+
+	// The weekend inside Bob's request is not listed
+	saturday := resp.Weeks[0].Days[4]
+	assert.Equal(t, "2027-06-05", saturday.Date)
+	assert.False(t, saturday.BusinessDay)
+	assert.Empty(t, saturday.Off)
+
+	// Monday 7 June opens the next week with Bob still off
+	monday := resp.Weeks[1].Days[0]
+	assert.Equal(t, "2027-06-07", monday.Date)
+	require.Len(t, monday.Off, 1)
+	assert.Equal(t, "emp-2", monday.Off[0].UserID)
+}
+
+func TestTeamWeeks_ISOWeekAcrossNewYear(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.ListTeamFn = func(_ context.Context, _, _ int, _ string) ([]*domain.TeamVacation, error) {
+		return []*domain.TeamVacation{}, nil
+	}
+
+	resp, err := d.svc.TeamWeeks(ctx, 1, 2027, "")
+
+	require.NoError(t, err)
+	// 1-3 January 2027 belong to the last ISO week of 2026
+	assert.Equal(t, 2026, resp.Weeks[0].Year)
+	assert.Equal(t, 53, resp.Weeks[0].Week)
+	assert.Len(t, resp.Weeks[0].Days, 3)
+	assert.Equal(t, 2027, resp.Weeks[1].Year)
+	assert.Equal(t, 1, resp.Weeks[1].Week)
+}
+
+func TestTeamWeeks_InvalidMonth(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	_, err := d.svc.TeamWeeks(ctx, 13, 2027, "")
 
 	require.Error(t, err)
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
+// =========================================================================
+// Gantt
+// =========================================================================
+
+func TestGantt_MemberWithTwoPeriodsHasTwoSegments(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.ListTeamRangeFn = func(_ context.Context, from, to, _ string) ([]*domain.TeamVacation, error) {
+		assert.Equal(t, "2027-06-01", from)
+		assert.Equal(t, "2027-06-30", to)
+		return []*domain.TeamVacation{
+			{ID: "v1", UserID: "emp-1", UserName: "Bob", StartDate: "2027-06-07", EndDate: "2027-06-11", TotalDays: 5},
+			{ID: "v2", UserID: "emp-2", UserName: "Alice", StartDate: "2027-06-14", EndDate: "2027-06-15", TotalDays: 2},
+			{ID: "v3", UserID: "emp-1", UserName: "Bob", StartDate: "2027-06-21", EndDate: "2027-06-25", TotalDays: 5},
+		}, nil
+	}
+
+	chart, err := d.svc.Gantt(ctx, "2027-06-01", "2027-06-30", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, 30, chart.Days)
+	require.Len(t, chart.Members, 2)
+
+	// Members are ordered by name
+	assert.Equal(t, "Alice", chart.Members[0].UserName)
+	bob := chart.Members[1]
+	assert.Equal(t, "emp-1", bob.UserID)
+	require.Len(t, bob.Segments, 2)
+
+	assert.Equal(t, "v1", bob.Segments[0].ID)
+	assert.Equal(t, "2027-06-07", bob.Segments[0].StartDate)
+	assert.Equal(t, "2027-06-11", bob.Segments[0].EndDate)
+	assert.InDelta(t, 6.0/30, bob.Segments[0].Offset, 0.0001)
+	assert.InDelta(t, 5.0/30, bob.Segments[0].Width, 0.0001)
+
+	assert.Equal(t, "v3", bob.Segments[1].ID)
+	assert.Equal(t, "2027-06-21", bob.Segments[1].StartDate)
+	assert.Equal(t, "2027-06-25", bob.Segments[1].EndDate)
+	assert.InDelta(t, 20.0/30, bob.Segments[1].Offset, 0.0001)
+	assert.InDelta(t, 5.0/30, bob.Segments[1].Width, 0.0001)
+}
+
+func TestGantt_ClipsSegmentsToRange(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.ListTeamRangeFn = func(_ context.Context, _, _, _ string) ([]*domain.TeamVacation, error) {
+		return []*domain.TeamVacation{
+			{ID: "v1", UserID: "emp-1", UserName: "Bob", StartDate: "2027-05-28", EndDate: "2027-06-03", TotalDays: 5},
+			{ID: "v2", UserID: "emp-2", UserName: "Carol", StartDate: "2027-06-08", EndDate: "2027-06-15", TotalDays: 6},
+		}, nil
+	}
+
+	chart, err := d.svc.Gantt(ctx, "2027-06-01", "2027-06-10", "")
+
+	require.NoError(t, err)
+	require.Len(t, chart.Members, 2)
+
+	first := chart.Members[0].Segments[0]
+	assert.Equal(t, "2027-05-28", first.StartDate, "actual dates are kept")
+	assert.InDelta(t, 0.0, first.Offset, 0.0001)
+	assert.InDelta(t, 0.3, first.Width, 0.0001)
+
+	second := chart.Members[1].Segments[0]
+	assert.InDelta(t, 0.7, second.Offset, 0.0001)
+	assert.InDelta(t, 0.3, second.Width, 0.0001)
+}
+
+func TestGantt_InvalidRange(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	_, err := d.svc.Gantt(ctx, "2027-06-30", "2027-06-01", "")
+	assertVacationAppError(t, err, dto.ErrValidation)
+
+	_, err = d.svc.Gantt(ctx, "01/06/2027", "2027-06-30", "")
+	assertVacationAppError(t, err, dto.ErrValidation)
+
+	_, err = d.svc.Gantt(ctx, "2027-01-01", "2028-06-30", "")
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
+func TestGantt_RepoError(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.ListTeamRangeFn = func(_ context.Context, _, _, _ string) ([]*domain.TeamVacation, error) {
+		return nil, errors.New("db error")
+	}
+
+	_, err := d.svc.Gantt(ctx, "2027-06-01", "2027-06-30", "")
+	assertVacationAppError(t, err, dto.ErrInternal)
+}
+
+// =========================================================================
+// CoverageForRange
+// =========================================================================
+
+func TestCoverageForRange_PerDate(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.vacationRepo.ListTeamRangeFn = func(_ context.Context, from, to, teamID string) ([]*domain.TeamVacation, error) {
+		assert.Equal(t, "2027-06-18", from)
+		assert.Equal(t, "2027-06-21", to)
+		assert.Equal(t, "team-1", teamID)
+		return []*domain.TeamVacation{
+			{ID: "v1", UserID: "emp-2", UserName: "Bob", StartDate: "2027-06-14", EndDate: "2027-06-18"},
+			{ID: "v2", UserID: "emp-1", UserName: "Alice", StartDate: "2027-06-18", EndDate: "2027-06-21"},
+		}, nil
+	}
+
+	coverage, err := d.svc.CoverageForRange(ctx, "2027-06-18", "2027-06-21", "team-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "team-1", coverage.TeamID)
+	require.Len(t, coverage.Days, 4)
+
+	friday := coverage.Days[0]
+	assert.Equal(t, "2027-06-18", friday.Date)
+	assert.True(t, friday.BusinessDay)
+	assert.Equal(t, 2, friday.Count)
+	assert.Equal(t, []string{"Alice", "Bob"}, friday.Names)
+
+	saturday := coverage.Days[1]
+	assert.False(t, saturday.BusinessDay)
+	assert.Equal(t, []string{"Alice"}, saturday.Names)
+
+	monday := coverage.Days[3]
+	assert.Equal(t, "2027-06-21", monday.Date)
+	assert.Equal(t, 1, monday.Count)
+}
+
+func TestCoverageForRange_EmptyDayHasNoNames(t *testing.T) {
+	d := newServiceBundle()
+
+	coverage, err := d.svc.CoverageForRange(context.Background(), "2027-06-18", "2027-06-18", "")
+
+	require.NoError(t, err)
+	require.Len(t, coverage.Days, 1)
+	assert.Equal(t, 0, coverage.Days[0].Count)
+	assert.NotNil(t, coverage.Days[0].Names)
+}
+
+func TestCoverageForRange_InvalidRange(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	_, err := d.svc.CoverageForRange(ctx, "2027-06-20", "2027-06-18", "")
+	assertVacationAppError(t, err, dto.ErrValidation)
+
+	_, err = d.svc.CoverageForRange(ctx, "18/06/2027", "2027-06-18", "")
+	assertVacationAppError(t, err, dto.ErrValidation)
+
+	_, err = d.svc.CoverageForRange(ctx, "2027-01-01", "2028-06-01", "")
+	assertVacationAppError(t, err, dto.ErrValidation)
+}
+
+// =========================================================================
+// Statement
+// =========================================================================
+
+func TestStatement_ArithmeticHolds(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+	employee := newTestEmployee("emp-1", 24)
+
+	at := func(date string) time.Time {
+		parsed, _ := time.Parse("2006-01-02", date)
+		return parsed
+	}
+
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return employee, nil
+	}
+	d.ledgerRepo.ListByUserFn = func(_ context.Context, _ string) ([]*domain.LedgerEntry, error) {
+		return []*domain.LedgerEntry{
+			{Delta: 25, Reason: domain.LedgerOpening, CreatedAt: at("2025-03-01")},
+			{Delta: -10, Reason: domain.LedgerVacation, CreatedAt: at("2025-08-01")},
+			// 2026: opening balance is 15
+			{Delta: 10, Reason: domain.LedgerReset, CreatedAt: at("2026-01-01")},
+			{Delta: 3, Reason: domain.LedgerAdjustment, CreatedAt: at("2026-02-10")},
+			{Delta: -5, Reason: domain.LedgerVacation, CreatedAt: at("2026-04-01")},
+			{Delta: -1, Reason: domain.LedgerAdjustment, CreatedAt: at("2026-05-01")},
+			{Delta: -3, Reason: domain.LedgerVacation, CreatedAt: at("2026-09-01")},
+			// Later years are excluded
+			{Delta: -4, Reason: domain.LedgerVacation, CreatedAt: at("2027-02-01")},
+		}, nil
+	}
+	d.vacationRepo.ListByUserFn = func(_ context.Context, _ string, status *domain.VacationStatus, year *int, _, _ string) ([]*domain.VacationRequest, error) {
+		require.NotNil(t, status)
+		assert.Equal(t, domain.StatusApproved, *status)
+		require.NotNil(t, year)
+		assert.Equal(t, 2026, *year)
+		return []*domain.VacationRequest{newApprovedRequest("req-1", "emp-1", 5)}, nil
+	}
+
+	statement, err := d.svc.Statement(ctx, "emp-1", 2026)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2026, statement.Year)
+	assert.Equal(t, 15, statement.OpeningBalance)
+	assert.Equal(t, 2, statement.Grants)
+	assert.Equal(t, 8, statement.Taken)
+	assert.Equal(t, 10, statement.Carryover)
+	assert.Equal(t, 19, statement.ClosingBalance)
+	assert.Equal(t, statement.ClosingBalance,
+		statement.OpeningBalance+statement.Grants-statement.Taken+statement.Carryover)
+	assert.Len(t, statement.Requests, 1)
+}
+
+func TestStatement_UsesLeaveYear(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	at := func(date string) time.Time {
+		parsed, _ := time.Parse("2006-01-02", date)
+		return parsed
+	}
+
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		settings := domain.DefaultSettings()
+		settings.VacationResetMonth = 4
+		return &settings, nil
+	}
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.ledgerRepo.ListByUserFn = func(_ context.Context, _ string) ([]*domain.LedgerEntry, error) {
+		return []*domain.LedgerEntry{
+			{Delta: 20, Reason: domain.LedgerOpening, CreatedAt: at("2026-05-01")},
+			// Still leave year 2026 under an April reset
+			{Delta: -2, Reason: domain.LedgerVacation, CreatedAt: at("2027-03-15")},
+			{Delta: -3, Reason: domain.LedgerVacation, CreatedAt: at("2027-04-10")},
+		}, nil
+	}
+
+	statement, err := d.svc.Statement(ctx, "emp-1", 2027)
+
+	require.NoError(t, err)
+	assert.Equal(t, 18, statement.OpeningBalance)
+	assert.Equal(t, 3, statement.Taken)
+	assert.Equal(t, 15, statement.ClosingBalance)
+}
+
+func TestLeaveYearOf(t *testing.T) {
+	settings := domain.DefaultSettings()
+	date := time.Date(2028, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 2028, service.LeaveYearOf(&settings, date))
+
+	settings.VacationResetMonth = 4
+	assert.Equal(t, 2027, service.LeaveYearOf(&settings, date))
+	assert.Equal(t, 2028, service.LeaveYearOf(&settings, date.AddDate(0, 1, 0)))
+}
+
+func TestStatement_UserNotFound(t *testing.T) {
+	d := newServiceBundle()
+
+	_, err := d.svc.Statement(context.Background(), "missing", 2026)
+	assertVacationAppError(t, err, dto.ErrNotFound)
+}
+
+func TestStatement_LedgerError(t *testing.T) {
+	d := newServiceBundle()
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.ledgerRepo.ListByUserFn = func(_ context.Context, _ string) ([]*domain.LedgerEntry, error) {
+		return nil, errors.New("db error")
+	}
+
+	_, err := d.svc.Statement(context.Background(), "emp-1", 2026)
+	assertVacationAppError(t, err, dto.ErrInternal)
+}
+
+// =========================================================================
+// AnnualReport
+// =========================================================================
+
+func TestAnnualReport_Success(t *testing.T) {
+	d := newServiceBundle()
+	ctx := context.Background()
+
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 17), nil
+	}
+	d.vacationRepo.GetUserYearStatsFn = func(_ context.Context, userID string, year int) (*repository.UserYearStats, error) {
+		assert.Equal(t, "emp-1", userID)
+		assert.Equal(t, 2026, year)
+		stats := &repository.UserYearStats{TotalRequested: 4, TotalApproved: 2, TotalRejected: 1, TotalPending: 1, TotalDaysUsed: 8}
+		stats.DaysByMonth[1] = 3
+		stats.DaysByMonth[7] = 5
+		return stats, nil
+	}
+	d.vacationRepo.ListByUserFn = func(_ context.Context, _ string, status *domain.VacationStatus, year *int, _, _ string) ([]*domain.VacationRequest, error) {
+		require.NotNil(t, status)
+		assert.Equal(t, domain.StatusApproved, *status)
+		require.NotNil(t, year)
+		assert.Equal(t, 2026, *year)
+		return []*domain.VacationRequest{
+			newApprovedRequest("req-1", "emp-1", 3),
+			newApprovedRequest("req-2", "emp-1", 5),
+		}, nil
+	}
+
+	report, err := d.svc.AnnualReport(ctx, "emp-1", 2026)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2026, report.Year)
+	assert.Equal(t, 4, report.TotalRequested)
+	assert.Equal(t, 2, report.Approved)
+	assert.Equal(t, 1, report.Rejected)
+	assert.Equal(t, 1, report.Pending)
+	assert.Equal(t, 8, report.DaysUsed)
+	assert.Equal(t, 17, report.RemainingBalance)
+	require.Len(t, report.Months, 12)
+	assert.Equal(t, dto.MonthUsageResponse{Month: 1, DaysUsed: 0}, report.Months[0])
+	assert.Equal(t, dto.MonthUsageResponse{Month: 2, DaysUsed: 3}, report.Months[1])
+	assert.Equal(t, dto.MonthUsageResponse{Month: 8, DaysUsed: 5}, report.Months[7])
+	assert.Len(t, report.Requests, 2)
+}
+
+func TestAnnualReport_UserNotFound(t *testing.T) {
+	d := newServiceBundle()
+
+	_, err := d.svc.AnnualReport(context.Background(), "missing", 2026)
+	assertVacationAppError(t, err, dto.ErrNotFound)
+}
+
+func TestAnnualReport_StatsError(t *testing.T) {
+	d := newServiceBundle()
+	d.userRepo.GetByIDFn = func(_ context.Context, _ string) (*domain.User, error) {
+		return newTestEmployee("emp-1", 20), nil
+	}
+	d.vacationRepo.GetUserYearStatsFn = func(_ context.Context, _ string, _ int) (*repository.UserYearStats, error) {
+		return nil, errors.New("db error")
+	}
+
+	_, err := d.svc.AnnualReport(context.Background(), "emp-1", 2026)
 	assertVacationAppError(t, err, dto.ErrInternal)
 }
+
+// =========================================================================
+// AccrueMonthly
+// =========================================================================
+
+func accrualSettings() *domain.Settings {
+	settings := domain.DefaultSettings()
+	settings.AccrualEnabled = true
+	settings.AccrualDaysPerMonth = 2
+	return &settings
+}
+
+func TestAccrueMonthly_CreditsActiveEmployeesUpToCap(t *testing.T) {
+	d := newServiceBundle()
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return accrualSettings(), nil
+	}
+	inactive := newTestEmployee("emp-3", 10)
+	inactive.Active = false
+	d.userRepo.GetByRoleFn = func(_ context.Context, _ domain.Role) ([]*domain.User, error) {
+		return []*domain.User{newTestEmployee("emp-1", 10), newTestEmployee("emp-2", 24), inactive, newTestEmployee("emp-4", 25)}, nil
+	}
+	var claimedMonth string
+	d.settingsRepo.ClaimAccrualMonthTxFn = func(_ context.Context, _ *sql.Tx, month string) (bool, error) {
+		claimedMonth = month
+		return true, nil
+	}
+	// Balances as the transaction sees them; emp-1 was charged a day after the employees were loaded
+	balances := map[string]int{"emp-1": 9, "emp-2": 24, "emp-3": 10, "emp-4": 25}
+	d.userRepo.CreditVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, id string, amount, ceiling int) (int, error) {
+		credit := min(amount, ceiling-balances[id])
+		if credit <= 0 {
+			return 0, nil
+		}
+		balances[id] += credit
+		return credit, nil
+	}
+	var entries []*domain.LedgerEntry
+	d.ledgerRepo.CreateTxFn = func(_ context.Context, _ *sql.Tx, entry *domain.LedgerEntry) error {
+		entries = append(entries, entry)
+		return nil
+	}
+
+	count, err := d.svc.AccrueMonthly(context.Background(), time.Date(2027, 3, 1, 9, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "2027-03", claimedMonth)
+	assert.Equal(t, map[string]int{"emp-1": 11, "emp-2": 25, "emp-3": 10, "emp-4": 25}, balances)
+	require.Len(t, entries, 2)
+	assert.Equal(t, domain.LedgerAccrual, entries[0].Reason)
+	assert.Equal(t, 2, entries[0].Delta)
+	assert.Equal(t, 1, entries[1].Delta)
+}
+
+func TestAccrueMonthly_AlreadyAccruedThisMonth(t *testing.T) {
+	d := newServiceBundle()
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		settings := accrualSettings()
+		settings.LastAccrualMonth = "2027-03"
+		return settings, nil
+	}
+	d.userRepo.GetByRoleFn = func(_ context.Context, _ domain.Role) ([]*domain.User, error) {
+		t.Fatal("employees should not be loaded for an accrued month")
+		return nil, nil
+	}
+
+	count, err := d.svc.AccrueMonthly(context.Background(), time.Date(2027, 3, 20, 9, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestAccrueMonthly_LostClaimCreditsNothing(t *testing.T) {
+	d := newServiceBundle()
+	d.settingsRepo.GetFn = func(_ context.Context) (*domain.Settings, error) {
+		return accrualSettings(), nil
+	}
+	d.userRepo.GetByRoleFn = func(_ context.Context, _ domain.Role) ([]*domain.User, error) {
+		return []*domain.User{newTestEmployee("emp-1", 10)}, nil
+	}
+	d.settingsRepo.ClaimAccrualMonthTxFn = func(_ context.Context, _ *sql.Tx, _ string) (bool, error) {
+		return false, nil
+	}
+	d.userRepo.CreditVacationBalanceTxFn = func(_ context.Context, _ *sql.Tx, _ string, _, _ int) (int, error) {
+		t.Fatal("balance should not change when the month was already claimed")
+		return 0, nil
+	}
+
+	count, err := d.svc.AccrueMonthly(context.Background(), time.Date(2027, 3, 1, 9, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestAccrueMonthly_Disabled(t *testing.T) {
+	d := newServiceBundle()
+
+	count, err := d.svc.AccrueMonthly(context.Background(), time.Now())
+
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
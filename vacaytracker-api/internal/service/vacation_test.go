@@ -149,8 +149,8 @@ func TestCalculateBusinessDays(t *testing.T) {
 		},
 		{
 			name:  "Friday to Monday excluding weekends",
-			start: date(2025, 12, 5),  // Friday
-			end:   date(2025, 12, 8),  // Monday
+			start: date(2025, 12, 5), // Friday
+			end:   date(2025, 12, 8), // Monday
 			policy: domain.WeekendPolicy{
 				ExcludeWeekends: true,
 				ExcludedDays:    []int{0, 6},
@@ -244,3 +244,76 @@ func TestCalculateBusinessDays_EdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestCheckNotice(t *testing.T) {
+	date := func(year, month, day int) time.Time {
+		return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+	employee := &domain.User{ID: "emp-1", Role: domain.RoleEmployee}
+	admin := &domain.User{ID: "admin-1", Role: domain.RoleAdmin}
+	today := date(2025, 12, 1) // Monday
+
+	tests := []struct {
+		name    string
+		notice  int
+		user    *domain.User
+		start   time.Time
+		wantErr bool
+	}{
+		{"rule disabled", 0, employee, today, false},
+		{"exactly enough notice", 5, employee, date(2025, 12, 8), false}, // Mon-Fri before the start
+		{"weekend does not count", 6, employee, date(2025, 12, 8), true},
+		{"starting today", 1, employee, today, true},
+		{"admin bypasses rule", 30, admin, date(2025, 12, 2), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := domain.DefaultSettings()
+			settings.MinNoticeDays = tt.notice
+			err := checkNotice(&settings, tt.user, tt.start, today)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkNotice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDayBreakdown(t *testing.T) {
+	date := func(year, month, day int) time.Time {
+		return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+	standardPolicy := domain.WeekendPolicy{
+		ExcludeWeekends: true,
+		ExcludedDays:    []int{0, 6},
+	}
+
+	// Fri 13 Jun 2025 to Tue 17 Jun 2025 spans a weekend
+	days := dayBreakdown(date(2025, 6, 13), date(2025, 6, 17), standardPolicy)
+	want := []domain.RequestDay{
+		{Date: "2025-06-13", Type: domain.DayBusiness},
+		{Date: "2025-06-14", Type: domain.DayWeekend},
+		{Date: "2025-06-15", Type: domain.DayWeekend},
+		{Date: "2025-06-16", Type: domain.DayBusiness},
+		{Date: "2025-06-17", Type: domain.DayBusiness},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("dayBreakdown() returned %d days, want %d", len(days), len(want))
+	}
+	for i := range want {
+		if days[i] != want[i] {
+			t.Errorf("dayBreakdown()[%d] = %+v, want %+v", i, days[i], want[i])
+		}
+	}
+
+	// The business days in the breakdown always match the deducted total
+	business := 0
+	for _, day := range dayBreakdown(date(2025, 1, 1), date(2025, 3, 31), standardPolicy) {
+		if day.Type == domain.DayBusiness {
+			business++
+		}
+	}
+	if got := calculateBusinessDays(date(2025, 1, 1), date(2025, 3, 31), standardPolicy); business != got {
+		t.Errorf("breakdown has %d business days, calculateBusinessDays = %d", business, got)
+	}
+}
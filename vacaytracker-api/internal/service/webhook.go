@@ -0,0 +1,181 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/repository"
+)
+
+// WebhookEvent identifies a request lifecycle event posted to the webhook
+type WebhookEvent string
+
+const (
+	WebhookRequestSubmitted WebhookEvent = "request.submitted"
+	WebhookRequestApproved  WebhookEvent = "request.approved"
+	WebhookRequestRejected  WebhookEvent = "request.rejected"
+	WebhookRequestCancelled WebhookEvent = "request.cancelled"
+)
+
+// Webhook delivery configuration
+const (
+	webhookTimeout     = 5 * time.Second
+	webhookMaxAttempts = 3
+	webhookRetryDelay  = 500 * time.Millisecond
+)
+
+// WebhookPayload is the JSON body posted to the configured webhook URL
+// Text is a one-line summary so chat tools (Slack, Teams) can render it as-is
+type WebhookPayload struct {
+	Event     WebhookEvent          `json:"event"`
+	Text      string                `json:"text"`
+	Request   WebhookRequestSummary `json:"request"`
+	User      WebhookUser           `json:"user"`
+	Timestamp string                `json:"timestamp"`
+}
+
+// WebhookRequestSummary describes the vacation request in a webhook payload
+type WebhookRequestSummary struct {
+	ID        string  `json:"id"`
+	StartDate string  `json:"startDate"`
+	EndDate   string  `json:"endDate"`
+	TotalDays int     `json:"totalDays"`
+	Status    string  `json:"status"`
+	Reason    *string `json:"reason,omitempty"`
+}
+
+// WebhookUser identifies the request owner in a webhook payload
+type WebhookUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// WebhookService posts request lifecycle events to the webhook URL from settings
+type WebhookService struct {
+	settingsRepo repository.SettingsRepository
+	client       *http.Client
+	retryDelay   time.Duration
+}
+
+// NewWebhookService creates a new WebhookService
+func NewWebhookService(settingsRepo repository.SettingsRepository) *WebhookService {
+	return &WebhookService{
+		settingsRepo: settingsRepo,
+		client:       &http.Client{Timeout: webhookTimeout},
+		retryDelay:   webhookRetryDelay,
+	}
+}
+
+// Notify posts an event in the background; delivery failures are logged, never returned
+func (s *WebhookService) Notify(event WebhookEvent, user *domain.User, vacation *domain.VacationRequest) {
+	payload := newWebhookPayload(event, user, vacation, time.Now())
+	go func() {
+		if err := s.deliver(context.Background(), payload); err != nil {
+			log.Printf("[WEBHOOK ERROR] %s for request %s: %v", event, vacation.ID, err)
+		}
+	}()
+}
+
+// deliver posts the payload to the configured URL, retrying network errors and 5xx/429 responses
+// Does nothing when no webhook URL is configured
+func (s *WebhookService) deliver(ctx context.Context, payload WebhookPayload) error {
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get settings: %w", err)
+	}
+	if settings == nil || settings.WebhookURL == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("webhook cancelled: %w", ctx.Err())
+			case <-time.After(s.retryDelay * time.Duration(attempt-1)):
+			}
+		}
+
+		retryable, err := s.post(ctx, settings.WebhookURL, body)
+		if err == nil {
+			log.Printf("[WEBHOOK] Delivered %s for request %s", payload.Event, payload.Request.ID)
+			return nil
+		}
+		lastErr = err
+		if !retryable {
+			return err
+		}
+		log.Printf("[WEBHOOK] Attempt %d/%d failed for %s: %v", attempt, webhookMaxAttempts, payload.Event, err)
+	}
+
+	return fmt.Errorf("webhook failed after %d attempts: %w", webhookMaxAttempts, lastErr)
+}
+
+// post sends a single request and reports whether a failure is worth retrying
+func (s *WebhookService) post(ctx context.Context, url string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "VacayTracker-Webhook/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retryable, fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
+
+// newWebhookPayload builds the payload for an event
+func newWebhookPayload(event WebhookEvent, user *domain.User, vacation *domain.VacationRequest, now time.Time) WebhookPayload {
+	var verb string
+	switch event {
+	case WebhookRequestApproved:
+		verb = "was approved for"
+	case WebhookRequestRejected:
+		verb = "was rejected for"
+	case WebhookRequestCancelled:
+		verb = "cancelled"
+	default:
+		verb = "requested"
+	}
+
+	return WebhookPayload{
+		Event: event,
+		Text:  fmt.Sprintf("%s %s %d day(s) off (%s to %s)", user.Name, verb, vacation.TotalDays, vacation.StartDate, vacation.EndDate),
+		Request: WebhookRequestSummary{
+			ID:        vacation.ID,
+			StartDate: vacation.StartDate,
+			EndDate:   vacation.EndDate,
+			TotalDays: vacation.TotalDays,
+			Status:    string(vacation.Status),
+			Reason:    vacation.Reason,
+		},
+		User: WebhookUser{
+			ID:    user.ID,
+			Name:  user.Name,
+			Email: user.Email,
+		},
+		Timestamp: now.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vacaytracker-api/internal/domain"
+	"vacaytracker-api/internal/testutil"
+)
+
+func newWebhookTestService(url string) *WebhookService {
+	settingsRepo := &testutil.MockSettingsRepository{
+		GetFn: func(ctx context.Context) (*domain.Settings, error) {
+			settings := domain.DefaultSettings()
+			settings.WebhookURL = url
+			return &settings, nil
+		},
+	}
+	svc := NewWebhookService(settingsRepo)
+	svc.retryDelay = time.Millisecond
+	return svc
+}
+
+func webhookTestPayload(event WebhookEvent) WebhookPayload {
+	user := &domain.User{ID: "user-1", Name: "Alice", Email: "alice@test.com"}
+	vacation := &domain.VacationRequest{
+		ID:        "req-1",
+		UserID:    "user-1",
+		StartDate: "2026-07-06",
+		EndDate:   "2026-07-10",
+		TotalDays: 5,
+		Status:    domain.StatusApproved,
+	}
+	return newWebhookPayload(event, user, vacation, time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC))
+}
+
+func TestWebhookDeliver_PostsPayload(t *testing.T) {
+	var got WebhookPayload
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	svc := newWebhookTestService(server.URL)
+	err := svc.deliver(context.Background(), webhookTestPayload(WebhookRequestApproved))
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, WebhookRequestApproved, got.Event)
+	assert.Equal(t, "Alice was approved for 5 day(s) off (2026-07-06 to 2026-07-10)", got.Text)
+	assert.Equal(t, "req-1", got.Request.ID)
+	assert.Equal(t, "approved", got.Request.Status)
+	assert.Equal(t, 5, got.Request.TotalDays)
+	assert.Equal(t, "alice@test.com", got.User.Email)
+	assert.Equal(t, "2026-06-01T09:00:00Z", got.Timestamp)
+}
+
+func TestNewWebhookPayload_Cancelled(t *testing.T) {
+	payload := webhookTestPayload(WebhookRequestCancelled)
+
+	assert.Equal(t, WebhookRequestCancelled, payload.Event)
+	assert.Equal(t, "Alice cancelled 5 day(s) off (2026-07-06 to 2026-07-10)", payload.Text)
+}
+
+func TestWebhookDeliver_RetriesServerErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	svc := newWebhookTestService(server.URL)
+	err := svc.deliver(context.Background(), webhookTestPayload(WebhookRequestSubmitted))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestWebhookDeliver_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	svc := newWebhookTestService(server.URL)
+	err := svc.deliver(context.Background(), webhookTestPayload(WebhookRequestRejected))
+	assert.Error(t, err)
+	assert.Equal(t, int32(webhookMaxAttempts), atomic.LoadInt32(&calls))
+}
+
+func TestWebhookDeliver_ClientErrorNotRetried(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	svc := newWebhookTestService(server.URL)
+	err := svc.deliver(context.Background(), webhookTestPayload(WebhookRequestApproved))
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestWebhookDeliver_NoURLIsNoop(t *testing.T) {
+	svc := newWebhookTestService("")
+	assert.NoError(t, svc.deliver(context.Background(), webhookTestPayload(WebhookRequestApproved)))
+}
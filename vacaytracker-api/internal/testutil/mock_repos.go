@@ -12,23 +12,37 @@ import (
 // MockUserRepository is a mock implementation of repository.UserRepository.
 // Set function fields to customize behavior per test.
 type MockUserRepository struct {
-	CreateFn                func(ctx context.Context, user *domain.User) error
-	GetByIDFn               func(ctx context.Context, id string) (*domain.User, error)
-	GetByEmailFn            func(ctx context.Context, email string) (*domain.User, error)
-	GetAllFn                func(ctx context.Context, role *domain.Role, search string, limit, offset int) ([]*domain.User, int, error)
-	GetByRoleFn             func(ctx context.Context, role domain.Role) ([]*domain.User, error)
-	CountByRoleFn           func(ctx context.Context, role domain.Role) (int, error)
-	UpdateFn                func(ctx context.Context, user *domain.User) error
-	UpdatePasswordFn        func(ctx context.Context, id, passwordHash string) error
-	UpdateEmailPreferencesFn func(ctx context.Context, id string, prefs domain.EmailPreferences) error
-	UpdateVacationBalanceFn  func(ctx context.Context, id string, balance int) error
+	CreateFn                  func(ctx context.Context, user *domain.User) error
+	GetByIDFn                 func(ctx context.Context, id string) (*domain.User, error)
+	GetByEmailFn              func(ctx context.Context, email string) (*domain.User, error)
+	GetAllFn                  func(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, limit, offset int) ([]*domain.User, int, error)
+	GetAllAfterFn             func(ctx context.Context, filter domain.UserFilter, afterID string, limit int) ([]*domain.User, error)
+	GetByRoleFn               func(ctx context.Context, role domain.Role) ([]*domain.User, error)
+	ListByManagerFn           func(ctx context.Context, managerID string) ([]*domain.User, error)
+	ListByTeamFn              func(ctx context.Context, teamID string) ([]*domain.User, error)
+	CountByRoleFn             func(ctx context.Context, role domain.Role) (int, error)
+	CountActiveFn             func(ctx context.Context, teamID string) (int, error)
+	UpdateFn                  func(ctx context.Context, user *domain.User) error
+	UpdateTxFn                func(ctx context.Context, tx *sql.Tx, user *domain.User) error
+	UpdatePasswordFn          func(ctx context.Context, id, passwordHash string) error
+	UpdateEmailPreferencesFn  func(ctx context.Context, id string, prefs domain.EmailPreferences) error
+	TouchLastLoginFn          func(ctx context.Context, id string) error
+	SetPendingEmailFn         func(ctx context.Context, id, email string) error
+	ConfirmPendingEmailFn     func(ctx context.Context, id, email string) error
+	UpdateVacationBalanceFn   func(ctx context.Context, id string, balance int) error
 	UpdateVacationBalanceTxFn func(ctx context.Context, tx *sql.Tx, id string, balance int) error
-	DeleteFn                func(ctx context.Context, id string) error
-	EmailExistsFn           func(ctx context.Context, email string) (bool, error)
-	EmailExistsExcludingFn  func(ctx context.Context, email, excludeID string) (bool, error)
+	AddVacationBalanceTxFn    func(ctx context.Context, tx *sql.Tx, id string, delta, floor int) (int, error)
+	CreditVacationBalanceTxFn func(ctx context.Context, tx *sql.Tx, id string, amount, ceiling int) (int, error)
+	DeleteFn                  func(ctx context.Context, id string) error
+	RestoreFn                 func(ctx context.Context, id string) error
+	SetActiveFn               func(ctx context.Context, id string, active bool) error
+	EmailExistsFn             func(ctx context.Context, email string) (bool, error)
+	EmailExistsExcludingFn    func(ctx context.Context, email, excludeID string) (bool, error)
 	GetNewsletterRecipientsFn func(ctx context.Context) ([]*domain.User, error)
-	GetLowBalanceUsersFn    func(ctx context.Context, threshold int) ([]*domain.User, error)
-	UpdateAllBalancesFn     func(ctx context.Context, balance int) (int64, error)
+	GetLowBalanceUsersFn      func(ctx context.Context, threshold int) ([]*domain.User, error)
+	UpdateAllBalancesFn       func(ctx context.Context, balance int) (int64, error)
+	UpdateAllBalancesTxFn     func(ctx context.Context, tx *sql.Tx, balance int) (int64, error)
+	ListBalancesFn            func(ctx context.Context, year int, sort domain.UserSort) ([]*repository.EmployeeBalance, error)
 }
 
 func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
@@ -52,13 +66,20 @@ func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*dom
 	return nil, nil
 }
 
-func (m *MockUserRepository) GetAll(ctx context.Context, role *domain.Role, search string, limit, offset int) ([]*domain.User, int, error) {
+func (m *MockUserRepository) GetAll(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, limit, offset int) ([]*domain.User, int, error) {
 	if m.GetAllFn != nil {
-		return m.GetAllFn(ctx, role, search, limit, offset)
+		return m.GetAllFn(ctx, filter, sort, limit, offset)
 	}
 	return nil, 0, nil
 }
 
+func (m *MockUserRepository) GetAllAfter(ctx context.Context, filter domain.UserFilter, afterID string, limit int) ([]*domain.User, error) {
+	if m.GetAllAfterFn != nil {
+		return m.GetAllAfterFn(ctx, filter, afterID, limit)
+	}
+	return nil, nil
+}
+
 func (m *MockUserRepository) GetByRole(ctx context.Context, role domain.Role) ([]*domain.User, error) {
 	if m.GetByRoleFn != nil {
 		return m.GetByRoleFn(ctx, role)
@@ -66,6 +87,20 @@ func (m *MockUserRepository) GetByRole(ctx context.Context, role domain.Role) ([
 	return nil, nil
 }
 
+func (m *MockUserRepository) ListByManager(ctx context.Context, managerID string) ([]*domain.User, error) {
+	if m.ListByManagerFn != nil {
+		return m.ListByManagerFn(ctx, managerID)
+	}
+	return nil, nil
+}
+
+func (m *MockUserRepository) ListByTeam(ctx context.Context, teamID string) ([]*domain.User, error) {
+	if m.ListByTeamFn != nil {
+		return m.ListByTeamFn(ctx, teamID)
+	}
+	return nil, nil
+}
+
 func (m *MockUserRepository) CountByRole(ctx context.Context, role domain.Role) (int, error) {
 	if m.CountByRoleFn != nil {
 		return m.CountByRoleFn(ctx, role)
@@ -73,6 +108,13 @@ func (m *MockUserRepository) CountByRole(ctx context.Context, role domain.Role)
 	return 0, nil
 }
 
+func (m *MockUserRepository) CountActive(ctx context.Context, teamID string) (int, error) {
+	if m.CountActiveFn != nil {
+		return m.CountActiveFn(ctx, teamID)
+	}
+	return 0, nil
+}
+
 func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	if m.UpdateFn != nil {
 		return m.UpdateFn(ctx, user)
@@ -80,6 +122,13 @@ func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) erro
 	return nil
 }
 
+func (m *MockUserRepository) UpdateTx(ctx context.Context, tx *sql.Tx, user *domain.User) error {
+	if m.UpdateTxFn != nil {
+		return m.UpdateTxFn(ctx, tx, user)
+	}
+	return nil
+}
+
 func (m *MockUserRepository) UpdatePassword(ctx context.Context, id, passwordHash string) error {
 	if m.UpdatePasswordFn != nil {
 		return m.UpdatePasswordFn(ctx, id, passwordHash)
@@ -94,6 +143,27 @@ func (m *MockUserRepository) UpdateEmailPreferences(ctx context.Context, id stri
 	return nil
 }
 
+func (m *MockUserRepository) TouchLastLogin(ctx context.Context, id string) error {
+	if m.TouchLastLoginFn != nil {
+		return m.TouchLastLoginFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) SetPendingEmail(ctx context.Context, id, email string) error {
+	if m.SetPendingEmailFn != nil {
+		return m.SetPendingEmailFn(ctx, id, email)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) ConfirmPendingEmail(ctx context.Context, id, email string) error {
+	if m.ConfirmPendingEmailFn != nil {
+		return m.ConfirmPendingEmailFn(ctx, id, email)
+	}
+	return nil
+}
+
 func (m *MockUserRepository) UpdateVacationBalance(ctx context.Context, id string, balance int) error {
 	if m.UpdateVacationBalanceFn != nil {
 		return m.UpdateVacationBalanceFn(ctx, id, balance)
@@ -108,6 +178,20 @@ func (m *MockUserRepository) UpdateVacationBalanceTx(ctx context.Context, tx *sq
 	return nil
 }
 
+func (m *MockUserRepository) AddVacationBalanceTx(ctx context.Context, tx *sql.Tx, id string, delta, floor int) (int, error) {
+	if m.AddVacationBalanceTxFn != nil {
+		return m.AddVacationBalanceTxFn(ctx, tx, id, delta, floor)
+	}
+	return 0, nil
+}
+
+func (m *MockUserRepository) CreditVacationBalanceTx(ctx context.Context, tx *sql.Tx, id string, amount, ceiling int) (int, error) {
+	if m.CreditVacationBalanceTxFn != nil {
+		return m.CreditVacationBalanceTxFn(ctx, tx, id, amount, ceiling)
+	}
+	return amount, nil
+}
+
 func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
 	if m.DeleteFn != nil {
 		return m.DeleteFn(ctx, id)
@@ -115,6 +199,20 @@ func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (m *MockUserRepository) Restore(ctx context.Context, id string) error {
+	if m.RestoreFn != nil {
+		return m.RestoreFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) SetActive(ctx context.Context, id string, active bool) error {
+	if m.SetActiveFn != nil {
+		return m.SetActiveFn(ctx, id, active)
+	}
+	return nil
+}
+
 func (m *MockUserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
 	if m.EmailExistsFn != nil {
 		return m.EmailExistsFn(ctx, email)
@@ -150,19 +248,50 @@ func (m *MockUserRepository) UpdateAllBalances(ctx context.Context, balance int)
 	return 0, nil
 }
 
+func (m *MockUserRepository) UpdateAllBalancesTx(ctx context.Context, tx *sql.Tx, balance int) (int64, error) {
+	if m.UpdateAllBalancesTxFn != nil {
+		return m.UpdateAllBalancesTxFn(ctx, tx, balance)
+	}
+	return 0, nil
+}
+
+func (m *MockUserRepository) ListBalances(ctx context.Context, year int, sort domain.UserSort) ([]*repository.EmployeeBalance, error) {
+	if m.ListBalancesFn != nil {
+		return m.ListBalancesFn(ctx, year, sort)
+	}
+	return nil, nil
+}
+
 // MockVacationRepository is a mock implementation of repository.VacationRepository.
 type MockVacationRepository struct {
-	CreateFn        func(ctx context.Context, req *domain.VacationRequest) error
-	CreateTxFn      func(ctx context.Context, tx *sql.Tx, req *domain.VacationRequest) error
-	GetByIDFn       func(ctx context.Context, id string) (*domain.VacationRequest, error)
-	ListByUserFn    func(ctx context.Context, userID string, status *domain.VacationStatus, year *int) ([]*domain.VacationRequest, error)
-	ListPendingFn   func(ctx context.Context) ([]*domain.VacationRequest, error)
-	ListTeamFn      func(ctx context.Context, month, year int) ([]*domain.TeamVacation, error)
-	UpdateStatusFn  func(ctx context.Context, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error
-	UpdateStatusTxFn func(ctx context.Context, tx *sql.Tx, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error
-	DeleteFn        func(ctx context.Context, id string) error
-	HasOverlapFn    func(ctx context.Context, userID, startDate, endDate string) (bool, error)
-	GetMonthlyStatsFn func(ctx context.Context, year, month int) (*repository.MonthlyStats, error)
+	CreateFn                  func(ctx context.Context, req *domain.VacationRequest) error
+	CreateTxFn                func(ctx context.Context, tx *sql.Tx, req *domain.VacationRequest) error
+	GetByIDFn                 func(ctx context.Context, id string) (*domain.VacationRequest, error)
+	GetByIDsFn                func(ctx context.Context, ids []string) ([]*domain.VacationRequest, error)
+	ListByUserFn              func(ctx context.Context, userID string, status *domain.VacationStatus, year *int, from, to string) ([]*domain.VacationRequest, error)
+	ListPendingFn             func(ctx context.Context, from, to string) ([]*domain.VacationRequest, error)
+	ListStalePendingFn        func(ctx context.Context, createdBefore time.Time) ([]*domain.VacationRequest, error)
+	ListApprovedStartingOnFn  func(ctx context.Context, date string) ([]*domain.VacationRequest, error)
+	ListCreatedBetweenFn      func(ctx context.Context, from, to string) ([]*domain.VacationRequest, error)
+	ListTeamFn                func(ctx context.Context, month, year int, teamID string) ([]*domain.TeamVacation, error)
+	ListTeamWithPendingFn     func(ctx context.Context, month, year int, teamID string) ([]*domain.TeamVacation, error)
+	ListTeamRangeFn           func(ctx context.Context, from, to, teamID string) ([]*domain.TeamVacation, error)
+	ListUpcomingApprovedFn    func(ctx context.Context, from, to string) ([]*domain.TeamVacation, error)
+	UpdateStatusFn            func(ctx context.Context, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error
+	UpdateStatusTxFn          func(ctx context.Context, tx *sql.Tx, id string, status domain.VacationStatus, reviewedBy string, rejectionReason *string) error
+	AdvanceApprovalStepFn     func(ctx context.Context, id string, step int, status domain.VacationStatus, reviewedBy string, comment *string) error
+	UpdateApprovalCommentTxFn func(ctx context.Context, tx *sql.Tx, id string, comment string) error
+	UpdateBalanceOverrideTxFn func(ctx context.Context, tx *sql.Tx, id string, reason string) error
+	AdjustDatesTxFn           func(ctx context.Context, tx *sql.Tx, id, startDate, endDate string, totalDays int) error
+	PromoteTentativeTxFn      func(ctx context.Context, tx *sql.Tx, id string, status domain.VacationStatus, totalDays int) error
+	ClaimStartReminderFn      func(ctx context.Context, id string) (bool, error)
+	UpdateTotalDaysFn         func(ctx context.Context, id string, totalDays int) error
+	DeleteFn                  func(ctx context.Context, id string) error
+	DeleteTxFn                func(ctx context.Context, tx *sql.Tx, id string) error
+	HasOverlapFn              func(ctx context.Context, userID, startDate, endDate string, allowTouching bool, types []domain.RequestType) (bool, error)
+	GetMonthlyStatsFn         func(ctx context.Context, year, month int, teamID string) (*repository.MonthlyStats, error)
+	GetYearlyStatsFn          func(ctx context.Context, year int) (*repository.YearlyStats, error)
+	GetUserYearStatsFn        func(ctx context.Context, userID string, year int) (*repository.UserYearStats, error)
 }
 
 func (m *MockVacationRepository) Create(ctx context.Context, req *domain.VacationRequest) error {
@@ -186,23 +315,72 @@ func (m *MockVacationRepository) GetByID(ctx context.Context, id string) (*domai
 	return nil, nil
 }
 
-func (m *MockVacationRepository) ListByUser(ctx context.Context, userID string, status *domain.VacationStatus, year *int) ([]*domain.VacationRequest, error) {
+func (m *MockVacationRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.VacationRequest, error) {
+	if m.GetByIDsFn != nil {
+		return m.GetByIDsFn(ctx, ids)
+	}
+	return nil, nil
+}
+
+func (m *MockVacationRepository) ListByUser(ctx context.Context, userID string, status *domain.VacationStatus, year *int, from, to string) ([]*domain.VacationRequest, error) {
 	if m.ListByUserFn != nil {
-		return m.ListByUserFn(ctx, userID, status, year)
+		return m.ListByUserFn(ctx, userID, status, year, from, to)
 	}
 	return nil, nil
 }
 
-func (m *MockVacationRepository) ListPending(ctx context.Context) ([]*domain.VacationRequest, error) {
+func (m *MockVacationRepository) ListPending(ctx context.Context, from, to string) ([]*domain.VacationRequest, error) {
 	if m.ListPendingFn != nil {
-		return m.ListPendingFn(ctx)
+		return m.ListPendingFn(ctx, from, to)
+	}
+	return nil, nil
+}
+
+func (m *MockVacationRepository) ListStalePending(ctx context.Context, createdBefore time.Time) ([]*domain.VacationRequest, error) {
+	if m.ListStalePendingFn != nil {
+		return m.ListStalePendingFn(ctx, createdBefore)
 	}
 	return nil, nil
 }
 
-func (m *MockVacationRepository) ListTeam(ctx context.Context, month, year int) ([]*domain.TeamVacation, error) {
+func (m *MockVacationRepository) ListApprovedStartingOn(ctx context.Context, date string) ([]*domain.VacationRequest, error) {
+	if m.ListApprovedStartingOnFn != nil {
+		return m.ListApprovedStartingOnFn(ctx, date)
+	}
+	return nil, nil
+}
+
+func (m *MockVacationRepository) ListCreatedBetween(ctx context.Context, from, to string) ([]*domain.VacationRequest, error) {
+	if m.ListCreatedBetweenFn != nil {
+		return m.ListCreatedBetweenFn(ctx, from, to)
+	}
+	return nil, nil
+}
+
+func (m *MockVacationRepository) ListTeam(ctx context.Context, month, year int, teamID string) ([]*domain.TeamVacation, error) {
 	if m.ListTeamFn != nil {
-		return m.ListTeamFn(ctx, month, year)
+		return m.ListTeamFn(ctx, month, year, teamID)
+	}
+	return nil, nil
+}
+
+func (m *MockVacationRepository) ListTeamWithPending(ctx context.Context, month, year int, teamID string) ([]*domain.TeamVacation, error) {
+	if m.ListTeamWithPendingFn != nil {
+		return m.ListTeamWithPendingFn(ctx, month, year, teamID)
+	}
+	return nil, nil
+}
+
+func (m *MockVacationRepository) ListTeamRange(ctx context.Context, from, to, teamID string) ([]*domain.TeamVacation, error) {
+	if m.ListTeamRangeFn != nil {
+		return m.ListTeamRangeFn(ctx, from, to, teamID)
+	}
+	return nil, nil
+}
+
+func (m *MockVacationRepository) ListUpcomingApproved(ctx context.Context, from, to string) ([]*domain.TeamVacation, error) {
+	if m.ListUpcomingApprovedFn != nil {
+		return m.ListUpcomingApprovedFn(ctx, from, to)
 	}
 	return nil, nil
 }
@@ -221,6 +399,55 @@ func (m *MockVacationRepository) UpdateStatusTx(ctx context.Context, tx *sql.Tx,
 	return nil
 }
 
+func (m *MockVacationRepository) AdvanceApprovalStep(ctx context.Context, id string, step int, status domain.VacationStatus, reviewedBy string, comment *string) error {
+	if m.AdvanceApprovalStepFn != nil {
+		return m.AdvanceApprovalStepFn(ctx, id, step, status, reviewedBy, comment)
+	}
+	return nil
+}
+
+func (m *MockVacationRepository) UpdateApprovalCommentTx(ctx context.Context, tx *sql.Tx, id string, comment string) error {
+	if m.UpdateApprovalCommentTxFn != nil {
+		return m.UpdateApprovalCommentTxFn(ctx, tx, id, comment)
+	}
+	return nil
+}
+
+func (m *MockVacationRepository) UpdateBalanceOverrideTx(ctx context.Context, tx *sql.Tx, id string, reason string) error {
+	if m.UpdateBalanceOverrideTxFn != nil {
+		return m.UpdateBalanceOverrideTxFn(ctx, tx, id, reason)
+	}
+	return nil
+}
+
+func (m *MockVacationRepository) AdjustDatesTx(ctx context.Context, tx *sql.Tx, id, startDate, endDate string, totalDays int) error {
+	if m.AdjustDatesTxFn != nil {
+		return m.AdjustDatesTxFn(ctx, tx, id, startDate, endDate, totalDays)
+	}
+	return nil
+}
+
+func (m *MockVacationRepository) PromoteTentativeTx(ctx context.Context, tx *sql.Tx, id string, status domain.VacationStatus, totalDays int) error {
+	if m.PromoteTentativeTxFn != nil {
+		return m.PromoteTentativeTxFn(ctx, tx, id, status, totalDays)
+	}
+	return nil
+}
+
+func (m *MockVacationRepository) ClaimStartReminder(ctx context.Context, id string) (bool, error) {
+	if m.ClaimStartReminderFn != nil {
+		return m.ClaimStartReminderFn(ctx, id)
+	}
+	return true, nil
+}
+
+func (m *MockVacationRepository) UpdateTotalDays(ctx context.Context, id string, totalDays int) error {
+	if m.UpdateTotalDaysFn != nil {
+		return m.UpdateTotalDaysFn(ctx, id, totalDays)
+	}
+	return nil
+}
+
 func (m *MockVacationRepository) Delete(ctx context.Context, id string) error {
 	if m.DeleteFn != nil {
 		return m.DeleteFn(ctx, id)
@@ -228,25 +455,48 @@ func (m *MockVacationRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (m *MockVacationRepository) HasOverlap(ctx context.Context, userID, startDate, endDate string) (bool, error) {
+func (m *MockVacationRepository) DeleteTx(ctx context.Context, tx *sql.Tx, id string) error {
+	if m.DeleteTxFn != nil {
+		return m.DeleteTxFn(ctx, tx, id)
+	}
+	return nil
+}
+
+func (m *MockVacationRepository) HasOverlap(ctx context.Context, userID, startDate, endDate string, allowTouching bool, types []domain.RequestType) (bool, error) {
 	if m.HasOverlapFn != nil {
-		return m.HasOverlapFn(ctx, userID, startDate, endDate)
+		return m.HasOverlapFn(ctx, userID, startDate, endDate, allowTouching, types)
 	}
 	return false, nil
 }
 
-func (m *MockVacationRepository) GetMonthlyStats(ctx context.Context, year, month int) (*repository.MonthlyStats, error) {
+func (m *MockVacationRepository) GetMonthlyStats(ctx context.Context, year, month int, teamID string) (*repository.MonthlyStats, error) {
 	if m.GetMonthlyStatsFn != nil {
-		return m.GetMonthlyStatsFn(ctx, year, month)
+		return m.GetMonthlyStatsFn(ctx, year, month, teamID)
 	}
 	return &repository.MonthlyStats{}, nil
 }
 
+func (m *MockVacationRepository) GetYearlyStats(ctx context.Context, year int) (*repository.YearlyStats, error) {
+	if m.GetYearlyStatsFn != nil {
+		return m.GetYearlyStatsFn(ctx, year)
+	}
+	return &repository.YearlyStats{}, nil
+}
+
+func (m *MockVacationRepository) GetUserYearStats(ctx context.Context, userID string, year int) (*repository.UserYearStats, error) {
+	if m.GetUserYearStatsFn != nil {
+		return m.GetUserYearStatsFn(ctx, userID, year)
+	}
+	return &repository.UserYearStats{}, nil
+}
+
 // MockSettingsRepository is a mock implementation of repository.SettingsRepository.
 type MockSettingsRepository struct {
-	GetFn                    func(ctx context.Context) (*domain.Settings, error)
-	UpdateFn                 func(ctx context.Context, settings *domain.Settings) error
-	UpdateLastNewsletterSentFn func(ctx context.Context, sentAt time.Time) error
+	GetFn                          func(ctx context.Context) (*domain.Settings, error)
+	UpdateFn                       func(ctx context.Context, settings *domain.Settings) error
+	UpdateLastNewsletterSentFn     func(ctx context.Context, sentAt time.Time) error
+	UpdateLastPersonalDigestSentFn func(ctx context.Context, sentAt time.Time) error
+	ClaimAccrualMonthTxFn          func(ctx context.Context, tx *sql.Tx, month string) (bool, error)
 }
 
 func (m *MockSettingsRepository) Get(ctx context.Context) (*domain.Settings, error) {
@@ -271,6 +521,228 @@ func (m *MockSettingsRepository) UpdateLastNewsletterSent(ctx context.Context, s
 	return nil
 }
 
+func (m *MockSettingsRepository) UpdateLastPersonalDigestSent(ctx context.Context, sentAt time.Time) error {
+	if m.UpdateLastPersonalDigestSentFn != nil {
+		return m.UpdateLastPersonalDigestSentFn(ctx, sentAt)
+	}
+	return nil
+}
+
+func (m *MockSettingsRepository) ClaimAccrualMonthTx(ctx context.Context, tx *sql.Tx, month string) (bool, error) {
+	if m.ClaimAccrualMonthTxFn != nil {
+		return m.ClaimAccrualMonthTxFn(ctx, tx, month)
+	}
+	return true, nil
+}
+
+// MockLedgerRepository is a mock implementation of repository.LedgerRepository.
+type MockLedgerRepository struct {
+	CreateFn     func(ctx context.Context, entry *domain.LedgerEntry) error
+	CreateTxFn   func(ctx context.Context, tx *sql.Tx, entry *domain.LedgerEntry) error
+	ListByUserFn func(ctx context.Context, userID string) ([]*domain.LedgerEntry, error)
+	SumByUserFn  func(ctx context.Context) (map[string]int, error)
+}
+
+func (m *MockLedgerRepository) Create(ctx context.Context, entry *domain.LedgerEntry) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, entry)
+	}
+	return nil
+}
+
+func (m *MockLedgerRepository) CreateTx(ctx context.Context, tx *sql.Tx, entry *domain.LedgerEntry) error {
+	if m.CreateTxFn != nil {
+		return m.CreateTxFn(ctx, tx, entry)
+	}
+	return nil
+}
+
+func (m *MockLedgerRepository) ListByUser(ctx context.Context, userID string) ([]*domain.LedgerEntry, error) {
+	if m.ListByUserFn != nil {
+		return m.ListByUserFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockLedgerRepository) SumByUser(ctx context.Context) (map[string]int, error) {
+	if m.SumByUserFn != nil {
+		return m.SumByUserFn(ctx)
+	}
+	return map[string]int{}, nil
+}
+
+// MockRefreshTokenRepository is a mock implementation of repository.RefreshTokenRepository.
+type MockRefreshTokenRepository struct {
+	CreateFn           func(ctx context.Context, token *domain.RefreshToken) error
+	GetByHashFn        func(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	RevokeFn           func(ctx context.Context, id string) (bool, error)
+	RevokeAllForUserFn func(ctx context.Context, userID string) error
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, token)
+	}
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	if m.GetByHashFn != nil {
+		return m.GetByHashFn(ctx, tokenHash)
+	}
+	return nil, nil
+}
+
+func (m *MockRefreshTokenRepository) Revoke(ctx context.Context, id string) (bool, error) {
+	if m.RevokeFn != nil {
+		return m.RevokeFn(ctx, id)
+	}
+	return true, nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	if m.RevokeAllForUserFn != nil {
+		return m.RevokeAllForUserFn(ctx, userID)
+	}
+	return nil
+}
+
+// MockEmailOutboxRepository is a mock implementation of repository.EmailOutboxRepository.
+type MockEmailOutboxRepository struct {
+	CreateFn         func(ctx context.Context, msg *domain.EmailMessage) error
+	UpdateDeliveryFn func(ctx context.Context, msg *domain.EmailMessage) error
+	ListDueFn        func(ctx context.Context, now time.Time, limit int) ([]*domain.EmailMessage, error)
+	ListRecentFn     func(ctx context.Context, limit int) ([]*domain.EmailMessage, error)
+}
+
+func (m *MockEmailOutboxRepository) Create(ctx context.Context, msg *domain.EmailMessage) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, msg)
+	}
+	return nil
+}
+
+func (m *MockEmailOutboxRepository) UpdateDelivery(ctx context.Context, msg *domain.EmailMessage) error {
+	if m.UpdateDeliveryFn != nil {
+		return m.UpdateDeliveryFn(ctx, msg)
+	}
+	return nil
+}
+
+func (m *MockEmailOutboxRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.EmailMessage, error) {
+	if m.ListDueFn != nil {
+		return m.ListDueFn(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockEmailOutboxRepository) ListRecent(ctx context.Context, limit int) ([]*domain.EmailMessage, error) {
+	if m.ListRecentFn != nil {
+		return m.ListRecentFn(ctx, limit)
+	}
+	return nil, nil
+}
+
+// MockTeamRepository is a mock implementation of repository.TeamRepository.
+type MockTeamRepository struct {
+	CreateFn              func(ctx context.Context, team *domain.Team) error
+	GetByIDFn             func(ctx context.Context, id string) (*domain.Team, error)
+	ListFn                func(ctx context.Context) ([]*domain.Team, error)
+	UpdateFn              func(ctx context.Context, team *domain.Team) error
+	DeleteFn              func(ctx context.Context, id string) error
+	NameExistsExcludingFn func(ctx context.Context, name, excludeID string) (bool, error)
+}
+
+func (m *MockTeamRepository) Create(ctx context.Context, team *domain.Team) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, team)
+	}
+	return nil
+}
+
+func (m *MockTeamRepository) GetByID(ctx context.Context, id string) (*domain.Team, error) {
+	if m.GetByIDFn != nil {
+		return m.GetByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockTeamRepository) List(ctx context.Context) ([]*domain.Team, error) {
+	if m.ListFn != nil {
+		return m.ListFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockTeamRepository) Update(ctx context.Context, team *domain.Team) error {
+	if m.UpdateFn != nil {
+		return m.UpdateFn(ctx, team)
+	}
+	return nil
+}
+
+func (m *MockTeamRepository) Delete(ctx context.Context, id string) error {
+	if m.DeleteFn != nil {
+		return m.DeleteFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockTeamRepository) NameExistsExcluding(ctx context.Context, name, excludeID string) (bool, error) {
+	if m.NameExistsExcludingFn != nil {
+		return m.NameExistsExcludingFn(ctx, name, excludeID)
+	}
+	return false, nil
+}
+
+// MockCommentRepository is a mock implementation of repository.CommentRepository.
+type MockCommentRepository struct {
+	CreateFn        func(ctx context.Context, comment *domain.RequestComment) error
+	ListByRequestFn func(ctx context.Context, requestID string) ([]*domain.RequestComment, error)
+}
+
+func (m *MockCommentRepository) Create(ctx context.Context, comment *domain.RequestComment) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, comment)
+	}
+	return nil
+}
+
+func (m *MockCommentRepository) ListByRequest(ctx context.Context, requestID string) ([]*domain.RequestComment, error) {
+	if m.ListByRequestFn != nil {
+		return m.ListByRequestFn(ctx, requestID)
+	}
+	return []*domain.RequestComment{}, nil
+}
+
+// MockAttachmentRepository is a mock implementation of repository.AttachmentRepository.
+type MockAttachmentRepository struct {
+	CreateFn        func(ctx context.Context, attachment *domain.Attachment) error
+	GetByIDFn       func(ctx context.Context, id string) (*domain.Attachment, error)
+	ListByRequestFn func(ctx context.Context, requestID string) ([]*domain.Attachment, error)
+}
+
+func (m *MockAttachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, attachment)
+	}
+	return nil
+}
+
+func (m *MockAttachmentRepository) GetByID(ctx context.Context, id string) (*domain.Attachment, error) {
+	if m.GetByIDFn != nil {
+		return m.GetByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockAttachmentRepository) ListByRequest(ctx context.Context, requestID string) ([]*domain.Attachment, error) {
+	if m.ListByRequestFn != nil {
+		return m.ListByRequestFn(ctx, requestID)
+	}
+	return []*domain.Attachment{}, nil
+}
+
 // MockTransactor is a mock implementation of repository.Transactor.
 type MockTransactor struct {
 	TransactionFn func(fn func(tx *sql.Tx) error) error
@@ -283,3 +755,23 @@ func (m *MockTransactor) Transaction(fn func(tx *sql.Tx) error) error {
 	// Default: execute the function with a nil tx (for simple tests)
 	return fn(nil)
 }
+
+// MockAuditRepository is a mock implementation of repository.AuditRepository.
+type MockAuditRepository struct {
+	CreateFn func(ctx context.Context, entry *domain.AuditEntry) error
+	ListFn   func(ctx context.Context, filter domain.AuditFilter, limit, offset int) ([]*domain.AuditEntry, int, error)
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, entry)
+	}
+	return nil
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, filter domain.AuditFilter, limit, offset int) ([]*domain.AuditEntry, int, error) {
+	if m.ListFn != nil {
+		return m.ListFn(ctx, filter, limit, offset)
+	}
+	return []*domain.AuditEntry{}, 0, nil
+}
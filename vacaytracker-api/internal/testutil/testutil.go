@@ -21,7 +21,7 @@ func SetupTestDB(t *testing.T) *sqlite.DB {
 	t.Helper()
 
 	dbPath := filepath.Join(t.TempDir(), "test.db")
-	db, err := sqlite.New(dbPath)
+	db, err := sqlite.New(dbPath, sqlite.DefaultPoolConfig())
 	require.NoError(t, err)
 
 	// Find migrations directory relative to project root
@@ -103,5 +103,5 @@ func SetAuthContext(c *gin.Context, userID, email, name string, role domain.Role
 	c.Set("userID", userID)
 	c.Set("email", email)
 	c.Set("name", name)
-	c.Set("role", string(role))
+	c.Set("role", role)
 }
@@ -0,0 +1,52 @@
+-- ============================================
+-- Multi-level approval workflow
+-- Migration: 002_approval_levels
+-- ============================================
+
+-- Approval chain configuration (JSON array of {name, approverId})
+-- An empty chain keeps the single admin approval
+ALTER TABLE settings ADD COLUMN approval_levels TEXT NOT NULL DEFAULT '[]';
+
+-- Rebuild vacation_requests to allow the 'awaiting_final' status
+-- and to track the current position in the approval chain
+CREATE TABLE vacation_requests_new (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    total_days INTEGER NOT NULL,
+    reason TEXT,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'awaiting_final', 'approved', 'rejected')),
+    approval_step INTEGER NOT NULL DEFAULT 0,
+    reviewed_by TEXT,
+    reviewed_at TEXT,
+    rejection_reason TEXT,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+INSERT INTO vacation_requests_new (
+    id, user_id, start_date, end_date, total_days, reason, status,
+    reviewed_by, reviewed_at, rejection_reason, created_at, updated_at
+)
+SELECT
+    id, user_id, start_date, end_date, total_days, reason, status,
+    reviewed_by, reviewed_at, rejection_reason, created_at, updated_at
+FROM vacation_requests;
+
+DROP TABLE vacation_requests;
+
+ALTER TABLE vacation_requests_new RENAME TO vacation_requests;
+
+-- Recreate indexes and triggers dropped with the old table
+CREATE INDEX IF NOT EXISTS idx_vacation_requests_user_id ON vacation_requests(user_id);
+CREATE INDEX IF NOT EXISTS idx_vacation_requests_status ON vacation_requests(status);
+
+CREATE TRIGGER IF NOT EXISTS vacation_requests_updated_at
+    AFTER UPDATE ON vacation_requests
+    FOR EACH ROW
+BEGIN
+    UPDATE vacation_requests SET updated_at = datetime('now') WHERE id = NEW.id;
+END;
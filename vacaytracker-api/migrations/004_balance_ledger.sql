@@ -0,0 +1,33 @@
+-- ============================================
+-- Vacation balance ledger
+-- Migration: 004_balance_ledger
+-- ============================================
+
+-- Every change to a user's vacation balance is recorded as a signed delta
+-- The sum of a user's entries should equal users.vacation_balance
+CREATE TABLE IF NOT EXISTS balance_ledger (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    delta INTEGER NOT NULL,
+    reason TEXT NOT NULL CHECK (reason IN ('opening', 'vacation', 'adjustment', 'reset')),
+    reference_id TEXT,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Index for per-user ledger lookups
+CREATE INDEX IF NOT EXISTS idx_balance_ledger_user_id ON balance_ledger(user_id);
+
+-- Opening entry for users that already exist
+INSERT INTO balance_ledger (id, user_id, delta, reason)
+SELECT lower(hex(randomblob(16))), id, vacation_balance, 'opening'
+FROM users;
+
+-- Opening entry for every new user, whichever code path creates it
+CREATE TRIGGER IF NOT EXISTS users_opening_balance
+    AFTER INSERT ON users
+    FOR EACH ROW
+BEGIN
+    INSERT INTO balance_ledger (id, user_id, delta, reason)
+    VALUES (lower(hex(randomblob(16))), NEW.id, NEW.vacation_balance, 'opening');
+END;
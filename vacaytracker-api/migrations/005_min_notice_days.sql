@@ -0,0 +1,7 @@
+-- ============================================
+-- Minimum notice period for vacation requests
+-- Migration: 005_min_notice_days
+-- ============================================
+
+-- Business days of notice required before a request's start date (0 disables the rule)
+ALTER TABLE settings ADD COLUMN min_notice_days INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- ============================================
+-- Approval comments
+-- Migration: 006_approval_comments
+-- ============================================
+
+-- When enabled, approvers must explain why they approved a request
+ALTER TABLE settings ADD COLUMN approval_comment_required INTEGER NOT NULL DEFAULT 0;
+
+-- Comment left by the most recent approver
+ALTER TABLE vacation_requests ADD COLUMN approval_comment TEXT;
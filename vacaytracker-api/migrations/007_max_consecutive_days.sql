@@ -0,0 +1,7 @@
+-- ============================================
+-- Maximum consecutive vacation days
+-- Migration: 007_max_consecutive_days
+-- ============================================
+
+-- Largest number of business days a single request may cover (0 = unlimited)
+ALTER TABLE settings ADD COLUMN max_consecutive_days INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,7 @@
+-- ============================================
+-- Blackout periods
+-- Migration: 008_blackout_periods
+-- ============================================
+
+-- Date ranges where new leave is disallowed (JSON array of {id, startDate, endDate, reason})
+ALTER TABLE settings ADD COLUMN blackout_periods TEXT NOT NULL DEFAULT '[]';
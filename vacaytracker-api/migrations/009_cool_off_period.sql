@@ -0,0 +1,10 @@
+-- ============================================
+-- Cool-off period between long vacations
+-- Migration: 009_cool_off_period
+-- ============================================
+
+-- Requests longer than long_vacation_days business days count as long (0 disables the rule)
+ALTER TABLE settings ADD COLUMN long_vacation_days INTEGER NOT NULL DEFAULT 0;
+
+-- Minimum calendar days between two long vacations of the same user
+ALTER TABLE settings ADD COLUMN cool_off_days INTEGER NOT NULL DEFAULT 0;
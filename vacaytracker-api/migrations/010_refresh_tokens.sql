@@ -0,0 +1,19 @@
+-- ============================================
+-- Refresh tokens
+-- Migration: 010_refresh_tokens
+-- ============================================
+
+-- Issued refresh tokens, stored as SHA-256 hashes so a database leak can't be replayed
+-- A token is revoked when it is rotated on refresh
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TEXT NOT NULL,
+    revoked_at TEXT,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Index for revoking all of a user's tokens
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);
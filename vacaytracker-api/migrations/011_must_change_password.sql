@@ -0,0 +1,7 @@
+-- ============================================
+-- Forced password change
+-- Migration: 011_must_change_password
+-- ============================================
+
+-- Set for users created with a temporary password; cleared when they change it
+ALTER TABLE users ADD COLUMN must_change_password INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- ============================================
+-- Manager hierarchy
+-- Migration: 012_manager_hierarchy
+-- ============================================
+
+-- A user's direct manager; managers can review their reports' requests
+ALTER TABLE users ADD COLUMN manager_id TEXT REFERENCES users(id) ON DELETE SET NULL;
+
+-- Index for listing a manager's direct reports
+CREATE INDEX IF NOT EXISTS idx_users_manager_id ON users(manager_id);
@@ -0,0 +1,18 @@
+-- ============================================
+-- Teams
+-- Migration: 013_teams
+-- ============================================
+
+-- Teams (departments) that scope calendar views
+CREATE TABLE IF NOT EXISTS teams (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- A user's team; removing a team leaves its members unassigned
+ALTER TABLE users ADD COLUMN team_id TEXT REFERENCES teams(id) ON DELETE SET NULL;
+
+-- Index for team-scoped calendar and stats queries
+CREATE INDEX IF NOT EXISTS idx_users_team_id ON users(team_id);
@@ -0,0 +1,7 @@
+-- ============================================
+-- Soft-delete users
+-- Migration: 014_soft_delete_users
+-- ============================================
+
+-- Deleted users are kept so their vacation history stays queryable
+ALTER TABLE users ADD COLUMN deleted_at TEXT;
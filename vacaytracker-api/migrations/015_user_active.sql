@@ -0,0 +1,7 @@
+-- ============================================
+-- Active users
+-- Migration: 015_user_active
+-- ============================================
+
+-- Deactivated users keep their account but cannot log in
+ALTER TABLE users ADD COLUMN active INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,40 @@
+-- ============================================
+-- Monthly balance accrual
+-- Migration: 016_accrual
+-- ============================================
+
+-- When enabled, employees earn accrual_days_per_month each month instead of a single yearly grant
+ALTER TABLE settings ADD COLUMN accrual_enabled INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE settings ADD COLUMN accrual_days_per_month INTEGER NOT NULL DEFAULT 2;
+
+-- Last month (YYYY-MM) credited by the accrual job, so restarts never credit a month twice
+ALTER TABLE settings ADD COLUMN last_accrual_month TEXT;
+
+-- Rebuild the ledger so its reason check accepts accrual entries
+DROP TRIGGER IF EXISTS users_opening_balance;
+
+CREATE TABLE balance_ledger_new (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    delta INTEGER NOT NULL,
+    reason TEXT NOT NULL CHECK (reason IN ('opening', 'vacation', 'adjustment', 'reset', 'accrual')),
+    reference_id TEXT,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT INTO balance_ledger_new (id, user_id, delta, reason, reference_id, created_at)
+SELECT id, user_id, delta, reason, reference_id, created_at FROM balance_ledger;
+
+DROP TABLE balance_ledger;
+ALTER TABLE balance_ledger_new RENAME TO balance_ledger;
+
+CREATE INDEX IF NOT EXISTS idx_balance_ledger_user_id ON balance_ledger(user_id);
+
+CREATE TRIGGER IF NOT EXISTS users_opening_balance
+    AFTER INSERT ON users
+    FOR EACH ROW
+BEGIN
+    INSERT INTO balance_ledger (id, user_id, delta, reason)
+    VALUES (lower(hex(randomblob(16))), NEW.id, NEW.vacation_balance, 'opening');
+END;
@@ -0,0 +1,8 @@
+-- ============================================
+-- Negative balance (overdraw) policy
+-- Migration: 017_negative_balance
+-- ============================================
+
+-- When enabled, requests may take a balance down to -max_overdraw_days
+ALTER TABLE settings ADD COLUMN allow_negative_balance INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE settings ADD COLUMN max_overdraw_days INTEGER NOT NULL DEFAULT 0;
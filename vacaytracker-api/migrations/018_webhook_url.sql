@@ -0,0 +1,7 @@
+-- ============================================
+-- Request lifecycle webhook
+-- Migration: 018_webhook_url
+-- ============================================
+
+-- Receives a JSON POST when requests are submitted, approved or rejected; empty disables
+ALTER TABLE settings ADD COLUMN webhook_url TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+-- ============================================
+-- Per-user locale
+-- Migration: 019_user_locale
+-- ============================================
+
+-- Language for emails sent to the user; unknown values fall back to English
+ALTER TABLE users ADD COLUMN locale TEXT NOT NULL DEFAULT 'en';
@@ -0,0 +1,29 @@
+-- ============================================
+-- Email delivery queue
+-- Migration: 020_email_outbox
+-- ============================================
+
+-- Every queued email, kept after delivery so admins can see what was sent
+-- status is one of: pending, sent, retrying, failed
+-- next_attempt_at is when the scheduler may (re)send a pending or retrying email
+CREATE TABLE IF NOT EXISTS email_outbox (
+    id TEXT PRIMARY KEY,
+    recipient TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    html_body TEXT NOT NULL,
+    text_body TEXT NOT NULL,
+    tags TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TEXT,
+    sent_at TEXT,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Index for the scheduler's retry sweep
+CREATE INDEX IF NOT EXISTS idx_email_outbox_status_next ON email_outbox(status, next_attempt_at);
+
+-- Index for the admin delivery log (most recent first)
+CREATE INDEX IF NOT EXISTS idx_email_outbox_created_at ON email_outbox(created_at);
@@ -0,0 +1,8 @@
+-- ============================================
+-- Digest sections
+-- Migration: 021_digest_sections
+-- ============================================
+
+-- JSON object of booleans choosing which sections the newsletter digest includes
+-- Empty means the default (every section)
+ALTER TABLE settings ADD COLUMN digest_sections TEXT NOT NULL DEFAULT '';
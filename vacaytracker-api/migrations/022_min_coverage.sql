@@ -0,0 +1,8 @@
+-- ============================================
+-- Minimum staff coverage
+-- Migration: 022_min_coverage
+-- ============================================
+
+-- Employees who must remain available on every business day; approvals that would
+-- leave fewer are blocked. 0 disables the check
+ALTER TABLE settings ADD COLUMN min_coverage INTEGER NOT NULL DEFAULT 0;
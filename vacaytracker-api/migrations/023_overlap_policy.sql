@@ -0,0 +1,10 @@
+-- ============================================
+-- Overlapping request policy
+-- Migration: 023_overlap_policy
+-- ============================================
+
+-- 'block' rejects overlapping requests; 'warn' accepts them and flags them for the admin
+ALTER TABLE settings ADD COLUMN overlap_policy TEXT NOT NULL DEFAULT 'block';
+
+-- When set, requests sharing only a boundary day (one ends the day the other starts) do not overlap
+ALTER TABLE settings ADD COLUMN overlap_allow_touching INTEGER NOT NULL DEFAULT 0;
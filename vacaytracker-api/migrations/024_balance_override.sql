@@ -0,0 +1,7 @@
+-- ============================================
+-- Balance override on approval
+-- Migration: 024_balance_override
+-- ============================================
+
+-- Reason an admin gave for approving a request despite insufficient balance; NULL when no override was used
+ALTER TABLE vacation_requests ADD COLUMN balance_override_reason TEXT;
@@ -0,0 +1,8 @@
+-- ============================================
+-- Predefined rejection reasons
+-- Migration: 025_rejection_reasons
+-- ============================================
+
+-- JSON array of {code, label} pairs admins can pick from when rejecting a request
+-- Empty means no predefined reasons (free text only)
+ALTER TABLE settings ADD COLUMN rejection_reasons TEXT NOT NULL DEFAULT '';
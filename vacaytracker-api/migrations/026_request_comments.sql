@@ -0,0 +1,17 @@
+-- ============================================
+-- Request comments
+-- Migration: 026_request_comments
+-- ============================================
+
+-- Discussion thread between the requester and approvers on a vacation request
+-- Comments go away with their request
+CREATE TABLE IF NOT EXISTS request_comments (
+    id TEXT PRIMARY KEY,
+    request_id TEXT NOT NULL REFERENCES vacation_requests(id) ON DELETE CASCADE,
+    author_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Index for loading a request's thread in order
+CREATE INDEX IF NOT EXISTS idx_request_comments_request ON request_comments(request_id, created_at);
@@ -0,0 +1,19 @@
+-- ============================================
+-- Request attachments
+-- Migration: 027_request_attachments
+-- ============================================
+
+-- Files (e.g. sick notes) uploaded to a vacation request
+-- Only metadata lives here; the bytes are stored on disk under ATTACHMENT_DIR
+CREATE TABLE IF NOT EXISTS request_attachments (
+    id TEXT PRIMARY KEY,
+    request_id TEXT NOT NULL REFERENCES vacation_requests(id) ON DELETE CASCADE,
+    uploaded_by TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Index for listing a request's attachments
+CREATE INDEX IF NOT EXISTS idx_request_attachments_request ON request_attachments(request_id);
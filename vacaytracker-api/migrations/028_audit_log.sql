@@ -0,0 +1,21 @@
+-- ============================================
+-- Audit log
+-- Migration: 028_audit_log
+-- ============================================
+
+-- Record of admin mutations for compliance
+-- Entries are never updated or deleted, and outlive the users they mention
+CREATE TABLE IF NOT EXISTS audit_log (
+    id TEXT PRIMARY KEY,
+    actor_id TEXT NOT NULL,
+    action TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id TEXT NOT NULL DEFAULT '',
+    before_state TEXT,
+    after_state TEXT,
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Indexes for browsing by time and by actor
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log(created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor_id, created_at);
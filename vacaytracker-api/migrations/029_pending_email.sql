@@ -0,0 +1,8 @@
+-- ============================================
+-- Pending email change
+-- Migration: 029_pending_email
+-- ============================================
+
+-- New address a user asked to switch to, held until they confirm it from that inbox
+-- Login keeps using email until the change is confirmed
+ALTER TABLE users ADD COLUMN pending_email TEXT;
@@ -0,0 +1,7 @@
+-- ============================================
+-- Ledger entry notes
+-- Migration: 030_ledger_note
+-- ============================================
+
+-- Free-text explanation an admin gave for a balance change, e.g. a bulk grant after a crunch
+ALTER TABLE balance_ledger ADD COLUMN note TEXT;
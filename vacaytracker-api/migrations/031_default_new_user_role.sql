@@ -0,0 +1,7 @@
+-- ============================================
+-- Default role for created users
+-- Migration: 031_default_new_user_role
+-- ============================================
+
+-- Role given to users created without an explicit one; never admin
+ALTER TABLE settings ADD COLUMN default_new_user_role TEXT NOT NULL DEFAULT 'employee';
@@ -0,0 +1,9 @@
+-- ============================================
+-- Balance unit (days or hours)
+-- Migration: 032_balance_unit
+-- ============================================
+
+-- Unit vacation balances and request totals are kept in; in hours mode a business
+-- day counts as hours_per_day. Switching the unit doesn't convert existing values
+ALTER TABLE settings ADD COLUMN balance_unit TEXT NOT NULL DEFAULT 'days';
+ALTER TABLE settings ADD COLUMN hours_per_day INTEGER NOT NULL DEFAULT 8;
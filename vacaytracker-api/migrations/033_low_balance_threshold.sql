@@ -0,0 +1,7 @@
+-- ============================================
+-- Low balance notification threshold
+-- Migration: 033_low_balance_threshold
+-- ============================================
+
+-- Days at or below which an approval notifies the employee and their manager; 0 disables it
+ALTER TABLE settings ADD COLUMN low_balance_threshold INTEGER NOT NULL DEFAULT 5;
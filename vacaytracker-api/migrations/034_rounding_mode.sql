@@ -0,0 +1,8 @@
+-- ============================================
+-- Business day rounding
+-- Migration: 034_rounding_mode
+-- ============================================
+
+-- How a request's business days are rounded before they are charged:
+-- 'none' charges them exactly, 'ceil' rounds up to whole working weeks
+ALTER TABLE settings ADD COLUMN rounding_mode TEXT NOT NULL DEFAULT 'none';
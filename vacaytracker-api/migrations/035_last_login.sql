@@ -0,0 +1,7 @@
+-- ============================================
+-- Last login timestamp
+-- Migration: 035_last_login
+-- ============================================
+
+-- Set on every successful login so admins can spot dormant accounts; NULL if never logged in
+ALTER TABLE users ADD COLUMN last_login_at TEXT;
@@ -0,0 +1,10 @@
+-- ============================================
+-- Requests per leave year cap
+-- Migration: 036_max_requests_per_year
+-- ============================================
+
+-- Separate requests an employee may file per leave year; 0 means unlimited
+ALTER TABLE settings ADD COLUMN max_requests_per_year INTEGER NOT NULL DEFAULT 0;
+
+-- Whether rejected requests count toward the cap
+ALTER TABLE settings ADD COLUMN count_rejected_requests INTEGER NOT NULL DEFAULT 0;
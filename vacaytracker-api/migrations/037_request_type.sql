@@ -0,0 +1,8 @@
+-- ============================================
+-- Request types
+-- Migration: 037_request_type
+-- ============================================
+
+-- 'vacation' is paid leave deducted from the balance; 'remote' marks days worked
+-- from elsewhere, shown on the team calendar but never deducted
+ALTER TABLE vacation_requests ADD COLUMN type TEXT NOT NULL DEFAULT 'vacation';
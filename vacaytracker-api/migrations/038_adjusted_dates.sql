@@ -0,0 +1,9 @@
+-- ============================================
+-- Adjusted approval dates
+-- Migration: 038_adjusted_dates
+-- ============================================
+
+-- Set when an admin approved a different range than submitted (counter-offer);
+-- start_date/end_date then hold the approved range and these the original one
+ALTER TABLE vacation_requests ADD COLUMN original_start_date TEXT;
+ALTER TABLE vacation_requests ADD COLUMN original_end_date TEXT;
@@ -0,0 +1,8 @@
+-- ============================================
+-- Time zone for date checks
+-- Migration: 039_timezone
+-- ============================================
+
+-- IANA zone whose calendar date counts as "today" when rejecting past start dates
+-- and counting notice; stored dates stay plain ISO dates
+ALTER TABLE settings ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,8 @@
+-- ============================================
+-- Leave start reminders
+-- Migration: 040_start_reminder
+-- ============================================
+
+-- When the "your leave starts tomorrow" reminder was sent; NULL until then
+-- Set before sending so a restarted scheduler doesn't email the same request twice
+ALTER TABLE vacation_requests ADD COLUMN start_reminder_sent_at TEXT;
@@ -0,0 +1,7 @@
+-- ============================================
+-- Booking horizon
+-- Migration: 041_max_future_days
+-- ============================================
+
+-- Calendar days ahead of today a new request may start; 0 means unlimited
+ALTER TABLE settings ADD COLUMN max_future_days INTEGER NOT NULL DEFAULT 0;
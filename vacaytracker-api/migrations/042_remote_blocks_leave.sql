@@ -0,0 +1,7 @@
+-- ============================================
+-- Overlap scope across request types
+-- Migration: 042_remote_blocks_leave
+-- ============================================
+
+-- Whether remote days and paid leave on the same dates count as overlapping; 1 keeps any two requests conflicting
+ALTER TABLE settings ADD COLUMN remote_blocks_leave INTEGER NOT NULL DEFAULT 1;
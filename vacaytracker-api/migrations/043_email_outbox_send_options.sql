@@ -0,0 +1,8 @@
+-- ============================================
+-- Email delivery queue send options
+-- Migration: 043_email_outbox_send_options
+-- ============================================
+
+-- Carried through to every delivery attempt so retries keep the same Resend idempotency key and reply-to address
+ALTER TABLE email_outbox ADD COLUMN idempotency_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE email_outbox ADD COLUMN reply_to TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+-- ============================================
+-- Touching requests are consecutive, not sharing a day
+-- Migration: 044_overlap_touching_consecutive
+-- ============================================
+
+-- overlap_allow_touching now governs requests on consecutive days; a shared day always overlaps because it
+-- would be deducted twice. Consecutive requests never overlapped before, so every deployment keeps allowing them.
+-- A single settings row can't tell an explicit 0 from the old default, so upgrading resets it to 1 (see CLAUDE.md)
+UPDATE settings SET overlap_allow_touching = 1;